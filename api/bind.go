@@ -0,0 +1,44 @@
+package api
+
+import (
+	"errors"
+	"mime"
+	"net/http"
+	"strings"
+
+	"github.com/StairSupplies/go-core/jsonutils"
+	"github.com/StairSupplies/go-core/validate"
+)
+
+// DefaultBindMaxBytes is the request body limit used by Bind
+var DefaultBindMaxBytes int64 = 1 << 20
+
+// Bind decodes a JSON request body into dst and validates it with
+// validate.Struct. The returned error is an api.Error ready to be returned
+// from a HandlerFunc:
+//
+//   - 415 if the Content-Type is set and is not JSON
+//   - 413 if the body exceeds DefaultBindMaxBytes
+//   - 400 if the body is not valid JSON for dst
+//   - 422 with field errors as details if validation fails
+func Bind(r *http.Request, dst any) error {
+	if ct := r.Header.Get("Content-Type"); ct != "" {
+		mediaType, _, err := mime.ParseMediaType(ct)
+		if err != nil || (mediaType != "application/json" && !strings.HasSuffix(mediaType, "+json")) {
+			return NewError(http.StatusUnsupportedMediaType, errors.New("Content-Type must be application/json"))
+		}
+	}
+
+	if err := jsonutils.Decode(r.Body, dst, jsonutils.WithMaxBytes(DefaultBindMaxBytes)); err != nil {
+		if errors.Is(err, jsonutils.ErrBodyTooLarge) {
+			return NewError(http.StatusRequestEntityTooLarge, err)
+		}
+		return BadRequestError(err)
+	}
+
+	err := validate.Struct(dst)
+	if err == nil || errors.Is(err, validate.ErrNotStruct) {
+		return nil
+	}
+	return FromValidation(err)
+}
@@ -0,0 +1,99 @@
+package api
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/StairSupplies/go-core/validate"
+)
+
+type createOrderInput struct {
+	SKU      string `json:"sku" validate:"required"`
+	Quantity int    `json:"quantity" validate:"required,min=1"`
+}
+
+func TestBind(t *testing.T) {
+	tests := []struct {
+		name        string
+		contentType string
+		body        string
+		wantStatus  int
+	}{
+		{"valid", "application/json", `{"sku":"TREAD-36","quantity":2}`, 0},
+		{"json with charset", "application/json; charset=utf-8", `{"sku":"TREAD-36","quantity":2}`, 0},
+		{"vendor json", "application/vnd.api+json", `{"sku":"TREAD-36","quantity":2}`, 0},
+		{"no content type", "", `{"sku":"TREAD-36","quantity":2}`, 0},
+		{"form content type", "application/x-www-form-urlencoded", `sku=TREAD-36`, http.StatusUnsupportedMediaType},
+		{"malformed json", "application/json", `{"sku":`, http.StatusBadRequest},
+		{"unknown field", "application/json", `{"sku":"A","quantity":1,"price":5}`, http.StatusBadRequest},
+		{"empty body", "application/json", ``, http.StatusBadRequest},
+		{"too large", "application/json", `{"sku":"` + strings.Repeat("x", 2<<20) + `"}`, http.StatusRequestEntityTooLarge},
+		{"invalid", "application/json", `{"sku":"","quantity":0}`, http.StatusUnprocessableEntity},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodPost, "/orders", strings.NewReader(tc.body))
+			if tc.contentType != "" {
+				req.Header.Set("Content-Type", tc.contentType)
+			}
+
+			var input createOrderInput
+			err := Bind(req, &input)
+			if tc.wantStatus == 0 {
+				if err != nil {
+					t.Fatalf("Bind() error = %v", err)
+				}
+				if input.SKU != "TREAD-36" || input.Quantity != 2 {
+					t.Errorf("Unexpected input %+v", input)
+				}
+				return
+			}
+
+			var apiErr Error
+			if !errors.As(err, &apiErr) {
+				t.Fatalf("Expected api.Error, got %v", err)
+			}
+			if apiErr.StatusCode != tc.wantStatus {
+				t.Errorf("Expected status %d, got %d (%s)", tc.wantStatus, apiErr.StatusCode, apiErr.Message)
+			}
+		})
+	}
+}
+
+func TestBind_ValidationDetails(t *testing.T) {
+	req := httptest.NewRequest(http.MethodPost, "/orders", strings.NewReader(`{"sku":"","quantity":0}`))
+
+	var input createOrderInput
+	err := Bind(req, &input)
+
+	rr := httptest.NewRecorder()
+	WriteError(rr, err)
+
+	body := rr.Body.String()
+	for _, want := range []string{`"details"`, `"sku": "must be provided"`, `"quantity": "must be provided"`} {
+		if !strings.Contains(body, want) {
+			t.Errorf("Expected body to contain %s, got %s", want, body)
+		}
+	}
+
+	var apiErr Error
+	errors.As(err, &apiErr)
+	if _, ok := apiErr.Details.(validate.ValidationError); !ok {
+		t.Errorf("Expected ValidationError details, got %T", apiErr.Details)
+	}
+}
+
+func TestBind_NonStruct(t *testing.T) {
+	req := httptest.NewRequest(http.MethodPost, "/tags", strings.NewReader(`["a","b"]`))
+	var tags []string
+	if err := Bind(req, &tags); err != nil {
+		t.Fatalf("Bind() error = %v", err)
+	}
+	if len(tags) != 2 {
+		t.Errorf("Expected 2 tags, got %v", tags)
+	}
+}
@@ -22,7 +22,9 @@ Error responses use the Error struct wrapped in an Envelope:
 	{
 	  "error": {
 	    "status_code": 400,
-	    "message": "Bad request: invalid user ID"
+	    "code": "INVALID_USER_ID", // optional
+	    "message": "Bad request: invalid user ID",
+	    "details": { ... }         // optional
 	  }
 	}
 
@@ -39,6 +41,103 @@ Writing successful responses:
 	envelope := api.Envelope{"users": users, "count": len(users)}
 	api.WriteJSON(w, http.StatusOK, envelope, nil)
 
+Other success statuses have their own helpers, which keep the same envelope:
+
+	// 201 with a Location header
+	api.WriteCreated(w, "/api/users/"+user.ID, user)
+
+	// 202 for work that completes later
+	api.WriteAccepted(w, map[string]string{"job_id": job.ID})
+
+	// 204 with no body
+	api.WriteNoContent(w)
+
+WriteSuccessCtx also records the request ID set by the router and the time
+the response was written, so a response can be matched to its logs:
+
+	api.WriteSuccessCtx(r.Context(), w, users, meta)
+
+	{
+	  "status_code": 200,
+	  "data": [ ... ],
+	  "meta": { ... },
+	  "request_id": "host/abc123-000042",
+	  "timestamp": "2024-03-04T15:04:05.123456Z"
+	}
+
+# Large Responses
+
+JSON responses are indented by default, which is easy to read while
+developing. SetCompactJSON drops the indentation for every JSON writer,
+saving bytes and CPU in production:
+
+	api.SetCompactJSON(os.Getenv("APP_ENV") == "production")
+
+WriteJSON still builds the whole body in memory. For multi-megabyte exports,
+WriteJSONStream encodes a slice one element at a time and flushes the body
+to the client in DefaultStreamChunkSize chunks, without a Content-Length,
+so a compression middleware can gzip it as it goes:
+
+	func exportProducts(w http.ResponseWriter, r *http.Request) {
+	    products, err := store.AllProducts(r.Context())
+	    if err != nil {
+	        api.WriteError(w, err)
+	        return
+	    }
+	    if err := api.WriteJSONStream(w, http.StatusOK, products, nil); err != nil {
+	        logger.WithContext(r.Context()).Error("Export interrupted", zap.Error(err))
+	    }
+	}
+
+Errors encoding the first element are returned before anything is written;
+later errors truncate the response, so log them rather than writing an error.
+Handlers that are not behind a compression middleware can call
+WriteJSONStreamGzip instead, which gzips the body when the request's
+Accept-Encoding allows it and flushes the compressed output with each chunk.
+
+# File Downloads
+
+WriteAttachment sends a file for the browser to save, and WriteFile one to
+show inline, such as a PDF preview. Both set a Content-Disposition with the
+filename, encoded for non-ASCII names, and guess the Content-Type from its
+extension:
+
+	func downloadInvoice(w http.ResponseWriter, r *http.Request) error {
+	    f, err := os.Open(invoicePath(chi.URLParam(r, "id")))
+	    if err != nil {
+	        return api.NotFoundError(err)
+	    }
+	    defer f.Close()
+	    return api.WriteAttachment(w, r, "invoice.pdf", f, "")
+	}
+
+Content that can seek, such as an *os.File, is served with
+http.ServeContent, so Range requests resume interrupted downloads and
+conditional requests get 304 responses. Other readers, such as a generated
+export written through an io.Pipe, are streamed without buffering or range
+support.
+
+# Typed Responses
+
+WriteData is a typed form of WriteSuccess, and ParseSuccess reads the same
+envelope on the consuming side, decoding data straight into a Go type:
+
+	// Server
+	api.WriteData(w, orders, page.Meta(total))
+
+	// Client
+	orders, meta, err := api.ParseSuccess[[]Order](resp.Body)
+	if err != nil {
+		return err // an api.Error if the body was an error envelope
+	}
+	var page api.PaginatedMeta
+	if err := meta.Decode(&page); err != nil {
+		return err
+	}
+
+A body that is neither a success nor an error envelope fails with
+ErrNotSuccessResponse.
+
 # Error Handling
 
 The package provides error constructors for common HTTP error codes:
@@ -58,6 +157,24 @@ The package provides error constructors for common HTTP error codes:
 	    return api.UnauthorizedError(errors.New("invalid credentials"))
 	}
 
+Errors can carry a stable, machine-readable code and structured details so
+clients do not have to parse messages:
+
+	return api.NewCodedError(http.StatusConflict, "INVENTORY_OUT_OF_STOCK",
+	    errors.New("not enough stock"), map[string]int{"available": 2})
+
+	{
+	  "error": {
+	    "status_code": 409,
+	    "code": "INVENTORY_OUT_OF_STOCK",
+	    "message": "not enough stock",
+	    "details": {"available": 2}
+	  }
+	}
+
+Both members are omitted when empty. In problem+json responses they become
+the code and details extension members.
+
 # Handler Functions
 
 The package defines the HandlerFunc type that returns an error instead of directly
@@ -76,6 +193,198 @@ handling it, allowing for cleaner controller logic:
 
 The WrapHandler function automatically logs errors and writes appropriate error responses.
 
+# Custom Error Handling
+
+WrapHandler passes errors to HandleError, which uses DefaultErrorHandler
+unless another ErrorHandler is configured. Replace it to change how errors
+are mapped to responses, for example to hide internal messages:
+
+	api.SetErrorHandler(func(w http.ResponseWriter, r *http.Request, err error) {
+	    var apiErr api.Error
+	    if !errors.As(err, &apiErr) || apiErr.StatusCode >= 500 {
+	        logger.WithContext(r.Context()).Error("internal error", zap.Error(err))
+	        api.WriteError(w, api.ServerError(errors.New("internal server error")))
+	        return
+	    }
+	    api.DefaultErrorHandler(w, r, err)
+	})
+
+A handler stored with ContextWithErrorHandler takes precedence over the
+package-wide one; the router's Options.ErrorHandler uses this to scope a
+handler to one router.
+
+# Pagination
+
+ParsePagination reads the page, per_page and sort query parameters, and
+WritePaginated adds standard metadata to the success response:
+
+	var orderPages = api.PaginationConfig{
+	    DefaultPerPage: 25,
+	    MaxPerPage:     100,
+	    SortFields:     []string{"created_at", "total"},
+	}
+
+	func listOrders(w http.ResponseWriter, r *http.Request) error {
+	    page, err := orderPages.Parse(r) // ?page=2&per_page=25&sort=-created_at
+	    if err != nil {
+	        return err // 400 Bad Request
+	    }
+	    orders, total, err := store.ListOrders(r.Context(), page.Offset(), page.Limit(), page.Sort)
+	    if err != nil {
+	        return api.ServerError(err)
+	    }
+	    return api.WritePaginated(w, orders, page, total)
+	}
+
+The meta object has the form:
+
+	{"total": 95, "page": 2, "per_page": 25, "total_pages": 4}
+
+# Problem Details
+
+Errors can also be written as RFC 7807 application/problem+json. Return a
+Problem from a handler to use it for a single error, or switch the whole
+package at startup:
+
+	api.SetErrorFormat(api.FormatProblem)
+
+In problem mode api.Error values keep their status and message, which becomes
+the problem detail, and WrapHandler fills instance with the request path:
+
+	{
+	  "detail": "user not found",
+	  "instance": "/api/users/42",
+	  "status": 404,
+	  "title": "Not Found",
+	  "type": "about:blank"
+	}
+
+Extension members are added with With:
+
+	return api.NewProblem(http.StatusConflict, "order already shipped").
+	    With("order_id", order.ID)
+
+# Request Binding
+
+Bind decodes a JSON request body and validates it with the validate package
+in one step. Its error can be returned straight from a HandlerFunc:
+
+	type CreateOrder struct {
+		SKU      string `json:"sku" validate:"required"`
+		Quantity int    `json:"quantity" validate:"required,min=1"`
+	}
+
+	var input CreateOrder
+	if err := api.Bind(r, &input); err != nil {
+		return err
+	}
+
+Validation failures produce a 422 with the field errors as details:
+
+	{
+	  "error": {
+	    "status_code": 422,
+	    "message": "validation failed",
+	    "details": {"quantity": "must be provided"}
+	  }
+	}
+
+Non-JSON content types are rejected with 415, bodies larger than
+DefaultBindMaxBytes with 413, and malformed JSON with 400.
+
+WriteError gives a validate.ValidationError the same 422 response, so errors
+from a validate.Validator can be returned as they are. FromValidation makes
+the conversion explicit:
+
+	v := validate.New()
+	v.Check(input.Quantity <= stock, "quantity", "exceeds available stock")
+	if !v.Valid() {
+		return v.AsValidationError()
+	}
+
+# Content Negotiation
+
+Write renders data in the media type preferred by the request's Accept
+header, so one handler can serve both an API client and a spreadsheet
+export:
+
+	func exportOrders(w http.ResponseWriter, r *http.Request) error {
+	    orders, err := store.ListOrders(r.Context())
+	    if err != nil {
+	        return api.ServerError(err)
+	    }
+	    return api.Write(w, r, http.StatusOK, orders)
+	}
+
+JSON is used when there is no Accept header or it accepts anything. XML is
+written with encoding/xml, and CSV is available for slices of structs, with
+a header row named by each field's csv tag, then its json tag, then its name,
+or for a [][]string. Other media types can be added with RegisterEncoder:
+
+	api.RegisterEncoder("text/tab-separated-values", encodeTSV)
+
+When no registered type is acceptable, Write returns a 406 error instead of
+writing a response.
+
+# Idempotency Keys
+
+Idempotency makes POST and PATCH endpoints safe for clients to retry, which
+payment and order creation need:
+
+	r.With(api.Idempotency(api.IdempotencyOptions{
+	    Required: true,
+	    Scope:    func(r *http.Request) string { return userID(r) },
+	})).Post("/payments", createPayment)
+
+The first response for each Idempotency-Key header is stored for the TTL,
+24 hours by default, and replayed to later requests with the same key with
+an Idempotent-Replayed: true header. Reusing a key for a different request
+gets 422, and a duplicate that arrives while the first is still running gets
+409. 5xx responses are not stored, so failed requests can be retried. A key
+is locked for at most LockTTL, a minute by default, while its first request
+runs, so a request lost to a crash does not block the key for the full TTL.
+Bodies larger than MaxBodyBytes, DefaultBindMaxBytes by default, are
+rejected with 413.
+
+The default store keeps responses in memory. Implement IdempotencyStore on a
+shared store such as Redis when running more than one instance.
+
+# OpenAPI Validation
+
+ValidateOpenAPI checks requests, and optionally responses, against an
+OpenAPI 3 document in JSON, catching contract drift before consumers do:
+
+	spec, err := api.LoadOpenAPIFile("openapi.json")
+	if err != nil {
+	    return err
+	}
+
+	r.Use(api.ValidateOpenAPI(api.OpenAPIOptions{
+	    Spec:              spec,
+	    ValidateResponses: true,
+	    ReportOnly:        cfg.Env == config.EnvProduction,
+	}))
+
+Path, query, header and cookie parameters and JSON bodies are checked
+against their schemas, following local $ref pointers. A request that does
+not match gets a 400 listing each problem with a JSON pointer to it:
+
+	{
+	  "error": {
+	    "status_code": 400,
+	    "message": "request does not match the API specification",
+	    "details": [
+	      {"in": "body", "pointer": "/items/0/quantity", "message": "must be at least 1"},
+	      {"in": "query", "name": "limit", "message": "must be of type integer"}
+	    ]
+	  }
+	}
+
+A response with an undocumented status or a body that does not match its
+schema is replaced by a 500 with the same details. In production, set
+ReportOnly to log mismatches at warn level and leave traffic untouched.
+Routes the document does not describe are not checked.
+
 # Integration with Router
 
 This package works seamlessly with the router package, which provides additional
@@ -0,0 +1,62 @@
+package api
+
+import (
+	"context"
+	"net/http"
+	"sync/atomic"
+
+	"github.com/StairSupplies/go-core/logger"
+	"go.uber.org/zap"
+)
+
+// ErrorHandler reports an error returned by a HandlerFunc
+type ErrorHandler func(w http.ResponseWriter, r *http.Request, err error)
+
+// errorHandler holds the package-wide ErrorHandler
+var errorHandler atomic.Value
+
+// errorHandlerKey is the context key for request-scoped ErrorHandlers
+type errorHandlerKey struct{}
+
+// SetErrorHandler sets the package-wide ErrorHandler used by WrapHandler.
+// Passing nil restores DefaultErrorHandler.
+func SetErrorHandler(h ErrorHandler) {
+	errorHandler.Store(h)
+}
+
+// ContextWithErrorHandler returns a context whose requests are reported with
+// h instead of the package-wide handler. The router uses this to apply
+// Options.ErrorHandler.
+func ContextWithErrorHandler(ctx context.Context, h ErrorHandler) context.Context {
+	return context.WithValue(ctx, errorHandlerKey{}, h)
+}
+
+// HandleError reports err using the ErrorHandler from the request context,
+// then the package-wide handler, then DefaultErrorHandler
+func HandleError(w http.ResponseWriter, r *http.Request, err error) {
+	if h, ok := r.Context().Value(errorHandlerKey{}).(ErrorHandler); ok && h != nil {
+		h(w, r, err)
+		return
+	}
+	if h, _ := errorHandler.Load().(ErrorHandler); h != nil {
+		h(w, r, err)
+		return
+	}
+	DefaultErrorHandler(w, r, err)
+}
+
+// DefaultErrorHandler logs err with the request logger and writes it with
+// WriteError, filling the problem instance with the request path
+func DefaultErrorHandler(w http.ResponseWriter, r *http.Request, err error) {
+	// Get logger from context or use default
+	log := logger.WithContext(r.Context())
+
+	// Log the error
+	log.Error("HTTP API Error",
+		zap.String("path", r.URL.Path),
+		zap.String("err", err.Error()),
+	)
+
+	// Write the error response
+	writeError(w, err, r.URL.Path)
+}
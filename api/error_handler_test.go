@@ -0,0 +1,59 @@
+package api
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestHandleError(t *testing.T) {
+	failing := WrapHandler(func(w http.ResponseWriter, r *http.Request) error {
+		return ServerError(errors.New("db: connection refused"))
+	})
+
+	t.Run("default handler", func(t *testing.T) {
+		rr := httptest.NewRecorder()
+		failing(rr, httptest.NewRequest(http.MethodGet, "/orders", nil))
+		if rr.Code != http.StatusInternalServerError {
+			t.Errorf("Expected status 500, got %d", rr.Code)
+		}
+	})
+
+	t.Run("package handler", func(t *testing.T) {
+		SetErrorHandler(func(w http.ResponseWriter, r *http.Request, err error) {
+			w.WriteHeader(http.StatusTeapot)
+		})
+		defer SetErrorHandler(nil)
+
+		rr := httptest.NewRecorder()
+		failing(rr, httptest.NewRequest(http.MethodGet, "/orders", nil))
+		if rr.Code != http.StatusTeapot {
+			t.Errorf("Expected status 418, got %d", rr.Code)
+		}
+	})
+
+	t.Run("context handler takes precedence", func(t *testing.T) {
+		SetErrorHandler(func(w http.ResponseWriter, r *http.Request, err error) {
+			w.WriteHeader(http.StatusTeapot)
+		})
+		defer SetErrorHandler(nil)
+
+		var got error
+		req := httptest.NewRequest(http.MethodGet, "/orders", nil)
+		req = req.WithContext(ContextWithErrorHandler(context.Background(), func(w http.ResponseWriter, r *http.Request, err error) {
+			got = err
+			WriteError(w, ServerError(errors.New("internal error")))
+		}))
+
+		rr := httptest.NewRecorder()
+		failing(rr, req)
+		if got == nil || got.Error() != "API Error 500: db: connection refused" {
+			t.Errorf("Expected handler to receive the original error, got %v", got)
+		}
+		if rr.Code != http.StatusInternalServerError {
+			t.Errorf("Expected status 500, got %d", rr.Code)
+		}
+	})
+}
@@ -6,8 +6,10 @@ import (
 	"fmt"
 	"net/http"
 	"net/http/httptest"
+	"strings"
 
 	"github.com/StairSupplies/go-core/api"
+	"github.com/StairSupplies/go-core/ctxutils"
 )
 
 func ExampleEnvelope() {
@@ -54,6 +56,27 @@ func ExampleNewError() {
 	// Output: Status: 400, Message: something went wrong
 }
 
+func ExampleNewCodedError() {
+	err := api.NewCodedError(http.StatusConflict, "INVENTORY_OUT_OF_STOCK",
+		errors.New("not enough stock"), map[string]int{"available": 2})
+
+	w := httptest.NewRecorder()
+	api.WriteError(w, err)
+
+	fmt.Print(w.Body.String())
+	// Output:
+	// {
+	//   "error": {
+	//     "status_code": 409,
+	//     "code": "INVENTORY_OUT_OF_STOCK",
+	//     "message": "not enough stock",
+	//     "details": {
+	//       "available": 2
+	//     }
+	//   }
+	// }
+}
+
 func ExampleBadRequestError() {
 	// Helper function for common error types
 	err := errors.New("invalid input")
@@ -110,6 +133,26 @@ func ExampleWriteJSON() {
 	// }
 }
 
+func ExampleWriteJSONStream() {
+	type product struct {
+		SKU   string `json:"sku"`
+		Price int    `json:"price"`
+	}
+	products := []product{{"STAIR-01", 12900}, {"RAIL-07", 4500}}
+
+	w := httptest.NewRecorder()
+	if err := api.WriteJSONStream(w, http.StatusOK, products, nil); err != nil {
+		fmt.Println("Error:", err)
+		return
+	}
+
+	fmt.Println("Content-Type:", w.Header().Get("Content-Type"))
+	fmt.Print("Body: ", w.Body.String())
+	// Output:
+	// Content-Type: application/json
+	// Body: [{"sku":"STAIR-01","price":12900},{"sku":"RAIL-07","price":4500}]
+}
+
 func ExampleWriteSuccess() {
 	// Create a test response recorder
 	w := httptest.NewRecorder()
@@ -200,4 +243,259 @@ func ExampleWrapHandler() {
 	// // 1. Call your handler function
 	// // 2. If an error is returned, log it and write an error response
 	// // 3. Otherwise, your handler handles the response writing
-}
\ No newline at end of file
+}
+
+func ExampleWriteProblem() {
+	w := httptest.NewRecorder()
+
+	problem := api.NewProblem(http.StatusConflict, "order 42 was already shipped").
+		With("order_id", 42)
+	api.WriteProblem(w, problem)
+
+	fmt.Println("Status Code:", w.Code)
+	fmt.Println("Content-Type:", w.Header().Get("Content-Type"))
+	fmt.Println("Body:", w.Body.String())
+	// Output:
+	// Status Code: 409
+	// Content-Type: application/problem+json
+	// Body: {
+	//   "detail": "order 42 was already shipped",
+	//   "order_id": 42,
+	//   "status": 409,
+	//   "title": "Conflict",
+	//   "type": "about:blank"
+	// }
+}
+
+func ExampleWritePaginated() {
+	r := httptest.NewRequest(http.MethodGet, "/orders?page=2&per_page=2", nil)
+	w := httptest.NewRecorder()
+
+	page, err := api.ParsePagination(r)
+	if err != nil {
+		api.WriteError(w, err)
+		return
+	}
+
+	// In a real handler the items and total come from the database
+	api.WritePaginated(w, []string{"order-3", "order-4"}, page, 5)
+
+	var resp struct {
+		Meta api.PaginatedMeta `json:"meta"`
+	}
+	json.Unmarshal(w.Body.Bytes(), &resp)
+	fmt.Printf("%+v\n", resp.Meta)
+
+	// Output: {Total:5 Page:2 PerPage:2 TotalPages:3}
+}
+
+func ExampleBind() {
+	type createOrder struct {
+		SKU      string `json:"sku" validate:"required"`
+		Quantity int    `json:"quantity" validate:"required,min=1"`
+	}
+
+	r := httptest.NewRequest(http.MethodPost, "/orders", strings.NewReader(`{"sku":"TREAD-36"}`))
+	r.Header.Set("Content-Type", "application/json")
+
+	var input createOrder
+	err := api.Bind(r, &input)
+
+	var apiErr api.Error
+	if errors.As(err, &apiErr) {
+		fmt.Println(apiErr.StatusCode, apiErr.Message, apiErr.Details)
+	}
+
+	// Output: 422 validation failed validation failed: quantity must be provided
+}
+
+func ExampleWriteSuccessCtx() {
+	r := httptest.NewRequest(http.MethodGet, "/orders/42", nil)
+	ctx := ctxutils.WithRequestID(r.Context(), "req-42")
+	w := httptest.NewRecorder()
+
+	api.WriteSuccessCtx(ctx, w, map[string]string{"id": "42"})
+
+	var resp api.SuccessResponse
+	json.Unmarshal(w.Body.Bytes(), &resp)
+	fmt.Println(resp.RequestID, resp.Timestamp != nil)
+
+	// Output: req-42 true
+}
+
+func ExampleParseSuccess() {
+	type Order struct {
+		ID    string `json:"id"`
+		Total int    `json:"total"`
+	}
+
+	// Server side
+	w := httptest.NewRecorder()
+	api.WriteData(w, []Order{{ID: "A-1", Total: 120}}, api.PaginatedMeta{Total: 1, Page: 1, PerPage: 25, TotalPages: 1})
+
+	// Client side
+	orders, meta, err := api.ParseSuccess[[]Order](w.Body)
+	if err != nil {
+		fmt.Println("Error:", err)
+		return
+	}
+
+	var page api.PaginatedMeta
+	meta.Decode(&page)
+	fmt.Println(orders[0].ID, orders[0].Total, page.Total)
+
+	// Output: A-1 120 1
+}
+
+func ExampleWrite() {
+	type Shipment struct {
+		OrderID string `json:"order_id"`
+		Carrier string `json:"carrier"`
+		Boxes   int    `json:"boxes"`
+		Notes   string `csv:"-"`
+	}
+	shipments := []Shipment{
+		{OrderID: "A-1", Carrier: "UPS", Boxes: 3},
+		{OrderID: "A-2", Carrier: "FedEx", Boxes: 1},
+	}
+
+	r := httptest.NewRequest(http.MethodGet, "/shipments/export", nil)
+	r.Header.Set("Accept", "text/csv")
+	w := httptest.NewRecorder()
+
+	if err := api.Write(w, r, http.StatusOK, shipments); err != nil {
+		fmt.Println("Error:", err)
+		return
+	}
+
+	fmt.Println(w.Header().Get("Content-Type"))
+	fmt.Print(w.Body.String())
+
+	// Output:
+	// text/csv; charset=utf-8
+	// order_id,carrier,boxes
+	// A-1,UPS,3
+	// A-2,FedEx,1
+}
+
+func ExampleWriteCreated() {
+	createOrder := api.WrapHandler(func(w http.ResponseWriter, r *http.Request) error {
+		order := map[string]any{"id": "A-7", "total": 120}
+		return api.WriteCreated(w, "/orders/A-7", order)
+	})
+
+	w := httptest.NewRecorder()
+	createOrder(w, httptest.NewRequest(http.MethodPost, "/orders", nil))
+
+	fmt.Println(w.Code, w.Header().Get("Location"))
+	fmt.Print(w.Body.String())
+
+	// Output:
+	// 201 /orders/A-7
+	// {
+	//   "status_code": 201,
+	//   "data": {
+	//     "id": "A-7",
+	//     "total": 120
+	//   }
+	// }
+}
+
+func ExampleIdempotency() {
+	charges := 0
+	createPayment := func(w http.ResponseWriter, r *http.Request) {
+		charges++
+		api.WriteCreated(w, "/payments/1", map[string]int{"charge": charges})
+	}
+	h := api.Idempotency(api.IdempotencyOptions{})(http.HandlerFunc(createPayment))
+
+	// A client retries the same request after a timeout
+	for i := 0; i < 2; i++ {
+		req := httptest.NewRequest(http.MethodPost, "/payments", strings.NewReader(`{"amount":100}`))
+		req.Header.Set(api.IdempotencyKeyHeader, "7f9c2ba4")
+		rec := httptest.NewRecorder()
+		h.ServeHTTP(rec, req)
+		fmt.Printf("%d replayed=%t\n", rec.Code, rec.Header().Get(api.IdempotentReplayedHeader) == "true")
+	}
+	fmt.Println("charges:", charges)
+
+	// Output:
+	// 201 replayed=false
+	// 201 replayed=true
+	// charges: 1
+}
+
+func ExampleWriteAttachment() {
+	r := httptest.NewRequest(http.MethodGet, "/orders/export", nil)
+	w := httptest.NewRecorder()
+
+	csv := strings.NewReader("id,total\n1001,129.00\n")
+	if err := api.WriteAttachment(w, r, "orders.csv", csv, "text/csv"); err != nil {
+		fmt.Println("Error:", err)
+		return
+	}
+
+	fmt.Println("Content-Disposition:", w.Header().Get("Content-Disposition"))
+	fmt.Println("Content-Length:", w.Header().Get("Content-Length"))
+	fmt.Println("Accept-Ranges:", w.Header().Get("Accept-Ranges"))
+
+	// Output:
+	// Content-Disposition: attachment; filename=orders.csv
+	// Content-Length: 21
+	// Accept-Ranges: bytes
+}
+
+func ExampleValidateOpenAPI() {
+	spec, err := api.LoadOpenAPI([]byte(`{
+	  "openapi": "3.0.3",
+	  "paths": {
+	    "/orders": {
+	      "post": {
+	        "requestBody": {
+	          "required": true,
+	          "content": {"application/json": {"schema": {
+	            "type": "object",
+	            "required": ["sku", "quantity"],
+	            "properties": {
+	              "sku": {"type": "string"},
+	              "quantity": {"type": "integer", "minimum": 1}
+	            }
+	          }}}
+	        },
+	        "responses": {"201": {"description": "created"}}
+	      }
+	    }
+	  }
+	}`))
+	if err != nil {
+		panic(err)
+	}
+
+	handler := api.ValidateOpenAPI(api.OpenAPIOptions{Spec: spec})(
+		http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusCreated)
+		}))
+
+	req := httptest.NewRequest(http.MethodPost, "/orders", strings.NewReader(`{"quantity": 0}`))
+	req.Header.Set("Content-Type", "application/json")
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, req)
+
+	var body struct {
+		Error struct {
+			Message string             `json:"message"`
+			Details []api.OpenAPIError `json:"details"`
+		} `json:"error"`
+	}
+	json.Unmarshal(rec.Body.Bytes(), &body)
+
+	fmt.Println(rec.Code, body.Error.Message)
+	for _, d := range body.Error.Details {
+		fmt.Println(d.In, d.Pointer, d.Message)
+	}
+
+	// Output:
+	// 400 request does not match the API specification
+	// body /sku is required
+	// body /quantity must be at least 1
+}
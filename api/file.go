@@ -0,0 +1,84 @@
+package api
+
+import (
+	"io"
+	"io/fs"
+	"mime"
+	"net/http"
+	"path/filepath"
+	"time"
+)
+
+// WriteFile writes content as a file shown in the browser, such as a PDF
+// preview, with an inline Content-Disposition naming it filename. An empty
+// contentType is guessed from the filename's extension, then sniffed from
+// the content.
+//
+// If content is an io.ReadSeeker, such as an *os.File or bytes.Reader, it is
+// served with http.ServeContent: Content-Length is set, and Range,
+// If-Range and conditional requests are supported, so interrupted downloads
+// can resume. Set an ETag header first to let clients validate a resumed
+// download. Other readers are streamed as they are without range support.
+// Content is never buffered in memory, and an error is only returned if
+// copying a streamed reader fails part way.
+func WriteFile(w http.ResponseWriter, r *http.Request, filename string, content io.Reader, contentType string) error {
+	return writeFile(w, r, "inline", filename, content, contentType)
+}
+
+// WriteAttachment is like WriteFile, but with an attachment
+// Content-Disposition so browsers save the file as filename instead of
+// showing it. Use it for exports and other downloads.
+func WriteAttachment(w http.ResponseWriter, r *http.Request, filename string, content io.Reader, contentType string) error {
+	return writeFile(w, r, "attachment", filename, content, contentType)
+}
+
+// writeFile writes content with the given Content-Disposition type
+func writeFile(w http.ResponseWriter, r *http.Request, disposition, filename string, content io.Reader, contentType string) error {
+	if contentType == "" {
+		contentType = mime.TypeByExtension(filepath.Ext(filename))
+	}
+	if contentType != "" {
+		w.Header().Set("Content-Type", contentType)
+	}
+	w.Header().Set("Content-Disposition", contentDisposition(disposition, filename))
+
+	if rs, ok := content.(io.ReadSeeker); ok {
+		http.ServeContent(w, r, filename, modTime(content), rs)
+		return nil
+	}
+
+	if contentType == "" {
+		w.Header().Set("Content-Type", "application/octet-stream")
+	}
+	w.Header().Set("Accept-Ranges", "none")
+	w.WriteHeader(http.StatusOK)
+	if r.Method == http.MethodHead {
+		return nil
+	}
+	_, err := io.Copy(w, content)
+	return err
+}
+
+// contentDisposition formats a Content-Disposition header, encoding
+// non-ASCII filenames as RFC 2231 requires
+func contentDisposition(disposition, filename string) string {
+	if filename == "" {
+		return disposition
+	}
+	filename = filepath.Base(filename)
+	if v := mime.FormatMediaType(disposition, map[string]string{"filename": filename}); v != "" {
+		return v
+	}
+	return disposition
+}
+
+// modTime returns the modification time of a file, or the zero time for
+// other readers, which omits the Last-Modified header
+func modTime(content io.Reader) time.Time {
+	if f, ok := content.(interface{ Stat() (fs.FileInfo, error) }); ok {
+		if info, err := f.Stat(); err == nil {
+			return info.ModTime()
+		}
+	}
+	return time.Time{}
+}
@@ -0,0 +1,147 @@
+package api
+
+import (
+	"bytes"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"testing"
+)
+
+func TestContentDisposition(t *testing.T) {
+	tests := []struct {
+		disposition string
+		filename    string
+		want        string
+	}{
+		{"attachment", "orders.csv", "attachment; filename=orders.csv"},
+		{"inline", "quote 42.pdf", `inline; filename="quote 42.pdf"`},
+		{"attachment", "../../etc/passwd", "attachment; filename=passwd"},
+		{"attachment", "café.csv", "attachment; filename*=utf-8''caf%C3%A9.csv"},
+		{"attachment", "", "attachment"},
+	}
+
+	for _, tt := range tests {
+		if got := contentDisposition(tt.disposition, tt.filename); got != tt.want {
+			t.Errorf("contentDisposition(%q, %q): expected %q, got %q", tt.disposition, tt.filename, tt.want, got)
+		}
+	}
+}
+
+func TestWriteAttachment_ReadSeeker(t *testing.T) {
+	content := "id,sku\n1,STAIR-01\n2,RAIL-07\n"
+	r := httptest.NewRequest(http.MethodGet, "/export", nil)
+	w := httptest.NewRecorder()
+
+	if err := WriteAttachment(w, r, "orders.csv", strings.NewReader(content), ""); err != nil {
+		t.Fatalf("WriteAttachment() error = %v", err)
+	}
+
+	if w.Code != http.StatusOK {
+		t.Errorf("Expected status 200, got %d", w.Code)
+	}
+	if got := w.Header().Get("Content-Disposition"); got != "attachment; filename=orders.csv" {
+		t.Errorf("Expected attachment disposition, got %q", got)
+	}
+	if got := w.Header().Get("Content-Type"); !strings.HasPrefix(got, "text/csv") {
+		t.Errorf("Expected Content-Type from the extension, got %q", got)
+	}
+	if got := w.Header().Get("Content-Length"); got != strconv.Itoa(len(content)) {
+		t.Errorf("Expected Content-Length %d, got %q", len(content), got)
+	}
+	if got := w.Header().Get("Accept-Ranges"); got != "bytes" {
+		t.Errorf("Expected Accept-Ranges bytes, got %q", got)
+	}
+	if w.Body.String() != content {
+		t.Errorf("Expected body %q, got %q", content, w.Body.String())
+	}
+}
+
+func TestWriteFile_Range(t *testing.T) {
+	r := httptest.NewRequest(http.MethodGet, "/file", nil)
+	r.Header.Set("Range", "bytes=4-7")
+	w := httptest.NewRecorder()
+
+	if err := WriteFile(w, r, "data.bin", bytes.NewReader([]byte("0123456789")), "application/octet-stream"); err != nil {
+		t.Fatalf("WriteFile() error = %v", err)
+	}
+
+	if w.Code != http.StatusPartialContent {
+		t.Errorf("Expected status 206, got %d", w.Code)
+	}
+	if got := w.Header().Get("Content-Range"); got != "bytes 4-7/10" {
+		t.Errorf("Expected Content-Range bytes 4-7/10, got %q", got)
+	}
+	if w.Body.String() != "4567" {
+		t.Errorf("Expected body 4567, got %q", w.Body.String())
+	}
+	if got := w.Header().Get("Content-Disposition"); got != "inline; filename=data.bin" {
+		t.Errorf("Expected inline disposition, got %q", got)
+	}
+}
+
+func TestWriteFile_File(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "report.txt")
+	os.WriteFile(path, []byte("report"), 0644)
+	f, err := os.Open(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+
+	r := httptest.NewRequest(http.MethodGet, "/report", nil)
+	w := httptest.NewRecorder()
+	WriteFile(w, r, "report.txt", f, "")
+
+	if w.Header().Get("Last-Modified") == "" {
+		t.Error("Expected Last-Modified from the file")
+	}
+
+	// A conditional request with the same time is not modified
+	r.Header.Set("If-Modified-Since", w.Header().Get("Last-Modified"))
+	f.Seek(0, io.SeekStart)
+	w = httptest.NewRecorder()
+	WriteFile(w, r, "report.txt", f, "")
+	if w.Code != http.StatusNotModified {
+		t.Errorf("Expected status 304, got %d", w.Code)
+	}
+}
+
+func TestWriteFile_Stream(t *testing.T) {
+	// io.MultiReader hides the Seek method, as a pipe or response body would
+	content := io.MultiReader(strings.NewReader("streamed "), strings.NewReader("content"))
+	r := httptest.NewRequest(http.MethodGet, "/stream", nil)
+	r.Header.Set("Range", "bytes=0-3")
+	w := httptest.NewRecorder()
+
+	if err := WriteAttachment(w, r, "export", content, ""); err != nil {
+		t.Fatalf("WriteAttachment() error = %v", err)
+	}
+
+	if w.Code != http.StatusOK {
+		t.Errorf("Expected status 200 for a stream, got %d", w.Code)
+	}
+	if got := w.Header().Get("Accept-Ranges"); got != "none" {
+		t.Errorf("Expected Accept-Ranges none, got %q", got)
+	}
+	if got := w.Header().Get("Content-Type"); got != "application/octet-stream" {
+		t.Errorf("Expected application/octet-stream, got %q", got)
+	}
+	if w.Body.String() != "streamed content" {
+		t.Errorf("Expected the full body, got %q", w.Body.String())
+	}
+}
+
+func TestWriteFile_StreamHead(t *testing.T) {
+	r := httptest.NewRequest(http.MethodHead, "/stream", nil)
+	w := httptest.NewRecorder()
+
+	WriteFile(w, r, "notes.txt", io.MultiReader(strings.NewReader("notes")), "")
+	if w.Body.Len() != 0 {
+		t.Errorf("Expected no body for HEAD, got %q", w.Body.String())
+	}
+}
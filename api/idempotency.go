@@ -0,0 +1,348 @@
+package api
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"sync"
+	"time"
+)
+
+// IdempotencyKeyHeader is the request header holding the idempotency key
+const IdempotencyKeyHeader = "Idempotency-Key"
+
+// IdempotentReplayedHeader is set to "true" on responses replayed from the store
+const IdempotentReplayedHeader = "Idempotent-Replayed"
+
+// DefaultIdempotencyTTL is how long responses are kept when no TTL is given
+const DefaultIdempotencyTTL = 24 * time.Hour
+
+// DefaultIdempotencyLockTTL is how long a key stays locked when no LockTTL
+// is given
+const DefaultIdempotencyLockTTL = time.Minute
+
+// ErrIdempotencyKeyInUse is returned by IdempotencyStore.Lock when another
+// request holds the key
+var ErrIdempotencyKeyInUse = errors.New("api: idempotency key is in use")
+
+// IdempotencyOptions configures the Idempotency middleware
+type IdempotencyOptions struct {
+	// Store keeps responses between requests; nil means a store created with
+	// NewMemoryIdempotencyStore, which only works within one process
+	Store IdempotencyStore
+	// TTL is how long a response is replayed; zero means DefaultIdempotencyTTL
+	TTL time.Duration
+	// LockTTL is how long a key stays locked while its first request runs,
+	// so the key can be retried if that request never finishes; zero means
+	// DefaultIdempotencyLockTTL
+	LockTTL time.Duration
+	// Methods lists the methods the middleware applies to; empty means POST
+	// and PATCH
+	Methods []string
+	// Required rejects requests without an Idempotency-Key with 400
+	Required bool
+	// Scope, if set, returns a prefix for the stored key, such as the
+	// authenticated user, so clients cannot collide with each other's keys
+	Scope func(r *http.Request) string
+	// MaxBodyBytes limits the request body read to fingerprint the request;
+	// zero means DefaultBindMaxBytes. Larger bodies are rejected with 413.
+	MaxBodyBytes int64
+}
+
+// StoredResponse is a response recorded for an idempotency key
+type StoredResponse struct {
+	// RequestHash identifies the request the response belongs to, so a key
+	// reused for a different request can be rejected
+	RequestHash string
+	StatusCode  int
+	Header      http.Header
+	Body        []byte
+}
+
+// IdempotencyStore keeps the responses for idempotency keys. Implement it on
+// top of Redis or a database to share keys between instances.
+type IdempotencyStore interface {
+	// Get returns the response stored for key, or nil if there is none
+	Get(ctx context.Context, key string) (*StoredResponse, error)
+	// Lock reserves key for a request in progress, for at most ttl. It
+	// returns ErrIdempotencyKeyInUse if key is locked or has a response.
+	Lock(ctx context.Context, key string, ttl time.Duration) error
+	// Save stores resp for key for ttl and releases the lock
+	Save(ctx context.Context, key string, resp StoredResponse, ttl time.Duration) error
+	// Unlock releases the lock on key without storing a response
+	Unlock(ctx context.Context, key string) error
+}
+
+// Idempotency is a middleware that makes POST and PATCH requests safe to
+// retry. The response to the first request with a given Idempotency-Key
+// header is stored, and later requests with the same key get it replayed,
+// with the Idempotent-Replayed header set, without running the handler:
+//
+//	r.With(api.Idempotency(api.IdempotencyOptions{})).Post("/payments", createPayment)
+//
+// A key reused for a request with a different method, path or body is
+// rejected with 422, and a key whose first request is still running gets
+// 409. Responses with a 5xx status are not stored, so the request can be
+// retried. Requests without the header pass through unless Required is set.
+func Idempotency(opts IdempotencyOptions) func(next http.Handler) http.Handler {
+	if opts.Store == nil {
+		opts.Store = NewMemoryIdempotencyStore()
+	}
+	if opts.TTL <= 0 {
+		opts.TTL = DefaultIdempotencyTTL
+	}
+	if opts.LockTTL <= 0 {
+		opts.LockTTL = DefaultIdempotencyLockTTL
+	}
+	if opts.MaxBodyBytes <= 0 {
+		opts.MaxBodyBytes = DefaultBindMaxBytes
+	}
+	if len(opts.Methods) == 0 {
+		opts.Methods = []string{http.MethodPost, http.MethodPatch}
+	}
+	methods := make(map[string]bool, len(opts.Methods))
+	for _, m := range opts.Methods {
+		methods[m] = true
+	}
+
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if !methods[r.Method] {
+				next.ServeHTTP(w, r)
+				return
+			}
+			idemKey := r.Header.Get(IdempotencyKeyHeader)
+			if idemKey == "" {
+				if opts.Required {
+					WriteError(w, BadRequestError(fmt.Errorf("%s header is required", IdempotencyKeyHeader)))
+					return
+				}
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			key := idemKey
+			if opts.Scope != nil {
+				key = opts.Scope(r) + ":" + idemKey
+			}
+
+			body, err := io.ReadAll(http.MaxBytesReader(w, r.Body, opts.MaxBodyBytes))
+			if err != nil {
+				var tooLarge *http.MaxBytesError
+				if errors.As(err, &tooLarge) {
+					WriteError(w, NewError(http.StatusRequestEntityTooLarge,
+						fmt.Errorf("request body exceeds %d bytes", tooLarge.Limit)))
+					return
+				}
+				WriteError(w, BadRequestError(fmt.Errorf("failed to read request body: %w", err)))
+				return
+			}
+			r.Body = io.NopCloser(bytes.NewReader(body))
+			hash := requestHash(r, body)
+
+			ctx := r.Context()
+			stored, err := opts.Store.Get(ctx, key)
+			if err != nil {
+				WriteError(w, ServerError(fmt.Errorf("idempotency store failed: %w", err)))
+				return
+			}
+			if stored != nil {
+				replay(w, *stored, hash)
+				return
+			}
+
+			if err := opts.Store.Lock(ctx, key, opts.LockTTL); err != nil {
+				if errors.Is(err, ErrIdempotencyKeyInUse) {
+					// The first request may have finished since Get
+					if stored, _ := opts.Store.Get(ctx, key); stored != nil {
+						replay(w, *stored, hash)
+						return
+					}
+					w.Header().Set("Retry-After", "1")
+					WriteError(w, NewError(http.StatusConflict, errors.New("a request with this Idempotency-Key is in progress")))
+					return
+				}
+				WriteError(w, ServerError(fmt.Errorf("idempotency store failed: %w", err)))
+				return
+			}
+
+			rec := &idempotencyRecorder{ResponseWriter: w, status: http.StatusOK}
+			saved := false
+			defer func() {
+				if !saved {
+					opts.Store.Unlock(context.WithoutCancel(ctx), key)
+				}
+			}()
+
+			next.ServeHTTP(rec, r)
+
+			if rec.status >= http.StatusInternalServerError {
+				return
+			}
+			resp := StoredResponse{
+				RequestHash: hash,
+				StatusCode:  rec.status,
+				Header:      rec.header,
+				Body:        rec.body.Bytes(),
+			}
+			if resp.Header == nil {
+				resp.Header = w.Header().Clone()
+			}
+			saved = opts.Store.Save(context.WithoutCancel(ctx), key, resp, opts.TTL) == nil
+		})
+	}
+}
+
+// requestHash fingerprints the method, path and body of r
+func requestHash(r *http.Request, body []byte) string {
+	h := sha256.New()
+	io.WriteString(h, r.Method+" "+r.URL.Path+"\n")
+	h.Write(body)
+	return hex.EncodeToString(h.Sum(nil))
+}
+
+// replay writes a stored response, or 422 if it belongs to another request
+func replay(w http.ResponseWriter, stored StoredResponse, hash string) {
+	if stored.RequestHash != hash {
+		WriteError(w, UnprocessableEntityError(
+			fmt.Errorf("%s was already used for a different request", IdempotencyKeyHeader)))
+		return
+	}
+
+	for k, v := range stored.Header {
+		w.Header()[k] = append([]string(nil), v...)
+	}
+	w.Header().Set(IdempotentReplayedHeader, "true")
+	w.WriteHeader(stored.StatusCode)
+	w.Write(stored.Body)
+}
+
+// idempotencyRecorder passes a response through while keeping a copy
+type idempotencyRecorder struct {
+	http.ResponseWriter
+	status      int
+	header      http.Header
+	body        bytes.Buffer
+	wroteHeader bool
+}
+
+func (rec *idempotencyRecorder) WriteHeader(status int) {
+	if rec.wroteHeader {
+		return
+	}
+	rec.wroteHeader = true
+	rec.status = status
+	rec.header = rec.ResponseWriter.Header().Clone()
+	rec.ResponseWriter.WriteHeader(status)
+}
+
+func (rec *idempotencyRecorder) Write(p []byte) (int, error) {
+	if !rec.wroteHeader {
+		rec.WriteHeader(http.StatusOK)
+	}
+	rec.body.Write(p)
+	return rec.ResponseWriter.Write(p)
+}
+
+// Unwrap lets http.ResponseController reach the underlying writer
+func (rec *idempotencyRecorder) Unwrap() http.ResponseWriter {
+	return rec.ResponseWriter
+}
+
+// MemoryIdempotencyStore is an IdempotencyStore that keeps responses in
+// memory. Expired keys are discarded periodically.
+type MemoryIdempotencyStore struct {
+	mu        sync.Mutex
+	entries   map[string]*idempotencyEntry
+	lastSweep time.Time
+	now       func() time.Time
+}
+
+// idempotencyEntry is a locked key or a stored response
+type idempotencyEntry struct {
+	resp    *StoredResponse
+	expires time.Time
+}
+
+// idempotencySweepInterval is how often MemoryIdempotencyStore discards expired keys
+const idempotencySweepInterval = time.Minute
+
+// NewMemoryIdempotencyStore creates an empty in-memory store
+func NewMemoryIdempotencyStore() *MemoryIdempotencyStore {
+	return &MemoryIdempotencyStore{
+		entries: make(map[string]*idempotencyEntry),
+		now:     time.Now,
+	}
+}
+
+// Get implements IdempotencyStore
+func (s *MemoryIdempotencyStore) Get(_ context.Context, key string) (*StoredResponse, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	e := s.entry(key)
+	if e == nil || e.resp == nil {
+		return nil, nil
+	}
+	resp := *e.resp
+	return &resp, nil
+}
+
+// Lock implements IdempotencyStore
+func (s *MemoryIdempotencyStore) Lock(_ context.Context, key string, ttl time.Duration) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.entry(key) != nil {
+		return ErrIdempotencyKeyInUse
+	}
+	s.entries[key] = &idempotencyEntry{expires: s.now().Add(ttl)}
+	return nil
+}
+
+// Save implements IdempotencyStore
+func (s *MemoryIdempotencyStore) Save(_ context.Context, key string, resp StoredResponse, ttl time.Duration) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	resp.Header = resp.Header.Clone()
+	resp.Body = append([]byte(nil), resp.Body...)
+	s.entries[key] = &idempotencyEntry{resp: &resp, expires: s.now().Add(ttl)}
+	return nil
+}
+
+// Unlock implements IdempotencyStore
+func (s *MemoryIdempotencyStore) Unlock(_ context.Context, key string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if e, ok := s.entries[key]; ok && e.resp == nil {
+		delete(s.entries, key)
+	}
+	return nil
+}
+
+// entry returns the unexpired entry for key, sweeping expired entries
+// periodically; s.mu must be held
+func (s *MemoryIdempotencyStore) entry(key string) *idempotencyEntry {
+	now := s.now()
+	if now.Sub(s.lastSweep) >= idempotencySweepInterval {
+		for k, e := range s.entries {
+			if !now.Before(e.expires) {
+				delete(s.entries, k)
+			}
+		}
+		s.lastSweep = now
+	}
+
+	e, ok := s.entries[key]
+	if !ok || !now.Before(e.expires) {
+		return nil
+	}
+	return e
+}
@@ -0,0 +1,255 @@
+package api
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// idempotentRequest sends a request through h with the given key and body
+func idempotentRequest(h http.Handler, method, key, body string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(method, "/payments", strings.NewReader(body))
+	if key != "" {
+		req.Header.Set(IdempotencyKeyHeader, key)
+	}
+	rec := httptest.NewRecorder()
+	h.ServeHTTP(rec, req)
+	return rec
+}
+
+func TestIdempotency(t *testing.T) {
+	var calls atomic.Int32
+	h := Idempotency(IdempotencyOptions{})(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		n := calls.Add(1)
+		w.Header().Set("X-Call", string(rune('0'+n)))
+		WriteCreated(w, "/payments/1", map[string]int32{"call": n})
+	}))
+
+	first := idempotentRequest(h, http.MethodPost, "k1", `{"amount":10}`)
+	if first.Code != http.StatusCreated || first.Header().Get(IdempotentReplayedHeader) != "" {
+		t.Fatalf("Expected first request to run, got %d %v", first.Code, first.Header())
+	}
+
+	second := idempotentRequest(h, http.MethodPost, "k1", `{"amount":10}`)
+	if calls.Load() != 1 {
+		t.Errorf("Expected handler to run once, ran %d times", calls.Load())
+	}
+	if second.Code != http.StatusCreated || second.Body.String() != first.Body.String() {
+		t.Errorf("Expected replayed response, got %d %s", second.Code, second.Body.String())
+	}
+	if second.Header().Get(IdempotentReplayedHeader) != "true" {
+		t.Error("Expected Idempotent-Replayed header")
+	}
+	if second.Header().Get("X-Call") != "1" || second.Header().Get("Location") != "/payments/1" {
+		t.Errorf("Expected stored headers to be replayed, got %v", second.Header())
+	}
+
+	t.Run("different body", func(t *testing.T) {
+		rec := idempotentRequest(h, http.MethodPost, "k1", `{"amount":99}`)
+		if rec.Code != http.StatusUnprocessableEntity {
+			t.Errorf("Expected 422, got %d", rec.Code)
+		}
+	})
+
+	t.Run("other key", func(t *testing.T) {
+		idempotentRequest(h, http.MethodPost, "k2", `{"amount":10}`)
+		if calls.Load() != 2 {
+			t.Errorf("Expected a new key to run the handler, ran %d times", calls.Load())
+		}
+	})
+
+	t.Run("no key", func(t *testing.T) {
+		before := calls.Load()
+		idempotentRequest(h, http.MethodPost, "", "")
+		idempotentRequest(h, http.MethodPost, "", "")
+		if calls.Load() != before+2 {
+			t.Error("Expected requests without a key to pass through")
+		}
+	})
+
+	t.Run("other method", func(t *testing.T) {
+		before := calls.Load()
+		idempotentRequest(h, http.MethodPut, "k1", `{"amount":10}`)
+		if calls.Load() != before+1 {
+			t.Error("Expected PUT to pass through")
+		}
+	})
+}
+
+func TestIdempotency_Required(t *testing.T) {
+	h := Idempotency(IdempotencyOptions{Required: true})(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+
+	if rec := idempotentRequest(h, http.MethodPost, "", ""); rec.Code != http.StatusBadRequest {
+		t.Errorf("Expected 400 without a key, got %d", rec.Code)
+	}
+	if rec := idempotentRequest(h, http.MethodPost, "k", ""); rec.Code != http.StatusOK {
+		t.Errorf("Expected 200 with a key, got %d", rec.Code)
+	}
+}
+
+func TestIdempotency_ServerErrorNotStored(t *testing.T) {
+	var calls atomic.Int32
+	h := Idempotency(IdempotencyOptions{})(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if calls.Add(1) == 1 {
+			WriteError(w, errors.New("database unavailable"))
+			return
+		}
+		w.WriteHeader(http.StatusCreated)
+	}))
+
+	if rec := idempotentRequest(h, http.MethodPost, "k", "x"); rec.Code != http.StatusInternalServerError {
+		t.Fatalf("Expected 500, got %d", rec.Code)
+	}
+	if rec := idempotentRequest(h, http.MethodPost, "k", "x"); rec.Code != http.StatusCreated {
+		t.Errorf("Expected retry after a 5xx to run the handler, got %d", rec.Code)
+	}
+}
+
+func TestIdempotency_InProgress(t *testing.T) {
+	started := make(chan struct{})
+	release := make(chan struct{})
+	h := Idempotency(IdempotencyOptions{})(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		close(started)
+		<-release
+		w.WriteHeader(http.StatusCreated)
+	}))
+
+	done := make(chan *httptest.ResponseRecorder)
+	go func() { done <- idempotentRequest(h, http.MethodPost, "k", "x") }()
+	<-started
+
+	rec := idempotentRequest(h, http.MethodPost, "k", "x")
+	if rec.Code != http.StatusConflict || rec.Header().Get("Retry-After") == "" {
+		t.Errorf("Expected 409 with Retry-After while in progress, got %d", rec.Code)
+	}
+
+	close(release)
+	if first := <-done; first.Code != http.StatusCreated {
+		t.Errorf("Expected first request to finish with 201, got %d", first.Code)
+	}
+}
+
+func TestIdempotency_Scope(t *testing.T) {
+	var calls atomic.Int32
+	h := Idempotency(IdempotencyOptions{
+		Scope: func(r *http.Request) string { return r.Header.Get("X-User") },
+	})(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls.Add(1)
+	}))
+
+	for _, user := range []string{"ann", "bob", "ann"} {
+		req := httptest.NewRequest(http.MethodPost, "/payments", nil)
+		req.Header.Set(IdempotencyKeyHeader, "same")
+		req.Header.Set("X-User", user)
+		h.ServeHTTP(httptest.NewRecorder(), req)
+	}
+	if calls.Load() != 2 {
+		t.Errorf("Expected one call per user, got %d", calls.Load())
+	}
+}
+
+func TestIdempotency_MaxBodyBytes(t *testing.T) {
+	var calls atomic.Int32
+	h := Idempotency(IdempotencyOptions{MaxBodyBytes: 8})(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls.Add(1)
+		w.WriteHeader(http.StatusCreated)
+	}))
+
+	if rec := idempotentRequest(h, http.MethodPost, "k", `{"amount":10}`); rec.Code != http.StatusRequestEntityTooLarge {
+		t.Errorf("Expected 413 for a large body, got %d", rec.Code)
+	}
+	if rec := idempotentRequest(h, http.MethodPost, "k", `{}`); rec.Code != http.StatusCreated {
+		t.Errorf("Expected a small body to pass, got %d", rec.Code)
+	}
+	if calls.Load() != 1 {
+		t.Errorf("Expected the handler to run once, ran %d times", calls.Load())
+	}
+}
+
+// ttlStore records the TTLs passed to an IdempotencyStore
+type ttlStore struct {
+	IdempotencyStore
+	lockTTL, saveTTL time.Duration
+}
+
+func (s *ttlStore) Lock(ctx context.Context, key string, ttl time.Duration) error {
+	s.lockTTL = ttl
+	return s.IdempotencyStore.Lock(ctx, key, ttl)
+}
+
+func (s *ttlStore) Save(ctx context.Context, key string, resp StoredResponse, ttl time.Duration) error {
+	s.saveTTL = ttl
+	return s.IdempotencyStore.Save(ctx, key, resp, ttl)
+}
+
+func TestIdempotency_LockTTL(t *testing.T) {
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusCreated)
+	})
+
+	tests := []struct {
+		name     string
+		opts     IdempotencyOptions
+		wantLock time.Duration
+		wantSave time.Duration
+	}{
+		{"defaults", IdempotencyOptions{}, DefaultIdempotencyLockTTL, DefaultIdempotencyTTL},
+		{"custom", IdempotencyOptions{TTL: time.Hour, LockTTL: 5 * time.Second}, 5 * time.Second, time.Hour},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			store := &ttlStore{IdempotencyStore: NewMemoryIdempotencyStore()}
+			tt.opts.Store = store
+			idempotentRequest(Idempotency(tt.opts)(handler), http.MethodPost, "k", "x")
+
+			if store.lockTTL != tt.wantLock || store.saveTTL != tt.wantSave {
+				t.Errorf("Expected lock TTL %v and save TTL %v, got %v and %v",
+					tt.wantLock, tt.wantSave, store.lockTTL, store.saveTTL)
+			}
+		})
+	}
+}
+
+func TestMemoryIdempotencyStore(t *testing.T) {
+	ctx := context.Background()
+	now := time.Unix(0, 0)
+	s := NewMemoryIdempotencyStore()
+	s.now = func() time.Time { return now }
+
+	if err := s.Lock(ctx, "k", time.Minute); err != nil {
+		t.Fatalf("Lock() error = %v", err)
+	}
+	if err := s.Lock(ctx, "k", time.Minute); !errors.Is(err, ErrIdempotencyKeyInUse) {
+		t.Errorf("Expected ErrIdempotencyKeyInUse, got %v", err)
+	}
+	if resp, _ := s.Get(ctx, "k"); resp != nil {
+		t.Error("Expected no response for a locked key")
+	}
+
+	s.Unlock(ctx, "k")
+	if err := s.Lock(ctx, "k", time.Minute); err != nil {
+		t.Errorf("Expected Lock after Unlock to succeed, got %v", err)
+	}
+
+	s.Save(ctx, "k", StoredResponse{StatusCode: 201, Body: []byte("ok")}, time.Hour)
+	s.Unlock(ctx, "k")
+	if resp, _ := s.Get(ctx, "k"); resp == nil || resp.StatusCode != 201 {
+		t.Errorf("Expected saved response to survive Unlock, got %+v", resp)
+	}
+
+	now = now.Add(2 * time.Hour)
+	if resp, _ := s.Get(ctx, "k"); resp != nil {
+		t.Error("Expected response to expire")
+	}
+	if len(s.entries) != 0 {
+		t.Errorf("Expected expired entries to be swept, got %d", len(s.entries))
+	}
+}
@@ -0,0 +1,355 @@
+package api
+
+import (
+	"bytes"
+	"encoding"
+	"encoding/csv"
+	"encoding/json"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io"
+	"mime"
+	"net/http"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Media types with built-in encoders
+const (
+	MediaTypeJSON = "application/json"
+	MediaTypeXML  = "application/xml"
+	MediaTypeCSV  = "text/csv"
+)
+
+// ErrCSVUnsupported is returned when CSV is negotiated for data that is not
+// a slice of structs or a [][]string
+var ErrCSVUnsupported = errors.New("api: CSV data must be a slice of structs or [][]string")
+
+// Encoder writes data to w in a particular media type
+type Encoder func(w io.Writer, data any) error
+
+// encoderEntry is a registered media type and its encoder
+type encoderEntry struct {
+	mediaType string
+	encode    Encoder
+}
+
+var (
+	encodersMu sync.RWMutex
+	// encoders are kept in registration order, which breaks ties between
+	// equally preferred media types
+	encoders = []encoderEntry{
+		{MediaTypeJSON, encodeJSON},
+		{MediaTypeXML, encodeXML},
+		{"text/xml", encodeXML},
+		{MediaTypeCSV, encodeCSV},
+	}
+)
+
+// RegisterEncoder registers enc for mediaType, such as
+// "application/vnd.ms-excel", replacing any existing encoder for it.
+// Passing a nil encoder removes the media type.
+func RegisterEncoder(mediaType string, enc Encoder) {
+	mediaType = strings.ToLower(mediaType)
+
+	encodersMu.Lock()
+	defer encodersMu.Unlock()
+
+	for i, e := range encoders {
+		if e.mediaType != mediaType {
+			continue
+		}
+		if enc == nil {
+			encoders = append(encoders[:i:i], encoders[i+1:]...)
+		} else {
+			encoders[i].encode = enc
+		}
+		return
+	}
+	if enc != nil {
+		encoders = append(encoders, encoderEntry{mediaType, enc})
+	}
+}
+
+// Write writes data with the given status in the media type the request's
+// Accept header prefers: JSON, XML, CSV or any type added with
+// RegisterEncoder. JSON is used when the header is missing or accepts
+// anything. Unlike WriteSuccess, data is written as it is, without an
+// envelope.
+//
+// If no registered type is acceptable, Write returns a 406 api.Error without
+// writing anything, so it can be returned from a HandlerFunc. Encoding errors
+// are also returned before anything is written.
+func Write(w http.ResponseWriter, r *http.Request, status int, data any) error {
+	mediaType, enc, ok := negotiate(r.Header.Get("Accept"))
+	if !ok {
+		return NewError(http.StatusNotAcceptable,
+			fmt.Errorf("none of the requested media types are supported: %s", strings.Join(MediaTypes(), ", ")))
+	}
+
+	var buf bytes.Buffer
+	if err := enc(&buf, data); err != nil {
+		return err
+	}
+
+	contentType := mediaType
+	if strings.HasPrefix(mediaType, "text/") {
+		contentType += "; charset=utf-8"
+	}
+	w.Header().Set("Content-Type", contentType)
+	w.Header().Add("Vary", "Accept")
+	w.WriteHeader(status)
+	w.Write(buf.Bytes())
+
+	return nil
+}
+
+// MediaTypes returns the media types Write can produce, in registration order
+func MediaTypes() []string {
+	encodersMu.RLock()
+	defer encodersMu.RUnlock()
+
+	types := make([]string, len(encoders))
+	for i, e := range encoders {
+		types[i] = e.mediaType
+	}
+	return types
+}
+
+// acceptRange is one media range from an Accept header
+type acceptRange struct {
+	mediaType string
+	q         float64
+}
+
+// negotiate picks the registered encoder best matching accept
+func negotiate(accept string) (string, Encoder, bool) {
+	encodersMu.RLock()
+	defer encodersMu.RUnlock()
+
+	if strings.TrimSpace(accept) == "" {
+		return findEncoder(MediaTypeJSON)
+	}
+
+	ranges := parseAccept(accept)
+	for _, ar := range ranges {
+		if ar.q <= 0 {
+			break
+		}
+		switch {
+		case ar.mediaType == "*/*":
+			if mt, enc, ok := findEncoder(MediaTypeJSON); ok {
+				return mt, enc, true
+			}
+			if len(encoders) > 0 {
+				return encoders[0].mediaType, encoders[0].encode, true
+			}
+		case strings.HasSuffix(ar.mediaType, "/*"):
+			prefix := strings.TrimSuffix(ar.mediaType, "*")
+			for _, e := range encoders {
+				if strings.HasPrefix(e.mediaType, prefix) {
+					return e.mediaType, e.encode, true
+				}
+			}
+		default:
+			if mt, enc, ok := findEncoder(ar.mediaType); ok {
+				return mt, enc, true
+			}
+		}
+	}
+	return "", nil, false
+}
+
+// findEncoder returns the encoder registered for mediaType; encodersMu must be held
+func findEncoder(mediaType string) (string, Encoder, bool) {
+	for _, e := range encoders {
+		if e.mediaType == mediaType {
+			return e.mediaType, e.encode, true
+		}
+	}
+	return "", nil, false
+}
+
+// parseAccept parses an Accept header into media ranges ordered by
+// preference. Ranges with equal quality keep their header order, except that
+// more specific ranges come before wildcards.
+func parseAccept(accept string) []acceptRange {
+	var ranges []acceptRange
+	for _, part := range strings.Split(accept, ",") {
+		mediaType, params, err := mime.ParseMediaType(strings.TrimSpace(part))
+		if err != nil {
+			continue
+		}
+		q := 1.0
+		if v, ok := params["q"]; ok {
+			if parsed, err := strconv.ParseFloat(v, 64); err == nil {
+				q = parsed
+			}
+		}
+		ranges = append(ranges, acceptRange{mediaType: mediaType, q: q})
+	}
+
+	sort.SliceStable(ranges, func(i, j int) bool {
+		if ranges[i].q != ranges[j].q {
+			return ranges[i].q > ranges[j].q
+		}
+		return wildcards(ranges[i].mediaType) < wildcards(ranges[j].mediaType)
+	})
+	return ranges
+}
+
+// wildcards counts the wildcard parts of a media range
+func wildcards(mediaType string) int {
+	return strings.Count(mediaType, "*")
+}
+
+// encodeJSON writes data as JSON, like WriteJSON
+func encodeJSON(w io.Writer, data any) error {
+	js, err := marshalJSON(data)
+	if err != nil {
+		return err
+	}
+	_, err = w.Write(append(js, '\n'))
+	return err
+}
+
+// encodeXML writes data as indented XML with an XML declaration
+func encodeXML(w io.Writer, data any) error {
+	x, err := xml.MarshalIndent(data, "", "  ")
+	if err != nil {
+		return err
+	}
+	if _, err := io.WriteString(w, xml.Header); err != nil {
+		return err
+	}
+	_, err = w.Write(append(x, '\n'))
+	return err
+}
+
+// encodeCSV writes a [][]string as it is, or a slice of structs as a header
+// row followed by one row per element. Columns are named by the csv tag, then
+// the json tag, then the field name; tag a field `csv:"-"` to leave it out.
+func encodeCSV(w io.Writer, data any) error {
+	cw := csv.NewWriter(w)
+	if records, ok := data.([][]string); ok {
+		return cw.WriteAll(records)
+	}
+
+	rv := reflect.ValueOf(data)
+	for rv.Kind() == reflect.Pointer && !rv.IsNil() {
+		rv = rv.Elem()
+	}
+	if rv.Kind() != reflect.Slice && rv.Kind() != reflect.Array {
+		return ErrCSVUnsupported
+	}
+	et := rv.Type().Elem()
+	for et.Kind() == reflect.Pointer {
+		et = et.Elem()
+	}
+	if et.Kind() != reflect.Struct {
+		return ErrCSVUnsupported
+	}
+
+	columns := csvColumns(et)
+	header := make([]string, len(columns))
+	for i, c := range columns {
+		header[i] = c.name
+	}
+	if err := cw.Write(header); err != nil {
+		return err
+	}
+
+	row := make([]string, len(columns))
+	for i := 0; i < rv.Len(); i++ {
+		ev := rv.Index(i)
+		for ev.Kind() == reflect.Pointer && !ev.IsNil() {
+			ev = ev.Elem()
+		}
+		for j, c := range columns {
+			row[j] = ""
+			if ev.Kind() == reflect.Struct {
+				if fv, err := ev.FieldByIndexErr(c.index); err == nil {
+					row[j] = csvValue(fv)
+				}
+			}
+		}
+		if err := cw.Write(row); err != nil {
+			return err
+		}
+	}
+
+	cw.Flush()
+	return cw.Error()
+}
+
+// csvColumn is an exported struct field written as a CSV column
+type csvColumn struct {
+	name  string
+	index []int
+}
+
+// csvColumns returns the columns for struct type t, in field order
+func csvColumns(t reflect.Type) []csvColumn {
+	var columns []csvColumn
+	for _, sf := range reflect.VisibleFields(t) {
+		if !sf.IsExported() || sf.Anonymous {
+			continue
+		}
+		name, _, _ := strings.Cut(sf.Tag.Get("csv"), ",")
+		if name == "" {
+			name, _, _ = strings.Cut(sf.Tag.Get("json"), ",")
+		}
+		if name == "-" {
+			continue
+		}
+		if name == "" {
+			name = sf.Name
+		}
+		columns = append(columns, csvColumn{name: name, index: sf.Index})
+	}
+	return columns
+}
+
+// csvValue formats a field value for a CSV cell. Nil pointers are empty,
+// times use RFC 3339, and text marshalers and Stringers use their text form.
+func csvValue(v reflect.Value) string {
+	for v.Kind() == reflect.Pointer || v.Kind() == reflect.Interface {
+		if v.IsNil() {
+			return ""
+		}
+		v = v.Elem()
+	}
+
+	switch x := v.Interface().(type) {
+	case time.Time:
+		if x.IsZero() {
+			return ""
+		}
+		return x.Format(time.RFC3339)
+	case encoding.TextMarshaler:
+		b, err := x.MarshalText()
+		if err != nil {
+			return ""
+		}
+		return string(b)
+	case fmt.Stringer:
+		return x.String()
+	case []byte:
+		return string(x)
+	}
+
+	switch v.Kind() {
+	case reflect.Slice, reflect.Array, reflect.Map, reflect.Struct:
+		js, err := json.Marshal(v.Interface())
+		if err != nil {
+			return ""
+		}
+		return string(js)
+	}
+	return fmt.Sprint(v.Interface())
+}
@@ -0,0 +1,176 @@
+package api
+
+import (
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+type exportRow struct {
+	SKU      string     `json:"sku"`
+	Name     string     `csv:"product_name" json:"name"`
+	Quantity int        `json:"quantity"`
+	Shipped  *time.Time `json:"shipped_at"`
+	Internal string     `csv:"-"`
+}
+
+type xmlCount struct {
+	Count int
+}
+
+func TestWrite(t *testing.T) {
+	shipped := time.Date(2024, 3, 4, 15, 4, 5, 0, time.UTC)
+	rows := []exportRow{
+		{SKU: "STR-1", Name: "Oak tread, 36\"", Quantity: 4, Shipped: &shipped, Internal: "x"},
+		{SKU: "STR-2", Name: "Riser", Quantity: 0},
+	}
+
+	tests := []struct {
+		name        string
+		accept      string
+		data        any
+		contentType string
+		body        string
+	}{
+		{
+			name:        "no accept header defaults to JSON",
+			data:        map[string]int{"count": 2},
+			contentType: "application/json",
+			body:        "{\n  \"count\": 2\n}\n",
+		},
+		{
+			name:        "wildcard uses JSON",
+			accept:      "text/html;q=0.9, */*;q=0.8",
+			data:        map[string]int{"count": 2},
+			contentType: "application/json",
+			body:        "{\n  \"count\": 2\n}\n",
+		},
+		{
+			name:        "CSV from slice of structs",
+			accept:      "text/csv",
+			data:        rows,
+			contentType: "text/csv; charset=utf-8",
+			body: "sku,product_name,quantity,shipped_at\n" +
+				"STR-1,\"Oak tread, 36\"\"\",4,2024-03-04T15:04:05Z\n" +
+				"STR-2,Riser,0,\n",
+		},
+		{
+			name:        "CSV from records",
+			accept:      "application/json;q=0.1, text/csv",
+			data:        [][]string{{"a", "b"}, {"1", "2"}},
+			contentType: "text/csv; charset=utf-8",
+			body:        "a,b\n1,2\n",
+		},
+		{
+			name:        "quality values are respected",
+			accept:      "application/json;q=0.5, application/xml",
+			data:        xmlCount{Count: 2},
+			contentType: "application/xml",
+			body:        "<?xml version=\"1.0\" encoding=\"UTF-8\"?>\n<xmlCount>\n  <Count>2</Count>\n</xmlCount>\n",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := httptest.NewRequest(http.MethodGet, "/export", nil)
+			if tt.accept != "" {
+				r.Header.Set("Accept", tt.accept)
+			}
+			w := httptest.NewRecorder()
+
+			if err := Write(w, r, http.StatusOK, tt.data); err != nil {
+				t.Fatalf("Expected no error, got %v", err)
+			}
+			if got := w.Header().Get("Content-Type"); got != tt.contentType {
+				t.Errorf("Expected Content-Type %q, got %q", tt.contentType, got)
+			}
+			if got := w.Header().Get("Vary"); got != "Accept" {
+				t.Errorf("Expected Vary Accept, got %q", got)
+			}
+			if got := w.Body.String(); got != tt.body {
+				t.Errorf("Expected body %q, got %q", tt.body, got)
+			}
+		})
+	}
+}
+
+func TestWrite_XMLStruct(t *testing.T) {
+	// The struct is defined inline so the XML element name is predictable
+	type order struct {
+		ID    int    `xml:"id,attr"`
+		Total string `xml:"total"`
+	}
+	r := httptest.NewRequest(http.MethodGet, "/", nil)
+	r.Header.Set("Accept", "application/xml")
+	w := httptest.NewRecorder()
+
+	if err := Write(w, r, http.StatusCreated, order{ID: 7, Total: "12.50"}); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if w.Code != http.StatusCreated {
+		t.Errorf("Expected status %d, got %d", http.StatusCreated, w.Code)
+	}
+	if !strings.Contains(w.Body.String(), `<order id="7">`) {
+		t.Errorf("Expected order element, got %q", w.Body.String())
+	}
+}
+
+func TestWrite_NotAcceptable(t *testing.T) {
+	r := httptest.NewRequest(http.MethodGet, "/", nil)
+	r.Header.Set("Accept", "image/png, application/json;q=0")
+	w := httptest.NewRecorder()
+
+	err := Write(w, r, http.StatusOK, map[string]int{"count": 1})
+	var apiErr Error
+	if !errors.As(err, &apiErr) || apiErr.StatusCode != http.StatusNotAcceptable {
+		t.Fatalf("Expected 406 api.Error, got %v", err)
+	}
+	if w.Body.Len() != 0 {
+		t.Errorf("Expected nothing written, got %q", w.Body.String())
+	}
+}
+
+func TestWrite_CSVUnsupported(t *testing.T) {
+	r := httptest.NewRequest(http.MethodGet, "/", nil)
+	r.Header.Set("Accept", "text/csv")
+	w := httptest.NewRecorder()
+
+	err := Write(w, r, http.StatusOK, map[string]int{"count": 1})
+	if !errors.Is(err, ErrCSVUnsupported) {
+		t.Fatalf("Expected ErrCSVUnsupported, got %v", err)
+	}
+	if w.Body.Len() != 0 {
+		t.Errorf("Expected nothing written, got %q", w.Body.String())
+	}
+}
+
+func TestRegisterEncoder(t *testing.T) {
+	const mediaType = "text/tab-separated-values"
+	RegisterEncoder(mediaType, func(w io.Writer, data any) error {
+		_, err := io.WriteString(w, strings.Join(data.([]string), "\t"))
+		return err
+	})
+	t.Cleanup(func() { RegisterEncoder(mediaType, nil) })
+
+	r := httptest.NewRequest(http.MethodGet, "/", nil)
+	r.Header.Set("Accept", "text/tab-separated-values, text/csv;q=0.5")
+	w := httptest.NewRecorder()
+
+	if err := Write(w, r, http.StatusOK, []string{"a", "b"}); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if got := w.Body.String(); got != "a\tb" {
+		t.Errorf("Expected custom encoding, got %q", got)
+	}
+
+	RegisterEncoder(mediaType, nil)
+	for _, mt := range MediaTypes() {
+		if mt == mediaType {
+			t.Errorf("Expected %s to be removed", mediaType)
+		}
+	}
+}
@@ -0,0 +1,580 @@
+package api
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"mime"
+	"net/http"
+	"net/url"
+	"os"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/StairSupplies/go-core/logger"
+	"go.uber.org/zap"
+)
+
+// ErrInvalidOpenAPI is returned by LoadOpenAPI for documents it cannot use
+var ErrInvalidOpenAPI = errors.New("api: invalid OpenAPI document")
+
+// Messages of the errors written by ValidateOpenAPI
+const (
+	OpenAPIRequestMessage  = "request does not match the API specification"
+	OpenAPIResponseMessage = "response does not match the API specification"
+)
+
+// openAPIMethods are the operations a path item can hold
+var openAPIMethods = []string{"get", "put", "post", "delete", "options", "head", "patch", "trace"}
+
+// OpenAPISpec is a parsed OpenAPI 3 document, ready to validate requests and
+// responses. It is safe for concurrent use.
+type OpenAPISpec struct {
+	root       map[string]any
+	operations []*openAPIOperation
+
+	// patterns caches compiled schema patterns
+	patterns sync.Map
+}
+
+// openAPIOperation is one method of one documented path
+type openAPIOperation struct {
+	method string
+	// segments are the path template split on "/", such as
+	// ["orders", "{id}"]
+	segments []string
+	// templated is the number of parameter segments, so literal paths win
+	templated  int
+	parameters []map[string]any
+	body       map[string]any
+	responses  map[string]any
+}
+
+// OpenAPIError describes one way a request or response differs from the
+// OpenAPI document
+type OpenAPIError struct {
+	// In is where the problem is: "path", "query", "header" or "cookie" for
+	// parameters, "body" for the request body, or "response"
+	In string `json:"in"`
+	// Name is the parameter name, for parameters
+	Name string `json:"name,omitempty"`
+	// Pointer is the JSON pointer to the offending value, such as
+	// "/items/2/quantity"; empty means the whole value
+	Pointer string `json:"pointer,omitempty"`
+	Message string `json:"message"`
+}
+
+// OpenAPIOptions configures the ValidateOpenAPI middleware
+type OpenAPIOptions struct {
+	// Spec is the document requests and responses are checked against
+	Spec *OpenAPISpec
+	// ReportOnly logs mismatches at warn level instead of rejecting them, so
+	// contract drift is noticed in production without failing requests
+	ReportOnly bool
+	// ValidateResponses also checks the status and body of responses. The
+	// response is buffered until the handler returns.
+	ValidateResponses bool
+	// BasePath is removed from request paths before they are matched
+	// against the document, such as "/v1" for a router mounted there
+	BasePath string
+	// MaxBodyBytes is the largest request body read for validation; zero
+	// means DefaultBindMaxBytes
+	MaxBodyBytes int64
+}
+
+// LoadOpenAPI parses an OpenAPI 3 document in JSON. Schemas, parameters,
+// request bodies and responses may use local $ref pointers such as
+// "#/components/schemas/Order".
+func LoadOpenAPI(data []byte) (*OpenAPISpec, error) {
+	var root map[string]any
+	if err := json.Unmarshal(data, &root); err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidOpenAPI, err)
+	}
+	if v, _ := root["openapi"].(string); !strings.HasPrefix(v, "3.") {
+		return nil, fmt.Errorf("%w: openapi version %q is not 3.x", ErrInvalidOpenAPI, root["openapi"])
+	}
+
+	s := &OpenAPISpec{root: root}
+	paths, _ := root["paths"].(map[string]any)
+	for template, item := range paths {
+		pathItem, err := s.resolve(item)
+		if err != nil {
+			return nil, err
+		}
+		shared, _ := pathItem["parameters"].([]any)
+
+		for _, method := range openAPIMethods {
+			op, ok := pathItem[method].(map[string]any)
+			if !ok {
+				continue
+			}
+			operation, err := s.newOperation(strings.ToUpper(method), template, shared, op)
+			if err != nil {
+				return nil, err
+			}
+			s.operations = append(s.operations, operation)
+		}
+	}
+
+	// Literal paths win over templated ones, as the specification requires
+	sort.SliceStable(s.operations, func(i, j int) bool {
+		return s.operations[i].templated < s.operations[j].templated
+	})
+	return s, nil
+}
+
+// LoadOpenAPIFile reads and parses the OpenAPI 3 JSON document at path
+func LoadOpenAPIFile(path string) (*OpenAPISpec, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	return LoadOpenAPI(data)
+}
+
+// newOperation prepares the operation op of the path template, merging the
+// path item's shared parameters with its own
+func (s *OpenAPISpec) newOperation(method, template string, shared []any, op map[string]any) (*openAPIOperation, error) {
+	o := &openAPIOperation{method: method}
+	for _, seg := range strings.Split(strings.Trim(template, "/"), "/") {
+		if strings.HasPrefix(seg, "{") && strings.HasSuffix(seg, "}") {
+			o.templated++
+		}
+		o.segments = append(o.segments, seg)
+	}
+
+	own, _ := op["parameters"].([]any)
+	byKey := make(map[string]int)
+	for _, raw := range append(append([]any(nil), shared...), own...) {
+		p, err := s.resolve(raw)
+		if err != nil {
+			return nil, err
+		}
+		key := fmt.Sprint(p["in"], ":", p["name"])
+		if i, ok := byKey[key]; ok {
+			// An operation parameter overrides the shared one
+			o.parameters[i] = p
+			continue
+		}
+		byKey[key] = len(o.parameters)
+		o.parameters = append(o.parameters, p)
+	}
+
+	if raw, ok := op["requestBody"]; ok {
+		body, err := s.resolve(raw)
+		if err != nil {
+			return nil, err
+		}
+		o.body = body
+	}
+	o.responses, _ = op["responses"].(map[string]any)
+	return o, nil
+}
+
+// resolve returns node as an object, following a local $ref
+func (s *OpenAPISpec) resolve(node any) (map[string]any, error) {
+	for i := 0; i < 32; i++ {
+		obj, ok := node.(map[string]any)
+		if !ok {
+			return nil, fmt.Errorf("%w: expected an object, got %T", ErrInvalidOpenAPI, node)
+		}
+		ref, ok := obj["$ref"].(string)
+		if !ok {
+			return obj, nil
+		}
+		if node, ok = s.lookup(ref); !ok {
+			return nil, fmt.Errorf("%w: cannot resolve $ref %q", ErrInvalidOpenAPI, ref)
+		}
+	}
+	return nil, fmt.Errorf("%w: $ref cycle", ErrInvalidOpenAPI)
+}
+
+// lookup finds the node a local $ref such as "#/components/schemas/Order"
+// points to
+func (s *OpenAPISpec) lookup(ref string) (any, bool) {
+	pointer, ok := strings.CutPrefix(ref, "#")
+	if !ok {
+		return nil, false
+	}
+	var node any = s.root
+	for _, token := range strings.Split(strings.TrimPrefix(pointer, "/"), "/") {
+		if token == "" {
+			continue
+		}
+		token = strings.NewReplacer("~1", "/", "~0", "~").Replace(token)
+		if unescaped, err := url.PathUnescape(token); err == nil {
+			token = unescaped
+		}
+		obj, ok := node.(map[string]any)
+		if !ok {
+			return nil, false
+		}
+		if node, ok = obj[token]; !ok {
+			return nil, false
+		}
+	}
+	return node, true
+}
+
+// pattern returns the compiled schema pattern expr, or nil if it is invalid
+func (s *OpenAPISpec) pattern(expr string) *regexp.Regexp {
+	if re, ok := s.patterns.Load(expr); ok {
+		return re.(*regexp.Regexp)
+	}
+	re, err := regexp.Compile(expr)
+	if err != nil {
+		return nil
+	}
+	s.patterns.Store(expr, re)
+	return re
+}
+
+// match finds the operation for method and path, and its path parameters
+func (s *OpenAPISpec) match(method, path string) (*openAPIOperation, map[string]string) {
+	segments := strings.Split(strings.Trim(path, "/"), "/")
+	for _, o := range s.operations {
+		if o.method != method || len(o.segments) != len(segments) {
+			continue
+		}
+		params := make(map[string]string)
+		matched := true
+		for i, seg := range o.segments {
+			if name, ok := strings.CutPrefix(seg, "{"); ok && strings.HasSuffix(name, "}") && segments[i] != "" {
+				params[strings.TrimSuffix(name, "}")] = segments[i]
+				continue
+			}
+			if seg != segments[i] {
+				matched = false
+				break
+			}
+		}
+		if matched {
+			return o, params
+		}
+	}
+	return nil, nil
+}
+
+// ValidateOpenAPI is a middleware that checks requests, and optionally
+// responses, against an OpenAPI document:
+//
+//	spec, err := api.LoadOpenAPIFile("openapi.json")
+//	...
+//	r.Use(api.ValidateOpenAPI(api.OpenAPIOptions{
+//	    Spec:              spec,
+//	    ValidateResponses: true,
+//	    ReportOnly:        env == config.EnvProduction,
+//	}))
+//
+// Path, query, header and cookie parameters and JSON request bodies are
+// checked against their schemas. A request that does not match gets a 400
+// api error whose details list each problem as an OpenAPIError, with a JSON
+// pointer to the offending value. A response that does not match is
+// replaced by a 500 with the same details. With ReportOnly set, mismatches
+// are logged instead and requests and responses pass unchanged.
+//
+// Requests for paths and methods the document does not describe pass
+// through unchecked. It panics if opts.Spec is nil.
+func ValidateOpenAPI(opts OpenAPIOptions) func(next http.Handler) http.Handler {
+	if opts.Spec == nil {
+		panic("api: ValidateOpenAPI requires a Spec")
+	}
+	if opts.MaxBodyBytes <= 0 {
+		opts.MaxBodyBytes = DefaultBindMaxBytes
+	}
+
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			path := strings.TrimPrefix(r.URL.Path, strings.TrimSuffix(opts.BasePath, "/"))
+			op, pathParams := opts.Spec.match(r.Method, path)
+			if op == nil {
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			errs, err := opts.Spec.checkRequest(r, op, pathParams, opts.MaxBodyBytes)
+			if err != nil {
+				WriteError(w, BadRequestError(err))
+				return
+			}
+			if len(errs) > 0 {
+				if !opts.ReportOnly {
+					WriteError(w, BadRequestError(errors.New(OpenAPIRequestMessage)).WithDetails(errs))
+					return
+				}
+				logger.WithContext(r.Context()).Warn("Request does not match the OpenAPI document",
+					zap.String("method", r.Method),
+					zap.String("path", r.URL.Path),
+					zap.Any("openapi_errors", errs),
+				)
+			}
+
+			if !opts.ValidateResponses {
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			rec := &openAPIRecorder{header: make(http.Header), status: http.StatusOK}
+			next.ServeHTTP(rec, r)
+
+			if errs := opts.Spec.checkResponse(r, op, rec); len(errs) > 0 {
+				log := logger.WithContext(r.Context())
+				fields := []zap.Field{
+					zap.String("method", r.Method),
+					zap.String("path", r.URL.Path),
+					zap.Int("status", rec.status),
+					zap.Any("openapi_errors", errs),
+				}
+				if !opts.ReportOnly {
+					log.Error("Response does not match the OpenAPI document", fields...)
+					WriteError(w, ServerError(errors.New(OpenAPIResponseMessage)).WithDetails(errs))
+					return
+				}
+				log.Warn("Response does not match the OpenAPI document", fields...)
+			}
+			rec.flush(w)
+		})
+	}
+}
+
+// checkRequest checks the parameters and body of r against op. The error is
+// set only if the body cannot be read.
+func (s *OpenAPISpec) checkRequest(r *http.Request, op *openAPIOperation, pathParams map[string]string, maxBytes int64) ([]OpenAPIError, error) {
+	var errs []OpenAPIError
+	query := r.URL.Query()
+
+	for _, p := range op.parameters {
+		name, _ := p["name"].(string)
+		in, _ := p["in"].(string)
+
+		var values []string
+		switch in {
+		case "path":
+			if v, ok := pathParams[name]; ok {
+				values = []string{v}
+			}
+		case "query":
+			values = query[name]
+		case "header":
+			values = r.Header.Values(name)
+		case "cookie":
+			if c, err := r.Cookie(name); err == nil {
+				values = []string{c.Value}
+			}
+		default:
+			continue
+		}
+
+		if len(values) == 0 {
+			if required, _ := p["required"].(bool); required {
+				errs = append(errs, OpenAPIError{In: in, Name: name, Message: "is required"})
+			}
+			continue
+		}
+		schema, err := s.resolve(p["schema"])
+		if err != nil {
+			continue
+		}
+		explode := in == "query" || in == "cookie"
+		if e, ok := p["explode"].(bool); ok {
+			explode = e
+		}
+		v := schemaValidator{spec: s, in: in, name: name}
+		v.validate(schema, s.paramValue(schema, values, explode), "", 0)
+		errs = append(errs, v.errs...)
+	}
+
+	if op.body == nil {
+		return errs, nil
+	}
+
+	body, err := io.ReadAll(io.LimitReader(r.Body, maxBytes+1))
+	if err != nil {
+		return nil, fmt.Errorf("failed to read request body: %w", err)
+	}
+	if int64(len(body)) > maxBytes {
+		return nil, fmt.Errorf("request body must not be larger than %d bytes", maxBytes)
+	}
+	r.Body = io.NopCloser(bytes.NewReader(body))
+
+	if len(body) == 0 {
+		if required, _ := op.body["required"].(bool); required {
+			errs = append(errs, OpenAPIError{In: "body", Message: "is required"})
+		}
+		return errs, nil
+	}
+
+	content, _ := op.body["content"].(map[string]any)
+	mediaType, media, ok := s.media(content, r.Header.Get("Content-Type"))
+	if !ok {
+		errs = append(errs, OpenAPIError{In: "body", Message: fmt.Sprintf("content type %q is not supported", r.Header.Get("Content-Type"))})
+		return errs, nil
+	}
+	errs = append(errs, s.checkBody("body", mediaType, media, body, false)...)
+	return errs, nil
+}
+
+// checkResponse checks the status and body recorded by rec against op
+func (s *OpenAPISpec) checkResponse(r *http.Request, op *openAPIOperation, rec *openAPIRecorder) []OpenAPIError {
+	raw, ok := op.responses[strconv.Itoa(rec.status)]
+	if !ok {
+		raw, ok = op.responses[strconv.Itoa(rec.status/100)+"XX"]
+	}
+	if !ok {
+		raw, ok = op.responses[strconv.Itoa(rec.status/100)+"xx"]
+	}
+	if !ok {
+		raw, ok = op.responses["default"]
+	}
+	if !ok {
+		return []OpenAPIError{{In: "response", Message: fmt.Sprintf("status %d is not documented", rec.status)}}
+	}
+	resp, err := s.resolve(raw)
+	if err != nil {
+		return nil
+	}
+
+	content, _ := resp["content"].(map[string]any)
+	if len(content) == 0 || r.Method == http.MethodHead || rec.body.Len() == 0 {
+		return nil
+	}
+	mediaType, media, ok := s.media(content, rec.header.Get("Content-Type"))
+	if !ok {
+		return []OpenAPIError{{In: "response", Message: fmt.Sprintf("content type %q is not documented", rec.header.Get("Content-Type"))}}
+	}
+	return s.checkBody("response", mediaType, media, rec.body.Bytes(), true)
+}
+
+// checkBody checks a JSON body against the schema of media. Bodies of other
+// media types are not checked.
+func (s *OpenAPISpec) checkBody(in, mediaType string, media map[string]any, body []byte, response bool) []OpenAPIError {
+	if !isJSONMediaType(mediaType) || media["schema"] == nil {
+		return nil
+	}
+	schema, err := s.resolve(media["schema"])
+	if err != nil {
+		return nil
+	}
+
+	dec := json.NewDecoder(bytes.NewReader(body))
+	dec.UseNumber()
+	var value any
+	if err := dec.Decode(&value); err != nil {
+		return []OpenAPIError{{In: in, Message: "must be valid JSON"}}
+	}
+
+	v := schemaValidator{spec: s, in: in, response: response}
+	v.validate(schema, value, "", 0)
+	return v.errs
+}
+
+// media finds the entry of content for contentType, trying the exact media
+// type, then "type/*", then "*/*"
+func (s *OpenAPISpec) media(content map[string]any, contentType string) (string, map[string]any, bool) {
+	mediaType, _, err := mime.ParseMediaType(contentType)
+	if err != nil {
+		mediaType = strings.TrimSpace(contentType)
+	}
+	mediaType = strings.ToLower(mediaType)
+	major, _, _ := strings.Cut(mediaType, "/")
+
+	for _, key := range []string{mediaType, major + "/*", "*/*"} {
+		for documented, raw := range content {
+			if !strings.EqualFold(documented, key) {
+				continue
+			}
+			media, err := s.resolve(raw)
+			if err != nil {
+				media = map[string]any{}
+			}
+			return mediaType, media, true
+		}
+	}
+	return "", nil, false
+}
+
+// isJSONMediaType reports whether mediaType is JSON, such as
+// application/json or application/problem+json
+func isJSONMediaType(mediaType string) bool {
+	return mediaType == "application/json" || strings.HasSuffix(mediaType, "+json")
+}
+
+// paramValue converts the raw values of a parameter to the JSON value its
+// schema describes, so numbers and booleans can be checked. Arrays collect
+// repeated values, or split a single comma-separated one when explode is off.
+func (s *OpenAPISpec) paramValue(schema map[string]any, values []string, explode bool) any {
+	if schemaTypes(schema)["array"] {
+		if len(values) == 1 && !explode {
+			values = strings.Split(values[0], ",")
+		}
+		items, err := s.resolve(schema["items"])
+		if err != nil {
+			items = nil
+		}
+		out := make([]any, len(values))
+		for i, raw := range values {
+			out[i] = scalarParam(items, raw)
+		}
+		return out
+	}
+	return scalarParam(schema, values[0])
+}
+
+// scalarParam converts raw to a json.Number or bool if schema expects one
+// and raw parses as one; otherwise raw is returned for the type check to
+// report
+func scalarParam(schema map[string]any, raw string) any {
+	types := schemaTypes(schema)
+	switch {
+	case types["integer"] || types["number"]:
+		if _, err := strconv.ParseFloat(raw, 64); err == nil {
+			return json.Number(raw)
+		}
+	case types["boolean"]:
+		if b, err := strconv.ParseBool(raw); err == nil {
+			return b
+		}
+	}
+	return raw
+}
+
+// openAPIRecorder buffers a response so it can be checked before it is sent
+type openAPIRecorder struct {
+	header      http.Header
+	status      int
+	body        bytes.Buffer
+	wroteHeader bool
+}
+
+func (rec *openAPIRecorder) Header() http.Header {
+	return rec.header
+}
+
+func (rec *openAPIRecorder) WriteHeader(status int) {
+	if rec.wroteHeader {
+		return
+	}
+	rec.wroteHeader = true
+	rec.status = status
+}
+
+func (rec *openAPIRecorder) Write(p []byte) (int, error) {
+	if !rec.wroteHeader {
+		rec.WriteHeader(http.StatusOK)
+	}
+	return rec.body.Write(p)
+}
+
+// flush sends the buffered response to w
+func (rec *openAPIRecorder) flush(w http.ResponseWriter) {
+	for k, v := range rec.header {
+		w.Header()[k] = v
+	}
+	w.WriteHeader(rec.status)
+	w.Write(rec.body.Bytes())
+}
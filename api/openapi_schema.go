@@ -0,0 +1,437 @@
+package api
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/StairSupplies/go-core/validate"
+)
+
+// maxSchemaDepth bounds schema recursion that consumes no value, such as an
+// allOf that refers back to its own schema
+const maxSchemaDepth = 64
+
+// schemaValidator checks values against the schemas of an OpenAPISpec. It
+// supports the JSON Schema keywords OpenAPI documents commonly use: type,
+// nullable, enum, const, properties, required, additionalProperties, items,
+// the length, size and range limits, multipleOf, pattern, a few formats,
+// allOf, anyOf, oneOf and not.
+type schemaValidator struct {
+	spec *OpenAPISpec
+	in   string
+	name string
+	// response is set when checking responses, so writeOnly properties
+	// need not be present; in requests readOnly properties need not be
+	response bool
+	errs     []OpenAPIError
+}
+
+// fail records a problem with the value at pointer
+func (v *schemaValidator) fail(pointer, format string, args ...any) {
+	v.errs = append(v.errs, OpenAPIError{
+		In:      v.in,
+		Name:    v.name,
+		Pointer: pointer,
+		Message: fmt.Sprintf(format, args...),
+	})
+}
+
+// matches reports whether value satisfies schema, without recording errors
+func (v *schemaValidator) matches(schema map[string]any, value any, pointer string, depth int) bool {
+	sub := schemaValidator{spec: v.spec, in: v.in, name: v.name, response: v.response}
+	sub.validate(schema, value, pointer, depth)
+	return len(sub.errs) == 0
+}
+
+// validate checks value, found at pointer, against schema
+func (v *schemaValidator) validate(schema map[string]any, value any, pointer string, depth int) {
+	if depth > maxSchemaDepth {
+		return
+	}
+	schema, err := v.spec.resolve(schema)
+	if err != nil {
+		return
+	}
+
+	for _, raw := range schemaList(schema["allOf"]) {
+		if sub, err := v.spec.resolve(raw); err == nil {
+			v.validate(sub, value, pointer, depth+1)
+		}
+	}
+	if anyOf := schemaList(schema["anyOf"]); len(anyOf) > 0 {
+		matched := false
+		for _, raw := range anyOf {
+			if sub, err := v.spec.resolve(raw); err == nil && v.matches(sub, value, pointer, depth+1) {
+				matched = true
+				break
+			}
+		}
+		if !matched {
+			v.fail(pointer, "must match at least one of the allowed schemas")
+		}
+	}
+	if oneOf := schemaList(schema["oneOf"]); len(oneOf) > 0 {
+		count := 0
+		for _, raw := range oneOf {
+			if sub, err := v.spec.resolve(raw); err == nil && v.matches(sub, value, pointer, depth+1) {
+				count++
+			}
+		}
+		if count != 1 {
+			v.fail(pointer, "must match exactly one of the allowed schemas, matched %d", count)
+		}
+	}
+	if raw, ok := schema["not"]; ok {
+		if sub, err := v.spec.resolve(raw); err == nil && v.matches(sub, value, pointer, depth+1) {
+			v.fail(pointer, "must not match the excluded schema")
+		}
+	}
+
+	if value == nil {
+		types := schemaTypes(schema)
+		nullable, _ := schema["nullable"].(bool)
+		if len(types) > 0 && !types["null"] && !nullable {
+			v.fail(pointer, "must not be null")
+		} else if c, ok := schema["const"]; ok && c != nil {
+			v.fail(pointer, "must be %s", formatJSON(c))
+		}
+		return
+	}
+	if !v.checkType(schema, value, pointer) {
+		return
+	}
+
+	if enum, ok := schema["enum"].([]any); ok && !containsJSON(enum, value) {
+		v.fail(pointer, "must be one of: %s", joinJSON(enum))
+	}
+	if c, ok := schema["const"]; ok && !equalJSON(c, value) {
+		v.fail(pointer, "must be %s", formatJSON(c))
+	}
+
+	switch val := value.(type) {
+	case string:
+		v.checkString(schema, val, pointer)
+	case json.Number:
+		f, _ := val.Float64()
+		v.checkNumber(schema, f, pointer)
+	case []any:
+		v.checkArray(schema, val, pointer, depth)
+	case map[string]any:
+		v.checkObject(schema, val, pointer, depth)
+	}
+}
+
+// checkType reports whether value has one of the schema's types, recording
+// an error if not. Schemas without a type accept any value.
+func (v *schemaValidator) checkType(schema map[string]any, value any, pointer string) bool {
+	types := schemaTypes(schema)
+	if len(types) == 0 {
+		return true
+	}
+
+	var actual string
+	switch val := value.(type) {
+	case string:
+		actual = "string"
+	case bool:
+		actual = "boolean"
+	case json.Number:
+		actual = "number"
+		if types["integer"] && isInteger(val) {
+			actual = "integer"
+		}
+	case []any:
+		actual = "array"
+	case map[string]any:
+		actual = "object"
+	}
+	if types[actual] || actual == "integer" && types["number"] {
+		return true
+	}
+
+	names := make([]string, 0, len(types))
+	for t := range types {
+		if t != "null" {
+			names = append(names, t)
+		}
+	}
+	sort.Strings(names)
+	v.fail(pointer, "must be of type %s", strings.Join(names, " or "))
+	return false
+}
+
+// checkString applies the string keywords of schema
+func (v *schemaValidator) checkString(schema map[string]any, s string, pointer string) {
+	n := utf8.RuneCountInString(s)
+	if min, ok := schemaNumber(schema, "minLength"); ok && float64(n) < min {
+		v.fail(pointer, "must contain at least %s characters", formatNumber(min))
+	}
+	if max, ok := schemaNumber(schema, "maxLength"); ok && float64(n) > max {
+		v.fail(pointer, "must not contain more than %s characters", formatNumber(max))
+	}
+	if expr, ok := schema["pattern"].(string); ok {
+		if re := v.spec.pattern(expr); re != nil && !re.MatchString(s) {
+			v.fail(pointer, "must match the pattern %s", expr)
+		}
+	}
+
+	format, _ := schema["format"].(string)
+	valid := true
+	switch format {
+	case "date-time":
+		_, err := time.Parse(time.RFC3339, s)
+		valid = err == nil
+	case "date":
+		_, err := time.Parse(time.DateOnly, s)
+		valid = err == nil
+	case "email":
+		valid = validate.IsEmail(s)
+	case "uuid":
+		valid = validate.IsUUID(s)
+	case "uri":
+		valid = validate.IsURL(s, "http", "https", "ftp", "mailto", "urn")
+	}
+	if !valid {
+		v.fail(pointer, "must be a valid %s", format)
+	}
+}
+
+// checkNumber applies the numeric keywords of schema. exclusiveMinimum and
+// exclusiveMaximum may be booleans, as in OpenAPI 3.0, or limits, as in 3.1.
+func (v *schemaValidator) checkNumber(schema map[string]any, f float64, pointer string) {
+	exclusiveMin, _ := schema["exclusiveMinimum"].(bool)
+	exclusiveMax, _ := schema["exclusiveMaximum"].(bool)
+
+	if min, ok := schemaNumber(schema, "minimum"); ok {
+		if exclusiveMin && f <= min {
+			v.fail(pointer, "must be greater than %s", formatNumber(min))
+		} else if f < min {
+			v.fail(pointer, "must be at least %s", formatNumber(min))
+		}
+	}
+	if max, ok := schemaNumber(schema, "maximum"); ok {
+		if exclusiveMax && f >= max {
+			v.fail(pointer, "must be less than %s", formatNumber(max))
+		} else if f > max {
+			v.fail(pointer, "must not be greater than %s", formatNumber(max))
+		}
+	}
+	if min, ok := schemaNumber(schema, "exclusiveMinimum"); ok && f <= min {
+		v.fail(pointer, "must be greater than %s", formatNumber(min))
+	}
+	if max, ok := schemaNumber(schema, "exclusiveMaximum"); ok && f >= max {
+		v.fail(pointer, "must be less than %s", formatNumber(max))
+	}
+	if m, ok := schemaNumber(schema, "multipleOf"); ok && m > 0 {
+		if q := f / m; math.Abs(q-math.Round(q)) > 1e-9 {
+			v.fail(pointer, "must be a multiple of %s", formatNumber(m))
+		}
+	}
+}
+
+// checkArray applies the array keywords of schema and checks each item
+func (v *schemaValidator) checkArray(schema map[string]any, items []any, pointer string, depth int) {
+	if min, ok := schemaNumber(schema, "minItems"); ok && float64(len(items)) < min {
+		v.fail(pointer, "must contain at least %s items", formatNumber(min))
+	}
+	if max, ok := schemaNumber(schema, "maxItems"); ok && float64(len(items)) > max {
+		v.fail(pointer, "must not contain more than %s items", formatNumber(max))
+	}
+	if unique, _ := schema["uniqueItems"].(bool); unique {
+		for i := range items {
+			for j := 0; j < i; j++ {
+				if equalJSON(items[i], items[j]) {
+					v.fail(pointer+"/"+strconv.Itoa(i), "must not repeat an earlier item")
+					break
+				}
+			}
+		}
+	}
+
+	itemSchema, ok := schema["items"].(map[string]any)
+	if !ok {
+		return
+	}
+	for i, item := range items {
+		v.validate(itemSchema, item, pointer+"/"+strconv.Itoa(i), depth+1)
+	}
+}
+
+// checkObject applies the object keywords of schema and checks each property
+func (v *schemaValidator) checkObject(schema map[string]any, obj map[string]any, pointer string, depth int) {
+	properties, _ := schema["properties"].(map[string]any)
+
+	for _, raw := range schemaList(schema["required"]) {
+		name, _ := raw.(string)
+		if _, ok := obj[name]; ok {
+			continue
+		}
+		if prop, err := v.spec.resolve(properties[name]); err == nil {
+			if readOnly, _ := prop["readOnly"].(bool); readOnly && !v.response {
+				continue
+			}
+			if writeOnly, _ := prop["writeOnly"].(bool); writeOnly && v.response {
+				continue
+			}
+		}
+		v.fail(pointer+"/"+escapePointer(name), "is required")
+	}
+	if min, ok := schemaNumber(schema, "minProperties"); ok && float64(len(obj)) < min {
+		v.fail(pointer, "must contain at least %s properties", formatNumber(min))
+	}
+	if max, ok := schemaNumber(schema, "maxProperties"); ok && float64(len(obj)) > max {
+		v.fail(pointer, "must not contain more than %s properties", formatNumber(max))
+	}
+
+	// Visit properties in a stable order so errors are reproducible
+	names := make([]string, 0, len(obj))
+	for name := range obj {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		child := pointer + "/" + escapePointer(name)
+		if prop, ok := properties[name].(map[string]any); ok {
+			v.validate(prop, obj[name], child, depth+1)
+			continue
+		}
+		switch extra := schema["additionalProperties"].(type) {
+		case bool:
+			if !extra {
+				v.fail(child, "is not allowed")
+			}
+		case map[string]any:
+			v.validate(extra, obj[name], child, depth+1)
+		}
+	}
+}
+
+// schemaTypes returns the set of types a schema allows, from a type string
+// or, as in OpenAPI 3.1, a list of types
+func schemaTypes(schema map[string]any) map[string]bool {
+	types := make(map[string]bool)
+	switch t := schema["type"].(type) {
+	case string:
+		types[t] = true
+	case []any:
+		for _, name := range t {
+			if s, ok := name.(string); ok {
+				types[s] = true
+			}
+		}
+	}
+	return types
+}
+
+// schemaList returns node as a list, or nil if it is not one
+func schemaList(node any) []any {
+	list, _ := node.([]any)
+	return list
+}
+
+// schemaNumber returns the numeric keyword key of schema
+func schemaNumber(schema map[string]any, key string) (float64, bool) {
+	f, ok := schema[key].(float64)
+	return f, ok
+}
+
+// isInteger reports whether n has no fractional part
+func isInteger(n json.Number) bool {
+	if _, err := n.Int64(); err == nil {
+		return true
+	}
+	f, err := n.Float64()
+	return err == nil && f == math.Trunc(f)
+}
+
+// escapePointer escapes a property name for use in a JSON pointer
+func escapePointer(name string) string {
+	return strings.NewReplacer("~", "~0", "/", "~1").Replace(name)
+}
+
+// equalJSON reports whether two decoded JSON values are equal. Numbers are
+// compared by value, whether decoded as json.Number or float64.
+func equalJSON(a, b any) bool {
+	if fa, ok := jsonFloat(a); ok {
+		fb, ok := jsonFloat(b)
+		return ok && fa == fb
+	}
+	switch av := a.(type) {
+	case []any:
+		bv, ok := b.([]any)
+		if !ok || len(av) != len(bv) {
+			return false
+		}
+		for i := range av {
+			if !equalJSON(av[i], bv[i]) {
+				return false
+			}
+		}
+		return true
+	case map[string]any:
+		bv, ok := b.(map[string]any)
+		if !ok || len(av) != len(bv) {
+			return false
+		}
+		for k, x := range av {
+			y, ok := bv[k]
+			if !ok || !equalJSON(x, y) {
+				return false
+			}
+		}
+		return true
+	}
+	return a == b
+}
+
+// jsonFloat returns a decoded JSON number as a float64
+func jsonFloat(v any) (float64, bool) {
+	switch n := v.(type) {
+	case float64:
+		return n, true
+	case json.Number:
+		f, err := n.Float64()
+		return f, err == nil
+	}
+	return 0, false
+}
+
+// containsJSON reports whether list holds a value equal to value
+func containsJSON(list []any, value any) bool {
+	for _, item := range list {
+		if equalJSON(item, value) {
+			return true
+		}
+	}
+	return false
+}
+
+// joinJSON formats the values of list for a message
+func joinJSON(list []any) string {
+	parts := make([]string, len(list))
+	for i, item := range list {
+		parts[i] = formatJSON(item)
+	}
+	return strings.Join(parts, ", ")
+}
+
+// formatJSON formats a decoded JSON value for a message
+func formatJSON(v any) string {
+	if s, ok := v.(string); ok {
+		return s
+	}
+	b, _ := json.Marshal(v)
+	return string(b)
+}
+
+// formatNumber formats a schema limit without a needless fraction
+func formatNumber(f float64) string {
+	return strconv.FormatFloat(f, 'f', -1, 64)
+}
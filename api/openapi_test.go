@@ -0,0 +1,390 @@
+package api
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/StairSupplies/go-core/logger"
+	"github.com/StairSupplies/go-core/logger/logtest"
+	"go.uber.org/zap/zapcore"
+)
+
+const testOpenAPI = `{
+  "openapi": "3.0.3",
+  "info": {"title": "orders", "version": "1"},
+  "paths": {
+    "/orders": {
+      "get": {
+        "parameters": [
+          {"name": "status", "in": "query", "schema": {"type": "array", "items": {"type": "string", "enum": ["open", "closed"]}}},
+          {"name": "limit", "in": "query", "schema": {"type": "integer", "minimum": 1, "maximum": 100}},
+          {"name": "X-Tenant", "in": "header", "required": true, "schema": {"type": "string"}}
+        ],
+        "responses": {
+          "200": {"description": "ok", "content": {"application/json": {"schema": {"type": "array", "items": {"$ref": "#/components/schemas/Order"}}}}}
+        }
+      },
+      "post": {
+        "requestBody": {"$ref": "#/components/requestBodies/NewOrder"},
+        "responses": {
+          "201": {"$ref": "#/components/responses/Order"},
+          "4XX": {"description": "error", "content": {"application/json": {"schema": {"type": "object"}}}}
+        }
+      }
+    },
+    "/orders/export": {
+      "get": {"responses": {"200": {"description": "csv", "content": {"text/csv": {}}}}}
+    },
+    "/orders/{id}": {
+      "parameters": [{"name": "id", "in": "path", "required": true, "schema": {"type": "integer"}}],
+      "get": {"responses": {"200": {"$ref": "#/components/responses/Order"}}}
+    }
+  },
+  "components": {
+    "schemas": {
+      "Item": {
+        "type": "object",
+        "required": ["sku", "quantity"],
+        "additionalProperties": false,
+        "properties": {
+          "sku": {"type": "string", "pattern": "^[A-Z]+-[0-9]+$"},
+          "quantity": {"type": "integer", "minimum": 1}
+        }
+      },
+      "Order": {
+        "type": "object",
+        "required": ["id", "customer_email", "items"],
+        "properties": {
+          "id": {"type": "integer", "readOnly": true},
+          "customer_email": {"type": "string", "format": "email"},
+          "note": {"type": "string", "nullable": true, "maxLength": 10},
+          "items": {"type": "array", "minItems": 1, "items": {"$ref": "#/components/schemas/Item"}}
+        }
+      }
+    },
+    "requestBodies": {
+      "NewOrder": {"required": true, "content": {"application/json": {"schema": {"$ref": "#/components/schemas/Order"}}}}
+    },
+    "responses": {
+      "Order": {"description": "an order", "content": {"application/json": {"schema": {"$ref": "#/components/schemas/Order"}}}}
+    }
+  }
+}`
+
+func loadTestOpenAPI(t *testing.T) *OpenAPISpec {
+	t.Helper()
+	spec, err := LoadOpenAPI([]byte(testOpenAPI))
+	if err != nil {
+		t.Fatalf("LoadOpenAPI() error = %v", err)
+	}
+	return spec
+}
+
+// openAPIDetails decodes the OpenAPIErrors from an api error response
+func openAPIDetails(t *testing.T, rec *httptest.ResponseRecorder) []OpenAPIError {
+	t.Helper()
+	var body struct {
+		Error struct {
+			Message string         `json:"message"`
+			Details []OpenAPIError `json:"details"`
+		} `json:"error"`
+	}
+	if err := json.Unmarshal(rec.Body.Bytes(), &body); err != nil {
+		t.Fatalf("Failed to decode error response %q: %v", rec.Body.String(), err)
+	}
+	return body.Error.Details
+}
+
+func TestValidateOpenAPI_Requests(t *testing.T) {
+	spec := loadTestOpenAPI(t)
+	h := ValidateOpenAPI(OpenAPIOptions{Spec: spec})(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNoContent)
+	}))
+
+	tests := []struct {
+		name   string
+		method string
+		target string
+		header map[string]string
+		body   string
+		want   []OpenAPIError
+	}{
+		{
+			name:   "valid body",
+			method: http.MethodPost, target: "/orders",
+			body: `{"customer_email":"a@example.com","items":[{"sku":"AB-1","quantity":2}],"note":null}`,
+		},
+		{
+			name:   "body errors",
+			method: http.MethodPost, target: "/orders",
+			body: `{"customer_email":"nope","items":[{"sku":"ab","quantity":0,"color":"red"}],"note":"far too long"}`,
+			want: []OpenAPIError{
+				{In: "body", Pointer: "/customer_email", Message: "must be a valid email"},
+				{In: "body", Pointer: "/items/0/color", Message: "is not allowed"},
+				{In: "body", Pointer: "/items/0/quantity", Message: "must be at least 1"},
+				{In: "body", Pointer: "/items/0/sku", Message: "must match the pattern ^[A-Z]+-[0-9]+$"},
+				{In: "body", Pointer: "/note", Message: "must not contain more than 10 characters"},
+			},
+		},
+		{
+			name:   "missing properties",
+			method: http.MethodPost, target: "/orders",
+			body: `{"items":[{"sku":"AB-1","quantity":1.5}]}`,
+			want: []OpenAPIError{
+				{In: "body", Pointer: "/customer_email", Message: "is required"},
+				{In: "body", Pointer: "/items/0/quantity", Message: "must be of type integer"},
+			},
+		},
+		{
+			name:   "missing body",
+			method: http.MethodPost, target: "/orders",
+			want: []OpenAPIError{{In: "body", Message: "is required"}},
+		},
+		{
+			name:   "invalid JSON",
+			method: http.MethodPost, target: "/orders",
+			body: `{"items":`,
+			want: []OpenAPIError{{In: "body", Message: "must be valid JSON"}},
+		},
+		{
+			name:   "unsupported content type",
+			method: http.MethodPost, target: "/orders",
+			header: map[string]string{"Content-Type": "text/plain"},
+			body:   "hello",
+			want:   []OpenAPIError{{In: "body", Message: `content type "text/plain" is not supported`}},
+		},
+		{
+			name:   "valid parameters",
+			method: http.MethodGet, target: "/orders?status=open&status=closed&limit=10",
+			header: map[string]string{"X-Tenant": "acme"},
+		},
+		{
+			name:   "parameter errors",
+			method: http.MethodGet, target: "/orders?status=open&status=lost&limit=500",
+			want: []OpenAPIError{
+				{In: "query", Name: "status", Pointer: "/1", Message: "must be one of: open, closed"},
+				{In: "query", Name: "limit", Message: "must not be greater than 100"},
+				{In: "header", Name: "X-Tenant", Message: "is required"},
+			},
+		},
+		{
+			name:   "path parameter",
+			method: http.MethodGet, target: "/orders/abc",
+			want:   []OpenAPIError{{In: "path", Name: "id", Message: "must be of type integer"}},
+		},
+		{
+			name:   "literal path wins",
+			method: http.MethodGet, target: "/orders/export",
+		},
+		{
+			name:   "undocumented route",
+			method: http.MethodDelete, target: "/orders/abc",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(tt.method, tt.target, strings.NewReader(tt.body))
+			req.Header.Set("Content-Type", "application/json")
+			for k, v := range tt.header {
+				req.Header.Set(k, v)
+			}
+			rec := httptest.NewRecorder()
+			h.ServeHTTP(rec, req)
+
+			if tt.want == nil {
+				if rec.Code != http.StatusNoContent {
+					t.Errorf("Expected request to pass, got %d: %s", rec.Code, rec.Body.String())
+				}
+				return
+			}
+			if rec.Code != http.StatusBadRequest {
+				t.Fatalf("Expected status %d, got %d", http.StatusBadRequest, rec.Code)
+			}
+			if got := openAPIDetails(t, rec); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Expected details %+v, got %+v", tt.want, got)
+			}
+		})
+	}
+}
+
+func TestValidateOpenAPI_BasePath(t *testing.T) {
+	var got string
+	h := ValidateOpenAPI(OpenAPIOptions{Spec: loadTestOpenAPI(t), BasePath: "/v1/"})(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var order struct {
+			CustomerEmail string            `json:"customer_email"`
+			Items         []json.RawMessage `json:"items"`
+		}
+		if err := Bind(r, &order); err != nil {
+			t.Errorf("Bind() error = %v", err)
+		}
+		got = order.CustomerEmail
+	}))
+
+	send := func(body string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodPost, "/v1/orders", strings.NewReader(body))
+		req.Header.Set("Content-Type", "application/json")
+		rec := httptest.NewRecorder()
+		h.ServeHTTP(rec, req)
+		return rec
+	}
+
+	if rec := send(`{"items":[]}`); rec.Code != http.StatusBadRequest {
+		t.Errorf("Expected /v1/orders to be checked as /orders, got %d", rec.Code)
+	}
+
+	send(`{"customer_email":"a@example.com","items":[{"sku":"AB-1","quantity":2}]}`)
+	if got != "a@example.com" {
+		t.Errorf("Expected the handler to read the body, got %q", got)
+	}
+}
+
+func TestValidateOpenAPI_MaxBodyBytes(t *testing.T) {
+	h := ValidateOpenAPI(OpenAPIOptions{Spec: loadTestOpenAPI(t), MaxBodyBytes: 10})(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		t.Error("Expected handler not to run")
+	}))
+
+	req := httptest.NewRequest(http.MethodPost, "/orders", strings.NewReader(`{"customer_email":"a@example.com"}`))
+	req.Header.Set("Content-Type", "application/json")
+	rec := httptest.NewRecorder()
+	h.ServeHTTP(rec, req)
+
+	if rec.Code != http.StatusBadRequest {
+		t.Errorf("Expected status %d, got %d", http.StatusBadRequest, rec.Code)
+	}
+}
+
+func TestValidateOpenAPI_Responses(t *testing.T) {
+	spec := loadTestOpenAPI(t)
+
+	tests := []struct {
+		name    string
+		status  int
+		body    string
+		want    []OpenAPIError
+		matches bool
+	}{
+		{name: "valid", status: http.StatusOK, body: `{"id":7,"customer_email":"a@example.com","items":[{"sku":"AB-1","quantity":1}]}`, matches: true},
+		{name: "readOnly required in responses", status: http.StatusOK, body: `{"customer_email":"a@example.com","items":[{"sku":"AB-1","quantity":1}]}`,
+			want: []OpenAPIError{{In: "response", Pointer: "/id", Message: "is required"}}},
+		{name: "wrong type", status: http.StatusOK, body: `{"id":"7","customer_email":"a@example.com","items":[]}`,
+			want: []OpenAPIError{
+				{In: "response", Pointer: "/id", Message: "must be of type integer"},
+				{In: "response", Pointer: "/items", Message: "must contain at least 1 items"},
+			}},
+		{name: "undocumented status", status: http.StatusAccepted, body: `{}`,
+			want: []OpenAPIError{{In: "response", Message: "status 202 is not documented"}}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			h := ValidateOpenAPI(OpenAPIOptions{Spec: spec, ValidateResponses: true})(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.Header().Set("Content-Type", "application/json")
+				w.Header().Set("X-Handler", "orders")
+				w.WriteHeader(tt.status)
+				w.Write([]byte(tt.body))
+			}))
+			log, logs := logtest.NewTestLogger(t)
+			req := httptest.NewRequest(http.MethodGet, "/orders/7", nil)
+			req = req.WithContext(logger.NewContext(req.Context(), log))
+			rec := httptest.NewRecorder()
+			h.ServeHTTP(rec, req)
+
+			if tt.matches {
+				if rec.Code != tt.status || rec.Body.String() != tt.body || rec.Header().Get("X-Handler") != "orders" {
+					t.Errorf("Expected response to pass unchanged, got %d %q", rec.Code, rec.Body.String())
+				}
+				return
+			}
+			if rec.Code != http.StatusInternalServerError {
+				t.Fatalf("Expected status %d, got %d", http.StatusInternalServerError, rec.Code)
+			}
+			if rec.Header().Get("X-Handler") != "" {
+				t.Error("Expected the handler's headers to be discarded")
+			}
+			if got := openAPIDetails(t, rec); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Expected details %+v, got %+v", tt.want, got)
+			}
+			logs.AssertLogged(zapcore.ErrorLevel, "Response does not match the OpenAPI document", nil)
+		})
+	}
+}
+
+func TestValidateOpenAPI_ReportOnly(t *testing.T) {
+	log, logs := logtest.NewTestLogger(t)
+	h := ValidateOpenAPI(OpenAPIOptions{Spec: loadTestOpenAPI(t), ReportOnly: true, ValidateResponses: true})(
+		http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusTeapot)
+		}))
+
+	req := httptest.NewRequest(http.MethodGet, "/orders/abc", nil)
+	req = req.WithContext(logger.NewContext(req.Context(), log))
+	rec := httptest.NewRecorder()
+	h.ServeHTTP(rec, req)
+
+	if rec.Code != http.StatusTeapot {
+		t.Errorf("Expected the handler's response, got %d", rec.Code)
+	}
+	logs.AssertLogged(zapcore.WarnLevel, "Request does not match the OpenAPI document", map[string]interface{}{"path": "/orders/abc"})
+	logs.AssertLogged(zapcore.WarnLevel, "Response does not match the OpenAPI document", map[string]interface{}{"status": int64(http.StatusTeapot)})
+}
+
+func TestOpenAPISchemaCombinators(t *testing.T) {
+	spec, err := LoadOpenAPI([]byte(`{"openapi": "3.1.0", "paths": {}}`))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	schema := map[string]any{
+		"oneOf": []any{
+			map[string]any{"type": "string", "const": "all"},
+			map[string]any{"type": []any{"integer", "null"}, "exclusiveMinimum": 0.0, "multipleOf": 5.0},
+		},
+		"not": map[string]any{"const": 25.0},
+	}
+
+	tests := []struct {
+		value any
+		ok    bool
+	}{
+		{"all", true},
+		{json.Number("10"), true},
+		{nil, true},
+		{json.Number("0"), false},
+		{json.Number("7"), false},
+		{json.Number("25"), false},
+		{"some", false},
+		{true, false},
+	}
+	for _, tt := range tests {
+		v := schemaValidator{spec: spec, in: "body"}
+		v.validate(schema, tt.value, "", 0)
+		if ok := len(v.errs) == 0; ok != tt.ok {
+			t.Errorf("validate(%v): expected ok %v, got errors %+v", tt.value, tt.ok, v.errs)
+		}
+	}
+}
+
+func TestLoadOpenAPI_Errors(t *testing.T) {
+	tests := []struct {
+		name string
+		doc  string
+	}{
+		{"invalid JSON", `{"openapi":`},
+		{"swagger 2", `{"swagger": "2.0", "paths": {}}`},
+		{"unresolved ref", `{"openapi": "3.0.0", "paths": {"/a": {"post": {"requestBody": {"$ref": "#/components/requestBodies/Missing"}}}}}`},
+		{"ref cycle", `{"openapi": "3.0.0", "paths": {"/a": {"$ref": "#/paths/~1a"}}}`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := LoadOpenAPI([]byte(tt.doc)); !errors.Is(err, ErrInvalidOpenAPI) {
+				t.Errorf("Expected ErrInvalidOpenAPI, got %v", err)
+			}
+		})
+	}
+}
@@ -0,0 +1,137 @@
+package api
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+)
+
+// PaginationConfig controls how pagination query parameters are parsed
+type PaginationConfig struct {
+	// DefaultPerPage is used when per_page is absent
+	DefaultPerPage int
+	// MaxPerPage caps per_page; larger values are rejected
+	MaxPerPage int
+	// SortFields lists the fields clients may sort by. Sorting is rejected
+	// when empty.
+	SortFields []string
+}
+
+// DefaultPaginationConfig is used by ParsePagination
+var DefaultPaginationConfig = PaginationConfig{
+	DefaultPerPage: 20,
+	MaxPerPage:     100,
+}
+
+// SortField is a single field in a sort expression
+type SortField struct {
+	Field      string
+	Descending bool
+}
+
+// Pagination is a page request parsed from the page, per_page and sort
+// query parameters
+type Pagination struct {
+	Page    int
+	PerPage int
+	Sort    []SortField
+}
+
+// PaginatedMeta is the standard meta object for paginated responses
+type PaginatedMeta struct {
+	Total      int64 `json:"total"`
+	Page       int   `json:"page"`
+	PerPage    int   `json:"per_page"`
+	TotalPages int   `json:"total_pages"`
+}
+
+// ParsePagination parses pagination parameters using DefaultPaginationConfig
+func ParsePagination(r *http.Request) (Pagination, error) {
+	return DefaultPaginationConfig.Parse(r)
+}
+
+// Parse parses the page, per_page and sort query parameters of r.
+// Invalid values are returned as a 400 Bad Request api.Error.
+//
+// Sort takes a comma-separated list of fields, each optionally prefixed
+// with "-" for descending order, e.g. "sort=-created_at,name".
+func (cfg PaginationConfig) Parse(r *http.Request) (Pagination, error) {
+	query := r.URL.Query()
+	p := Pagination{Page: 1, PerPage: cfg.DefaultPerPage}
+	if p.PerPage <= 0 {
+		p.PerPage = DefaultPaginationConfig.DefaultPerPage
+	}
+
+	if v := query.Get("page"); v != "" {
+		page, err := strconv.Atoi(v)
+		if err != nil || page < 1 {
+			return Pagination{}, BadRequestError(errors.New("page must be a positive integer"))
+		}
+		p.Page = page
+	}
+
+	if v := query.Get("per_page"); v != "" {
+		perPage, err := strconv.Atoi(v)
+		if err != nil || perPage < 1 {
+			return Pagination{}, BadRequestError(errors.New("per_page must be a positive integer"))
+		}
+		if cfg.MaxPerPage > 0 && perPage > cfg.MaxPerPage {
+			return Pagination{}, BadRequestError(fmt.Errorf("per_page must not be greater than %d", cfg.MaxPerPage))
+		}
+		p.PerPage = perPage
+	}
+
+	if v := query.Get("sort"); v != "" {
+		for _, part := range strings.Split(v, ",") {
+			part = strings.TrimSpace(part)
+			field := SortField{Field: strings.TrimPrefix(part, "-"), Descending: strings.HasPrefix(part, "-")}
+			if !cfg.sortable(field.Field) {
+				return Pagination{}, BadRequestError(fmt.Errorf("cannot sort by %q", field.Field))
+			}
+			p.Sort = append(p.Sort, field)
+		}
+	}
+
+	return p, nil
+}
+
+// sortable reports whether field is in SortFields
+func (cfg PaginationConfig) sortable(field string) bool {
+	for _, f := range cfg.SortFields {
+		if f == field {
+			return true
+		}
+	}
+	return false
+}
+
+// Offset returns the number of items to skip
+func (p Pagination) Offset() int {
+	return (p.Page - 1) * p.PerPage
+}
+
+// Limit returns the maximum number of items on the page
+func (p Pagination) Limit() int {
+	return p.PerPage
+}
+
+// Meta builds the response metadata for a result set of total items
+func (p Pagination) Meta(total int64) PaginatedMeta {
+	totalPages := 0
+	if p.PerPage > 0 {
+		totalPages = int((total + int64(p.PerPage) - 1) / int64(p.PerPage))
+	}
+	return PaginatedMeta{
+		Total:      total,
+		Page:       p.Page,
+		PerPage:    p.PerPage,
+		TotalPages: totalPages,
+	}
+}
+
+// WritePaginated writes a success response with PaginatedMeta as its meta
+func WritePaginated(w http.ResponseWriter, data any, p Pagination, total int64) error {
+	return WriteSuccess(w, data, p.Meta(total))
+}
@@ -0,0 +1,118 @@
+package api
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestParsePagination(t *testing.T) {
+	cfg := PaginationConfig{DefaultPerPage: 25, MaxPerPage: 50, SortFields: []string{"created_at", "name"}}
+
+	tests := []struct {
+		name    string
+		query   string
+		want    Pagination
+		wantErr bool
+	}{
+		{"defaults", "", Pagination{Page: 1, PerPage: 25}, false},
+		{"explicit", "page=3&per_page=10", Pagination{Page: 3, PerPage: 10}, false},
+		{"sort", "sort=-created_at,name", Pagination{Page: 1, PerPage: 25, Sort: []SortField{
+			{Field: "created_at", Descending: true}, {Field: "name"},
+		}}, false},
+		{"page zero", "page=0", Pagination{}, true},
+		{"page not a number", "page=abc", Pagination{}, true},
+		{"per_page too large", "per_page=51", Pagination{}, true},
+		{"per_page negative", "per_page=-1", Pagination{}, true},
+		{"unknown sort field", "sort=password", Pagination{}, true},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodGet, "/orders?"+tc.query, nil)
+			got, err := cfg.Parse(req)
+			if tc.wantErr {
+				var apiErr Error
+				if !errors.As(err, &apiErr) || apiErr.StatusCode != http.StatusBadRequest {
+					t.Fatalf("Expected 400 api.Error, got %v", err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Parse() error = %v", err)
+			}
+			if got.Page != tc.want.Page || got.PerPage != tc.want.PerPage || len(got.Sort) != len(tc.want.Sort) {
+				t.Fatalf("Expected %+v, got %+v", tc.want, got)
+			}
+			for i := range got.Sort {
+				if got.Sort[i] != tc.want.Sort[i] {
+					t.Errorf("Expected sort %+v, got %+v", tc.want.Sort[i], got.Sort[i])
+				}
+			}
+		})
+	}
+}
+
+func TestParsePagination_DefaultConfig(t *testing.T) {
+	p, err := ParsePagination(httptest.NewRequest(http.MethodGet, "/orders", nil))
+	if err != nil {
+		t.Fatalf("ParsePagination() error = %v", err)
+	}
+	if p.Page != 1 || p.PerPage != 20 {
+		t.Errorf("Expected page 1 of 20, got %+v", p)
+	}
+
+	if _, err := ParsePagination(httptest.NewRequest(http.MethodGet, "/orders?sort=name", nil)); err == nil {
+		t.Error("Expected sorting to be rejected without SortFields")
+	}
+}
+
+func TestPagination_Meta(t *testing.T) {
+	tests := []struct {
+		total     int64
+		wantPages int
+	}{
+		{0, 0},
+		{1, 1},
+		{20, 1},
+		{21, 2},
+		{95, 5},
+	}
+
+	for _, tc := range tests {
+		p := Pagination{Page: 2, PerPage: 20}
+		meta := p.Meta(tc.total)
+		if meta.TotalPages != tc.wantPages {
+			t.Errorf("Expected %d pages for %d items, got %d", tc.wantPages, tc.total, meta.TotalPages)
+		}
+		if p.Offset() != 20 || p.Limit() != 20 {
+			t.Errorf("Expected offset 20 limit 20, got %d %d", p.Offset(), p.Limit())
+		}
+	}
+}
+
+func TestWritePaginated(t *testing.T) {
+	rr := httptest.NewRecorder()
+	err := WritePaginated(rr, []string{"a", "b"}, Pagination{Page: 1, PerPage: 2}, 5)
+	if err != nil {
+		t.Fatalf("WritePaginated() error = %v", err)
+	}
+
+	var resp struct {
+		Data []string      `json:"data"`
+		Meta PaginatedMeta `json:"meta"`
+	}
+	if err := json.Unmarshal(rr.Body.Bytes(), &resp); err != nil {
+		t.Fatalf("Failed to unmarshal response: %v", err)
+	}
+
+	want := PaginatedMeta{Total: 5, Page: 1, PerPage: 2, TotalPages: 3}
+	if resp.Meta != want {
+		t.Errorf("Expected meta %+v, got %+v", want, resp.Meta)
+	}
+	if len(resp.Data) != 2 {
+		t.Errorf("Expected 2 items, got %d", len(resp.Data))
+	}
+}
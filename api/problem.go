@@ -0,0 +1,166 @@
+package api
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sync/atomic"
+)
+
+// ProblemContentType is the media type for RFC 7807 problem details
+const ProblemContentType = "application/problem+json"
+
+// ErrorFormat selects how WriteError serializes errors
+type ErrorFormat int32
+
+const (
+	// FormatEnvelope writes errors as {"error": {"status_code": ..., "message": ...}}
+	FormatEnvelope ErrorFormat = iota
+	// FormatProblem writes errors as RFC 7807 application/problem+json
+	FormatProblem
+)
+
+// errorFormat holds the package-wide ErrorFormat
+var errorFormat atomic.Int32
+
+// SetErrorFormat sets how WriteError serializes errors that are not already
+// a Problem. The default is FormatEnvelope.
+func SetErrorFormat(format ErrorFormat) {
+	errorFormat.Store(int32(format))
+}
+
+// CurrentErrorFormat returns the package-wide ErrorFormat
+func CurrentErrorFormat() ErrorFormat {
+	return ErrorFormat(errorFormat.Load())
+}
+
+// Problem is an RFC 7807 problem details object.
+// Returning a Problem from a handler always produces application/problem+json,
+// regardless of the package-wide ErrorFormat.
+type Problem struct {
+	Type     string // URI identifying the problem type; "about:blank" if empty
+	Title    string // Short summary; defaults to the status text
+	Status   int    // HTTP status code
+	Detail   string // Explanation specific to this occurrence
+	Instance string // URI identifying this occurrence; defaults to the request path
+
+	// Extensions are additional members serialized alongside the standard ones
+	Extensions map[string]any
+}
+
+// NewProblem creates a Problem with the given status and detail
+func NewProblem(status int, detail string) Problem {
+	return Problem{
+		Status: status,
+		Detail: detail,
+	}
+}
+
+// Error implements the error interface
+func (p Problem) Error() string {
+	return fmt.Sprintf("API Error %d: %s", p.Status, p.Detail)
+}
+
+// With returns a copy of p with an extension member added
+func (p Problem) With(key string, value any) Problem {
+	ext := make(map[string]any, len(p.Extensions)+1)
+	for k, v := range p.Extensions {
+		ext[k] = v
+	}
+	ext[key] = value
+	p.Extensions = ext
+	return p
+}
+
+// MarshalJSON flattens extensions into the problem object
+func (p Problem) MarshalJSON() ([]byte, error) {
+	m := make(map[string]any, len(p.Extensions)+5)
+	for k, v := range p.Extensions {
+		m[k] = v
+	}
+
+	m["type"] = p.Type
+	if p.Type == "" {
+		m["type"] = "about:blank"
+	}
+	m["title"] = p.Title
+	if p.Title == "" {
+		m["title"] = http.StatusText(p.Status)
+	}
+	m["status"] = p.Status
+	if p.Detail != "" {
+		m["detail"] = p.Detail
+	}
+	if p.Instance != "" {
+		m["instance"] = p.Instance
+	}
+
+	return json.Marshal(m)
+}
+
+// UnmarshalJSON reads standard members and collects the rest as extensions
+func (p *Problem) UnmarshalJSON(data []byte) error {
+	var raw map[string]json.RawMessage
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return err
+	}
+
+	*p = Problem{}
+	fields := map[string]any{
+		"type":     &p.Type,
+		"title":    &p.Title,
+		"status":   &p.Status,
+		"detail":   &p.Detail,
+		"instance": &p.Instance,
+	}
+	for k, v := range raw {
+		if dst, ok := fields[k]; ok {
+			if err := json.Unmarshal(v, dst); err != nil {
+				return fmt.Errorf("problem member %q: %w", k, err)
+			}
+			continue
+		}
+		var ext any
+		if err := json.Unmarshal(v, &ext); err != nil {
+			return err
+		}
+		if p.Extensions == nil {
+			p.Extensions = make(map[string]any)
+		}
+		p.Extensions[k] = ext
+	}
+	return nil
+}
+
+// ToProblem converts an error to a Problem. api.Error values keep their
+// status and message, with their code and details as extension members;
+// validate.ValidationError values become a 422 with the field errors as
+// details, and other errors become a 500.
+func ToProblem(err error) Problem {
+	if e, ok := asValidation(err); ok {
+		err = e
+	}
+	switch e := err.(type) {
+	case Problem:
+		return e
+	case Error:
+		p := NewProblem(e.StatusCode, e.Message)
+		if e.Code != "" {
+			p = p.With("code", e.Code)
+		}
+		if e.Details != nil {
+			p = p.With("details", e.Details)
+		}
+		return p
+	default:
+		return NewProblem(http.StatusInternalServerError, err.Error())
+	}
+}
+
+// WriteProblem writes p as application/problem+json
+func WriteProblem(w http.ResponseWriter, p Problem) error {
+	if p.Status == 0 {
+		p.Status = http.StatusInternalServerError
+	}
+	return writeJSON(w, p.Status, p, nil, ProblemContentType)
+}
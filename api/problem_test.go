@@ -0,0 +1,123 @@
+package api
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestProblem_MarshalJSON(t *testing.T) {
+	p := NewProblem(http.StatusConflict, "order 42 was already shipped").With("order_id", 42)
+
+	data, err := json.Marshal(p)
+	if err != nil {
+		t.Fatalf("Marshal() error = %v", err)
+	}
+
+	var got map[string]any
+	json.Unmarshal(data, &got)
+
+	want := map[string]any{
+		"type":     "about:blank",
+		"title":    "Conflict",
+		"status":   float64(409),
+		"detail":   "order 42 was already shipped",
+		"order_id": float64(42),
+	}
+	for k, v := range want {
+		if got[k] != v {
+			t.Errorf("Expected %s to be %v, got %v", k, v, got[k])
+		}
+	}
+	if _, ok := got["instance"]; ok {
+		t.Error("Expected empty instance to be omitted")
+	}
+
+	var decoded Problem
+	if err := json.Unmarshal(data, &decoded); err != nil {
+		t.Fatalf("Unmarshal() error = %v", err)
+	}
+	if decoded.Status != 409 || decoded.Detail != p.Detail || decoded.Extensions["order_id"] != float64(42) {
+		t.Errorf("Unexpected round trip %+v", decoded)
+	}
+}
+
+func TestProblem_WithDoesNotMutate(t *testing.T) {
+	base := NewProblem(http.StatusBadRequest, "invalid")
+	_ = base.With("field", "email")
+	if base.Extensions != nil {
+		t.Error("Expected With to return a copy")
+	}
+}
+
+func TestToProblem(t *testing.T) {
+	tests := []struct {
+		name       string
+		err        error
+		wantStatus int
+		wantDetail string
+	}{
+		{"api error", NotFoundError(errors.New("user not found")), http.StatusNotFound, "user not found"},
+		{"plain error", errors.New("boom"), http.StatusInternalServerError, "boom"},
+		{"problem", NewProblem(http.StatusTeapot, "short and stout"), http.StatusTeapot, "short and stout"},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			p := ToProblem(tc.err)
+			if p.Status != tc.wantStatus || p.Detail != tc.wantDetail {
+				t.Errorf("Expected %d %q, got %d %q", tc.wantStatus, tc.wantDetail, p.Status, p.Detail)
+			}
+		})
+	}
+}
+
+func TestToProblem_Code(t *testing.T) {
+	p := ToProblem(NewCodedError(http.StatusConflict, "INVENTORY_OUT_OF_STOCK", errors.New("not enough stock"), []string{"sku-1"}))
+
+	if p.Extensions["code"] != "INVENTORY_OUT_OF_STOCK" {
+		t.Errorf("Expected code extension, got %v", p.Extensions)
+	}
+	if _, ok := p.Extensions["details"]; !ok {
+		t.Errorf("Expected details extension, got %v", p.Extensions)
+	}
+}
+
+func TestWriteError_Problem(t *testing.T) {
+	t.Run("problem error in envelope mode", func(t *testing.T) {
+		rr := httptest.NewRecorder()
+		WriteError(rr, NewProblem(http.StatusPaymentRequired, "card declined"))
+
+		if rr.Code != http.StatusPaymentRequired {
+			t.Errorf("Expected status 402, got %d", rr.Code)
+		}
+		if rr.Header().Get("Content-Type") != ProblemContentType {
+			t.Errorf("Expected Content-Type %s, got %s", ProblemContentType, rr.Header().Get("Content-Type"))
+		}
+	})
+
+	t.Run("problem format", func(t *testing.T) {
+		SetErrorFormat(FormatProblem)
+		defer SetErrorFormat(FormatEnvelope)
+
+		handler := WrapHandler(func(w http.ResponseWriter, r *http.Request) error {
+			return BadRequestError(errors.New("sku is required"))
+		})
+		rr := httptest.NewRecorder()
+		handler(rr, httptest.NewRequest(http.MethodPost, "/orders", nil))
+
+		if rr.Header().Get("Content-Type") != ProblemContentType {
+			t.Errorf("Expected Content-Type %s, got %s", ProblemContentType, rr.Header().Get("Content-Type"))
+		}
+
+		var p Problem
+		if err := json.Unmarshal(rr.Body.Bytes(), &p); err != nil {
+			t.Fatalf("Failed to unmarshal problem: %v", err)
+		}
+		if p.Status != http.StatusBadRequest || p.Title != "Bad Request" || p.Detail != "sku is required" || p.Instance != "/orders" {
+			t.Errorf("Unexpected problem %+v", p)
+		}
+	})
+}
@@ -1,12 +1,12 @@
 package api
 
 import (
-	"encoding/json"
+	"context"
 	"fmt"
 	"net/http"
+	"time"
 
-	"github.com/StairSupplies/go-core/logger"
-	"go.uber.org/zap"
+	"github.com/StairSupplies/go-core/ctxutils"
 )
 
 // Envelope is a map for wrapping JSON responses in a consistent structure.
@@ -16,12 +16,17 @@ type Envelope map[string]any
 // Error represents an API error response with status code and message.
 // It implements the error interface for seamless integration with Go's error handling.
 type Error struct {
-	StatusCode int    `json:"status_code"` // HTTP status code
-	Message    string `json:"message"`     // Human-readable error message
+	StatusCode int    `json:"status_code"`       // HTTP status code
+	Code       string `json:"code,omitempty"`    // Optional stable, machine-readable error code
+	Message    string `json:"message"`           // Human-readable error message
+	Details    any    `json:"details,omitempty"` // Optional structured details, e.g. field errors
 }
 
 // Error implements the error interface, returning a formatted error message.
 func (e Error) Error() string {
+	if e.Code != "" {
+		return fmt.Sprintf("API Error %d (%s): %s", e.StatusCode, e.Code, e.Message)
+	}
 	return fmt.Sprintf("API Error %d: %s", e.StatusCode, e.Message)
 }
 
@@ -34,6 +39,30 @@ func NewError(statusCode int, err error) Error {
 	}
 }
 
+// NewCodedError creates an API error with a machine-readable code, such as
+// "INVENTORY_OUT_OF_STOCK", that clients can rely on instead of the message.
+// Details may be nil.
+func NewCodedError(statusCode int, code string, err error, details any) Error {
+	e := NewError(statusCode, err)
+	e.Code = code
+	e.Details = details
+	return e
+}
+
+// WithCode returns a copy of the error with a machine-readable code attached
+func (e Error) WithCode(code string) Error {
+	e.Code = code
+	return e
+}
+
+// WithDetails returns a copy of the error with structured details attached.
+// Details are serialized alongside the message, and as a "details" member in
+// problem+json responses.
+func (e Error) WithDetails(details any) Error {
+	e.Details = details
+	return e
+}
+
 // ServerError returns a 500 Internal Server Error.
 // Use this for unexpected errors that are not the client's fault.
 func ServerError(err error) Error {
@@ -76,13 +105,25 @@ type SuccessResponse struct {
 	StatusCode int         `json:"status_code"`    // HTTP status code
 	Data       interface{} `json:"data"`           // Response payload
 	Meta       interface{} `json:"meta,omitempty"` // Optional metadata (pagination, counts, etc.)
+
+	// RequestID and Timestamp are only set by WriteSuccessCtx
+	RequestID string     `json:"request_id,omitempty"` // ID of the request, matching its log entries
+	Timestamp *time.Time `json:"timestamp,omitempty"`  // When the response was written, in UTC
 }
 
 // WriteJSON writes a JSON response with the given status and data.
 // It handles JSON serialization, content-type headers, and status code setting.
 // Additional headers can be provided to be included in the response.
+// The JSON is indented unless SetCompactJSON is set; use WriteJSONStream
+// for large payloads.
 func WriteJSON(w http.ResponseWriter, status int, data any, headers http.Header) error {
-	js, err := json.MarshalIndent(data, "", "  ")
+	return writeJSON(w, status, data, headers, "application/json")
+}
+
+// writeJSON writes data as JSON with the given content type, indented unless
+// SetCompactJSON is set
+func writeJSON(w http.ResponseWriter, status int, data any, headers http.Header, contentType string) error {
+	js, err := marshalJSON(data)
 	if err != nil {
 		return err
 	}
@@ -93,7 +134,7 @@ func WriteJSON(w http.ResponseWriter, status int, data any, headers http.Header)
 		w.Header()[key] = value
 	}
 
-	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("Content-Type", contentType)
 	w.WriteHeader(status)
 	w.Write(js)
 
@@ -104,24 +145,86 @@ func WriteJSON(w http.ResponseWriter, status int, data any, headers http.Header)
 // It wraps the provided data in a SuccessResponse structure.
 // Optional metadata can be provided as the final parameter.
 func WriteSuccess(w http.ResponseWriter, data any, meta ...any) error {
-	var metaData interface{}
-	if len(meta) > 0 {
-		metaData = meta[0]
+	return writeSuccess(w, http.StatusOK, nil, data, meta)
+}
+
+// WriteSuccessCtx is like WriteSuccess but also includes the request ID from
+// ctx and the current time, so a response can be matched to its logs.
+// The request ID is read with ctxutils.RequestID and omitted if not set.
+func WriteSuccessCtx(ctx context.Context, w http.ResponseWriter, data any, meta ...any) error {
+	return writeSuccess(w, http.StatusOK, nil, data, meta, func(resp *SuccessResponse) {
+		now := time.Now().UTC()
+		resp.RequestID = ctxutils.RequestID(ctx)
+		resp.Timestamp = &now
+	})
+}
+
+// WriteCreated writes a success response with status 201. If location is
+// not empty it is sent as the Location header, typically the URL of the new
+// resource. Optional metadata can be provided as the final parameter.
+func WriteCreated(w http.ResponseWriter, location string, data any, meta ...any) error {
+	var headers http.Header
+	if location != "" {
+		headers = http.Header{"Location": []string{location}}
 	}
+	return writeSuccess(w, http.StatusCreated, headers, data, meta)
+}
+
+// WriteAccepted writes a success response with status 202, for requests
+// that will be completed later. data typically describes how to follow the
+// work, such as a job ID. Optional metadata can be provided as the final parameter.
+func WriteAccepted(w http.ResponseWriter, data any, meta ...any) error {
+	return writeSuccess(w, http.StatusAccepted, nil, data, meta)
+}
 
+// WriteNoContent writes status 204 with no body. Any Content-Type set
+// earlier is removed, since the response has no content.
+func WriteNoContent(w http.ResponseWriter) error {
+	w.Header().Del("Content-Type")
+	w.WriteHeader(http.StatusNoContent)
+	return nil
+}
+
+// writeSuccess writes data and optional metadata in a SuccessResponse with
+// the given status. Each of decorate can set further fields before the
+// response is written.
+func writeSuccess(w http.ResponseWriter, status int, headers http.Header, data any, meta []any, decorate ...func(*SuccessResponse)) error {
 	resp := SuccessResponse{
-		StatusCode: http.StatusOK,
+		StatusCode: status,
 		Data:       data,
-		Meta:       metaData,
 	}
-
-	return WriteJSON(w, http.StatusOK, resp, nil)
+	if len(meta) > 0 {
+		resp.Meta = meta[0]
+	}
+	for _, fn := range decorate {
+		fn(&resp)
+	}
+	return WriteJSON(w, status, resp, headers)
 }
 
 // WriteError writes an error response.
 // It handles both api.Error instances and standard Go errors.
-// Standard errors are converted to 500 Internal Server Error responses.
+// A validate.ValidationError is written as a 422 with the field errors as
+// details, like FromValidation; other standard errors are converted to 500
+// Internal Server Error responses.
+// Problem values, or any error when the package uses FormatProblem, are
+// written as application/problem+json.
 func WriteError(w http.ResponseWriter, err error) {
+	writeError(w, err, "")
+}
+
+// writeError writes err in the configured format, using instance as the
+// problem instance when none is set
+func writeError(w http.ResponseWriter, err error, instance string) {
+	if _, ok := err.(Problem); ok || CurrentErrorFormat() == FormatProblem {
+		p := ToProblem(err)
+		if p.Instance == "" {
+			p.Instance = instance
+		}
+		WriteProblem(w, p)
+		return
+	}
+
 	var apiErr Error
 	var statusCode int
 
@@ -129,6 +232,9 @@ func WriteError(w http.ResponseWriter, err error) {
 	if e, ok := err.(Error); ok {
 		apiErr = e
 		statusCode = e.StatusCode
+	} else if e, ok := asValidation(err); ok {
+		apiErr = e
+		statusCode = e.StatusCode
 	} else {
 		// Default to internal server error
 		apiErr = ServerError(err)
@@ -145,20 +251,11 @@ type HandlerFunc func(w http.ResponseWriter, r *http.Request) error
 // WrapHandler wraps an API function with error handling.
 // It automatically handles logging errors and writing error responses.
 // This allows controller functions to focus on business logic and simply return errors.
+// Errors are passed to HandleError, so a custom ErrorHandler can change how they are reported.
 func WrapHandler(h HandlerFunc) http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
 		if err := h(w, r); err != nil {
-			// Get logger from context or use default
-			log := logger.WithContext(r.Context())
-
-			// Log the error
-			log.Error("HTTP API Error",
-				zap.String("path", r.URL.Path),
-				zap.String("err", err.Error()),
-			)
-
-			// Write the error response
-			WriteError(w, err)
+			HandleError(w, r, err)
 		}
 	}
 }
@@ -1,6 +1,7 @@
 package api
 
 import (
+	"context"
 	"encoding/json"
 	"errors"
 	"fmt"
@@ -8,7 +9,12 @@ import (
 	"net/http"
 	"net/http/httptest"
 	"reflect"
+	"strings"
 	"testing"
+	"time"
+
+	"github.com/StairSupplies/go-core/ctxutils"
+	"github.com/go-chi/chi/v5/middleware"
 )
 
 func TestError_Error(t *testing.T) {
@@ -41,6 +47,15 @@ func TestError_Error(t *testing.T) {
 			},
 			expected: "API Error 500: internal server error",
 		},
+		{
+			name: "coded error",
+			apiErr: Error{
+				StatusCode: http.StatusConflict,
+				Code:       "INVENTORY_OUT_OF_STOCK",
+				Message:    "not enough stock",
+			},
+			expected: "API Error 409 (INVENTORY_OUT_OF_STOCK): not enough stock",
+		},
 	}
 
 	for _, tc := range tests {
@@ -97,6 +112,37 @@ func TestNewError(t *testing.T) {
 	}
 }
 
+func TestNewCodedError(t *testing.T) {
+	details := map[string]int{"available": 2}
+	err := NewCodedError(http.StatusConflict, "INVENTORY_OUT_OF_STOCK", errors.New("not enough stock"), details)
+
+	if err.StatusCode != http.StatusConflict || err.Code != "INVENTORY_OUT_OF_STOCK" || err.Message != "not enough stock" {
+		t.Errorf("Unexpected error %+v", err)
+	}
+
+	rr := httptest.NewRecorder()
+	WriteError(rr, err)
+
+	want := `{"error":{"status_code":409,"code":"INVENTORY_OUT_OF_STOCK","message":"not enough stock","details":{"available":2}}}`
+	var got, expected any
+	json.Unmarshal(rr.Body.Bytes(), &got)
+	json.Unmarshal([]byte(want), &expected)
+	if !reflect.DeepEqual(got, expected) {
+		t.Errorf("Expected body %s, got %s", want, rr.Body.String())
+	}
+
+	plain := NotFoundError(errors.New("user not found"))
+	rr = httptest.NewRecorder()
+	WriteError(rr, plain)
+	if strings.Contains(rr.Body.String(), `"code"`) || strings.Contains(rr.Body.String(), `"details"`) {
+		t.Errorf("Expected code and details to be omitted, got %s", rr.Body.String())
+	}
+
+	if coded := plain.WithCode("USER_NOT_FOUND"); coded.Code != "USER_NOT_FOUND" || plain.Code != "" {
+		t.Errorf("Expected WithCode to return a copy, got %+v and %+v", coded, plain)
+	}
+}
+
 func TestErrorFactories(t *testing.T) {
 	err := errors.New("test error")
 
@@ -346,6 +392,134 @@ func TestWriteSuccess(t *testing.T) {
 	}
 }
 
+func TestWriteSuccess_OmitsTraceFields(t *testing.T) {
+	rr := httptest.NewRecorder()
+	WriteSuccess(rr, map[string]string{"key": "value"})
+
+	body := rr.Body.String()
+	if strings.Contains(body, "request_id") || strings.Contains(body, "timestamp") {
+		t.Errorf("Expected WriteSuccess to omit request_id and timestamp, got %s", body)
+	}
+}
+
+func TestWriteStatusHelpers(t *testing.T) {
+	tests := []struct {
+		name     string
+		write    func(w http.ResponseWriter) error
+		status   int
+		location string
+	}{
+		{
+			name: "created with location",
+			write: func(w http.ResponseWriter) error {
+				return WriteCreated(w, "/orders/42", map[string]int{"id": 42}, map[string]int{"count": 1})
+			},
+			status:   http.StatusCreated,
+			location: "/orders/42",
+		},
+		{
+			name: "created without location",
+			write: func(w http.ResponseWriter) error {
+				return WriteCreated(w, "", map[string]int{"id": 42})
+			},
+			status: http.StatusCreated,
+		},
+		{
+			name: "accepted",
+			write: func(w http.ResponseWriter) error {
+				return WriteAccepted(w, map[string]string{"job_id": "j-1"})
+			},
+			status: http.StatusAccepted,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			rr := httptest.NewRecorder()
+			if err := tc.write(rr); err != nil {
+				t.Fatalf("Expected no error, got %v", err)
+			}
+
+			if rr.Code != tc.status {
+				t.Errorf("Expected status code %d, got %d", tc.status, rr.Code)
+			}
+			if got := rr.Header().Get("Location"); got != tc.location {
+				t.Errorf("Expected Location %q, got %q", tc.location, got)
+			}
+
+			var resp SuccessResponse
+			if err := json.Unmarshal(rr.Body.Bytes(), &resp); err != nil {
+				t.Fatalf("Failed to unmarshal response: %v", err)
+			}
+			if resp.StatusCode != tc.status {
+				t.Errorf("Expected envelope status_code %d, got %d", tc.status, resp.StatusCode)
+			}
+			if resp.Data == nil {
+				t.Error("Expected data in the envelope")
+			}
+		})
+	}
+}
+
+func TestWriteNoContent(t *testing.T) {
+	rr := httptest.NewRecorder()
+	rr.Header().Set("Content-Type", "application/json")
+
+	if err := WriteNoContent(rr); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if rr.Code != http.StatusNoContent {
+		t.Errorf("Expected status code %d, got %d", http.StatusNoContent, rr.Code)
+	}
+	if rr.Body.Len() != 0 {
+		t.Errorf("Expected empty body, got %q", rr.Body.String())
+	}
+	if got := rr.Header().Get("Content-Type"); got != "" {
+		t.Errorf("Expected no Content-Type, got %q", got)
+	}
+}
+
+func TestWriteSuccessCtx(t *testing.T) {
+	tests := []struct {
+		name          string
+		ctx           context.Context
+		wantRequestID string
+	}{
+		{"chi request ID", context.WithValue(context.Background(), middleware.RequestIDKey, "chi-123"), "chi-123"},
+		{"ctxutils request ID", ctxutils.WithRequestID(context.Background(), "req-456"), "req-456"},
+		{"no request ID", context.Background(), ""},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			rr := httptest.NewRecorder()
+			before := time.Now().UTC().Add(-time.Second)
+
+			if err := WriteSuccessCtx(tc.ctx, rr, map[string]string{"key": "value"}, map[string]int{"count": 1}); err != nil {
+				t.Fatalf("WriteSuccessCtx returned an error: %v", err)
+			}
+
+			var response SuccessResponse
+			if err := json.Unmarshal(rr.Body.Bytes(), &response); err != nil {
+				t.Fatalf("Failed to unmarshal response: %v", err)
+			}
+
+			if response.RequestID != tc.wantRequestID {
+				t.Errorf("Expected request_id %q, got %q", tc.wantRequestID, response.RequestID)
+			}
+			if response.Timestamp == nil || response.Timestamp.Before(before) {
+				t.Errorf("Expected a current timestamp, got %v", response.Timestamp)
+			}
+			if response.Meta == nil {
+				t.Error("Expected meta to be set")
+			}
+			if tc.wantRequestID == "" && strings.Contains(rr.Body.String(), "request_id") {
+				t.Errorf("Expected request_id to be omitted, got %s", rr.Body.String())
+			}
+		})
+	}
+}
+
 func TestWriteError(t *testing.T) {
 	tests := []struct {
 		name           string
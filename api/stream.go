@@ -0,0 +1,225 @@
+package api
+
+import (
+	"bufio"
+	"bytes"
+	"compress/gzip"
+	"encoding"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"reflect"
+	"strconv"
+	"strings"
+	"sync/atomic"
+)
+
+// DefaultStreamChunkSize is how much WriteJSONStream buffers before it
+// writes and flushes a chunk to the client
+const DefaultStreamChunkSize = 32 << 10
+
+// compactJSON holds the package-wide compact JSON setting
+var compactJSON atomic.Bool
+
+// SetCompactJSON makes WriteJSON, the WriteSuccess family, WriteProblem and
+// Write produce compact JSON instead of indenting it, which saves bytes and
+// CPU for large responses in production. The default is indented JSON.
+func SetCompactJSON(compact bool) {
+	compactJSON.Store(compact)
+}
+
+// CompactJSON reports whether JSON responses are written without indentation
+func CompactJSON() bool {
+	return compactJSON.Load()
+}
+
+// marshalJSON encodes data as compact or indented JSON, following SetCompactJSON
+func marshalJSON(data any) ([]byte, error) {
+	if CompactJSON() {
+		return json.Marshal(data)
+	}
+	return json.MarshalIndent(data, "", "  ")
+}
+
+// WriteJSONStream writes data as compact JSON directly to w, for large
+// payloads such as exports. Slices and arrays are encoded one element at a
+// time, so the full encoding is never held in memory, and the body is
+// flushed to the client every DefaultStreamChunkSize bytes. No
+// Content-Length is set, so the response is sent chunked and can be
+// compressed by middleware such as chi's middleware.Compress, or by
+// WriteJSONStreamGzip.
+//
+// An error encoding a non-slice value or the first element is returned
+// before anything is written, like WriteJSON. An error encoding a later
+// element is returned after part of the body has been sent; the status can
+// no longer change, so log it instead of returning it from a HandlerFunc.
+func WriteJSONStream(w http.ResponseWriter, status int, data any, headers http.Header) error {
+	return writeJSONStream(w, status, data, headers, false)
+}
+
+// WriteJSONStreamGzip is WriteJSONStream with the body gzipped when r
+// accepts it, for handlers not behind a compression middleware. It sets
+// Content-Encoding and Vary, and flushes the compressed output with every
+// chunk so the client still receives the export as it is encoded.
+func WriteJSONStreamGzip(w http.ResponseWriter, r *http.Request, status int, data any, headers http.Header) error {
+	w.Header().Add("Vary", "Accept-Encoding")
+	compress := acceptsGzip(r) && w.Header().Get("Content-Encoding") == ""
+	return writeJSONStream(w, status, data, headers, compress)
+}
+
+// writeJSONStream implements WriteJSONStream, gzipping the body if compress
+// is set
+func writeJSONStream(w http.ResponseWriter, status int, data any, headers http.Header, compress bool) error {
+	// Values are encoded into buf and copied without the newline Encode
+	// adds, so an element that fails to encode writes nothing
+	var buf bytes.Buffer
+	enc := json.NewEncoder(&buf)
+	encode := func(v any) ([]byte, error) {
+		buf.Reset()
+		if err := enc.Encode(v); err != nil {
+			return nil, err
+		}
+		return bytes.TrimSuffix(buf.Bytes(), []byte{'\n'}), nil
+	}
+
+	rv := reflect.ValueOf(data)
+	if !streamable(rv) {
+		js, err := encode(data)
+		if err != nil {
+			return err
+		}
+		stream := writeStreamHeader(w, status, headers, compress)
+		stream.Write(js)
+		stream.WriteByte('\n')
+		return stream.Close()
+	}
+
+	var first []byte
+	if rv.Len() > 0 {
+		js, err := encode(rv.Index(0).Interface())
+		if err != nil {
+			return err
+		}
+		first = append([]byte(nil), js...)
+	}
+
+	stream := writeStreamHeader(w, status, headers, compress)
+	stream.WriteByte('[')
+	stream.Write(first)
+	for i := 1; i < rv.Len(); i++ {
+		js, err := encode(rv.Index(i).Interface())
+		if err != nil {
+			stream.Close()
+			return err
+		}
+		stream.WriteByte(',')
+		if _, err := stream.Write(js); err != nil {
+			return err
+		}
+	}
+	stream.WriteString("]\n")
+	return stream.Close()
+}
+
+// acceptsGzip reports whether r's Accept-Encoding allows a gzip response
+func acceptsGzip(r *http.Request) bool {
+	for _, part := range strings.Split(r.Header.Get("Accept-Encoding"), ",") {
+		coding, params, _ := strings.Cut(part, ";")
+		if !strings.EqualFold(strings.TrimSpace(coding), "gzip") {
+			continue
+		}
+		q, ok := strings.CutPrefix(strings.TrimSpace(params), "q=")
+		if !ok {
+			return true
+		}
+		weight, err := strconv.ParseFloat(q, 64)
+		return err == nil && weight > 0
+	}
+	return false
+}
+
+// streamable reports whether v is a slice or array WriteJSONStream encodes
+// element by element. Byte slices, nil slices and types with their own
+// encoding are encoded as a whole, as json.Marshal would.
+func streamable(v reflect.Value) bool {
+	switch v.Kind() {
+	case reflect.Slice:
+		if v.IsNil() || v.Type().Elem().Kind() == reflect.Uint8 {
+			return false
+		}
+	case reflect.Array:
+	default:
+		return false
+	}
+	switch v.Interface().(type) {
+	case json.Marshaler, encoding.TextMarshaler:
+		return false
+	}
+	return true
+}
+
+// jsonStream buffers a streamed JSON body into chunks; Close writes the
+// last chunk and ends the gzip stream, if any
+type jsonStream struct {
+	*bufio.Writer
+	gz *gzip.Writer
+}
+
+func (s *jsonStream) Close() error {
+	err := s.Flush()
+	if s.gz != nil {
+		if closeErr := s.gz.Close(); err == nil {
+			err = closeErr
+		}
+	}
+	return err
+}
+
+// writeStreamHeader writes the status and headers of a streamed JSON
+// response and returns a stream that flushes each chunk to w, gzipped if
+// compress is set
+func writeStreamHeader(w http.ResponseWriter, status int, headers http.Header, compress bool) *jsonStream {
+	for key, value := range headers {
+		w.Header()[key] = value
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Del("Content-Length")
+	if compress {
+		w.Header().Set("Content-Encoding", "gzip")
+	}
+	w.WriteHeader(status)
+
+	fw := &flushWriter{w: w, rc: http.NewResponseController(w)}
+	stream := &jsonStream{}
+	if compress {
+		stream.gz = gzip.NewWriter(w)
+		fw.w, fw.gz = stream.gz, stream.gz
+	}
+	stream.Writer = bufio.NewWriterSize(fw, DefaultStreamChunkSize)
+	return stream
+}
+
+// flushWriter flushes w, and the gzip writer in front of it if any, to the
+// client after every write
+type flushWriter struct {
+	w  io.Writer
+	gz *gzip.Writer
+	rc *http.ResponseController
+}
+
+func (f *flushWriter) Write(p []byte) (int, error) {
+	n, err := f.w.Write(p)
+	if err != nil {
+		return n, err
+	}
+	if f.gz != nil {
+		if err := f.gz.Flush(); err != nil {
+			return n, err
+		}
+	}
+	if err := f.rc.Flush(); err != nil && !errors.Is(err, http.ErrNotSupported) {
+		return n, err
+	}
+	return n, nil
+}
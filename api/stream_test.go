@@ -0,0 +1,209 @@
+package api
+
+import (
+	"compress/gzip"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+// failAt is a value whose JSON encoding fails when fail is set
+type failAt struct {
+	fail bool
+}
+
+func (f failAt) MarshalJSON() ([]byte, error) {
+	if f.fail {
+		return nil, errors.New("cannot encode")
+	}
+	return []byte(`"ok"`), nil
+}
+
+func TestWriteJSONStream(t *testing.T) {
+	tests := []struct {
+		name string
+		data any
+	}{
+		{"slice of structs", []struct {
+			ID   int    `json:"id"`
+			Name string `json:"name"`
+		}{{1, "a"}, {2, "<b>"}}},
+		{"empty slice", []int{}},
+		{"nil slice", []int(nil)},
+		{"array", [3]string{"x", "y", "z"}},
+		{"byte slice", []byte("raw")},
+		{"byte array", [2]byte{1, 2}},
+		{"map", map[string]int{"a": 1}},
+		{"struct", struct{ At time.Time }{time.Date(2024, 3, 4, 0, 0, 0, 0, time.UTC)}},
+		{"raw message", json.RawMessage(`[1,2]`)},
+		{"nil", nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			if err := WriteJSONStream(w, http.StatusCreated, tt.data, nil); err != nil {
+				t.Fatalf("WriteJSONStream() error = %v", err)
+			}
+
+			want, _ := json.Marshal(tt.data)
+			if got := w.Body.String(); got != string(want)+"\n" {
+				t.Errorf("Expected body %s, got %s", want, got)
+			}
+			if w.Code != http.StatusCreated {
+				t.Errorf("Expected status %d, got %d", http.StatusCreated, w.Code)
+			}
+		})
+	}
+}
+
+func TestWriteJSONStream_Headers(t *testing.T) {
+	w := httptest.NewRecorder()
+	w.Header().Set("Content-Length", "10")
+	headers := http.Header{"X-Export": []string{"products"}}
+
+	if err := WriteJSONStream(w, http.StatusOK, []int{1}, headers); err != nil {
+		t.Fatalf("WriteJSONStream() error = %v", err)
+	}
+	if got := w.Header().Get("Content-Type"); got != "application/json" {
+		t.Errorf("Expected Content-Type application/json, got %s", got)
+	}
+	if got := w.Header().Get("X-Export"); got != "products" {
+		t.Errorf("Expected X-Export header, got %q", got)
+	}
+	if got := w.Header().Get("Content-Length"); got != "" {
+		t.Errorf("Expected no Content-Length, got %s", got)
+	}
+}
+
+func TestWriteJSONStream_Chunks(t *testing.T) {
+	items := make([]string, 0, 4096)
+	for i := 0; i < cap(items); i++ {
+		items = append(items, strings.Repeat("x", 64))
+	}
+
+	w := httptest.NewRecorder()
+	if err := WriteJSONStream(w, http.StatusOK, items, nil); err != nil {
+		t.Fatalf("WriteJSONStream() error = %v", err)
+	}
+	if !w.Flushed {
+		t.Error("Expected the response to be flushed")
+	}
+	var got []string
+	if err := json.Unmarshal(w.Body.Bytes(), &got); err != nil || len(got) != len(items) {
+		t.Errorf("Expected %d items, got %d (err %v)", len(items), len(got), err)
+	}
+}
+
+func TestWriteJSONStream_Errors(t *testing.T) {
+	t.Run("before writing", func(t *testing.T) {
+		for _, data := range []any{failAt{fail: true}, []failAt{{fail: true}, {}}, make(chan int)} {
+			w := httptest.NewRecorder()
+			if err := WriteJSONStream(w, http.StatusOK, data, nil); err == nil {
+				t.Fatalf("Expected error for %T, got nil", data)
+			}
+			if w.Body.Len() != 0 || w.Header().Get("Content-Type") != "" {
+				t.Errorf("Expected nothing written for %T, got %q", data, w.Body.String())
+			}
+		}
+	})
+
+	t.Run("after writing", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		err := WriteJSONStream(w, http.StatusOK, []failAt{{}, {}, {fail: true}}, nil)
+		if err == nil {
+			t.Fatal("Expected error, got nil")
+		}
+		if got := w.Body.String(); got != `["ok","ok"` {
+			t.Errorf("Expected truncated body, got %s", got)
+		}
+	})
+}
+
+func TestWriteJSONStreamGzip(t *testing.T) {
+	items := make([]string, 0, 2048)
+	for i := 0; i < cap(items); i++ {
+		items = append(items, strings.Repeat("x", 64))
+	}
+
+	tests := []struct {
+		name           string
+		acceptEncoding string
+		wantGzip       bool
+	}{
+		{"gzip", "gzip", true},
+		{"among others", "br, gzip;q=0.8", true},
+		{"refused", "gzip;q=0, identity", false},
+		{"none", "", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := httptest.NewRequest(http.MethodGet, "/export", nil)
+			r.Header.Set("Accept-Encoding", tt.acceptEncoding)
+			w := httptest.NewRecorder()
+			if err := WriteJSONStreamGzip(w, r, http.StatusOK, items, nil); err != nil {
+				t.Fatalf("WriteJSONStreamGzip() error = %v", err)
+			}
+
+			if got := w.Header().Get("Vary"); got != "Accept-Encoding" {
+				t.Errorf("Expected Vary: Accept-Encoding, got %q", got)
+			}
+			gotGzip := w.Header().Get("Content-Encoding") == "gzip"
+			if gotGzip != tt.wantGzip {
+				t.Fatalf("Expected gzip %v, got Content-Encoding %q", tt.wantGzip, w.Header().Get("Content-Encoding"))
+			}
+
+			var body io.Reader = w.Body
+			if gotGzip {
+				if !w.Flushed {
+					t.Error("Expected the compressed response to be flushed")
+				}
+				zr, err := gzip.NewReader(w.Body)
+				if err != nil {
+					t.Fatalf("Expected a gzip body, got error %v", err)
+				}
+				body = zr
+			}
+			var got []string
+			if err := json.NewDecoder(body).Decode(&got); err != nil || len(got) != len(items) {
+				t.Errorf("Expected %d items, got %d (err %v)", len(items), len(got), err)
+			}
+		})
+	}
+}
+
+func TestSetCompactJSON(t *testing.T) {
+	defer SetCompactJSON(false)
+
+	data := map[string]int{"a": 1}
+	SetCompactJSON(true)
+	if !CompactJSON() {
+		t.Fatal("Expected CompactJSON to be true")
+	}
+
+	w := httptest.NewRecorder()
+	WriteJSON(w, http.StatusOK, data, nil)
+	if got := w.Body.String(); got != "{\"a\":1}\n" {
+		t.Errorf("Expected compact JSON, got %q", got)
+	}
+
+	r := httptest.NewRequest(http.MethodGet, "/", nil)
+	w = httptest.NewRecorder()
+	Write(w, r, http.StatusOK, data)
+	if got := w.Body.String(); got != "{\"a\":1}\n" {
+		t.Errorf("Expected compact JSON from Write, got %q", got)
+	}
+
+	SetCompactJSON(false)
+	w = httptest.NewRecorder()
+	WriteJSON(w, http.StatusOK, data, nil)
+	if got := w.Body.String(); got != "{\n  \"a\": 1\n}\n" {
+		t.Errorf("Expected indented JSON, got %q", got)
+	}
+}
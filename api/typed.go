@@ -0,0 +1,66 @@
+package api
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+)
+
+// ErrNotSuccessResponse is returned by ParseSuccess when the body is neither
+// a SuccessResponse nor an error envelope
+var ErrNotSuccessResponse = errors.New("api: body is not a success response")
+
+// Meta is the raw meta object of a success response read by ParseSuccess
+type Meta json.RawMessage
+
+// Decode unmarshals the meta object into v. It does nothing if the response
+// had no meta.
+func (m Meta) Decode(v any) error {
+	if len(m) == 0 || string(m) == "null" {
+		return nil
+	}
+	return json.Unmarshal(m, v)
+}
+
+// WriteData is a typed form of WriteSuccess. It writes data with status 200
+// in a SuccessResponse, with optional metadata as the final parameter.
+func WriteData[T any](w http.ResponseWriter, data T, meta ...any) error {
+	return WriteSuccess(w, data, meta...)
+}
+
+// envelope is the union of the success and error response bodies
+type envelope struct {
+	Data  json.RawMessage `json:"data"`
+	Meta  json.RawMessage `json:"meta"`
+	Error *Error          `json:"error"`
+}
+
+// ParseSuccess reads a SuccessResponse from r and decodes its data into T,
+// so service-to-service callers get typed values without re-casting maps.
+// The meta object is returned undecoded; use Meta.Decode to read it.
+//
+// If r holds an error envelope the api.Error it describes is returned.
+// Unknown fields are ignored so newer servers can add members.
+func ParseSuccess[T any](r io.Reader) (T, Meta, error) {
+	var data T
+
+	var env envelope
+	if err := json.NewDecoder(r).Decode(&env); err != nil {
+		return data, nil, fmt.Errorf("api: failed to decode response: %w", err)
+	}
+
+	if env.Error != nil {
+		return data, nil, *env.Error
+	}
+	if env.Data == nil {
+		return data, nil, ErrNotSuccessResponse
+	}
+
+	if err := json.Unmarshal(env.Data, &data); err != nil {
+		return data, nil, fmt.Errorf("api: failed to decode response data: %w", err)
+	}
+
+	return data, Meta(env.Meta), nil
+}
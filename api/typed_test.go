@@ -0,0 +1,113 @@
+package api
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+type typedOrder struct {
+	ID    string `json:"id"`
+	Total int    `json:"total"`
+}
+
+func TestWriteData_ParseSuccess(t *testing.T) {
+	w := httptest.NewRecorder()
+	orders := []typedOrder{{ID: "A-1", Total: 120}, {ID: "A-2", Total: 80}}
+	if err := WriteData(w, orders, PaginatedMeta{Total: 2, Page: 1, PerPage: 25, TotalPages: 1}); err != nil {
+		t.Fatalf("WriteData() error = %v", err)
+	}
+	if w.Code != http.StatusOK {
+		t.Errorf("Expected status %d, got %d", http.StatusOK, w.Code)
+	}
+
+	got, meta, err := ParseSuccess[[]typedOrder](w.Body)
+	if err != nil {
+		t.Fatalf("ParseSuccess() error = %v", err)
+	}
+	if len(got) != 2 || got[1] != orders[1] {
+		t.Errorf("Expected %v, got %v", orders, got)
+	}
+
+	var pm PaginatedMeta
+	if err := meta.Decode(&pm); err != nil {
+		t.Fatalf("Meta.Decode() error = %v", err)
+	}
+	if pm.Total != 2 || pm.PerPage != 25 {
+		t.Errorf("Expected pagination meta, got %+v", pm)
+	}
+}
+
+func TestParseSuccess(t *testing.T) {
+	tests := []struct {
+		name    string
+		body    string
+		want    typedOrder
+		wantErr error
+		errText string
+	}{
+		{
+			name: "success",
+			body: `{"status_code":200,"data":{"id":"A-1","total":5},"request_id":"r1","new_field":true}`,
+			want: typedOrder{ID: "A-1", Total: 5},
+		},
+		{
+			name: "null data",
+			body: `{"status_code":200,"data":null}`,
+		},
+		{
+			name:    "error envelope",
+			body:    `{"error":{"status_code":404,"message":"order not found"}}`,
+			wantErr: Error{StatusCode: http.StatusNotFound, Message: "order not found"},
+		},
+		{
+			name:    "not an envelope",
+			body:    `{"id":"A-1"}`,
+			wantErr: ErrNotSuccessResponse,
+		},
+		{
+			name:    "malformed",
+			body:    `{"data":`,
+			errText: "api: failed to decode response",
+		},
+		{
+			name:    "wrong data type",
+			body:    `{"data":{"id":1}}`,
+			errText: "api: failed to decode response data",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, _, err := ParseSuccess[typedOrder](strings.NewReader(tt.body))
+
+			switch {
+			case tt.wantErr != nil:
+				if !errors.Is(err, tt.wantErr) {
+					t.Errorf("Expected error %v, got %v", tt.wantErr, err)
+				}
+			case tt.errText != "":
+				if err == nil || !strings.Contains(err.Error(), tt.errText) {
+					t.Errorf("Expected error containing %q, got %v", tt.errText, err)
+				}
+			case err != nil:
+				t.Errorf("Expected no error, got %v", err)
+			}
+
+			if got != tt.want {
+				t.Errorf("Expected %+v, got %+v", tt.want, got)
+			}
+		})
+	}
+}
+
+func TestMeta_Decode_Empty(t *testing.T) {
+	var v map[string]any
+	for _, m := range []Meta{nil, Meta("null")} {
+		if err := m.Decode(&v); err != nil || v != nil {
+			t.Errorf("Expected empty meta to leave value unset, got %v, %v", v, err)
+		}
+	}
+}
@@ -0,0 +1,33 @@
+package api
+
+import (
+	"errors"
+
+	"github.com/StairSupplies/go-core/validate"
+)
+
+// ValidationMessage is the message of errors created by FromValidation
+const ValidationMessage = "validation failed"
+
+// FromValidation converts err to an api.Error. A validate.ValidationError,
+// even when wrapped, becomes a 422 with the field errors as details; an
+// api.Error is returned as it is, and any other error becomes a 500.
+func FromValidation(err error) Error {
+	if e, ok := err.(Error); ok {
+		return e
+	}
+	var verr validate.ValidationError
+	if errors.As(err, &verr) {
+		return UnprocessableEntityError(errors.New(ValidationMessage)).WithDetails(verr)
+	}
+	return ServerError(err)
+}
+
+// asValidation returns err as a 422 api.Error if it is a validate.ValidationError
+func asValidation(err error) (Error, bool) {
+	var verr validate.ValidationError
+	if _, isAPIError := err.(Error); isAPIError || !errors.As(err, &verr) {
+		return Error{}, false
+	}
+	return FromValidation(verr), true
+}
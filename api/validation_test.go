@@ -0,0 +1,85 @@
+package api
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/StairSupplies/go-core/validate"
+)
+
+func TestFromValidation(t *testing.T) {
+	verr := validate.ValidationError{"sku": "must be provided"}
+
+	tests := []struct {
+		name       string
+		err        error
+		wantStatus int
+		wantDetail bool
+	}{
+		{"validation error", verr, http.StatusUnprocessableEntity, true},
+		{"wrapped validation error", fmt.Errorf("create order: %w", verr), http.StatusUnprocessableEntity, true},
+		{"api error", NotFoundError(errors.New("order not found")), http.StatusNotFound, false},
+		{"other error", errors.New("database down"), http.StatusInternalServerError, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := FromValidation(tt.err)
+			if got.StatusCode != tt.wantStatus {
+				t.Errorf("Expected status %d, got %d", tt.wantStatus, got.StatusCode)
+			}
+			details, ok := got.Details.(validate.ValidationError)
+			if ok != tt.wantDetail {
+				t.Fatalf("Expected details %v, got %T", tt.wantDetail, got.Details)
+			}
+			if ok && (got.Message != ValidationMessage || details["sku"] != "must be provided") {
+				t.Errorf("Unexpected validation error %+v", got)
+			}
+		})
+	}
+}
+
+func TestWriteError_ValidationError(t *testing.T) {
+	handler := WrapHandler(func(w http.ResponseWriter, r *http.Request) error {
+		v := validate.New()
+		v.Check(validate.NotBlank(""), "sku", "must be provided")
+		if !v.Valid() {
+			return v.AsValidationError()
+		}
+		return nil
+	})
+
+	w := httptest.NewRecorder()
+	handler.ServeHTTP(w, httptest.NewRequest(http.MethodPost, "/orders", nil))
+
+	if w.Code != http.StatusUnprocessableEntity {
+		t.Fatalf("Expected status %d, got %d", http.StatusUnprocessableEntity, w.Code)
+	}
+	var body struct {
+		Error struct {
+			Message string            `json:"message"`
+			Details map[string]string `json:"details"`
+		} `json:"error"`
+	}
+	if err := json.Unmarshal(w.Body.Bytes(), &body); err != nil {
+		t.Fatalf("Expected an error envelope, got %q", w.Body.String())
+	}
+	if body.Error.Message != ValidationMessage || body.Error.Details["sku"] != "must be provided" {
+		t.Errorf("Unexpected error %+v", body.Error)
+	}
+}
+
+func TestToProblem_ValidationError(t *testing.T) {
+	p := ToProblem(fmt.Errorf("create order: %w", validate.ValidationError{"sku": "must be provided"}))
+
+	if p.Status != http.StatusUnprocessableEntity || p.Detail != ValidationMessage {
+		t.Errorf("Expected 422 validation problem, got %+v", p)
+	}
+	if details, ok := p.Extensions["details"].(validate.ValidationError); !ok || details["sku"] == "" {
+		t.Errorf("Expected field errors as details, got %v", p.Extensions["details"])
+	}
+}
@@ -12,7 +12,9 @@ import (
 
 // New creates a new configuration instance of type T by loading environment variables
 // and/or a .env file. The configuration struct should use mapstructure tags to define
-// which environment variables to bind to each field.
+// which environment variables to bind to each field. Fields tagged
+// `required:"true"` are checked with Validate, so a missing value is reported
+// together with every other missing value.
 func New[T any](path string) (*T, error) {
 	// Load .env file if available
 	err := godotenv.Load(path)
@@ -23,37 +25,21 @@ func New[T any](path string) (*T, error) {
 	// Configure viper
 	viper.AutomaticEnv()
 
-	// Create an instance of the type to inspect its fields
-	var cfg T
-	t := reflect.TypeOf(cfg)
-
-	// Handle both struct types and pointers to struct types
-	if t.Kind() == reflect.Ptr {
-		t = t.Elem()
-	}
-
-	// Ensure we're working with a struct
-	if t.Kind() != reflect.Struct {
-		return nil, fmt.Errorf("config type must be a struct or pointer to struct")
-	}
-
 	// Bind each field with a mapstructure tag to its environment variable
-	for i := 0; i < t.NumField(); i++ {
-		field := t.Field(i)
-		envVar := field.Tag.Get("mapstructure")
-		if envVar != "" {
-			err = viper.BindEnv(envVar, envVar)
-			if err != nil {
-				return nil, fmt.Errorf("failed to bind environment variable %s: %w", envVar, err)
-			}
-		}
+	var cfg T
+	if _, err := bindEnv(viper.GetViper(), reflect.TypeOf(cfg)); err != nil {
+		return nil, err
 	}
 
 	// Unmarshal the configuration
-	err = viper.Unmarshal(&cfg)
+	err = viper.Unmarshal(&cfg, viper.DecodeHook(decodeHook))
 	if err != nil {
 		return nil, fmt.Errorf("failed to unmarshal configuration: %w", err)
 	}
 
+	if err := Validate(&cfg); err != nil {
+		return nil, err
+	}
+
 	return &cfg, nil
 }
@@ -0,0 +1,70 @@
+package config
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+	"time"
+)
+
+var (
+	durationType = reflect.TypeOf(time.Duration(0))
+	timeType     = reflect.TypeOf(time.Time{})
+)
+
+// decodeHook converts string values, as read from the environment and .env
+// files, into the types of the fields they are decoded into:
+//
+//   - time.Duration from a duration string such as "1m30s"
+//   - slices from comma-separated values, with spaces around items trimmed
+//   - maps from comma-separated key=value pairs
+//
+// Other conversions, such as "8080" to int, are left to viper's weak typing.
+func decodeHook(from, to reflect.Type, data any) (any, error) {
+	s, ok := data.(string)
+	if !ok || from.Kind() != reflect.String {
+		return data, nil
+	}
+
+	switch {
+	case to == durationType:
+		return time.ParseDuration(strings.TrimSpace(s))
+	case to.Kind() == reflect.Slice && to.Elem().Kind() != reflect.Uint8:
+		return splitList(s), nil
+	case to.Kind() == reflect.Map:
+		m := make(map[string]string)
+		for _, pair := range splitList(s) {
+			k, v, found := strings.Cut(pair, "=")
+			if !found {
+				return nil, fmt.Errorf("invalid map entry %q: expected key=value", pair)
+			}
+			m[strings.TrimSpace(k)] = strings.TrimSpace(v)
+		}
+		return m, nil
+	}
+	return data, nil
+}
+
+// splitList splits a comma-separated list, dropping empty items
+func splitList(s string) []string {
+	items := []string{}
+	for _, item := range strings.Split(s, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			items = append(items, item)
+		}
+	}
+	return items
+}
+
+// setPath stores value in m under the nested keys in path, creating maps as needed
+func setPath(m map[string]any, path []string, value any) {
+	for _, key := range path[:len(path)-1] {
+		next, ok := m[key].(map[string]any)
+		if !ok {
+			next = make(map[string]any)
+			m[key] = next
+		}
+		m = next
+	}
+	m[path[len(path)-1]] = value
+}
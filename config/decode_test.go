@@ -0,0 +1,154 @@
+package config
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/StairSupplies/go-core/testutils"
+)
+
+type nestedDBConfig struct {
+	Host     string        `mapstructure:"HOST"`
+	Port     int           `mapstructure:"PORT"`
+	Timeout  time.Duration `mapstructure:"TIMEOUT"`
+	Password string        `mapstructure:"PASSWORD" secret:"true"`
+}
+
+type nestedConfig struct {
+	Name    string            `mapstructure:"NESTED_NAME"`
+	DB      nestedDBConfig    `mapstructure:"NDB"`
+	Cache   *nestedDBConfig   `mapstructure:"NCACHE"`
+	Hosts   []string          `mapstructure:"NESTED_HOSTS"`
+	Ports   []int             `mapstructure:"NESTED_PORTS"`
+	Labels  map[string]string `mapstructure:"NESTED_LABELS"`
+	Timeout time.Duration     `mapstructure:"NESTED_TIMEOUT"`
+}
+
+func TestLoad_NestedAndTypedValues(t *testing.T) {
+	testutils.UnsetEnv(t, "NESTED_NAME", "NDB_HOST", "NDB_PORT", "NDB_TIMEOUT", "NDB_PASSWORD",
+		"NCACHE_HOST", "NESTED_HOSTS", "NESTED_PORTS", "NESTED_LABELS", "NESTED_TIMEOUT")
+
+	dir := t.TempDir()
+	yaml := writeFile(t, dir, "nested.yaml", `
+nested_name: from-file
+ndb:
+  host: db.internal
+  port: 5432
+  timeout: 5s
+nested_hosts: [a, b]
+`)
+	envFile := writeFile(t, dir, "nested.env", "NDB_PORT=6543\nNCACHE_HOST=cache.internal\nNESTED_LABELS=team=core, tier = web\n")
+
+	t.Setenv("NDB_TIMEOUT", "1m30s")
+	t.Setenv("NESTED_PORTS", "80, 443")
+	t.Setenv("NESTED_TIMEOUT", "250ms")
+
+	cfg, err := Load[nestedConfig](WithFile(yaml), WithEnvFile(envFile))
+	if err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+
+	want := nestedConfig{
+		Name:    "from-file",
+		DB:      nestedDBConfig{Host: "db.internal", Port: 6543, Timeout: 90 * time.Second},
+		Cache:   &nestedDBConfig{Host: "cache.internal"},
+		Hosts:   []string{"a", "b"},
+		Ports:   []int{80, 443},
+		Labels:  map[string]string{"team": "core", "tier": "web"},
+		Timeout: 250 * time.Millisecond,
+	}
+	if !reflect.DeepEqual(*cfg, want) {
+		t.Errorf("Expected %+v, got %+v", want, *cfg)
+	}
+}
+
+func TestLoad_NestedDefaults(t *testing.T) {
+	testutils.UnsetEnv(t, "NDB_HOST", "NDB_PORT")
+	t.Setenv("NDB_PORT", "7000")
+
+	cfg, err := Load[nestedConfig](WithDefaults(map[string]any{"NDB.HOST": "localhost", "NDB.PORT": 5432}))
+	if err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+	if cfg.DB.Host != "localhost" || cfg.DB.Port != 7000 {
+		t.Errorf("Expected localhost:7000, got %s:%d", cfg.DB.Host, cfg.DB.Port)
+	}
+}
+
+func TestLoad_InvalidTypedValues(t *testing.T) {
+	tests := []struct {
+		name string
+		key  string
+		val  string
+	}{
+		{"duration", "NESTED_TIMEOUT", "soon"},
+		{"map entry", "NESTED_LABELS", "team"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			testutils.UnsetEnv(t, "NESTED_TIMEOUT", "NESTED_LABELS")
+			t.Setenv(tt.key, tt.val)
+			if _, err := Load[nestedConfig](); err == nil {
+				t.Errorf("Expected error for %s=%q", tt.key, tt.val)
+			}
+		})
+	}
+}
+
+func TestDecodeHook(t *testing.T) {
+	tests := []struct {
+		name string
+		to   reflect.Type
+		in   string
+		want any
+	}{
+		{"duration", durationType, " 2h ", 2 * time.Hour},
+		{"string slice", reflect.TypeOf([]string{}), "a, b,,c ", []string{"a", "b", "c"}},
+		{"empty slice", reflect.TypeOf([]int{}), "", []string{}},
+		{"bytes untouched", reflect.TypeOf([]byte{}), "a,b", "a,b"},
+		{"map", reflect.TypeOf(map[string]int{}), "a=1,b=2", map[string]string{"a": "1", "b": "2"}},
+		{"string untouched", reflect.TypeOf(""), "a,b", "a,b"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := decodeHook(reflect.TypeOf(""), tt.to, tt.in)
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Expected %#v, got %#v", tt.want, got)
+			}
+		})
+	}
+}
+
+func TestDescribe_Nested(t *testing.T) {
+	testutils.UnsetEnv(t, "NDB_HOST")
+	t.Setenv("NDB_HOST", "db.internal")
+
+	cfg := nestedConfig{DB: nestedDBConfig{Host: "db.internal", Password: "hunter2", Timeout: time.Second}}
+	fields, err := describeFields(cfg)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	got := make(map[string]describedField, len(fields))
+	for _, f := range fields {
+		got[f.key] = f
+	}
+	if f := got["NDB_HOST"]; f.value != "db.internal" || f.source != SourceEnv {
+		t.Errorf("Expected NDB_HOST from env, got %+v", f)
+	}
+	if f := got["NDB_PASSWORD"]; f.value != "[REDACTED]" {
+		t.Errorf("Expected NDB_PASSWORD to be masked, got %+v", f)
+	}
+	if f := got["NDB_TIMEOUT"]; f.value != "1s" {
+		t.Errorf("Expected NDB_TIMEOUT = 1s, got %+v", f)
+	}
+	if _, ok := got["NCACHE"]; !ok {
+		t.Error("Expected nil nested pointer to be listed as one field")
+	}
+}
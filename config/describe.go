@@ -0,0 +1,156 @@
+package config
+
+import (
+	"fmt"
+	"os"
+	"reflect"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/StairSupplies/go-core/logger"
+	"go.uber.org/zap"
+)
+
+// Sources reported by Describe and LogEffective
+const (
+	// SourceEnv marks values whose environment variable is set, including
+	// values exported from a .env file by New
+	SourceEnv = "env"
+	// SourceFileOrDefault marks values that are set but not from the environment
+	SourceFileOrDefault = "file/default"
+	// SourceUnset marks fields holding their zero value
+	SourceUnset = "unset"
+)
+
+// describedField is one configuration value prepared for display
+type describedField struct {
+	key    string
+	value  string
+	source string
+	// quote is set for string values, which Describe shows quoted
+	quote bool
+}
+
+// describeFields lists the fields of cfg with secrets masked
+func describeFields(cfg any) ([]describedField, error) {
+	v := reflect.ValueOf(cfg)
+	for v.Kind() == reflect.Pointer {
+		if v.IsNil() {
+			return nil, fmt.Errorf("config must not be nil")
+		}
+		v = v.Elem()
+	}
+	if v.Kind() != reflect.Struct {
+		return nil, fmt.Errorf("config type must be a struct or pointer to struct")
+	}
+
+	var fields []describedField
+	appendFields(&fields, v, "")
+	return fields, nil
+}
+
+// appendFields adds the fields of struct value v to fields, prefixing their
+// keys with prefix. Nested structs are flattened into keys such as DB_HOST,
+// matching their environment variables.
+func appendFields(fields *[]describedField, v reflect.Value, prefix string) {
+	eachField(v, prefix, func(key string, sf reflect.StructField, fv reflect.Value) {
+		tag, _, _ := strings.Cut(sf.Tag.Get("mapstructure"), ",")
+
+		f := describedField{key: key, source: SourceFileOrDefault}
+		if _, ok := os.LookupEnv(key); ok && tag != "" {
+			f.source = SourceEnv
+		} else if fv.IsZero() {
+			f.source = SourceUnset
+		}
+
+		switch {
+		case sf.Tag.Get("secret") == "true" && !fv.IsZero():
+			f.value = logger.RedactedValue
+		case fv.Kind() == reflect.String:
+			f.value = fv.String()
+			f.quote = true
+		default:
+			f.value = fmt.Sprintf("%v", fv.Interface())
+		}
+		*fields = append(*fields, f)
+	})
+}
+
+// eachField calls fn with each leaf field of struct value v and its key,
+// prefixed with prefix. Nested structs are flattened into keys such as
+// DB_HOST, matching their environment variables; untagged fields use their
+// Go name.
+func eachField(v reflect.Value, prefix string, fn func(key string, sf reflect.StructField, fv reflect.Value)) {
+	t := v.Type()
+	for i := 0; i < t.NumField(); i++ {
+		sf := t.Field(i)
+		tag, _, _ := strings.Cut(sf.Tag.Get("mapstructure"), ",")
+		if !sf.IsExported() || tag == "-" {
+			continue
+		}
+		key := prefix + tag
+		if tag == "" {
+			key = prefix + sf.Name
+		}
+
+		fv := v.Field(i)
+		if fv.Kind() == reflect.Pointer && !fv.IsNil() && fv.Elem().Kind() == reflect.Struct {
+			fv = fv.Elem()
+		}
+		if ft := fv.Type(); ft.Kind() == reflect.Struct && ft != timeType {
+			eachField(fv, key+"_", fn)
+			continue
+		}
+
+		fn(key, sf, fv)
+	}
+}
+
+// Describe renders the resolved configuration one field per line, with the
+// source of each value: "env" if its environment variable is set,
+// "file/default" for other non-zero values, and "unset" for zero values.
+// Fields tagged `secret:"true"` are masked:
+//
+//	APP_NAME     = "orders"     (env)
+//	APP_PORT     = 8080         (file/default)
+//	DB_PASSWORD  = [REDACTED]   (env)
+//	LOG_LEVEL    = ""           (unset)
+func Describe(cfg any) string {
+	fields, err := describeFields(cfg)
+	if err != nil {
+		return err.Error()
+	}
+
+	var b strings.Builder
+	tw := tabwriter.NewWriter(&b, 0, 4, 2, ' ', 0)
+	for _, f := range fields {
+		value := f.value
+		if f.quote {
+			value = fmt.Sprintf("%q", value)
+		}
+		fmt.Fprintf(tw, "%s\t= %s\t(%s)\n", f.key, value, f.source)
+	}
+	tw.Flush()
+	return b.String()
+}
+
+// LogEffective logs the resolved configuration at info level, with each
+// value as a field and the sources in a config_sources field. Secrets are
+// masked as in Describe.
+func LogEffective(cfg any, log *logger.Logger) {
+	fields, err := describeFields(cfg)
+	if err != nil {
+		log.Warn("Cannot describe configuration", zap.Error(err))
+		return
+	}
+
+	zapFields := make([]zap.Field, 0, len(fields)+1)
+	sources := make(map[string]string, len(fields))
+	for _, f := range fields {
+		zapFields = append(zapFields, zap.String(f.key, f.value))
+		sources[f.key] = f.source
+	}
+	zapFields = append(zapFields, zap.Any("config_sources", sources))
+
+	log.Info("Effective configuration", zapFields...)
+}
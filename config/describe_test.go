@@ -0,0 +1,69 @@
+package config
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/StairSupplies/go-core/logger"
+	"github.com/StairSupplies/go-core/logger/logtest"
+	"go.uber.org/zap/zapcore"
+)
+
+type describeConfig struct {
+	Name     string `mapstructure:"DESCRIBE_NAME"`
+	Port     int    `mapstructure:"DESCRIBE_PORT"`
+	Password string `mapstructure:"DESCRIBE_PASSWORD" secret:"true"`
+	Token    string `mapstructure:"DESCRIBE_TOKEN" secret:"true"`
+	Level    string `mapstructure:"DESCRIBE_LEVEL"`
+	Skipped  string `mapstructure:"-"`
+	internal string
+}
+
+func TestDescribe(t *testing.T) {
+	t.Setenv("DESCRIBE_NAME", "orders")
+	t.Setenv("DESCRIBE_PASSWORD", "hunter2")
+
+	cfg := &describeConfig{Name: "orders", Port: 8080, Password: "hunter2", Skipped: "x", internal: "y"}
+	got := Describe(cfg)
+
+	want := []string{
+		`DESCRIBE_NAME      = "orders"    (env)`,
+		`DESCRIBE_PORT      = 8080        (file/default)`,
+		`DESCRIBE_PASSWORD  = [REDACTED]  (env)`,
+		`DESCRIBE_TOKEN     = ""          (unset)`,
+		`DESCRIBE_LEVEL     = ""          (unset)`,
+	}
+	if got != strings.Join(want, "\n")+"\n" {
+		t.Errorf("Unexpected output:\n%s\nwant:\n%s", got, strings.Join(want, "\n"))
+	}
+	if strings.Contains(got, "hunter2") {
+		t.Error("Expected secret value to be masked")
+	}
+
+	if got := Describe("not a struct"); !strings.Contains(got, "must be a struct") {
+		t.Errorf("Expected error for non-struct config, got %q", got)
+	}
+}
+
+func TestLogEffective(t *testing.T) {
+	t.Setenv("DESCRIBE_NAME", "set")
+	t.Setenv("DESCRIBE_PASSWORD", "set")
+
+	log, rec := logtest.NewTestLoggerAt(t, zapcore.InfoLevel)
+	LogEffective(describeConfig{Name: "set", Password: "set"}, log)
+
+	if rec.Len() != 1 {
+		t.Fatalf("Expected one log entry, got %d", rec.Len())
+	}
+	fields := rec.Entries()[0].Fields
+	if fields["DESCRIBE_NAME"] != "set" {
+		t.Errorf("Expected unquoted name, got %v", fields["DESCRIBE_NAME"])
+	}
+	if fields["DESCRIBE_PASSWORD"] != logger.RedactedValue {
+		t.Errorf("Expected masked password, got %v", fields["DESCRIBE_PASSWORD"])
+	}
+	sources, _ := fields["config_sources"].(map[string]string)
+	if sources["DESCRIBE_NAME"] != SourceEnv || sources["DESCRIBE_PORT"] != SourceUnset {
+		t.Errorf("Unexpected sources %v", sources)
+	}
+}
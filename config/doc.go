@@ -12,7 +12,16 @@ with optional support for loading from .env files.
   - Type-safe configuration using generics
   - Automatic binding of environment variables to struct fields
   - Support for loading from .env files using godotenv
+  - Layered YAML, JSON, and TOML config files with environment overrides
+  - Nested structs, slices, maps, and time.Duration fields
+  - Hot reload of configuration files and environment variables with Watch
+  - Startup reporting of the effective configuration with secrets masked
+  - Required fields and custom rules, with every missing value reported at once
+  - Defaults from struct tags, and .env.example files and Markdown tables
+    generated from the configuration struct
   - Environment constants for standard deployment environments
+  - Feature flags with percent rollouts and per-request overrides in the
+    featureflags subpackage
 
 # Usage
 
@@ -40,6 +49,159 @@ Then load the configuration:
 		log.Fatalf("Failed to load configuration: %v", err)
 	}
 
+# Config Files
+
+Load reads layered configuration from files as well as the environment,
+which suits deployments that mount config files:
+
+	cfg, err := config.Load[AppConfig](
+		config.WithDefaults(map[string]any{"LOG_LEVEL": "info"}),
+		config.WithFile("/etc/app/config.yaml"),
+		config.WithOptionalFile("/etc/app/overrides.json"),
+		config.WithEnvFile(".env"),
+	)
+
+Keys match mapstructure tags case-insensitively, so APP_PORT above is read
+from this YAML file:
+
+	app_name: orders
+	app_port: 8080
+
+Load uses its own viper instance and, unlike New, does not export .env
+values to the process environment.
+
+# Nested and Typed Values
+
+A struct field with a mapstructure tag groups the fields of its type under
+that prefix. Environment variables join the parts with an underscore, and
+config files nest them:
+
+	type DBConfig struct {
+		Host    string        `mapstructure:"HOST"`
+		Timeout time.Duration `mapstructure:"TIMEOUT"`
+	}
+
+	type AppConfig struct {
+		DB      DBConfig          `mapstructure:"DB"`
+		Origins []string          `mapstructure:"CORS_ORIGINS"`
+		Labels  map[string]string `mapstructure:"LABELS"`
+	}
+
+	// DB_HOST=db.internal
+	// DB_TIMEOUT=5s
+	// CORS_ORIGINS=https://a.example, https://b.example
+	// LABELS=team=core,tier=web
+
+	db:
+	  host: db.internal
+	  timeout: 5s
+
+Pointers to structs work the same way, and a field tagged
+`mapstructure:",squash"` adds its fields without a prefix. Strings are
+converted to the field type: durations such as "1m30s", slices from
+comma-separated values, and maps from comma-separated key=value pairs. Keys
+for WithDefaults use dots, as in "DB.HOST". Describe lists nested fields by
+their environment variable, such as DB_HOST.
+
+# Watching for Changes
+
+Watch loads a configuration and reloads it periodically, calling its
+callback when the result changes. This suits settings such as feature flags
+or log levels that should be tuned without a restart:
+
+	w, err := config.Watch(".env", func(cfg *AppConfig) {
+		applyLogLevel(cfg.LogLevel)
+	}, config.WithWatchInterval(5*time.Second))
+	if err != nil {
+		log.Fatalf("Failed to load configuration: %v", err)
+	}
+	defer w.Close()
+
+	current := w.Current()
+
+The path is read like WithFile for YAML, JSON and TOML files and like
+WithEnvFile otherwise. Bound environment variables are re-read on each
+reload too. A failed reload keeps the previous configuration and is reported
+to WithWatchErrorHandler. Additional subscribers can be added with Subscribe.
+
+# Describing the Effective Configuration
+
+Describe renders the resolved configuration with the source of each value,
+and LogEffective logs it, which helps debug mis-configured deployments. Tag
+fields with `secret:"true"` to mask them:
+
+	type AppConfig struct {
+		AppName    string `mapstructure:"APP_NAME"`
+		DBPassword string `mapstructure:"DB_PASSWORD" secret:"true"`
+	}
+
+	config.LogEffective(cfg, log)
+
+	fmt.Print(config.Describe(cfg))
+	// APP_NAME     = "orders"    (env)
+	// DB_PASSWORD  = [REDACTED]  (env)
+
+Values are marked "env" when their environment variable is set, which
+includes .env values exported by New, "file/default" when set by other
+means, and "unset" when they hold the zero value.
+
+# Required Values and Validation
+
+Tag fields with `required:"true"` to make New and Load fail when they hold
+their zero value. Every missing value is reported in one error, so a
+deployment can be fixed in one go:
+
+	type AppConfig struct {
+		DBURL     string `mapstructure:"DB_URL" required:"true"`
+		RedisAddr string `mapstructure:"REDIS_ADDR" required:"true"`
+		APIKey    string `mapstructure:"API_KEY" required:"true" secret:"true"`
+	}
+
+	cfg, err := config.New[AppConfig]("")
+	// missing required configuration: DB_URL, REDIS_ADDR, API_KEY
+
+WithValidation adds rules for individual fields, keyed as Describe shows
+them. Check makes a rule from a function of the field's type:
+
+	cfg, err := config.Load[AppConfig](
+		config.WithFile("config.yaml"),
+		config.WithValidation(config.Check("APP_PORT", func(port int) error {
+			if port < 1 || port > 65535 {
+				return errors.New("must be between 1 and 65535")
+			}
+			return nil
+		})),
+	)
+
+The error is a *ValidationError with the Missing keys and the Invalid ones.
+Validate runs the same checks on a configuration built some other way.
+
+# Defaults and Documentation
+
+Tag fields with `default:"..."` to give them a default, which Load applies
+below WithDefaults and every other layer, and with `doc:"..."` to describe
+them:
+
+	type AppConfig struct {
+		Port  int    `mapstructure:"APP_PORT" default:"8080" doc:"HTTP listen port"`
+		DBURL string `mapstructure:"DB_URL" required:"true" secret:"true" doc:"Postgres connection string"`
+	}
+
+Document lists the environment variables of a configuration struct with
+their types, defaults, descriptions and markers. Example renders them as a
+.env.example file and Markdown as a table for a README, so both can be
+regenerated whenever the struct changes:
+
+	os.WriteFile(".env.example", []byte(config.Example[AppConfig]()), 0644)
+
+	// # HTTP listen port
+	// APP_PORT=8080
+	//
+	// # Postgres connection string (required, secret)
+	// DB_URL=
+
+Secret values are never written, even when they have a default.
+
 # Environment Management
 
 The package provides constants for standard deployment environments:
@@ -56,12 +218,52 @@ Helper functions for environment management:
 	// Get the default environment (development)
 	defaultEnv := config.GetDefaultEnvironment()
 
+NewForEnv loads the .env profile for an environment, read from the working
+directory or the one given with WithEnvDir:
+
+	env := config.Environment(os.Getenv("APP_ENV"))
+	cfg, err := config.NewForEnv[AppConfig](env)
+
+Files are merged from lowest to highest precedence, and missing ones are
+skipped:
+
+  1. .env, shared by all environments
+  2. .env.<env>, such as .env.production
+  3. .env.<env>.local, for local overrides that are not committed
+
+Unlike New, NewForEnv does not export these values to the process
+environment. An empty env means the default environment, and an unknown one
+fails with ErrInvalidEnvironment.
+
 # Priority Order
 
 When loading configuration, environment variables take precedence over values defined
 in .env files. This allows for easy overriding of configuration values in different
 deployment environments.
 
+Load applies its layers from lowest to highest precedence:
+
+  1. Defaults from WithDefaults, then from default tags
+  2. Config files, in the order given
+  3. .env files, in the order given; for NewForEnv the profile files come
+     first, so files from WithEnvFile override them
+  4. Environment variables
+
+# Feature Flags
+
+The featureflags subpackage loads flags from FEATURE_ environment variables
+and flag files, with bool, percent rollout and variant flags, per-request
+overrides from a header and change notifications for hot toggling:
+
+	flags, err := featureflags.New(featureflags.WithFile("/etc/app/flags.yaml"))
+	if err != nil {
+		log.Fatalf("Failed to load feature flags: %v", err)
+	}
+
+	if flags.IsEnabled(ctx, "new_checkout") {
+		...
+	}
+
 # Dependencies
 
 This package uses:
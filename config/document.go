@@ -0,0 +1,166 @@
+package config
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+)
+
+// FieldDoc describes one environment variable of a configuration struct
+type FieldDoc struct {
+	// Env is the environment variable, such as DB_HOST for a HOST field in a
+	// struct tagged DB
+	Env string
+	// Type is the Go type of the field, such as "int" or "time.Duration"
+	Type string
+	// Default is the field's `default:"..."` tag
+	Default string
+	// Description is the field's `doc:"..."` tag
+	Description string
+	// Required is set for fields tagged `required:"true"`
+	Required bool
+	// Secret is set for fields tagged `secret:"true"`
+	Secret bool
+}
+
+// Document lists the environment variables T binds, in field order, with
+// their types and the default, doc, required and secret tags:
+//
+//	type AppConfig struct {
+//		Port  int    `mapstructure:"APP_PORT" default:"8080" doc:"HTTP listen port"`
+//		DBURL string `mapstructure:"DB_URL" required:"true" secret:"true"`
+//	}
+//
+// Fields are found as Load binds them: untagged fields are skipped, nested
+// structs are flattened into names such as DB_HOST, and squashed structs
+// contribute their fields directly.
+func Document[T any]() []FieldDoc {
+	t := reflect.TypeOf((*T)(nil)).Elem()
+	for t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+	if t.Kind() != reflect.Struct {
+		return nil
+	}
+
+	var docs []FieldDoc
+	appendDocs(&docs, t, "")
+	return docs
+}
+
+// appendDocs adds the fields of struct type t to docs, prefixing their
+// environment variables with envPrefix. It mirrors bindFields.
+func appendDocs(docs *[]FieldDoc, t reflect.Type, envPrefix string) {
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		name, opts, _ := strings.Cut(field.Tag.Get("mapstructure"), ",")
+
+		ft := field.Type
+		if ft.Kind() == reflect.Ptr {
+			ft = ft.Elem()
+		}
+		nested := ft.Kind() == reflect.Struct && ft != durationType && ft != timeType
+
+		if nested && name == "" && strings.Contains(opts, "squash") {
+			appendDocs(docs, ft, envPrefix)
+			continue
+		}
+		if name == "" || name == "-" {
+			continue
+		}
+		if nested {
+			appendDocs(docs, ft, envPrefix+name+"_")
+			continue
+		}
+
+		*docs = append(*docs, FieldDoc{
+			Env:         envPrefix + name,
+			Type:        ft.String(),
+			Default:     field.Tag.Get("default"),
+			Description: field.Tag.Get("doc"),
+			Required:    field.Tag.Get("required") == "true",
+			Secret:      field.Tag.Get("secret") == "true",
+		})
+	}
+}
+
+// Example renders a .env.example file for T from Document. Each variable is
+// preceded by its description and markers, and set to its default; secrets
+// are always left empty so the file is safe to commit:
+//
+//	# HTTP listen port
+//	APP_PORT=8080
+//
+//	# (required, secret)
+//	DB_URL=
+func Example[T any]() string {
+	var b strings.Builder
+	for i, doc := range Document[T]() {
+		if i > 0 {
+			b.WriteString("\n")
+		}
+
+		var comment []string
+		if doc.Description != "" {
+			comment = append(comment, doc.Description)
+		}
+		if markers := doc.markers(); markers != "" {
+			comment = append(comment, "("+markers+")")
+		}
+		if len(comment) > 0 {
+			fmt.Fprintf(&b, "# %s\n", strings.Join(comment, " "))
+		}
+
+		value := doc.Default
+		if doc.Secret {
+			value = ""
+		}
+		fmt.Fprintf(&b, "%s=%s\n", doc.Env, envValue(value))
+	}
+	return b.String()
+}
+
+// Markdown renders the variables of T from Document as a Markdown table with
+// Variable, Type, Default, Required and Description columns, for a README
+func Markdown[T any]() string {
+	var b strings.Builder
+	b.WriteString("| Variable | Type | Default | Required | Description |\n")
+	b.WriteString("| --- | --- | --- | --- | --- |\n")
+	for _, doc := range Document[T]() {
+		def := ""
+		if doc.Default != "" && !doc.Secret {
+			def = "`" + doc.Default + "`"
+		}
+		required := ""
+		if doc.Required {
+			required = "yes"
+		}
+		description := doc.Description
+		if doc.Secret {
+			description = strings.TrimSpace(description + " (secret)")
+		}
+		fmt.Fprintf(&b, "| `%s` | %s | %s | %s | %s |\n",
+			doc.Env, doc.Type, def, required, strings.ReplaceAll(description, "|", `\|`))
+	}
+	return b.String()
+}
+
+// markers lists the required and secret markers of doc
+func (doc FieldDoc) markers() string {
+	var markers []string
+	if doc.Required {
+		markers = append(markers, "required")
+	}
+	if doc.Secret {
+		markers = append(markers, "secret")
+	}
+	return strings.Join(markers, ", ")
+}
+
+// envValue quotes value for a .env file if it contains spaces, quotes or #
+func envValue(value string) string {
+	if strings.ContainsAny(value, " \t\"'#") {
+		return fmt.Sprintf("%q", value)
+	}
+	return value
+}
@@ -0,0 +1,122 @@
+package config
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/StairSupplies/go-core/testutils"
+)
+
+type documentDB struct {
+	Host string `mapstructure:"HOST" default:"localhost" doc:"Database host"`
+	Port int    `mapstructure:"PORT" default:"5432"`
+}
+
+type documentShared struct {
+	Region string `mapstructure:"DOC_REGION" doc:"Cloud region"`
+}
+
+type documentConfig struct {
+	Name     string         `mapstructure:"DOC_NAME" required:"true" doc:"Service name"`
+	Timeout  time.Duration  `mapstructure:"DOC_TIMEOUT" default:"30s" doc:"Request timeout"`
+	Password string         `mapstructure:"DOC_PASSWORD" default:"changeme" secret:"true"`
+	Greeting string         `mapstructure:"DOC_GREETING" default:"hello world" doc:"Shown on the | home page"`
+	DB       documentDB     `mapstructure:"DOC_DB"`
+	Shared   documentShared `mapstructure:",squash"`
+	Ignored  string         `mapstructure:"-"`
+	Untagged string
+}
+
+func TestDocument(t *testing.T) {
+	got := Document[documentConfig]()
+	want := []FieldDoc{
+		{Env: "DOC_NAME", Type: "string", Description: "Service name", Required: true},
+		{Env: "DOC_TIMEOUT", Type: "time.Duration", Default: "30s", Description: "Request timeout"},
+		{Env: "DOC_PASSWORD", Type: "string", Default: "changeme", Secret: true},
+		{Env: "DOC_GREETING", Type: "string", Default: "hello world", Description: "Shown on the | home page"},
+		{Env: "DOC_DB_HOST", Type: "string", Default: "localhost", Description: "Database host"},
+		{Env: "DOC_DB_PORT", Type: "int", Default: "5432"},
+		{Env: "DOC_REGION", Type: "string", Description: "Cloud region"},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("Expected %+v, got %+v", want, got)
+	}
+
+	if docs := Document[*documentConfig](); len(docs) != len(want) {
+		t.Errorf("Expected pointer type to be documented, got %d fields", len(docs))
+	}
+	if docs := Document[string](); docs != nil {
+		t.Errorf("Expected nil for non-struct type, got %+v", docs)
+	}
+}
+
+func TestExample(t *testing.T) {
+	want := `# Service name (required)
+DOC_NAME=
+
+# Request timeout
+DOC_TIMEOUT=30s
+
+# (secret)
+DOC_PASSWORD=
+
+# Shown on the | home page
+DOC_GREETING="hello world"
+
+# Database host
+DOC_DB_HOST=localhost
+
+DOC_DB_PORT=5432
+
+# Cloud region
+DOC_REGION=
+`
+	if got := Example[documentConfig](); got != want {
+		t.Errorf("Unexpected output:\n%s\nwant:\n%s", got, want)
+	}
+}
+
+func TestMarkdown(t *testing.T) {
+	got := Markdown[documentConfig]()
+
+	for _, line := range []string{
+		"| Variable | Type | Default | Required | Description |",
+		"| `DOC_NAME` | string |  | yes | Service name |",
+		"| `DOC_TIMEOUT` | time.Duration | `30s` |  | Request timeout |",
+		"| `DOC_PASSWORD` | string |  |  | (secret) |",
+		"| `DOC_GREETING` | string | `hello world` |  | Shown on the \\| home page |",
+	} {
+		if !strings.Contains(got, line+"\n") {
+			t.Errorf("Expected line %q in:\n%s", line, got)
+		}
+	}
+	if strings.Contains(got, "changeme") {
+		t.Error("Expected secret default to be omitted")
+	}
+}
+
+func TestLoad_DefaultTag(t *testing.T) {
+	testutils.UnsetEnv(t, "DOC_NAME", "DOC_TIMEOUT", "DOC_DB_HOST", "DOC_DB_PORT")
+	t.Setenv("DOC_NAME", "orders")
+	t.Setenv("DOC_DB_PORT", "6543")
+
+	cfg, err := Load[documentConfig](WithDefaults(map[string]any{"DOC_GREETING": "hi"}))
+	if err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+
+	if cfg.Timeout != 30*time.Second {
+		t.Errorf("Expected default tag to apply, got Timeout = %v", cfg.Timeout)
+	}
+	if cfg.DB.Host != "localhost" {
+		t.Errorf("Expected nested default tag to apply, got DB.Host = %q", cfg.DB.Host)
+	}
+	if cfg.DB.Port != 6543 {
+		t.Errorf("Expected environment to override default tag, got DB.Port = %d", cfg.DB.Port)
+	}
+	if cfg.Greeting != "hi" {
+		t.Errorf("Expected WithDefaults to override default tag, got Greeting = %q", cfg.Greeting)
+	}
+}
@@ -1,5 +1,15 @@
 package config
 
+import (
+	"errors"
+	"fmt"
+	"path/filepath"
+)
+
+// ErrInvalidEnvironment is returned by NewForEnv for an environment other
+// than the standard ones
+var ErrInvalidEnvironment = errors.New("config: invalid environment")
+
 // Environment represents the environment in which the application is running.
 type Environment string
 
@@ -29,3 +39,47 @@ func IsValidEnvironment(env Environment) bool {
 func GetDefaultEnvironment() Environment {
 	return EnvDevelopment
 }
+
+// EnvFiles returns the .env files for env in dir, from lowest to highest
+// precedence: .env, .env.<env> and .env.<env>.local
+func EnvFiles(dir string, env Environment) []string {
+	name := ".env." + string(env)
+	return []string{
+		filepath.Join(dir, ".env"),
+		filepath.Join(dir, name),
+		filepath.Join(dir, name+".local"),
+	}
+}
+
+// NewForEnv creates a configuration instance of type T for env, merging the
+// .env files returned by EnvFiles so each layer overrides the one before it:
+//
+//  1. .env, shared by all environments
+//  2. .env.<env>, such as .env.production
+//  3. .env.<env>.local, for machine-specific overrides kept out of version control
+//
+// Missing files are skipped, and environment variables override them all.
+// Files are read from the working directory unless WithEnvDir is given.
+// Other options are passed to Load, so config files and defaults can be
+// combined with the profile; .env files from WithEnvFile override the profile.
+// An empty env means GetDefaultEnvironment.
+func NewForEnv[T any](env Environment, opts ...LoadOption) (*T, error) {
+	if env == "" {
+		env = GetDefaultEnvironment()
+	}
+	if !IsValidEnvironment(env) {
+		return nil, fmt.Errorf("%w: %q", ErrInvalidEnvironment, env)
+	}
+
+	l := &loader{}
+	for _, opt := range opts {
+		opt(l)
+	}
+
+	files := EnvFiles(l.envDir, env)
+	profile := make([]LoadOption, 0, len(files)+len(opts))
+	for _, path := range files {
+		profile = append(profile, WithEnvFile(path))
+	}
+	return Load[T](append(profile, opts...)...)
+}
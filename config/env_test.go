@@ -1,7 +1,12 @@
 package config
 
 import (
+	"errors"
+	"path/filepath"
+	"reflect"
 	"testing"
+
+	"github.com/StairSupplies/go-core/testutils"
 )
 
 func TestIsValidEnvironment(t *testing.T) {
@@ -72,4 +77,94 @@ func TestEnvironmentString(t *testing.T) {
 			}
 		})
 	}
-}
\ No newline at end of file
+}
+
+func TestEnvFiles(t *testing.T) {
+	got := EnvFiles("conf", EnvProduction)
+	want := []string{
+		filepath.Join("conf", ".env"),
+		filepath.Join("conf", ".env.production"),
+		filepath.Join("conf", ".env.production.local"),
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("Expected %v, got %v", want, got)
+	}
+}
+
+func TestNewForEnv(t *testing.T) {
+	type ProfileConfig struct {
+		Name  string `mapstructure:"PROFILE_NAME"`
+		Port  int    `mapstructure:"PROFILE_PORT"`
+		DBURL string `mapstructure:"PROFILE_DB_URL"`
+		Debug bool   `mapstructure:"PROFILE_DEBUG"`
+	}
+	testutils.UnsetEnv(t, "PROFILE_NAME", "PROFILE_PORT", "PROFILE_DB_URL", "PROFILE_DEBUG")
+
+	dir := t.TempDir()
+	writeFile(t, dir, ".env", "PROFILE_NAME=base\nPROFILE_PORT=8080\nPROFILE_DB_URL=postgres://base\nPROFILE_DEBUG=true\n")
+	writeFile(t, dir, ".env.production", "PROFILE_PORT=80\nPROFILE_DB_URL=postgres://prod\nPROFILE_DEBUG=false\n")
+	writeFile(t, dir, ".env.production.local", "PROFILE_DB_URL=postgres://prod-local\n")
+
+	t.Run("precedence", func(t *testing.T) {
+		t.Setenv("PROFILE_PORT", "9090")
+
+		cfg, err := NewForEnv[ProfileConfig](EnvProduction, WithEnvDir(dir))
+		if err != nil {
+			t.Fatalf("NewForEnv() error = %v", err)
+		}
+		if cfg.Name != "base" {
+			t.Errorf("Expected Name from .env, got %q", cfg.Name)
+		}
+		if cfg.Debug {
+			t.Error("Expected .env.production to override .env, got Debug = true")
+		}
+		if cfg.DBURL != "postgres://prod-local" {
+			t.Errorf("Expected .env.production.local to override .env.production, got DBURL = %q", cfg.DBURL)
+		}
+		if cfg.Port != 9090 {
+			t.Errorf("Expected environment to override .env files, got Port = %d", cfg.Port)
+		}
+	})
+
+	t.Run("missing profile files are skipped", func(t *testing.T) {
+		cfg, err := NewForEnv[ProfileConfig](EnvStaging, WithEnvDir(dir))
+		if err != nil {
+			t.Fatalf("NewForEnv() error = %v", err)
+		}
+		if cfg.Port != 8080 || cfg.DBURL != "postgres://base" || !cfg.Debug {
+			t.Errorf("Expected values from .env only, got %+v", *cfg)
+		}
+	})
+
+	t.Run("empty environment uses the default", func(t *testing.T) {
+		writeFile(t, dir, ".env.development", "PROFILE_NAME=dev\n")
+
+		cfg, err := NewForEnv[ProfileConfig]("", WithEnvDir(dir))
+		if err != nil {
+			t.Fatalf("NewForEnv() error = %v", err)
+		}
+		if cfg.Name != "dev" {
+			t.Errorf("Expected Name from .env.development, got %q", cfg.Name)
+		}
+	})
+
+	t.Run("options are passed to Load", func(t *testing.T) {
+		cfg, err := NewForEnv[ProfileConfig](EnvStaging,
+			WithEnvDir(dir),
+			WithDefaults(map[string]any{"PROFILE_NAME": "default", "PROFILE_PORT": 1}),
+		)
+		if err != nil {
+			t.Fatalf("NewForEnv() error = %v", err)
+		}
+		if cfg.Port != 8080 {
+			t.Errorf("Expected .env to override defaults, got Port = %d", cfg.Port)
+		}
+	})
+
+	t.Run("invalid environment", func(t *testing.T) {
+		_, err := NewForEnv[ProfileConfig]("qa", WithEnvDir(dir))
+		if !errors.Is(err, ErrInvalidEnvironment) {
+			t.Errorf("Expected ErrInvalidEnvironment, got %v", err)
+		}
+	})
+}
@@ -1,8 +1,11 @@
 package config_test
 
 import (
+	"errors"
 	"fmt"
 	"os"
+	"path/filepath"
+	"time"
 
 	"github.com/StairSupplies/go-core/config"
 )
@@ -119,4 +122,182 @@ func ExampleNew_withEnvFile() {
 
 	// Output:
 	// Database connection string: localhost:5432/myapp
-}
\ No newline at end of file
+}
+
+func ExampleNewForEnv() {
+	type ServerConfig struct {
+		Port  int    `mapstructure:"PROFILE_EXAMPLE_PORT"`
+		DBURL string `mapstructure:"PROFILE_EXAMPLE_DB_URL"`
+	}
+
+	// Usually these files sit next to the service binary
+	dir, _ := os.MkdirTemp("", "config-example")
+	defer os.RemoveAll(dir)
+	os.WriteFile(filepath.Join(dir, ".env"), []byte("PROFILE_EXAMPLE_PORT=8080\nPROFILE_EXAMPLE_DB_URL=postgres://localhost/orders\n"), 0644)
+	os.WriteFile(filepath.Join(dir, ".env.production"), []byte("PROFILE_EXAMPLE_DB_URL=postgres://db.internal/orders\n"), 0644)
+
+	cfg, err := config.NewForEnv[ServerConfig](config.EnvProduction, config.WithEnvDir(dir))
+	if err != nil {
+		fmt.Printf("Error loading configuration: %v\n", err)
+		return
+	}
+
+	fmt.Printf("port %d, database %s\n", cfg.Port, cfg.DBURL)
+
+	// Output: port 8080, database postgres://db.internal/orders
+}
+
+func ExampleLoad() {
+	type ServerConfig struct {
+		Name     string `mapstructure:"SERVICE_NAME"`
+		Port     int    `mapstructure:"SERVICE_PORT"`
+		LogLevel string `mapstructure:"SERVICE_LOG_LEVEL"`
+	}
+
+	// In a real deployment this file would be mounted into the container
+	dir, _ := os.MkdirTemp("", "config-example")
+	defer os.RemoveAll(dir)
+	path := filepath.Join(dir, "config.yaml")
+	os.WriteFile(path, []byte("service_name: orders\nservice_port: 8080\n"), 0644)
+
+	cfg, err := config.Load[ServerConfig](
+		config.WithDefaults(map[string]any{"SERVICE_LOG_LEVEL": "info"}),
+		config.WithFile(path),
+	)
+	if err != nil {
+		fmt.Printf("Error loading configuration: %v\n", err)
+		return
+	}
+
+	fmt.Printf("%s on port %d, log level %s\n", cfg.Name, cfg.Port, cfg.LogLevel)
+
+	// Output: orders on port 8080, log level info
+}
+
+func ExampleLoad_nested() {
+	type DBConfig struct {
+		Host    string        `mapstructure:"HOST"`
+		Timeout time.Duration `mapstructure:"TIMEOUT"`
+	}
+	type ServiceConfig struct {
+		DB      DBConfig          `mapstructure:"ORDERS_DB"`
+		Origins []string          `mapstructure:"ORDERS_ORIGINS"`
+		Labels  map[string]string `mapstructure:"ORDERS_LABELS"`
+	}
+
+	os.Setenv("ORDERS_DB_HOST", "db.internal")
+	os.Setenv("ORDERS_DB_TIMEOUT", "5s")
+	os.Setenv("ORDERS_ORIGINS", "https://a.example, https://b.example")
+	os.Setenv("ORDERS_LABELS", "team=core,tier=web")
+	defer func() {
+		for _, k := range []string{"ORDERS_DB_HOST", "ORDERS_DB_TIMEOUT", "ORDERS_ORIGINS", "ORDERS_LABELS"} {
+			os.Unsetenv(k)
+		}
+	}()
+
+	cfg, err := config.Load[ServiceConfig]()
+	if err != nil {
+		fmt.Printf("Error loading configuration: %v\n", err)
+		return
+	}
+
+	fmt.Println(cfg.DB.Host, cfg.DB.Timeout)
+	fmt.Println(cfg.Origins)
+	fmt.Println(cfg.Labels["team"], cfg.Labels["tier"])
+
+	// Output:
+	// db.internal 5s
+	// [https://a.example https://b.example]
+	// core web
+}
+
+func ExampleWatch() {
+	type RuntimeConfig struct {
+		LogLevel string `mapstructure:"RUNTIME_LOG_LEVEL"`
+	}
+
+	dir, _ := os.MkdirTemp("", "config-example")
+	defer os.RemoveAll(dir)
+	path := filepath.Join(dir, "runtime.env")
+	os.WriteFile(path, []byte("RUNTIME_LOG_LEVEL=info\n"), 0644)
+
+	w, err := config.Watch(path, func(cfg *RuntimeConfig) {
+		// Called after each change, for example to adjust the log level
+		fmt.Println("log level changed to", cfg.LogLevel)
+	})
+	if err != nil {
+		fmt.Printf("Error loading configuration: %v\n", err)
+		return
+	}
+	defer w.Close()
+
+	fmt.Println("log level:", w.Current().LogLevel)
+
+	// Output: log level: info
+}
+
+func ExampleDescribe() {
+	type DBConfig struct {
+		Host     string `mapstructure:"DESCRIBE_DB_HOST"`
+		Port     int    `mapstructure:"DESCRIBE_DB_PORT"`
+		Password string `mapstructure:"DESCRIBE_DB_PASSWORD" secret:"true"`
+	}
+
+	os.Setenv("DESCRIBE_DB_HOST", "db.internal")
+	os.Setenv("DESCRIBE_DB_PASSWORD", "hunter2")
+	defer os.Unsetenv("DESCRIBE_DB_HOST")
+	defer os.Unsetenv("DESCRIBE_DB_PASSWORD")
+
+	cfg, err := config.Load[DBConfig](config.WithDefaults(map[string]any{"DESCRIBE_DB_PORT": 5432}))
+	if err != nil {
+		fmt.Printf("Error loading configuration: %v\n", err)
+		return
+	}
+
+	fmt.Print(config.Describe(cfg))
+
+	// Output:
+	// DESCRIBE_DB_HOST      = "db.internal"  (env)
+	// DESCRIBE_DB_PORT      = 5432           (file/default)
+	// DESCRIBE_DB_PASSWORD  = [REDACTED]     (env)
+}
+
+func ExampleValidate() {
+	type AppConfig struct {
+		DBURL     string `mapstructure:"DB_URL" required:"true"`
+		RedisAddr string `mapstructure:"REDIS_ADDR" required:"true"`
+		APIKey    string `mapstructure:"API_KEY" required:"true"`
+		Port      int    `mapstructure:"APP_PORT"`
+	}
+
+	cfg := AppConfig{RedisAddr: "redis:6379", Port: 70000}
+	err := config.Validate(&cfg, config.Check("APP_PORT", func(port int) error {
+		if port < 1 || port > 65535 {
+			return errors.New("must be between 1 and 65535")
+		}
+		return nil
+	}))
+
+	fmt.Println(err)
+
+	// Output: missing required configuration: DB_URL, API_KEY; invalid configuration: APP_PORT: must be between 1 and 65535
+}
+
+func ExampleExample() {
+	type AppConfig struct {
+		Port   int    `mapstructure:"APP_PORT" default:"8080" doc:"HTTP listen port"`
+		DBURL  string `mapstructure:"DB_URL" required:"true" secret:"true" doc:"Postgres connection string"`
+		Region string `mapstructure:"REGION"`
+	}
+
+	fmt.Print(config.Example[AppConfig]())
+
+	// Output:
+	// # HTTP listen port
+	// APP_PORT=8080
+	//
+	// # Postgres connection string (required, secret)
+	// DB_URL=
+	//
+	// REGION=
+}
@@ -0,0 +1,87 @@
+/*
+Package featureflags provides feature flags loaded from the environment and
+config files, with percent rollouts, variants, per-request overrides and
+change notifications.
+
+# Features
+
+  - Bool, percent rollout and string variant flags
+  - Flags from defaults, YAML, JSON, TOML and .env files, and the environment
+  - Stable percent rollouts keyed by user, tenant or any other key
+  - Per-request overrides through a header, for testing in production
+  - Hot reloading with Watch and change notifications with OnChange
+  - A Flags interface for substituting other providers
+
+# Defining Flags
+
+A flag's kind follows from its value: booleans such as "true" or "off",
+percentages such as "25%", and anything else is a variant name. Environment
+variables starting with FEATURE_ define flags, as do files:
+
+	FEATURE_NEW_CHECKOUT=true
+	FEATURE_SEARCH_V2=25%
+	FEATURE_CHECKOUT_THEME=dark
+
+	# flags.yaml
+	new_checkout: true
+	search_v2: 25%
+	checkout_theme: dark
+
+Names are case-insensitive and treat dashes as underscores, so
+"new-checkout" and NEW_CHECKOUT name the same flag.
+
+# Usage
+
+	flags, err := featureflags.New(
+	    featureflags.WithDefaults(map[string]string{"search_v2": "off"}),
+	    featureflags.WithFile("/etc/app/flags.yaml"),
+	)
+	if err != nil {
+	    return err
+	}
+
+	if flags.IsEnabled(ctx, "search_v2") {
+	    return searchV2(ctx, query)
+	}
+
+	switch flags.Variant(ctx, "checkout_theme") {
+	case "dark":
+	    ...
+	}
+
+The environment overrides files, later files override earlier ones, and
+files override WithDefaults. Unknown flags are off.
+
+# Percent Rollouts
+
+A percent flag is on for a stable share of callers. Callers are identified
+by the key set with WithRolloutKey, then ctxutils.UserID, then
+ctxutils.TenantID; without a key the flag is off unless it is at 100%. The
+same caller stays in or out of a rollout as it grows, and each flag picks a
+different set of callers.
+
+	ctx = featureflags.WithRolloutKey(ctx, sessionID)
+
+# Per-Request Overrides
+
+OverrideMiddleware reads overrides from the X-Feature-Flags header, so a
+request can try a flag before it is rolled out:
+
+	X-Feature-Flags: search_v2=on, checkout_theme=light
+
+Apply it only to trusted traffic. WithOverrides sets overrides on a context
+directly, for example in tests.
+
+# Hot Toggling
+
+Watch reloads the sources periodically. OnChange is notified of each flag
+whose value changes, and Override changes a flag in the running process:
+
+	stop := flags.Watch(10 * time.Second)
+	defer stop()
+
+	flags.OnChange(func(c featureflags.Change) {
+	    log.Info("Flag changed", zap.String("flag", c.Name), zap.Stringer("value", c.New))
+	})
+*/
+package featureflags
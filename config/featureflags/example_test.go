@@ -0,0 +1,65 @@
+package featureflags_test
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/StairSupplies/go-core/config/featureflags"
+	"github.com/StairSupplies/go-core/ctxutils"
+)
+
+func ExampleNew() {
+	flags, err := featureflags.New(
+		featureflags.WithEnvPrefix("EXAMPLE_FEATURE_"),
+		featureflags.WithDefaults(map[string]string{
+			"new_checkout":   "on",
+			"search_v2":      "100%",
+			"checkout_theme": "dark",
+		}),
+	)
+	if err != nil {
+		fmt.Printf("Error loading flags: %v\n", err)
+		return
+	}
+
+	ctx := ctxutils.WithUserID(context.Background(), "user-42")
+	fmt.Println(flags.IsEnabled(ctx, "new-checkout"))
+	fmt.Println(flags.IsEnabled(ctx, "search_v2"))
+	fmt.Println(flags.Variant(ctx, "checkout_theme"))
+	fmt.Println(flags.IsEnabled(ctx, "unknown"))
+
+	// Output:
+	// true
+	// true
+	// dark
+	// false
+}
+
+func ExampleSet_OnChange() {
+	flags, _ := featureflags.New(featureflags.WithEnvPrefix("EXAMPLE_FEATURE_"))
+
+	flags.OnChange(func(c featureflags.Change) {
+		fmt.Printf("%s: %q -> %q\n", c.Name, c.Old, c.New)
+	})
+	flags.Override("bulk_pricing", "25%")
+	flags.ClearOverride("bulk_pricing")
+
+	// Output:
+	// bulk_pricing: "" -> "25%"
+	// bulk_pricing: "25%" -> ""
+}
+
+func ExampleWithOverrides() {
+	flags, _ := featureflags.New(
+		featureflags.WithEnvPrefix("EXAMPLE_FEATURE_"),
+		featureflags.WithDefaults(map[string]string{"search_v2": "off"}),
+	)
+
+	ctx := featureflags.WithOverrides(context.Background(), featureflags.ParseOverrides("search_v2=on"))
+	fmt.Println(flags.IsEnabled(context.Background(), "search_v2"))
+	fmt.Println(flags.IsEnabled(ctx, "search_v2"))
+
+	// Output:
+	// false
+	// true
+}
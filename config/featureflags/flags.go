@@ -0,0 +1,496 @@
+package featureflags
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"hash/fnv"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/StairSupplies/go-core/ctxutils"
+	"github.com/joho/godotenv"
+	"github.com/spf13/viper"
+)
+
+// DefaultEnvPrefix marks the environment variables that hold flags, so
+// FEATURE_NEW_CHECKOUT=true defines the flag "new_checkout"
+const DefaultEnvPrefix = "FEATURE_"
+
+// DefaultWatchInterval is how often Watch reloads flags by default
+const DefaultWatchInterval = 5 * time.Second
+
+// Kind is the type of a flag, decided by the form of its value
+type Kind string
+
+const (
+	// KindBool flags are on or off: "true", "false", "on", "off", "yes",
+	// "no", "1" or "0"
+	KindBool Kind = "bool"
+	// KindPercent flags are on for a stable share of callers, written as
+	// "25%"
+	KindPercent Kind = "percent"
+	// KindVariant flags select a named variant, written as any other value
+	// such as "blue"
+	KindVariant Kind = "variant"
+)
+
+// Flag is the parsed value of a feature flag
+type Flag struct {
+	// Kind is the type of the flag; it is empty for an undefined flag
+	Kind Kind
+	// Enabled is the value of a bool flag
+	Enabled bool
+	// Percent is the share of callers, from 0 to 100, a percent flag is on for
+	Percent float64
+	// Variant is the value of a variant flag
+	Variant string
+}
+
+// Parse parses a flag value: a boolean, a percentage such as "25%", or
+// otherwise a variant name. An empty value is an off bool flag.
+func Parse(value string) (Flag, error) {
+	value = strings.TrimSpace(value)
+	switch strings.ToLower(value) {
+	case "", "false", "off", "no", "0":
+		return Flag{Kind: KindBool}, nil
+	case "true", "on", "yes", "1":
+		return Flag{Kind: KindBool, Enabled: true}, nil
+	}
+	if p, ok := strings.CutSuffix(value, "%"); ok {
+		percent, err := strconv.ParseFloat(strings.TrimSpace(p), 64)
+		if err != nil || percent < 0 || percent > 100 {
+			return Flag{}, fmt.Errorf("featureflags: invalid percentage %q", value)
+		}
+		return Flag{Kind: KindPercent, Percent: percent}, nil
+	}
+	return Flag{Kind: KindVariant, Variant: value}, nil
+}
+
+// String formats the flag in the form Parse reads
+func (f Flag) String() string {
+	switch f.Kind {
+	case KindBool:
+		return strconv.FormatBool(f.Enabled)
+	case KindPercent:
+		return strconv.FormatFloat(f.Percent, 'f', -1, 64) + "%"
+	case KindVariant:
+		return f.Variant
+	}
+	return ""
+}
+
+// enabledFor reports whether the flag is on for rollout key
+func (f Flag) enabledFor(name, key string) bool {
+	switch f.Kind {
+	case KindBool:
+		return f.Enabled
+	case KindVariant:
+		return true
+	case KindPercent:
+		if f.Percent >= 100 {
+			return true
+		}
+		if key == "" || f.Percent <= 0 {
+			return false
+		}
+		return float64(bucket(name, key)) < f.Percent*100
+	}
+	return false
+}
+
+// bucket places key in one of 10000 buckets, differently for each flag so
+// the same callers are not always first in every rollout
+func bucket(name, key string) uint32 {
+	h := fnv.New32a()
+	h.Write([]byte(name))
+	h.Write([]byte{0})
+	h.Write([]byte(key))
+	return h.Sum32() % 10000
+}
+
+// Change describes a flag whose value changed on reload or override. Old
+// has an empty Kind for a new flag and New for a removed one.
+type Change struct {
+	Name string
+	Old  Flag
+	New  Flag
+}
+
+// Flags reports the state of feature flags for a request
+type Flags interface {
+	// IsEnabled reports whether the named flag is on for ctx. Unknown flags
+	// are off.
+	IsEnabled(ctx context.Context, name string) bool
+	// Variant returns the variant of the named flag for ctx, or "" if it is
+	// not a variant flag
+	Variant(ctx context.Context, name string) string
+}
+
+// settings holds the settings applied by Option
+type settings struct {
+	defaults  map[string]string
+	files     []string
+	envPrefix string
+	onError   func(error)
+}
+
+// Option configures New
+type Option func(*settings)
+
+// WithDefaults sets flag values used when no file or environment variable
+// defines a flag
+func WithDefaults(defaults map[string]string) Option {
+	return func(s *settings) {
+		if s.defaults == nil {
+			s.defaults = make(map[string]string)
+		}
+		for k, v := range defaults {
+			s.defaults[k] = v
+		}
+	}
+}
+
+// WithFile adds a file of flags. YAML, JSON and TOML files map flag names to
+// values; any other file is read as a .env file of prefixed variables.
+// Later files override earlier ones, and a missing file is skipped.
+func WithFile(path string) Option {
+	return func(s *settings) {
+		s.files = append(s.files, path)
+	}
+}
+
+// WithEnvPrefix sets the prefix of environment variables that define
+// flags, DefaultEnvPrefix by default. An empty prefix disables the
+// environment.
+func WithEnvPrefix(prefix string) Option {
+	return func(s *settings) {
+		s.envPrefix = prefix
+	}
+}
+
+// WithErrorHandler sets a function called when a reload started by Watch
+// fails. The previous flags stay in effect until a reload succeeds.
+func WithErrorHandler(fn func(error)) Option {
+	return func(s *settings) {
+		s.onError = fn
+	}
+}
+
+// Set is a Flags loaded from defaults, files and the environment. It is
+// safe for concurrent use.
+type Set struct {
+	settings settings
+
+	mu        sync.RWMutex
+	loaded    map[string]Flag
+	overrides map[string]Flag
+	listeners []func(Change)
+}
+
+// New loads flags with precedence, from lowest to highest: WithDefaults,
+// files in the order given, and environment variables.
+func New(opts ...Option) (*Set, error) {
+	s := &Set{settings: settings{envPrefix: DefaultEnvPrefix}}
+	for _, opt := range opts {
+		opt(&s.settings)
+	}
+	if err := s.Reload(); err != nil {
+		return nil, err
+	}
+	return s, nil
+}
+
+// Name normalizes a flag name: lowercase with dashes as underscores, so
+// "New-Checkout" and NEW_CHECKOUT both name "new_checkout"
+func Name(name string) string {
+	return strings.ReplaceAll(strings.ToLower(strings.TrimSpace(name)), "-", "_")
+}
+
+// IsEnabled implements Flags. Per-request overrides from WithOverrides come
+// first. Percent flags are on for a stable share of rollout keys, taken from
+// WithRolloutKey, then ctxutils.UserID, then ctxutils.TenantID; without a
+// key they are off unless set to 100%.
+func (s *Set) IsEnabled(ctx context.Context, name string) bool {
+	name = Name(name)
+	return s.flag(ctx, name).enabledFor(name, rolloutKey(ctx))
+}
+
+// Variant implements Flags
+func (s *Set) Variant(ctx context.Context, name string) string {
+	f := s.flag(ctx, Name(name))
+	if f.Kind != KindVariant {
+		return ""
+	}
+	return f.Variant
+}
+
+// Flag returns the current value of the named flag, ignoring per-request
+// overrides, and whether it is defined
+func (s *Set) Flag(name string) (Flag, bool) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	f, ok := s.current(Name(name))
+	return f, ok
+}
+
+// All returns the current value of every defined flag
+func (s *Set) All() map[string]Flag {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	all := make(map[string]Flag, len(s.loaded)+len(s.overrides))
+	for name, f := range s.loaded {
+		all[name] = f
+	}
+	for name, f := range s.overrides {
+		all[name] = f
+	}
+	return all
+}
+
+// flag returns the named flag for ctx
+func (s *Set) flag(ctx context.Context, name string) Flag {
+	if f, ok := overridesKey.ValueOr(ctx, nil)[name]; ok {
+		return f
+	}
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	f, _ := s.current(name)
+	return f
+}
+
+// current returns the named flag; s.mu must be held
+func (s *Set) current(name string) (Flag, bool) {
+	if f, ok := s.overrides[name]; ok {
+		return f, true
+	}
+	f, ok := s.loaded[name]
+	return f, ok
+}
+
+// Override sets the named flag in this process, taking precedence over
+// every source until ClearOverride is called, for example from an admin
+// endpoint or a test. Listeners are notified if the value changes.
+func (s *Set) Override(name, value string) error {
+	f, err := Parse(value)
+	if err != nil {
+		return err
+	}
+	name = Name(name)
+
+	s.mu.Lock()
+	old, _ := s.current(name)
+	if s.overrides == nil {
+		s.overrides = make(map[string]Flag)
+	}
+	s.overrides[name] = f
+	listeners := s.listeners
+	s.mu.Unlock()
+
+	if old != f {
+		notify(listeners, []Change{{Name: name, Old: old, New: f}})
+	}
+	return nil
+}
+
+// ClearOverride removes an override set with Override
+func (s *Set) ClearOverride(name string) {
+	name = Name(name)
+
+	s.mu.Lock()
+	old, _ := s.current(name)
+	delete(s.overrides, name)
+	f, _ := s.current(name)
+	listeners := s.listeners
+	s.mu.Unlock()
+
+	if old != f {
+		notify(listeners, []Change{{Name: name, Old: old, New: f}})
+	}
+}
+
+// OnChange adds a function called for each flag whose value changes on
+// Reload, Override or ClearOverride, for hot toggling of behavior that is
+// set up once, such as a background worker
+func (s *Set) OnChange(fn func(Change)) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.listeners = append(s.listeners, fn)
+}
+
+// Reload reads the sources again and notifies listeners of flags whose
+// value changed. On error the current flags are kept.
+func (s *Set) Reload() error {
+	loaded, err := s.load()
+	if err != nil {
+		return err
+	}
+
+	s.mu.Lock()
+	var changes []Change
+	for name := range union(s.loaded, loaded) {
+		if _, overridden := s.overrides[name]; overridden {
+			continue
+		}
+		if old, f := s.loaded[name], loaded[name]; old != f {
+			changes = append(changes, Change{Name: name, Old: old, New: f})
+		}
+	}
+	s.loaded = loaded
+	listeners := s.listeners
+	s.mu.Unlock()
+
+	notify(listeners, changes)
+	return nil
+}
+
+// Watch reloads the flags every interval, DefaultWatchInterval if zero,
+// until the returned function is called. Failed reloads are reported to
+// WithErrorHandler.
+func (s *Set) Watch(interval time.Duration) (stop func()) {
+	if interval <= 0 {
+		interval = DefaultWatchInterval
+	}
+	done := make(chan struct{})
+	var once sync.Once
+
+	go func() {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-done:
+				return
+			case <-ticker.C:
+				if err := s.Reload(); err != nil && s.settings.onError != nil {
+					s.settings.onError(err)
+				}
+			}
+		}
+	}()
+
+	return func() { once.Do(func() { close(done) }) }
+}
+
+// load reads the flags from every source
+func (s *Set) load() (map[string]Flag, error) {
+	values := make(map[string]string)
+	for name, v := range s.settings.defaults {
+		values[Name(name)] = v
+	}
+
+	for _, path := range s.settings.files {
+		if _, err := os.Stat(path); errors.Is(err, os.ErrNotExist) {
+			continue
+		}
+		if err := s.readFile(path, values); err != nil {
+			return nil, err
+		}
+	}
+
+	if prefix := s.settings.envPrefix; prefix != "" {
+		for _, kv := range os.Environ() {
+			k, v, _ := strings.Cut(kv, "=")
+			if name, ok := strings.CutPrefix(k, prefix); ok && name != "" {
+				values[Name(name)] = v
+			}
+		}
+	}
+
+	flags := make(map[string]Flag, len(values))
+	for name, v := range values {
+		f, err := Parse(v)
+		if err != nil {
+			return nil, fmt.Errorf("flag %s: %w", name, err)
+		}
+		flags[name] = f
+	}
+	return flags, nil
+}
+
+// readFile adds the flags in path to values
+func (s *Set) readFile(path string, values map[string]string) error {
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".yaml", ".yml", ".json", ".toml":
+		v := viper.New()
+		v.SetConfigFile(path)
+		if err := v.ReadInConfig(); err != nil {
+			return fmt.Errorf("failed to read flags file %s: %w", path, err)
+		}
+		for name, val := range v.AllSettings() {
+			values[Name(name)] = fmt.Sprint(val)
+		}
+	default:
+		env, err := godotenv.Read(path)
+		if err != nil {
+			return fmt.Errorf("failed to read flags file %s: %w", path, err)
+		}
+		for k, val := range env {
+			if name, ok := strings.CutPrefix(k, s.settings.envPrefix); ok && name != "" {
+				values[Name(name)] = val
+			}
+		}
+	}
+	return nil
+}
+
+// union returns the names defined in a or b
+func union(a, b map[string]Flag) map[string]struct{} {
+	names := make(map[string]struct{}, len(a)+len(b))
+	for name := range a {
+		names[name] = struct{}{}
+	}
+	for name := range b {
+		names[name] = struct{}{}
+	}
+	return names
+}
+
+// notify calls every listener with every change
+func notify(listeners []func(Change), changes []Change) {
+	for _, c := range changes {
+		for _, fn := range listeners {
+			fn(c)
+		}
+	}
+}
+
+var (
+	rolloutKeyKey = ctxutils.NewKey[string]("featureflags_rollout_key")
+	overridesKey  = ctxutils.NewKey[map[string]Flag]("featureflags_overrides")
+)
+
+// WithRolloutKey returns a copy of ctx that places the caller in percent
+// rollouts by key, such as a session or device ID, instead of the user ID
+func WithRolloutKey(ctx context.Context, key string) context.Context {
+	return rolloutKeyKey.WithValue(ctx, key)
+}
+
+// rolloutKey returns the key that places ctx in percent rollouts
+func rolloutKey(ctx context.Context) string {
+	if key := rolloutKeyKey.ValueOr(ctx, ""); key != "" {
+		return key
+	}
+	if id := ctxutils.UserID(ctx); id != "" {
+		return id
+	}
+	return ctxutils.TenantID(ctx)
+}
+
+// WithOverrides returns a copy of ctx in which the given flags take the
+// given values, on top of any overrides already in ctx
+func WithOverrides(ctx context.Context, overrides map[string]Flag) context.Context {
+	merged := make(map[string]Flag)
+	for name, f := range overridesKey.ValueOr(ctx, nil) {
+		merged[name] = f
+	}
+	for name, f := range overrides {
+		merged[Name(name)] = f
+	}
+	return overridesKey.WithValue(ctx, merged)
+}
@@ -0,0 +1,249 @@
+package featureflags
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/StairSupplies/go-core/ctxutils"
+)
+
+func TestParse(t *testing.T) {
+	tests := []struct {
+		input   string
+		want    Flag
+		wantErr bool
+	}{
+		{"true", Flag{Kind: KindBool, Enabled: true}, false},
+		{"ON", Flag{Kind: KindBool, Enabled: true}, false},
+		{"1", Flag{Kind: KindBool, Enabled: true}, false},
+		{"off", Flag{Kind: KindBool}, false},
+		{"", Flag{Kind: KindBool}, false},
+		{"25%", Flag{Kind: KindPercent, Percent: 25}, false},
+		{" 12.5 % ", Flag{Kind: KindPercent, Percent: 12.5}, false},
+		{"dark", Flag{Kind: KindVariant, Variant: "dark"}, false},
+		{"150%", Flag{}, true},
+		{"lots%", Flag{}, true},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.input, func(t *testing.T) {
+			got, err := Parse(tc.input)
+			if (err != nil) != tc.wantErr {
+				t.Fatalf("Expected error %v, got %v", tc.wantErr, err)
+			}
+			if got != tc.want {
+				t.Errorf("Expected %+v, got %+v", tc.want, got)
+			}
+		})
+	}
+}
+
+func TestFlagString(t *testing.T) {
+	for _, value := range []string{"true", "false", "25%", "12.5%", "dark"} {
+		f, _ := Parse(value)
+		if f.String() != value {
+			t.Errorf("Expected %q, got %q", value, f.String())
+		}
+	}
+}
+
+func TestNew_Sources(t *testing.T) {
+	dir := t.TempDir()
+	yamlFile := filepath.Join(dir, "flags.yaml")
+	os.WriteFile(yamlFile, []byte("new-checkout: false\nsearch_v2: 25%\ncheckout_theme: dark\nbulk_pricing: true\n"), 0o644)
+	envFile := filepath.Join(dir, "flags.env")
+	os.WriteFile(envFile, []byte("FFTEST_CHECKOUT_THEME=light\nOTHER=ignored\n"), 0o644)
+	t.Setenv("FFTEST_NEW_CHECKOUT", "on")
+
+	flags, err := New(
+		WithEnvPrefix("FFTEST_"),
+		WithDefaults(map[string]string{"bulk_pricing": "off", "legacy_export": "on"}),
+		WithFile(yamlFile),
+		WithFile(envFile),
+		WithFile(filepath.Join(dir, "missing.yaml")),
+	)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	ctx := context.Background()
+	if !flags.IsEnabled(ctx, "NEW_CHECKOUT") {
+		t.Error("Expected the environment to override the file")
+	}
+	if got := flags.Variant(ctx, "checkout-theme"); got != "light" {
+		t.Errorf("Expected later files to override earlier ones, got %q", got)
+	}
+	if !flags.IsEnabled(ctx, "bulk_pricing") {
+		t.Error("Expected files to override defaults")
+	}
+	if !flags.IsEnabled(ctx, "legacy_export") {
+		t.Error("Expected defaults to be used")
+	}
+	if flags.IsEnabled(ctx, "unknown") || flags.Variant(ctx, "unknown") != "" {
+		t.Error("Expected unknown flags to be off")
+	}
+	if _, ok := flags.Flag("other"); ok {
+		t.Error("Expected unprefixed .env variables to be ignored")
+	}
+	if len(flags.All()) != 5 {
+		t.Errorf("Expected 5 flags, got %v", flags.All())
+	}
+}
+
+func TestNew_InvalidValue(t *testing.T) {
+	if _, err := New(WithEnvPrefix(""), WithDefaults(map[string]string{"x": "200%"})); err == nil {
+		t.Error("Expected an error for an invalid percentage")
+	}
+}
+
+func TestIsEnabled_PercentRollout(t *testing.T) {
+	flags, _ := New(WithEnvPrefix(""), WithDefaults(map[string]string{
+		"half": "50%", "none": "0%", "all": "100%",
+	}))
+
+	if flags.IsEnabled(context.Background(), "half") {
+		t.Error("Expected a percent flag to be off without a rollout key")
+	}
+	if !flags.IsEnabled(context.Background(), "all") {
+		t.Error("Expected a 100% flag to be on without a rollout key")
+	}
+
+	on := 0
+	for i := 0; i < 1000; i++ {
+		ctx := ctxutils.WithUserID(context.Background(), fmt.Sprintf("user-%d", i))
+		enabled := flags.IsEnabled(ctx, "half")
+		if enabled != flags.IsEnabled(ctx, "half") {
+			t.Fatal("Expected the same answer for the same user")
+		}
+		if enabled {
+			on++
+		}
+		if flags.IsEnabled(ctx, "none") {
+			t.Fatal("Expected a 0% flag to be off")
+		}
+	}
+	if on < 400 || on > 600 {
+		t.Errorf("Expected about half of users in a 50%% rollout, got %d of 1000", on)
+	}
+
+	// The rollout key takes precedence over the user and tenant
+	ctx := ctxutils.WithTenantID(context.Background(), "acme")
+	byTenant := flags.IsEnabled(ctx, "half")
+	if byTenant != flags.IsEnabled(WithRolloutKey(context.Background(), "acme"), "half") {
+		t.Error("Expected the tenant to be used as the rollout key")
+	}
+}
+
+func TestOverride(t *testing.T) {
+	flags, _ := New(WithEnvPrefix(""), WithDefaults(map[string]string{"new_checkout": "off"}))
+
+	var mu sync.Mutex
+	var changes []Change
+	flags.OnChange(func(c Change) {
+		mu.Lock()
+		defer mu.Unlock()
+		changes = append(changes, c)
+	})
+
+	if err := flags.Override("New-Checkout", "on"); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if !flags.IsEnabled(context.Background(), "new_checkout") {
+		t.Error("Expected the override to turn the flag on")
+	}
+	flags.Override("new_checkout", "on")
+	if err := flags.Override("new_checkout", "x%"); err == nil {
+		t.Error("Expected an error for an invalid value")
+	}
+
+	flags.ClearOverride("new_checkout")
+	if flags.IsEnabled(context.Background(), "new_checkout") {
+		t.Error("Expected the loaded value after clearing the override")
+	}
+
+	if len(changes) != 2 {
+		t.Fatalf("Expected 2 changes, got %v", changes)
+	}
+	if changes[0].Name != "new_checkout" || !changes[0].New.Enabled || changes[0].Old.Enabled {
+		t.Errorf("Expected new_checkout to turn on, got %+v", changes[0])
+	}
+	if changes[1].New.Enabled {
+		t.Errorf("Expected new_checkout to turn off, got %+v", changes[1])
+	}
+}
+
+func TestReload(t *testing.T) {
+	file := filepath.Join(t.TempDir(), "flags.json")
+	os.WriteFile(file, []byte(`{"search_v2": "off", "theme": "dark"}`), 0o644)
+
+	flags, err := New(WithEnvPrefix(""), WithFile(file))
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	changes := make(chan Change, 10)
+	flags.OnChange(func(c Change) { changes <- c })
+
+	os.WriteFile(file, []byte(`{"search_v2": "on", "theme": "dark", "bulk": "10%"}`), 0o644)
+	if err := flags.Reload(); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if !flags.IsEnabled(context.Background(), "search_v2") {
+		t.Error("Expected the reloaded value")
+	}
+	got := map[string]Change{}
+	for len(changes) > 0 {
+		c := <-changes
+		got[c.Name] = c
+	}
+	if len(got) != 2 || got["bulk"].Old.Kind != "" || got["bulk"].New.Percent != 10 {
+		t.Errorf("Expected changes to search_v2 and a new bulk flag, got %+v", got)
+	}
+
+	// A failed reload keeps the current flags
+	os.WriteFile(file, []byte(`{"search_v2": "300%"}`), 0o644)
+	if err := flags.Reload(); err == nil {
+		t.Error("Expected an error for an invalid value")
+	}
+	if !flags.IsEnabled(context.Background(), "search_v2") {
+		t.Error("Expected the previous flags to be kept")
+	}
+}
+
+func TestWatch(t *testing.T) {
+	file := filepath.Join(t.TempDir(), "flags.yaml")
+	os.WriteFile(file, []byte("search_v2: off\n"), 0o644)
+
+	errs := make(chan error, 10)
+	flags, _ := New(WithEnvPrefix(""), WithFile(file), WithErrorHandler(func(err error) { errs <- err }))
+
+	changed := make(chan Change, 1)
+	flags.OnChange(func(c Change) { changed <- c })
+
+	stop := flags.Watch(10 * time.Millisecond)
+	defer stop()
+
+	os.WriteFile(file, []byte("search_v2: on\n"), 0o644)
+	select {
+	case c := <-changed:
+		if c.Name != "search_v2" || !c.New.Enabled {
+			t.Errorf("Expected search_v2 to turn on, got %+v", c)
+		}
+	case <-time.After(2 * time.Second):
+		t.Fatal("Expected a change notification")
+	}
+
+	os.WriteFile(file, []byte("search_v2: 500%\n"), 0o644)
+	select {
+	case <-errs:
+	case <-time.After(2 * time.Second):
+		t.Fatal("Expected the reload error to be reported")
+	}
+	stop()
+	stop()
+}
@@ -0,0 +1,56 @@
+package featureflags
+
+import (
+	"net/http"
+	"strings"
+)
+
+// DefaultOverrideHeader is the header OverrideMiddleware reads by default
+const DefaultOverrideHeader = "X-Feature-Flags"
+
+// OverrideMiddleware lets a request override flags for itself with a header
+// such as "X-Feature-Flags: new_checkout=on, theme=dark". A name without a
+// value turns the flag on; entries with invalid values are ignored. The
+// header is read from header, DefaultOverrideHeader if empty.
+//
+// Anyone who can send the header can change the flags of their request, so
+// apply it only to internal traffic or behind authentication:
+//
+//	r.With(router.Auth(staffAuth), featureflags.OverrideMiddleware("")).Get("/checkout", checkout)
+func OverrideMiddleware(header string) func(next http.Handler) http.Handler {
+	if header == "" {
+		header = DefaultOverrideHeader
+	}
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			overrides := ParseOverrides(strings.Join(r.Header.Values(header), ","))
+			if len(overrides) == 0 {
+				next.ServeHTTP(w, r)
+				return
+			}
+			next.ServeHTTP(w, r.WithContext(WithOverrides(r.Context(), overrides)))
+		})
+	}
+}
+
+// ParseOverrides parses a comma-separated list of name=value overrides, as
+// read by OverrideMiddleware
+func ParseOverrides(s string) map[string]Flag {
+	overrides := make(map[string]Flag)
+	for _, entry := range strings.Split(s, ",") {
+		name, value, ok := strings.Cut(entry, "=")
+		name = Name(name)
+		if name == "" {
+			continue
+		}
+		if !ok {
+			value = "on"
+		}
+		f, err := Parse(value)
+		if err != nil {
+			continue
+		}
+		overrides[name] = f
+	}
+	return overrides
+}
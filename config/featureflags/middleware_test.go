@@ -0,0 +1,62 @@
+package featureflags
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestParseOverrides(t *testing.T) {
+	got := ParseOverrides(" Search-V2=on, theme=light, beta, bad=200%, =x")
+	want := map[string]Flag{
+		"search_v2": {Kind: KindBool, Enabled: true},
+		"theme":     {Kind: KindVariant, Variant: "light"},
+		"beta":      {Kind: KindBool, Enabled: true},
+	}
+	if len(got) != len(want) {
+		t.Fatalf("Expected %v, got %v", want, got)
+	}
+	for name, f := range want {
+		if got[name] != f {
+			t.Errorf("Expected %s = %+v, got %+v", name, f, got[name])
+		}
+	}
+}
+
+func TestOverrideMiddleware(t *testing.T) {
+	flags, _ := New(WithEnvPrefix(""), WithDefaults(map[string]string{"search_v2": "off", "theme": "dark"}))
+
+	var enabled bool
+	var theme string
+	h := OverrideMiddleware("")(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		enabled = flags.IsEnabled(r.Context(), "search_v2")
+		theme = flags.Variant(r.Context(), "theme")
+	}))
+
+	req := httptest.NewRequest(http.MethodGet, "/", nil)
+	h.ServeHTTP(httptest.NewRecorder(), req)
+	if enabled || theme != "dark" {
+		t.Errorf("Expected loaded flags without the header, got %v %q", enabled, theme)
+	}
+
+	req.Header.Set(DefaultOverrideHeader, "search_v2=on, theme=light")
+	h.ServeHTTP(httptest.NewRecorder(), req)
+	if !enabled || theme != "light" {
+		t.Errorf("Expected overridden flags, got %v %q", enabled, theme)
+	}
+
+	if flags.IsEnabled(context.Background(), "search_v2") {
+		t.Error("Expected overrides to apply only to the request")
+	}
+}
+
+func TestWithOverrides_Merge(t *testing.T) {
+	ctx := WithOverrides(context.Background(), map[string]Flag{"a": {Kind: KindBool, Enabled: true}})
+	ctx = WithOverrides(ctx, map[string]Flag{"B": {Kind: KindBool, Enabled: true}})
+
+	flags, _ := New(WithEnvPrefix(""))
+	if !flags.IsEnabled(ctx, "a") || !flags.IsEnabled(ctx, "b") {
+		t.Error("Expected overrides to be merged")
+	}
+}
@@ -0,0 +1,224 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"reflect"
+	"strings"
+
+	"github.com/joho/godotenv"
+	"github.com/spf13/viper"
+)
+
+// source is a single configuration layer
+type source struct {
+	path     string
+	optional bool
+	env      bool
+}
+
+// loader collects the layers applied by Load
+type loader struct {
+	defaults map[string]any
+	sources  []source
+	// envDir is where NewForEnv looks for .env profile files
+	envDir string
+	// rules are checked by Validate after loading
+	rules []Rule
+}
+
+// LoadOption configures Load
+type LoadOption func(*loader)
+
+// WithFile adds a YAML, JSON or TOML file, detected by extension. Files are
+// applied in the order given, later files overriding earlier ones. A missing
+// file is an error.
+func WithFile(path string) LoadOption {
+	return func(l *loader) {
+		l.sources = append(l.sources, source{path: path})
+	}
+}
+
+// WithOptionalFile is like WithFile but silently skips a missing file
+func WithOptionalFile(path string) LoadOption {
+	return func(l *loader) {
+		l.sources = append(l.sources, source{path: path, optional: true})
+	}
+}
+
+// WithEnvFile adds a .env file. Unlike New, its values are not exported to
+// the process environment. A missing file is skipped.
+func WithEnvFile(path string) LoadOption {
+	return func(l *loader) {
+		l.sources = append(l.sources, source{path: path, optional: true, env: true})
+	}
+}
+
+// WithEnvDir sets the directory NewForEnv reads its .env files from,
+// instead of the working directory. Load ignores it.
+func WithEnvDir(dir string) LoadOption {
+	return func(l *loader) {
+		l.envDir = dir
+	}
+}
+
+// WithDefaults sets values used when no other layer provides a key
+func WithDefaults(defaults map[string]any) LoadOption {
+	return func(l *loader) {
+		if l.defaults == nil {
+			l.defaults = make(map[string]any)
+		}
+		for k, v := range defaults {
+			l.defaults[k] = v
+		}
+	}
+}
+
+// Load creates a configuration instance of type T from layered sources.
+// Precedence, from lowest to highest, is:
+//
+//  1. defaults from WithDefaults, then from `default:"..."` struct tags
+//  2. config files from WithFile and WithOptionalFile, in the order given
+//  3. .env files from WithEnvFile, in the order given
+//  4. environment variables
+//
+// Keys match mapstructure tags case-insensitively, so a field tagged
+// `mapstructure:"APP_PORT"` is read from "app_port" in a YAML file and from
+// the APP_PORT environment variable.
+//
+// The result is checked with Validate, using the rules from WithValidation;
+// a *ValidationError lists every missing or invalid value.
+func Load[T any](opts ...LoadOption) (*T, error) {
+	l := &loader{}
+	for _, opt := range opts {
+		opt(l)
+	}
+
+	v := viper.New()
+	for k, val := range l.defaults {
+		v.SetDefault(k, val)
+	}
+
+	// Config files are merged before .env files regardless of option order
+	for _, src := range l.sources {
+		if src.env {
+			continue
+		}
+		if src.optional {
+			if _, err := os.Stat(src.path); errors.Is(err, os.ErrNotExist) {
+				continue
+			}
+		}
+		v.SetConfigFile(src.path)
+		if err := v.MergeInConfig(); err != nil {
+			return nil, fmt.Errorf("failed to read config file %s: %w", src.path, err)
+		}
+	}
+
+	var cfg T
+	envKeys, err := bindEnv(v, reflect.TypeOf(cfg))
+	if err != nil {
+		return nil, err
+	}
+
+	for _, src := range l.sources {
+		if !src.env {
+			continue
+		}
+		values, err := godotenv.Read(src.path)
+		if errors.Is(err, os.ErrNotExist) {
+			continue
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to read env file %s: %w", src.path, err)
+		}
+		envMap := make(map[string]any, len(values))
+		for k, val := range values {
+			key := k
+			if bound, ok := envKeys[k]; ok {
+				key = bound
+			}
+			setPath(envMap, strings.Split(key, "."), val)
+		}
+		if err := v.MergeConfigMap(envMap); err != nil {
+			return nil, fmt.Errorf("failed to merge env file %s: %w", src.path, err)
+		}
+	}
+
+	if err := v.Unmarshal(&cfg, viper.DecodeHook(decodeHook)); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal configuration: %w", err)
+	}
+
+	if err := Validate(&cfg, l.rules...); err != nil {
+		return nil, err
+	}
+
+	return &cfg, nil
+}
+
+// bindEnv binds each field with a mapstructure tag to its environment
+// variable and returns the config key bound to each variable. Fields of
+// nested structs are bound under the struct's tag, so HOST in a struct tagged
+// DB is the key "DB.HOST" and the variable DB_HOST. A field's default tag is
+// set as its default unless another default or a config file provides it.
+func bindEnv(v *viper.Viper, t reflect.Type) (map[string]string, error) {
+	// Handle both struct types and pointers to struct types
+	if t != nil && t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+
+	// Ensure we're working with a struct
+	if t == nil || t.Kind() != reflect.Struct {
+		return nil, fmt.Errorf("config type must be a struct or pointer to struct")
+	}
+
+	keys := make(map[string]string)
+	if err := bindFields(v, t, "", "", keys); err != nil {
+		return nil, err
+	}
+	return keys, nil
+}
+
+// bindFields binds the fields of struct type t, prefixing their keys with
+// keyPrefix and their environment variables with envPrefix
+func bindFields(v *viper.Viper, t reflect.Type, keyPrefix, envPrefix string, keys map[string]string) error {
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		name, opts, _ := strings.Cut(field.Tag.Get("mapstructure"), ",")
+
+		ft := field.Type
+		if ft.Kind() == reflect.Ptr {
+			ft = ft.Elem()
+		}
+		nested := ft.Kind() == reflect.Struct && ft != durationType && ft != timeType
+
+		if nested && name == "" && strings.Contains(opts, "squash") {
+			if err := bindFields(v, ft, keyPrefix, envPrefix, keys); err != nil {
+				return err
+			}
+			continue
+		}
+		if name == "" || name == "-" {
+			continue
+		}
+
+		if nested {
+			if err := bindFields(v, ft, keyPrefix+name+".", envPrefix+name+"_", keys); err != nil {
+				return err
+			}
+			continue
+		}
+
+		key, envVar := keyPrefix+name, envPrefix+name
+		if def, ok := field.Tag.Lookup("default"); ok && !v.IsSet(key) {
+			v.SetDefault(key, def)
+		}
+		if err := v.BindEnv(key, envVar); err != nil {
+			return fmt.Errorf("failed to bind environment variable %s: %w", envVar, err)
+		}
+		keys[envVar] = key
+	}
+
+	return nil
+}
@@ -0,0 +1,135 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/StairSupplies/go-core/logger"
+	"github.com/StairSupplies/go-core/testutils"
+)
+
+func writeFile(t *testing.T, dir, name, content string) string {
+	t.Helper()
+	path := filepath.Join(dir, name)
+	if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+		t.Fatalf("Failed to write %s: %v", name, err)
+	}
+	return path
+}
+
+func TestLoad(t *testing.T) {
+	// Other tests load .env files into the process environment
+	testutils.UnsetEnv(t, "APP_NAME", "APP_PORT", "LOG_LEVEL", "DB_URL", "ENABLE_SSL")
+
+	dir := t.TempDir()
+	base := writeFile(t, dir, "base.yaml", `
+app_name: BaseApp
+app_port: 8080
+log_level: info
+db_url: postgres://base
+`)
+	override := writeFile(t, dir, "override.json", `{"LOG_LEVEL": "debug", "ENABLE_SSL": true}`)
+	envFile := writeFile(t, dir, "test.env", "DB_URL=postgres://from-env-file\n")
+
+	t.Run("precedence", func(t *testing.T) {
+		t.Setenv("APP_PORT", "9090")
+
+		cfg, err := Load[TestConfig](
+			WithDefaults(map[string]any{"APP_NAME": "DefaultApp", "LOG_LEVEL": "warn"}),
+			WithEnvFile(envFile),
+			WithFile(base),
+			WithFile(override),
+		)
+		if err != nil {
+			t.Fatalf("Load() error = %v", err)
+		}
+
+		if cfg.AppName != "BaseApp" {
+			t.Errorf("Expected file to override default, got AppName = %q", cfg.AppName)
+		}
+		if cfg.LogLevel != "debug" {
+			t.Errorf("Expected later file to override earlier file, got LogLevel = %q", cfg.LogLevel)
+		}
+		if cfg.DBUrl != "postgres://from-env-file" {
+			t.Errorf("Expected .env file to override config files, got DBUrl = %q", cfg.DBUrl)
+		}
+		if cfg.AppPort != 9090 {
+			t.Errorf("Expected environment to override everything, got AppPort = %d", cfg.AppPort)
+		}
+		if !cfg.EnableSSL {
+			t.Error("Expected EnableSSL = true from JSON file")
+		}
+	})
+
+	t.Run("env file does not modify the environment", func(t *testing.T) {
+		if _, err := Load[TestConfig](WithEnvFile(envFile)); err != nil {
+			t.Fatalf("Load() error = %v", err)
+		}
+		if _, ok := os.LookupEnv("DB_URL"); ok {
+			t.Error("Expected DB_URL not to be exported")
+		}
+	})
+
+	t.Run("defaults only", func(t *testing.T) {
+		cfg, err := Load[TestConfig](WithDefaults(map[string]any{"APP_PORT": 3000}))
+		if err != nil {
+			t.Fatalf("Load() error = %v", err)
+		}
+		if cfg.AppPort != 3000 {
+			t.Errorf("Expected AppPort = 3000, got %d", cfg.AppPort)
+		}
+	})
+
+	t.Run("optional file", func(t *testing.T) {
+		if _, err := Load[TestConfig](WithOptionalFile(filepath.Join(dir, "missing.yaml"))); err != nil {
+			t.Errorf("Expected missing optional file to be skipped, got %v", err)
+		}
+		if _, err := Load[TestConfig](WithFile(filepath.Join(dir, "missing.yaml"))); err == nil {
+			t.Error("Expected error for missing required file")
+		}
+	})
+
+	t.Run("invalid file", func(t *testing.T) {
+		bad := writeFile(t, dir, "bad.yaml", "app_name: [unclosed")
+		_, err := Load[TestConfig](WithFile(bad))
+		if err == nil || !strings.Contains(err.Error(), "bad.yaml") {
+			t.Errorf("Expected error naming the file, got %v", err)
+		}
+	})
+
+	t.Run("invalid config type", func(t *testing.T) {
+		if _, err := Load[string](); err == nil {
+			t.Error("Expected error for invalid config type")
+		}
+	})
+}
+
+func TestLoad_LoggerEnvConfig(t *testing.T) {
+	type appConfig struct {
+		logger.EnvConfig `mapstructure:",squash"`
+		Port             int `mapstructure:"PORT"`
+	}
+
+	testutils.UnsetEnv(t, "LOG_FORMAT", "SERVICE_NAME", "ENV")
+	t.Setenv("LOG_LEVEL", "warn")
+	t.Setenv("SERVICE_NAME", "orders")
+	t.Setenv("PORT", "8080")
+
+	cfg, err := Load[appConfig]()
+	if err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+	if cfg.Level != "warn" || cfg.ServiceName != "orders" || cfg.Port != 8080 {
+		t.Errorf("Expected logging settings from the environment, got %+v", cfg)
+	}
+
+	log, err := logger.NewFromConfigStruct(cfg.EnvConfig)
+	if err != nil {
+		t.Fatalf("NewFromConfigStruct() error = %v", err)
+	}
+	if log.Level() != logger.WarnLevel {
+		t.Errorf("Expected level warn, got %v", log.Level())
+	}
+}
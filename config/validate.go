@@ -0,0 +1,137 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+)
+
+// ValidationError reports every problem Validate found with a configuration
+type ValidationError struct {
+	// Missing lists the keys of required fields that are not set, in field order
+	Missing []string
+	// Invalid maps keys to the errors returned by their rules
+	Invalid map[string]error
+}
+
+// Error lists the missing keys, then the invalid ones in key order:
+//
+//	missing required configuration: DB_URL, REDIS_ADDR; invalid configuration: APP_PORT: must be below 65536
+func (e *ValidationError) Error() string {
+	var parts []string
+	if len(e.Missing) > 0 {
+		parts = append(parts, "missing required configuration: "+strings.Join(e.Missing, ", "))
+	}
+	if len(e.Invalid) > 0 {
+		keys := make([]string, 0, len(e.Invalid))
+		for k := range e.Invalid {
+			keys = append(keys, k)
+		}
+		sort.Strings(keys)
+
+		invalid := make([]string, len(keys))
+		for i, k := range keys {
+			invalid[i] = fmt.Sprintf("%s: %v", k, e.Invalid[k])
+		}
+		parts = append(parts, "invalid configuration: "+strings.Join(invalid, ", "))
+	}
+	return strings.Join(parts, "; ")
+}
+
+// Rule checks the value of one configuration field
+type Rule struct {
+	// Key is the field's key as Describe shows it, such as "DB_HOST"
+	Key string
+	// Check returns an error describing what is wrong with value
+	Check func(value any) error
+}
+
+// Check returns a Rule for the field key whose value has type V:
+//
+//	config.Check("APP_PORT", func(port int) error {
+//		if port < 1 || port > 65535 {
+//			return errors.New("must be between 1 and 65535")
+//		}
+//		return nil
+//	})
+func Check[V any](key string, fn func(V) error) Rule {
+	return Rule{
+		Key: key,
+		Check: func(value any) error {
+			v, ok := value.(V)
+			if !ok {
+				var want V
+				return fmt.Errorf("has type %T, rule expects %T", value, want)
+			}
+			return fn(v)
+		},
+	}
+}
+
+// WithValidation adds rules that Load checks after loading, together with
+// the required tags
+func WithValidation(rules ...Rule) LoadOption {
+	return func(l *loader) {
+		l.rules = append(l.rules, rules...)
+	}
+}
+
+// Validate checks cfg, a struct or pointer to struct, and returns a
+// *ValidationError listing every problem at once, or nil. Fields tagged
+// `required:"true"` must not hold their zero value, and each rule must pass.
+// Rules are not run for missing required fields, and a rule for a key cfg
+// does not have is reported as invalid. New and Load call Validate.
+func Validate(cfg any, rules ...Rule) error {
+	v := reflect.ValueOf(cfg)
+	for v.Kind() == reflect.Pointer {
+		if v.IsNil() {
+			return fmt.Errorf("config must not be nil")
+		}
+		v = v.Elem()
+	}
+	if v.Kind() != reflect.Struct {
+		return fmt.Errorf("config type must be a struct or pointer to struct")
+	}
+
+	values := make(map[string]reflect.Value)
+	verr := &ValidationError{}
+	eachField(v, "", func(key string, sf reflect.StructField, fv reflect.Value) {
+		values[key] = fv
+		if sf.Tag.Get("required") == "true" && fv.IsZero() {
+			verr.Missing = append(verr.Missing, key)
+		}
+	})
+
+	missing := make(map[string]bool, len(verr.Missing))
+	for _, key := range verr.Missing {
+		missing[key] = true
+	}
+	for _, r := range rules {
+		if missing[r.Key] {
+			continue
+		}
+		var err error
+		if fv, ok := values[r.Key]; !ok {
+			err = errors.New("no such configuration key")
+		} else {
+			err = r.Check(fv.Interface())
+		}
+		if err == nil {
+			continue
+		}
+		if verr.Invalid == nil {
+			verr.Invalid = make(map[string]error)
+		}
+		// Keep the first failure when a key has several rules
+		if _, exists := verr.Invalid[r.Key]; !exists {
+			verr.Invalid[r.Key] = err
+		}
+	}
+
+	if len(verr.Missing) == 0 && len(verr.Invalid) == 0 {
+		return nil
+	}
+	return verr
+}
@@ -0,0 +1,161 @@
+package config
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/StairSupplies/go-core/testutils"
+)
+
+type requiredDBConfig struct {
+	URL     string        `mapstructure:"URL" required:"true"`
+	Timeout time.Duration `mapstructure:"TIMEOUT"`
+}
+
+type requiredConfig struct {
+	DB        requiredDBConfig `mapstructure:"REQ_DB"`
+	RedisAddr string           `mapstructure:"REQ_REDIS_ADDR" required:"true"`
+	APIKey    string           `mapstructure:"REQ_API_KEY" required:"true" secret:"true"`
+	Port      int              `mapstructure:"REQ_PORT"`
+}
+
+func TestValidate(t *testing.T) {
+	portRule := Check("REQ_PORT", func(port int) error {
+		if port < 1 || port > 65535 {
+			return errors.New("must be between 1 and 65535")
+		}
+		return nil
+	})
+
+	tests := []struct {
+		name        string
+		cfg         requiredConfig
+		rules       []Rule
+		wantMissing []string
+		wantInvalid []string
+	}{
+		{
+			name:        "all missing",
+			cfg:         requiredConfig{},
+			wantMissing: []string{"REQ_DB_URL", "REQ_REDIS_ADDR", "REQ_API_KEY"},
+		},
+		{
+			name:  "valid",
+			cfg:   requiredConfig{DB: requiredDBConfig{URL: "postgres://db"}, RedisAddr: "redis:6379", APIKey: "k", Port: 8080},
+			rules: []Rule{portRule},
+		},
+		{
+			name:        "missing and invalid",
+			cfg:         requiredConfig{DB: requiredDBConfig{URL: "postgres://db"}, Port: 70000},
+			rules:       []Rule{portRule},
+			wantMissing: []string{"REQ_REDIS_ADDR", "REQ_API_KEY"},
+			wantInvalid: []string{"REQ_PORT"},
+		},
+		{
+			name: "rules skip missing fields",
+			cfg:  requiredConfig{DB: requiredDBConfig{URL: "postgres://db"}, APIKey: "k", Port: 1},
+			rules: []Rule{Check("REQ_REDIS_ADDR", func(string) error {
+				return errors.New("never called")
+			})},
+			wantMissing: []string{"REQ_REDIS_ADDR"},
+		},
+		{
+			name: "unknown key and wrong type",
+			cfg:  requiredConfig{DB: requiredDBConfig{URL: "postgres://db"}, RedisAddr: "redis:6379", APIKey: "k"},
+			rules: []Rule{
+				Check("REQ_NOPE", func(string) error { return nil }),
+				Check("REQ_PORT", func(string) error { return nil }),
+			},
+			wantInvalid: []string{"REQ_NOPE", "REQ_PORT"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := Validate(&tt.cfg, tt.rules...)
+			if len(tt.wantMissing) == 0 && len(tt.wantInvalid) == 0 {
+				if err != nil {
+					t.Fatalf("Expected no error, got %v", err)
+				}
+				return
+			}
+
+			var verr *ValidationError
+			if !errors.As(err, &verr) {
+				t.Fatalf("Expected *ValidationError, got %v", err)
+			}
+			if strings.Join(verr.Missing, ",") != strings.Join(tt.wantMissing, ",") {
+				t.Errorf("Expected missing %v, got %v", tt.wantMissing, verr.Missing)
+			}
+			if len(verr.Invalid) != len(tt.wantInvalid) {
+				t.Errorf("Expected invalid %v, got %v", tt.wantInvalid, verr.Invalid)
+			}
+			for _, key := range tt.wantInvalid {
+				if verr.Invalid[key] == nil {
+					t.Errorf("Expected %s to be invalid, got %v", key, verr.Invalid)
+				}
+			}
+		})
+	}
+}
+
+func TestValidationError_Error(t *testing.T) {
+	err := &ValidationError{
+		Missing: []string{"DB_URL", "REDIS_ADDR", "API_KEY"},
+		Invalid: map[string]error{
+			"PORT":      errors.New("must be between 1 and 65535"),
+			"LOG_LEVEL": errors.New("unknown level"),
+		},
+	}
+
+	want := "missing required configuration: DB_URL, REDIS_ADDR, API_KEY; " +
+		"invalid configuration: LOG_LEVEL: unknown level, PORT: must be between 1 and 65535"
+	if err.Error() != want {
+		t.Errorf("Expected %q, got %q", want, err.Error())
+	}
+}
+
+func TestNew_Required(t *testing.T) {
+	testutils.UnsetEnv(t, "REQ_DB_URL", "REQ_REDIS_ADDR", "REQ_API_KEY")
+	t.Setenv("REQ_REDIS_ADDR", "redis:6379")
+
+	_, err := New[requiredConfig]("")
+	if err == nil || err.Error() != "missing required configuration: REQ_DB_URL, REQ_API_KEY" {
+		t.Errorf("Expected both missing variables reported, got %v", err)
+	}
+
+	t.Setenv("REQ_DB_URL", "postgres://db")
+	t.Setenv("REQ_API_KEY", "secret")
+	if _, err := New[requiredConfig](""); err != nil {
+		t.Errorf("Expected configuration to load, got %v", err)
+	}
+}
+
+func TestLoad_WithValidation(t *testing.T) {
+	testutils.UnsetEnv(t, "REQ_DB_URL", "REQ_REDIS_ADDR", "REQ_API_KEY", "REQ_PORT")
+
+	dir := t.TempDir()
+	file := writeFile(t, dir, "config.yaml", `
+req_db:
+  url: postgres://db
+req_redis_addr: redis:6379
+req_api_key: secret
+req_port: 0
+`)
+
+	_, err := Load[requiredConfig](
+		WithFile(file),
+		WithValidation(Check("REQ_PORT", func(port int) error {
+			if port == 0 {
+				return errors.New("must be set")
+			}
+			return nil
+		})),
+	)
+	var verr *ValidationError
+	if !errors.As(err, &verr) || verr.Invalid["REQ_PORT"] == nil || len(verr.Missing) != 0 {
+		t.Errorf("Expected REQ_PORT to be invalid, got %v", err)
+	}
+}
@@ -0,0 +1,179 @@
+package config
+
+import (
+	"path/filepath"
+	"reflect"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// DefaultWatchInterval is how often Watch reloads configuration by default
+const DefaultWatchInterval = 2 * time.Second
+
+// watchSettings holds the settings applied by WatchOption
+type watchSettings struct {
+	interval time.Duration
+	onError  func(error)
+	load     []LoadOption
+}
+
+// WatchOption configures Watch
+type WatchOption func(*watchSettings)
+
+// WithWatchInterval sets how often the configuration is reloaded
+func WithWatchInterval(d time.Duration) WatchOption {
+	return func(s *watchSettings) {
+		if d > 0 {
+			s.interval = d
+		}
+	}
+}
+
+// WithWatchErrorHandler sets a function called when a reload fails. The
+// previous configuration stays in effect until a reload succeeds.
+func WithWatchErrorHandler(fn func(error)) WatchOption {
+	return func(s *watchSettings) {
+		s.onError = fn
+	}
+}
+
+// WithWatchLoadOptions adds Load options, such as WithDefaults, applied on
+// every reload
+func WithWatchLoadOptions(opts ...LoadOption) WatchOption {
+	return func(s *watchSettings) {
+		s.load = append(s.load, opts...)
+	}
+}
+
+// Watcher reloads a configuration of type T and notifies subscribers when
+// it changes. It is safe for concurrent use.
+type Watcher[T any] struct {
+	settings watchSettings
+	load     []LoadOption
+	current  atomic.Pointer[T]
+
+	mu          sync.Mutex
+	subscribers []func(*T)
+
+	stop     chan struct{}
+	done     chan struct{}
+	stopOnce sync.Once
+}
+
+// Watch loads a configuration of type T from path and reloads it periodically,
+// calling onChange with the new configuration whenever a reload produces a
+// different value. Changes to bound environment variables are picked up as
+// well as changes to the file.
+//
+// Files ending in .yaml, .yml, .json or .toml are read as with WithFile; any
+// other path is read as a .env file with WithEnvFile, and an empty path reads
+// the environment only. The initial load must succeed; later failures are
+// reported to WithWatchErrorHandler and leave the previous value in place.
+//
+// Callbacks run one at a time on the watcher's goroutine. Call Close to stop
+// watching.
+func Watch[T any](path string, onChange func(*T), opts ...WatchOption) (*Watcher[T], error) {
+	settings := watchSettings{interval: DefaultWatchInterval}
+	for _, opt := range opts {
+		opt(&settings)
+	}
+
+	load := append([]LoadOption(nil), settings.load...)
+	if path != "" {
+		load = append(load, watchSource(path))
+	}
+
+	cfg, err := Load[T](load...)
+	if err != nil {
+		return nil, err
+	}
+
+	w := &Watcher[T]{
+		settings: settings,
+		load:     load,
+		stop:     make(chan struct{}),
+		done:     make(chan struct{}),
+	}
+	w.current.Store(cfg)
+	if onChange != nil {
+		w.subscribers = append(w.subscribers, onChange)
+	}
+
+	go w.run()
+	return w, nil
+}
+
+// watchSource returns the Load option for path based on its extension
+func watchSource(path string) LoadOption {
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".yaml", ".yml", ".json", ".toml":
+		return WithFile(path)
+	default:
+		return WithEnvFile(path)
+	}
+}
+
+// Current returns the most recently loaded configuration. Callers must not
+// modify it.
+func (w *Watcher[T]) Current() *T {
+	return w.current.Load()
+}
+
+// Subscribe adds a function called with the new configuration after each change
+func (w *Watcher[T]) Subscribe(fn func(*T)) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	w.subscribers = append(w.subscribers, fn)
+}
+
+// Close stops watching and waits for any running callback to return.
+// It is safe to call more than once.
+func (w *Watcher[T]) Close() error {
+	w.stopOnce.Do(func() { close(w.stop) })
+	<-w.done
+	return nil
+}
+
+// run reloads the configuration until Close is called
+func (w *Watcher[T]) run() {
+	defer close(w.done)
+
+	ticker := time.NewTicker(w.settings.interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-w.stop:
+			return
+		case <-ticker.C:
+			w.reload()
+		}
+	}
+}
+
+// reload loads the configuration and notifies subscribers if it changed
+func (w *Watcher[T]) reload() {
+	cfg, err := Load[T](w.load...)
+	if err != nil {
+		if w.settings.onError != nil {
+			w.settings.onError(err)
+		}
+		return
+	}
+
+	if reflect.DeepEqual(cfg, w.current.Load()) {
+		return
+	}
+	w.current.Store(cfg)
+
+	w.mu.Lock()
+	subscribers := make([]func(*T), len(w.subscribers))
+	copy(subscribers, w.subscribers)
+	w.mu.Unlock()
+
+	for _, fn := range subscribers {
+		fn(cfg)
+	}
+}
@@ -0,0 +1,123 @@
+package config
+
+import (
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/StairSupplies/go-core/testutils"
+)
+
+type watchConfig struct {
+	LogLevel string `mapstructure:"WATCH_LOG_LEVEL"`
+	Beta     bool   `mapstructure:"WATCH_BETA"`
+}
+
+func TestWatch(t *testing.T) {
+	testutils.UnsetEnv(t, "WATCH_LOG_LEVEL", "WATCH_BETA")
+
+	dir := t.TempDir()
+	path := writeFile(t, dir, "watch.env", "WATCH_LOG_LEVEL=info\n")
+
+	changes := make(chan *watchConfig, 4)
+	w, err := Watch(path, func(cfg *watchConfig) { changes <- cfg },
+		WithWatchInterval(10*time.Millisecond))
+	if err != nil {
+		t.Fatalf("Watch() error = %v", err)
+	}
+	defer w.Close()
+
+	if got := w.Current().LogLevel; got != "info" {
+		t.Fatalf("Expected initial LogLevel = info, got %q", got)
+	}
+
+	subscribed := make(chan *watchConfig, 4)
+	w.Subscribe(func(cfg *watchConfig) { subscribed <- cfg })
+
+	writeFile(t, dir, "watch.env", "WATCH_LOG_LEVEL=debug\nWATCH_BETA=true\n")
+
+	for name, ch := range map[string]chan *watchConfig{"onChange": changes, "subscriber": subscribed} {
+		select {
+		case cfg := <-ch:
+			if cfg.LogLevel != "debug" || !cfg.Beta {
+				t.Errorf("Expected %s to receive the new config, got %+v", name, cfg)
+			}
+		case <-time.After(2 * time.Second):
+			t.Fatalf("Timed out waiting for %s", name)
+		}
+	}
+
+	if got := w.Current().LogLevel; got != "debug" {
+		t.Errorf("Expected Current LogLevel = debug, got %q", got)
+	}
+
+	select {
+	case cfg := <-changes:
+		t.Errorf("Expected no notification without a change, got %+v", cfg)
+	case <-time.After(50 * time.Millisecond):
+	}
+}
+
+func TestWatch_Env(t *testing.T) {
+	t.Setenv("WATCH_LOG_LEVEL", "info")
+
+	changes := make(chan *watchConfig, 1)
+	w, err := Watch("", func(cfg *watchConfig) { changes <- cfg },
+		WithWatchInterval(10*time.Millisecond))
+	if err != nil {
+		t.Fatalf("Watch() error = %v", err)
+	}
+	defer w.Close()
+
+	t.Setenv("WATCH_LOG_LEVEL", "warn")
+
+	select {
+	case cfg := <-changes:
+		if cfg.LogLevel != "warn" {
+			t.Errorf("Expected LogLevel = warn, got %q", cfg.LogLevel)
+		}
+	case <-time.After(2 * time.Second):
+		t.Fatal("Timed out waiting for environment change")
+	}
+}
+
+func TestWatch_Errors(t *testing.T) {
+	testutils.UnsetEnv(t, "WATCH_LOG_LEVEL", "WATCH_BETA")
+
+	dir := t.TempDir()
+	if _, err := Watch[watchConfig](filepath.Join(dir, "missing.yaml"), nil); err == nil {
+		t.Error("Expected error for missing config file")
+	}
+
+	path := writeFile(t, dir, "watch.yaml", "watch_log_level: info\n")
+	errs := make(chan error, 4)
+	w, err := Watch[watchConfig](path, nil,
+		WithWatchInterval(10*time.Millisecond),
+		WithWatchErrorHandler(func(err error) { errs <- err }),
+	)
+	if err != nil {
+		t.Fatalf("Watch() error = %v", err)
+	}
+
+	writeFile(t, dir, "watch.yaml", "watch_log_level: [unclosed")
+
+	select {
+	case err := <-errs:
+		if err == nil {
+			t.Error("Expected a reload error")
+		}
+	case <-time.After(2 * time.Second):
+		t.Fatal("Timed out waiting for reload error")
+	}
+
+	if got := w.Current().LogLevel; got != "info" {
+		t.Errorf("Expected previous config to be kept, got LogLevel = %q", got)
+	}
+
+	if err := w.Close(); err != nil {
+		t.Errorf("Close() error = %v", err)
+	}
+	if err := w.Close(); err != nil {
+		t.Errorf("Expected second Close to succeed, got %v", err)
+	}
+}
@@ -0,0 +1,151 @@
+package cryptoutils
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"encoding/base64"
+	"encoding/binary"
+	"errors"
+	"fmt"
+)
+
+var (
+	// ErrInvalidKey is returned for keys that are not 16, 24, or 32 bytes
+	ErrInvalidKey = errors.New("invalid encryption key")
+
+	// ErrUnknownKey is returned when ciphertext was encrypted with a key not in the keyring
+	ErrUnknownKey = errors.New("unknown encryption key")
+
+	// ErrDecrypt is returned when ciphertext is malformed or fails authentication
+	ErrDecrypt = errors.New("decryption failed")
+)
+
+// formatVersion is the first byte of every ciphertext produced by a Keyring
+const formatVersion byte = 1
+
+// headerSize is the format version byte plus the big-endian key ID
+const headerSize = 1 + 4
+
+// Keyring encrypts with a primary AES-GCM key and decrypts with any key it holds,
+// so keys can be rotated without re-encrypting existing data at once.
+// Ciphertext layout: version (1 byte) | key ID (4 bytes) | nonce (12 bytes) | sealed data.
+type Keyring struct {
+	primary uint32
+	aeads   map[uint32]cipher.AEAD
+}
+
+// NewKeyring creates a keyring from AES keys indexed by key ID. New data is
+// encrypted with the primary key; all keys can decrypt.
+func NewKeyring(keys map[uint32][]byte, primary uint32) (*Keyring, error) {
+	if _, ok := keys[primary]; !ok {
+		return nil, fmt.Errorf("%w: primary key %d not provided", ErrInvalidKey, primary)
+	}
+
+	kr := &Keyring{primary: primary, aeads: make(map[uint32]cipher.AEAD, len(keys))}
+	for id, key := range keys {
+		block, err := aes.NewCipher(key)
+		if err != nil {
+			return nil, fmt.Errorf("%w: key %d: %v", ErrInvalidKey, id, err)
+		}
+		aead, err := cipher.NewGCM(block)
+		if err != nil {
+			return nil, fmt.Errorf("%w: key %d: %v", ErrInvalidKey, id, err)
+		}
+		kr.aeads[id] = aead
+	}
+	return kr, nil
+}
+
+// GenerateKey returns a random 32-byte AES-256 key
+func GenerateKey() ([]byte, error) {
+	return RandomBytes(32)
+}
+
+// Encrypt seals plaintext with the primary key. associatedData is authenticated but
+// not encrypted; the same value must be passed to Decrypt, which binds the ciphertext
+// to its context (e.g. a user ID) so it cannot be swapped between records.
+func (kr *Keyring) Encrypt(plaintext, associatedData []byte) ([]byte, error) {
+	aead := kr.aeads[kr.primary]
+
+	nonce, err := RandomBytes(aead.NonceSize())
+	if err != nil {
+		return nil, err
+	}
+
+	out := make([]byte, headerSize, headerSize+len(nonce)+len(plaintext)+aead.Overhead())
+	out[0] = formatVersion
+	binary.BigEndian.PutUint32(out[1:headerSize], kr.primary)
+	out = append(out, nonce...)
+
+	return aead.Seal(out, nonce, plaintext, associatedData), nil
+}
+
+// Decrypt opens ciphertext produced by Encrypt with any key in the keyring
+func (kr *Keyring) Decrypt(ciphertext, associatedData []byte) ([]byte, error) {
+	id, err := keyID(ciphertext)
+	if err != nil {
+		return nil, err
+	}
+
+	aead, ok := kr.aeads[id]
+	if !ok {
+		return nil, fmt.Errorf("%w: %d", ErrUnknownKey, id)
+	}
+
+	body := ciphertext[headerSize:]
+	if len(body) < aead.NonceSize()+aead.Overhead() {
+		return nil, ErrDecrypt
+	}
+
+	nonce, sealed := body[:aead.NonceSize()], body[aead.NonceSize():]
+	plaintext, err := aead.Open(nil, nonce, sealed, associatedData)
+	if err != nil {
+		return nil, ErrDecrypt
+	}
+	return plaintext, nil
+}
+
+// EncryptString encrypts s and returns URL-safe base64 suitable for database columns
+func (kr *Keyring) EncryptString(s string, associatedData []byte) (string, error) {
+	ciphertext, err := kr.Encrypt([]byte(s), associatedData)
+	if err != nil {
+		return "", err
+	}
+	return base64.RawURLEncoding.EncodeToString(ciphertext), nil
+}
+
+// DecryptString decrypts a value produced by EncryptString
+func (kr *Keyring) DecryptString(s string, associatedData []byte) (string, error) {
+	ciphertext, err := base64.RawURLEncoding.DecodeString(s)
+	if err != nil {
+		return "", ErrDecrypt
+	}
+	plaintext, err := kr.Decrypt(ciphertext, associatedData)
+	if err != nil {
+		return "", err
+	}
+	return string(plaintext), nil
+}
+
+// NeedsRotation reports whether ciphertext was encrypted with a key other than the primary
+func (kr *Keyring) NeedsRotation(ciphertext []byte) bool {
+	id, err := keyID(ciphertext)
+	return err == nil && id != kr.primary
+}
+
+// Rotate decrypts ciphertext and re-encrypts it with the primary key
+func (kr *Keyring) Rotate(ciphertext, associatedData []byte) ([]byte, error) {
+	plaintext, err := kr.Decrypt(ciphertext, associatedData)
+	if err != nil {
+		return nil, err
+	}
+	return kr.Encrypt(plaintext, associatedData)
+}
+
+// keyID returns the key ID from a ciphertext header
+func keyID(ciphertext []byte) (uint32, error) {
+	if len(ciphertext) < headerSize || ciphertext[0] != formatVersion {
+		return 0, ErrDecrypt
+	}
+	return binary.BigEndian.Uint32(ciphertext[1:headerSize]), nil
+}
@@ -0,0 +1,117 @@
+package cryptoutils
+
+import (
+	"bytes"
+	"errors"
+	"testing"
+)
+
+func newTestKeyring(t *testing.T, primary uint32, ids ...uint32) (*Keyring, map[uint32][]byte) {
+	t.Helper()
+	keys := make(map[uint32][]byte)
+	for _, id := range ids {
+		key, err := GenerateKey()
+		if err != nil {
+			t.Fatalf("GenerateKey() error = %v", err)
+		}
+		keys[id] = key
+	}
+	kr, err := NewKeyring(keys, primary)
+	if err != nil {
+		t.Fatalf("NewKeyring() error = %v", err)
+	}
+	return kr, keys
+}
+
+func TestKeyring_RoundTrip(t *testing.T) {
+	kr, _ := newTestKeyring(t, 1, 1)
+	ad := []byte("user:42")
+
+	ciphertext, err := kr.Encrypt([]byte("4111 1111 1111 1111"), ad)
+	if err != nil {
+		t.Fatalf("Encrypt() error = %v", err)
+	}
+	if bytes.Contains(ciphertext, []byte("4111")) {
+		t.Error("Expected plaintext not to appear in ciphertext")
+	}
+
+	plaintext, err := kr.Decrypt(ciphertext, ad)
+	if err != nil || string(plaintext) != "4111 1111 1111 1111" {
+		t.Errorf("Decrypt() = %q, %v", plaintext, err)
+	}
+
+	again, _ := kr.Encrypt([]byte("4111 1111 1111 1111"), ad)
+	if bytes.Equal(ciphertext, again) {
+		t.Error("Expected a fresh nonce for every encryption")
+	}
+}
+
+func TestKeyring_DecryptFailures(t *testing.T) {
+	kr, _ := newTestKeyring(t, 1, 1)
+	ciphertext, _ := kr.Encrypt([]byte("secret"), []byte("user:42"))
+
+	tampered := append([]byte(nil), ciphertext...)
+	tampered[len(tampered)-1] ^= 0xff
+
+	other, _ := newTestKeyring(t, 9, 9)
+	foreign, _ := other.Encrypt([]byte("secret"), nil)
+
+	tests := []struct {
+		name       string
+		ciphertext []byte
+		ad         []byte
+		wantErr    error
+	}{
+		{"wrong associated data", ciphertext, []byte("user:43"), ErrDecrypt},
+		{"tampered", tampered, []byte("user:42"), ErrDecrypt},
+		{"truncated", ciphertext[:10], []byte("user:42"), ErrDecrypt},
+		{"empty", nil, nil, ErrDecrypt},
+		{"unknown key", foreign, nil, ErrUnknownKey},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			if _, err := kr.Decrypt(tc.ciphertext, tc.ad); !errors.Is(err, tc.wantErr) {
+				t.Errorf("Expected %v, got %v", tc.wantErr, err)
+			}
+		})
+	}
+}
+
+func TestKeyring_Rotation(t *testing.T) {
+	old, keys := newTestKeyring(t, 1, 1)
+	ciphertext, _ := old.EncryptString("secret", nil)
+
+	keys[2], _ = GenerateKey()
+	rotated, err := NewKeyring(keys, 2)
+	if err != nil {
+		t.Fatalf("NewKeyring() error = %v", err)
+	}
+
+	plaintext, err := rotated.DecryptString(ciphertext, nil)
+	if err != nil || plaintext != "secret" {
+		t.Fatalf("Expected old ciphertext to decrypt after rotation, got %q, %v", plaintext, err)
+	}
+
+	raw, _ := old.Encrypt([]byte("secret"), nil)
+	if !rotated.NeedsRotation(raw) {
+		t.Error("Expected ciphertext under the old key to need rotation")
+	}
+
+	fresh, err := rotated.Rotate(raw, nil)
+	if err != nil {
+		t.Fatalf("Rotate() error = %v", err)
+	}
+	if rotated.NeedsRotation(fresh) {
+		t.Error("Expected rotated ciphertext to use the primary key")
+	}
+}
+
+func TestNewKeyring_Errors(t *testing.T) {
+	if _, err := NewKeyring(map[uint32][]byte{1: make([]byte, 32)}, 2); !errors.Is(err, ErrInvalidKey) {
+		t.Errorf("Expected ErrInvalidKey for missing primary, got %v", err)
+	}
+	if _, err := NewKeyring(map[uint32][]byte{1: []byte("short")}, 1); !errors.Is(err, ErrInvalidKey) {
+		t.Errorf("Expected ErrInvalidKey for short key, got %v", err)
+	}
+}
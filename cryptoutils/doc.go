@@ -0,0 +1,78 @@
+/*
+Package cryptoutils provides vetted cryptographic building blocks: authenticated
+encryption with key rotation, HMAC signing, secure random tokens, and password
+hashing.
+
+Everything is built on the standard library's crypto packages and
+golang.org/x/crypto. Use these helpers instead of assembling primitives by hand.
+
+# Features
+
+  - AES-GCM encryption with versioned keys and associated data
+  - Key rotation: decrypt with any key, encrypt with the primary
+  - HMAC-SHA256 signing and constant-time verification
+  - Random bytes and URL-safe tokens
+  - Password hashing with argon2id, a configurable policy and PHC-formatted hashes
+  - Verification of existing bcrypt hashes and a PBKDF2-SHA256 fallback
+  - NeedsRehash for upgrading hashes after policy changes
+
+# Encryption
+
+A Keyring holds AES keys by ID. Every ciphertext records the ID of the key that
+produced it, so old data stays readable after a new primary key is introduced:
+
+	keyring, err := cryptoutils.NewKeyring(map[uint32][]byte{
+		1: oldKey,
+		2: newKey,
+	}, 2)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	// Associated data binds the ciphertext to its record
+	ad := []byte("customer:" + customerID)
+	encrypted, err := keyring.EncryptString(taxID, ad)
+
+	taxID, err := keyring.DecryptString(encrypted, ad)
+
+Re-encrypting data under the primary key in a background job:
+
+	if keyring.NeedsRotation(ciphertext) {
+		ciphertext, err = keyring.Rotate(ciphertext, ad)
+	}
+
+Generate new keys with GenerateKey and store them in a secret manager.
+
+# Signing
+
+	signature := cryptoutils.SignHex(secret, payload)
+
+	if !cryptoutils.VerifyHex(secret, payload, r.Header.Get("X-Signature")) {
+		return api.UnauthorizedError(errors.New("invalid signature"))
+	}
+
+Use Equal to compare API keys and other secrets without leaking timing information.
+
+# Passwords
+
+Passwords are hashed with argon2id using the parameters in a PasswordPolicy.
+Hashes use the PHC string format and record their own parameters, so raising
+the policy never breaks existing hashes:
+
+	hash, err := cryptoutils.HashPassword(password)
+	if errors.Is(err, cryptoutils.ErrPasswordTooShort) {
+		return api.BadRequestError(err)
+	}
+
+	ok, err := cryptoutils.VerifyPassword(user.PasswordHash, password)
+	if ok && cryptoutils.DefaultPasswordPolicy.NeedsRehash(user.PasswordHash) {
+		user.PasswordHash, _ = cryptoutils.HashPassword(password)
+	}
+
+The algorithm name is part of every stored hash. Verify also accepts bcrypt
+hashes ($2a$, $2b$, $2y$) imported from other systems, and NeedsRehash always
+reports them so they are upgraded to argon2id on the next login. Deployments
+that must use a FIPS-approved algorithm can set Algorithm to
+PBKDF2SHA256Fallback, which uses PBKDF2-HMAC-SHA256 with Iterations rounds.
+*/
+package cryptoutils
@@ -0,0 +1,40 @@
+package cryptoutils_test
+
+import (
+	"fmt"
+
+	"github.com/StairSupplies/go-core/cryptoutils"
+)
+
+func ExampleKeyring() {
+	// In production, keys come from a secret manager
+	key, _ := cryptoutils.GenerateKey()
+	keyring, err := cryptoutils.NewKeyring(map[uint32][]byte{1: key}, 1)
+	if err != nil {
+		fmt.Printf("Error creating keyring: %v\n", err)
+		return
+	}
+
+	// Bind the ciphertext to the customer it belongs to
+	ad := []byte("customer:1001")
+
+	encrypted, _ := keyring.EncryptString("123-45-6789", ad)
+	decrypted, _ := keyring.DecryptString(encrypted, ad)
+	fmt.Println(decrypted)
+
+	_, err = keyring.DecryptString(encrypted, []byte("customer:1002"))
+	fmt.Println(err)
+	// Output:
+	// 123-45-6789
+	// decryption failed
+}
+
+func ExampleSignHex() {
+	signature := cryptoutils.SignHex([]byte("secret"), []byte("order:1001"))
+
+	fmt.Println(cryptoutils.VerifyHex([]byte("secret"), []byte("order:1001"), signature))
+	fmt.Println(cryptoutils.VerifyHex([]byte("secret"), []byte("order:1002"), signature))
+	// Output:
+	// true
+	// false
+}
@@ -0,0 +1,61 @@
+package cryptoutils
+
+import (
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/base64"
+	"encoding/hex"
+	"fmt"
+)
+
+// Sign returns the HMAC-SHA256 of message under key
+func Sign(key, message []byte) []byte {
+	mac := hmac.New(sha256.New, key)
+	mac.Write(message)
+	return mac.Sum(nil)
+}
+
+// SignHex returns the hex-encoded HMAC-SHA256 of message under key
+func SignHex(key, message []byte) string {
+	return hex.EncodeToString(Sign(key, message))
+}
+
+// Verify reports whether mac is the HMAC-SHA256 of message under key, in constant time
+func Verify(key, message, mac []byte) bool {
+	return hmac.Equal(Sign(key, message), mac)
+}
+
+// VerifyHex reports whether the hex-encoded mac is the HMAC-SHA256 of message under key
+func VerifyHex(key, message []byte, mac string) bool {
+	decoded, err := hex.DecodeString(mac)
+	if err != nil {
+		return false
+	}
+	return Verify(key, message, decoded)
+}
+
+// Equal compares two strings in constant time, for comparing secrets such as API keys
+func Equal(a, b string) bool {
+	return subtle.ConstantTimeCompare([]byte(a), []byte(b)) == 1
+}
+
+// RandomBytes returns n bytes from a cryptographically secure source
+func RandomBytes(n int) ([]byte, error) {
+	b := make([]byte, n)
+	if _, err := rand.Read(b); err != nil {
+		return nil, fmt.Errorf("failed to read random bytes: %w", err)
+	}
+	return b, nil
+}
+
+// RandomToken returns n random bytes encoded as URL-safe base64 without padding,
+// suitable for session IDs, API keys, and reset tokens
+func RandomToken(n int) (string, error) {
+	b, err := RandomBytes(n)
+	if err != nil {
+		return "", err
+	}
+	return base64.RawURLEncoding.EncodeToString(b), nil
+}
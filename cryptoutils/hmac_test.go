@@ -0,0 +1,40 @@
+package cryptoutils
+
+import "testing"
+
+func TestSign(t *testing.T) {
+	// Test case 2 from RFC 4231
+	got := SignHex([]byte("Jefe"), []byte("what do ya want for nothing?"))
+	want := "5bdcc146bf60754e6a042426089575c75a003f089d2739839dec58b964ec3843"
+	if got != want {
+		t.Errorf("Expected %s, got %s", want, got)
+	}
+
+	if !VerifyHex([]byte("Jefe"), []byte("what do ya want for nothing?"), want) {
+		t.Error("Expected signature to verify")
+	}
+	if VerifyHex([]byte("Jefe"), []byte("what do ya want for something?"), want) {
+		t.Error("Expected modified message not to verify")
+	}
+	if VerifyHex([]byte("Jefe"), nil, "not-hex") {
+		t.Error("Expected malformed signature not to verify")
+	}
+}
+
+func TestRandomToken(t *testing.T) {
+	a, err := RandomToken(32)
+	if err != nil {
+		t.Fatalf("RandomToken() error = %v", err)
+	}
+	b, _ := RandomToken(32)
+
+	if len(a) != 43 {
+		t.Errorf("Expected 43 characters, got %d", len(a))
+	}
+	if Equal(a, b) {
+		t.Error("Expected distinct tokens")
+	}
+	if !Equal(a, a) {
+		t.Error("Expected token to equal itself")
+	}
+}
@@ -0,0 +1,277 @@
+package cryptoutils
+
+import (
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+
+	"golang.org/x/crypto/argon2"
+	"golang.org/x/crypto/bcrypt"
+	"golang.org/x/crypto/pbkdf2"
+)
+
+var (
+	// ErrPasswordTooShort is returned when a password is shorter than the policy minimum
+	ErrPasswordTooShort = errors.New("password is too short")
+
+	// ErrPasswordTooLong is returned when a password is longer than the policy maximum
+	ErrPasswordTooLong = errors.New("password is too long")
+
+	// ErrInvalidHash is returned when a stored hash cannot be parsed
+	ErrInvalidHash = errors.New("invalid password hash")
+)
+
+// PasswordAlgorithm identifies a password hashing algorithm by its PHC name
+type PasswordAlgorithm string
+
+// Supported password hashing algorithms
+const (
+	// Argon2id is the default and recommended algorithm
+	Argon2id PasswordAlgorithm = "argon2id"
+	// PBKDF2SHA256Fallback is PBKDF2-HMAC-SHA256, for deployments that
+	// require a FIPS-approved algorithm. Prefer Argon2id otherwise.
+	PBKDF2SHA256Fallback PasswordAlgorithm = "pbkdf2-sha256"
+)
+
+// PasswordPolicy controls password length limits and hashing cost
+type PasswordPolicy struct {
+	// MinLength is the minimum number of characters
+	MinLength int
+	// MaxLength is the maximum number of characters, which bounds hashing time
+	MaxLength int
+	// Algorithm is used for new hashes; empty means Argon2id
+	Algorithm PasswordAlgorithm
+	// Memory is the argon2id memory cost in KiB
+	Memory uint32
+	// Time is the argon2id number of passes over the memory
+	Time uint32
+	// Threads is the argon2id degree of parallelism
+	Threads uint8
+	// Iterations is the PBKDF2 iteration count, used with PBKDF2SHA256Fallback
+	Iterations int
+	// SaltLength is the salt size in bytes
+	SaltLength int
+	// KeyLength is the derived key size in bytes
+	KeyLength int
+}
+
+// DefaultPasswordPolicy follows current OWASP guidance: argon2id with 19 MiB
+// of memory, two passes and one thread, or 600,000 PBKDF2 iterations when the
+// fallback is selected
+var DefaultPasswordPolicy = PasswordPolicy{
+	MinLength:  12,
+	MaxLength:  128,
+	Algorithm:  Argon2id,
+	Memory:     19 * 1024,
+	Time:       2,
+	Threads:    1,
+	Iterations: 600_000,
+	SaltLength: 16,
+	KeyLength:  32,
+}
+
+// HashPassword hashes password with DefaultPasswordPolicy
+func HashPassword(password string) (string, error) {
+	return DefaultPasswordPolicy.Hash(password)
+}
+
+// VerifyPassword checks password against a hash produced by HashPassword,
+// or a bcrypt hash carried over from another system
+func VerifyPassword(hash, password string) (bool, error) {
+	return DefaultPasswordPolicy.Verify(hash, password)
+}
+
+// Validate checks password against the policy's length limits
+func (p PasswordPolicy) Validate(password string) error {
+	n := utf8.RuneCountInString(password)
+	if n < p.MinLength {
+		return fmt.Errorf("%w: minimum is %d characters", ErrPasswordTooShort, p.MinLength)
+	}
+	if p.MaxLength > 0 && n > p.MaxLength {
+		return fmt.Errorf("%w: maximum is %d characters", ErrPasswordTooLong, p.MaxLength)
+	}
+	return nil
+}
+
+// algorithm returns the algorithm used for new hashes
+func (p PasswordPolicy) algorithm() PasswordAlgorithm {
+	if p.Algorithm == "" {
+		return Argon2id
+	}
+	return p.Algorithm
+}
+
+// Hash validates password and returns a PHC-formatted hash:
+//
+//	$argon2id$v=19$m=19456,t=2,p=1$<salt>$<hash>
+//	$pbkdf2-sha256$i=600000$<salt>$<hash>
+func (p PasswordPolicy) Hash(password string) (string, error) {
+	if err := p.Validate(password); err != nil {
+		return "", err
+	}
+
+	salt, err := RandomBytes(p.SaltLength)
+	if err != nil {
+		return "", err
+	}
+
+	params := hashParams{
+		algorithm:  p.algorithm(),
+		memory:     p.Memory,
+		time:       p.Time,
+		threads:    p.Threads,
+		iterations: p.Iterations,
+		salt:       salt,
+	}
+	switch params.algorithm {
+	case Argon2id:
+		if params.memory == 0 || params.time == 0 || params.threads == 0 {
+			return "", errors.New("cryptoutils: argon2id policy needs Memory, Time and Threads")
+		}
+	case PBKDF2SHA256Fallback:
+		if params.iterations < 1 {
+			return "", errors.New("cryptoutils: pbkdf2 policy needs Iterations")
+		}
+	default:
+		return "", fmt.Errorf("cryptoutils: unsupported password algorithm %q", params.algorithm)
+	}
+	params.key = params.derive(password, p.KeyLength)
+	return params.String(), nil
+}
+
+// Verify reports whether password matches hash. The parameters stored in the
+// hash are used, so hashes created under an older policy or another supported
+// algorithm still verify. bcrypt hashes ($2a$, $2b$, $2y$) are verified but
+// never created; NeedsRehash reports them so they can be upgraded.
+func (p PasswordPolicy) Verify(hash, password string) (bool, error) {
+	if isBcrypt(hash) {
+		err := bcrypt.CompareHashAndPassword([]byte(hash), []byte(password))
+		if errors.Is(err, bcrypt.ErrMismatchedHashAndPassword) {
+			return false, nil
+		}
+		if err != nil {
+			return false, ErrInvalidHash
+		}
+		return true, nil
+	}
+
+	params, err := parseHash(hash)
+	if err != nil {
+		return false, err
+	}
+	// Reject oversized input before doing any expensive work
+	if p.MaxLength > 0 && utf8.RuneCountInString(password) > p.MaxLength {
+		return false, nil
+	}
+
+	key := params.derive(password, len(params.key))
+	return subtle.ConstantTimeCompare(key, params.key) == 1, nil
+}
+
+// NeedsRehash reports whether hash was created with another algorithm or
+// weaker parameters than the policy, so it should be replaced after the next
+// successful login
+func (p PasswordPolicy) NeedsRehash(hash string) bool {
+	params, err := parseHash(hash)
+	if err != nil || params.algorithm != p.algorithm() {
+		return true
+	}
+	if len(params.key) < p.KeyLength || len(params.salt) < p.SaltLength {
+		return true
+	}
+	if params.algorithm == Argon2id {
+		return params.memory < p.Memory || params.time < p.Time || params.threads < p.Threads
+	}
+	return params.iterations < p.Iterations
+}
+
+// isBcrypt reports whether hash is in the bcrypt modular crypt format
+func isBcrypt(hash string) bool {
+	return strings.HasPrefix(hash, "$2a$") || strings.HasPrefix(hash, "$2b$") || strings.HasPrefix(hash, "$2y$")
+}
+
+// hashParams are the parsed components of a PHC hash
+type hashParams struct {
+	algorithm  PasswordAlgorithm
+	memory     uint32
+	time       uint32
+	threads    uint8
+	iterations int
+	salt       []byte
+	key        []byte
+}
+
+// derive computes a key of keyLen bytes from password with the parameters
+func (h hashParams) derive(password string, keyLen int) []byte {
+	if h.algorithm == Argon2id {
+		return argon2.IDKey([]byte(password), h.salt, h.time, h.memory, h.threads, uint32(keyLen))
+	}
+	return pbkdf2.Key([]byte(password), h.salt, h.iterations, keyLen, sha256.New)
+}
+
+// String formats the parameters as a PHC string
+func (h hashParams) String() string {
+	var settings string
+	if h.algorithm == Argon2id {
+		settings = fmt.Sprintf("v=%d$m=%d,t=%d,p=%d", argon2.Version, h.memory, h.time, h.threads)
+	} else {
+		settings = fmt.Sprintf("i=%d", h.iterations)
+	}
+	return fmt.Sprintf("$%s$%s$%s$%s",
+		h.algorithm,
+		settings,
+		base64.RawStdEncoding.EncodeToString(h.salt),
+		base64.RawStdEncoding.EncodeToString(h.key),
+	)
+}
+
+// parseHash parses an argon2id or pbkdf2-sha256 PHC string
+func parseHash(hash string) (hashParams, error) {
+	parts := strings.Split(hash, "$")
+	if len(parts) < 5 || parts[0] != "" {
+		return hashParams{}, ErrInvalidHash
+	}
+
+	var params hashParams
+	switch PasswordAlgorithm(parts[1]) {
+	case Argon2id:
+		if len(parts) != 6 || parts[2] != "v="+strconv.Itoa(argon2.Version) {
+			return hashParams{}, ErrInvalidHash
+		}
+		var memory, time uint32
+		var threads uint8
+		if _, err := fmt.Sscanf(parts[3], "m=%d,t=%d,p=%d", &memory, &time, &threads); err != nil ||
+			memory == 0 || time == 0 || threads == 0 {
+			return hashParams{}, ErrInvalidHash
+		}
+		params = hashParams{algorithm: Argon2id, memory: memory, time: time, threads: threads}
+		parts = parts[1:]
+	case PBKDF2SHA256Fallback:
+		if len(parts) != 5 || !strings.HasPrefix(parts[2], "i=") {
+			return hashParams{}, ErrInvalidHash
+		}
+		iterations, err := strconv.Atoi(strings.TrimPrefix(parts[2], "i="))
+		if err != nil || iterations < 1 {
+			return hashParams{}, ErrInvalidHash
+		}
+		params = hashParams{algorithm: PBKDF2SHA256Fallback, iterations: iterations}
+	default:
+		return hashParams{}, ErrInvalidHash
+	}
+
+	salt, err := base64.RawStdEncoding.DecodeString(parts[3])
+	if err != nil {
+		return hashParams{}, ErrInvalidHash
+	}
+	key, err := base64.RawStdEncoding.DecodeString(parts[4])
+	if err != nil || len(key) == 0 {
+		return hashParams{}, ErrInvalidHash
+	}
+	params.salt, params.key = salt, key
+	return params, nil
+}
@@ -0,0 +1,164 @@
+package cryptoutils
+
+import (
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"strings"
+	"testing"
+
+	"golang.org/x/crypto/bcrypt"
+)
+
+// testPolicy keeps memory and passes low so tests stay fast
+var testPolicy = PasswordPolicy{MinLength: 8, MaxLength: 64, Memory: 64, Time: 1, Threads: 1, SaltLength: 16, KeyLength: 32}
+
+// testFallbackPolicy keeps iteration counts low so tests stay fast
+var testFallbackPolicy = PasswordPolicy{MinLength: 8, MaxLength: 64, Algorithm: PBKDF2SHA256Fallback, Iterations: 1000, SaltLength: 16, KeyLength: 32}
+
+func TestPBKDF2SHA256(t *testing.T) {
+	// Test vectors from RFC 7914, section 11
+	tests := []struct {
+		password   string
+		salt       string
+		iterations int
+		keyLen     int
+		want       string
+	}{
+		{"passwd", "salt", 1, 64,
+			"55ac046e56e3089fec1691c22544b605f94185216dde0465e68b9d57c20dacbc49ca9cccf179b645991664b39d77ef317c71b845b1e30bd509112041d3a19783"},
+		{"Password", "NaCl", 80000, 64,
+			"4ddcd8f60b98be21830cee5ef22701f9641a4418d04c0414aeff08876b34ab56a1d425a1225833549adb841b51c9b3176a272bdebba1d078478f62b397f33c8d"},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.password, func(t *testing.T) {
+			key, _ := hex.DecodeString(tc.want)
+			hash := hashParams{
+				algorithm:  PBKDF2SHA256Fallback,
+				iterations: tc.iterations,
+				salt:       []byte(tc.salt),
+				key:        key,
+			}.String()
+
+			if ok, err := testFallbackPolicy.Verify(hash, tc.password); !ok || err != nil {
+				t.Errorf("Expected %s to verify, got %v, %v", hash, ok, err)
+			}
+		})
+	}
+}
+
+func TestArgon2id(t *testing.T) {
+	// Reference vector from the argon2 package tests: password, somesalt, t=1, m=64, p=1
+	want := "655ad15eac652dc59f7170a7332bf49b8469be1fdb9c28bb"
+	key, _ := hex.DecodeString(want)
+	hash := "$argon2id$v=19$m=64,t=1,p=1$" +
+		base64.RawStdEncoding.EncodeToString([]byte("somesalt")) + "$" +
+		base64.RawStdEncoding.EncodeToString(key)
+
+	if ok, err := testPolicy.Verify(hash, "password"); !ok || err != nil {
+		t.Errorf("Expected %s to verify, got %v, %v", hash, ok, err)
+	}
+}
+
+func TestPasswordPolicy_HashAndVerify(t *testing.T) {
+	hash, err := testPolicy.Hash("correct horse battery")
+	if err != nil {
+		t.Fatalf("Hash() error = %v", err)
+	}
+	if !strings.HasPrefix(hash, "$argon2id$v=19$m=64,t=1,p=1$") {
+		t.Errorf("Unexpected hash format %q", hash)
+	}
+
+	if ok, err := testPolicy.Verify(hash, "correct horse battery"); !ok || err != nil {
+		t.Errorf("Expected password to verify, got %v, %v", ok, err)
+	}
+	if ok, _ := testPolicy.Verify(hash, "wrong horse battery"); ok {
+		t.Error("Expected wrong password not to verify")
+	}
+
+	other, _ := testPolicy.Hash("correct horse battery")
+	if other == hash {
+		t.Error("Expected a unique salt per hash")
+	}
+}
+
+func TestPasswordPolicy_Fallback(t *testing.T) {
+	hash, err := testFallbackPolicy.Hash("correct horse battery")
+	if err != nil {
+		t.Fatalf("Hash() error = %v", err)
+	}
+	if !strings.HasPrefix(hash, "$pbkdf2-sha256$i=1000$") {
+		t.Errorf("Unexpected hash format %q", hash)
+	}
+	if ok, err := testFallbackPolicy.Verify(hash, "correct horse battery"); !ok || err != nil {
+		t.Errorf("Expected password to verify, got %v, %v", ok, err)
+	}
+
+	// Hashes verify regardless of the algorithm the policy creates
+	if ok, _ := testPolicy.Verify(hash, "correct horse battery"); !ok {
+		t.Error("Expected fallback hash to verify under the argon2id policy")
+	}
+	if !testPolicy.NeedsRehash(hash) {
+		t.Error("Expected fallback hash to need rehashing under the argon2id policy")
+	}
+}
+
+func TestPasswordPolicy_Bcrypt(t *testing.T) {
+	legacy, err := bcrypt.GenerateFromPassword([]byte("correct horse battery"), bcrypt.MinCost)
+	if err != nil {
+		t.Fatalf("GenerateFromPassword() error = %v", err)
+	}
+	hash := string(legacy)
+
+	if ok, err := testPolicy.Verify(hash, "correct horse battery"); !ok || err != nil {
+		t.Errorf("Expected bcrypt hash to verify, got %v, %v", ok, err)
+	}
+	if ok, err := testPolicy.Verify(hash, "wrong horse battery"); ok || err != nil {
+		t.Errorf("Expected wrong password not to verify, got %v, %v", ok, err)
+	}
+	if !testPolicy.NeedsRehash(hash) {
+		t.Error("Expected bcrypt hash to need rehashing")
+	}
+}
+
+func TestPasswordPolicy_Validate(t *testing.T) {
+	if _, err := testPolicy.Hash("short"); !errors.Is(err, ErrPasswordTooShort) {
+		t.Errorf("Expected ErrPasswordTooShort, got %v", err)
+	}
+	if _, err := testPolicy.Hash(strings.Repeat("x", 65)); !errors.Is(err, ErrPasswordTooLong) {
+		t.Errorf("Expected ErrPasswordTooLong, got %v", err)
+	}
+	// Length is measured in characters, not bytes
+	if err := testPolicy.Validate("ñññññññ"); !errors.Is(err, ErrPasswordTooShort) {
+		t.Errorf("Expected 7 characters to be too short, got %v", err)
+	}
+}
+
+func TestPasswordPolicy_NeedsRehash(t *testing.T) {
+	hash, _ := testPolicy.Hash("correct horse battery")
+
+	if testPolicy.NeedsRehash(hash) {
+		t.Error("Expected hash under the current policy not to need rehashing")
+	}
+
+	stronger := testPolicy
+	stronger.Memory = 128
+	if !stronger.NeedsRehash(hash) {
+		t.Error("Expected hash to need rehashing after raising memory")
+	}
+	if ok, _ := stronger.Verify(hash, "correct horse battery"); !ok {
+		t.Error("Expected old hashes to verify under a stronger policy")
+	}
+	if !stronger.NeedsRehash("garbage") {
+		t.Error("Expected unparseable hash to need rehashing")
+	}
+}
+
+func TestVerifyPassword_InvalidHash(t *testing.T) {
+	for _, hash := range []string{"", "plain", "$bcrypt$x$y$z", "$2b$10$short", "$argon2id$v=16$m=64,t=1,p=1$c2FsdA$a2V5", "$argon2id$v=19$m=0,t=1,p=1$c2FsdA$a2V5", "$pbkdf2-sha256$n=1$c2FsdA$a2V5", "$pbkdf2-sha256$i=1$!!$a2V5"} {
+		if _, err := VerifyPassword(hash, "password123"); !errors.Is(err, ErrInvalidHash) {
+			t.Errorf("Expected ErrInvalidHash for %q, got %v", hash, err)
+		}
+	}
+}
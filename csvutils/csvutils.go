@@ -0,0 +1,272 @@
+package csvutils
+
+import (
+	"encoding"
+	"errors"
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+)
+
+var (
+	// ErrNotStruct is returned when the record type is not a struct
+	ErrNotStruct = errors.New("csvutils: record type must be a struct")
+
+	// ErrMissingColumn is returned in strict mode when a tagged column is not in the header
+	ErrMissingColumn = errors.New("missing column")
+
+	// ErrUnknownColumn is returned in strict mode when the header has a column with no matching field
+	ErrUnknownColumn = errors.New("unknown column")
+
+	// ErrUnsupportedType is returned when a field type cannot be converted
+	ErrUnsupportedType = errors.New("unsupported field type")
+)
+
+// RowError describes a failure to convert a single cell
+type RowError struct {
+	// Line is the 1-based line number in the input, counting the header
+	Line int
+	// Column is the header name of the cell
+	Column string
+	// Value is the raw cell value
+	Value string
+	// Err is the underlying conversion error
+	Err error
+}
+
+// Error implements the error interface
+func (e *RowError) Error() string {
+	return fmt.Sprintf("line %d, column %q: %v", e.Line, e.Column, e.Err)
+}
+
+// Unwrap returns the underlying error
+func (e *RowError) Unwrap() error {
+	return e.Err
+}
+
+// Errors collects the row errors encountered by ReadAll
+type Errors []*RowError
+
+// Error implements the error interface
+func (e Errors) Error() string {
+	if len(e) == 1 {
+		return e[0].Error()
+	}
+	return fmt.Sprintf("%s (and %d more errors)", e[0].Error(), len(e)-1)
+}
+
+// options holds reader and writer settings
+type options struct {
+	delimiter  rune
+	timeFormat string
+	strict     bool
+}
+
+// Option configures a Reader or Writer
+type Option func(*options)
+
+// WithDelimiter sets the field delimiter. Defaults to a comma.
+func WithDelimiter(r rune) Option {
+	return func(o *options) {
+		o.delimiter = r
+	}
+}
+
+// WithTimeFormat sets the default layout for time.Time fields. Defaults to time.RFC3339.
+// Individual fields can override it with the format tag option.
+func WithTimeFormat(layout string) Option {
+	return func(o *options) {
+		o.timeFormat = layout
+	}
+}
+
+// WithStrict makes the Reader reject headers with missing or unknown columns
+func WithStrict() Option {
+	return func(o *options) {
+		o.strict = true
+	}
+}
+
+// newOptions applies opts over the defaults
+func newOptions(opts []Option) options {
+	o := options{delimiter: ',', timeFormat: time.RFC3339}
+	for _, opt := range opts {
+		opt(&o)
+	}
+	return o
+}
+
+// field maps a struct field to a CSV column
+type field struct {
+	name   string
+	index  []int
+	format string
+}
+
+var (
+	timeType            = reflect.TypeOf(time.Time{})
+	textUnmarshalerType = reflect.TypeOf((*encoding.TextUnmarshaler)(nil)).Elem()
+	textMarshalerType   = reflect.TypeOf((*encoding.TextMarshaler)(nil)).Elem()
+)
+
+// fieldsOf returns the CSV columns for struct type t in field order.
+// Fields are named by the csv tag, or by the field name when untagged; "-" skips a field.
+func fieldsOf(t reflect.Type) ([]field, error) {
+	if t.Kind() != reflect.Struct {
+		return nil, ErrNotStruct
+	}
+
+	var fields []field
+	for i := 0; i < t.NumField(); i++ {
+		sf := t.Field(i)
+		if !sf.IsExported() {
+			continue
+		}
+
+		tag := sf.Tag.Get("csv")
+		if tag == "-" {
+			continue
+		}
+
+		name, opts, _ := strings.Cut(tag, ",")
+		if name == "" {
+			name = sf.Name
+		}
+
+		f := field{name: name, index: sf.Index}
+		for _, opt := range strings.Split(opts, ",") {
+			if layout, ok := strings.CutPrefix(opt, "format="); ok {
+				f.format = layout
+			}
+		}
+
+		if !supported(sf.Type) {
+			return nil, fmt.Errorf("%w: %s (%s)", ErrUnsupportedType, sf.Name, sf.Type)
+		}
+		fields = append(fields, f)
+	}
+	return fields, nil
+}
+
+// supported reports whether values of type t can be converted to and from cells
+func supported(t reflect.Type) bool {
+	if t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+	if t == timeType || reflect.PointerTo(t).Implements(textUnmarshalerType) {
+		return true
+	}
+	switch t.Kind() {
+	case reflect.String, reflect.Bool,
+		reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64,
+		reflect.Float32, reflect.Float64:
+		return true
+	}
+	return false
+}
+
+// setValue parses s into v. Empty cells leave v at its zero value.
+func setValue(v reflect.Value, s, layout string) error {
+	if s == "" {
+		v.SetZero()
+		return nil
+	}
+
+	if v.Kind() == reflect.Pointer {
+		ptr := reflect.New(v.Type().Elem())
+		if err := setValue(ptr.Elem(), s, layout); err != nil {
+			return err
+		}
+		v.Set(ptr)
+		return nil
+	}
+
+	if v.Type() == timeType {
+		t, err := time.Parse(layout, s)
+		if err != nil {
+			return fmt.Errorf("invalid time, expected layout %q", layout)
+		}
+		v.Set(reflect.ValueOf(t))
+		return nil
+	}
+
+	if u, ok := v.Addr().Interface().(encoding.TextUnmarshaler); ok {
+		return u.UnmarshalText([]byte(s))
+	}
+
+	switch v.Kind() {
+	case reflect.String:
+		v.SetString(s)
+	case reflect.Bool:
+		b, err := strconv.ParseBool(strings.TrimSpace(s))
+		if err != nil {
+			return errors.New("invalid boolean")
+		}
+		v.SetBool(b)
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		n, err := strconv.ParseInt(strings.TrimSpace(s), 10, v.Type().Bits())
+		if err != nil {
+			return errors.New("invalid integer")
+		}
+		v.SetInt(n)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		n, err := strconv.ParseUint(strings.TrimSpace(s), 10, v.Type().Bits())
+		if err != nil {
+			return errors.New("invalid unsigned integer")
+		}
+		v.SetUint(n)
+	case reflect.Float32, reflect.Float64:
+		f, err := strconv.ParseFloat(strings.TrimSpace(s), v.Type().Bits())
+		if err != nil {
+			return errors.New("invalid number")
+		}
+		v.SetFloat(f)
+	default:
+		return ErrUnsupportedType
+	}
+	return nil
+}
+
+// formatValue renders v as a cell. Nil pointers render as an empty cell.
+func formatValue(v reflect.Value, layout string) (string, error) {
+	if v.Kind() == reflect.Pointer {
+		if v.IsNil() {
+			return "", nil
+		}
+		v = v.Elem()
+	}
+
+	if v.Type() == timeType {
+		t := v.Interface().(time.Time)
+		if t.IsZero() {
+			return "", nil
+		}
+		return t.Format(layout), nil
+	}
+
+	if v.Type().Implements(textMarshalerType) {
+		b, err := v.Interface().(encoding.TextMarshaler).MarshalText()
+		return string(b), err
+	}
+	if v.CanAddr() && reflect.PointerTo(v.Type()).Implements(textMarshalerType) {
+		b, err := v.Addr().Interface().(encoding.TextMarshaler).MarshalText()
+		return string(b), err
+	}
+
+	switch v.Kind() {
+	case reflect.String:
+		return v.String(), nil
+	case reflect.Bool:
+		return strconv.FormatBool(v.Bool()), nil
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return strconv.FormatInt(v.Int(), 10), nil
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return strconv.FormatUint(v.Uint(), 10), nil
+	case reflect.Float32, reflect.Float64:
+		return strconv.FormatFloat(v.Float(), 'f', -1, v.Type().Bits()), nil
+	}
+	return "", ErrUnsupportedType
+}
@@ -0,0 +1,190 @@
+package csvutils
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"strings"
+	"testing"
+	"time"
+)
+
+type order struct {
+	ID        int        `csv:"id"`
+	SKU       string     `csv:"sku"`
+	Quantity  uint       `csv:"qty"`
+	Price     float64    `csv:"price"`
+	Rush      bool       `csv:"rush"`
+	ShippedOn time.Time  `csv:"shipped_on,format=2006-01-02"`
+	Notes     *string    `csv:"notes"`
+	Internal  string     `csv:"-"`
+	Created   *time.Time `csv:"created"`
+}
+
+func TestReadAll(t *testing.T) {
+	input := "\ufeffid,sku,qty,price,rush,shipped_on,notes,created,extra\n" +
+		"1,TRD-36,4,45.5,true,2024-03-01,fragile,2024-03-01T10:00:00Z,x\n" +
+		"2,RSR-36,10,18,false,,,,\n"
+
+	rows, err := ReadAll[order](strings.NewReader(input))
+	if err != nil {
+		t.Fatalf("ReadAll() error = %v", err)
+	}
+	if len(rows) != 2 {
+		t.Fatalf("Expected 2 rows, got %d", len(rows))
+	}
+
+	first := rows[0]
+	if first.ID != 1 || first.SKU != "TRD-36" || first.Quantity != 4 || first.Price != 45.5 || !first.Rush {
+		t.Errorf("Unexpected first row %+v", first)
+	}
+	if !first.ShippedOn.Equal(time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC)) {
+		t.Errorf("Expected shipped_on 2024-03-01, got %v", first.ShippedOn)
+	}
+	if first.Notes == nil || *first.Notes != "fragile" {
+		t.Errorf("Expected notes 'fragile', got %v", first.Notes)
+	}
+	if first.Created == nil {
+		t.Error("Expected created to be parsed with the default RFC3339 layout")
+	}
+
+	second := rows[1]
+	if second.Notes != nil || second.Created != nil || !second.ShippedOn.IsZero() {
+		t.Errorf("Expected empty cells to leave zero values, got %+v", second)
+	}
+}
+
+func TestReadAll_RowErrors(t *testing.T) {
+	input := "id,sku,qty\n" +
+		"1,TRD-36,4\n" +
+		"two,RSR-36,1\n" +
+		"3,NSL-01,-5\n" +
+		"4,BAL-02,2\n"
+
+	rows, err := ReadAll[order](strings.NewReader(input))
+
+	var rowErrs Errors
+	if !errors.As(err, &rowErrs) {
+		t.Fatalf("Expected Errors, got %v", err)
+	}
+	if len(rows) != 2 {
+		t.Errorf("Expected valid rows to be returned, got %d", len(rows))
+	}
+	if len(rowErrs) != 2 {
+		t.Fatalf("Expected 2 row errors, got %d", len(rowErrs))
+	}
+	if rowErrs[0].Line != 3 || rowErrs[0].Column != "id" || rowErrs[0].Value != "two" {
+		t.Errorf("Unexpected first error %+v", rowErrs[0])
+	}
+	if rowErrs[1].Line != 4 || rowErrs[1].Column != "qty" {
+		t.Errorf("Unexpected second error %+v", rowErrs[1])
+	}
+	if !strings.Contains(err.Error(), "and 1 more") {
+		t.Errorf("Expected summary error message, got %q", err.Error())
+	}
+}
+
+func TestNewReader_Strict(t *testing.T) {
+	type item struct {
+		SKU string `csv:"sku"`
+		Qty int    `csv:"qty"`
+	}
+
+	tests := []struct {
+		name    string
+		input   string
+		wantErr error
+	}{
+		{"exact header", "sku,qty\n", nil},
+		{"case insensitive", "SKU,Qty\n", nil},
+		{"missing column", "sku\n", ErrMissingColumn},
+		{"unknown column", "sku,qty,color\n", ErrUnknownColumn},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			_, err := NewReader[item](strings.NewReader(tc.input), WithStrict())
+			if !errors.Is(err, tc.wantErr) {
+				t.Errorf("Expected %v, got %v", tc.wantErr, err)
+			}
+		})
+	}
+
+	if _, err := NewReader[item](strings.NewReader("")); !errors.Is(err, io.EOF) {
+		t.Errorf("Expected io.EOF for empty input, got %v", err)
+	}
+	if _, err := NewReader[string](strings.NewReader("a\n")); !errors.Is(err, ErrNotStruct) {
+		t.Errorf("Expected ErrNotStruct, got %v", err)
+	}
+}
+
+func TestReader_Stream(t *testing.T) {
+	type item struct {
+		SKU string `csv:"sku"`
+		Qty int    `csv:"qty"`
+	}
+
+	r, err := NewReader[item](strings.NewReader("sku;qty\nTRD-36;4\nRSR-36;oops\nBAL-02;1\n"), WithDelimiter(';'))
+	if err != nil {
+		t.Fatalf("NewReader() error = %v", err)
+	}
+
+	var total int
+	var failed int
+	for {
+		row, err := r.Read()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			failed++
+			continue
+		}
+		total += row.Qty
+	}
+
+	if total != 5 || failed != 1 {
+		t.Errorf("Expected total 5 with 1 failure, got %d with %d", total, failed)
+	}
+}
+
+func TestWriteAll(t *testing.T) {
+	notes := "fragile, handle with care"
+	rows := []order{
+		{ID: 1, SKU: "TRD-36", Quantity: 4, Price: 45.5, Rush: true,
+			ShippedOn: time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC), Notes: &notes, Internal: "hidden"},
+		{ID: 2, SKU: "RSR-36", Quantity: 10, Price: 18},
+	}
+
+	var buf bytes.Buffer
+	if err := WriteAll(&buf, rows); err != nil {
+		t.Fatalf("WriteAll() error = %v", err)
+	}
+
+	want := "id,sku,qty,price,rush,shipped_on,notes,created\n" +
+		"1,TRD-36,4,45.5,true,2024-03-01,\"fragile, handle with care\",\n" +
+		"2,RSR-36,10,18,false,,,\n"
+	if buf.String() != want {
+		t.Errorf("Unexpected output:\n%s\nwant:\n%s", buf.String(), want)
+	}
+
+	// The output must read back into the same values
+	got, err := ReadAll[order](&buf)
+	if err != nil {
+		t.Fatalf("ReadAll() error = %v", err)
+	}
+	if got[0].SKU != "TRD-36" || *got[0].Notes != notes || got[0].Internal != "" {
+		t.Errorf("Unexpected round trip %+v", got[0])
+	}
+}
+
+func TestWriter_UnsupportedType(t *testing.T) {
+	type bad struct {
+		Tags []string `csv:"tags"`
+	}
+
+	err := WriteAll(io.Discard, []bad{{}})
+	if !errors.Is(err, ErrUnsupportedType) {
+		t.Errorf("Expected ErrUnsupportedType, got %v", err)
+	}
+}
@@ -0,0 +1,90 @@
+/*
+Package csvutils provides struct-tag-based CSV reading and writing.
+
+Import and export features describe their rows as structs; csvutils maps header
+columns to fields, converts cell values, and reports conversion failures with the
+line and column that caused them so users can fix their spreadsheets.
+
+# Features
+
+  - Columns mapped by csv struct tags, matched case-insensitively
+  - Conversion for strings, bools, integers, floats, time.Time, pointers, and
+    types implementing encoding.TextMarshaler and encoding.TextUnmarshaler
+  - Custom delimiters for semicolon- and tab-separated files
+  - Streaming Reader and Writer for large files
+  - Row-level errors that do not stop the import
+  - Optional strict header validation
+
+# Struct Tags
+
+Fields are named by the csv tag, or by the field name when untagged. A tag of "-"
+skips the field. time.Time fields accept a format option that overrides the
+default RFC 3339 layout:
+
+	type Order struct {
+		ID        int       `csv:"order_id"`
+		SKU       string    `csv:"sku"`
+		Quantity  int       `csv:"qty"`
+		ShippedOn time.Time `csv:"shipped_on,format=2006-01-02"`
+		Notes     *string   `csv:"notes"`
+		Internal  string    `csv:"-"`
+	}
+
+Empty cells leave fields at their zero value; pointer fields stay nil.
+
+# Reading
+
+ReadAll reads every row, skipping rows that fail to convert and returning them as
+an Errors value alongside the rows that succeeded:
+
+	orders, err := csvutils.ReadAll[Order](file)
+	var rowErrs csvutils.Errors
+	if errors.As(err, &rowErrs) {
+		for _, e := range rowErrs {
+			// e.Line, e.Column, e.Value, e.Err
+		}
+	} else if err != nil {
+		return err
+	}
+
+For large files, read one row at a time:
+
+	r, err := csvutils.NewReader[Order](file, csvutils.WithDelimiter(';'))
+	if err != nil {
+		return err
+	}
+	for {
+		order, err := r.Read()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		var rowErr *csvutils.RowError
+		if errors.As(err, &rowErr) {
+			continue // or record it and keep going
+		}
+		if err != nil {
+			return err
+		}
+		process(order)
+	}
+
+WithStrict rejects headers that are missing a tagged column or contain an
+unknown one.
+
+# Writing
+
+WriteAll writes a header followed by every row:
+
+	err := csvutils.WriteAll(w, orders)
+
+For streaming exports, use a Writer and flush when done:
+
+	cw := csvutils.NewWriter[Order](w)
+	for _, order := range orders {
+		if err := cw.Write(order); err != nil {
+			return err
+		}
+	}
+	return cw.Flush()
+*/
+package csvutils
@@ -0,0 +1,74 @@
+package csvutils_test
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/StairSupplies/go-core/csvutils"
+)
+
+type lineItem struct {
+	SKU      string  `csv:"sku"`
+	Quantity int     `csv:"quantity"`
+	Price    float64 `csv:"unit_price"`
+}
+
+func ExampleReadAll() {
+	input := "sku,quantity,unit_price\nTRD-36,4,45.50\nRSR-36,ten,18.00\n"
+
+	items, err := csvutils.ReadAll[lineItem](strings.NewReader(input))
+
+	var rowErrs csvutils.Errors
+	if errors.As(err, &rowErrs) {
+		for _, e := range rowErrs {
+			fmt.Println(e)
+		}
+	}
+	fmt.Printf("%d valid row(s): %+v\n", len(items), items[0])
+	// Output:
+	// line 3, column "quantity": invalid integer
+	// 1 valid row(s): {SKU:TRD-36 Quantity:4 Price:45.5}
+}
+
+func ExampleNewReader() {
+	input := "sku;quantity;unit_price\nTRD-36;4;45.50\nRSR-36;2;18.00\n"
+
+	r, err := csvutils.NewReader[lineItem](strings.NewReader(input), csvutils.WithDelimiter(';'))
+	if err != nil {
+		fmt.Printf("Error reading header: %v\n", err)
+		return
+	}
+
+	for {
+		item, err := r.Read()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			fmt.Printf("Skipping row: %v\n", err)
+			continue
+		}
+		fmt.Println(item.SKU, item.Quantity)
+	}
+	// Output:
+	// TRD-36 4
+	// RSR-36 2
+}
+
+func ExampleWriteAll() {
+	items := []lineItem{
+		{SKU: "TRD-36", Quantity: 4, Price: 45.5},
+		{SKU: "RSR-36", Quantity: 2, Price: 18},
+	}
+
+	if err := csvutils.WriteAll(os.Stdout, items); err != nil {
+		fmt.Printf("Error writing CSV: %v\n", err)
+	}
+	// Output:
+	// sku,quantity,unit_price
+	// TRD-36,4,45.5
+	// RSR-36,2,18
+}
@@ -0,0 +1,147 @@
+package csvutils
+
+import (
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"reflect"
+	"strings"
+)
+
+// Reader decodes CSV rows into values of type T, one row at a time.
+// The first row of the input must be a header.
+type Reader[T any] struct {
+	csv     *csv.Reader
+	opts    options
+	columns []*field // columns[i] is the field for header column i, or nil
+	header  []string
+	line    int
+}
+
+// NewReader reads the header from r and returns a Reader for the remaining rows
+func NewReader[T any](r io.Reader, opts ...Option) (*Reader[T], error) {
+	o := newOptions(opts)
+
+	var zero T
+	fields, err := fieldsOf(reflect.TypeOf(zero))
+	if err != nil {
+		return nil, err
+	}
+
+	cr := csv.NewReader(r)
+	cr.Comma = o.delimiter
+	cr.FieldsPerRecord = -1
+	cr.ReuseRecord = true
+
+	header, err := cr.Read()
+	if err != nil {
+		if errors.Is(err, io.EOF) {
+			return nil, fmt.Errorf("csvutils: missing header row: %w", err)
+		}
+		return nil, fmt.Errorf("csvutils: failed to read header: %w", err)
+	}
+	header = append([]string(nil), header...)
+
+	byName := make(map[string]*field, len(fields))
+	for i := range fields {
+		byName[strings.ToLower(fields[i].name)] = &fields[i]
+	}
+
+	columns := make([]*field, len(header))
+	seen := make(map[string]bool, len(header))
+	for i, h := range header {
+		// Strip the UTF-8 byte order mark that spreadsheet exports often prepend
+		h = strings.TrimSpace(strings.TrimPrefix(h, "\ufeff"))
+		header[i] = h
+
+		f, ok := byName[strings.ToLower(h)]
+		if !ok {
+			if o.strict {
+				return nil, fmt.Errorf("csvutils: %w %q", ErrUnknownColumn, h)
+			}
+			continue
+		}
+		columns[i] = f
+		seen[strings.ToLower(f.name)] = true
+	}
+
+	if o.strict {
+		for _, f := range fields {
+			if !seen[strings.ToLower(f.name)] {
+				return nil, fmt.Errorf("csvutils: %w %q", ErrMissingColumn, f.name)
+			}
+		}
+	}
+
+	return &Reader[T]{csv: cr, opts: o, columns: columns, header: header, line: 1}, nil
+}
+
+// Header returns the column names from the input
+func (r *Reader[T]) Header() []string {
+	return r.header
+}
+
+// Read decodes the next row. It returns io.EOF when there are no more rows.
+// Conversion failures are returned as a *RowError; reading can continue with the next row.
+func (r *Reader[T]) Read() (T, error) {
+	var out T
+
+	record, err := r.csv.Read()
+	if err != nil {
+		return out, err
+	}
+	r.line, _ = r.csv.FieldPos(0)
+
+	v := reflect.ValueOf(&out).Elem()
+	for i, cell := range record {
+		if i >= len(r.columns) || r.columns[i] == nil {
+			continue
+		}
+		f := r.columns[i]
+
+		layout := f.format
+		if layout == "" {
+			layout = r.opts.timeFormat
+		}
+		if err := setValue(v.FieldByIndex(f.index), cell, layout); err != nil {
+			return out, &RowError{Line: r.line, Column: r.header[i], Value: cell, Err: err}
+		}
+	}
+
+	return out, nil
+}
+
+// ReadAll decodes every row from r. Rows that fail to convert are skipped and
+// reported together in an Errors value, alongside the rows that succeeded.
+func ReadAll[T any](r io.Reader, opts ...Option) ([]T, error) {
+	reader, err := NewReader[T](r, opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	var rows []T
+	var rowErrs Errors
+	for {
+		row, err := reader.Read()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+
+		var rowErr *RowError
+		if errors.As(err, &rowErr) {
+			rowErrs = append(rowErrs, rowErr)
+			continue
+		}
+		if err != nil {
+			return rows, fmt.Errorf("csvutils: %w", err)
+		}
+
+		rows = append(rows, row)
+	}
+
+	if len(rowErrs) > 0 {
+		return rows, rowErrs
+	}
+	return rows, nil
+}
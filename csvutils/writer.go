@@ -0,0 +1,95 @@
+package csvutils
+
+import (
+	"encoding/csv"
+	"fmt"
+	"io"
+	"reflect"
+)
+
+// Writer encodes values of type T as CSV rows, writing the header before the first row
+type Writer[T any] struct {
+	csv         *csv.Writer
+	opts        options
+	fields      []field
+	record      []string
+	wroteHeader bool
+	err         error
+}
+
+// NewWriter returns a Writer that writes to w. Call Flush when done.
+func NewWriter[T any](w io.Writer, opts ...Option) *Writer[T] {
+	o := newOptions(opts)
+
+	var zero T
+	fields, err := fieldsOf(reflect.TypeOf(zero))
+
+	cw := csv.NewWriter(w)
+	cw.Comma = o.delimiter
+
+	return &Writer[T]{
+		csv:    cw,
+		opts:   o,
+		fields: fields,
+		record: make([]string, len(fields)),
+		err:    err,
+	}
+}
+
+// WriteHeader writes the header row. It is called automatically by the first Write.
+func (w *Writer[T]) WriteHeader() error {
+	if w.err != nil {
+		return w.err
+	}
+	if w.wroteHeader {
+		return nil
+	}
+	w.wroteHeader = true
+
+	for i, f := range w.fields {
+		w.record[i] = f.name
+	}
+	return w.csv.Write(w.record)
+}
+
+// Write encodes v as a row
+func (w *Writer[T]) Write(v T) error {
+	if err := w.WriteHeader(); err != nil {
+		return err
+	}
+
+	rv := reflect.ValueOf(&v).Elem()
+	for i, f := range w.fields {
+		layout := f.format
+		if layout == "" {
+			layout = w.opts.timeFormat
+		}
+
+		cell, err := formatValue(rv.FieldByIndex(f.index), layout)
+		if err != nil {
+			return fmt.Errorf("csvutils: column %q: %w", f.name, err)
+		}
+		w.record[i] = cell
+	}
+	return w.csv.Write(w.record)
+}
+
+// Flush writes any buffered rows to the underlying writer
+func (w *Writer[T]) Flush() error {
+	w.csv.Flush()
+	return w.csv.Error()
+}
+
+// WriteAll writes a header and every row to w
+func WriteAll[T any](w io.Writer, rows []T, opts ...Option) error {
+	cw := NewWriter[T](w, opts...)
+	if err := cw.WriteHeader(); err != nil {
+		return err
+	}
+	for _, row := range rows {
+		if err := cw.Write(row); err != nil {
+			return err
+		}
+	}
+	return cw.Flush()
+}
@@ -0,0 +1,140 @@
+package ctxutils
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/go-chi/chi/v5/middleware"
+)
+
+func TestKey(t *testing.T) {
+	type cart struct{ Items int }
+
+	cartKey := NewKey[*cart]("cart")
+	otherKey := NewKey[*cart]("cart")
+
+	ctx := cartKey.WithValue(context.Background(), &cart{Items: 3})
+
+	if c, ok := cartKey.Value(ctx); !ok || c.Items != 3 {
+		t.Errorf("Expected cart with 3 items, got %v (%v)", c, ok)
+	}
+	if _, ok := otherKey.Value(ctx); ok {
+		t.Error("Expected keys with the same name not to collide")
+	}
+	if got := NewKey[int]("count").ValueOr(ctx, 7); got != 7 {
+		t.Errorf("Expected default 7, got %d", got)
+	}
+	if cartKey.String() != "cart" {
+		t.Errorf("Expected name 'cart', got %q", cartKey.String())
+	}
+}
+
+func TestRequestValues(t *testing.T) {
+	ctx := context.Background()
+	if RequestID(ctx) != "" || UserID(ctx) != "" || TenantID(ctx) != "" {
+		t.Error("Expected empty values in a bare context")
+	}
+
+	ctx = WithRequestID(ctx, "req-1")
+	ctx = WithUserID(ctx, "user-1")
+	ctx = WithTenantID(ctx, "tenant-1")
+
+	if RequestID(ctx) != "req-1" || UserID(ctx) != "user-1" || TenantID(ctx) != "tenant-1" {
+		t.Errorf("Unexpected values %q %q %q", RequestID(ctx), UserID(ctx), TenantID(ctx))
+	}
+}
+
+func TestRequestID_ChiFallback(t *testing.T) {
+	var got string
+	handler := middleware.RequestID(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		got = RequestID(r.Context())
+	}))
+
+	req := httptest.NewRequest(http.MethodGet, "/", nil)
+	req.Header.Set(middleware.RequestIDHeader, "from-header")
+	handler.ServeHTTP(httptest.NewRecorder(), req)
+
+	if got != "from-header" {
+		t.Errorf("Expected request ID from chi middleware, got %q", got)
+	}
+}
+
+func TestDetach(t *testing.T) {
+	parent, cancel := context.WithTimeout(WithUserID(context.Background(), "user-1"), time.Millisecond)
+	detached := Detach(parent)
+	cancel()
+
+	if detached.Err() != nil {
+		t.Error("Expected detached context not to be canceled with its parent")
+	}
+	if _, ok := detached.Deadline(); ok {
+		t.Error("Expected detached context to have no deadline")
+	}
+	if UserID(detached) != "user-1" {
+		t.Error("Expected detached context to keep values")
+	}
+
+	bounded, cancelBounded := DetachWithTimeout(parent, time.Hour)
+	defer cancelBounded()
+	if bounded.Err() != nil {
+		t.Error("Expected bounded context to outlive its parent")
+	}
+	if _, ok := bounded.Deadline(); !ok {
+		t.Error("Expected bounded context to have its own deadline")
+	}
+}
+
+func TestDeadlineHelpers(t *testing.T) {
+	if _, ok := Remaining(context.Background()); ok {
+		t.Error("Expected no deadline on a background context")
+	}
+	if !HasTime(context.Background(), time.Hour) {
+		t.Error("Expected contexts without a deadline to have time")
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Minute)
+	defer cancel()
+
+	remaining, ok := Remaining(ctx)
+	if !ok || remaining <= 59*time.Second || remaining > time.Minute {
+		t.Errorf("Unexpected remaining time %v", remaining)
+	}
+	if !HasTime(ctx, 30*time.Second) || HasTime(ctx, 2*time.Minute) {
+		t.Error("Unexpected HasTime result")
+	}
+
+	margin, cancelMargin := WithMargin(ctx, 10*time.Second)
+	defer cancelMargin()
+	parentDeadline, _ := ctx.Deadline()
+	marginDeadline, _ := margin.Deadline()
+	if parentDeadline.Sub(marginDeadline) != 10*time.Second {
+		t.Errorf("Expected deadline 10s earlier, got %v", parentDeadline.Sub(marginDeadline))
+	}
+
+	expired, cancelExpired := context.WithTimeout(context.Background(), -time.Second)
+	defer cancelExpired()
+	if remaining, _ := Remaining(expired); remaining != 0 {
+		t.Errorf("Expected zero remaining after deadline, got %v", remaining)
+	}
+	if HasTime(expired, 0) {
+		t.Error("Expected expired context to have no time")
+	}
+}
+
+func TestPropagatedHeaders(t *testing.T) {
+	ctx := context.Background()
+	if PropagatedHeaders(ctx) != nil {
+		t.Error("Expected no headers in a bare context")
+	}
+
+	h := http.Header{"Traceparent": {"00-abc-def-01"}}
+	ctx = WithPropagatedHeaders(ctx, h)
+	h.Set("Traceparent", "changed")
+
+	if got := PropagatedHeaders(ctx).Get("traceparent"); got != "00-abc-def-01" {
+		t.Errorf("Expected stored copy of the headers, got %q", got)
+	}
+}
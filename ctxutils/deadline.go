@@ -0,0 +1,49 @@
+package ctxutils
+
+import (
+	"context"
+	"time"
+)
+
+// Detach returns a context that carries ctx's values but is never canceled and has
+// no deadline. Use it for background work started by a request that must finish
+// after the response is sent, such as sending an email or writing an audit log.
+func Detach(ctx context.Context) context.Context {
+	return context.WithoutCancel(ctx)
+}
+
+// DetachWithTimeout is like Detach but bounds the background work with its own timeout
+func DetachWithTimeout(ctx context.Context, timeout time.Duration) (context.Context, context.CancelFunc) {
+	return context.WithTimeout(Detach(ctx), timeout)
+}
+
+// Remaining returns the time left before ctx's deadline. ok is false if ctx has no deadline.
+// The duration is zero once the deadline has passed.
+func Remaining(ctx context.Context) (remaining time.Duration, ok bool) {
+	deadline, ok := ctx.Deadline()
+	if !ok {
+		return 0, false
+	}
+	return max(time.Until(deadline), 0), true
+}
+
+// HasTime reports whether ctx is not done and has at least d left before its
+// deadline. Contexts without a deadline always have time.
+func HasTime(ctx context.Context, d time.Duration) bool {
+	if ctx.Err() != nil {
+		return false
+	}
+	remaining, ok := Remaining(ctx)
+	return !ok || remaining >= d
+}
+
+// WithMargin returns a context whose deadline is margin earlier than ctx's, leaving
+// time to handle a timeout (e.g. write an error response) before the caller gives
+// up. If ctx has no deadline it is returned with a no-op cancel function.
+func WithMargin(ctx context.Context, margin time.Duration) (context.Context, context.CancelFunc) {
+	deadline, ok := ctx.Deadline()
+	if !ok {
+		return ctx, func() {}
+	}
+	return context.WithDeadline(ctx, deadline.Add(-margin))
+}
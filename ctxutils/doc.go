@@ -0,0 +1,73 @@
+/*
+Package ctxutils provides typed context values, request-scoped identifiers, and
+deadline helpers.
+
+# Features
+
+  - Key[T], a typed context key that never collides with other packages
+  - Request ID, user ID, and tenant ID accessors shared across packages
+  - Inbound headers, such as trace context, carried to outgoing requests
+  - Detach for background work that must outlive a request
+  - Remaining, HasTime, and WithMargin for working with deadlines
+
+# Typed Values
+
+Key removes the need for private key types and type assertions:
+
+	var cartKey = ctxutils.NewKey[*Cart]("cart")
+
+	ctx = cartKey.WithValue(ctx, cart)
+
+	cart, ok := cartKey.Value(ctx)
+
+# Request Identifiers
+
+Middleware records who is making a request, and any package can read it back:
+
+	ctx = ctxutils.WithUserID(ctx, claims.Subject)
+	ctx = ctxutils.WithTenantID(ctx, claims.Tenant)
+
+	log.Info("Order placed",
+		zap.String("user_id", ctxutils.UserID(ctx)),
+		zap.String("request_id", ctxutils.RequestID(ctx)),
+	)
+
+RequestID falls back to the ID set by router.RequestID, so it works in any
+handler mounted on a go-core router.
+
+WithPropagatedHeaders stores inbound headers that outgoing calls should
+carry, such as X-Request-Id and the W3C traceparent. A router with
+Options.PropagateHeaders set to DefaultPropagatedHeaders stores them for every
+request, and a rest.Client created with WithPropagateHeaders copies them onto
+its requests:
+
+	ctx = ctxutils.WithPropagatedHeaders(ctx, http.Header{"Traceparent": {tp}})
+	h := ctxutils.PropagatedHeaders(ctx)
+
+# Background Work
+
+A request context is canceled as soon as the response is written. Detach keeps
+the values (request ID, logger, user) but drops the cancellation and deadline:
+
+	go func(ctx context.Context) {
+		ctx, cancel := context.WithTimeout(ctx, 30*time.Second)
+		defer cancel()
+		mailer.Send(ctx, receipt)
+	}(ctxutils.Detach(r.Context()))
+
+DetachWithTimeout combines both steps.
+
+# Deadlines
+
+Skipping optional work when a request is nearly out of time:
+
+	if ctxutils.HasTime(ctx, 200*time.Millisecond) {
+		recommendations, _ = fetchRecommendations(ctx)
+	}
+
+Leaving time to write an error response before the client gives up:
+
+	ctx, cancel := ctxutils.WithMargin(r.Context(), 500*time.Millisecond)
+	defer cancel()
+*/
+package ctxutils
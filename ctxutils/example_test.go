@@ -0,0 +1,32 @@
+package ctxutils_test
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/StairSupplies/go-core/ctxutils"
+)
+
+type session struct {
+	Role string
+}
+
+var sessionKey = ctxutils.NewKey[*session]("session")
+
+func ExampleNewKey() {
+	ctx := sessionKey.WithValue(context.Background(), &session{Role: "admin"})
+
+	if s, ok := sessionKey.Value(ctx); ok {
+		fmt.Println(s.Role)
+	}
+	// Output: admin
+}
+
+func ExampleDetach() {
+	ctx, cancel := context.WithCancel(ctxutils.WithRequestID(context.Background(), "req-123"))
+	cancel() // the request finished
+
+	background := ctxutils.Detach(ctx)
+	fmt.Println(background.Err(), ctxutils.RequestID(background))
+	// Output: <nil> req-123
+}
@@ -0,0 +1,66 @@
+package ctxutils
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/go-chi/chi/v5/middleware"
+)
+
+var (
+	requestIDKey = NewKey[string]("request_id")
+	userIDKey    = NewKey[string]("user_id")
+	tenantIDKey  = NewKey[string]("tenant_id")
+)
+
+// WithRequestID returns a copy of ctx carrying the request ID
+func WithRequestID(ctx context.Context, id string) context.Context {
+	return requestIDKey.WithValue(ctx, id)
+}
+
+// RequestID returns the request ID from ctx, falling back to the ID set by chi's
+// RequestID middleware. It returns an empty string if neither is present.
+func RequestID(ctx context.Context) string {
+	if id, ok := requestIDKey.Value(ctx); ok {
+		return id
+	}
+	return middleware.GetReqID(ctx)
+}
+
+// WithUserID returns a copy of ctx carrying the authenticated user's ID
+func WithUserID(ctx context.Context, id string) context.Context {
+	return userIDKey.WithValue(ctx, id)
+}
+
+// UserID returns the authenticated user's ID from ctx, or an empty string
+func UserID(ctx context.Context) string {
+	return userIDKey.ValueOr(ctx, "")
+}
+
+// WithTenantID returns a copy of ctx carrying the tenant ID
+func WithTenantID(ctx context.Context, id string) context.Context {
+	return tenantIDKey.WithValue(ctx, id)
+}
+
+// TenantID returns the tenant ID from ctx, or an empty string
+func TenantID(ctx context.Context) string {
+	return tenantIDKey.ValueOr(ctx, "")
+}
+
+// DefaultPropagatedHeaders are the inbound headers forwarded to outgoing
+// requests when no others are named: the request ID and W3C trace context
+var DefaultPropagatedHeaders = []string{middleware.RequestIDHeader, "traceparent", "tracestate"}
+
+var propagatedHeadersKey = NewKey[http.Header]("propagated_headers")
+
+// WithPropagatedHeaders returns a copy of ctx carrying headers to forward on
+// outgoing requests, such as those made with a rest.Client
+func WithPropagatedHeaders(ctx context.Context, headers http.Header) context.Context {
+	return propagatedHeadersKey.WithValue(ctx, headers.Clone())
+}
+
+// PropagatedHeaders returns the headers stored with WithPropagatedHeaders, or
+// nil if there are none. The result must not be modified.
+func PropagatedHeaders(ctx context.Context) http.Header {
+	return propagatedHeadersKey.ValueOr(ctx, nil)
+}
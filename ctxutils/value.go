@@ -0,0 +1,41 @@
+package ctxutils
+
+import "context"
+
+// Key is a typed context key. Each call to NewKey returns a distinct key, so
+// values stored by different packages never collide.
+type Key[T any] struct {
+	name *string
+}
+
+// NewKey returns a new typed key. The name is used only for debugging.
+func NewKey[T any](name string) Key[T] {
+	return Key[T]{name: &name}
+}
+
+// WithValue returns a copy of ctx carrying v under the key
+func (k Key[T]) WithValue(ctx context.Context, v T) context.Context {
+	return context.WithValue(ctx, k.name, v)
+}
+
+// Value returns the value stored under the key and whether it was present
+func (k Key[T]) Value(ctx context.Context) (T, bool) {
+	v, ok := ctx.Value(k.name).(T)
+	return v, ok
+}
+
+// ValueOr returns the value stored under the key, or def if it is not present
+func (k Key[T]) ValueOr(ctx context.Context, def T) T {
+	if v, ok := k.Value(ctx); ok {
+		return v
+	}
+	return def
+}
+
+// String returns the key name
+func (k Key[T]) String() string {
+	if k.name == nil {
+		return ""
+	}
+	return *k.name
+}
@@ -14,7 +14,8 @@ middleware patterns.
 # Router Package
 
 Package router provides an opinionated chi-based HTTP router with built-in middleware
-for logging, error handling, and request tracing.
+for logging, error handling, and request tracing. Its websocket subpackage
+implements websocket connections for router.Websocket endpoints.
 
 	import "github.com/StairSupplies/go-core/router"
 
@@ -28,23 +29,168 @@ JSON serialization/deserialization.
 # JSON Utils Package
 
 Package jsonutils provides enhanced JSON utilities for encoding and decoding with
-better error handling.
+better error handling, plus canonical encoding, diffing, merge patches and
+JSON pointers.
 
 	import "github.com/StairSupplies/go-core/jsonutils"
 
 # Logger Package
 
 Package logger provides structured logging using Uber's Zap with context-aware logging
-and both structured and formatted logging options.
+and both structured and formatted logging options. Its logtest subpackage
+records log output in tests and asserts on it.
 
 	import "github.com/StairSupplies/go-core/logger"
 
 # Config Package
 
 Package config provides utilities for loading application configuration from environment
-variables with type-safe retrieval using generics.
+variables with type-safe retrieval using generics. Its featureflags subpackage
+provides feature flags with percent rollouts and per-request overrides.
 
 	import "github.com/StairSupplies/go-core/config"
+	import "github.com/StairSupplies/go-core/config/featureflags"
+
+# Storage Package
+
+Package storage provides a blob storage abstraction with Amazon S3, Google Cloud Storage,
+and local filesystem implementations.
+
+	import "github.com/StairSupplies/go-core/storage"
+
+# Mail Package
+
+Package mail provides transactional email sending with SMTP and Amazon SES backends,
+templating, attachments, and retries.
+
+	import "github.com/StairSupplies/go-core/mail"
+
+# Grpcutils Package
+
+Package grpcutils provides gRPC server interceptors for logging, panic recovery,
+request IDs, timeouts, and mapping api errors to gRPC status codes.
+
+	import "github.com/StairSupplies/go-core/grpcutils"
+
+# Webhook Package
+
+Package webhook provides HMAC signing and verification for webhooks, a receiver
+handler with replay protection, and a dispatcher with retries.
+
+	import "github.com/StairSupplies/go-core/webhook"
+
+# Money Package
+
+Package money provides exact monetary arithmetic using integer minor units, with
+allocation, parsing, formatting, and JSON encoding.
+
+	import "github.com/StairSupplies/go-core/money"
+
+# Sliceutils Package
+
+Package sliceutils provides generic helpers for mapping, filtering, grouping,
+batching, and paginating slices.
+
+	import "github.com/StairSupplies/go-core/sliceutils"
+
+# Maputils Package
+
+Package maputils provides generic helpers for extracting, merging, and filtering
+maps, and a type-safe concurrent map.
+
+	import "github.com/StairSupplies/go-core/maputils"
+
+# Testutils Package
+
+Package testutils provides shared test fixtures: JSON request builders, api envelope
+assertions, golden files, a fake clock, a captured logger, and environment scoping.
+
+	import "github.com/StairSupplies/go-core/testutils"
+
+# Csvutils Package
+
+Package csvutils provides struct-tag-based CSV reading and writing with streaming
+and row-level error reporting.
+
+	import "github.com/StairSupplies/go-core/csvutils"
+
+# Fileutils Package
+
+Package fileutils provides atomic writes, traversal-safe path joining, checksummed
+copy and move, and temporary directory helpers.
+
+	import "github.com/StairSupplies/go-core/fileutils"
+
+# Netutils Package
+
+Package netutils provides free-port selection, readiness checks, CIDR matching,
+and dialers with sane timeouts.
+
+	import "github.com/StairSupplies/go-core/netutils"
+
+# I18n Package
+
+Package i18n provides message catalogs, plural rules, and Accept-Language
+negotiation for customer-facing text.
+
+	import "github.com/StairSupplies/go-core/i18n"
+
+# Cryptoutils Package
+
+Package cryptoutils provides AES-GCM encryption with key rotation, HMAC helpers,
+random tokens, and password hashing.
+
+	import "github.com/StairSupplies/go-core/cryptoutils"
+
+# Semver Package
+
+Package semver provides semantic version parsing, comparison, sorting, and
+constraint matching.
+
+	import "github.com/StairSupplies/go-core/semver"
+
+# Ctxutils Package
+
+Package ctxutils provides typed context values, request, user, and tenant ID
+accessors, Detach for background work, and deadline helpers.
+
+	import "github.com/StairSupplies/go-core/ctxutils"
+
+# Validate Package
+
+Package validate provides struct-tag-based request validation and an
+imperative Validator, with errors keyed by JSON field name.
+
+	import "github.com/StairSupplies/go-core/validate"
+
+# Str Package
+
+Package str provides URL slug generation, diacritic removal, whitespace
+normalization, and case conversion.
+
+	import "github.com/StairSupplies/go-core/str"
+
+# Timeutils Package
+
+Package timeutils provides timezone-aware business hours with elapsed
+business time and SLA deadline calculations, humanized relative times, and
+day, week, quarter and year boundaries.
+
+	import "github.com/StairSupplies/go-core/timeutils"
+
+# Health Package
+
+Package health provides a registry of named dependency checks with timeouts,
+and liveness and readiness handlers that report per-check status as JSON.
+
+	import "github.com/StairSupplies/go-core/health"
+
+# Errutils Package
+
+Package errutils provides errors that capture a stack trace, WithStack for
+annotating existing errors, and Chain for listing wrapped errors.
+
+	import "github.com/StairSupplies/go-core/errutils"
 
 See the individual package documentation for more details and examples.
 */
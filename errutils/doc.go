@@ -0,0 +1,40 @@
+/*
+Package errutils provides errors that record the stack trace where they were
+created, and helpers for inspecting error chains.
+
+Errors from the standard library are plain strings once they are logged,
+which makes it hard to tell where a failure started. Wrapping them with
+WithStack at the point they enter your code keeps that information, and
+logger.Err writes it out with the full error chain.
+
+# Features
+
+  - New and Errorf, which record the caller's stack trace
+  - WithStack for annotating errors from other packages
+  - StackTrace and HasStack for reading the recorded stack back
+  - Chain for listing every error wrapped with %w or errors.Join
+
+# Capturing Stack Traces
+
+	row := db.QueryRowContext(ctx, query, id)
+	if err := row.Scan(&order.ID, &order.Total); err != nil {
+		return errutils.WithStack(err)
+	}
+
+	if qty <= 0 {
+		return errutils.Errorf("invalid quantity %d for %s", qty, sku)
+	}
+
+Stack-carrying errors behave like the errors they wrap: errors.Is, errors.As
+and Error all see through them. WithStack keeps an existing stack, so
+wrapping the same error at several layers records where it started.
+
+# Inspecting Errors
+
+	errutils.StackTrace(err)  // "main.loadOrder\n\t/app/orders.go:42\n..."
+	errutils.Chain(err)       // [err, the error it wraps, ...]
+
+Log stack-carrying errors with logger.Err to record the message, the chain
+and the stack as structured fields.
+*/
+package errutils
@@ -0,0 +1,35 @@
+package errutils_test
+
+import (
+	"errors"
+	"fmt"
+	"io"
+
+	"github.com/StairSupplies/go-core/errutils"
+)
+
+func ExampleWithStack() {
+	err := fmt.Errorf("reading order: %w", errutils.WithStack(io.ErrUnexpectedEOF))
+
+	fmt.Println(err)
+	fmt.Println(errors.Is(err, io.ErrUnexpectedEOF))
+	fmt.Println(errutils.HasStack(err))
+
+	// Output:
+	// reading order: unexpected EOF
+	// true
+	// true
+}
+
+func ExampleChain() {
+	err := fmt.Errorf("saving order: %w", errutils.Errorf("inventory: %w", io.EOF))
+
+	for _, e := range errutils.Chain(err) {
+		fmt.Println(e)
+	}
+
+	// Output:
+	// saving order: inventory: EOF
+	// inventory: EOF
+	// EOF
+}
@@ -0,0 +1,117 @@
+package errutils
+
+import (
+	"errors"
+	"fmt"
+	"runtime"
+	"strconv"
+	"strings"
+)
+
+// maxStackDepth is the most frames recorded for one stack trace
+const maxStackDepth = 64
+
+// stackError is an error annotated with the stack where it was captured
+type stackError struct {
+	err error
+	pcs []uintptr
+}
+
+func (e *stackError) Error() string { return e.err.Error() }
+
+func (e *stackError) Unwrap() error { return e.err }
+
+// StackTrace returns the stack where the error was captured, one function
+// and file:line pair per frame, as in a panic trace
+func (e *stackError) StackTrace() string {
+	var b strings.Builder
+	frames := runtime.CallersFrames(e.pcs)
+	for {
+		frame, more := frames.Next()
+		if b.Len() > 0 {
+			b.WriteByte('\n')
+		}
+		b.WriteString(frame.Function)
+		b.WriteString("\n\t")
+		b.WriteString(frame.File)
+		b.WriteByte(':')
+		b.WriteString(strconv.Itoa(frame.Line))
+		if !more {
+			break
+		}
+	}
+	return b.String()
+}
+
+// New returns an error with the given text and the caller's stack trace
+func New(text string) error {
+	return capture(errors.New(text), 3)
+}
+
+// Errorf formats an error like fmt.Errorf, including %w wrapping, and
+// records the caller's stack trace
+func Errorf(format string, args ...any) error {
+	return capture(fmt.Errorf(format, args...), 3)
+}
+
+// WithStack records the caller's stack trace on err. It returns nil if err is
+// nil, and err unchanged if its chain already carries a stack trace, so the
+// innermost, most useful stack is kept.
+func WithStack(err error) error {
+	if err == nil || HasStack(err) {
+		return err
+	}
+	return capture(err, 3)
+}
+
+// capture wraps err with the stack, skipping skip frames including itself
+func capture(err error, skip int) error {
+	pcs := make([]uintptr, maxStackDepth)
+	n := runtime.Callers(skip, pcs)
+	return &stackError{err: err, pcs: pcs[:n]}
+}
+
+// HasStack reports whether any error in err's chain carries a stack trace
+func HasStack(err error) bool {
+	var se *stackError
+	return errors.As(err, &se)
+}
+
+// StackTrace returns the stack trace recorded by New, Errorf or WithStack on
+// the first error in err's chain that has one, or "" if there is none
+func StackTrace(err error) string {
+	var se *stackError
+	if !errors.As(err, &se) {
+		return ""
+	}
+	return se.StackTrace()
+}
+
+// Chain returns err followed by the errors it wraps, depth first, following
+// both Unwrap() error and Unwrap() []error. The wrappers added by New, Errorf
+// and WithStack are left out, since their message is that of the error they
+// wrap.
+func Chain(err error) []error {
+	var chain []error
+	var walk func(error)
+	walk = func(err error) {
+		if err == nil {
+			return
+		}
+		if se, ok := err.(*stackError); ok {
+			walk(se.err)
+			return
+		}
+		chain = append(chain, err)
+		switch x := err.(type) {
+		case interface{ Unwrap() error }:
+			walk(x.Unwrap())
+		case interface{ Unwrap() []error }:
+			for _, e := range x.Unwrap() {
+				walk(e)
+			}
+		}
+	}
+	walk(err)
+	return chain
+}
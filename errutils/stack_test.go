@@ -0,0 +1,110 @@
+package errutils
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"testing"
+)
+
+func TestWithStack(t *testing.T) {
+	if WithStack(nil) != nil {
+		t.Error("Expected WithStack(nil) to return nil")
+	}
+
+	err := WithStack(io.EOF)
+	if err.Error() != io.EOF.Error() {
+		t.Errorf("Expected message %q, got %q", io.EOF.Error(), err.Error())
+	}
+	if !errors.Is(err, io.EOF) {
+		t.Error("Expected errors.Is to see through WithStack")
+	}
+	if !HasStack(err) {
+		t.Error("Expected error to carry a stack")
+	}
+
+	stack := StackTrace(err)
+	if !strings.Contains(stack, "errutils.TestWithStack") {
+		t.Errorf("Expected stack to start in the test, got:\n%s", stack)
+	}
+	if strings.Contains(stack, "errutils.capture") || strings.Contains(stack, "errutils.WithStack") {
+		t.Errorf("Expected stack to leave out errutils frames, got:\n%s", stack)
+	}
+	if !strings.Contains(stack, "stack_test.go:") {
+		t.Errorf("Expected stack to include file and line, got:\n%s", stack)
+	}
+}
+
+func TestWithStackKeepsInnermost(t *testing.T) {
+	inner := New("boom")
+	outer := WithStack(fmt.Errorf("loading order: %w", inner))
+
+	if outer.Error() != "loading order: boom" {
+		t.Errorf("Expected wrapped message, got %q", outer.Error())
+	}
+	if StackTrace(outer) != StackTrace(inner) {
+		t.Error("Expected the inner stack to be kept")
+	}
+	if _, ok := outer.(*stackError); ok {
+		t.Error("Expected WithStack not to add a second stack")
+	}
+}
+
+func TestErrorf(t *testing.T) {
+	err := Errorf("reading config: %w", io.ErrUnexpectedEOF)
+	if err.Error() != "reading config: unexpected EOF" {
+		t.Errorf("Expected formatted message, got %q", err.Error())
+	}
+	if !errors.Is(err, io.ErrUnexpectedEOF) {
+		t.Error("Expected %w to be preserved")
+	}
+	if !strings.Contains(StackTrace(err), "errutils.TestErrorf") {
+		t.Errorf("Expected stack to start in the test, got:\n%s", StackTrace(err))
+	}
+}
+
+func TestStackTraceWithoutStack(t *testing.T) {
+	if s := StackTrace(io.EOF); s != "" {
+		t.Errorf("Expected empty stack, got %q", s)
+	}
+	if HasStack(io.EOF) || HasStack(nil) {
+		t.Error("Expected no stack")
+	}
+}
+
+func TestChain(t *testing.T) {
+	base := errors.New("connection refused")
+	wrapped := fmt.Errorf("query orders: %w", WithStack(base))
+	joined := errors.Join(wrapped, io.EOF)
+
+	tests := []struct {
+		name string
+		err  error
+		want []string
+	}{
+		{"nil", nil, nil},
+		{"single", base, []string{"connection refused"}},
+		{"wrapped", wrapped, []string{"query orders: connection refused", "connection refused"}},
+		{"joined", joined, []string{
+			"query orders: connection refused\nEOF",
+			"query orders: connection refused",
+			"connection refused",
+			"EOF",
+		}},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			chain := Chain(tc.err)
+			if len(chain) != len(tc.want) {
+				t.Fatalf("Expected %d errors, got %d: %v", len(tc.want), len(chain), chain)
+			}
+			for i, err := range chain {
+				if err.Error() != tc.want[i] {
+					t.Errorf("Expected chain[%d] %q, got %q", i, tc.want[i], err.Error())
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,49 @@
+/*
+Package fileutils provides safe file operations for batch jobs and services that
+work with the local filesystem.
+
+# Features
+
+  - Atomic writes using a temporary file, fsync, and rename
+  - SafeJoin for building paths from untrusted input without directory traversal
+  - Copy and Move that verify SHA-256 checksums
+  - Move that falls back to copy-and-delete across filesystems
+  - Exists, IsDir, IsFile, and Size helpers
+  - WithTempDir for scoped scratch directories
+
+# Atomic Writes
+
+WriteFile and WriteAtomic never leave a partially written file behind. The data is
+written to a temporary file in the same directory and renamed into place, so
+readers see either the old contents or the new contents:
+
+	err := fileutils.WriteFile("/var/lib/app/state.json", data, 0o644)
+
+	err := fileutils.WriteAtomic(path, resp.Body, 0o644)
+
+# Untrusted Paths
+
+SafeJoin rejects paths that would escape the base directory:
+
+	path, err := fileutils.SafeJoin(uploadDir, r.FormValue("filename"))
+	if errors.Is(err, fileutils.ErrPathTraversal) {
+		return api.BadRequestError(err)
+	}
+
+# Copying and Moving
+
+Copy and Move return the SHA-256 of the file so callers can record it:
+
+	sum, err := fileutils.Copy("/data/in/orders.csv", "/data/archive/orders.csv")
+
+	sum, err := fileutils.Move("/tmp/export.csv", "/mnt/shared/export.csv")
+
+# Temporary Directories
+
+WithTempDir removes the directory and everything in it when the function returns:
+
+	err := fileutils.WithTempDir("import-*", func(dir string) error {
+		return unzip(archive, dir)
+	})
+*/
+package fileutils
@@ -0,0 +1,42 @@
+package fileutils_test
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/StairSupplies/go-core/fileutils"
+)
+
+func ExampleSafeJoin() {
+	// The file name comes from user input and must stay inside the uploads directory
+	_, err := fileutils.SafeJoin("/srv/uploads", "../../etc/passwd")
+	fmt.Println(errors.Is(err, fileutils.ErrPathTraversal))
+
+	path, _ := fileutils.SafeJoin("/srv/uploads", "invoices", "1001.pdf")
+	fmt.Println(filepath.ToSlash(path))
+	// Output:
+	// true
+	// /srv/uploads/invoices/1001.pdf
+}
+
+func ExampleWithTempDir() {
+	err := fileutils.WithTempDir("export-*", func(dir string) error {
+		path := filepath.Join(dir, "orders.csv")
+		if err := fileutils.WriteFile(path, []byte("id,sku\n1,TRD-36\n"), 0o644); err != nil {
+			return err
+		}
+
+		size, err := fileutils.Size(path)
+		if err != nil {
+			return err
+		}
+		fmt.Println(size)
+		return nil
+	})
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+	}
+	// Output: 16
+}
@@ -0,0 +1,220 @@
+package fileutils
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+var (
+	// ErrPathTraversal is returned by SafeJoin when the result would escape the base directory
+	ErrPathTraversal = errors.New("path escapes base directory")
+
+	// ErrChecksumMismatch is returned when a copied file does not match its source
+	ErrChecksumMismatch = errors.New("checksum mismatch")
+)
+
+// WriteFile atomically writes data to path. See WriteAtomic.
+func WriteFile(path string, data []byte, perm fs.FileMode) error {
+	return WriteAtomic(path, bytes.NewReader(data), perm)
+}
+
+// WriteAtomic writes r to a temporary file in the same directory as path, syncs it,
+// and renames it over path. Readers see either the old contents or the new contents,
+// never a partial write.
+func WriteAtomic(path string, r io.Reader, perm fs.FileMode) error {
+	_, err := writeAtomic(path, r, perm)
+	return err
+}
+
+// writeAtomic implements WriteAtomic, returning the SHA-256 of the written data
+func writeAtomic(path string, r io.Reader, perm fs.FileMode) (string, error) {
+	dir := filepath.Dir(path)
+	tmp, err := os.CreateTemp(dir, "."+filepath.Base(path)+".tmp-*")
+	if err != nil {
+		return "", fmt.Errorf("failed to create temp file: %w", err)
+	}
+	tmpName := tmp.Name()
+	defer os.Remove(tmpName)
+
+	h := sha256.New()
+	if _, err := io.Copy(io.MultiWriter(tmp, h), r); err != nil {
+		tmp.Close()
+		return "", fmt.Errorf("failed to write %s: %w", path, err)
+	}
+	if err := tmp.Chmod(perm); err != nil {
+		tmp.Close()
+		return "", fmt.Errorf("failed to set permissions on %s: %w", path, err)
+	}
+	if err := tmp.Sync(); err != nil {
+		tmp.Close()
+		return "", fmt.Errorf("failed to sync %s: %w", path, err)
+	}
+	if err := tmp.Close(); err != nil {
+		return "", fmt.Errorf("failed to close %s: %w", path, err)
+	}
+
+	if err := os.Rename(tmpName, path); err != nil {
+		return "", fmt.Errorf("failed to replace %s: %w", path, err)
+	}
+	syncDir(dir)
+
+	return hex.EncodeToString(h.Sum(nil)), nil
+}
+
+// syncDir flushes directory metadata so a rename survives a crash. Errors are
+// ignored because some platforms do not support syncing directories.
+func syncDir(dir string) {
+	if d, err := os.Open(dir); err == nil {
+		d.Sync()
+		d.Close()
+	}
+}
+
+// SafeJoin joins untrusted path elements onto base and returns an error if the
+// result would be outside base, e.g. because an element contains "..".
+func SafeJoin(base string, elems ...string) (string, error) {
+	base = filepath.Clean(base)
+	joined := filepath.Join(append([]string{base}, elems...)...)
+
+	rel, err := filepath.Rel(base, joined)
+	if err != nil || rel == ".." || strings.HasPrefix(rel, ".."+string(filepath.Separator)) {
+		return "", fmt.Errorf("%w: %s", ErrPathTraversal, filepath.Join(elems...))
+	}
+	for _, e := range elems {
+		if filepath.IsAbs(e) || filepath.VolumeName(e) != "" {
+			return "", fmt.Errorf("%w: %s", ErrPathTraversal, e)
+		}
+	}
+	return joined, nil
+}
+
+// Checksum returns the hex-encoded SHA-256 of the file at path
+func Checksum(path string) (string, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return "", err
+	}
+	defer f.Close()
+
+	h := sha256.New()
+	if _, err := io.Copy(h, f); err != nil {
+		return "", fmt.Errorf("failed to read %s: %w", path, err)
+	}
+	return hex.EncodeToString(h.Sum(nil)), nil
+}
+
+// Copy atomically copies src to dst, preserving its permissions, and verifies the
+// copy against the source checksum. It returns the hex-encoded SHA-256 of the contents.
+func Copy(src, dst string) (string, error) {
+	in, err := os.Open(src)
+	if err != nil {
+		return "", err
+	}
+	defer in.Close()
+
+	info, err := in.Stat()
+	if err != nil {
+		return "", err
+	}
+	if !info.Mode().IsRegular() {
+		return "", fmt.Errorf("cannot copy %s: not a regular file", src)
+	}
+
+	sum, err := writeAtomic(dst, in, info.Mode().Perm())
+	if err != nil {
+		return "", err
+	}
+
+	got, err := Checksum(dst)
+	if err != nil {
+		return "", err
+	}
+	if got != sum {
+		return "", fmt.Errorf("%w: copied %s to %s", ErrChecksumMismatch, src, dst)
+	}
+	return sum, nil
+}
+
+// Move renames src to dst. When a rename is not possible, such as across
+// filesystems, it copies and verifies the file before removing src. It returns
+// the hex-encoded SHA-256 of the contents.
+func Move(src, dst string) (string, error) {
+	sum, err := Checksum(src)
+	if err != nil {
+		return "", err
+	}
+
+	if err := os.Rename(src, dst); err == nil {
+		return sum, nil
+	}
+
+	copied, err := Copy(src, dst)
+	if err != nil {
+		return "", err
+	}
+	if copied != sum {
+		os.Remove(dst)
+		return "", fmt.Errorf("%w: %s changed while moving", ErrChecksumMismatch, src)
+	}
+	if err := os.Remove(src); err != nil {
+		return "", fmt.Errorf("copied to %s but failed to remove %s: %w", dst, src, err)
+	}
+	return sum, nil
+}
+
+// Exists reports whether path exists. Errors other than not-exist are returned.
+func Exists(path string) (bool, error) {
+	_, err := os.Stat(path)
+	if err == nil {
+		return true, nil
+	}
+	if errors.Is(err, fs.ErrNotExist) {
+		return false, nil
+	}
+	return false, err
+}
+
+// IsDir reports whether path exists and is a directory
+func IsDir(path string) bool {
+	info, err := os.Stat(path)
+	return err == nil && info.IsDir()
+}
+
+// IsFile reports whether path exists and is a regular file
+func IsFile(path string) bool {
+	info, err := os.Stat(path)
+	return err == nil && info.Mode().IsRegular()
+}
+
+// Size returns the size of the file at path in bytes
+func Size(path string) (int64, error) {
+	info, err := os.Stat(path)
+	if err != nil {
+		return 0, err
+	}
+	return info.Size(), nil
+}
+
+// WithTempDir creates a temporary directory, calls fn with its path, and removes
+// the directory and its contents when fn returns
+func WithTempDir(pattern string, fn func(dir string) error) (err error) {
+	dir, err := os.MkdirTemp("", pattern)
+	if err != nil {
+		return fmt.Errorf("failed to create temp dir: %w", err)
+	}
+	defer func() {
+		if rmErr := os.RemoveAll(dir); rmErr != nil && err == nil {
+			err = fmt.Errorf("failed to remove temp dir: %w", rmErr)
+		}
+	}()
+
+	return fn(dir)
+}
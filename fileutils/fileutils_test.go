@@ -0,0 +1,175 @@
+package fileutils
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"testing"
+)
+
+func TestWriteFile(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "report.csv")
+
+	if err := WriteFile(path, []byte("first"), 0o600); err != nil {
+		t.Fatalf("WriteFile() error = %v", err)
+	}
+	if err := WriteFile(path, []byte("second"), 0o600); err != nil {
+		t.Fatalf("WriteFile() error = %v", err)
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil || string(data) != "second" {
+		t.Errorf("Expected 'second', got %q (%v)", data, err)
+	}
+
+	if runtime.GOOS != "windows" {
+		info, _ := os.Stat(path)
+		if info.Mode().Perm() != 0o600 {
+			t.Errorf("Expected mode 0600, got %v", info.Mode().Perm())
+		}
+	}
+
+	entries, _ := os.ReadDir(dir)
+	if len(entries) != 1 {
+		t.Errorf("Expected temp files to be cleaned up, found %d entries", len(entries))
+	}
+}
+
+func TestWriteAtomic_FailureKeepsOriginal(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "data.json")
+	os.WriteFile(path, []byte("original"), 0o644)
+
+	err := WriteAtomic(path, &failingReader{}, 0o644)
+	if err == nil {
+		t.Fatal("Expected error from failing reader")
+	}
+
+	data, _ := os.ReadFile(path)
+	if string(data) != "original" {
+		t.Errorf("Expected original contents to survive, got %q", data)
+	}
+}
+
+type failingReader struct{}
+
+func (failingReader) Read([]byte) (int, error) {
+	return 0, errors.New("disk on fire")
+}
+
+func TestSafeJoin(t *testing.T) {
+	base := filepath.Join(string(filepath.Separator), "srv", "uploads")
+
+	tests := []struct {
+		name    string
+		elems   []string
+		want    string
+		wantErr bool
+	}{
+		{"simple", []string{"invoices", "1001.pdf"}, filepath.Join(base, "invoices", "1001.pdf"), false},
+		{"inner dot dot", []string{"invoices", "..", "1001.pdf"}, filepath.Join(base, "1001.pdf"), false},
+		{"base itself", []string{"."}, base, false},
+		{"traversal", []string{"..", "etc", "passwd"}, "", true},
+		{"nested traversal", []string{"invoices", "../../etc/passwd"}, "", true},
+		{"sibling with shared prefix", []string{"../uploads-other/file"}, "", true},
+		{"absolute element", []string{"/etc/passwd"}, "", true},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			got, err := SafeJoin(base, tc.elems...)
+			if tc.wantErr {
+				if !errors.Is(err, ErrPathTraversal) {
+					t.Errorf("Expected ErrPathTraversal, got %q (%v)", got, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("SafeJoin() error = %v", err)
+			}
+			if got != tc.want {
+				t.Errorf("Expected %q, got %q", tc.want, got)
+			}
+		})
+	}
+}
+
+func TestCopyAndMove(t *testing.T) {
+	dir := t.TempDir()
+	src := filepath.Join(dir, "src.txt")
+	os.WriteFile(src, []byte("hello"), 0o640)
+
+	want, err := Checksum(src)
+	if err != nil {
+		t.Fatalf("Checksum() error = %v", err)
+	}
+	if want != "2cf24dba5fb0a30e26e83b2ac5b9e29e1b161e5c1fa7425e73043362938b9824" {
+		t.Errorf("Unexpected checksum %s", want)
+	}
+
+	copied := filepath.Join(dir, "copy.txt")
+	sum, err := Copy(src, copied)
+	if err != nil || sum != want {
+		t.Fatalf("Copy() = %s, %v", sum, err)
+	}
+	if data, _ := os.ReadFile(copied); string(data) != "hello" {
+		t.Errorf("Expected copied contents, got %q", data)
+	}
+
+	moved := filepath.Join(dir, "moved.txt")
+	sum, err = Move(copied, moved)
+	if err != nil || sum != want {
+		t.Fatalf("Move() = %s, %v", sum, err)
+	}
+	if ok, _ := Exists(copied); ok {
+		t.Error("Expected source to be removed after Move")
+	}
+	if !IsFile(moved) {
+		t.Error("Expected destination to exist after Move")
+	}
+
+	if _, err := Copy(dir, filepath.Join(dir, "dir-copy")); err == nil {
+		t.Error("Expected error copying a directory")
+	}
+}
+
+func TestExistsAndSize(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "file")
+	os.WriteFile(path, []byte(strings.Repeat("x", 42)), 0o644)
+
+	if ok, err := Exists(path); !ok || err != nil {
+		t.Errorf("Expected file to exist, got %v (%v)", ok, err)
+	}
+	if ok, err := Exists(filepath.Join(dir, "missing")); ok || err != nil {
+		t.Errorf("Expected missing file not to exist, got %v (%v)", ok, err)
+	}
+	if !IsDir(dir) || IsDir(path) || IsFile(dir) {
+		t.Error("Unexpected IsDir/IsFile result")
+	}
+	if size, err := Size(path); size != 42 || err != nil {
+		t.Errorf("Expected size 42, got %d (%v)", size, err)
+	}
+}
+
+func TestWithTempDir(t *testing.T) {
+	var captured string
+	err := WithTempDir("fileutils-test-*", func(dir string) error {
+		captured = dir
+		return os.WriteFile(filepath.Join(dir, "scratch"), []byte("x"), 0o644)
+	})
+	if err != nil {
+		t.Fatalf("WithTempDir() error = %v", err)
+	}
+	if IsDir(captured) {
+		t.Error("Expected temp dir to be removed")
+	}
+
+	wantErr := errors.New("job failed")
+	if err := WithTempDir("fileutils-test-*", func(string) error { return wantErr }); !errors.Is(err, wantErr) {
+		t.Errorf("Expected fn error to be returned, got %v", err)
+	}
+}
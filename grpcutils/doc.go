@@ -0,0 +1,55 @@
+/*
+Package grpcutils provides gRPC server interceptors that give gRPC services the
+same behaviour as the router's HTTP middleware.
+
+# Features
+
+  - Request IDs read from or returned in x-request-id metadata, stored with ctxutils
+  - Structured call logging through the logger package, with the call logger in the context
+  - Panic recovery with stack traces and an optional PanicHandler
+  - Per-call timeouts
+  - api.Error values mapped to gRPC status codes, with their Code as an ErrorInfo reason
+
+# Creating a Server
+
+NewServer installs the interceptors enabled in Options. DefaultOptions matches
+the router's defaults:
+
+	server := grpcutils.NewServer(grpcutils.DefaultOptions())
+	orderspb.RegisterOrdersServer(server, &ordersService{})
+
+	lis, err := net.Listen("tcp", ":9090")
+	if err != nil {
+		log.Fatal(err)
+	}
+	server.Serve(lis)
+
+To add interceptors or options of your own, use ServerOptions:
+
+	opts := grpcutils.ServerOptions(grpcutils.DefaultOptions())
+	opts = append(opts, grpc.ChainUnaryInterceptor(authInterceptor))
+	server := grpc.NewServer(opts...)
+
+# Errors
+
+Handlers can return the same api errors used by HTTP handlers. They are
+converted with ToStatus using CodeFromHTTPStatus:
+
+	func (s *ordersService) GetOrder(ctx context.Context, req *orderspb.GetOrderRequest) (*orderspb.Order, error) {
+		order, err := s.store.Find(ctx, req.Id)
+		if errors.Is(err, store.ErrNotFound) {
+			return nil, api.NotFoundError(err) // codes.NotFound
+		}
+		...
+	}
+
+Errors that already carry a gRPC status are passed through, and other errors
+become codes.Internal with a generic message.
+
+# Logging
+
+Handlers log through the context so entries carry the method and request ID:
+
+	logger.WithContext(ctx).Info("Order loaded", zap.String("order_id", req.Id))
+*/
+package grpcutils
@@ -0,0 +1,29 @@
+package grpcutils_test
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/StairSupplies/go-core/api"
+	"github.com/StairSupplies/go-core/grpcutils"
+)
+
+func ExampleToStatus() {
+	err := api.NewCodedError(http.StatusNotFound, "ORDER_NOT_FOUND", errors.New("order not found"), nil)
+
+	s := grpcutils.ToStatus(err)
+	fmt.Println(s.Code(), s.Message())
+	// Output: NotFound order not found
+}
+
+func ExampleNewServer() {
+	opts := grpcutils.DefaultOptions()
+	opts.EnableLogging = false
+
+	server := grpcutils.NewServer(opts)
+	defer server.Stop()
+
+	fmt.Println(server.GetServiceInfo())
+	// Output: map[]
+}
@@ -0,0 +1,91 @@
+package grpcutils
+
+import (
+	"context"
+	"time"
+
+	"github.com/StairSupplies/go-core/logger"
+	"google.golang.org/grpc"
+)
+
+// Options configures the interceptors installed by ServerOptions
+type Options struct {
+	// EnableLogging enables the logging interceptors
+	EnableLogging bool
+	// EnableRecovery enables panic recovery
+	EnableRecovery bool
+	// EnableRequestID enables request IDs
+	EnableRequestID bool
+	// Logger is used by the logging interceptors; nil uses the global logger
+	Logger *logger.Logger
+	// Timeout, if positive, limits unary calls. Streams are not limited.
+	Timeout time.Duration
+	// PanicHandler, if set, is called by the recovery interceptors with each
+	// recovered panic
+	PanicHandler PanicHandler
+}
+
+// DefaultOptions returns the default options, matching the router's defaults
+func DefaultOptions() Options {
+	return Options{
+		EnableLogging:   true,
+		EnableRecovery:  true,
+		EnableRequestID: true,
+		Timeout:         60 * time.Second,
+	}
+}
+
+// ServerOptions returns grpc.ServerOptions installing the interceptors
+// enabled in opts. Errors are always converted with ToStatus.
+//
+// Interceptors run in this order: request ID, logging, error conversion,
+// recovery, then timeout, so logs carry the request ID and the final status
+// code, including for calls that panicked.
+func ServerOptions(opts Options) []grpc.ServerOption {
+	var unary []grpc.UnaryServerInterceptor
+	var stream []grpc.StreamServerInterceptor
+
+	if opts.EnableRequestID {
+		unary = append(unary, UnaryRequestID())
+		stream = append(stream, StreamRequestID())
+	}
+	if opts.EnableLogging {
+		unary = append(unary, UnaryLogger(opts.Logger))
+		stream = append(stream, StreamLogger(opts.Logger))
+	}
+	unary = append(unary, UnaryErrors())
+	stream = append(stream, StreamErrors())
+	if opts.EnableRecovery {
+		unary = append(unary, UnaryRecoverer(opts.PanicHandler))
+		stream = append(stream, StreamRecoverer(opts.PanicHandler))
+	}
+	if opts.Timeout > 0 {
+		unary = append(unary, UnaryTimeout(opts.Timeout))
+	}
+
+	return []grpc.ServerOption{
+		grpc.ChainUnaryInterceptor(unary...),
+		grpc.ChainStreamInterceptor(stream...),
+	}
+}
+
+// NewServer creates a grpc.Server with the interceptors from opts and any
+// further server options
+func NewServer(opts Options, extra ...grpc.ServerOption) *grpc.Server {
+	return grpc.NewServer(append(ServerOptions(opts), extra...)...)
+}
+
+// serverStream overrides the context of a grpc.ServerStream
+type serverStream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+func (s *serverStream) Context() context.Context {
+	return s.ctx
+}
+
+// withContext returns ss with its context replaced by ctx
+func withContext(ss grpc.ServerStream, ctx context.Context) grpc.ServerStream {
+	return &serverStream{ServerStream: ss, ctx: ctx}
+}
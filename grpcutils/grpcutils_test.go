@@ -0,0 +1,207 @@
+package grpcutils
+
+import (
+	"context"
+	"errors"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/StairSupplies/go-core/api"
+	"github.com/StairSupplies/go-core/ctxutils"
+	"github.com/StairSupplies/go-core/logger"
+	"github.com/StairSupplies/go-core/logger/logtest"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+)
+
+// testService implements the health service with handlers set by each test
+type testService struct {
+	healthpb.UnimplementedHealthServer
+	check func(ctx context.Context) error
+	watch func(ctx context.Context) error
+}
+
+func (s *testService) Check(ctx context.Context, req *healthpb.HealthCheckRequest) (*healthpb.HealthCheckResponse, error) {
+	if err := s.check(ctx); err != nil {
+		return nil, err
+	}
+	return &healthpb.HealthCheckResponse{Status: healthpb.HealthCheckResponse_SERVING}, nil
+}
+
+func (s *testService) Watch(req *healthpb.HealthCheckRequest, stream healthpb.Health_WatchServer) error {
+	return s.watch(stream.Context())
+}
+
+// newTestClient serves svc with opts over an in-memory listener
+func newTestClient(t *testing.T, opts Options, svc *testService) healthpb.HealthClient {
+	t.Helper()
+	lis := bufconn.Listen(1 << 20)
+	server := NewServer(opts)
+	healthpb.RegisterHealthServer(server, svc)
+	go server.Serve(lis)
+	t.Cleanup(server.Stop)
+
+	conn, err := grpc.NewClient("passthrough:///bufnet",
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) {
+			return lis.DialContext(ctx)
+		}),
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+	)
+	if err != nil {
+		t.Fatalf("NewClient() error = %v", err)
+	}
+	t.Cleanup(func() { conn.Close() })
+	return healthpb.NewHealthClient(conn)
+}
+
+func TestServer_RequestID(t *testing.T) {
+	var got string
+	client := newTestClient(t, DefaultOptions(), &testService{check: func(ctx context.Context) error {
+		got = ctxutils.RequestID(ctx)
+		return nil
+	}})
+
+	t.Run("from metadata", func(t *testing.T) {
+		ctx := metadata.AppendToOutgoingContext(context.Background(), RequestIDKey, "req-123")
+		var header metadata.MD
+		if _, err := client.Check(ctx, &healthpb.HealthCheckRequest{}, grpc.Header(&header)); err != nil {
+			t.Fatalf("Check() error = %v", err)
+		}
+		if got != "req-123" {
+			t.Errorf("Expected request ID req-123, got %q", got)
+		}
+		if ids := header.Get(RequestIDKey); len(ids) != 1 || ids[0] != "req-123" {
+			t.Errorf("Expected request ID in response header, got %v", ids)
+		}
+	})
+
+	t.Run("generated", func(t *testing.T) {
+		var header metadata.MD
+		if _, err := client.Check(context.Background(), &healthpb.HealthCheckRequest{}, grpc.Header(&header)); err != nil {
+			t.Fatalf("Check() error = %v", err)
+		}
+		if len(got) != 32 {
+			t.Errorf("Expected a generated request ID, got %q", got)
+		}
+		if ids := header.Get(RequestIDKey); len(ids) != 1 || ids[0] != got {
+			t.Errorf("Expected generated request ID %q in response header, got %v", got, ids)
+		}
+	})
+}
+
+func TestServer_Errors(t *testing.T) {
+	client := newTestClient(t, DefaultOptions(), &testService{
+		check: func(ctx context.Context) error {
+			return api.NotFoundError(errors.New("service not found"))
+		},
+		watch: func(ctx context.Context) error {
+			return api.ForbiddenError(errors.New("no access"))
+		},
+	})
+
+	_, err := client.Check(context.Background(), &healthpb.HealthCheckRequest{})
+	if s := status.Convert(err); s.Code() != codes.NotFound || s.Message() != "service not found" {
+		t.Errorf("Expected NotFound: service not found, got %v", err)
+	}
+
+	stream, err := client.Watch(context.Background(), &healthpb.HealthCheckRequest{})
+	if err != nil {
+		t.Fatalf("Watch() error = %v", err)
+	}
+	_, err = stream.Recv()
+	if code := status.Code(err); code != codes.PermissionDenied {
+		t.Errorf("Expected PermissionDenied, got %v", err)
+	}
+}
+
+func TestServer_Recovery(t *testing.T) {
+	log, rec := logtest.NewTestLogger(t)
+	var handled any
+	opts := DefaultOptions()
+	opts.Logger = log
+	opts.PanicHandler = func(ctx context.Context, method string, recovered any) {
+		handled = recovered
+	}
+	client := newTestClient(t, opts, &testService{
+		check: func(ctx context.Context) error { panic("boom") },
+		watch: func(ctx context.Context) error { panic("stream boom") },
+	})
+
+	_, err := client.Check(context.Background(), &healthpb.HealthCheckRequest{})
+	if s := status.Convert(err); s.Code() != codes.Internal || s.Message() != "internal server error" {
+		t.Errorf("Expected Internal: internal server error, got %v", err)
+	}
+	if handled != "boom" {
+		t.Errorf("Expected PanicHandler to receive boom, got %v", handled)
+	}
+
+	panics := rec.FilterMessage("gRPC handler panicked")
+	if len(panics) != 1 {
+		t.Fatalf("Expected one panic log entry, got %d", len(panics))
+	}
+	if panics[0].Fields["method"] != healthpb.Health_Check_FullMethodName || panics[0].Fields["request_id"] == "" {
+		t.Errorf("Expected method and request_id fields, got %v", panics[0].Fields)
+	}
+
+	completed := rec.FilterMessage("gRPC call completed")
+	if len(completed) != 1 || completed[0].Fields["code"] != "Internal" {
+		t.Errorf("Expected the completed call to be logged as Internal, got %v", completed)
+	}
+
+	stream, err := client.Watch(context.Background(), &healthpb.HealthCheckRequest{})
+	if err != nil {
+		t.Fatalf("Watch() error = %v", err)
+	}
+	if _, err := stream.Recv(); status.Code(err) != codes.Internal {
+		t.Errorf("Expected Internal from panicking stream, got %v", err)
+	}
+	if handled != "stream boom" {
+		t.Errorf("Expected PanicHandler to receive stream boom, got %v", handled)
+	}
+}
+
+func TestServer_Logging(t *testing.T) {
+	log, rec := logtest.NewTestLogger(t)
+	opts := DefaultOptions()
+	opts.Logger = log
+	client := newTestClient(t, opts, &testService{check: func(ctx context.Context) error {
+		logger.WithContext(ctx).Info("checking")
+		return api.BadRequestError(errors.New("bad service name"))
+	}})
+
+	ctx := metadata.AppendToOutgoingContext(context.Background(), RequestIDKey, "req-456")
+	client.Check(ctx, &healthpb.HealthCheckRequest{})
+
+	for _, msg := range []string{"gRPC call started", "checking", "gRPC call completed"} {
+		entries := rec.FilterMessage(msg)
+		if len(entries) != 1 {
+			t.Fatalf("Expected one %q entry, got %d", msg, len(entries))
+		}
+		if entries[0].Fields["request_id"] != "req-456" || entries[0].Fields["method"] != healthpb.Health_Check_FullMethodName {
+			t.Errorf("Expected request_id and method on %q, got %v", msg, entries[0].Fields)
+		}
+	}
+	if code := rec.FilterMessage("gRPC call completed")[0].Fields["code"]; code != "InvalidArgument" {
+		t.Errorf("Expected code InvalidArgument, got %v", code)
+	}
+}
+
+func TestServer_Timeout(t *testing.T) {
+	opts := DefaultOptions()
+	opts.Timeout = 20 * time.Millisecond
+	client := newTestClient(t, opts, &testService{check: func(ctx context.Context) error {
+		<-ctx.Done()
+		return ctx.Err()
+	}})
+
+	_, err := client.Check(context.Background(), &healthpb.HealthCheckRequest{})
+	if code := status.Code(err); code != codes.DeadlineExceeded {
+		t.Errorf("Expected DeadlineExceeded, got %v", err)
+	}
+}
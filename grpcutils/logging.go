@@ -0,0 +1,76 @@
+package grpcutils
+
+import (
+	"context"
+	"time"
+
+	"github.com/StairSupplies/go-core/ctxutils"
+	"github.com/StairSupplies/go-core/logger"
+	"go.uber.org/zap"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+)
+
+// startCall stores a call logger in ctx and logs the start of the call. A nil
+// log uses the logger already in ctx, or the global logger.
+func startCall(ctx context.Context, log *logger.Logger, method string, stream bool) (context.Context, *logger.Logger) {
+	if log == nil {
+		log = logger.WithContext(ctx)
+	}
+	fields := []zap.Field{
+		zap.String("method", method),
+		zap.String("request_id", ctxutils.RequestID(ctx)),
+		zap.Bool("stream", stream),
+	}
+	if p, ok := peer.FromContext(ctx); ok && p.Addr != nil {
+		fields = append(fields, zap.String("remote_addr", p.Addr.String()))
+	}
+	callLog := log.With(fields...)
+
+	ctx = logger.NewContext(ctx, callLog)
+	callLog.WithTrace(ctx).Info("gRPC call started")
+	return ctx, callLog
+}
+
+// finishCall logs the outcome of a call. Server-side failures are logged at
+// error level and client errors at info, as the router does for 5xx and 4xx.
+func finishCall(ctx context.Context, log *logger.Logger, start time.Time, err error) {
+	code := status.Code(err)
+	log = log.WithTrace(ctx).With(
+		zap.String("code", code.String()),
+		zap.Duration("duration", time.Since(start)),
+	)
+	switch code {
+	case codes.Internal, codes.Unknown, codes.DataLoss, codes.Unavailable, codes.Unimplemented:
+		log.Error("gRPC call completed", zap.Error(err))
+	default:
+		log.Info("gRPC call completed")
+	}
+}
+
+// UnaryLogger logs the start and end of each call with its method, request
+// ID, status code and duration, and stores the call logger in the context so
+// handlers can use logger.WithContext. A nil log uses the logger already in
+// the context, or the global logger.
+func UnaryLogger(log *logger.Logger) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+		start := time.Now()
+		ctx, callLog := startCall(ctx, log, info.FullMethod, false)
+		resp, err := handler(ctx, req)
+		finishCall(ctx, callLog, start, err)
+		return resp, err
+	}
+}
+
+// StreamLogger is UnaryLogger for streaming calls
+func StreamLogger(log *logger.Logger) grpc.StreamServerInterceptor {
+	return func(srv any, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		start := time.Now()
+		ctx, callLog := startCall(ss.Context(), log, info.FullMethod, true)
+		err := handler(srv, withContext(ss, ctx))
+		finishCall(ctx, callLog, start, err)
+		return err
+	}
+}
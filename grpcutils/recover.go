@@ -0,0 +1,60 @@
+package grpcutils
+
+import (
+	"context"
+	"fmt"
+	"runtime/debug"
+
+	"github.com/StairSupplies/go-core/ctxutils"
+	"github.com/StairSupplies/go-core/logger"
+	"go.uber.org/zap"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// PanicHandler is called with the value recovered from a panicking handler,
+// after the panic has been logged, for example to report it to an error
+// tracker
+type PanicHandler func(ctx context.Context, method string, recovered any)
+
+// recoverPanic logs a recovered panic, calls handler and returns the
+// Internal status sent to the client
+func recoverPanic(ctx context.Context, method string, rvr any, handler PanicHandler) error {
+	logger.WithContext(ctx).Error("gRPC handler panicked",
+		zap.String("panic", fmt.Sprint(rvr)),
+		zap.String("stack", string(debug.Stack())),
+		zap.String("method", method),
+		zap.String("request_id", ctxutils.RequestID(ctx)),
+	)
+	if handler != nil {
+		handler(ctx, method, rvr)
+	}
+	return status.Error(codes.Internal, "internal server error")
+}
+
+// UnaryRecoverer recovers from panics in handlers. The panic value and stack
+// trace are logged, handler is called if not nil, and the call fails with
+// codes.Internal.
+func UnaryRecoverer(handler PanicHandler) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req any, info *grpc.UnaryServerInfo, h grpc.UnaryHandler) (resp any, err error) {
+		defer func() {
+			if rvr := recover(); rvr != nil {
+				resp, err = nil, recoverPanic(ctx, info.FullMethod, rvr, handler)
+			}
+		}()
+		return h(ctx, req)
+	}
+}
+
+// StreamRecoverer is UnaryRecoverer for streaming calls
+func StreamRecoverer(handler PanicHandler) grpc.StreamServerInterceptor {
+	return func(srv any, ss grpc.ServerStream, info *grpc.StreamServerInfo, h grpc.StreamHandler) (err error) {
+		defer func() {
+			if rvr := recover(); rvr != nil {
+				err = recoverPanic(ss.Context(), info.FullMethod, rvr, handler)
+			}
+		}()
+		return h(srv, ss)
+	}
+}
@@ -0,0 +1,55 @@
+package grpcutils
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+
+	"github.com/StairSupplies/go-core/ctxutils"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+)
+
+// RequestIDKey is the metadata key carrying the request ID. gRPC metadata
+// keys are lowercase, so it matches the router's X-Request-Id header when
+// calls pass through an HTTP gateway.
+const RequestIDKey = "x-request-id"
+
+// withRequestID stores the caller's request ID, or a new one, in ctx and
+// returns it in the response header metadata
+func withRequestID(ctx context.Context) context.Context {
+	var id string
+	if md, ok := metadata.FromIncomingContext(ctx); ok {
+		if values := md.Get(RequestIDKey); len(values) > 0 {
+			id = values[0]
+		}
+	}
+	if id == "" {
+		id = newRequestID()
+	}
+	// SetHeader only fails once headers are sent, which cannot have happened yet
+	_ = grpc.SetHeader(ctx, metadata.Pairs(RequestIDKey, id))
+	return ctxutils.WithRequestID(ctx, id)
+}
+
+// newRequestID returns a random request identifier
+func newRequestID() string {
+	b := make([]byte, 16)
+	rand.Read(b)
+	return hex.EncodeToString(b)
+}
+
+// UnaryRequestID reads the request ID from the incoming metadata, or
+// generates one, and stores it with ctxutils.WithRequestID
+func UnaryRequestID() grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+		return handler(withRequestID(ctx), req)
+	}
+}
+
+// StreamRequestID is UnaryRequestID for streaming calls
+func StreamRequestID() grpc.StreamServerInterceptor {
+	return func(srv any, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		return handler(srv, withContext(ss, withRequestID(ss.Context())))
+	}
+}
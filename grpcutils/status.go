@@ -0,0 +1,107 @@
+package grpcutils
+
+import (
+	"context"
+	"errors"
+	"net/http"
+
+	"github.com/StairSupplies/go-core/api"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// ErrorDomain is the ErrorInfo domain attached to statuses built from coded
+// api errors
+const ErrorDomain = "go-core"
+
+// CodeFromHTTPStatus returns the gRPC code matching an HTTP status code, so an
+// api.Error means the same thing over both transports
+func CodeFromHTTPStatus(statusCode int) codes.Code {
+	switch statusCode {
+	case http.StatusOK:
+		return codes.OK
+	case http.StatusBadRequest, http.StatusUnprocessableEntity:
+		return codes.InvalidArgument
+	case http.StatusUnauthorized:
+		return codes.Unauthenticated
+	case http.StatusForbidden:
+		return codes.PermissionDenied
+	case http.StatusNotFound:
+		return codes.NotFound
+	case http.StatusConflict:
+		return codes.Aborted
+	case http.StatusPreconditionFailed:
+		return codes.FailedPrecondition
+	case http.StatusTooManyRequests:
+		return codes.ResourceExhausted
+	case 499: // client closed request
+		return codes.Canceled
+	case http.StatusNotImplemented:
+		return codes.Unimplemented
+	case http.StatusServiceUnavailable:
+		return codes.Unavailable
+	case http.StatusGatewayTimeout:
+		return codes.DeadlineExceeded
+	}
+	if statusCode >= 400 && statusCode < 500 {
+		return codes.FailedPrecondition
+	}
+	return codes.Internal
+}
+
+// ToStatus converts err to a gRPC status. Errors that already carry a status
+// are returned as they are, api.Error values are mapped by status code with
+// their Code attached as an ErrorInfo reason, and context errors become
+// DeadlineExceeded or Canceled. Anything else is Internal, with a generic
+// message so internal details are not sent to clients.
+func ToStatus(err error) *status.Status {
+	if err == nil {
+		return nil
+	}
+	if s, ok := status.FromError(err); ok {
+		return s
+	}
+
+	var apiErr api.Error
+	if errors.As(err, &apiErr) {
+		s := status.New(CodeFromHTTPStatus(apiErr.StatusCode), apiErr.Message)
+		if apiErr.Code == "" {
+			return s
+		}
+		if detailed, err := s.WithDetails(&errdetails.ErrorInfo{Reason: apiErr.Code, Domain: ErrorDomain}); err == nil {
+			return detailed
+		}
+		return s
+	}
+
+	switch {
+	case errors.Is(err, context.DeadlineExceeded):
+		return status.New(codes.DeadlineExceeded, err.Error())
+	case errors.Is(err, context.Canceled):
+		return status.New(codes.Canceled, err.Error())
+	}
+	return status.New(codes.Internal, "internal server error")
+}
+
+// UnaryErrors converts errors returned by handlers with ToStatus
+func UnaryErrors() grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+		resp, err := handler(ctx, req)
+		if err != nil {
+			return resp, ToStatus(err).Err()
+		}
+		return resp, nil
+	}
+}
+
+// StreamErrors converts errors returned by stream handlers with ToStatus
+func StreamErrors() grpc.StreamServerInterceptor {
+	return func(srv any, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		if err := handler(srv, ss); err != nil {
+			return ToStatus(err).Err()
+		}
+		return nil
+	}
+}
@@ -0,0 +1,70 @@
+package grpcutils
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/StairSupplies/go-core/api"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestToStatus(t *testing.T) {
+	tests := []struct {
+		name    string
+		err     error
+		code    codes.Code
+		message string
+	}{
+		{"bad request", api.BadRequestError(errors.New("invalid order ID")), codes.InvalidArgument, "invalid order ID"},
+		{"unauthorized", api.UnauthorizedError(errors.New("token expired")), codes.Unauthenticated, "token expired"},
+		{"forbidden", api.ForbiddenError(errors.New("no access")), codes.PermissionDenied, "no access"},
+		{"not found", api.NotFoundError(errors.New("order not found")), codes.NotFound, "order not found"},
+		{"unprocessable", api.UnprocessableEntityError(errors.New("bad state")), codes.InvalidArgument, "bad state"},
+		{"conflict", api.NewError(http.StatusConflict, errors.New("version mismatch")), codes.Aborted, "version mismatch"},
+		{"rate limited", api.NewError(http.StatusTooManyRequests, errors.New("slow down")), codes.ResourceExhausted, "slow down"},
+		{"other client error", api.NewError(http.StatusGone, errors.New("gone")), codes.FailedPrecondition, "gone"},
+		{"server error", api.ServerError(errors.New("db down")), codes.Internal, "db down"},
+		{"wrapped api error", fmt.Errorf("loading order: %w", api.NotFoundError(errors.New("order not found"))), codes.NotFound, "order not found"},
+		{"status error", status.Error(codes.Unavailable, "try later"), codes.Unavailable, "try later"},
+		{"deadline", fmt.Errorf("query: %w", context.DeadlineExceeded), codes.DeadlineExceeded, "query: context deadline exceeded"},
+		{"canceled", context.Canceled, codes.Canceled, "context canceled"},
+		{"plain error", errors.New("secret connection string"), codes.Internal, "internal server error"},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			s := ToStatus(tc.err)
+			if s.Code() != tc.code {
+				t.Errorf("Expected code %s, got %s", tc.code, s.Code())
+			}
+			if s.Message() != tc.message {
+				t.Errorf("Expected message %q, got %q", tc.message, s.Message())
+			}
+		})
+	}
+
+	if s := ToStatus(nil); s != nil {
+		t.Errorf("Expected nil status for nil error, got %v", s)
+	}
+}
+
+func TestToStatus_ErrorCode(t *testing.T) {
+	err := api.NewCodedError(http.StatusConflict, "ORDER_LOCKED", errors.New("order is locked"), nil)
+
+	s := ToStatus(err)
+	if len(s.Details()) != 1 {
+		t.Fatalf("Expected one detail, got %d", len(s.Details()))
+	}
+	info, ok := s.Details()[0].(*errdetails.ErrorInfo)
+	if !ok {
+		t.Fatalf("Expected ErrorInfo, got %T", s.Details()[0])
+	}
+	if info.Reason != "ORDER_LOCKED" || info.Domain != ErrorDomain {
+		t.Errorf("Expected reason ORDER_LOCKED in domain %s, got %s in %s", ErrorDomain, info.Reason, info.Domain)
+	}
+}
@@ -0,0 +1,68 @@
+/*
+Package health provides liveness and readiness checks for HTTP services.
+
+Services register named checks for the dependencies they need, such as a
+database ping, a downstream API or a queue depth, and expose the results to
+load balancers and orchestrators as JSON.
+
+# Features
+
+  - A concurrency-safe Checker registry of named checks
+  - Checks run concurrently, each with its own timeout
+  - A check that ignores its context or panics is reported down instead of
+    hanging or crashing the probe
+  - Readiness and liveness handlers with per-check status and latency
+  - Integration with the router package via Options.HealthChecker
+
+# Registering Checks
+
+	checker := health.NewChecker()
+
+	checker.Register("database", db.PingContext, health.WithTimeout(2*time.Second))
+	checker.Register("inventory-api", func(ctx context.Context) error {
+		return inventory.Ping(ctx)
+	})
+	checker.Register("queue", func(ctx context.Context) error {
+		depth, err := queue.Depth(ctx)
+		if err != nil {
+			return err
+		}
+		if depth > 10000 {
+			return fmt.Errorf("depth %d exceeds 10000", depth)
+		}
+		return nil
+	})
+
+Checks without WithTimeout are bounded by DefaultTimeout.
+
+# Readiness and Liveness
+
+Readiness runs every check; an instance that is not ready should be taken
+out of the load balancer until its dependencies recover. Liveness only runs
+checks registered with ForLiveness, since restarting an instance does not
+fix a database outage. With no such checks a live process is always up.
+
+Both handlers respond 200 when every check is up and 503 otherwise:
+
+	{
+	  "status": "down",
+	  "checks": {
+	    "database": {"status": "up", "latency_ms": 1.84},
+	    "inventory-api": {"status": "down", "latency_ms": 2000.31, "error": "health: check timed out"}
+	  }
+	}
+
+# Router Integration
+
+Set Options.HealthChecker to serve the liveness report at /healthz and the
+readiness report at /readyz:
+
+	opts := router.DefaultOptions()
+	opts.HealthChecker = checker
+	r := router.NewWithOptions(opts)
+
+The handlers can also be mounted anywhere:
+
+	mux.Handle("/internal/ready", checker.ReadinessHandler())
+*/
+package health
@@ -0,0 +1,56 @@
+package health_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"time"
+
+	"github.com/StairSupplies/go-core/health"
+)
+
+func ExampleChecker() {
+	checker := health.NewChecker()
+	checker.Register("database", func(ctx context.Context) error {
+		return nil // e.g. db.PingContext(ctx)
+	}, health.WithTimeout(time.Second))
+	checker.Register("inventory-api", func(ctx context.Context) error {
+		return errors.New("connection refused")
+	})
+
+	report := checker.Readiness(context.Background())
+	fmt.Println(report.Status)
+	for _, name := range checker.Names() {
+		fmt.Println(name, report.Checks[name].Status)
+	}
+	fmt.Println(report.Checks["inventory-api"].Error)
+
+	// Output:
+	// down
+	// database up
+	// inventory-api down
+	// connection refused
+}
+
+func ExampleChecker_ReadinessHandler() {
+	checker := health.NewChecker()
+	checker.Register("queue", func(ctx context.Context) error {
+		return errors.New("depth 12000 exceeds 10000")
+	})
+
+	w := httptest.NewRecorder()
+	checker.ReadinessHandler().ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/readyz", nil))
+	fmt.Println(w.Code)
+
+	w = httptest.NewRecorder()
+	checker.LivenessHandler().ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/healthz", nil))
+	fmt.Println(w.Code, w.Body.String())
+
+	// Output:
+	// 503
+	// 200 {
+	//   "status": "up"
+	// }
+}
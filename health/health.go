@@ -0,0 +1,222 @@
+package health
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/StairSupplies/go-core/api"
+)
+
+// DefaultTimeout bounds each check that has no timeout of its own
+const DefaultTimeout = 5 * time.Second
+
+// Status is the outcome of a check or of a whole report
+type Status string
+
+// Check and report statuses
+const (
+	StatusUp   Status = "up"
+	StatusDown Status = "down"
+)
+
+// ErrTimeout is reported for a check that did not finish within its timeout
+var ErrTimeout = errors.New("health: check timed out")
+
+// CheckFunc reports whether a dependency is healthy by returning nil
+type CheckFunc func(ctx context.Context) error
+
+// CheckResult is the outcome of one check
+type CheckResult struct {
+	Status    Status  `json:"status"`
+	LatencyMS float64 `json:"latency_ms"`
+	Error     string  `json:"error,omitempty"`
+}
+
+// Report is the outcome of a set of checks. Status is StatusDown if any
+// check is down.
+type Report struct {
+	Status Status                 `json:"status"`
+	Checks map[string]CheckResult `json:"checks,omitempty"`
+}
+
+// check is a registered check
+type check struct {
+	name     string
+	fn       CheckFunc
+	timeout  time.Duration
+	liveness bool
+}
+
+// CheckOption configures a registered check
+type CheckOption func(*check)
+
+// WithTimeout sets how long the check may run before it is reported down
+func WithTimeout(d time.Duration) CheckOption {
+	return func(c *check) {
+		c.timeout = d
+	}
+}
+
+// ForLiveness also runs the check for liveness probes. Only use it for
+// problems a restart fixes, such as a deadlocked worker; a failing
+// dependency should not get every instance restarted.
+func ForLiveness() CheckOption {
+	return func(c *check) {
+		c.liveness = true
+	}
+}
+
+// Checker is a registry of named health checks. It is safe for concurrent use.
+type Checker struct {
+	mu     sync.RWMutex
+	checks map[string]check
+}
+
+// NewChecker creates an empty Checker
+func NewChecker() *Checker {
+	return &Checker{checks: make(map[string]check)}
+}
+
+// Register adds a check under name, replacing any check with the same name
+func (c *Checker) Register(name string, fn CheckFunc, opts ...CheckOption) {
+	chk := check{name: name, fn: fn, timeout: DefaultTimeout}
+	for _, opt := range opts {
+		opt(&chk)
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.checks[name] = chk
+}
+
+// Unregister removes the check registered under name
+func (c *Checker) Unregister(name string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	delete(c.checks, name)
+}
+
+// Names returns the names of the registered checks in sorted order
+func (c *Checker) Names() []string {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	names := make([]string, 0, len(c.checks))
+	for name := range c.checks {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// Readiness runs every registered check concurrently and reports the results
+func (c *Checker) Readiness(ctx context.Context) Report {
+	return c.run(ctx, false)
+}
+
+// Liveness runs the checks registered with ForLiveness. With none it
+// reports the process as up.
+func (c *Checker) Liveness(ctx context.Context) Report {
+	return c.run(ctx, true)
+}
+
+// ReadinessHandler serves Readiness as JSON, with status 503 when any check is down
+func (c *Checker) ReadinessHandler() http.Handler {
+	return reportHandler(c.Readiness)
+}
+
+// LivenessHandler serves Liveness as JSON, with status 503 when any check is down
+func (c *Checker) LivenessHandler() http.Handler {
+	return reportHandler(c.Liveness)
+}
+
+// run runs the selected checks and collects their results
+func (c *Checker) run(ctx context.Context, livenessOnly bool) Report {
+	c.mu.RLock()
+	checks := make([]check, 0, len(c.checks))
+	for _, chk := range c.checks {
+		if !livenessOnly || chk.liveness {
+			checks = append(checks, chk)
+		}
+	}
+	c.mu.RUnlock()
+
+	report := Report{Status: StatusUp}
+	if len(checks) == 0 {
+		return report
+	}
+
+	results := make([]CheckResult, len(checks))
+	var wg sync.WaitGroup
+	for i, chk := range checks {
+		wg.Add(1)
+		go func(i int, chk check) {
+			defer wg.Done()
+			results[i] = runCheck(ctx, chk)
+		}(i, chk)
+	}
+	wg.Wait()
+
+	report.Checks = make(map[string]CheckResult, len(checks))
+	for i, chk := range checks {
+		report.Checks[chk.name] = results[i]
+		if results[i].Status == StatusDown {
+			report.Status = StatusDown
+		}
+	}
+	return report
+}
+
+// runCheck runs one check under its timeout. A check that ignores its
+// context is abandoned when the timeout expires rather than waited for.
+func runCheck(ctx context.Context, chk check) CheckResult {
+	ctx, cancel := context.WithTimeout(ctx, chk.timeout)
+	defer cancel()
+
+	start := time.Now()
+	done := make(chan error, 1)
+	go func() {
+		defer func() {
+			if rec := recover(); rec != nil {
+				done <- fmt.Errorf("health: check panicked: %v", rec)
+			}
+		}()
+		done <- chk.fn(ctx)
+	}()
+
+	var err error
+	select {
+	case err = <-done:
+	case <-ctx.Done():
+		err = ErrTimeout
+	}
+
+	result := CheckResult{
+		Status:    StatusUp,
+		LatencyMS: float64(time.Since(start).Microseconds()) / 1000,
+	}
+	if err != nil {
+		result.Status = StatusDown
+		result.Error = err.Error()
+	}
+	return result
+}
+
+// reportHandler serves the report returned by fn
+func reportHandler(fn func(context.Context) Report) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		report := fn(r.Context())
+
+		status := http.StatusOK
+		if report.Status != StatusUp {
+			status = http.StatusServiceUnavailable
+		}
+		headers := http.Header{"Cache-Control": []string{"no-store"}}
+		api.WriteJSON(w, status, report, headers)
+	})
+}
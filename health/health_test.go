@@ -0,0 +1,146 @@
+package health
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestChecker_Readiness(t *testing.T) {
+	c := NewChecker()
+	c.Register("database", func(ctx context.Context) error { return nil })
+	c.Register("inventory", func(ctx context.Context) error { return errors.New("connection refused") })
+
+	report := c.Readiness(context.Background())
+
+	if report.Status != StatusDown {
+		t.Errorf("Expected status down, got %s", report.Status)
+	}
+	if got := report.Checks["database"]; got.Status != StatusUp || got.Error != "" {
+		t.Errorf("Expected database to be up, got %+v", got)
+	}
+	if got := report.Checks["inventory"]; got.Status != StatusDown || got.Error != "connection refused" {
+		t.Errorf("Expected inventory to be down with its error, got %+v", got)
+	}
+}
+
+func TestChecker_Timeout(t *testing.T) {
+	c := NewChecker()
+	block := make(chan struct{})
+	defer close(block)
+
+	// A check that ignores its context is abandoned
+	c.Register("stuck", func(ctx context.Context) error {
+		<-block
+		return nil
+	}, WithTimeout(20*time.Millisecond))
+
+	start := time.Now()
+	report := c.Readiness(context.Background())
+
+	if elapsed := time.Since(start); elapsed > time.Second {
+		t.Errorf("Expected the check to be abandoned after its timeout, took %v", elapsed)
+	}
+	if got := report.Checks["stuck"]; got.Status != StatusDown || got.Error != ErrTimeout.Error() {
+		t.Errorf("Expected stuck to time out, got %+v", got)
+	}
+}
+
+func TestChecker_Panic(t *testing.T) {
+	c := NewChecker()
+	c.Register("broken", func(ctx context.Context) error { panic("nil map") })
+
+	got := c.Readiness(context.Background()).Checks["broken"]
+	if got.Status != StatusDown || got.Error != "health: check panicked: nil map" {
+		t.Errorf("Expected broken to be down, got %+v", got)
+	}
+}
+
+func TestChecker_Liveness(t *testing.T) {
+	c := NewChecker()
+	c.Register("database", func(ctx context.Context) error { return errors.New("down") })
+
+	report := c.Liveness(context.Background())
+	if report.Status != StatusUp || len(report.Checks) != 0 {
+		t.Errorf("Expected liveness to skip dependency checks, got %+v", report)
+	}
+
+	c.Register("worker", func(ctx context.Context) error { return errors.New("deadlocked") }, ForLiveness())
+	report = c.Liveness(context.Background())
+	if report.Status != StatusDown || len(report.Checks) != 1 {
+		t.Errorf("Expected only the liveness check to run, got %+v", report)
+	}
+}
+
+func TestChecker_RegisterUnregister(t *testing.T) {
+	c := NewChecker()
+	c.Register("b", func(ctx context.Context) error { return nil })
+	c.Register("a", func(ctx context.Context) error { return nil })
+	c.Register("a", func(ctx context.Context) error { return errors.New("replaced") })
+
+	if names := c.Names(); len(names) != 2 || names[0] != "a" || names[1] != "b" {
+		t.Errorf("Expected [a b], got %v", names)
+	}
+	if got := c.Readiness(context.Background()).Checks["a"]; got.Error != "replaced" {
+		t.Errorf("Expected the replacement check to run, got %+v", got)
+	}
+
+	c.Unregister("a")
+	c.Unregister("b")
+	if report := c.Readiness(context.Background()); report.Status != StatusUp || report.Checks != nil {
+		t.Errorf("Expected an empty report, got %+v", report)
+	}
+}
+
+func TestHandlers(t *testing.T) {
+	c := NewChecker()
+	healthy := true
+	c.Register("database", func(ctx context.Context) error {
+		if !healthy {
+			return errors.New("ping failed")
+		}
+		return nil
+	})
+
+	tests := []struct {
+		name       string
+		healthy    bool
+		handler    http.Handler
+		wantStatus int
+		wantChecks int
+	}{
+		{"ready", true, c.ReadinessHandler(), http.StatusOK, 1},
+		{"not ready", false, c.ReadinessHandler(), http.StatusServiceUnavailable, 1},
+		{"live while dependency down", false, c.LivenessHandler(), http.StatusOK, 0},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			healthy = tc.healthy
+			w := httptest.NewRecorder()
+			tc.handler.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/readyz", nil))
+
+			if w.Code != tc.wantStatus {
+				t.Errorf("Expected status %d, got %d", tc.wantStatus, w.Code)
+			}
+			if got := w.Header().Get("Content-Type"); got != "application/json" {
+				t.Errorf("Expected JSON content type, got %q", got)
+			}
+			if got := w.Header().Get("Cache-Control"); got != "no-store" {
+				t.Errorf("Expected Cache-Control no-store, got %q", got)
+			}
+
+			var report Report
+			if err := json.Unmarshal(w.Body.Bytes(), &report); err != nil {
+				t.Fatalf("Failed to decode report: %v", err)
+			}
+			if len(report.Checks) != tc.wantChecks {
+				t.Errorf("Expected %d checks, got %+v", tc.wantChecks, report.Checks)
+			}
+		})
+	}
+}
@@ -0,0 +1,188 @@
+package i18n
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"path"
+	"strings"
+	"text/template"
+
+	"golang.org/x/text/language"
+)
+
+// ErrInvalidCatalog is returned when a message file cannot be loaded
+var ErrInvalidCatalog = errors.New("invalid message catalog")
+
+// pluralForms are the CLDR plural categories accepted in message files
+var pluralForms = []string{"zero", "one", "two", "few", "many", "other"}
+
+// message is a parsed catalog entry. Simple messages only have an "other" form.
+type message struct {
+	forms map[string]*template.Template
+}
+
+// Bundle holds message catalogs for a set of languages. A Bundle is safe for
+// concurrent use once loading is complete.
+type Bundle struct {
+	fallback language.Tag
+	tags     []language.Tag
+	catalogs map[language.Tag]map[string]*message
+	matcher  language.Matcher
+}
+
+// NewBundle creates an empty bundle. fallback is used when no requested language
+// is supported and for messages missing from a language's catalog.
+func NewBundle(fallback language.Tag) *Bundle {
+	b := &Bundle{
+		fallback: fallback,
+		catalogs: make(map[language.Tag]map[string]*message),
+	}
+	b.addLanguage(fallback)
+	return b
+}
+
+// LoadFS loads every <locale>.json file in dir of fsys, e.g. "en.json" or "es-MX.json".
+// Each file maps message IDs either to a template string or to an object of plural
+// forms ("zero", "one", "two", "few", "many", "other"):
+//
+//	{
+//	  "welcome": "Welcome back, {{.Name}}!",
+//	  "cart.items": {"one": "{{.Count}} item", "other": "{{.Count}} items"}
+//	}
+func (b *Bundle) LoadFS(fsys fs.FS, dir string) error {
+	files, err := fs.Glob(fsys, path.Join(dir, "*.json"))
+	if err != nil {
+		return err
+	}
+	if len(files) == 0 {
+		return fmt.Errorf("%w: no .json files in %q", ErrInvalidCatalog, dir)
+	}
+
+	for _, file := range files {
+		data, err := fs.ReadFile(fsys, file)
+		if err != nil {
+			return err
+		}
+		locale := strings.TrimSuffix(path.Base(file), ".json")
+		tag, err := language.Parse(locale)
+		if err != nil {
+			return fmt.Errorf("%w: %s: unknown locale %q", ErrInvalidCatalog, file, locale)
+		}
+		if err := b.AddMessages(tag, data); err != nil {
+			return fmt.Errorf("%s: %w", file, err)
+		}
+	}
+	return nil
+}
+
+// AddMessages adds the messages in a JSON catalog to the given language,
+// replacing any existing messages with the same IDs
+func (b *Bundle) AddMessages(tag language.Tag, data []byte) error {
+	var raw map[string]json.RawMessage
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return fmt.Errorf("%w: %v", ErrInvalidCatalog, err)
+	}
+
+	catalog := b.addLanguage(tag)
+	for id, value := range raw {
+		forms := map[string]string{}
+
+		var simple string
+		if err := json.Unmarshal(value, &simple); err == nil {
+			forms["other"] = simple
+		} else if err := json.Unmarshal(value, &forms); err != nil {
+			return fmt.Errorf("%w: message %q must be a string or an object of plural forms", ErrInvalidCatalog, id)
+		}
+
+		if _, ok := forms["other"]; !ok {
+			return fmt.Errorf("%w: message %q is missing the \"other\" form", ErrInvalidCatalog, id)
+		}
+
+		msg := &message{forms: make(map[string]*template.Template, len(forms))}
+		for form, text := range forms {
+			if !isPluralForm(form) {
+				return fmt.Errorf("%w: message %q has unknown plural form %q", ErrInvalidCatalog, id, form)
+			}
+			tmpl, err := template.New(id).Option("missingkey=zero").Parse(text)
+			if err != nil {
+				return fmt.Errorf("%w: message %q: %v", ErrInvalidCatalog, id, err)
+			}
+			msg.forms[form] = tmpl
+		}
+		catalog[id] = msg
+	}
+	return nil
+}
+
+// Languages returns the languages with catalogs, starting with the fallback
+func (b *Bundle) Languages() []language.Tag {
+	return append([]language.Tag(nil), b.tags...)
+}
+
+// Match returns the best supported language for the given preferences, which may be
+// Accept-Language header values or language tags. It returns the fallback if nothing matches.
+func (b *Bundle) Match(preferences ...string) language.Tag {
+	var desired []language.Tag
+	for _, p := range preferences {
+		tags, _, err := language.ParseAcceptLanguage(p)
+		if err != nil {
+			continue
+		}
+		desired = append(desired, tags...)
+	}
+	if len(desired) == 0 {
+		return b.fallback
+	}
+
+	_, index, confidence := b.matcher.Match(desired...)
+	if confidence == language.No {
+		return b.fallback
+	}
+	return b.tags[index]
+}
+
+// Localizer returns a Localizer for the best match among the given preferences
+func (b *Bundle) Localizer(preferences ...string) *Localizer {
+	return &Localizer{bundle: b, tag: b.Match(preferences...)}
+}
+
+// addLanguage returns the catalog for tag, creating it if needed
+func (b *Bundle) addLanguage(tag language.Tag) map[string]*message {
+	if catalog, ok := b.catalogs[tag]; ok {
+		return catalog
+	}
+	catalog := make(map[string]*message)
+	b.catalogs[tag] = catalog
+	b.tags = append(b.tags, tag)
+	b.matcher = language.NewMatcher(b.tags)
+	return catalog
+}
+
+// lookup returns the message for id in tag, falling back to parent languages
+// (es-MX to es) and then to the bundle fallback
+func (b *Bundle) lookup(tag language.Tag, id string) (*message, language.Tag) {
+	for t := tag; ; t = t.Parent() {
+		if msg, ok := b.catalogs[t][id]; ok {
+			return msg, t
+		}
+		if t == language.Und {
+			break
+		}
+	}
+	if msg, ok := b.catalogs[b.fallback][id]; ok {
+		return msg, b.fallback
+	}
+	return nil, tag
+}
+
+// isPluralForm reports whether form is a CLDR plural category
+func isPluralForm(form string) bool {
+	for _, f := range pluralForms {
+		if f == form {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,35 @@
+package i18n
+
+import (
+	"context"
+	"net/http"
+)
+
+// contextKey is a private type for context keys to avoid collisions
+type contextKey int
+
+// localizerKey is the key for Localizer values in contexts
+const localizerKey contextKey = iota
+
+// NewContext returns a context carrying l
+func NewContext(ctx context.Context, l *Localizer) context.Context {
+	return context.WithValue(ctx, localizerKey, l)
+}
+
+// FromContext returns the Localizer stored in ctx, or nil if there is none
+func FromContext(ctx context.Context) *Localizer {
+	l, _ := ctx.Value(localizerKey).(*Localizer)
+	return l
+}
+
+// Middleware negotiates a language from the lang query parameter or the
+// Accept-Language header, stores a Localizer in the request context, and sets the
+// Content-Language response header
+func (b *Bundle) Middleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		l := b.Localizer(r.URL.Query().Get("lang"), r.Header.Get("Accept-Language"))
+
+		w.Header().Set("Content-Language", l.Language().String())
+		next.ServeHTTP(w, r.WithContext(NewContext(r.Context(), l)))
+	})
+}
@@ -0,0 +1,78 @@
+/*
+Package i18n provides message catalogs, plural rules, and locale negotiation for
+customer-facing text.
+
+Messages live in JSON files embedded in the service binary, one file per locale.
+Handlers get a Localizer for the caller's language from the request context and
+render messages by ID instead of hard-coding English strings.
+
+# Features
+
+  - JSON message catalogs loaded from an embed.FS
+  - Message templates using text/template syntax
+  - CLDR plural rules for every language supported by golang.org/x/text
+  - Locale negotiation from Accept-Language headers and a lang query parameter
+  - Fallback from regional variants to their parent language and then to a default
+  - HTTP middleware that stores a Localizer in the request context
+  - Localized api.Error values
+
+# Message Files
+
+Each file is named after its locale and maps message IDs to templates. Messages
+that depend on a count provide CLDR plural forms; "other" is always required:
+
+	// locales/en.json
+	{
+	  "welcome": "Welcome back, {{.Name}}!",
+	  "order.not_found": "Order {{.ID}} was not found",
+	  "cart.items": {
+	    "one": "{{.Count}} item in your cart",
+	    "other": "{{.Count}} items in your cart"
+	  }
+	}
+
+Loading embedded catalogs at startup:
+
+	//go:embed locales/*.json
+	var locales embed.FS
+
+	bundle := i18n.NewBundle(language.English)
+	if err := bundle.LoadFS(locales, "locales"); err != nil {
+		log.Fatal(err)
+	}
+
+# Localizing Requests
+
+The middleware negotiates a language for each request. The lang query parameter
+takes precedence over the Accept-Language header:
+
+	r := router.New()
+	r.Use(bundle.Middleware)
+
+	r.Get("/cart", api.WrapHandler(func(w http.ResponseWriter, r *http.Request) error {
+		l := i18n.FromContext(r.Context())
+		return api.WriteSuccess(w, map[string]string{
+			"summary": l.Plural("cart.items", len(cart.Items), nil),
+		})
+	}))
+
+Plural passes {"Count": count} to the template when data is nil.
+
+# Localized Errors
+
+Localizer.Error builds an api.Error with a translated message:
+
+	return l.Error(http.StatusNotFound, "order.not_found", map[string]any{"ID": id})
+
+# Missing Messages
+
+Messages missing from a language fall back to its parent language (es-MX to es)
+and then to the bundle's fallback language. Messages missing everywhere render as
+their ID so gaps are easy to spot.
+
+# Integration
+
+Localizer implements the Translator interface. Packages that produce
+customer-facing text accept a Translator rather than depending on Bundle directly.
+*/
+package i18n
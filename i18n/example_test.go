@@ -0,0 +1,25 @@
+package i18n_test
+
+import (
+	"fmt"
+
+	"github.com/StairSupplies/go-core/i18n"
+	"golang.org/x/text/language"
+)
+
+func ExampleLocalizer_Plural() {
+	bundle := i18n.NewBundle(language.English)
+	bundle.AddMessages(language.English, []byte(`{
+		"cart.items": {"one": "{{.Count}} item", "other": "{{.Count}} items"}
+	}`))
+	bundle.AddMessages(language.Spanish, []byte(`{
+		"cart.items": {"one": "{{.Count}} artículo", "other": "{{.Count}} artículos"}
+	}`))
+
+	es := bundle.Localizer("es-MX,es;q=0.9,en;q=0.5")
+	fmt.Println(es.Plural("cart.items", 1, nil))
+	fmt.Println(es.Plural("cart.items", 3, nil))
+	// Output:
+	// 1 artículo
+	// 3 artículos
+}
@@ -0,0 +1,154 @@
+package i18n
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"testing"
+
+	"golang.org/x/text/language"
+)
+
+func newTestBundle(t *testing.T) *Bundle {
+	t.Helper()
+	b := NewBundle(language.English)
+	if err := b.LoadFS(os.DirFS("testdata"), "locales"); err != nil {
+		t.Fatalf("LoadFS() error = %v", err)
+	}
+	return b
+}
+
+func TestBundle_Match(t *testing.T) {
+	b := newTestBundle(t)
+
+	tests := []struct {
+		name        string
+		preferences []string
+		want        language.Tag
+	}{
+		{"exact", []string{"es"}, language.Spanish},
+		{"regional variant", []string{"es-MX,es;q=0.9"}, language.Spanish},
+		{"quality ordering", []string{"fr;q=0.5, pl;q=0.8"}, language.Polish},
+		{"unsupported", []string{"ja"}, language.English},
+		{"empty", []string{""}, language.English},
+		{"query before header", []string{"pl", "es"}, language.Polish},
+		{"malformed", []string{"!!"}, language.English},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			got := b.Match(tc.preferences...)
+			base, _ := got.Base()
+			wantBase, _ := tc.want.Base()
+			if base != wantBase {
+				t.Errorf("Match(%v) = %v, want %v", tc.preferences, got, tc.want)
+			}
+		})
+	}
+}
+
+func TestLocalizer_T(t *testing.T) {
+	b := newTestBundle(t)
+	es := b.Localizer("es-MX")
+
+	if got := es.T("welcome", map[string]string{"Name": "Ana"}); got != "¡Bienvenido de nuevo, Ana!" {
+		t.Errorf("Unexpected translation %q", got)
+	}
+	if got := es.T("only.english", nil); got != "Only in English" {
+		t.Errorf("Expected fallback to English, got %q", got)
+	}
+	if got := es.T("missing.id", nil); got != "missing.id" {
+		t.Errorf("Expected missing message to render its ID, got %q", got)
+	}
+}
+
+func TestLocalizer_Plural(t *testing.T) {
+	b := newTestBundle(t)
+
+	tests := []struct {
+		lang  string
+		count int
+		want  string
+	}{
+		{"en", 1, "1 item in your cart"},
+		{"en", 0, "0 items in your cart"},
+		{"en", 5, "5 items in your cart"},
+		{"es", 1, "1 artículo en tu carrito"},
+		{"pl", 1, "1 produkt"},
+		{"pl", 3, "3 produkty"},
+		{"pl", 5, "5 produktów"},
+		{"pl", 22, "22 produkty"},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.want, func(t *testing.T) {
+			if got := b.Localizer(tc.lang).Plural("cart.items", tc.count, nil); got != tc.want {
+				t.Errorf("Expected %q, got %q", tc.want, got)
+			}
+		})
+	}
+}
+
+func TestLocalizer_Error(t *testing.T) {
+	b := newTestBundle(t)
+
+	err := b.Localizer("es").Error(http.StatusNotFound, "order.not_found", map[string]int{"ID": 1001})
+	if err.StatusCode != http.StatusNotFound || err.Message != "No se encontró el pedido 1001" {
+		t.Errorf("Unexpected error %+v", err)
+	}
+}
+
+func TestBundle_AddMessagesErrors(t *testing.T) {
+	tests := []struct {
+		name string
+		data string
+	}{
+		{"invalid json", `{`},
+		{"missing other", `{"items": {"one": "1 item"}}`},
+		{"unknown form", `{"items": {"other": "items", "plenty": "lots"}}`},
+		{"bad template", `{"greeting": "Hello {{.Name"}`},
+		{"wrong type", `{"greeting": 42}`},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			err := NewBundle(language.English).AddMessages(language.English, []byte(tc.data))
+			if !errors.Is(err, ErrInvalidCatalog) {
+				t.Errorf("Expected ErrInvalidCatalog, got %v", err)
+			}
+		})
+	}
+}
+
+func TestMiddleware(t *testing.T) {
+	b := newTestBundle(t)
+
+	var got string
+	handler := b.Middleware(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		got = FromContext(r.Context()).T("welcome", map[string]string{"Name": "Ana"})
+	}))
+
+	req := httptest.NewRequest(http.MethodGet, "/", nil)
+	req.Header.Set("Accept-Language", "es-ES,es;q=0.9,en;q=0.8")
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, req)
+
+	if got != "¡Bienvenido de nuevo, Ana!" {
+		t.Errorf("Unexpected translation %q", got)
+	}
+	if rec.Header().Get("Content-Language") != "es" {
+		t.Errorf("Expected Content-Language es, got %q", rec.Header().Get("Content-Language"))
+	}
+
+	req = httptest.NewRequest(http.MethodGet, "/?lang=en", nil)
+	req.Header.Set("Accept-Language", "es")
+	handler.ServeHTTP(httptest.NewRecorder(), req)
+	if got != "Welcome back, Ana!" {
+		t.Errorf("Expected lang query parameter to win, got %q", got)
+	}
+
+	if FromContext(req.Context()) != nil {
+		t.Error("Expected no Localizer in a bare context")
+	}
+}
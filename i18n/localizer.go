@@ -0,0 +1,91 @@
+package i18n
+
+import (
+	"strings"
+
+	"github.com/StairSupplies/go-core/api"
+	"golang.org/x/text/feature/plural"
+	"golang.org/x/text/language"
+)
+
+// Translator translates message IDs. It is the integration point for packages that
+// produce customer-facing text, such as validation messages.
+type Translator interface {
+	// T returns the message for id rendered with data
+	T(id string, data any) string
+}
+
+// Localizer renders messages in a single language
+type Localizer struct {
+	bundle *Bundle
+	tag    language.Tag
+}
+
+var _ Translator = (*Localizer)(nil)
+
+// Language returns the language the Localizer renders
+func (l *Localizer) Language() language.Tag {
+	return l.tag
+}
+
+// T returns the message for id rendered with data. Missing messages render as
+// the ID itself so untranslated text is visible rather than blank.
+func (l *Localizer) T(id string, data any) string {
+	return l.render(id, "other", data)
+}
+
+// Plural returns the plural form of message id that matches count, rendered with
+// data. When data is nil, the template receives {"Count": count}.
+func (l *Localizer) Plural(id string, count int, data any) string {
+	if data == nil {
+		data = map[string]any{"Count": count}
+	}
+	return l.render(id, pluralForm(l.tag, count), data)
+}
+
+// Error returns an api.Error with the given status whose message is the localized
+// message for id
+func (l *Localizer) Error(statusCode int, id string, data any) api.Error {
+	return api.Error{StatusCode: statusCode, Message: l.T(id, data)}
+}
+
+// render executes the requested plural form, falling back to "other"
+func (l *Localizer) render(id, form string, data any) string {
+	msg, _ := l.bundle.lookup(l.tag, id)
+	if msg == nil {
+		return id
+	}
+
+	tmpl, ok := msg.forms[form]
+	if !ok {
+		tmpl = msg.forms["other"]
+	}
+
+	var b strings.Builder
+	if err := tmpl.Execute(&b, data); err != nil {
+		return id
+	}
+	return b.String()
+}
+
+// pluralForm returns the CLDR cardinal plural category of count in tag
+func pluralForm(tag language.Tag, count int) string {
+	n := count
+	if n < 0 {
+		n = -n
+	}
+
+	switch plural.Cardinal.MatchPlural(tag, n, 0, 0, 0, 0) {
+	case plural.Zero:
+		return "zero"
+	case plural.One:
+		return "one"
+	case plural.Two:
+		return "two"
+	case plural.Few:
+		return "few"
+	case plural.Many:
+		return "many"
+	}
+	return "other"
+}
@@ -0,0 +1,45 @@
+package jsonutils
+
+import (
+	"bytes"
+	"encoding/json"
+)
+
+// Canonical returns the JSON encoding of v with object keys sorted at every
+// level and no insignificant whitespace, so equal values always produce the
+// same bytes. Use it for hashes and signatures.
+//
+// Numbers are written as encoding/json produces them, and HTML characters
+// are not escaped.
+func Canonical(v any) ([]byte, error) {
+	value, err := toValue(v)
+	if err != nil {
+		return nil, err
+	}
+
+	var buf bytes.Buffer
+	enc := json.NewEncoder(&buf)
+	enc.SetEscapeHTML(false)
+	// encoding/json sorts map keys, so re-encoding the generic value is enough
+	if err := enc.Encode(value); err != nil {
+		return nil, err
+	}
+	return bytes.TrimSuffix(buf.Bytes(), []byte("\n")), nil
+}
+
+// toValue converts v to its generic JSON form: map[string]any, []any,
+// string, json.Number, bool or nil
+func toValue(v any) (any, error) {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return nil, err
+	}
+
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.UseNumber()
+	var value any
+	if err := dec.Decode(&value); err != nil {
+		return nil, err
+	}
+	return value, nil
+}
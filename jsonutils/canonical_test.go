@@ -0,0 +1,63 @@
+package jsonutils
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestCanonical(t *testing.T) {
+	type item struct {
+		Zeta  string `json:"zeta"`
+		Alpha int    `json:"alpha"`
+	}
+
+	tests := []struct {
+		name  string
+		input any
+		want  string
+	}{
+		{
+			name:  "struct fields sorted",
+			input: item{Zeta: "z", Alpha: 1},
+			want:  `{"alpha":1,"zeta":"z"}`,
+		},
+		{
+			name:  "nested maps sorted",
+			input: map[string]any{"b": []any{map[string]int{"y": 2, "x": 1}}, "a": nil},
+			want:  `{"a":null,"b":[{"x":1,"y":2}]}`,
+		},
+		{
+			name:  "raw JSON reordered",
+			input: json.RawMessage(`{ "b" : 1, "a" : { "d": true, "c": "<&>" } }`),
+			want:  `{"a":{"c":"<&>","d":true},"b":1}`,
+		},
+		{
+			name:  "large numbers keep precision",
+			input: json.RawMessage(`{"id": 12345678901234567890}`),
+			want:  `{"id":12345678901234567890}`,
+		},
+		{
+			name:  "scalar",
+			input: "plain",
+			want:  `"plain"`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := Canonical(tt.input)
+			if err != nil {
+				t.Fatalf("Expected no error, got %v", err)
+			}
+			if string(got) != tt.want {
+				t.Errorf("Expected %s, got %s", tt.want, got)
+			}
+		})
+	}
+}
+
+func TestCanonical_Error(t *testing.T) {
+	if _, err := Canonical(func() {}); err == nil {
+		t.Error("Expected error for unsupported type")
+	}
+}
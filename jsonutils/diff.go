@@ -0,0 +1,143 @@
+package jsonutils
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// ChangeOp is the kind of a Change
+type ChangeOp string
+
+// Change operations
+const (
+	OpAdd     ChangeOp = "add"
+	OpRemove  ChangeOp = "remove"
+	OpReplace ChangeOp = "replace"
+)
+
+// Change is a single difference found by Diff. From and To hold the generic
+// JSON form of the values: maps, slices, strings, json.Number, bools or nil.
+type Change struct {
+	Op   ChangeOp `json:"op"`
+	Path string   `json:"path"`           // JSON Pointer (RFC 6901), such as "/items/0/sku"
+	From any      `json:"from,omitempty"` // Old value; unset for OpAdd
+	To   any      `json:"to,omitempty"`   // New value; unset for OpRemove
+}
+
+// String returns a short description of the change, such as
+// `replace /status: "pending" -> "shipped"`
+func (c Change) String() string {
+	switch c.Op {
+	case OpAdd:
+		return fmt.Sprintf("add %s: %s", c.Path, compact(c.To))
+	case OpRemove:
+		return fmt.Sprintf("remove %s: %s", c.Path, compact(c.From))
+	default:
+		return fmt.Sprintf("%s %s: %s -> %s", c.Op, c.Path, compact(c.From), compact(c.To))
+	}
+}
+
+// compact returns the canonical JSON for a generic value
+func compact(v any) string {
+	data, err := Canonical(v)
+	if err != nil {
+		return fmt.Sprint(v)
+	}
+	return string(data)
+}
+
+// Diff compares the JSON encodings of a and b and returns the changes that
+// turn a into b, ordered by path with object keys sorted. It returns nil if
+// they are equal.
+//
+// Objects are compared member by member and arrays element by element, so
+// an element inserted at the front of an array shows up as a replacement of
+// every following element.
+func Diff(a, b any) ([]Change, error) {
+	va, err := toValue(a)
+	if err != nil {
+		return nil, err
+	}
+	vb, err := toValue(b)
+	if err != nil {
+		return nil, err
+	}
+
+	var changes []Change
+	diffValues(&changes, "", va, vb)
+	return changes, nil
+}
+
+// diffValues appends the changes between the generic values a and b at path
+func diffValues(changes *[]Change, path string, a, b any) {
+	switch av := a.(type) {
+	case map[string]any:
+		if bv, ok := b.(map[string]any); ok {
+			diffObjects(changes, path, av, bv)
+			return
+		}
+	case []any:
+		if bv, ok := b.([]any); ok {
+			diffArrays(changes, path, av, bv)
+			return
+		}
+	default:
+		if a == b {
+			return
+		}
+	}
+	*changes = append(*changes, Change{Op: OpReplace, Path: path, From: a, To: b})
+}
+
+// diffObjects appends the changes between two JSON objects
+func diffObjects(changes *[]Change, path string, a, b map[string]any) {
+	keys := make([]string, 0, len(a)+len(b))
+	for k := range a {
+		keys = append(keys, k)
+	}
+	for k := range b {
+		if _, ok := a[k]; !ok {
+			keys = append(keys, k)
+		}
+	}
+	sort.Strings(keys)
+
+	for _, k := range keys {
+		childPath := path + "/" + escapePointer(k)
+		av, inA := a[k]
+		bv, inB := b[k]
+		switch {
+		case !inA:
+			*changes = append(*changes, Change{Op: OpAdd, Path: childPath, To: bv})
+		case !inB:
+			*changes = append(*changes, Change{Op: OpRemove, Path: childPath, From: av})
+		default:
+			diffValues(changes, childPath, av, bv)
+		}
+	}
+}
+
+// diffArrays appends the changes between two JSON arrays
+func diffArrays(changes *[]Change, path string, a, b []any) {
+	for i := 0; i < len(a) || i < len(b); i++ {
+		childPath := path + "/" + strconv.Itoa(i)
+		switch {
+		case i >= len(a):
+			*changes = append(*changes, Change{Op: OpAdd, Path: childPath, To: b[i]})
+		case i >= len(b):
+			*changes = append(*changes, Change{Op: OpRemove, Path: childPath, From: a[i]})
+		default:
+			diffValues(changes, childPath, a[i], b[i])
+		}
+	}
+}
+
+// pointerEscaper escapes a JSON Pointer reference token
+var pointerEscaper = strings.NewReplacer("~", "~0", "/", "~1")
+
+// escapePointer escapes key for use in a JSON Pointer
+func escapePointer(key string) string {
+	return pointerEscaper.Replace(key)
+}
@@ -0,0 +1,123 @@
+package jsonutils
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+)
+
+func TestDiff(t *testing.T) {
+	type address struct {
+		City string `json:"city"`
+	}
+	type customer struct {
+		Name    string            `json:"name"`
+		Email   string            `json:"email,omitempty"`
+		Address address           `json:"address"`
+		Tags    []string          `json:"tags"`
+		Labels  map[string]string `json:"labels,omitempty"`
+	}
+
+	before := customer{
+		Name:    "Ada",
+		Email:   "ada@example.com",
+		Address: address{City: "Reading"},
+		Tags:    []string{"wholesale", "net30"},
+		Labels:  map[string]string{"a/b": "x"},
+	}
+	after := customer{
+		Name:    "Ada Lovelace",
+		Address: address{City: "London"},
+		Tags:    []string{"wholesale", "net60", "priority"},
+	}
+
+	got, err := Diff(before, after)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	want := []Change{
+		{Op: OpReplace, Path: "/address/city", From: "Reading", To: "London"},
+		{Op: OpRemove, Path: "/email", From: "ada@example.com"},
+		{Op: OpRemove, Path: "/labels", From: map[string]any{"a/b": "x"}},
+		{Op: OpReplace, Path: "/name", From: "Ada", To: "Ada Lovelace"},
+		{Op: OpReplace, Path: "/tags/1", From: "net30", To: "net60"},
+		{Op: OpAdd, Path: "/tags/2", To: "priority"},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("Expected %v, got %v", want, got)
+	}
+}
+
+func TestDiff_Cases(t *testing.T) {
+	tests := []struct {
+		name string
+		a, b any
+		want []Change
+	}{
+		{
+			name: "equal values",
+			a:    map[string]any{"x": []int{1, 2}},
+			b:    json.RawMessage(`{"x":[1,2]}`),
+		},
+		{
+			name: "type change",
+			a:    map[string]any{"x": 1},
+			b:    map[string]any{"x": []int{1}},
+			want: []Change{{Op: OpReplace, Path: "/x", From: json.Number("1"), To: []any{json.Number("1")}}},
+		},
+		{
+			name: "array shrinks",
+			a:    []int{1, 2, 3},
+			b:    []int{1},
+			want: []Change{
+				{Op: OpRemove, Path: "/1", From: json.Number("2")},
+				{Op: OpRemove, Path: "/2", From: json.Number("3")},
+			},
+		},
+		{
+			name: "escaped keys",
+			a:    map[string]int{"a/b": 1, "c~d": 1},
+			b:    map[string]int{"a/b": 2, "c~d": 2},
+			want: []Change{
+				{Op: OpReplace, Path: "/a~1b", From: json.Number("1"), To: json.Number("2")},
+				{Op: OpReplace, Path: "/c~0d", From: json.Number("1"), To: json.Number("2")},
+			},
+		},
+		{
+			name: "root replaced",
+			a:    "draft",
+			b:    nil,
+			want: []Change{{Op: OpReplace, Path: "", From: "draft", To: nil}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := Diff(tt.a, tt.b)
+			if err != nil {
+				t.Fatalf("Expected no error, got %v", err)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Expected %v, got %v", tt.want, got)
+			}
+		})
+	}
+}
+
+func TestChange_String(t *testing.T) {
+	tests := []struct {
+		change Change
+		want   string
+	}{
+		{Change{Op: OpAdd, Path: "/tags/0", To: "new"}, `add /tags/0: "new"`},
+		{Change{Op: OpRemove, Path: "/email", From: "a@b.c"}, `remove /email: "a@b.c"`},
+		{Change{Op: OpReplace, Path: "/qty", From: json.Number("1"), To: json.Number("2")}, `replace /qty: 1 -> 2`},
+	}
+
+	for _, tt := range tests {
+		if got := tt.change.String(); got != tt.want {
+			t.Errorf("Expected %q, got %q", tt.want, got)
+		}
+	}
+}
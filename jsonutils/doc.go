@@ -39,5 +39,81 @@ Decoding provides detailed error messages for common JSON parsing issues:
 - Empty body detection
 - Unknown field identification
 - Multiple JSON value detection
+
+# Size Limits
+
+DecodeLimited, or Decode with WithMaxBytes, stops reading once the input
+exceeds a limit, so oversized payloads are rejected without being buffered:
+
+    err := jsonutils.DecodeLimited(r.Body, &order, 1<<20)
+    if errors.Is(err, jsonutils.ErrBodyTooLarge) {
+        return api.NewError(http.StatusRequestEntityTooLarge, err)
+    }
+
+Bodies already wrapped in http.MaxBytesReader report the same ErrBodyTooLarge.
+
+# Streaming
+
+DecodeStream reads a top-level JSON array or newline-delimited JSON one
+element at a time, so large export files are never held in memory at once:
+
+    err := jsonutils.DecodeStream(file, func(raw json.RawMessage) error {
+        var order Order
+        if err := jsonutils.Decode(bytes.NewReader(raw), &order); err != nil {
+            return err
+        }
+        return store.Save(ctx, order)
+    })
+
+Malformed elements and errors returned by the callback stop the stream and
+are reported as an *ElementError with the element's index:
+
+    element 2: body contains incorrect JSON type for field "total"
+
+# Canonical Encoding
+
+Canonical encodes a value with object keys sorted at every level and no
+extra whitespace, so the same data always hashes the same way:
+
+    data, err := jsonutils.Canonical(order)
+    sum := sha256.Sum256(data)
+
+# Diffing
+
+Diff compares the JSON forms of two values and lists what changed, with
+JSON Pointer paths, for example to record an audit trail:
+
+    changes, err := jsonutils.Diff(before, after)
+    for _, c := range changes {
+        fmt.Println(c) // replace /address/city: "Reading" -> "London"
+    }
+
+Arrays are compared by index, and numbers are reported as json.Number so
+large IDs keep their precision.
+
+# Merge Patch
+
+MergePatch applies an RFC 7386 JSON merge patch, the usual body of a PATCH
+request: members in the patch replace those in the document, null removes
+a member, and nested objects are merged:
+
+    updated, err := jsonutils.MergePatch(original, []byte(`{"status":"shipped","notes":null}`))
+
+MergePatchInto applies a patch to a struct through its JSON form:
+
+    if err := jsonutils.MergePatchInto(&order, body); err != nil {
+        return api.BadRequestError(err)
+    }
+
+# JSON Pointers
+
+GetPointer and SetPointer read and write a single value addressed by an
+RFC 6901 JSON Pointer, the same paths Diff reports:
+
+    sku, err := jsonutils.GetPointer(doc, "/items/0/sku")    // `"OAK-36"`
+    doc, err = jsonutils.SetPointer(doc, "/items/0/qty", 3)
+    doc, err = jsonutils.SetPointer(doc, "/items/-", newItem) // append
+
+Missing values are reported with ErrPointerNotFound.
 */
 package jsonutils
\ No newline at end of file
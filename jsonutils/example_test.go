@@ -2,6 +2,8 @@ package jsonutils_test
 
 import (
 	"bytes"
+	"encoding/json"
+	"errors"
 	"fmt"
 	"strings"
 
@@ -151,4 +153,113 @@ func ExampleDecode_errorHandling() {
 	// Print the error message
 	fmt.Printf("Error: %v\n", err)
 	// Output: Error: body contains badly-formed JSON
-}
\ No newline at end of file
+}
+
+func ExampleDecodeLimited() {
+	type Order struct {
+		SKU string `json:"sku"`
+	}
+
+	payload := `{"sku":"` + strings.Repeat("X", 2048) + `"}`
+
+	var order Order
+	err := jsonutils.DecodeLimited(strings.NewReader(payload), &order, 1024)
+	if errors.Is(err, jsonutils.ErrBodyTooLarge) {
+		fmt.Println(err)
+	}
+	// Output: body too large: must not be larger than 1024 bytes
+}
+
+// ExampleDecodeStream demonstrates processing newline-delimited JSON one record at a time.
+func ExampleDecodeStream() {
+	type Order struct {
+		ID    string `json:"id"`
+		Total int    `json:"total"`
+	}
+
+	export := strings.NewReader(`{"id":"A-1","total":120}
+{"id":"A-2","total":80}
+{"id":"A-3","total":"free"}
+`)
+
+	err := jsonutils.DecodeStream(export, func(raw json.RawMessage) error {
+		var order Order
+		if err := jsonutils.Decode(bytes.NewReader(raw), &order); err != nil {
+			return err
+		}
+		fmt.Printf("%s: %d\n", order.ID, order.Total)
+		return nil
+	})
+	if err != nil {
+		fmt.Println("Error:", err)
+	}
+
+	// Output:
+	// A-1: 120
+	// A-2: 80
+	// Error: element 2: body contains incorrect JSON type for field "total"
+}
+
+func ExampleCanonical() {
+	payload := map[string]any{
+		"total": 120,
+		"id":    "A-1",
+		"items": []map[string]any{{"sku": "STR-1", "qty": 2}},
+	}
+
+	data, _ := jsonutils.Canonical(payload)
+	fmt.Println(string(data))
+
+	// Output: {"id":"A-1","items":[{"qty":2,"sku":"STR-1"}],"total":120}
+}
+
+func ExampleDiff() {
+	type Order struct {
+		Status string   `json:"status"`
+		Items  []string `json:"items"`
+	}
+
+	before := Order{Status: "pending", Items: []string{"STR-1"}}
+	after := Order{Status: "shipped", Items: []string{"STR-1", "STR-2"}}
+
+	changes, err := jsonutils.Diff(before, after)
+	if err != nil {
+		fmt.Println("Error:", err)
+		return
+	}
+	for _, c := range changes {
+		fmt.Println(c)
+	}
+
+	// Output:
+	// add /items/1: "STR-2"
+	// replace /status: "pending" -> "shipped"
+}
+
+func ExampleMergePatch() {
+	original := []byte(`{"status":"pending","notes":"leave at door","address":{"city":"Reading","zip":"RG1"}}`)
+	patch := []byte(`{"status":"shipped","notes":null,"address":{"city":"London"}}`)
+
+	merged, err := jsonutils.MergePatch(original, patch)
+	if err != nil {
+		fmt.Println("Error:", err)
+		return
+	}
+	fmt.Println(string(merged))
+
+	// Output: {"address":{"city":"London","zip":"RG1"},"status":"shipped"}
+}
+
+func ExampleGetPointer() {
+	doc := []byte(`{"items":[{"sku":"OAK-36","qty":1}]}`)
+
+	sku, _ := jsonutils.GetPointer(doc, "/items/0/sku")
+	fmt.Println(string(sku))
+
+	doc, _ = jsonutils.SetPointer(doc, "/items/0/qty", 4)
+	fmt.Println(string(doc))
+
+	// Output:
+	// "OAK-36"
+	// {"items":[{"qty":4,"sku":"OAK-36"}]}
+}
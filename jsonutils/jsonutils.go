@@ -5,45 +5,89 @@ import (
 	"errors"
 	"fmt"
 	"io"
+	"net/http"
 	"strings"
 )
 
-// decode decodes JSON from a reader into a target struct
-func decode(r io.Reader, dst interface{}) error {
-	dec := json.NewDecoder(r)
-	dec.DisallowUnknownFields()
+// ErrBodyTooLarge is returned when the input exceeds the configured size limit
+var ErrBodyTooLarge = errors.New("body too large")
 
-	err := dec.Decode(dst)
-	if err != nil {
-		var syntaxError *json.SyntaxError
-		var unmarshalTypeError *json.UnmarshalTypeError
+// decodeOptions holds the settings applied by DecodeOption
+type decodeOptions struct {
+	maxBytes int64
+}
 
-		switch {
-		case errors.As(err, &syntaxError):
-			return fmt.Errorf("body contains badly-formed JSON (at position %d)", syntaxError.Offset)
+// DecodeOption configures Decode
+type DecodeOption func(*decodeOptions)
 
-		case errors.As(err, &unmarshalTypeError):
-			if unmarshalTypeError.Field != "" {
-				return fmt.Errorf("body contains incorrect JSON type for field %q", unmarshalTypeError.Field)
-			}
-			return fmt.Errorf("body contains incorrect JSON type (at position %d)", unmarshalTypeError.Offset)
+// WithMaxBytes limits the input to n bytes. Larger input fails with ErrBodyTooLarge.
+func WithMaxBytes(n int64) DecodeOption {
+	return func(o *decodeOptions) {
+		o.maxBytes = n
+	}
+}
 
-		case errors.Is(err, io.EOF):
-			return errors.New("body must not be empty")
+// maxBytesReader fails with ErrBodyTooLarge once more than n bytes are read,
+// like http.MaxBytesReader but without a ResponseWriter
+type maxBytesReader struct {
+	r   io.Reader
+	n   int64 // bytes remaining
+	max int64
+	err error
+}
 
-		case errors.Is(err, io.ErrUnexpectedEOF):
-			return errors.New("body contains badly-formed JSON")
+func (l *maxBytesReader) Read(p []byte) (int, error) {
+	if l.err != nil {
+		return 0, l.err
+	}
+	if len(p) == 0 {
+		return 0, nil
+	}
+	// Read one byte past the limit to detect oversized input
+	if int64(len(p)) > l.n+1 {
+		p = p[:l.n+1]
+	}
+	n, err := l.r.Read(p)
 
-		case strings.HasPrefix(err.Error(), "json: unknown field "):
-			fieldName := strings.TrimPrefix(err.Error(), "json: unknown field ")
-			return fmt.Errorf("body contains unknown field %s", fieldName)
-		}
+	if int64(n) <= l.n {
+		l.n -= int64(n)
+		l.err = err
+		return n, err
+	}
 
-		return err
+	n = int(l.n)
+	l.n = 0
+	l.err = tooLarge(l.max)
+	return n, l.err
+}
+
+// tooLarge returns the error for input larger than limit bytes
+func tooLarge(limit int64) error {
+	return fmt.Errorf("%w: must not be larger than %d bytes", ErrBodyTooLarge, limit)
+}
+
+// decode decodes JSON from a reader into a target struct
+func decode(r io.Reader, dst interface{}, opts ...DecodeOption) error {
+	var o decodeOptions
+	for _, opt := range opts {
+		opt(&o)
+	}
+	if o.maxBytes > 0 {
+		r = &maxBytesReader{r: r, n: o.maxBytes, max: o.maxBytes}
+	}
+
+	dec := json.NewDecoder(r)
+	dec.DisallowUnknownFields()
+
+	if err := dec.Decode(dst); err != nil {
+		return decodeError(err)
 	}
 
 	// Check if there's more than one JSON value in the request body
-	err = dec.Decode(&struct{}{})
+	err := dec.Decode(&struct{}{})
+	if errors.Is(err, ErrBodyTooLarge) {
+		return err
+	}
 	if !errors.Is(err, io.EOF) {
 		return errors.New("body must only contain a single JSON value")
 	}
@@ -51,6 +95,42 @@ func decode(r io.Reader, dst interface{}) error {
 	return nil
 }
 
+// decodeError converts an encoding/json error into a descriptive message
+func decodeError(err error) error {
+	var syntaxError *json.SyntaxError
+	var unmarshalTypeError *json.UnmarshalTypeError
+	var maxBytesError *http.MaxBytesError
+
+	switch {
+	case errors.Is(err, ErrBodyTooLarge):
+		return err
+
+	case errors.As(err, &maxBytesError):
+		return tooLarge(maxBytesError.Limit)
+
+	case errors.As(err, &syntaxError):
+		return fmt.Errorf("body contains badly-formed JSON (at position %d)", syntaxError.Offset)
+
+	case errors.As(err, &unmarshalTypeError):
+		if unmarshalTypeError.Field != "" {
+			return fmt.Errorf("body contains incorrect JSON type for field %q", unmarshalTypeError.Field)
+		}
+		return fmt.Errorf("body contains incorrect JSON type (at position %d)", unmarshalTypeError.Offset)
+
+	case errors.Is(err, io.EOF):
+		return errors.New("body must not be empty")
+
+	case errors.Is(err, io.ErrUnexpectedEOF):
+		return errors.New("body contains badly-formed JSON")
+
+	case strings.HasPrefix(err.Error(), "json: unknown field "):
+		fieldName := strings.TrimPrefix(err.Error(), "json: unknown field ")
+		return fmt.Errorf("body contains unknown field %s", fieldName)
+	}
+
+	return err
+}
+
 // Pretty returns a pretty-printed JSON string for an object
 func Pretty(v interface{}) (string, error) {
 	data, err := json.MarshalIndent(v, "", "  ")
@@ -68,6 +148,12 @@ func Encode(w io.Writer, v interface{}) error {
 }
 
 // Decode reads JSON from a reader into a target struct
-func Decode(r io.Reader, v interface{}) error {
-	return decode(r, v)
+func Decode(r io.Reader, v interface{}, opts ...DecodeOption) error {
+	return decode(r, v, opts...)
+}
+
+// DecodeLimited is like Decode but fails with ErrBodyTooLarge if r holds
+// more than maxBytes bytes
+func DecodeLimited(r io.Reader, v interface{}, maxBytes int64) error {
+	return decode(r, v, WithMaxBytes(maxBytes))
 }
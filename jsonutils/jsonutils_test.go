@@ -3,6 +3,9 @@ package jsonutils
 import (
 	"bytes"
 	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
 	"strings"
 	"testing"
 )
@@ -232,4 +235,61 @@ func TestPrettyWithComplexTypes(t *testing.T) {
 			t.Errorf("Expected pretty output to contain key '%s', but it doesn't", key)
 		}
 	}
-}
\ No newline at end of file
+}
+
+func TestDecodeLimited(t *testing.T) {
+	body := `{"name":"John","age":30,"email":"john@example.com"}`
+
+	tests := []struct {
+		name     string
+		input    string
+		maxBytes int64
+		wantErr  bool
+	}{
+		{"under limit", body, 1024, false},
+		{"exactly at limit", body, int64(len(body)), false},
+		{"one byte over", body, int64(len(body)) - 1, true},
+		{"trailing whitespace over limit", body + strings.Repeat(" ", 100), int64(len(body)) + 10, true},
+		{"huge body", `{"name":"` + strings.Repeat("x", 1<<20) + `"}`, 1024, true},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			var result TestStruct
+			err := DecodeLimited(strings.NewReader(tc.input), &result, tc.maxBytes)
+			if tc.wantErr {
+				if !errors.Is(err, ErrBodyTooLarge) {
+					t.Fatalf("Expected ErrBodyTooLarge, got %v", err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("DecodeLimited() error = %v", err)
+			}
+			if result.Name != "John" {
+				t.Errorf("Expected name John, got %q", result.Name)
+			}
+		})
+	}
+}
+
+func TestDecode_WithMaxBytes(t *testing.T) {
+	var result TestStruct
+	err := Decode(strings.NewReader(`{"name":"John"}`), &result, WithMaxBytes(5))
+	if !errors.Is(err, ErrBodyTooLarge) {
+		t.Fatalf("Expected ErrBodyTooLarge, got %v", err)
+	}
+	if err.Error() != "body too large: must not be larger than 5 bytes" {
+		t.Errorf("Unexpected error message %q", err.Error())
+	}
+}
+
+func TestDecode_HTTPMaxBytesReader(t *testing.T) {
+	body := io.NopCloser(strings.NewReader(`{"name":"John"}`))
+	r := http.MaxBytesReader(httptest.NewRecorder(), body, 5)
+
+	var result TestStruct
+	if err := Decode(r, &result); !errors.Is(err, ErrBodyTooLarge) {
+		t.Errorf("Expected ErrBodyTooLarge, got %v", err)
+	}
+}
@@ -0,0 +1,111 @@
+package jsonutils
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"reflect"
+)
+
+// MergePatch applies an RFC 7386 JSON merge patch to original and returns
+// the result. Members of a patch object replace those of the original,
+// members set to null are removed, and nested objects are merged
+// recursively; any other patch value, including an array, replaces the
+// original outright. An empty original is treated as null.
+//
+// Numbers keep their original text, and object keys come out sorted.
+func MergePatch(original, patch []byte) ([]byte, error) {
+	var target any
+	if len(bytes.TrimSpace(original)) > 0 {
+		var err error
+		if target, err = parseValue(original); err != nil {
+			return nil, fmt.Errorf("invalid original document: %w", err)
+		}
+	}
+	p, err := parseValue(patch)
+	if err != nil {
+		return nil, fmt.Errorf("invalid merge patch: %w", err)
+	}
+	return encodeValue(mergeValue(target, p))
+}
+
+// MergePatchInto applies an RFC 7386 JSON merge patch to the JSON form of v,
+// which must be a pointer, and decodes the result back into it. Use it in
+// PATCH handlers to update a loaded record:
+//
+//	order, err := store.Get(ctx, id)
+//	...
+//	if err := jsonutils.MergePatchInto(order, body); err != nil {
+//		return api.BadRequestError(err)
+//	}
+//
+// Fields removed by the patch are reset to their zero value.
+func MergePatchInto(v any, patch []byte) error {
+	original, err := json.Marshal(v)
+	if err != nil {
+		return err
+	}
+	merged, err := MergePatch(original, patch)
+	if err != nil {
+		return err
+	}
+	return resetAndDecode(v, merged)
+}
+
+// resetAndDecode zeroes the value v points to and decodes data into it, so
+// members missing from data leave zero values rather than stale ones
+func resetAndDecode(v any, data []byte) error {
+	rv := reflect.ValueOf(v)
+	if rv.Kind() != reflect.Pointer || rv.IsNil() {
+		return fmt.Errorf("jsonutils: MergePatchInto needs a non-nil pointer, got %T", v)
+	}
+	rv.Elem().Set(reflect.Zero(rv.Elem().Type()))
+	return json.Unmarshal(data, v)
+}
+
+// mergeValue merges the generic patch value into target
+func mergeValue(target, patch any) any {
+	p, ok := patch.(map[string]any)
+	if !ok {
+		return patch
+	}
+	t, ok := target.(map[string]any)
+	if !ok {
+		t = make(map[string]any, len(p))
+	}
+	for k, v := range p {
+		if v == nil {
+			delete(t, k)
+			continue
+		}
+		t[k] = mergeValue(t[k], v)
+	}
+	return t
+}
+
+// parseValue decodes a single JSON value into its generic form, keeping
+// numbers as json.Number
+func parseValue(data []byte) (any, error) {
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.UseNumber()
+	var value any
+	if err := dec.Decode(&value); err != nil {
+		return nil, err
+	}
+	if dec.More() {
+		return nil, errors.New("unexpected data after JSON value")
+	}
+	return value, nil
+}
+
+// encodeValue encodes a generic value compactly without HTML escaping
+func encodeValue(v any) ([]byte, error) {
+	var buf bytes.Buffer
+	enc := json.NewEncoder(&buf)
+	enc.SetEscapeHTML(false)
+	if err := enc.Encode(v); err != nil {
+		return nil, err
+	}
+	return bytes.TrimSuffix(buf.Bytes(), []byte("\n")), nil
+}
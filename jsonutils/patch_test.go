@@ -0,0 +1,102 @@
+package jsonutils
+
+import (
+	"testing"
+)
+
+func TestMergePatch(t *testing.T) {
+	// The examples from RFC 7386, appendix A
+	tests := []struct {
+		original string
+		patch    string
+		want     string
+	}{
+		{`{"a":"b"}`, `{"a":"c"}`, `{"a":"c"}`},
+		{`{"a":"b"}`, `{"b":"c"}`, `{"a":"b","b":"c"}`},
+		{`{"a":"b"}`, `{"a":null}`, `{}`},
+		{`{"a":"b","b":"c"}`, `{"a":null}`, `{"b":"c"}`},
+		{`{"a":["b"]}`, `{"a":"c"}`, `{"a":"c"}`},
+		{`{"a":"c"}`, `{"a":["b"]}`, `{"a":["b"]}`},
+		{`{"a":{"b":"c"}}`, `{"a":{"b":"d","c":null}}`, `{"a":{"b":"d"}}`},
+		{`{"a":[{"b":"c"}]}`, `{"a":[1]}`, `{"a":[1]}`},
+		{`["a","b"]`, `["c","d"]`, `["c","d"]`},
+		{`{"a":"b"}`, `["c"]`, `["c"]`},
+		{`{"a":"foo"}`, `null`, `null`},
+		{`{"a":"foo"}`, `"bar"`, `"bar"`},
+		{`{"e":null}`, `{"a":1}`, `{"a":1,"e":null}`},
+		{`[1,2]`, `{"a":"b","c":null}`, `{"a":"b"}`},
+		{`{}`, `{"a":{"bb":{"ccc":null}}}`, `{"a":{"bb":{}}}`},
+		// Beyond the RFC: empty originals, number precision and HTML
+		{``, `{"a":1}`, `{"a":1}`},
+		{`{"id":12345678901234567890}`, `{"note":"<b>"}`, `{"id":12345678901234567890,"note":"<b>"}`},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.original+" "+tc.patch, func(t *testing.T) {
+			got, err := MergePatch([]byte(tc.original), []byte(tc.patch))
+			if err != nil {
+				t.Fatalf("Expected no error, got %v", err)
+			}
+			if string(got) != tc.want {
+				t.Errorf("Expected %s, got %s", tc.want, got)
+			}
+		})
+	}
+}
+
+func TestMergePatch_Invalid(t *testing.T) {
+	tests := []struct {
+		name     string
+		original string
+		patch    string
+	}{
+		{"invalid original", `{"a":`, `{}`},
+		{"invalid patch", `{}`, `{"a":`},
+		{"trailing data", `{}`, `{} {}`},
+		{"empty patch", `{}`, ``},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			if _, err := MergePatch([]byte(tc.original), []byte(tc.patch)); err == nil {
+				t.Error("Expected an error")
+			}
+		})
+	}
+}
+
+func TestMergePatchInto(t *testing.T) {
+	type address struct {
+		City string `json:"city"`
+		Zip  string `json:"zip,omitempty"`
+	}
+	type order struct {
+		Status  string   `json:"status"`
+		Notes   *string  `json:"notes,omitempty"`
+		Address address  `json:"address"`
+		Tags    []string `json:"tags"`
+	}
+
+	notes := "leave at door"
+	o := order{Status: "pending", Notes: &notes, Address: address{City: "Reading", Zip: "RG1"}, Tags: []string{"a"}}
+	err := MergePatchInto(&o, []byte(`{"status":"shipped","notes":null,"address":{"city":"London"},"tags":["b","c"]}`))
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	if o.Status != "shipped" || o.Notes != nil {
+		t.Errorf("Expected status shipped and notes removed, got %+v", o)
+	}
+	if o.Address.City != "London" || o.Address.Zip != "RG1" {
+		t.Errorf("Expected address to be merged, got %+v", o.Address)
+	}
+	if len(o.Tags) != 2 || o.Tags[0] != "b" {
+		t.Errorf("Expected tags to be replaced, got %v", o.Tags)
+	}
+
+	if err := MergePatchInto(o, []byte(`{}`)); err == nil {
+		t.Error("Expected an error for a non-pointer")
+	}
+	if err := MergePatchInto(&o, []byte(`{"status":1}`)); err == nil {
+		t.Error("Expected an error for a mistyped field")
+	}
+}
@@ -0,0 +1,166 @@
+package jsonutils
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// JSON Pointer errors
+var (
+	// ErrInvalidPointer is returned for a pointer that is not "" and does not
+	// start with "/"
+	ErrInvalidPointer = errors.New("invalid JSON pointer")
+	// ErrPointerNotFound is returned when a pointer names a value that does
+	// not exist
+	ErrPointerNotFound = errors.New("JSON pointer not found")
+)
+
+// ParsePointer splits an RFC 6901 JSON Pointer, such as "/items/0/sku", into
+// its unescaped reference tokens. The empty pointer refers to the whole
+// document and has no tokens.
+func ParsePointer(pointer string) ([]string, error) {
+	if pointer == "" {
+		return nil, nil
+	}
+	if !strings.HasPrefix(pointer, "/") {
+		return nil, fmt.Errorf("%w: %q", ErrInvalidPointer, pointer)
+	}
+	tokens := strings.Split(pointer[1:], "/")
+	for i, t := range tokens {
+		tokens[i] = pointerUnescaper.Replace(t)
+	}
+	return tokens, nil
+}
+
+// pointerUnescaper reverses pointerEscaper; "~1" is replaced before "~0"
+// so "~01" becomes "~1"
+var pointerUnescaper = strings.NewReplacer("~1", "/", "~0", "~")
+
+// GetPointer returns the raw JSON of the value pointer refers to in doc
+//
+//	sku, err := jsonutils.GetPointer(body, "/items/0/sku") // `"OAK-36"`
+func GetPointer(doc []byte, pointer string) (json.RawMessage, error) {
+	tokens, err := ParsePointer(pointer)
+	if err != nil {
+		return nil, err
+	}
+	value, err := parseValue(doc)
+	if err != nil {
+		return nil, err
+	}
+
+	for i, token := range tokens {
+		switch v := value.(type) {
+		case map[string]any:
+			member, ok := v[token]
+			if !ok {
+				return nil, notFound(tokens[:i+1])
+			}
+			value = member
+		case []any:
+			idx, ok := arrayIndex(token, len(v))
+			if !ok || idx == len(v) {
+				return nil, notFound(tokens[:i+1])
+			}
+			value = v[idx]
+		default:
+			return nil, notFound(tokens[:i+1])
+		}
+	}
+	return encodeValue(value)
+}
+
+// SetPointer returns doc with the value pointer refers to replaced by the
+// JSON encoding of value. Setting a missing object member adds it, and the
+// index "-" or the array's length appends to an array; the parent of the
+// target must exist. The empty pointer replaces the whole document.
+func SetPointer(doc []byte, pointer string, value any) ([]byte, error) {
+	tokens, err := ParsePointer(pointer)
+	if err != nil {
+		return nil, err
+	}
+	newValue, err := toValue(value)
+	if err != nil {
+		return nil, err
+	}
+	root, err := parseValue(doc)
+	if err != nil {
+		return nil, err
+	}
+
+	root, err = setValue(root, tokens, newValue, nil)
+	if err != nil {
+		return nil, err
+	}
+	return encodeValue(root)
+}
+
+// setValue sets tokens within target to value and returns the updated
+// target; seen holds the tokens already walked, for error messages
+func setValue(target any, tokens []string, value any, seen []string) (any, error) {
+	if len(tokens) == 0 {
+		return value, nil
+	}
+	token, rest := tokens[0], tokens[1:]
+	path := append(seen, token)
+
+	switch v := target.(type) {
+	case map[string]any:
+		member, ok := v[token]
+		if !ok && len(rest) > 0 {
+			return nil, notFound(path)
+		}
+		updated, err := setValue(member, rest, value, path)
+		if err != nil {
+			return nil, err
+		}
+		v[token] = updated
+		return v, nil
+	case []any:
+		idx, ok := arrayIndex(token, len(v))
+		if !ok || (idx == len(v) && len(rest) > 0) {
+			return nil, notFound(path)
+		}
+		if idx == len(v) {
+			return append(v, value), nil
+		}
+		updated, err := setValue(v[idx], rest, value, path)
+		if err != nil {
+			return nil, err
+		}
+		v[idx] = updated
+		return v, nil
+	default:
+		return nil, notFound(path)
+	}
+}
+
+// arrayIndex parses an array reference token for an array of length n. It
+// accepts "-" and indexes up to n, both meaning the position after the last
+// element; leading zeros are not allowed.
+func arrayIndex(token string, n int) (int, bool) {
+	if token == "-" {
+		return n, true
+	}
+	if token == "" || (len(token) > 1 && token[0] == '0') || strings.Trim(token, "0123456789") != "" {
+		return 0, false
+	}
+	idx, err := strconv.Atoi(token)
+	if err != nil || idx < 0 || idx > n {
+		return 0, false
+	}
+	return idx, true
+}
+
+// notFound reports the pointer made of tokens as not found
+func notFound(tokens []string) error {
+	var b strings.Builder
+	for _, t := range tokens {
+		b.WriteByte('/')
+		b.WriteString(escapePointer(t))
+	}
+	return fmt.Errorf("%w: %s", ErrPointerNotFound, b.String())
+}
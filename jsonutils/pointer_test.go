@@ -0,0 +1,146 @@
+package jsonutils
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+)
+
+// rfc6901Doc is the example document from RFC 6901, section 5
+const rfc6901Doc = `{
+	"foo": ["bar", "baz"],
+	"": 0,
+	"a/b": 1,
+	"c%d": 2,
+	"e^f": 3,
+	"g|h": 4,
+	"i\\j": 5,
+	"k\"l": 6,
+	" ": 7,
+	"m~n": 8
+}`
+
+func TestParsePointer(t *testing.T) {
+	tests := []struct {
+		pointer string
+		want    []string
+		wantErr bool
+	}{
+		{"", nil, false},
+		{"/", []string{""}, false},
+		{"/foo/0", []string{"foo", "0"}, false},
+		{"/a~1b/m~0n", []string{"a/b", "m~n"}, false},
+		{"/~01", []string{"~1"}, false},
+		{"foo", nil, true},
+	}
+	for _, tc := range tests {
+		t.Run(tc.pointer, func(t *testing.T) {
+			got, err := ParsePointer(tc.pointer)
+			if (err != nil) != tc.wantErr {
+				t.Fatalf("Expected error %v, got %v", tc.wantErr, err)
+			}
+			if tc.wantErr && !errors.Is(err, ErrInvalidPointer) {
+				t.Errorf("Expected ErrInvalidPointer, got %v", err)
+			}
+			if !reflect.DeepEqual(got, tc.want) {
+				t.Errorf("Expected %q, got %q", tc.want, got)
+			}
+		})
+	}
+}
+
+func TestGetPointer(t *testing.T) {
+	tests := []struct {
+		pointer string
+		want    string
+	}{
+		{"/foo", `["bar","baz"]`},
+		{"/foo/0", `"bar"`},
+		{"/", `0`},
+		{"/a~1b", `1`},
+		{"/c%d", `2`},
+		{"/e^f", `3`},
+		{"/g|h", `4`},
+		{"/i\\j", `5`},
+		{"/k\"l", `6`},
+		{"/ ", `7`},
+		{"/m~0n", `8`},
+	}
+	for _, tc := range tests {
+		t.Run(tc.pointer, func(t *testing.T) {
+			got, err := GetPointer([]byte(rfc6901Doc), tc.pointer)
+			if err != nil {
+				t.Fatalf("Expected no error, got %v", err)
+			}
+			if string(got) != tc.want {
+				t.Errorf("Expected %s, got %s", tc.want, got)
+			}
+		})
+	}
+
+	whole, err := GetPointer([]byte(`{"b":1,"a":[1]}`), "")
+	if err != nil || string(whole) != `{"a":[1],"b":1}` {
+		t.Errorf("Expected the whole document, got %s, %v", whole, err)
+	}
+}
+
+func TestGetPointer_NotFound(t *testing.T) {
+	for _, pointer := range []string{"/missing", "/foo/2", "/foo/-", "/foo/01", "/foo/+1", "/foo/0/x", "/a~1b/x"} {
+		t.Run(pointer, func(t *testing.T) {
+			_, err := GetPointer([]byte(rfc6901Doc), pointer)
+			if !errors.Is(err, ErrPointerNotFound) {
+				t.Errorf("Expected ErrPointerNotFound, got %v", err)
+			}
+		})
+	}
+
+	if _, err := GetPointer([]byte(`{`), "/a"); err == nil {
+		t.Error("Expected an error for invalid JSON")
+	}
+}
+
+func TestSetPointer(t *testing.T) {
+	doc := `{"items":[{"sku":"OAK-36","qty":1}],"customer":{"name":"Ada"}}`
+
+	tests := []struct {
+		name    string
+		pointer string
+		value   any
+		want    string
+	}{
+		{"replace member", "/items/0/qty", 3, `{"customer":{"name":"Ada"},"items":[{"qty":3,"sku":"OAK-36"}]}`},
+		{"add member", "/customer/email", "ada@example.com", `{"customer":{"email":"ada@example.com","name":"Ada"},"items":[{"qty":1,"sku":"OAK-36"}]}`},
+		{"append with dash", "/items/-", map[string]any{"sku": "MAPLE-42"}, `{"customer":{"name":"Ada"},"items":[{"qty":1,"sku":"OAK-36"},{"sku":"MAPLE-42"}]}`},
+		{"append with length", "/items/1", "x", `{"customer":{"name":"Ada"},"items":[{"qty":1,"sku":"OAK-36"},"x"]}`},
+		{"whole document", "", []int{1}, `[1]`},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			got, err := SetPointer([]byte(doc), tc.pointer, tc.value)
+			if err != nil {
+				t.Fatalf("Expected no error, got %v", err)
+			}
+			if string(got) != tc.want {
+				t.Errorf("Expected %s, got %s", tc.want, got)
+			}
+		})
+	}
+}
+
+func TestSetPointer_Errors(t *testing.T) {
+	doc := []byte(`{"items":[1],"name":"x"}`)
+	for _, pointer := range []string{"/missing/child", "/items/5", "/items/-/x", "/name/x"} {
+		t.Run(pointer, func(t *testing.T) {
+			if _, err := SetPointer(doc, pointer, 1); !errors.Is(err, ErrPointerNotFound) {
+				t.Errorf("Expected ErrPointerNotFound, got %v", err)
+			}
+		})
+	}
+
+	if _, err := SetPointer(doc, "items", 1); !errors.Is(err, ErrInvalidPointer) {
+		t.Errorf("Expected ErrInvalidPointer, got %v", err)
+	}
+	if _, err := SetPointer(doc, "/a", make(chan int)); err == nil {
+		t.Error("Expected an error for a value that cannot be encoded")
+	}
+}
@@ -0,0 +1,126 @@
+package jsonutils
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+)
+
+// ElementError reports a failure for one element of a stream decoded by
+// DecodeStream
+type ElementError struct {
+	// Index is the zero-based position of the element in the stream
+	Index int
+	Err   error
+}
+
+func (e *ElementError) Error() string {
+	return fmt.Sprintf("element %d: %v", e.Index, e.Err)
+}
+
+func (e *ElementError) Unwrap() error {
+	return e.Err
+}
+
+// DecodeStream calls fn for each element of a top-level JSON array, or for
+// each value of newline-delimited JSON, reading r incrementally so only one
+// element is held in memory at a time. The format is detected from the first
+// non-whitespace byte: '[' for an array, anything else for NDJSON.
+//
+// Malformed elements and errors returned by fn stop the stream and are
+// returned as an *ElementError holding the element's index. Empty input
+// yields no elements. WithMaxBytes limits the size of the whole stream.
+func DecodeStream(r io.Reader, fn func(json.RawMessage) error, opts ...DecodeOption) error {
+	var o decodeOptions
+	for _, opt := range opts {
+		opt(&o)
+	}
+	if o.maxBytes > 0 {
+		r = &maxBytesReader{r: r, n: o.maxBytes, max: o.maxBytes}
+	}
+
+	br := bufio.NewReader(r)
+	first, err := peekNonSpace(br)
+	if errors.Is(err, io.EOF) {
+		return nil
+	}
+	if err != nil {
+		return decodeError(err)
+	}
+
+	dec := json.NewDecoder(br)
+	if first == '[' {
+		return decodeArray(dec, fn)
+	}
+	return decodeLines(dec, fn)
+}
+
+// decodeArray calls fn for each element of the array read by dec
+func decodeArray(dec *json.Decoder, fn func(json.RawMessage) error) error {
+	// Consume the opening bracket
+	if _, err := dec.Token(); err != nil {
+		return decodeError(err)
+	}
+
+	index := 0
+	for ; dec.More(); index++ {
+		var raw json.RawMessage
+		if err := dec.Decode(&raw); err != nil {
+			return &ElementError{Index: index, Err: decodeError(err)}
+		}
+		if err := fn(raw); err != nil {
+			return &ElementError{Index: index, Err: err}
+		}
+	}
+
+	// Consume the closing bracket
+	if _, err := dec.Token(); err != nil {
+		if errors.Is(err, io.EOF) {
+			err = io.ErrUnexpectedEOF
+		}
+		return &ElementError{Index: index, Err: decodeError(err)}
+	}
+
+	if _, err := dec.Token(); !errors.Is(err, io.EOF) {
+		if errors.Is(err, ErrBodyTooLarge) {
+			return err
+		}
+		return errors.New("body must only contain a single JSON value")
+	}
+	return nil
+}
+
+// decodeLines calls fn for each value read by dec until the input ends
+func decodeLines(dec *json.Decoder, fn func(json.RawMessage) error) error {
+	for index := 0; ; index++ {
+		var raw json.RawMessage
+		err := dec.Decode(&raw)
+		if errors.Is(err, io.EOF) {
+			return nil
+		}
+		if err != nil {
+			return &ElementError{Index: index, Err: decodeError(err)}
+		}
+		if err := fn(raw); err != nil {
+			return &ElementError{Index: index, Err: err}
+		}
+	}
+}
+
+// peekNonSpace skips leading whitespace and returns the next byte without
+// consuming it
+func peekNonSpace(br *bufio.Reader) (byte, error) {
+	for {
+		b, err := br.ReadByte()
+		if err != nil {
+			return 0, err
+		}
+		switch b {
+		case ' ', '\t', '\r', '\n':
+			continue
+		}
+		return b, br.UnreadByte()
+	}
+}
@@ -0,0 +1,111 @@
+package jsonutils
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestDecodeStream(t *testing.T) {
+	tests := []struct {
+		name    string
+		input   string
+		want    []string
+		wantErr string
+		index   int
+	}{
+		{name: "array", input: ` [{"id":1}, {"id":2}, 3, "four"] `, want: []string{`{"id":1}`, `{"id":2}`, `3`, `"four"`}},
+		{name: "empty array", input: `[]`},
+		{name: "ndjson", input: "{\"id\":1}\n{\"id\":2}\n\n{\"id\":3}\n", want: []string{`{"id":1}`, `{"id":2}`, `{"id":3}`}},
+		{name: "single object", input: `{"id":1}`, want: []string{`{"id":1}`}},
+		{name: "empty input", input: "  \n"},
+		{name: "malformed element", input: `[{"id":1}, {"id":}]`, want: []string{`{"id":1}`}, wantErr: "element 1: body contains badly-formed JSON", index: 1},
+		{name: "truncated array", input: `[{"id":1}`, want: []string{`{"id":1}`}, wantErr: "element 1: body contains badly-formed JSON", index: 1},
+		{name: "malformed line", input: "{\"id\":1}\n{\"id\" 2}\n", want: []string{`{"id":1}`}, wantErr: "element 1: body contains badly-formed JSON (at position", index: 1},
+		{name: "trailing data", input: `[1] [2]`, want: []string{`1`}, wantErr: "body must only contain a single JSON value", index: -1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got []string
+			err := DecodeStream(strings.NewReader(tt.input), func(raw json.RawMessage) error {
+				got = append(got, string(raw))
+				return nil
+			})
+
+			if tt.wantErr == "" && err != nil {
+				t.Fatalf("Expected no error, got %v", err)
+			}
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("Expected error containing %q, got %v", tt.wantErr, err)
+				}
+				var elemErr *ElementError
+				if tt.index >= 0 && (!errors.As(err, &elemErr) || elemErr.Index != tt.index) {
+					t.Errorf("Expected ElementError for index %d, got %#v", tt.index, err)
+				}
+			}
+
+			if len(got) != len(tt.want) {
+				t.Fatalf("Expected %d elements, got %d: %v", len(tt.want), len(got), got)
+			}
+			for i := range tt.want {
+				if got[i] != tt.want[i] {
+					t.Errorf("Element %d: expected %s, got %s", i, tt.want[i], got[i])
+				}
+			}
+		})
+	}
+}
+
+func TestDecodeStream_CallbackError(t *testing.T) {
+	errStop := errors.New("stop")
+	calls := 0
+	err := DecodeStream(strings.NewReader(`[1,2,3]`), func(raw json.RawMessage) error {
+		calls++
+		if calls == 2 {
+			return errStop
+		}
+		return nil
+	})
+
+	if !errors.Is(err, errStop) {
+		t.Errorf("Expected callback error to be wrapped, got %v", err)
+	}
+	if err == nil || err.Error() != "element 1: stop" {
+		t.Errorf("Expected error %q, got %v", "element 1: stop", err)
+	}
+	if calls != 2 {
+		t.Errorf("Expected stream to stop after 2 calls, got %d", calls)
+	}
+}
+
+func TestDecodeStream_ElementDecode(t *testing.T) {
+	var names []string
+	err := DecodeStream(strings.NewReader(`[{"name":"a"},{"name":"b","extra":true}]`), func(raw json.RawMessage) error {
+		var v TestStruct
+		if err := Decode(bytes.NewReader(raw), &v); err != nil {
+			return err
+		}
+		names = append(names, v.Name)
+		return nil
+	})
+
+	want := `element 1: body contains unknown field "extra"`
+	if err == nil || err.Error() != want {
+		t.Errorf("Expected error %q, got %v", want, err)
+	}
+	if len(names) != 1 || names[0] != "a" {
+		t.Errorf("Expected first element to be decoded, got %v", names)
+	}
+}
+
+func TestDecodeStream_MaxBytes(t *testing.T) {
+	input := "[" + strings.Repeat(`{"id":1},`, 100) + `{"id":1}]`
+	err := DecodeStream(strings.NewReader(input), func(json.RawMessage) error { return nil }, WithMaxBytes(64))
+	if !errors.Is(err, ErrBodyTooLarge) {
+		t.Errorf("Expected ErrBodyTooLarge, got %v", err)
+	}
+}
@@ -0,0 +1,231 @@
+package logger
+
+import (
+	"bytes"
+	"fmt"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"go.uber.org/zap/zapcore"
+)
+
+// Async buffer defaults
+const (
+	// DefaultAsyncBufferSize is the number of entries queued when no size is given
+	DefaultAsyncBufferSize = 8192
+	// DefaultAsyncFlushInterval is how often buffered output is written when
+	// no interval is given
+	DefaultAsyncFlushInterval = time.Second
+)
+
+// asyncBatchSize is how much output is gathered before it is written early
+const asyncBatchSize = 256 << 10
+
+// OverflowPolicy decides what happens to entries logged while the async
+// buffer is full
+type OverflowPolicy int
+
+const (
+	// OverflowBlock makes the logging goroutine wait for room in the buffer
+	OverflowBlock OverflowPolicy = iota
+	// OverflowDrop discards the entry; the number dropped is reported on the
+	// error output at the next flush
+	OverflowDrop
+)
+
+// AsyncConfig configures asynchronous, batched writing of log output
+type AsyncConfig struct {
+	// BufferSize is the number of entries that can wait to be written
+	BufferSize int
+	// FlushInterval is the longest an entry waits before it is written
+	FlushInterval time.Duration
+	// Overflow decides what happens when the buffer is full
+	Overflow OverflowPolicy
+}
+
+// WithAsyncBuffer writes log output from a background goroutine, in batches,
+// instead of on the logging goroutine. Up to size entries are queued and
+// output is written at least every flushInterval; zero values use the
+// defaults. Call Sync before the process exits to write what is queued, or
+// Close to also stop the background goroutine.
+func WithAsyncBuffer(size int, flushInterval time.Duration) Option {
+	return func(cfg *Config) {
+		if cfg.Async == nil {
+			cfg.Async = &AsyncConfig{}
+		}
+		cfg.Async.BufferSize = size
+		cfg.Async.FlushInterval = flushInterval
+	}
+}
+
+// WithAsyncOverflowPolicy sets what happens when the async buffer is full.
+// It enables the async buffer with default settings if needed.
+func WithAsyncOverflowPolicy(policy OverflowPolicy) Option {
+	return func(cfg *Config) {
+		if cfg.Async == nil {
+			cfg.Async = &AsyncConfig{}
+		}
+		cfg.Async.Overflow = policy
+	}
+}
+
+// asyncWriter is a zapcore.WriteSyncer that hands writes to a background
+// goroutine, which writes them to out in batches
+type asyncWriter struct {
+	out      zapcore.WriteSyncer
+	errOut   zapcore.WriteSyncer
+	overflow OverflowPolicy
+
+	interval time.Duration
+	entries  chan []byte
+	syncs    chan chan error
+	dropped  atomic.Int64
+	start    sync.Once
+
+	// mu is held for reading while an entry or sync is handed to run, and
+	// for writing to set closed, so nothing is queued after run has stopped
+	mu        sync.RWMutex
+	closed    bool
+	stop      chan struct{}
+	done      chan struct{}
+	closeOnce sync.Once
+	closeErr  error
+}
+
+// newAsyncWriter creates an asyncWriter; its goroutine starts on first use
+func newAsyncWriter(out, errOut zapcore.WriteSyncer, cfg AsyncConfig) *asyncWriter {
+	if cfg.BufferSize <= 0 {
+		cfg.BufferSize = DefaultAsyncBufferSize
+	}
+	if cfg.FlushInterval <= 0 {
+		cfg.FlushInterval = DefaultAsyncFlushInterval
+	}
+	return &asyncWriter{
+		out:      out,
+		errOut:   errOut,
+		overflow: cfg.Overflow,
+		interval: cfg.FlushInterval,
+		entries:  make(chan []byte, cfg.BufferSize),
+		syncs:    make(chan chan error),
+		stop:     make(chan struct{}),
+		done:     make(chan struct{}),
+	}
+}
+
+// Write queues a copy of p, which zap reuses after Write returns
+func (w *asyncWriter) Write(p []byte) (int, error) {
+	w.start.Do(func() { go w.run() })
+
+	w.mu.RLock()
+	defer w.mu.RUnlock()
+	if w.closed {
+		return w.out.Write(p)
+	}
+
+	entry := append([]byte(nil), p...)
+	if w.overflow == OverflowDrop {
+		select {
+		case w.entries <- entry:
+		default:
+			w.dropped.Add(1)
+		}
+		return len(p), nil
+	}
+
+	w.entries <- entry
+	return len(p), nil
+}
+
+// Sync writes everything queued so far and syncs the output
+func (w *asyncWriter) Sync() error {
+	w.start.Do(func() { go w.run() })
+
+	w.mu.RLock()
+	defer w.mu.RUnlock()
+	if w.closed {
+		return w.out.Sync()
+	}
+
+	done := make(chan error)
+	w.syncs <- done
+	return <-done
+}
+
+// Close writes everything queued, syncs the output and stops the background
+// goroutine. Later writes go straight to the output.
+func (w *asyncWriter) Close() error {
+	w.closeOnce.Do(func() {
+		w.start.Do(func() { go w.run() })
+
+		w.mu.Lock()
+		w.closed = true
+		w.mu.Unlock()
+
+		close(w.stop)
+		<-w.done
+	})
+	return w.closeErr
+}
+
+// run writes queued entries until Close is called
+func (w *asyncWriter) run() {
+	defer close(w.done)
+	ticker := time.NewTicker(w.interval)
+	defer ticker.Stop()
+
+	var buf bytes.Buffer
+	flush := func() error {
+		if n := w.dropped.Swap(0); n > 0 {
+			fmt.Fprintf(w.errOut, "%v logger: dropped %d log entries because the async buffer was full\n", time.Now(), n)
+			w.errOut.Sync()
+		}
+		if buf.Len() == 0 {
+			return nil
+		}
+		_, err := w.out.Write(buf.Bytes())
+		buf.Reset()
+		return err
+	}
+
+	for {
+		select {
+		case entry := <-w.entries:
+			buf.Write(entry)
+			if buf.Len() >= asyncBatchSize {
+				w.reportError(flush())
+			}
+		case <-ticker.C:
+			w.reportError(flush())
+		case done := <-w.syncs:
+			// Take everything queued before Sync was called
+			for n := len(w.entries); n > 0; n-- {
+				buf.Write(<-w.entries)
+			}
+			err := flush()
+			if syncErr := w.out.Sync(); err == nil {
+				err = syncErr
+			}
+			done <- err
+		case <-w.stop:
+			// Nothing can be queued once closed is set
+			for n := len(w.entries); n > 0; n-- {
+				buf.Write(<-w.entries)
+			}
+			w.closeErr = flush()
+			if syncErr := w.out.Sync(); w.closeErr == nil {
+				w.closeErr = syncErr
+			}
+			return
+		}
+	}
+}
+
+// reportError writes a failed background write to the error output, as zap
+// does for failed synchronous writes
+func (w *asyncWriter) reportError(err error) {
+	if err != nil {
+		fmt.Fprintf(w.errOut, "%v write error: %v\n", time.Now(), err)
+		w.errOut.Sync()
+	}
+}
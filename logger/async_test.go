@@ -0,0 +1,180 @@
+package logger
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+)
+
+func TestWithAsyncBuffer(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "app.log")
+	log, err := New(
+		WithOutputPaths([]string{path}),
+		WithAsyncBuffer(16, time.Hour),
+		WithRedactedKeys("password"),
+	)
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+
+	log.Info("order created", zap.String("order_id", "o1"), zap.String("password", "hunter2"))
+	log.Debug("below the level")
+	log.With(zap.String("component", "billing")).Warnw("retrying", "attempt", 2)
+
+	// Nothing is written until the buffer is flushed
+	time.Sleep(10 * time.Millisecond)
+	if data, _ := os.ReadFile(path); len(data) != 0 {
+		t.Fatalf("Expected no output before Sync, got %s", data)
+	}
+
+	if err := log.Sync(); err != nil {
+		t.Fatalf("Sync() error = %v", err)
+	}
+	data, _ := os.ReadFile(path)
+	lines := strings.Split(strings.TrimSpace(string(data)), "\n")
+	if len(lines) != 2 {
+		t.Fatalf("Expected 2 lines after Sync, got %d: %s", len(lines), data)
+	}
+	if !strings.Contains(lines[0], `"order_id":"o1"`) || !strings.Contains(lines[0], `"password":"[REDACTED]"`) {
+		t.Errorf("Expected fields and redaction to apply, got %s", lines[0])
+	}
+	if !strings.Contains(lines[0], `"caller":"logger/async_test.go:`) {
+		t.Errorf("Expected caller of the log call, got %s", lines[0])
+	}
+	if !strings.Contains(lines[1], `"component":"billing"`) {
+		t.Errorf("Expected child fields, got %s", lines[1])
+	}
+}
+
+func TestWithAsyncBuffer_FlushInterval(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "app.log")
+	log, err := New(WithOutputPaths([]string{path}), WithAsyncBuffer(0, 5*time.Millisecond))
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+
+	log.Info("flushed by the ticker")
+
+	deadline := time.Now().Add(2 * time.Second)
+	for {
+		data, _ := os.ReadFile(path)
+		if strings.Contains(string(data), "flushed by the ticker") {
+			break
+		}
+		if time.Now().After(deadline) {
+			t.Fatal("Expected output to be flushed on the interval")
+		}
+		time.Sleep(5 * time.Millisecond)
+	}
+}
+
+func TestAsyncWriter_Overflow(t *testing.T) {
+	tests := []struct {
+		name    string
+		policy  OverflowPolicy
+		writes  int
+		want    int
+		dropped bool
+	}{
+		{name: "drop", policy: OverflowDrop, writes: 3, want: 2, dropped: true},
+		{name: "block", policy: OverflowBlock, writes: 2, want: 2},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var out, errOut bytes.Buffer
+			w := newAsyncWriter(zapcore.AddSync(&out), zapcore.AddSync(&errOut),
+				AsyncConfig{BufferSize: 2, FlushInterval: time.Hour, Overflow: tt.policy})
+
+			// Hold back the background goroutine so the buffer fills up
+			w.start.Do(func() {})
+			for i := 0; i < tt.writes; i++ {
+				w.Write([]byte("entry\n"))
+			}
+			go w.run()
+
+			if err := w.Sync(); err != nil {
+				t.Fatalf("Sync() error = %v", err)
+			}
+			if got := strings.Count(out.String(), "entry"); got != tt.want {
+				t.Errorf("Expected %d entries written, got %d", tt.want, got)
+			}
+			if got := strings.Contains(errOut.String(), "dropped 1 log entries"); got != tt.dropped {
+				t.Errorf("Expected dropped report %v, got %q", tt.dropped, errOut.String())
+			}
+		})
+	}
+}
+
+func TestAsyncWriter_Close(t *testing.T) {
+	var out, errOut bytes.Buffer
+	w := newAsyncWriter(zapcore.AddSync(&out), zapcore.AddSync(&errOut),
+		AsyncConfig{BufferSize: 16, FlushInterval: time.Hour})
+
+	w.Write([]byte("queued\n"))
+	if err := w.Close(); err != nil {
+		t.Fatalf("Close() error = %v", err)
+	}
+
+	select {
+	case <-w.done:
+	case <-time.After(time.Second):
+		t.Fatal("Expected the background goroutine to exit")
+	}
+	if !strings.Contains(out.String(), "queued") {
+		t.Errorf("Expected queued entries to be flushed on Close, got %q", out.String())
+	}
+
+	// Writes after Close go straight to the output
+	w.Write([]byte("after close\n"))
+	if err := w.Sync(); err != nil {
+		t.Errorf("Sync() after Close error = %v", err)
+	}
+	if !strings.Contains(out.String(), "after close") {
+		t.Errorf("Expected writes after Close to be written, got %q", out.String())
+	}
+	if err := w.Close(); err != nil {
+		t.Errorf("Expected a second Close to succeed, got %v", err)
+	}
+}
+
+func TestLogger_Close(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "app.log")
+	log, err := New(WithOutputPaths([]string{path}), WithAsyncBuffer(16, time.Hour))
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+
+	log.Named("billing").Info("before close")
+	if err := log.With(zap.String("k", "v")).Close(); err != nil {
+		t.Fatalf("Close() error = %v", err)
+	}
+	log.Info("after close")
+
+	data, _ := os.ReadFile(path)
+	if !strings.Contains(string(data), "before close") || !strings.Contains(string(data), "after close") {
+		t.Errorf("Expected both entries to be written, got %s", data)
+	}
+
+	// Loggers without an async buffer are only synced
+	if err := NewNopLogger().Close(); err != nil {
+		t.Errorf("Expected Close of a nop logger to succeed, got %v", err)
+	}
+}
+
+func TestWithAsyncOverflowPolicy(t *testing.T) {
+	cfg := Config{}
+	WithAsyncOverflowPolicy(OverflowDrop)(&cfg)
+	WithAsyncBuffer(100, time.Second)(&cfg)
+
+	want := AsyncConfig{BufferSize: 100, FlushInterval: time.Second, Overflow: OverflowDrop}
+	if cfg.Async == nil || *cfg.Async != want {
+		t.Errorf("Expected %+v, got %+v", want, cfg.Async)
+	}
+}
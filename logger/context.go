@@ -2,6 +2,8 @@ package logger
 
 import (
 	"context"
+
+	"go.uber.org/zap/zapcore"
 )
 
 // contextKey is a private type for context keys to avoid collisions
@@ -15,13 +17,32 @@ func NewContext(ctx context.Context, logger *Logger) context.Context {
 	return context.WithValue(ctx, loggerKey, logger)
 }
 
-// WithContext returns the logger associated with the context, or a default logger if none exists
+// ContextWithLogger creates a new context with the provided logger.
+// It is equivalent to NewContext.
+func ContextWithLogger(ctx context.Context, logger *Logger) context.Context {
+	return NewContext(ctx, logger)
+}
+
+// AppendFields returns a new context whose logger is the context's logger,
+// or the global logger if none exists, with fields added. Middlewares can
+// call it in turn to enrich the request logger without knowing who stored it.
+func AppendFields(ctx context.Context, fields ...zapcore.Field) context.Context {
+	if len(fields) == 0 {
+		return ctx
+	}
+	return NewContext(ctx, fromContext(ctx).With(fields...))
+}
+
+// WithContext returns the logger associated with the context, or the global logger if none exists.
+// If the context carries an active trace span, trace_id and span_id fields are attached.
 func WithContext(ctx context.Context) *Logger {
+	return fromContext(ctx).WithTrace(ctx)
+}
+
+// fromContext returns the logger stored in ctx, or the global logger
+func fromContext(ctx context.Context) *Logger {
 	if logger, ok := ctx.Value(loggerKey).(*Logger); ok {
 		return logger
 	}
-	
-	// Return a default logger
-	logger, _ := New()
-	return logger
-}
\ No newline at end of file
+	return L()
+}
@@ -34,6 +34,48 @@ Initialize the global logger:
 	    panic(err)
 	}
 
+Until Init is called the global logger uses the default configuration: info
+level JSON to stdout. L returns the global logger itself, for example to pass
+it to code that takes a *Logger, and WithContext falls back to it when the
+context carries no logger. Replace installs an existing logger and returns a
+function that restores the previous one:
+
+	restore := logger.Replace(logger.NewNopLogger())
+	defer restore()
+
+# Configuration from the Environment
+
+FromEnv builds a logger from the standard variables, so every main.go does
+not repeat the same level and format wiring:
+
+	LOG_LEVEL     debug, info, warn or error
+	LOG_FORMAT    json, console, pretty or gcp
+	SERVICE_NAME  added to every entry as "service"
+	ENV           added to every entry as "env"
+
+	log, err := logger.FromEnv()
+	if err != nil {
+	    panic(err)
+	}
+	logger.Replace(log)
+
+When ENV is development, dev or local, the level defaults to debug and the
+format to console; otherwise they default to info and json. Options passed to
+FromEnv override the environment.
+
+EnvConfig holds the same settings with mapstructure tags, so it can be
+embedded in a struct loaded by the config package and passed to
+NewFromConfigStruct:
+
+	type AppConfig struct {
+	    logger.EnvConfig `mapstructure:",squash"`
+	    Port             int `mapstructure:"PORT"`
+	}
+
+	cfg, err := config.Load[AppConfig]()
+	...
+	log, err := logger.NewFromConfigStruct(cfg.EnvConfig)
+
 # Instance-Based Logger Creation
 
 Create multiple logger instances:
@@ -128,6 +170,59 @@ Use context to pass loggers:
 	    log.Info("Processing request")
 	}
 
+AppendFields adds fields to whichever logger the context carries, falling
+back to the global logger, so middlewares can enrich it in turn:
+
+	ctx = logger.AppendFields(ctx, zap.String("request_id", requestID))
+	ctx = logger.AppendFields(ctx, zap.String("user_id", user.ID))
+	ctx = logger.AppendFields(ctx, zap.String("tenant", tenant))
+
+# Trace Correlation
+
+WithContext attaches trace_id and span_id fields when the context carries an
+active span, and (*Logger).WithTrace does the same for any logger. The package
+has no tracing dependency; register an extractor once at startup to read
+OpenTelemetry spans:
+
+	logger.RegisterTraceExtractor(func(ctx context.Context) (logger.SpanContext, bool) {
+	    sc := trace.SpanContextFromContext(ctx)
+	    return logger.SpanContext{
+	        TraceID: sc.TraceID().String(),
+	        SpanID:  sc.SpanID().String(),
+	        Sampled: sc.IsSampled(),
+	    }, sc.IsValid()
+	})
+
+Without a tracing library, spans can be stored directly, for example from an
+incoming W3C traceparent header:
+
+	if sc, ok := logger.ParseTraceparent(r.Header.Get("traceparent")); ok {
+	    ctx = logger.ContextWithSpan(ctx, sc)
+	}
+
+# Google Cloud Logging
+
+WithGCPEncoding writes JSON that Cloud Logging parses natively: the level
+becomes severity (DEBUG, INFO, WARNING, ERROR, ...), msg becomes message, and
+trace_id and span_id become logging.googleapis.com/trace and
+logging.googleapis.com/spanId:
+
+	log, err := logger.New(
+	    logger.WithGCPEncoding(),
+	    logger.WithGCPProjectID("my-project"), // defaults to $GOOGLE_CLOUD_PROJECT
+	)
+
+Access logs can attach an httpRequest object so requests show up in the
+Logs Explorer request view:
+
+	log.Info("request completed", logger.HTTPRequestField(logger.HTTPRequest{
+	    Method:       r.Method,
+	    URL:          r.URL.String(),
+	    Status:       ww.Status(),
+	    ResponseSize: int64(ww.BytesWritten()),
+	    Latency:      time.Since(start),
+	}))
+
 # Structured Fields
 
 Create child loggers with additional fields:
@@ -146,9 +241,146 @@ Create child loggers with additional fields:
 	})
 	myLoggerWithFields.Info("User logged in")
 
+# Error Chains and Stack Traces
+
+Err records an error as an object with its message, the messages of the
+errors it wraps, and the stack trace captured by errutils.New,
+errutils.Errorf or errutils.WithStack:
+
+	if err := row.Scan(&order.ID); err != nil {
+	    return errutils.WithStack(err)
+	}
+
+	log.Error("Loading order failed", logger.Err(err))
+	// {"msg":"Loading order failed","error":{"message":"load order: sql: no rows in result set",
+	//   "chain":["load order: sql: no rows in result set","sql: no rows in result set"],
+	//   "stack":"main.loadOrder\n\t/app/orders.go:42\n..."}}
+
+ErrorWithStack logs at error level with Err, and adds the caller's stack
+when the error has not captured one:
+
+	log.ErrorWithStack("Loading order failed", err, zap.String("order_id", id))
+
+# Redaction
+
+WithRedactedKeys replaces sensitive values with "[REDACTED]" in every entry,
+whether they are logged as fields, sugared key-value pairs, initial fields
+or keys of a logged map:
+
+	log, err := logger.New(
+	    logger.WithServiceName("checkout"),
+	    logger.WithRedactedKeys("password", "authorization", "ssn", "card_number"),
+	)
+
+	log.Infow("Signup", "email", email, "password", password)
+	// {"msg":"Signup","email":"ada@example.com","password":"[REDACTED]"}
+
+Keys match case-insensitively, and also as a suffix after "_", "." or "-",
+so "authorization" also covers "req_header_Authorization".
+
+# Hooks and Additional Sinks
+
+WithCoreWrapper wraps the zap core the logger writes to, so entries can be
+teed to an error tracker or any other zapcore.Core without giving up the
+rest of the package's configuration:
+
+	log, err := logger.New(
+	    logger.WithServiceName("checkout"),
+	    logger.WithRedactedKeys("password"),
+	    logger.WithCoreWrapper(func(core zapcore.Core) zapcore.Core {
+	        return zapcore.NewTee(core, sentryCore) // sentryCore only enables ErrorLevel
+	    }),
+	)
+
+Each core in the tee keeps its own level, and redaction is applied before
+entries reach any of them. For simple notifications WithHooks calls a
+function with every entry, without its fields:
+
+	logger.WithHooks(func(e zapcore.Entry) error {
+	    if e.Level >= zapcore.ErrorLevel {
+	        errorCount.Add(1)
+	    }
+	    return nil
+	})
+
+# Asynchronous Output
+
+WithAsyncBuffer moves writing off the logging goroutine. Entries are queued
+and written in batches by a background goroutine, at least once per flush
+interval:
+
+	log, err := logger.New(
+	    logger.WithAsyncBuffer(8192, time.Second),
+	    logger.WithAsyncOverflowPolicy(logger.OverflowDrop),
+	)
+	defer log.Close()
+
+When the queue is full the default OverflowBlock makes callers wait, so no
+entries are lost. OverflowDrop discards them instead and reports how many
+were dropped on stderr. Sync writes everything queued so far, and Fatal
+entries are always flushed before the process exits, but entries still
+queued when the process ends any other way are lost. Close flushes the queue
+and stops the background goroutine; later entries are written directly.
+
+# Pretty Console Output
+
+WithPrettyConsole replaces JSON with one readable line per entry for local
+development, with colored levels, a short caller and fields inline:
+
+	log, err := logger.New(logger.WithPrettyConsole())
+
+	// 12:04:05.123 INFO  api/server.go:42  Server started port=8080 env=dev
+
+WithPrettyConsoleConfig sets the time format, disables colors, or writes
+error fields and stack traces on indented lines below the entry with
+MultilineErrors. Colors are also disabled when NO_COLOR is set. GCP encoding
+takes precedence over the pretty console.
+
+# Runtime Level Changes
+
+The level of a logger can be changed while the service runs. The change
+applies to the logger and every child created from it with With, WithFields
+or WithContext:
+
+	log.SetLevel(logger.DebugLevel)
+
+LevelHandler exposes the level over HTTP so a production service can be
+switched into debug mode without a restart. Mount it on an internal route:
+
+	r.Handle("/debug/loglevel", log.LevelHandler())
+
+	curl localhost:8080/debug/loglevel
+	{"level":"info"}
+	curl -X PUT -d '{"level":"debug"}' localhost:8080/debug/loglevel
+	{"level":"debug"}
+
+# Named Loggers
+
+Named creates a child logger whose entries carry a "logger" field, so a
+library or subsystem can scope its logs without a logger of its own. Names
+of nested children are joined with dots:
+
+	log.Named("db").Named("migrations").Info("Applied migration")
+	// {"level":"info","logger":"db.migrations","msg":"Applied migration",...}
+
+WithNamedLevels sets a minimum level per name. A name also applies to its
+children, and the longest matching name wins:
+
+	log, err := logger.New(
+	    logger.WithLevel("info"),
+	    logger.WithNamedLevels(map[string]string{
+	        "db":      "debug",
+	        "db.pool": "warn",
+	    }),
+	)
+
+Loggers without a matching name use the logger's level, which SetLevel
+still changes at runtime.
+
 # Cleanup
 
-Flush any buffered logger entries before exit:
+Flush any buffered logger entries before exit. This matters most for
+loggers using WithAsyncBuffer:
 
 	func main() {
 	    // Initialize and use logger...
@@ -201,5 +433,16 @@ When creating a library that might be used by applications with their own logger
 	func (l *MyLibrary) DoSomething() {
 	    l.logger.Info("Doing something")
 	}
+
+# Testing Log Output
+
+The logtest subpackage provides a logger that records entries, for tests of
+code that accepts a *logger.Logger:
+
+	log, rec := logtest.NewTestLogger(t)
+	lib := NewLibrary(WithLogger(log))
+
+	lib.DoSomething()
+	rec.AssertLogged(zapcore.InfoLevel, "Doing something", nil)
 */
 package logger
\ No newline at end of file
@@ -0,0 +1,124 @@
+package logger
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+)
+
+// ErrInvalidFormat is returned by NewFromConfigStruct and FromEnv for an
+// unknown LOG_FORMAT
+var ErrInvalidFormat = errors.New("logger: invalid log format")
+
+// Log formats accepted in LOG_FORMAT
+const (
+	// FormatJSON writes JSON, the default outside development
+	FormatJSON = "json"
+	// FormatConsole writes zap's development console output, the default in
+	// development
+	FormatConsole = "console"
+	// FormatPretty writes colorized console output, see WithPrettyConsole
+	FormatPretty = "pretty"
+	// FormatGCP writes JSON for Google Cloud Logging, see WithGCPEncoding
+	FormatGCP = "gcp"
+)
+
+// EnvConfig holds the standard logging settings of a service. FromEnv reads
+// it from the environment, and its mapstructure tags let it be embedded in a
+// struct loaded with the config package:
+//
+//	type AppConfig struct {
+//	    logger.EnvConfig `mapstructure:",squash"`
+//	    Port int `mapstructure:"PORT"`
+//	}
+type EnvConfig struct {
+	// Level is the minimum level; empty means debug in development and
+	// info elsewhere
+	Level string `mapstructure:"LOG_LEVEL"`
+	// Format is json, console, pretty or gcp; empty means console in
+	// development and json elsewhere
+	Format string `mapstructure:"LOG_FORMAT"`
+	// ServiceName is added to every entry as the service field
+	ServiceName string `mapstructure:"SERVICE_NAME"`
+	// Environment, such as "production", is added to every entry as the env
+	// field. "development", "dev" and "local" select development mode.
+	Environment string `mapstructure:"ENV"`
+}
+
+// FromEnv builds a logger from the LOG_LEVEL, LOG_FORMAT, SERVICE_NAME and
+// ENV environment variables, as NewFromConfigStruct does. Options are
+// applied last, so they override the environment.
+func FromEnv(options ...Option) (*Logger, error) {
+	return NewFromConfigStruct(EnvConfig{
+		Level:       os.Getenv("LOG_LEVEL"),
+		Format:      os.Getenv("LOG_FORMAT"),
+		ServiceName: os.Getenv("SERVICE_NAME"),
+		Environment: os.Getenv("ENV"),
+	}, options...)
+}
+
+// NewFromConfigStruct builds a logger from the standard settings in cfg.
+// Development environments default to debug level and console output;
+// others default to info level and JSON. Options are applied last, so they
+// override cfg.
+func NewFromConfigStruct(cfg EnvConfig, options ...Option) (*Logger, error) {
+	logCfg, err := cfg.config()
+	if err != nil {
+		return nil, err
+	}
+	for _, option := range options {
+		option(&logCfg)
+	}
+	return NewLogger(logCfg)
+}
+
+// config converts the settings to a Config
+func (e EnvConfig) config() (Config, error) {
+	dev := isDevelopment(e.Environment)
+
+	cfg := Config{
+		Level:       e.Level,
+		OutputPaths: []string{"stdout"},
+		ServiceName: e.ServiceName,
+	}
+	if cfg.Level == "" {
+		cfg.Level = "info"
+		if dev {
+			cfg.Level = "debug"
+		}
+	}
+	if e.Environment != "" {
+		cfg.InitialFields = map[string]interface{}{"env": e.Environment}
+	}
+
+	format := strings.ToLower(strings.TrimSpace(e.Format))
+	if format == "" {
+		format = FormatJSON
+		if dev {
+			format = FormatConsole
+		}
+	}
+	switch format {
+	case FormatJSON:
+	case FormatConsole:
+		cfg.Development = true
+	case FormatPretty:
+		cfg.Pretty = &PrettyConfig{}
+	case FormatGCP:
+		cfg.GCPEncoding = true
+	default:
+		return Config{}, fmt.Errorf("%w: %q", ErrInvalidFormat, e.Format)
+	}
+
+	return cfg, nil
+}
+
+// isDevelopment reports whether env names a development environment
+func isDevelopment(env string) bool {
+	switch strings.ToLower(strings.TrimSpace(env)) {
+	case "development", "dev", "local":
+		return true
+	}
+	return false
+}
@@ -0,0 +1,108 @@
+package logger
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+)
+
+func TestEnvConfig_Config(t *testing.T) {
+	tests := []struct {
+		name string
+		env  EnvConfig
+		want Config
+	}{
+		{
+			name: "defaults",
+			env:  EnvConfig{},
+			want: Config{Level: "info", OutputPaths: []string{"stdout"}},
+		},
+		{
+			name: "development",
+			env:  EnvConfig{Environment: "development", ServiceName: "orders"},
+			want: Config{
+				Level:         "debug",
+				Development:   true,
+				OutputPaths:   []string{"stdout"},
+				ServiceName:   "orders",
+				InitialFields: map[string]interface{}{"env": "development"},
+			},
+		},
+		{
+			name: "production with level",
+			env:  EnvConfig{Environment: "production", Level: "warn"},
+			want: Config{
+				Level:         "warn",
+				OutputPaths:   []string{"stdout"},
+				InitialFields: map[string]interface{}{"env": "production"},
+			},
+		},
+		{
+			name: "local with json",
+			env:  EnvConfig{Environment: "local", Format: "JSON"},
+			want: Config{
+				Level:         "debug",
+				OutputPaths:   []string{"stdout"},
+				InitialFields: map[string]interface{}{"env": "local"},
+			},
+		},
+		{
+			name: "pretty",
+			env:  EnvConfig{Format: FormatPretty},
+			want: Config{Level: "info", OutputPaths: []string{"stdout"}, Pretty: &PrettyConfig{}},
+		},
+		{
+			name: "gcp",
+			env:  EnvConfig{Format: FormatGCP},
+			want: Config{Level: "info", OutputPaths: []string{"stdout"}, GCPEncoding: true},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := tt.env.config()
+			if err != nil {
+				t.Fatalf("config() error = %v", err)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Expected %+v, got %+v", tt.want, got)
+			}
+		})
+	}
+}
+
+func TestEnvConfig_InvalidFormat(t *testing.T) {
+	_, err := NewFromConfigStruct(EnvConfig{Format: "xml"})
+	if !errors.Is(err, ErrInvalidFormat) {
+		t.Errorf("Expected ErrInvalidFormat, got %v", err)
+	}
+}
+
+func TestFromEnv(t *testing.T) {
+	t.Setenv("LOG_LEVEL", "warn")
+	t.Setenv("LOG_FORMAT", "json")
+	t.Setenv("SERVICE_NAME", "orders")
+	t.Setenv("ENV", "staging")
+
+	log, err := FromEnv()
+	if err != nil {
+		t.Fatalf("FromEnv() error = %v", err)
+	}
+	if log.Level() != WarnLevel {
+		t.Errorf("Expected level warn, got %v", log.Level())
+	}
+
+	// Options override the environment
+	log, err = FromEnv(WithLevel("error"))
+	if err != nil {
+		t.Fatalf("FromEnv() error = %v", err)
+	}
+	if log.Level() != ErrorLevel {
+		t.Errorf("Expected level error, got %v", log.Level())
+	}
+
+	t.Setenv("LOG_LEVEL", "loud")
+	if _, err := FromEnv(); err == nil {
+		t.Error("Expected error for invalid LOG_LEVEL, got nil")
+	}
+}
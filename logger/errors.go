@@ -0,0 +1,65 @@
+package logger
+
+import (
+	"github.com/StairSupplies/go-core/errutils"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+)
+
+// ErrorKey is the field name used by Err
+const ErrorKey = "error"
+
+// Err returns a field that records err as an object under "error", with its
+// message, the messages of the errors it wraps under "chain", and the stack
+// trace recorded by errutils.New, errutils.Errorf or errutils.WithStack under
+// "stack". A nil error adds no field.
+//
+//	{"error": {"message": "query orders: connection refused",
+//	  "chain": ["query orders: connection refused", "connection refused"],
+//	  "stack": "main.loadOrders\n\t/app/orders.go:42\n..."}}
+func Err(err error) zap.Field {
+	if err == nil {
+		return zap.Skip()
+	}
+	return zap.Object(ErrorKey, errorObject{err})
+}
+
+// errorObject encodes an error for Err
+type errorObject struct {
+	err error
+}
+
+// MarshalLogObject implements zapcore.ObjectMarshaler
+func (e errorObject) MarshalLogObject(enc zapcore.ObjectEncoder) error {
+	enc.AddString("message", e.err.Error())
+	if chain := errutils.Chain(e.err); len(chain) > 1 {
+		enc.AddArray("chain", zapcore.ArrayMarshalerFunc(func(arr zapcore.ArrayEncoder) error {
+			for _, err := range chain {
+				arr.AppendString(err.Error())
+			}
+			return nil
+		}))
+	}
+	if stack := errutils.StackTrace(e.err); stack != "" {
+		enc.AddString("stack", stack)
+	}
+	return nil
+}
+
+// ErrorWithStack logs msg at error level with err recorded by Err. If err
+// carries no stack trace, the stack of the caller is logged instead, so the
+// entry always says where the failure was handled.
+func (l *Logger) ErrorWithStack(msg string, err error, fields ...zapcore.Field) {
+	l.logger.Error(msg, errorWithStackFields(err, fields, 2)...)
+}
+
+// errorWithStackFields prepends Err(err) to fields, and the current stack if
+// err has none, leaving out skip frames above errorWithStackFields' caller
+func errorWithStackFields(err error, fields []zapcore.Field, skip int) []zapcore.Field {
+	all := make([]zapcore.Field, 0, len(fields)+2)
+	all = append(all, Err(err))
+	if !errutils.HasStack(err) {
+		all = append(all, zap.StackSkip("stacktrace", skip))
+	}
+	return append(all, fields...)
+}
@@ -0,0 +1,114 @@
+package logger
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/StairSupplies/go-core/errutils"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+	"go.uber.org/zap/zaptest/observer"
+)
+
+func TestErr(t *testing.T) {
+	logger, observed := captureOutput(t)
+
+	err := fmt.Errorf("query orders: %w", errutils.WithStack(errors.New("connection refused")))
+	logger.Error("Failed", Err(err))
+
+	fields := observed.All()[0].ContextMap()
+	obj, ok := fields[ErrorKey].(map[string]interface{})
+	if !ok {
+		t.Fatalf("Expected error object, got %#v", fields[ErrorKey])
+	}
+	if obj["message"] != "query orders: connection refused" {
+		t.Errorf("Expected message, got %v", obj["message"])
+	}
+	chain, _ := obj["chain"].([]interface{})
+	if len(chain) != 2 || chain[1] != "connection refused" {
+		t.Errorf("Expected 2 chain entries, got %v", obj["chain"])
+	}
+	if stack, _ := obj["stack"].(string); !strings.Contains(stack, "logger.TestErr") {
+		t.Errorf("Expected stack from the test, got %q", stack)
+	}
+}
+
+func TestErr_Plain(t *testing.T) {
+	logger, observed := captureOutput(t)
+
+	logger.Error("Failed", Err(io.EOF), Err(nil))
+
+	fields := observed.All()[0].ContextMap()
+	if len(fields) != 1 {
+		t.Errorf("Expected only the error field, got %v", fields)
+	}
+	obj := fields[ErrorKey].(map[string]interface{})
+	if obj["message"] != "EOF" {
+		t.Errorf("Expected message EOF, got %v", obj["message"])
+	}
+	if _, ok := obj["chain"]; ok {
+		t.Errorf("Expected no chain for an unwrapped error, got %v", obj["chain"])
+	}
+	if _, ok := obj["stack"]; ok {
+		t.Errorf("Expected no stack, got %v", obj["stack"])
+	}
+}
+
+func TestErrorWithStack(t *testing.T) {
+	core, observed := observer.New(zapcore.DebugLevel)
+	logger := NewFromZap(zap.New(core, zap.AddCaller(), zap.AddCallerSkip(1)))
+
+	logger.ErrorWithStack("Failed", io.EOF, zap.String("order_id", "o1"))
+	logger.ErrorWithStack("Failed", errutils.WithStack(io.EOF))
+
+	logs := observed.All()
+	if len(logs) != 2 {
+		t.Fatalf("Expected 2 log entries, got %d", len(logs))
+	}
+	for _, entry := range logs {
+		if entry.Level != zapcore.ErrorLevel {
+			t.Errorf("Expected error level, got %v", entry.Level)
+		}
+		if file := filepath.Base(entry.Caller.File); file != "errors_test.go" {
+			t.Errorf("Expected caller errors_test.go, got %s", file)
+		}
+	}
+
+	first := logs[0].ContextMap()
+	if first["order_id"] != "o1" {
+		t.Errorf("Expected order_id field, got %v", first)
+	}
+	stack, _ := first["stacktrace"].(string)
+	if !strings.HasPrefix(stack, "github.com/StairSupplies/go-core/logger.TestErrorWithStack") {
+		t.Errorf("Expected stack to start at the caller, got:\n%s", stack)
+	}
+
+	second := logs[1].ContextMap()
+	if _, ok := second["stacktrace"]; ok {
+		t.Error("Expected no extra stack for an error that carries one")
+	}
+	if obj := second[ErrorKey].(map[string]interface{}); obj["stack"] == nil {
+		t.Error("Expected the error's own stack")
+	}
+}
+
+func TestGlobalErrorWithStack(t *testing.T) {
+	core, observed := observer.New(zapcore.DebugLevel)
+	restore := Replace(NewFromZap(zap.New(core, zap.AddCaller(), zap.AddCallerSkip(1))))
+	defer restore()
+
+	ErrorWithStack("Failed", io.EOF)
+
+	entry := observed.All()[0]
+	if file := filepath.Base(entry.Caller.File); file != "errors_test.go" {
+		t.Errorf("Expected caller errors_test.go, got %s", file)
+	}
+	stack, _ := entry.ContextMap()["stacktrace"].(string)
+	if !strings.HasPrefix(stack, "github.com/StairSupplies/go-core/logger.TestGlobalErrorWithStack") {
+		t.Errorf("Expected stack to start at the caller, got:\n%s", stack)
+	}
+}
@@ -3,10 +3,13 @@ package logger_test
 import (
 	"context"
 	"fmt"
+	"os"
 	"time"
 
+	"github.com/StairSupplies/go-core/errutils"
 	"github.com/StairSupplies/go-core/logger"
 	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
 )
 
 func Example() {
@@ -26,6 +29,23 @@ func Example() {
 	// No Output: Log output is not captured in examples
 }
 
+func ExampleInit() {
+	if err := logger.Init(logger.Config{
+		Level:       "info",
+		ServiceName: "example-service",
+	}); err != nil {
+		fmt.Printf("Error initializing logger: %v\n", err)
+		return
+	}
+	defer logger.Sync()
+
+	// Package-level functions log through the global logger
+	logger.Info("Service started", zap.String("version", "1.0.0"))
+	logger.Debugf("Not visible at %s level", logger.L().Level())
+
+	// No Output: Log output is not captured in examples
+}
+
 func ExampleNew() {
 	// Create a logger with custom options
 	log, err := logger.New(
@@ -159,6 +179,20 @@ func ProcessRequest(ctx context.Context) {
 	log.Info("Processing request")
 }
 
+func ExampleAppendFields() {
+	ctx := logger.ContextWithLogger(context.Background(), logger.NewNopLogger())
+
+	// Each middleware adds what it knows to the request logger
+	ctx = logger.AppendFields(ctx, zap.String("request_id", "req-abc123"))
+	ctx = logger.AppendFields(ctx, zap.String("user_id", "user-456"))
+	ctx = logger.AppendFields(ctx, zap.String("tenant", "acme"))
+
+	// Entries carry request_id, user_id and tenant
+	logger.WithContext(ctx).Info("Order created")
+
+	// No Output: Log output is not captured in examples
+}
+
 func ExampleWithContext() {
 	// Create a context with no logger
 	ctx := context.Background()
@@ -202,4 +236,166 @@ func ExampleLogger_Debug() {
 	)
 
 	// No Output: Log output is not captured in examples
-}
\ No newline at end of file
+}
+
+func ExampleLogger_SetLevel() {
+	log, err := logger.New(logger.WithLevel("info"))
+	if err != nil {
+		fmt.Printf("Failed to create logger: %v\n", err)
+		return
+	}
+
+	worker := log.WithFields(map[string]interface{}{"component": "worker"})
+
+	// Children share the parent's level
+	log.SetLevel(logger.DebugLevel)
+	fmt.Println(worker.Level())
+
+	// Output: debug
+}
+
+func ExampleLogger_Named() {
+	// sink prints just the logger name and message
+	sink := zapcore.NewCore(
+		zapcore.NewJSONEncoder(zapcore.EncoderConfig{MessageKey: "msg", NameKey: "logger"}),
+		zapcore.AddSync(os.Stdout),
+		zapcore.DebugLevel,
+	)
+
+	log, err := logger.New(
+		logger.WithOutputPaths([]string{os.DevNull}),
+		logger.WithLevel("info"),
+		logger.WithNamedLevels(map[string]string{"db": "debug"}),
+		logger.WithCoreWrapper(func(core zapcore.Core) zapcore.Core {
+			return zapcore.NewTee(core, sink)
+		}),
+	)
+	if err != nil {
+		fmt.Printf("Failed to create logger: %v\n", err)
+		return
+	}
+
+	migrations := log.Named("db").Named("migrations")
+	migrations.Debug("Applying migration")
+	log.Named("http").Debug("Request received")
+
+	// Output: {"logger":"db.migrations","msg":"Applying migration"}
+}
+
+func ExampleWithRedactedKeys() {
+	log, err := logger.New(
+		logger.WithRedactedKeys("password", "authorization"),
+		logger.WithDisableCaller(true),
+	)
+	if err != nil {
+		fmt.Printf("Failed to create logger: %v\n", err)
+		return
+	}
+
+	// The password and Authorization header values are written as "[REDACTED]"
+	log.Infow("Login attempt",
+		"user", "ada",
+		"password", "hunter2",
+		"req_header_Authorization", "Bearer abc123",
+	)
+
+	// No Output: Log output is not captured in examples
+}
+
+func ExampleWithCoreWrapper() {
+	// errorSink stands in for an error tracker's core
+	errorSink := zapcore.NewCore(
+		zapcore.NewJSONEncoder(zapcore.EncoderConfig{MessageKey: "msg"}),
+		zapcore.AddSync(os.Stdout),
+		zapcore.ErrorLevel,
+	)
+
+	log, err := logger.New(
+		logger.WithOutputPaths([]string{os.DevNull}),
+		logger.WithRedactedKeys("card_number"),
+		logger.WithCoreWrapper(func(core zapcore.Core) zapcore.Core {
+			return zapcore.NewTee(core, errorSink)
+		}),
+	)
+	if err != nil {
+		fmt.Printf("Error creating logger: %v\n", err)
+		return
+	}
+
+	log.Info("Payment started")
+	log.Error("Payment failed", zap.String("card_number", "4111111111111111"))
+
+	// Output: {"msg":"Payment failed","card_number":"[REDACTED]"}
+}
+
+func ExampleWithAsyncBuffer() {
+	log, err := logger.New(
+		logger.WithServiceName("orders"),
+		logger.WithAsyncBuffer(4096, 500*time.Millisecond),
+		logger.WithAsyncOverflowPolicy(logger.OverflowDrop),
+	)
+	if err != nil {
+		fmt.Printf("Error creating logger: %v\n", err)
+		return
+	}
+	// Write whatever is still queued before exiting
+	defer log.Sync()
+
+	log.Info("Order created", zap.String("order_id", "A-1"))
+
+	// No Output: Log output is not captured in examples
+}
+
+func ExampleWithPrettyConsole() {
+	// Readable, colorized output for local development
+	log, err := logger.New(
+		logger.WithLevel("debug"),
+		logger.WithPrettyConsoleConfig(logger.PrettyConfig{MultilineErrors: true}),
+	)
+	if err != nil {
+		fmt.Printf("Error creating logger: %v\n", err)
+		return
+	}
+	defer log.Sync()
+
+	// 12:04:05.123 INFO  app/main.go:42  Server started port=8080
+	log.Info("Server started", zap.Int("port", 8080))
+
+	// No Output: Log output is not captured in examples
+}
+
+func ExampleErr() {
+	log, err := logger.New()
+	if err != nil {
+		fmt.Printf("Error creating logger: %v\n", err)
+		return
+	}
+	defer log.Sync()
+
+	_, err = os.Open("orders.csv")
+	err = fmt.Errorf("importing orders: %w", errutils.WithStack(err))
+
+	// Logs the message, the wrapped errors and the stack captured above
+	log.Error("Import failed", logger.Err(err))
+
+	// Adds the caller's stack when the error carries none
+	log.ErrorWithStack("Import failed", os.ErrNotExist)
+
+	// No Output: Log output is not captured in examples
+}
+
+func ExampleNewFromConfigStruct() {
+	log, err := logger.NewFromConfigStruct(logger.EnvConfig{
+		Environment: "production",
+		ServiceName: "orders",
+	})
+	if err != nil {
+		fmt.Printf("Failed to create logger: %v\n", err)
+		return
+	}
+
+	// Production defaults to info level JSON
+	fmt.Println(log.Level())
+
+	// Output: info
+}
@@ -0,0 +1,179 @@
+package logger
+
+import (
+	"fmt"
+	"os"
+	"time"
+
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+)
+
+// Cloud Logging special field names
+const (
+	gcpTraceKey  = "logging.googleapis.com/trace"
+	gcpSpanIDKey = "logging.googleapis.com/spanId"
+)
+
+// WithGCPEncoding writes JSON using the Google Cloud Logging special keys
+// (severity, message, logging.googleapis.com/trace) so entries are parsed
+// natively instead of landing in textPayload. The trace field is qualified
+// with the project from WithGCPProjectID or GOOGLE_CLOUD_PROJECT.
+func WithGCPEncoding() Option {
+	return func(cfg *Config) {
+		cfg.GCPEncoding = true
+	}
+}
+
+// WithGCPProjectID sets the project used to qualify trace IDs in GCP encoding
+func WithGCPProjectID(projectID string) Option {
+	return func(cfg *Config) {
+		cfg.GCPProjectID = projectID
+	}
+}
+
+// gcpEncoderConfig returns an encoder config using Cloud Logging field names
+func gcpEncoderConfig() zapcore.EncoderConfig {
+	return zapcore.EncoderConfig{
+		TimeKey:        "time",
+		LevelKey:       "severity",
+		NameKey:        "logger",
+		CallerKey:      "caller",
+		FunctionKey:    zapcore.OmitKey,
+		MessageKey:     "message",
+		StacktraceKey:  "stack_trace",
+		LineEnding:     zapcore.DefaultLineEnding,
+		EncodeLevel:    gcpLevelEncoder,
+		EncodeTime:     zapcore.RFC3339NanoTimeEncoder,
+		EncodeDuration: zapcore.SecondsDurationEncoder,
+		EncodeCaller:   zapcore.ShortCallerEncoder,
+	}
+}
+
+// gcpLevelEncoder maps zap levels to Cloud Logging severities
+func gcpLevelEncoder(l zapcore.Level, enc zapcore.PrimitiveArrayEncoder) {
+	switch l {
+	case zapcore.DebugLevel:
+		enc.AppendString("DEBUG")
+	case zapcore.InfoLevel:
+		enc.AppendString("INFO")
+	case zapcore.WarnLevel:
+		enc.AppendString("WARNING")
+	case zapcore.ErrorLevel:
+		enc.AppendString("ERROR")
+	case zapcore.DPanicLevel:
+		enc.AppendString("CRITICAL")
+	case zapcore.PanicLevel:
+		enc.AppendString("ALERT")
+	case zapcore.FatalLevel:
+		enc.AppendString("EMERGENCY")
+	default:
+		enc.AppendString("DEFAULT")
+	}
+}
+
+// gcpCore renames trace correlation fields to their Cloud Logging keys
+type gcpCore struct {
+	zapcore.Core
+	projectID string
+}
+
+// newGCPCore wraps core, falling back to GOOGLE_CLOUD_PROJECT for the project
+func newGCPCore(core zapcore.Core, projectID string) zapcore.Core {
+	if projectID == "" {
+		projectID = os.Getenv("GOOGLE_CLOUD_PROJECT")
+	}
+	return &gcpCore{Core: core, projectID: projectID}
+}
+
+func (c *gcpCore) With(fields []zapcore.Field) zapcore.Core {
+	return &gcpCore{Core: c.Core.With(c.rename(fields)), projectID: c.projectID}
+}
+
+func (c *gcpCore) Check(ent zapcore.Entry, ce *zapcore.CheckedEntry) *zapcore.CheckedEntry {
+	if c.Enabled(ent.Level) {
+		return ce.AddCore(ent, c)
+	}
+	return ce
+}
+
+func (c *gcpCore) Write(ent zapcore.Entry, fields []zapcore.Field) error {
+	return c.Core.Write(ent, c.rename(fields))
+}
+
+// rename returns fields with trace_id and span_id mapped to Cloud Logging keys
+func (c *gcpCore) rename(fields []zapcore.Field) []zapcore.Field {
+	var out []zapcore.Field
+	for i, f := range fields {
+		if f.Type != zapcore.StringType || (f.Key != TraceIDKey && f.Key != SpanIDKey) {
+			continue
+		}
+		if out == nil {
+			out = append([]zapcore.Field(nil), fields...)
+		}
+		if f.Key == SpanIDKey {
+			out[i] = zap.String(gcpSpanIDKey, f.String)
+			continue
+		}
+		trace := f.String
+		if c.projectID != "" {
+			trace = fmt.Sprintf("projects/%s/traces/%s", c.projectID, f.String)
+		}
+		out[i] = zap.String(gcpTraceKey, trace)
+	}
+	if out == nil {
+		return fields
+	}
+	return out
+}
+
+// HTTPRequest describes an HTTP request in the shape Cloud Logging expects
+// for the httpRequest field. Other backends simply see a nested object.
+type HTTPRequest struct {
+	Method       string
+	URL          string
+	Status       int
+	RequestSize  int64
+	ResponseSize int64
+	UserAgent    string
+	RemoteIP     string
+	Referer      string
+	Latency      time.Duration
+	Protocol     string
+}
+
+// MarshalLogObject implements zapcore.ObjectMarshaler
+func (r HTTPRequest) MarshalLogObject(enc zapcore.ObjectEncoder) error {
+	enc.AddString("requestMethod", r.Method)
+	enc.AddString("requestUrl", r.URL)
+	if r.Status != 0 {
+		enc.AddInt("status", r.Status)
+	}
+	if r.RequestSize > 0 {
+		enc.AddString("requestSize", fmt.Sprint(r.RequestSize))
+	}
+	if r.ResponseSize > 0 {
+		enc.AddString("responseSize", fmt.Sprint(r.ResponseSize))
+	}
+	if r.UserAgent != "" {
+		enc.AddString("userAgent", r.UserAgent)
+	}
+	if r.RemoteIP != "" {
+		enc.AddString("remoteIp", r.RemoteIP)
+	}
+	if r.Referer != "" {
+		enc.AddString("referer", r.Referer)
+	}
+	if r.Latency > 0 {
+		enc.AddString("latency", fmt.Sprintf("%.9fs", r.Latency.Seconds()))
+	}
+	if r.Protocol != "" {
+		enc.AddString("protocol", r.Protocol)
+	}
+	return nil
+}
+
+// HTTPRequestField returns the httpRequest field for an access log entry
+func HTTPRequestField(r HTTPRequest) zap.Field {
+	return zap.Object("httpRequest", r)
+}
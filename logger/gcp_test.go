@@ -0,0 +1,104 @@
+package logger
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+func readJSONLines(t *testing.T, path string) []map[string]any {
+	t.Helper()
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("Failed to read log file: %v", err)
+	}
+
+	var entries []map[string]any
+	for _, line := range strings.Split(strings.TrimSpace(string(data)), "\n") {
+		var entry map[string]any
+		if err := json.Unmarshal([]byte(line), &entry); err != nil {
+			t.Fatalf("Failed to parse log line %q: %v", line, err)
+		}
+		entries = append(entries, entry)
+	}
+	return entries
+}
+
+func TestWithGCPEncoding(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "gcp.log")
+	log, err := New(
+		WithGCPEncoding(),
+		WithGCPProjectID("stairs-prod"),
+		WithOutputPaths([]string{path}),
+		WithServiceName("orders"),
+	)
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+
+	ctx := ContextWithSpan(context.Background(), SpanContext{TraceID: "abc123", SpanID: "def456"})
+	log.WithTrace(ctx).Warn("slow query", HTTPRequestField(HTTPRequest{
+		Method:       "GET",
+		URL:          "/orders?page=2",
+		Status:       200,
+		ResponseSize: 512,
+		Latency:      1500 * time.Millisecond,
+	}))
+	log.Sync()
+
+	entry := readJSONLines(t, path)[0]
+
+	checks := map[string]any{
+		"severity":                      "WARNING",
+		"message":                       "slow query",
+		"service":                       "orders",
+		"logging.googleapis.com/trace":  "projects/stairs-prod/traces/abc123",
+		"logging.googleapis.com/spanId": "def456",
+	}
+	for k, want := range checks {
+		if entry[k] != want {
+			t.Errorf("Expected %s = %v, got %v", k, want, entry[k])
+		}
+	}
+	if _, ok := entry[TraceIDKey]; ok {
+		t.Error("Expected trace_id to be renamed")
+	}
+
+	req, ok := entry["httpRequest"].(map[string]any)
+	if !ok {
+		t.Fatalf("Expected httpRequest object, got %v", entry["httpRequest"])
+	}
+	if req["requestMethod"] != "GET" || req["status"] != float64(200) || req["responseSize"] != "512" || req["latency"] != "1.500000000s" {
+		t.Errorf("Unexpected httpRequest %v", req)
+	}
+}
+
+func TestGCPLevelEncoder(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "levels.log")
+	log, err := New(WithGCPEncoding(), WithLevel("debug"), WithOutputPaths([]string{path}))
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+
+	log.Debug("d")
+	log.Info("i")
+	log.Error("e", zap.String("trace_id", "no-project"))
+	log.Sync()
+
+	entries := readJSONLines(t, path)
+	want := []string{"DEBUG", "INFO", "ERROR"}
+	for i, severity := range want {
+		if entries[i]["severity"] != severity {
+			t.Errorf("Expected severity %s, got %v", severity, entries[i]["severity"])
+		}
+	}
+	if os.Getenv("GOOGLE_CLOUD_PROJECT") == "" && entries[2]["logging.googleapis.com/trace"] != "no-project" {
+		t.Errorf("Expected unqualified trace without a project, got %v", entries[2]["logging.googleapis.com/trace"])
+	}
+}
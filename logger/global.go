@@ -0,0 +1,198 @@
+package logger
+
+import (
+	"sync"
+
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+)
+
+var (
+	globalMu sync.RWMutex
+	// global is the logger returned by L; nil until first use or Init
+	global *Logger
+	// globalSkip is global with one more caller frame skipped, so the
+	// package-level functions report their caller rather than this file
+	globalSkip *Logger
+)
+
+// Init builds a logger from cfg and installs it as the global logger
+func Init(cfg Config) error {
+	l, err := NewLogger(cfg)
+	if err != nil {
+		return err
+	}
+	Replace(l)
+	return nil
+}
+
+// Replace installs l as the global logger and returns a function that
+// restores the previous one, which is useful in tests
+func Replace(l *Logger) func() {
+	globalMu.Lock()
+	defer globalMu.Unlock()
+
+	prev := global
+	setGlobal(l)
+	return func() {
+		globalMu.Lock()
+		defer globalMu.Unlock()
+		setGlobal(prev)
+	}
+}
+
+// setGlobal sets the global loggers; globalMu must be held
+func setGlobal(l *Logger) {
+	global = l
+	globalSkip = nil
+	if l != nil {
+		globalSkip = &Logger{
+			logger:  l.logger.WithOptions(zap.AddCallerSkip(1)),
+			sugared: l.sugared.WithOptions(zap.AddCallerSkip(1)),
+			level:   l.level,
+			closer:  l.closer,
+		}
+	}
+}
+
+// L returns the global logger. Until Init or Replace is called it is a
+// logger with the default configuration.
+func L() *Logger {
+	globalMu.RLock()
+	l := global
+	globalMu.RUnlock()
+	if l != nil {
+		return l
+	}
+	return ensureGlobal(false)
+}
+
+// skipped returns the global logger used by the package-level functions
+func skipped() *Logger {
+	globalMu.RLock()
+	l := globalSkip
+	globalMu.RUnlock()
+	if l != nil {
+		return l
+	}
+	return ensureGlobal(true)
+}
+
+// ensureGlobal installs a default global logger if none is set yet
+func ensureGlobal(skip bool) *Logger {
+	globalMu.Lock()
+	defer globalMu.Unlock()
+
+	if global == nil {
+		l, err := New()
+		if err != nil {
+			l = NewNopLogger()
+		}
+		setGlobal(l)
+	}
+	if skip {
+		return globalSkip
+	}
+	return global
+}
+
+// With creates a child of the global logger with additional fields
+func With(fields ...zapcore.Field) *Logger {
+	return L().With(fields...)
+}
+
+// WithFields creates a child of the global logger with additional fields as key-value pairs
+func WithFields(fields map[string]interface{}) *Logger {
+	return L().WithFields(fields)
+}
+
+// Named creates a named child of the global logger
+func Named(name string) *Logger {
+	return L().Named(name)
+}
+
+// Sync flushes any buffered entries of the global logger
+func Sync() error {
+	return L().Sync()
+}
+
+// Debug logs at debug level using the global logger
+func Debug(msg string, fields ...zapcore.Field) {
+	skipped().Debug(msg, fields...)
+}
+
+// Info logs at info level using the global logger
+func Info(msg string, fields ...zapcore.Field) {
+	skipped().Info(msg, fields...)
+}
+
+// Warn logs at warn level using the global logger
+func Warn(msg string, fields ...zapcore.Field) {
+	skipped().Warn(msg, fields...)
+}
+
+// Error logs at error level using the global logger
+func Error(msg string, fields ...zapcore.Field) {
+	skipped().Error(msg, fields...)
+}
+
+// ErrorWithStack logs msg at error level with err and a stack trace using
+// the global logger; see Logger.ErrorWithStack
+func ErrorWithStack(msg string, err error, fields ...zapcore.Field) {
+	skipped().Error(msg, errorWithStackFields(err, fields, 2)...)
+}
+
+// Fatal logs at fatal level using the global logger and then calls os.Exit(1)
+func Fatal(msg string, fields ...zapcore.Field) {
+	skipped().Fatal(msg, fields...)
+}
+
+// Debugf logs at debug level with formatting using the global logger
+func Debugf(template string, args ...interface{}) {
+	skipped().Debugf(template, args...)
+}
+
+// Debugw logs at debug level with structured key-value pairs using the global logger
+func Debugw(msg string, keysAndValues ...interface{}) {
+	skipped().Debugw(msg, keysAndValues...)
+}
+
+// Infof logs at info level with formatting using the global logger
+func Infof(template string, args ...interface{}) {
+	skipped().Infof(template, args...)
+}
+
+// Infow logs at info level with structured key-value pairs using the global logger
+func Infow(msg string, keysAndValues ...interface{}) {
+	skipped().Infow(msg, keysAndValues...)
+}
+
+// Warnf logs at warn level with formatting using the global logger
+func Warnf(template string, args ...interface{}) {
+	skipped().Warnf(template, args...)
+}
+
+// Warnw logs at warn level with structured key-value pairs using the global logger
+func Warnw(msg string, keysAndValues ...interface{}) {
+	skipped().Warnw(msg, keysAndValues...)
+}
+
+// Errorf logs at error level with formatting using the global logger
+func Errorf(template string, args ...interface{}) {
+	skipped().Errorf(template, args...)
+}
+
+// Errorw logs at error level with structured key-value pairs using the global logger
+func Errorw(msg string, keysAndValues ...interface{}) {
+	skipped().Errorw(msg, keysAndValues...)
+}
+
+// Fatalf logs at fatal level with formatting using the global logger and then calls os.Exit(1)
+func Fatalf(template string, args ...interface{}) {
+	skipped().Fatalf(template, args...)
+}
+
+// Fatalw logs at fatal level with structured key-value pairs using the global logger and then calls os.Exit(1)
+func Fatalw(msg string, keysAndValues ...interface{}) {
+	skipped().Fatalw(msg, keysAndValues...)
+}
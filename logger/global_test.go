@@ -0,0 +1,114 @@
+package logger
+
+import (
+	"context"
+	"path/filepath"
+	"sync"
+	"testing"
+
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+	"go.uber.org/zap/zaptest/observer"
+)
+
+func TestGlobalLogger(t *testing.T) {
+	core, observed := observer.New(zapcore.DebugLevel)
+	// Skip the method wrapper frame, as loggers built by New do
+	restore := Replace(NewFromZap(zap.New(core, zap.AddCaller(), zap.AddCallerSkip(1))))
+	defer restore()
+
+	Debug("debug message", zap.Int("item_id", 1))
+	Infof("info %d", 2)
+	Warnw("warn message", "key", "value")
+	Error("error message")
+	With(zap.String("request_id", "abc")).Info("child message")
+	WithFields(map[string]interface{}{"user_id": "u1"}).Info("fields message")
+
+	logs := observed.All()
+	if len(logs) != 6 {
+		t.Fatalf("Expected 6 log entries, got %d", len(logs))
+	}
+
+	wantMessages := []string{"debug message", "info 2", "warn message", "error message", "child message", "fields message"}
+	for i, want := range wantMessages {
+		if logs[i].Message != want {
+			t.Errorf("Expected message %q, got %q", want, logs[i].Message)
+		}
+	}
+	if logs[2].ContextMap()["key"] != "value" {
+		t.Errorf("Expected key field, got %v", logs[2].ContextMap())
+	}
+	if logs[4].ContextMap()["request_id"] != "abc" {
+		t.Errorf("Expected request_id field, got %v", logs[4].ContextMap())
+	}
+
+	// Package-level functions report their caller, not global.go
+	for _, entry := range logs[:4] {
+		if file := filepath.Base(entry.Caller.File); file != "global_test.go" {
+			t.Errorf("Expected caller global_test.go, got %s", file)
+		}
+	}
+
+	if err := Sync(); err != nil {
+		t.Errorf("Expected no error from Sync, got %v", err)
+	}
+}
+
+func TestReplace_Restore(t *testing.T) {
+	first := NewNopLogger()
+	restoreFirst := Replace(first)
+	defer restoreFirst()
+
+	second := NewNopLogger()
+	restore := Replace(second)
+	if L() != second {
+		t.Error("Expected L to return the replacement logger")
+	}
+
+	restore()
+	if L() != first {
+		t.Error("Expected restore to reinstate the previous logger")
+	}
+}
+
+func TestInit(t *testing.T) {
+	defer Replace(L())()
+
+	if err := Init(Config{Level: "invalid"}); err == nil {
+		t.Fatal("Expected error for invalid level, got nil")
+	}
+
+	if err := Init(Config{Level: "warn", ServiceName: "global-test"}); err != nil {
+		t.Fatalf("Init() error = %v", err)
+	}
+	if L().Level() != WarnLevel {
+		t.Errorf("Expected level warn, got %v", L().Level())
+	}
+}
+
+func TestWithContext_FallsBackToGlobal(t *testing.T) {
+	core, observed := observer.New(zapcore.InfoLevel)
+	defer Replace(NewFromZap(zap.New(core)))()
+
+	WithContext(context.Background()).Info("from global")
+
+	if observed.Len() != 1 {
+		t.Fatalf("Expected 1 log entry, got %d", observed.Len())
+	}
+}
+
+func TestGlobalLogger_Concurrent(t *testing.T) {
+	defer Replace(NewNopLogger())()
+
+	var wg sync.WaitGroup
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			Replace(NewNopLogger())
+			Info("concurrent")
+			_ = L()
+		}()
+	}
+	wg.Wait()
+}
@@ -0,0 +1,47 @@
+package logger
+
+import (
+	"go.uber.org/zap/zapcore"
+)
+
+// CoreWrapper wraps the zap core of a logger
+type CoreWrapper func(zapcore.Core) zapcore.Core
+
+// Hook is called with each entry that passes the level check
+type Hook func(zapcore.Entry) error
+
+// WithCoreWrapper wraps the logger's core, for example to tee entries to an
+// error tracker with zapcore.NewTee. Wrappers are applied in the order given.
+// Redaction applies to the wrapped core as a whole, so sinks added here only
+// see redacted values.
+func WithCoreWrapper(wrapper CoreWrapper) Option {
+	return func(cfg *Config) {
+		cfg.CoreWrappers = append(cfg.CoreWrappers, wrapper)
+	}
+}
+
+// WithHooks calls each hook with every logged entry. Hooks see the entry's
+// level, message, time and caller but not its fields; use WithCoreWrapper
+// when the fields are needed.
+func WithHooks(hooks ...Hook) Option {
+	return func(cfg *Config) {
+		cfg.Hooks = append(cfg.Hooks, hooks...)
+	}
+}
+
+// wrapCore applies wrappers to core in order
+func wrapCore(core zapcore.Core, wrappers []CoreWrapper) zapcore.Core {
+	for _, wrap := range wrappers {
+		core = wrap(core)
+	}
+	return core
+}
+
+// entryHooks adapts hooks to the signature zap.Hooks expects
+func entryHooks(hooks []Hook) []func(zapcore.Entry) error {
+	fns := make([]func(zapcore.Entry) error, len(hooks))
+	for i, hook := range hooks {
+		fns[i] = hook
+	}
+	return fns
+}
@@ -0,0 +1,77 @@
+package logger
+
+import (
+	"path/filepath"
+	"testing"
+
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+	"go.uber.org/zap/zaptest/observer"
+)
+
+func TestWithCoreWrapper(t *testing.T) {
+	sink, observed := observer.New(zapcore.ErrorLevel)
+
+	log, err := New(
+		WithOutputPaths([]string{filepath.Join(t.TempDir(), "app.log")}),
+		WithRedactedKeys("password"),
+		WithCoreWrapper(func(core zapcore.Core) zapcore.Core {
+			return zapcore.NewTee(core, sink)
+		}),
+	)
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+
+	log.Info("not an error")
+	log.Error("payment failed", zap.String("order_id", "o1"), zap.String("password", "hunter2"))
+	log.With(zap.String("component", "billing")).Errorw("refund failed", "amount", 10)
+
+	entries := observed.All()
+	if len(entries) != 2 {
+		t.Fatalf("Expected 2 entries in the teed sink, got %d", len(entries))
+	}
+
+	fields := entries[0].ContextMap()
+	if fields["order_id"] != "o1" {
+		t.Errorf("Expected fields to reach the sink, got %v", fields)
+	}
+	if fields["password"] != RedactedValue {
+		t.Errorf("Expected the sink to see redacted values, got %v", fields["password"])
+	}
+	if entries[1].ContextMap()["component"] != "billing" {
+		t.Errorf("Expected child logger fields to reach the sink, got %v", entries[1].ContextMap())
+	}
+}
+
+func TestWithHooks(t *testing.T) {
+	var levels []zapcore.Level
+	var messages []string
+
+	log, err := New(
+		WithOutputPaths([]string{filepath.Join(t.TempDir(), "app.log")}),
+		WithHooks(func(e zapcore.Entry) error {
+			levels = append(levels, e.Level)
+			return nil
+		}, func(e zapcore.Entry) error {
+			if e.Level >= zapcore.ErrorLevel {
+				messages = append(messages, e.Message)
+			}
+			return nil
+		}),
+	)
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+
+	log.Debug("below level")
+	log.Info("started")
+	log.Errorf("failed %d times", 3)
+
+	if len(levels) != 2 || levels[0] != zapcore.InfoLevel || levels[1] != zapcore.ErrorLevel {
+		t.Errorf("Expected hooks for info and error entries, got %v", levels)
+	}
+	if len(messages) != 1 || messages[0] != "failed 3 times" {
+		t.Errorf("Expected the error message, got %v", messages)
+	}
+}
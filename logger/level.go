@@ -0,0 +1,47 @@
+package logger
+
+import (
+	"net/http"
+
+	"go.uber.org/zap"
+)
+
+// SetLevel changes the minimum level of l at runtime. The level is shared
+// with every logger derived from l and with the logger l was derived from.
+// It has no effect on loggers created with NewFromZap or NewNopLogger.
+func (l *Logger) SetLevel(level Level) {
+	if l.level != nil {
+		l.level.SetLevel(level)
+	}
+}
+
+// Level returns the current minimum level of l
+func (l *Logger) Level() Level {
+	if l.level != nil {
+		return l.level.Level()
+	}
+	return l.logger.Level()
+}
+
+// AtomicLevel returns the zap level controlling l, and false for loggers
+// whose level cannot be changed
+func (l *Logger) AtomicLevel() (zap.AtomicLevel, bool) {
+	if l.level == nil {
+		return zap.AtomicLevel{}, false
+	}
+	return *l.level, true
+}
+
+// LevelHandler returns an http.Handler that reports the level on GET and
+// changes it on PUT, taking either a JSON body such as {"level":"debug"} or
+// a level form value. Mount it on an internal route:
+//
+//	r.Handle("/debug/loglevel", log.LevelHandler())
+func (l *Logger) LevelHandler() http.Handler {
+	if l.level == nil {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			http.Error(w, "log level is not adjustable", http.StatusNotImplemented)
+		})
+	}
+	return l.level
+}
@@ -0,0 +1,82 @@
+package logger
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"go.uber.org/zap"
+)
+
+func TestLogger_SetLevel(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "app.log")
+	log, err := New(WithLevel("info"), WithOutputPaths([]string{path}))
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+	child := log.With(zap.String("component", "worker")).WithFields(map[string]interface{}{"shard": 1})
+
+	child.Debug("hidden")
+	log.SetLevel(DebugLevel)
+	child.Debug("visible")
+	log.Sync()
+
+	if log.Level() != DebugLevel || child.Level() != DebugLevel {
+		t.Errorf("Expected debug level, got %v and %v", log.Level(), child.Level())
+	}
+
+	data, _ := os.ReadFile(path)
+	if strings.Contains(string(data), "hidden") {
+		t.Error("Expected debug entry before SetLevel to be dropped")
+	}
+	if !strings.Contains(string(data), "visible") {
+		t.Errorf("Expected debug entry after SetLevel, got %s", data)
+	}
+}
+
+func TestLogger_SetLevelUnmanaged(t *testing.T) {
+	log := NewFromZap(zap.NewNop())
+	log.SetLevel(DebugLevel)
+
+	if _, ok := log.AtomicLevel(); ok {
+		t.Error("Expected wrapped zap logger to have no atomic level")
+	}
+
+	w := httptest.NewRecorder()
+	log.LevelHandler().ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/debug/loglevel", nil))
+	if w.Code != http.StatusNotImplemented {
+		t.Errorf("Expected status code %d, got %d", http.StatusNotImplemented, w.Code)
+	}
+}
+
+func TestLogger_LevelHandler(t *testing.T) {
+	log, err := New(WithLevel("warn"))
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+	handler := log.LevelHandler()
+
+	w := httptest.NewRecorder()
+	handler.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/debug/loglevel", nil))
+	if !strings.Contains(w.Body.String(), `"level":"warn"`) {
+		t.Errorf("Expected current level warn, got %s", w.Body.String())
+	}
+
+	w = httptest.NewRecorder()
+	handler.ServeHTTP(w, httptest.NewRequest(http.MethodPut, "/debug/loglevel", strings.NewReader(`{"level":"debug"}`)))
+	if w.Code != http.StatusOK {
+		t.Fatalf("Expected status code %d, got %d: %s", http.StatusOK, w.Code, w.Body.String())
+	}
+	if log.Level() != DebugLevel {
+		t.Errorf("Expected level debug after PUT, got %v", log.Level())
+	}
+
+	w = httptest.NewRecorder()
+	handler.ServeHTTP(w, httptest.NewRequest(http.MethodPut, "/debug/loglevel", strings.NewReader(`{"level":"loud"}`)))
+	if w.Code != http.StatusBadRequest {
+		t.Errorf("Expected status code %d for invalid level, got %d", http.StatusBadRequest, w.Code)
+	}
+}
@@ -21,6 +21,9 @@ const (
 type Config struct {
 	// Level is the minimum log level to output
 	Level string
+	// NamedLevels sets the minimum level of named loggers, keyed by name;
+	// a name also applies to its children
+	NamedLevels map[string]string
 	// Development sets development mode (more human-friendly output)
 	Development bool
 	// OutputPaths defines where logs are written to
@@ -33,16 +36,36 @@ type Config struct {
 	DisableCaller bool
 	// DisableStacktrace disables including stack traces in log output
 	DisableStacktrace bool
+	// GCPEncoding writes JSON using Google Cloud Logging field names
+	GCPEncoding bool
+	// GCPProjectID qualifies trace IDs in GCP encoding (default $GOOGLE_CLOUD_PROJECT)
+	GCPProjectID string
+	// RedactedKeys lists field keys whose values are replaced with RedactedValue
+	RedactedKeys []string
+	// CoreWrappers wrap the zap core, for example to tee entries to another sink
+	CoreWrappers []CoreWrapper
+	// Hooks are called with each entry that passes the level check
+	Hooks []Hook
+	// Async, if set, writes output in batches from a background goroutine
+	Async *AsyncConfig
+	// Pretty, if set, writes colorized, human-friendly console output;
+	// ignored with GCPEncoding
+	Pretty *PrettyConfig
 }
 
 // Logger represents a logger instance
 type Logger struct {
 	logger  *zap.Logger
 	sugared *zap.SugaredLogger
+	// level is shared by a logger and its children; nil for wrapped zap loggers
+	level *zap.AtomicLevel
+	// closer stops the async writer shared by a logger and its children;
+	// nil without WithAsyncBuffer
+	closer func() error
 }
 
 // buildZapLogger builds a zap logger from the configuration
-func buildZapLogger(cfg Config) (*zap.Logger, error) {
+func buildZapLogger(cfg Config) (*zap.Logger, zap.AtomicLevel, func() error, error) {
 	// Set default output path if none provided
 	if len(cfg.OutputPaths) == 0 {
 		cfg.OutputPaths = []string{"stdout"}
@@ -52,13 +75,14 @@ func buildZapLogger(cfg Config) (*zap.Logger, error) {
 	level := zap.InfoLevel
 	if cfg.Level != "" {
 		if err := level.Set(cfg.Level); err != nil {
-			return nil, err
+			return nil, zap.AtomicLevel{}, nil, err
 		}
 	}
+	atomicLevel := zap.NewAtomicLevelAt(level)
 
 	// Create zap config
 	zapConfig := zap.Config{
-		Level:             zap.NewAtomicLevelAt(level),
+		Level:             atomicLevel,
 		Development:       cfg.Development,
 		Encoding:          "json",
 		EncoderConfig:     zap.NewProductionEncoderConfig(),
@@ -78,10 +102,63 @@ func buildZapLogger(cfg Config) (*zap.Logger, error) {
 		zapConfig.EncoderConfig.EncodeTime = zapcore.ISO8601TimeEncoder
 	}
 
+	buildOptions := []zap.Option{zap.AddCallerSkip(1)}
+
+	// Cloud Logging expects JSON with its own field names
+	if cfg.GCPEncoding {
+		zapConfig.Encoding = "json"
+		zapConfig.EncoderConfig = gcpEncoderConfig()
+		buildOptions = append(buildOptions, zap.WrapCore(func(core zapcore.Core) zapcore.Core {
+			return newGCPCore(core, cfg.GCPProjectID)
+		}))
+	}
+
+	// Let applications add sinks, inside redaction so they never see secrets
+	if len(cfg.CoreWrappers) > 0 {
+		buildOptions = append(buildOptions, zap.WrapCore(func(core zapcore.Core) zapcore.Core {
+			return wrapCore(core, cfg.CoreWrappers)
+		}))
+	}
+
+	if len(cfg.Hooks) > 0 {
+		buildOptions = append(buildOptions, zap.Hooks(entryHooks(cfg.Hooks)...))
+	}
+
+	// Redact sensitive fields in all structured output
+	if len(cfg.RedactedKeys) > 0 {
+		buildOptions = append(buildOptions, zap.WrapCore(func(core zapcore.Core) zapcore.Core {
+			return newRedactCore(core, cfg.RedactedKeys)
+		}))
+	}
+
+	// Named loggers may log below the default level, so the core lets
+	// through anything a named level allows and filters each entry by name
+	var enabler zapcore.LevelEnabler = atomicLevel
+	if len(cfg.NamedLevels) > 0 {
+		named, err := parseNamedLevels(cfg.NamedLevels)
+		if err != nil {
+			return nil, zap.AtomicLevel{}, nil, err
+		}
+		enabler = named.enabler(atomicLevel)
+		buildOptions = append(buildOptions, zap.WrapCore(func(core zapcore.Core) zapcore.Core {
+			return &namedLevelCore{Core: core, named: named, level: atomicLevel}
+		}))
+	}
+
 	// Build the logger
-	logger, err := zapConfig.Build(zap.AddCallerSkip(1))
+	var logger *zap.Logger
+	var closer func() error
+	var err error
+	if cfg.Pretty != nil && !cfg.GCPEncoding {
+		enc := newPrettyEncoder(*cfg.Pretty)
+		logger, closer, err = buildCustomLogger(zapConfig, enc, enabler, cfg.Async, buildOptions)
+	} else if cfg.Async != nil || len(cfg.NamedLevels) > 0 {
+		logger, closer, err = buildCustomLogger(zapConfig, newEncoder(zapConfig), enabler, cfg.Async, buildOptions)
+	} else {
+		logger, err = zapConfig.Build(buildOptions...)
+	}
 	if err != nil {
-		return nil, err
+		return nil, zap.AtomicLevel{}, nil, err
 	}
 
 	// Add default fields
@@ -98,7 +175,55 @@ func buildZapLogger(cfg Config) (*zap.Logger, error) {
 		logger = logger.With(fields...)
 	}
 
-	return logger, nil
+	return logger, atomicLevel, closer, nil
+}
+
+// newEncoder creates the encoder zap.Config.Build would use
+func newEncoder(zapConfig zap.Config) zapcore.Encoder {
+	if zapConfig.Encoding == "console" {
+		return zapcore.NewConsoleEncoder(zapConfig.EncoderConfig)
+	}
+	return zapcore.NewJSONEncoder(zapConfig.EncoderConfig)
+}
+
+// buildCustomLogger builds a logger like zap.Config.Build, but with enc, level
+// and, if async is set, output written through an asyncWriter, whose Close
+// is returned
+func buildCustomLogger(zapConfig zap.Config, enc zapcore.Encoder, level zapcore.LevelEnabler, async *AsyncConfig, opts []zap.Option) (*zap.Logger, func() error, error) {
+	sink, closeOut, err := zap.Open(zapConfig.OutputPaths...)
+	if err != nil {
+		return nil, nil, err
+	}
+	errSink, _, err := zap.Open(zapConfig.ErrorOutputPaths...)
+	if err != nil {
+		closeOut()
+		return nil, nil, err
+	}
+
+	// The options zap.Config.Build derives from the config
+	buildOptions := []zap.Option{zap.ErrorOutput(errSink)}
+	if zapConfig.Development {
+		buildOptions = append(buildOptions, zap.Development())
+	}
+	if !zapConfig.DisableCaller {
+		buildOptions = append(buildOptions, zap.AddCaller())
+	}
+	stackLevel := zap.ErrorLevel
+	if zapConfig.Development {
+		stackLevel = zap.WarnLevel
+	}
+	if !zapConfig.DisableStacktrace {
+		buildOptions = append(buildOptions, zap.AddStacktrace(stackLevel))
+	}
+
+	var out zapcore.WriteSyncer = sink
+	var closer func() error
+	if async != nil {
+		w := newAsyncWriter(sink, errSink, *async)
+		out, closer = w, w.Close
+	}
+	core := zapcore.NewCore(enc, out, level)
+	return zap.New(core, append(buildOptions, opts...)...), closer, nil
 }
 
 // Option is a function that configures the logger
@@ -160,7 +285,7 @@ func WithDisableStacktrace(disable bool) Option {
 
 // NewLogger creates a new logger from the configuration
 func NewLogger(cfg Config) (*Logger, error) {
-	zapLogger, err := buildZapLogger(cfg)
+	zapLogger, level, closer, err := buildZapLogger(cfg)
 	if err != nil {
 		return nil, err
 	}
@@ -168,6 +293,8 @@ func NewLogger(cfg Config) (*Logger, error) {
 	return &Logger{
 		logger:  zapLogger,
 		sugared: zapLogger.Sugar(),
+		level:   &level,
+		closer:  closer,
 	}, nil
 }
 
@@ -188,6 +315,14 @@ func New(options ...Option) (*Logger, error) {
 	return NewLogger(cfg)
 }
 
+// NewFromZap wraps an existing zap logger, e.g. one built on an observer core in tests
+func NewFromZap(zapLogger *zap.Logger) *Logger {
+	return &Logger{
+		logger:  zapLogger,
+		sugared: zapLogger.Sugar(),
+	}
+}
+
 // With creates a child logger with additional fields
 func (l *Logger) With(fields ...zapcore.Field) *Logger {
 	// Create a new logger with the fields
@@ -202,6 +337,8 @@ func (l *Logger) With(fields ...zapcore.Field) *Logger {
 	return &Logger{
 		logger:  newLogger,
 		sugared: newLogger.Sugar().With(args...),
+		level:   l.level,
+		closer:  l.closer,
 	}
 }
 
@@ -215,6 +352,8 @@ func (l *Logger) WithFields(fields map[string]interface{}) *Logger {
 	return &Logger{
 		logger:  l.logger,
 		sugared: l.sugared.With(args...),
+		level:   l.level,
+		closer:  l.closer,
 	}
 }
 
@@ -298,6 +437,17 @@ func (l *Logger) Sync() error {
 	return l.logger.Sync()
 }
 
+// Close flushes buffered log entries and, for a logger created with
+// WithAsyncBuffer, stops its background goroutine; entries logged afterwards
+// are written synchronously. The writer is shared with child loggers, so
+// closing any of them closes it for all. Other loggers are only synced.
+func (l *Logger) Close() error {
+	if l.closer != nil {
+		return l.closer()
+	}
+	return l.Sync()
+}
+
 // NewNopLogger returns a no-op logger for testing where logs are undesired
 func NewNopLogger() *Logger {
 	// Create a no-op zap logger
@@ -411,4 +411,73 @@ func TestLoggerOutput(t *testing.T) {
 	if logMap["key"] != "value" {
 		t.Errorf("Expected 'key' field to be 'value', got '%v'", logMap["key"])
 	}
-}
\ No newline at end of file
+}
+
+func TestNewFromZap(t *testing.T) {
+	core, observed := observer.New(zapcore.InfoLevel)
+	log := NewFromZap(zap.New(core))
+
+	log.Info("wrapped", zap.String("key", "value"))
+	log.Infow("sugared", "key", "value")
+
+	if observed.Len() != 2 {
+		t.Fatalf("Expected 2 log entries, got %d", observed.Len())
+	}
+	if observed.All()[0].Message != "wrapped" {
+		t.Errorf("Expected message 'wrapped', got %q", observed.All()[0].Message)
+	}
+}
+
+func TestContextWithLogger(t *testing.T) {
+	logger, observed := captureOutput(t)
+
+	ctx := ContextWithLogger(context.Background(), logger)
+	WithContext(ctx).Info("context message")
+
+	if observed.Len() != 1 {
+		t.Fatalf("Expected 1 log entry, got %d", observed.Len())
+	}
+}
+
+func TestAppendFields(t *testing.T) {
+	logger, observed := captureOutput(t)
+
+	base := NewContext(context.Background(), logger)
+	ctx := AppendFields(base, zap.String("request_id", "req-1"))
+	ctx = AppendFields(ctx, zap.String("user_id", "u1"))
+	ctx = AppendFields(ctx, zap.String("tenant", "acme"))
+
+	WithContext(ctx).Info("enriched")
+	WithContext(base).Info("base")
+
+	logs := observed.All()
+	if len(logs) != 2 {
+		t.Fatalf("Expected 2 log entries, got %d", len(logs))
+	}
+
+	fields := logs[0].ContextMap()
+	for key, want := range map[string]string{"request_id": "req-1", "user_id": "u1", "tenant": "acme"} {
+		if fields[key] != want {
+			t.Errorf("Expected %s to be %q, got %v", key, want, fields[key])
+		}
+	}
+	if len(logs[1].ContextMap()) != 0 {
+		t.Errorf("Expected the parent context logger to be unchanged, got %v", logs[1].ContextMap())
+	}
+}
+
+func TestAppendFields_GlobalFallback(t *testing.T) {
+	core, observed := observer.New(zapcore.InfoLevel)
+	defer Replace(NewFromZap(zap.New(core)))()
+
+	ctx := AppendFields(context.Background(), zap.String("request_id", "req-1"))
+	WithContext(ctx).Info("from global")
+
+	logs := observed.All()
+	if len(logs) != 1 {
+		t.Fatalf("Expected 1 log entry, got %d", len(logs))
+	}
+	if logs[0].ContextMap()["request_id"] != "req-1" {
+		t.Errorf("Expected request_id field, got %v", logs[0].ContextMap())
+	}
+}
@@ -0,0 +1,44 @@
+/*
+Package logtest provides a logger for tests that records what is logged and
+assertions on the recorded entries.
+
+It lets packages that accept a *logger.Logger check their log output without
+building zap observer cores themselves.
+
+# Features
+
+  - A *logger.Logger that records entries instead of writing them
+  - Queries by message, level and field
+  - AssertLogged and AssertNotLogged with readable failure messages
+
+# Recording Entries
+
+NewTestLogger returns the logger and a Recorder bound to the test:
+
+	log, rec := logtest.NewTestLogger(t)
+	svc := orders.NewService(orders.WithLogger(log))
+
+	svc.Cancel(ctx, "ORD-1001")
+
+	rec.AssertLogged(zapcore.InfoLevel, "Order canceled", map[string]interface{}{
+		"order_id": "ORD-1001",
+	})
+	rec.AssertNotLogged(zapcore.ErrorLevel, "Refund failed")
+
+AssertLogged passes if any entry has the level, the message and at least the
+given fields. Values are compared as zap records them, so an int field matches
+an expected int, and an error field matches the error or its message.
+
+# Querying Entries
+
+Entries, FilterMessage, FilterLevel and FilterField return Entry values with
+the fields of each entry in a map:
+
+	for _, e := range rec.FilterLevel(zapcore.WarnLevel) {
+		t.Logf("%s %v", e.Message, e.Fields)
+	}
+
+NewTestLoggerAt records only entries at or above a level, and Reset discards
+what was recorded so far.
+*/
+package logtest
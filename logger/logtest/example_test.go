@@ -0,0 +1,28 @@
+package logtest_test
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/StairSupplies/go-core/logger/logtest"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+)
+
+func ExampleNewTestLogger() {
+	t := &testing.T{} // use the *testing.T passed to your test
+	log, rec := logtest.NewTestLogger(t)
+
+	log.Info("Order created", zap.String("order_id", "ORD-1001"), zap.Int("items", 3))
+
+	rec.AssertLogged(zapcore.InfoLevel, "Order created", map[string]interface{}{
+		"order_id": "ORD-1001",
+		"items":    3,
+	})
+
+	for _, e := range rec.Entries() {
+		fmt.Println(e)
+	}
+
+	// Output: info "Order created" items=3 order_id=ORD-1001
+}
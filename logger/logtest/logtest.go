@@ -0,0 +1,200 @@
+package logtest
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/StairSupplies/go-core/logger"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+	"go.uber.org/zap/zaptest/observer"
+)
+
+// Entry is one recorded log entry
+type Entry struct {
+	// Level is the entry's level
+	Level zapcore.Level
+	// Message is the log message
+	Message string
+	// Fields holds the entry's fields, including those added with With,
+	// as zap's map encoder represents them
+	Fields map[string]interface{}
+	// LoggerName is the name of the logger that wrote the entry, if any
+	LoggerName string
+	// Time is when the entry was logged
+	Time time.Time
+}
+
+// Recorder holds the entries written to a test logger
+type Recorder struct {
+	t    testing.TB
+	logs *observer.ObservedLogs
+}
+
+// NewTestLogger returns a logger that records every entry at debug level and
+// above, and the Recorder to query them with
+func NewTestLogger(t testing.TB) (*logger.Logger, *Recorder) {
+	t.Helper()
+	return NewTestLoggerAt(t, zapcore.DebugLevel)
+}
+
+// NewTestLoggerAt is like NewTestLogger but only records entries at level and above
+func NewTestLoggerAt(t testing.TB, level zapcore.Level) (*logger.Logger, *Recorder) {
+	t.Helper()
+	core, logs := observer.New(level)
+	return logger.NewFromZap(zap.New(core)), &Recorder{t: t, logs: logs}
+}
+
+// Entries returns every entry recorded so far, oldest first
+func (r *Recorder) Entries() []Entry {
+	return toEntries(r.logs.All())
+}
+
+// Len returns the number of entries recorded so far
+func (r *Recorder) Len() int {
+	return r.logs.Len()
+}
+
+// FilterMessage returns the entries with exactly the message msg
+func (r *Recorder) FilterMessage(msg string) []Entry {
+	return toEntries(r.logs.FilterMessage(msg).All())
+}
+
+// FilterLevel returns the entries logged at exactly level
+func (r *Recorder) FilterLevel(level zapcore.Level) []Entry {
+	return toEntries(r.logs.FilterLevelExact(level).All())
+}
+
+// FilterField returns the entries with the field key set to value
+func (r *Recorder) FilterField(key string, value interface{}) []Entry {
+	var matched []Entry
+	for _, e := range r.Entries() {
+		if e.HasField(key, value) {
+			matched = append(matched, e)
+		}
+	}
+	return matched
+}
+
+// Observed returns the zap observer the entries are recorded in, for code
+// written against zaptest/observer
+func (r *Recorder) Observed() *observer.ObservedLogs {
+	return r.logs
+}
+
+// Reset discards the entries recorded so far
+func (r *Recorder) Reset() {
+	r.logs.TakeAll()
+}
+
+// AssertLogged fails the test unless an entry was logged at level with the
+// message msg and at least the given fields. Field values are compared as zap
+// records them, so an int matches the int64 it is stored as.
+func (r *Recorder) AssertLogged(level zapcore.Level, msg string, fields map[string]interface{}) {
+	r.t.Helper()
+	if r.find(level, msg, fields) {
+		return
+	}
+	r.t.Errorf("Expected %s entry %q with fields %v, got:\n%s", level, msg, fields, r.dump())
+}
+
+// AssertNotLogged fails the test if an entry was logged at level with the message msg
+func (r *Recorder) AssertNotLogged(level zapcore.Level, msg string) {
+	r.t.Helper()
+	if r.find(level, msg, nil) {
+		r.t.Errorf("Expected no %s entry %q, got:\n%s", level, msg, r.dump())
+	}
+}
+
+// find reports whether a matching entry was recorded
+func (r *Recorder) find(level zapcore.Level, msg string, fields map[string]interface{}) bool {
+	for _, e := range r.FilterMessage(msg) {
+		if e.Level != level {
+			continue
+		}
+		matched := true
+		for k, v := range fields {
+			if !e.HasField(k, v) {
+				matched = false
+				break
+			}
+		}
+		if matched {
+			return true
+		}
+	}
+	return false
+}
+
+// dump formats the recorded entries for a failure message
+func (r *Recorder) dump() string {
+	entries := r.Entries()
+	if len(entries) == 0 {
+		return "\t(no entries)"
+	}
+	lines := make([]string, len(entries))
+	for i, e := range entries {
+		lines[i] = "\t" + e.String()
+	}
+	return strings.Join(lines, "\n")
+}
+
+// HasField reports whether the entry has the field key set to value
+func (e Entry) HasField(key string, value interface{}) bool {
+	got, ok := e.Fields[key]
+	if !ok {
+		return false
+	}
+	return reflect.DeepEqual(got, fieldValue(key, value))
+}
+
+// String formats the entry as its level, message and sorted fields
+func (e Entry) String() string {
+	keys := make([]string, 0, len(e.Fields))
+	for k := range e.Fields {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	var b strings.Builder
+	fmt.Fprintf(&b, "%s %q", e.Level, e.Message)
+	for _, k := range keys {
+		fmt.Fprintf(&b, " %s=%v", k, e.Fields[k])
+	}
+	return b.String()
+}
+
+// fieldValue converts value to the form zap's map encoder records it in
+func fieldValue(key string, value interface{}) interface{} {
+	if f, ok := value.(zap.Field); ok {
+		f.Key = key
+		return encodeField(f)
+	}
+	return encodeField(zap.Any(key, value))
+}
+
+// encodeField returns the value f adds to a map encoder
+func encodeField(f zap.Field) interface{} {
+	enc := zapcore.NewMapObjectEncoder()
+	f.AddTo(enc)
+	return enc.Fields[f.Key]
+}
+
+// toEntries converts observed entries to Entry values
+func toEntries(logged []observer.LoggedEntry) []Entry {
+	entries := make([]Entry, len(logged))
+	for i, l := range logged {
+		entries[i] = Entry{
+			Level:      l.Level,
+			Message:    l.Message,
+			Fields:     l.ContextMap(),
+			LoggerName: l.LoggerName,
+			Time:       l.Time,
+		}
+	}
+	return entries
+}
@@ -0,0 +1,130 @@
+package logtest
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+)
+
+// fakeT records failures instead of failing the test
+type fakeT struct {
+	testing.TB
+	errors []string
+}
+
+func (f *fakeT) Helper() {}
+
+func (f *fakeT) Errorf(format string, args ...interface{}) {
+	f.errors = append(f.errors, format)
+}
+
+func TestRecorder(t *testing.T) {
+	log, rec := NewTestLogger(t)
+
+	log.With(zap.String("service", "orders")).Info("Order created", zap.String("order_id", "ORD-1"), zap.Int("items", 3))
+	log.Warn("Stock low", zap.String("sku", "OAK-36"))
+	log.Debug("Cache miss")
+
+	if rec.Len() != 3 {
+		t.Fatalf("Expected 3 entries, got %d", rec.Len())
+	}
+
+	entries := rec.Entries()
+	if entries[0].Message != "Order created" || entries[0].Level != zapcore.InfoLevel {
+		t.Errorf("Unexpected first entry %v", entries[0])
+	}
+	if entries[0].Fields["service"] != "orders" {
+		t.Errorf("Expected field added with With, got %v", entries[0].Fields)
+	}
+
+	if got := rec.FilterMessage("Stock low"); len(got) != 1 || got[0].Fields["sku"] != "OAK-36" {
+		t.Errorf("Expected one Stock low entry, got %v", got)
+	}
+	if got := rec.FilterLevel(zapcore.DebugLevel); len(got) != 1 || got[0].Message != "Cache miss" {
+		t.Errorf("Expected one debug entry, got %v", got)
+	}
+	if got := rec.FilterField("items", 3); len(got) != 1 {
+		t.Errorf("Expected int field to match, got %v", got)
+	}
+
+	rec.Reset()
+	if rec.Len() != 0 {
+		t.Errorf("Expected no entries after Reset, got %d", rec.Len())
+	}
+}
+
+func TestNewTestLoggerAt(t *testing.T) {
+	log, rec := NewTestLoggerAt(t, zapcore.WarnLevel)
+
+	log.Info("Ignored")
+	log.Error("Recorded")
+
+	if rec.Len() != 1 || rec.Entries()[0].Message != "Recorded" {
+		t.Errorf("Expected only the error entry, got %v", rec.Entries())
+	}
+}
+
+func TestAssertLogged(t *testing.T) {
+	ft := &fakeT{}
+	log, rec := NewTestLogger(ft)
+	log.Error("Payment failed", zap.Error(errors.New("card declined")), zap.Int("attempt", 2))
+
+	tests := []struct {
+		name   string
+		level  zapcore.Level
+		msg    string
+		fields map[string]interface{}
+		pass   bool
+	}{
+		{"message and level", zapcore.ErrorLevel, "Payment failed", nil, true},
+		{"subset of fields", zapcore.ErrorLevel, "Payment failed", map[string]interface{}{"attempt": 2}, true},
+		{"error field", zapcore.ErrorLevel, "Payment failed", map[string]interface{}{"error": errors.New("card declined")}, true},
+		{"zap field", zapcore.ErrorLevel, "Payment failed", map[string]interface{}{"attempt": zap.Int("ignored", 2)}, true},
+		{"wrong level", zapcore.WarnLevel, "Payment failed", nil, false},
+		{"wrong message", zapcore.ErrorLevel, "Payment succeeded", nil, false},
+		{"wrong field value", zapcore.ErrorLevel, "Payment failed", map[string]interface{}{"attempt": 3}, false},
+		{"missing field", zapcore.ErrorLevel, "Payment failed", map[string]interface{}{"order_id": "ORD-1"}, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ft.errors = nil
+			rec.AssertLogged(tt.level, tt.msg, tt.fields)
+			if passed := len(ft.errors) == 0; passed != tt.pass {
+				t.Errorf("Expected pass %v, got %v", tt.pass, passed)
+			}
+		})
+	}
+}
+
+func TestAssertNotLogged(t *testing.T) {
+	ft := &fakeT{}
+	log, rec := NewTestLogger(ft)
+	log.Info("Order created")
+
+	rec.AssertNotLogged(zapcore.ErrorLevel, "Order created")
+	if len(ft.errors) != 0 {
+		t.Errorf("Expected no failure for another level, got %v", ft.errors)
+	}
+
+	rec.AssertNotLogged(zapcore.InfoLevel, "Order created")
+	if len(ft.errors) != 1 {
+		t.Errorf("Expected one failure, got %v", ft.errors)
+	}
+}
+
+func TestEntryString(t *testing.T) {
+	e := Entry{
+		Level:   zapcore.InfoLevel,
+		Message: "Order created",
+		Fields:  map[string]interface{}{"sku": "OAK-36", "items": int64(3)},
+	}
+
+	got := e.String()
+	if !strings.HasPrefix(got, `info "Order created"`) || !strings.HasSuffix(got, "items=3 sku=OAK-36") {
+		t.Errorf("Expected sorted fields, got %q", got)
+	}
+}
@@ -0,0 +1,105 @@
+package logger
+
+import (
+	"strings"
+
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+)
+
+// Named creates a child logger whose entries carry name in the "logger"
+// field. Names of nested children are joined with dots, so
+// log.Named("db").Named("migrations") logs as "db.migrations". Use it to
+// scope the logs of a library or subsystem without building a new logger.
+func (l *Logger) Named(name string) *Logger {
+	return &Logger{
+		logger:  l.logger.Named(name),
+		sugared: l.sugared.Named(name),
+		level:   l.level,
+		closer:  l.closer,
+	}
+}
+
+// WithNamedLevels sets the minimum level of named loggers, keyed by name. A
+// name also applies to its children, and the longest matching name wins, so
+// {"db": "debug", "db.pool": "warn"} logs debug entries from "db.migrations"
+// but only warnings from "db.pool". Other loggers use the configured level.
+func WithNamedLevels(levels map[string]string) Option {
+	return func(cfg *Config) {
+		if cfg.NamedLevels == nil {
+			cfg.NamedLevels = make(map[string]string)
+		}
+		for name, level := range levels {
+			cfg.NamedLevels[name] = level
+		}
+	}
+}
+
+// namedLevels holds the parsed minimum levels of named loggers
+type namedLevels struct {
+	levels map[string]zapcore.Level
+	// min is the lowest of the named levels
+	min zapcore.Level
+}
+
+// parseNamedLevels parses levels keyed by logger name
+func parseNamedLevels(levels map[string]string) (*namedLevels, error) {
+	nl := &namedLevels{levels: make(map[string]zapcore.Level, len(levels)), min: zapcore.InvalidLevel}
+	for name, text := range levels {
+		var level zapcore.Level
+		if err := level.Set(text); err != nil {
+			return nil, err
+		}
+		nl.levels[name] = level
+		if level < nl.min {
+			nl.min = level
+		}
+	}
+	return nl, nil
+}
+
+// enabler lets through entries that either the default level or some named
+// level could allow; namedLevelCore makes the final decision per entry
+func (nl *namedLevels) enabler(level zap.AtomicLevel) zapcore.LevelEnabler {
+	return zap.LevelEnablerFunc(func(l zapcore.Level) bool {
+		return l >= nl.min || level.Enabled(l)
+	})
+}
+
+// levelFor returns the minimum level for a logger name, and false if no
+// named level applies
+func (nl *namedLevels) levelFor(name string) (zapcore.Level, bool) {
+	for name != "" {
+		if level, ok := nl.levels[name]; ok {
+			return level, true
+		}
+		i := strings.LastIndexByte(name, '.')
+		if i < 0 {
+			break
+		}
+		name = name[:i]
+	}
+	return 0, false
+}
+
+// namedLevelCore drops entries below the level of the logger that wrote them
+type namedLevelCore struct {
+	zapcore.Core
+	named *namedLevels
+	level zap.AtomicLevel
+}
+
+func (c *namedLevelCore) With(fields []zapcore.Field) zapcore.Core {
+	return &namedLevelCore{Core: c.Core.With(fields), named: c.named, level: c.level}
+}
+
+func (c *namedLevelCore) Check(ent zapcore.Entry, ce *zapcore.CheckedEntry) *zapcore.CheckedEntry {
+	if level, ok := c.named.levelFor(ent.LoggerName); ok {
+		if ent.Level < level {
+			return ce
+		}
+	} else if !c.level.Enabled(ent.Level) {
+		return ce
+	}
+	return c.Core.Check(ent, ce)
+}
@@ -0,0 +1,117 @@
+package logger
+
+import (
+	"path/filepath"
+	"testing"
+
+	"go.uber.org/zap/zapcore"
+	"go.uber.org/zap/zaptest/observer"
+)
+
+func TestNamed(t *testing.T) {
+	logger, observed := captureOutput(t)
+
+	logger.Named("db").Named("migrations").Info("applied")
+	logger.Named("http").Infow("request", "path", "/users")
+
+	logs := observed.All()
+	if len(logs) != 2 {
+		t.Fatalf("Expected 2 log entries, got %d", len(logs))
+	}
+	if logs[0].LoggerName != "db.migrations" {
+		t.Errorf("Expected logger name 'db.migrations', got '%s'", logs[0].LoggerName)
+	}
+	if logs[1].LoggerName != "http" {
+		t.Errorf("Expected sugared logger name 'http', got '%s'", logs[1].LoggerName)
+	}
+}
+
+func TestNamed_SharesLevel(t *testing.T) {
+	log, err := New(WithOutputPaths([]string{filepath.Join(t.TempDir(), "app.log")}))
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+
+	db := log.Named("db")
+	log.SetLevel(DebugLevel)
+	if db.Level() != DebugLevel {
+		t.Errorf("Expected named logger to share the level, got %v", db.Level())
+	}
+}
+
+func TestNamedLevels_LevelFor(t *testing.T) {
+	named, err := parseNamedLevels(map[string]string{"db": "debug", "db.pool": "warn"})
+	if err != nil {
+		t.Fatalf("parseNamedLevels() error = %v", err)
+	}
+
+	tests := []struct {
+		name  string
+		level zapcore.Level
+		ok    bool
+	}{
+		{"db", zapcore.DebugLevel, true},
+		{"db.migrations", zapcore.DebugLevel, true},
+		{"db.pool", zapcore.WarnLevel, true},
+		{"db.pool.conn", zapcore.WarnLevel, true},
+		{"dbx", 0, false},
+		{"http", 0, false},
+		{"", 0, false},
+	}
+
+	for _, tt := range tests {
+		level, ok := named.levelFor(tt.name)
+		if ok != tt.ok || level != tt.level {
+			t.Errorf("levelFor(%q): expected (%v, %v), got (%v, %v)", tt.name, tt.level, tt.ok, level, ok)
+		}
+	}
+}
+
+func TestWithNamedLevels(t *testing.T) {
+	core, observed := observer.New(zapcore.DebugLevel)
+	log, err := New(
+		WithOutputPaths([]string{filepath.Join(t.TempDir(), "app.log")}),
+		WithLevel("info"),
+		WithNamedLevels(map[string]string{"db": "debug", "db.pool": "error"}),
+		WithCoreWrapper(func(c zapcore.Core) zapcore.Core { return zapcore.NewTee(c, core) }),
+	)
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+
+	log.Debug("root debug")
+	log.Info("root info")
+	log.Named("db").Named("migrations").Debug("migrations debug")
+	log.Named("db").Named("pool").Warn("pool warn")
+	log.Named("db").Named("pool").Error("pool error")
+	log.Named("http").Debug("http debug")
+
+	var got []string
+	for _, e := range observed.All() {
+		got = append(got, e.Message)
+	}
+	want := []string{"root info", "migrations debug", "pool error"}
+	if len(got) != len(want) {
+		t.Fatalf("Expected %v, got %v", want, got)
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Errorf("Expected %v, got %v", want, got)
+			break
+		}
+	}
+
+	// The default level still changes at runtime
+	log.SetLevel(DebugLevel)
+	log.Named("http").Debug("http debug")
+	if observed.Len() != 4 {
+		t.Errorf("Expected debug entry after SetLevel, got %d entries", observed.Len())
+	}
+}
+
+func TestWithNamedLevels_InvalidLevel(t *testing.T) {
+	_, err := New(WithNamedLevels(map[string]string{"db": "loud"}))
+	if err == nil {
+		t.Fatal("Expected error for invalid named level, got nil")
+	}
+}
@@ -0,0 +1,300 @@
+package logger
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+	"unicode"
+
+	"go.uber.org/zap"
+	"go.uber.org/zap/buffer"
+	"go.uber.org/zap/zapcore"
+)
+
+// DefaultPrettyTimeFormat is the time layout used by the pretty console
+// encoder when none is given
+const DefaultPrettyTimeFormat = "15:04:05.000"
+
+// PrettyConfig configures the pretty console encoder
+type PrettyConfig struct {
+	// NoColor disables ANSI colors. Colors are also disabled when the
+	// NO_COLOR environment variable is set.
+	NoColor bool
+	// MultilineErrors writes error fields, with their stack traces if any,
+	// on indented lines below the entry instead of inline
+	MultilineErrors bool
+	// TimeFormat is the layout for entry times; empty means DefaultPrettyTimeFormat
+	TimeFormat string
+}
+
+// WithPrettyConsole writes colorized, human-friendly output for local
+// development instead of JSON:
+//
+//	12:04:05.123 INFO  api/server.go:42  Server started  port=8080 env=dev
+//
+// It is ignored when GCP encoding is enabled.
+func WithPrettyConsole() Option {
+	return func(cfg *Config) {
+		if cfg.Pretty == nil {
+			cfg.Pretty = &PrettyConfig{}
+		}
+	}
+}
+
+// WithPrettyConsoleConfig enables the pretty console encoder with pc
+func WithPrettyConsoleConfig(pc PrettyConfig) Option {
+	return func(cfg *Config) {
+		cfg.Pretty = &pc
+	}
+}
+
+// ANSI escape sequences used by the pretty console encoder
+const (
+	ansiReset   = "\x1b[0m"
+	ansiDim     = "\x1b[2m"
+	ansiRed     = "\x1b[31m"
+	ansiYellow  = "\x1b[33m"
+	ansiBlue    = "\x1b[34m"
+	ansiMagenta = "\x1b[35m"
+	ansiCyan    = "\x1b[36m"
+)
+
+var prettyPool = buffer.NewPool()
+
+// prettyEncoder is a zapcore.Encoder writing one readable line per entry,
+// with fields inline as key=value
+type prettyEncoder struct {
+	cfg   PrettyConfig
+	color bool
+	// context holds the fields added with With, in order
+	context []zapcore.Field
+}
+
+// newPrettyEncoder creates a prettyEncoder
+func newPrettyEncoder(cfg PrettyConfig) *prettyEncoder {
+	if cfg.TimeFormat == "" {
+		cfg.TimeFormat = DefaultPrettyTimeFormat
+	}
+	_, noColor := os.LookupEnv("NO_COLOR")
+	return &prettyEncoder{cfg: cfg, color: !cfg.NoColor && !noColor}
+}
+
+// Clone implements zapcore.Encoder
+func (e *prettyEncoder) Clone() zapcore.Encoder {
+	clone := *e
+	clone.context = append([]zapcore.Field(nil), e.context...)
+	return &clone
+}
+
+// EncodeEntry implements zapcore.Encoder
+func (e *prettyEncoder) EncodeEntry(ent zapcore.Entry, fields []zapcore.Field) (*buffer.Buffer, error) {
+	buf := prettyPool.Get()
+
+	buf.AppendString(e.paint(ansiDim, ent.Time.Format(e.cfg.TimeFormat)))
+	buf.AppendByte(' ')
+	buf.AppendString(e.paint(levelColor(ent.Level), fmt.Sprintf("%-5s", ent.Level.CapitalString())))
+	if ent.LoggerName != "" {
+		buf.AppendByte(' ')
+		buf.AppendString(e.paint(ansiMagenta, ent.LoggerName))
+	}
+	if ent.Caller.Defined {
+		buf.AppendByte(' ')
+		buf.AppendString(e.paint(ansiDim, ent.Caller.TrimmedPath()))
+	}
+	buf.AppendString("  ")
+	buf.AppendString(ent.Message)
+
+	all := append(append([]zapcore.Field(nil), e.context...), fields...)
+	values := zapcore.NewMapObjectEncoder()
+	for _, f := range all {
+		f.AddTo(values)
+	}
+
+	var block []string
+	for _, f := range topLevelFields(all) {
+		value, ok := values.Fields[f.Key]
+		if !ok {
+			continue
+		}
+		if f.Type == zapcore.ErrorType {
+			verbose, hasVerbose := values.Fields[f.Key+"Verbose"].(string)
+			if e.cfg.MultilineErrors {
+				text := fmt.Sprint(value)
+				if hasVerbose {
+					text = verbose
+				}
+				block = append(block, "  "+e.paint(ansiRed, f.Key+":"), indent(text, "    "))
+				continue
+			}
+			e.appendField(buf, f.Key, value)
+			if hasVerbose {
+				e.appendField(buf, f.Key+"Verbose", verbose)
+			}
+			continue
+		}
+		e.appendField(buf, f.Key, value)
+	}
+
+	for _, line := range block {
+		buf.AppendByte('\n')
+		buf.AppendString(line)
+	}
+	if ent.Stack != "" {
+		buf.AppendByte('\n')
+		buf.AppendString(indent(ent.Stack, "    "))
+	}
+	buf.AppendByte('\n')
+	return buf, nil
+}
+
+// appendField writes " key=value" to buf
+func (e *prettyEncoder) appendField(buf *buffer.Buffer, key string, value any) {
+	buf.AppendByte(' ')
+	buf.AppendString(e.paint(ansiCyan, key+"="))
+	buf.AppendString(formatPrettyValue(value))
+}
+
+// paint wraps s in an ANSI color when colors are enabled
+func (e *prettyEncoder) paint(color, s string) string {
+	if !e.color {
+		return s
+	}
+	return color + s + ansiReset
+}
+
+// levelColor returns the color used for a level
+func levelColor(l zapcore.Level) string {
+	switch {
+	case l == zapcore.DebugLevel:
+		return ansiMagenta
+	case l == zapcore.InfoLevel:
+		return ansiBlue
+	case l == zapcore.WarnLevel:
+		return ansiYellow
+	default:
+		return ansiRed
+	}
+}
+
+// topLevelFields returns the fields that are not inside a namespace, without
+// duplicate keys. A namespace field is kept and holds the fields after it.
+func topLevelFields(fields []zapcore.Field) []zapcore.Field {
+	seen := make(map[string]bool, len(fields))
+	top := make([]zapcore.Field, 0, len(fields))
+	for _, f := range fields {
+		if seen[f.Key] || f.Type == zapcore.SkipType {
+			continue
+		}
+		seen[f.Key] = true
+		top = append(top, f)
+		if f.Type == zapcore.NamespaceType {
+			break
+		}
+	}
+	return top
+}
+
+// formatPrettyValue formats a field value for inline output. Strings are
+// quoted when they contain spaces or special characters; objects and arrays
+// are written as JSON.
+func formatPrettyValue(v any) string {
+	switch x := v.(type) {
+	case string:
+		if needsQuotes(x) {
+			return strconv.Quote(x)
+		}
+		return x
+	case time.Time:
+		return x.Format(time.RFC3339Nano)
+	case time.Duration:
+		return x.String()
+	case []byte:
+		return strconv.Quote(string(x))
+	case error:
+		return strconv.Quote(x.Error())
+	case fmt.Stringer:
+		return x.String()
+	case nil:
+		return "null"
+	}
+
+	switch reflect.ValueOf(v).Kind() {
+	case reflect.Map, reflect.Slice, reflect.Array, reflect.Struct, reflect.Pointer:
+		js, err := json.Marshal(v)
+		if err == nil {
+			return string(js)
+		}
+	}
+	return fmt.Sprint(v)
+}
+
+// needsQuotes reports whether s must be quoted to read as one value
+func needsQuotes(s string) bool {
+	if s == "" {
+		return true
+	}
+	for _, r := range s {
+		if unicode.IsSpace(r) || r == '"' || r == '=' || !unicode.IsPrint(r) {
+			return true
+		}
+	}
+	return false
+}
+
+// indent prefixes each line of s with prefix
+func indent(s, prefix string) string {
+	lines := strings.Split(strings.TrimRight(s, "\n"), "\n")
+	for i, line := range lines {
+		lines[i] = prefix + line
+	}
+	return strings.Join(lines, "\n")
+}
+
+// add records a context field added with With
+func (e *prettyEncoder) add(f zapcore.Field) {
+	e.context = append(e.context, f)
+}
+
+// The zapcore.ObjectEncoder methods record context fields
+
+func (e *prettyEncoder) AddArray(k string, v zapcore.ArrayMarshaler) error {
+	e.add(zap.Array(k, v))
+	return nil
+}
+
+func (e *prettyEncoder) AddObject(k string, v zapcore.ObjectMarshaler) error {
+	e.add(zap.Object(k, v))
+	return nil
+}
+
+func (e *prettyEncoder) AddReflected(k string, v interface{}) error {
+	e.add(zap.Reflect(k, v))
+	return nil
+}
+
+func (e *prettyEncoder) AddBinary(k string, v []byte)          { e.add(zap.Binary(k, v)) }
+func (e *prettyEncoder) AddByteString(k string, v []byte)      { e.add(zap.ByteString(k, v)) }
+func (e *prettyEncoder) AddBool(k string, v bool)              { e.add(zap.Bool(k, v)) }
+func (e *prettyEncoder) AddComplex128(k string, v complex128)  { e.add(zap.Complex128(k, v)) }
+func (e *prettyEncoder) AddComplex64(k string, v complex64)    { e.add(zap.Complex64(k, v)) }
+func (e *prettyEncoder) AddDuration(k string, v time.Duration) { e.add(zap.Duration(k, v)) }
+func (e *prettyEncoder) AddFloat64(k string, v float64)        { e.add(zap.Float64(k, v)) }
+func (e *prettyEncoder) AddFloat32(k string, v float32)        { e.add(zap.Float32(k, v)) }
+func (e *prettyEncoder) AddInt(k string, v int)                { e.add(zap.Int(k, v)) }
+func (e *prettyEncoder) AddInt64(k string, v int64)            { e.add(zap.Int64(k, v)) }
+func (e *prettyEncoder) AddInt32(k string, v int32)            { e.add(zap.Int32(k, v)) }
+func (e *prettyEncoder) AddInt16(k string, v int16)            { e.add(zap.Int16(k, v)) }
+func (e *prettyEncoder) AddInt8(k string, v int8)              { e.add(zap.Int8(k, v)) }
+func (e *prettyEncoder) AddString(k, v string)                 { e.add(zap.String(k, v)) }
+func (e *prettyEncoder) AddTime(k string, v time.Time)         { e.add(zap.Time(k, v)) }
+func (e *prettyEncoder) AddUint(k string, v uint)              { e.add(zap.Uint(k, v)) }
+func (e *prettyEncoder) AddUint64(k string, v uint64)          { e.add(zap.Uint64(k, v)) }
+func (e *prettyEncoder) AddUint32(k string, v uint32)          { e.add(zap.Uint32(k, v)) }
+func (e *prettyEncoder) AddUint16(k string, v uint16)          { e.add(zap.Uint16(k, v)) }
+func (e *prettyEncoder) AddUint8(k string, v uint8)            { e.add(zap.Uint8(k, v)) }
+func (e *prettyEncoder) AddUintptr(k string, v uintptr)        { e.add(zap.Uintptr(k, v)) }
+func (e *prettyEncoder) OpenNamespace(k string)                { e.add(zap.Namespace(k)) }
@@ -0,0 +1,155 @@
+package logger
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+)
+
+func encodePretty(t *testing.T, enc zapcore.Encoder, ent zapcore.Entry, fields ...zapcore.Field) string {
+	t.Helper()
+	buf, err := enc.EncodeEntry(ent, fields)
+	if err != nil {
+		t.Fatalf("EncodeEntry() error = %v", err)
+	}
+	defer buf.Free()
+	return buf.String()
+}
+
+func TestPrettyEncoder(t *testing.T) {
+	ent := zapcore.Entry{
+		Level:   zapcore.InfoLevel,
+		Time:    time.Date(2024, 3, 1, 12, 4, 5, 123e6, time.UTC),
+		Message: "Server started",
+		Caller:  zapcore.NewEntryCaller(0, "/src/app/api/server.go", 42, true),
+	}
+
+	enc := newPrettyEncoder(PrettyConfig{NoColor: true})
+	child := enc.Clone()
+	child.AddString("service", "orders")
+
+	tests := []struct {
+		name   string
+		enc    zapcore.Encoder
+		fields []zapcore.Field
+		want   string
+	}{
+		{
+			"plain",
+			enc,
+			nil,
+			"12:04:05.123 INFO  api/server.go:42  Server started\n",
+		},
+		{
+			"inline fields",
+			child,
+			[]zapcore.Field{
+				zap.Int("port", 8080),
+				zap.String("addr", "localhost:8080"),
+				zap.String("note", "two words"),
+				zap.Duration("timeout", 5*time.Second),
+				zap.Any("tags", []string{"a", "b"}),
+			},
+			`12:04:05.123 INFO  api/server.go:42  Server started service=orders port=8080 addr=localhost:8080 note="two words" timeout=5s tags=["a","b"]` + "\n",
+		},
+		{
+			"namespace",
+			enc,
+			[]zapcore.Field{zap.String("id", "1"), zap.Namespace("http"), zap.Int("status", 200)},
+			`12:04:05.123 INFO  api/server.go:42  Server started id=1 http={"status":200}` + "\n",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := encodePretty(t, tt.enc, ent, tt.fields...); got != tt.want {
+				t.Errorf("Expected %q, got %q", tt.want, got)
+			}
+		})
+	}
+
+	if got := encodePretty(t, enc, ent, zap.Int("port", 1)); strings.Contains(got, "service") {
+		t.Errorf("Expected Clone not to modify the original, got %q", got)
+	}
+}
+
+func TestPrettyEncoder_Errors(t *testing.T) {
+	ent := zapcore.Entry{Level: zapcore.ErrorLevel, Time: time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC), Message: "failed"}
+	err := fmt.Errorf("save order: %w", errors.New("connection refused"))
+	multiline := errors.New("line one\nline two")
+
+	inline := encodePretty(t, newPrettyEncoder(PrettyConfig{NoColor: true}), ent, zap.Error(err))
+	want := `00:00:00.000 ERROR  failed error="save order: connection refused"` + "\n"
+	if inline != want {
+		t.Errorf("Expected %q, got %q", want, inline)
+	}
+
+	ent.Stack = "main.main\n\t/src/main.go:10"
+	multi := encodePretty(t, newPrettyEncoder(PrettyConfig{NoColor: true, MultilineErrors: true}), ent, zap.Error(err), zap.Int("attempt", 2), zap.NamedError("cause", multiline))
+	want = "00:00:00.000 ERROR  failed attempt=2\n  error:\n    save order: connection refused\n  cause:\n    line one\n    line two\n    main.main\n    \t/src/main.go:10\n"
+	if multi != want {
+		t.Errorf("Expected %q, got %q", want, multi)
+	}
+}
+
+func TestPrettyEncoder_Color(t *testing.T) {
+	t.Setenv("NO_COLOR", "")
+	os.Unsetenv("NO_COLOR")
+
+	ent := zapcore.Entry{Level: zapcore.WarnLevel, Time: time.Now(), Message: "slow"}
+	got := encodePretty(t, newPrettyEncoder(PrettyConfig{}), ent, zap.Int("ms", 900))
+	if !strings.Contains(got, ansiYellow+"WARN "+ansiReset) {
+		t.Errorf("Expected colored level, got %q", got)
+	}
+	if !strings.Contains(got, ansiCyan+"ms="+ansiReset+"900") {
+		t.Errorf("Expected colored key, got %q", got)
+	}
+
+	t.Setenv("NO_COLOR", "1")
+	if got := encodePretty(t, newPrettyEncoder(PrettyConfig{}), ent); strings.Contains(got, "\x1b[") {
+		t.Errorf("Expected NO_COLOR to disable colors, got %q", got)
+	}
+}
+
+func TestWithPrettyConsole(t *testing.T) {
+	t.Setenv("NO_COLOR", "1")
+	path := filepath.Join(t.TempDir(), "app.log")
+	log, err := New(
+		WithOutputPaths([]string{path}),
+		WithPrettyConsole(),
+		WithServiceName("orders"),
+		WithRedactedKeys("password"),
+	)
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+
+	log.Info("user created", zap.String("user", "jane"), zap.String("password", "hunter2"))
+	log.Sync()
+
+	data, _ := os.ReadFile(path)
+	got := string(data)
+	for _, want := range []string{"INFO ", "logger/pretty_test.go:", "user created", "service=orders", "user=jane", "password=[REDACTED]"} {
+		if !strings.Contains(got, want) {
+			t.Errorf("Expected output to contain %q, got %q", want, got)
+		}
+	}
+
+	gcp, err := New(WithOutputPaths([]string{path}), WithPrettyConsole(), WithGCPEncoding())
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+	os.Truncate(path, 0)
+	gcp.Info("json")
+	gcp.Sync()
+	if data, _ := os.ReadFile(path); !strings.HasPrefix(string(data), "{") {
+		t.Errorf("Expected GCP encoding to take precedence, got %s", data)
+	}
+}
@@ -0,0 +1,153 @@
+package logger
+
+import (
+	"net/http"
+	"strings"
+
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+)
+
+// RedactedValue replaces the value of redacted fields
+const RedactedValue = "[REDACTED]"
+
+// WithRedactedKeys replaces the value of any field whose key matches one of
+// keys with RedactedValue. Matching ignores case, and a key also matches
+// fields that end with it after a "_", "." or "-" separator, so "password"
+// redacts "password", "user_password" and "user.Password". Maps logged with
+// zap.Any or WithFields are redacted one level deep.
+func WithRedactedKeys(keys ...string) Option {
+	return func(cfg *Config) {
+		cfg.RedactedKeys = append(cfg.RedactedKeys, keys...)
+	}
+}
+
+// redactor matches field keys against a list of sensitive keys
+type redactor struct {
+	keys []string
+}
+
+func newRedactor(keys []string) *redactor {
+	lower := make([]string, 0, len(keys))
+	for _, k := range keys {
+		if k != "" {
+			lower = append(lower, strings.ToLower(k))
+		}
+	}
+	return &redactor{keys: lower}
+}
+
+// matches reports whether key should be redacted
+func (r *redactor) matches(key string) bool {
+	key = strings.ToLower(key)
+	for _, k := range r.keys {
+		if key == k {
+			return true
+		}
+		if strings.HasSuffix(key, k) {
+			switch key[len(key)-len(k)-1] {
+			case '_', '.', '-':
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// redactField returns f with its value, or the values of a map, redacted
+func (r *redactor) redactField(f zapcore.Field) (zapcore.Field, bool) {
+	if r.matches(f.Key) {
+		return zap.String(f.Key, RedactedValue), true
+	}
+	if f.Type != zapcore.ReflectType {
+		return f, false
+	}
+
+	switch m := f.Interface.(type) {
+	case map[string]interface{}:
+		if out, ok := redactMap(r, m, RedactedValue); ok {
+			return zap.Any(f.Key, out), true
+		}
+	case map[string]string:
+		if out, ok := redactMap(r, m, RedactedValue); ok {
+			return zap.Any(f.Key, out), true
+		}
+	case http.Header:
+		if out, ok := redactMap(r, m, []string{RedactedValue}); ok {
+			return zap.Any(f.Key, http.Header(out)), true
+		}
+	case map[string][]string:
+		if out, ok := redactMap(r, m, []string{RedactedValue}); ok {
+			return zap.Any(f.Key, out), true
+		}
+	}
+	return f, false
+}
+
+// redactMap returns a copy of m with matching keys set to redacted, or false
+// if nothing matched
+func redactMap[V any](r *redactor, m map[string]V, redacted V) (map[string]V, bool) {
+	var out map[string]V
+	for k := range m {
+		if !r.matches(k) {
+			continue
+		}
+		if out == nil {
+			out = make(map[string]V, len(m))
+			for k2, v := range m {
+				out[k2] = v
+			}
+		}
+		out[k] = redacted
+	}
+	return out, out != nil
+}
+
+// redactCore redacts sensitive fields before they reach the encoder
+type redactCore struct {
+	zapcore.Core
+	redactor *redactor
+}
+
+func newRedactCore(core zapcore.Core, keys []string) zapcore.Core {
+	return &redactCore{Core: core, redactor: newRedactor(keys)}
+}
+
+func (c *redactCore) With(fields []zapcore.Field) zapcore.Core {
+	return &redactCore{Core: c.Core.With(c.redact(fields)), redactor: c.redactor}
+}
+
+func (c *redactCore) Check(ent zapcore.Entry, ce *zapcore.CheckedEntry) *zapcore.CheckedEntry {
+	if c.Enabled(ent.Level) {
+		return ce.AddCore(ent, c)
+	}
+	return ce
+}
+
+// Write checks the entry against the wrapped core again so that cores teed
+// inside it by WithCoreWrapper keep their own levels
+func (c *redactCore) Write(ent zapcore.Entry, fields []zapcore.Field) error {
+	if ce := c.Core.Check(ent, nil); ce != nil {
+		ce.Write(c.redact(fields)...)
+	}
+	return nil
+}
+
+// redact returns fields with sensitive values replaced, copying only if needed
+func (c *redactCore) redact(fields []zapcore.Field) []zapcore.Field {
+	var out []zapcore.Field
+	for i, f := range fields {
+		redacted, changed := c.redactor.redactField(f)
+		if !changed {
+			continue
+		}
+		if out == nil {
+			out = append([]zapcore.Field(nil), fields...)
+		}
+		out[i] = redacted
+	}
+	if out == nil {
+		return fields
+	}
+	return out
+}
@@ -0,0 +1,96 @@
+package logger
+
+import (
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+	"go.uber.org/zap/zaptest/observer"
+)
+
+func TestRedactor_Matches(t *testing.T) {
+	r := newRedactor([]string{"password", "Authorization", "ssn"})
+
+	tests := []struct {
+		key  string
+		want bool
+	}{
+		{"password", true},
+		{"PASSWORD", true},
+		{"user_password", true},
+		{"user.password", true},
+		{"req_header_Authorization", true},
+		{"x-ssn", true},
+		{"passwordless", false},
+		{"mypassword", false},
+		{"username", false},
+	}
+
+	for _, tc := range tests {
+		if got := r.matches(tc.key); got != tc.want {
+			t.Errorf("matches(%q) = %v, want %v", tc.key, got, tc.want)
+		}
+	}
+}
+
+func TestRedactCore(t *testing.T) {
+	core, logs := observer.New(zapcore.DebugLevel)
+	log := zap.New(newRedactCore(core, []string{"password", "authorization"}))
+
+	log.With(zap.String("password", "hunter2")).Info("login",
+		zap.String("user", "ada"),
+		zap.String("req_header_Authorization", "Bearer abc"),
+		zap.Any("body", map[string]interface{}{"email": "ada@example.com", "password": "hunter2"}),
+		zap.Any("headers", http.Header{"Authorization": {"Bearer abc"}, "Accept": {"*/*"}}),
+	)
+	log.Sugar().Infow("sugared", "password", "hunter2")
+
+	entries := logs.All()
+	fields := entries[0].ContextMap()
+
+	if fields["password"] != RedactedValue || fields["req_header_Authorization"] != RedactedValue {
+		t.Errorf("Expected top-level fields to be redacted, got %v", fields)
+	}
+	if fields["user"] != "ada" {
+		t.Errorf("Expected user to be kept, got %v", fields["user"])
+	}
+	body := fields["body"].(map[string]interface{})
+	if body["password"] != RedactedValue || body["email"] != "ada@example.com" {
+		t.Errorf("Expected map value to be redacted, got %v", body)
+	}
+	headers := fields["headers"].(http.Header)
+	if headers.Get("Authorization") != RedactedValue || headers.Get("Accept") != "*/*" {
+		t.Errorf("Expected header to be redacted, got %v", headers)
+	}
+	if got := entries[1].ContextMap()["password"]; got != RedactedValue {
+		t.Errorf("Expected sugared field to be redacted, got %v", got)
+	}
+}
+
+func TestWithRedactedKeys(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "app.log")
+	log, err := New(
+		WithOutputPaths([]string{path}),
+		WithRedactedKeys("ssn"),
+		WithInitialFields(map[string]interface{}{"ssn": "123-45-6789"}),
+	)
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+
+	log.WithFields(map[string]interface{}{"customer_ssn": "987-65-4321"}).Info("customer verified")
+	log.Sync()
+
+	data, _ := os.ReadFile(path)
+	out := string(data)
+	if strings.Contains(out, "6789") || strings.Contains(out, "4321") {
+		t.Errorf("Expected SSNs to be redacted, got %s", out)
+	}
+	if !strings.Contains(out, RedactedValue) {
+		t.Errorf("Expected %s in output, got %s", RedactedValue, out)
+	}
+}
@@ -0,0 +1,127 @@
+package logger
+
+import (
+	"context"
+	"encoding/hex"
+	"strings"
+	"sync/atomic"
+
+	"go.uber.org/zap"
+)
+
+// Field names used for trace correlation
+const (
+	TraceIDKey = "trace_id"
+	SpanIDKey  = "span_id"
+)
+
+// SpanContext identifies the active trace span
+type SpanContext struct {
+	TraceID string
+	SpanID  string
+	Sampled bool
+}
+
+// IsValid reports whether the span context has a trace ID
+func (sc SpanContext) IsValid() bool {
+	return sc.TraceID != ""
+}
+
+// TraceExtractor finds the active span in a context. Register one to
+// correlate logs with a tracing library such as OpenTelemetry.
+type TraceExtractor func(ctx context.Context) (SpanContext, bool)
+
+// traceExtractor holds the registered TraceExtractor
+var traceExtractor atomic.Value
+
+// spanKey is the key for SpanContext values in contexts
+const spanKey contextKey = loggerKey + 1
+
+// RegisterTraceExtractor sets the function used to find the active span.
+// Passing nil restores the default, which only sees spans stored with
+// ContextWithSpan.
+func RegisterTraceExtractor(fn TraceExtractor) {
+	traceExtractor.Store(fn)
+}
+
+// ContextWithSpan returns a context carrying sc
+func ContextWithSpan(ctx context.Context, sc SpanContext) context.Context {
+	return context.WithValue(ctx, spanKey, sc)
+}
+
+// SpanFromContext returns the active span, consulting the registered
+// extractor first
+func SpanFromContext(ctx context.Context) (SpanContext, bool) {
+	if fn, _ := traceExtractor.Load().(TraceExtractor); fn != nil {
+		if sc, ok := fn(ctx); ok && sc.IsValid() {
+			return sc, true
+		}
+	}
+	sc, ok := ctx.Value(spanKey).(SpanContext)
+	return sc, ok && sc.IsValid()
+}
+
+// ParseTraceparent parses a W3C traceparent header
+// ("00-<trace-id>-<span-id>-<flags>")
+func ParseTraceparent(header string) (SpanContext, bool) {
+	parts := strings.Split(strings.TrimSpace(header), "-")
+	if len(parts) < 4 || len(parts[0]) != 2 || parts[0] == "ff" ||
+		!isHexID(parts[1], 32) || !isHexID(parts[2], 16) || len(parts[3]) != 2 {
+		return SpanContext{}, false
+	}
+	// Version 00 has exactly four fields
+	if parts[0] == "00" && len(parts) != 4 {
+		return SpanContext{}, false
+	}
+
+	flags, err := hex.DecodeString(parts[3])
+	if err != nil {
+		return SpanContext{}, false
+	}
+
+	return SpanContext{
+		TraceID: parts[1],
+		SpanID:  parts[2],
+		Sampled: flags[0]&1 == 1,
+	}, true
+}
+
+// Traceparent formats sc as a W3C traceparent header, or returns an empty
+// string if sc has no valid trace and span IDs
+func (sc SpanContext) Traceparent() string {
+	if !isHexID(sc.TraceID, 32) || !isHexID(sc.SpanID, 16) {
+		return ""
+	}
+	flags := "00"
+	if sc.Sampled {
+		flags = "01"
+	}
+	return "00-" + sc.TraceID + "-" + sc.SpanID + "-" + flags
+}
+
+// isHexID reports whether s is a non-zero lowercase hex string of length n
+func isHexID(s string, n int) bool {
+	if len(s) != n || strings.Trim(s, "0") == "" {
+		return false
+	}
+	for _, c := range s {
+		if (c < '0' || c > '9') && (c < 'a' || c > 'f') {
+			return false
+		}
+	}
+	return true
+}
+
+// WithTrace returns a child logger with trace_id and span_id fields from the
+// active span in ctx, or l itself if there is none
+func (l *Logger) WithTrace(ctx context.Context) *Logger {
+	sc, ok := SpanFromContext(ctx)
+	if !ok {
+		return l
+	}
+	fields := []zap.Field{zap.String(TraceIDKey, sc.TraceID)}
+	if sc.SpanID != "" {
+		fields = append(fields, zap.String(SpanIDKey, sc.SpanID))
+	}
+	return l.With(fields...)
+}
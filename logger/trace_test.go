@@ -0,0 +1,101 @@
+package logger
+
+import (
+	"context"
+	"testing"
+)
+
+func TestParseTraceparent(t *testing.T) {
+	tests := []struct {
+		name        string
+		header      string
+		wantOK      bool
+		wantSampled bool
+	}{
+		{"sampled", "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01", true, true},
+		{"not sampled", "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-00", true, false},
+		{"future version with extra field", "01-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01-extra", true, true},
+		{"zero trace id", "00-00000000000000000000000000000000-00f067aa0ba902b7-01", false, false},
+		{"uppercase", "00-4BF92F3577B34DA6A3CE929D0E0E4736-00f067aa0ba902b7-01", false, false},
+		{"invalid version", "ff-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01", false, false},
+		{"version 00 with extra field", "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01-extra", false, false},
+		{"garbage", "not-a-traceparent", false, false},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			sc, ok := ParseTraceparent(tc.header)
+			if ok != tc.wantOK {
+				t.Fatalf("Expected ok=%v, got %v", tc.wantOK, ok)
+			}
+			if !ok {
+				return
+			}
+			if sc.TraceID != "4bf92f3577b34da6a3ce929d0e0e4736" || sc.SpanID != "00f067aa0ba902b7" {
+				t.Errorf("Unexpected span context %+v", sc)
+			}
+			if sc.Sampled != tc.wantSampled {
+				t.Errorf("Expected sampled=%v, got %v", tc.wantSampled, sc.Sampled)
+			}
+		})
+	}
+}
+
+func TestSpanContext_Traceparent(t *testing.T) {
+	for _, header := range []string{
+		"00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01",
+		"00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-00",
+	} {
+		sc, _ := ParseTraceparent(header)
+		if got := sc.Traceparent(); got != header {
+			t.Errorf("Expected %q, got %q", header, got)
+		}
+	}
+
+	if got := (SpanContext{TraceID: "trace-1", SpanID: "span-1"}).Traceparent(); got != "" {
+		t.Errorf("Expected no traceparent for invalid IDs, got %q", got)
+	}
+}
+
+func TestWithTrace(t *testing.T) {
+	log, observed := captureOutput(t)
+	ctx := ContextWithSpan(context.Background(), SpanContext{TraceID: "trace-1", SpanID: "span-1"})
+
+	log.WithTrace(ctx).Info("traced")
+	log.WithTrace(context.Background()).Info("untraced")
+
+	entries := observed.All()
+	if len(entries) != 2 {
+		t.Fatalf("Expected 2 log entries, got %d", len(entries))
+	}
+
+	fields := entries[0].ContextMap()
+	if fields[TraceIDKey] != "trace-1" || fields[SpanIDKey] != "span-1" {
+		t.Errorf("Expected trace fields, got %v", fields)
+	}
+	if _, ok := entries[1].ContextMap()[TraceIDKey]; ok {
+		t.Error("Expected no trace fields without a span")
+	}
+}
+
+type fakeSpanKey struct{}
+
+func TestWithContext_Trace(t *testing.T) {
+	log, observed := captureOutput(t)
+
+	RegisterTraceExtractor(func(ctx context.Context) (SpanContext, bool) {
+		id, ok := ctx.Value(fakeSpanKey{}).(string)
+		return SpanContext{TraceID: id, SpanID: "from-extractor"}, ok
+	})
+	defer RegisterTraceExtractor(nil)
+
+	ctx := NewContext(context.Background(), log)
+	ctx = context.WithValue(ctx, fakeSpanKey{}, "trace-2")
+
+	WithContext(ctx).Info("handled")
+
+	fields := observed.All()[0].ContextMap()
+	if fields[TraceIDKey] != "trace-2" || fields[SpanIDKey] != "from-extractor" {
+		t.Errorf("Expected extractor trace fields, got %v", fields)
+	}
+}
@@ -0,0 +1,66 @@
+package mail
+
+import (
+	"context"
+	"sync"
+
+	"github.com/StairSupplies/go-core/logger"
+	"go.uber.org/zap"
+)
+
+// DevSender logs messages instead of delivering them.
+// Use it in local development and tests so no real email is sent.
+type DevSender struct {
+	log      *logger.Logger
+	mu       sync.Mutex
+	messages []Message
+}
+
+// NewDevSender creates a sender that logs each message to log.
+// If log is nil, a default logger is created.
+func NewDevSender(log *logger.Logger) *DevSender {
+	if log == nil {
+		log, _ = logger.New(logger.WithInitialFields(map[string]interface{}{
+			"component": "mail",
+		}))
+	}
+	return &DevSender{log: log}
+}
+
+// Send validates and logs msg, then records it for inspection via Messages
+func (s *DevSender) Send(ctx context.Context, msg *Message) error {
+	if err := msg.Validate(); err != nil {
+		return err
+	}
+
+	attachments := make([]string, 0, len(msg.Attachments))
+	for _, a := range msg.Attachments {
+		attachments = append(attachments, a.Filename)
+	}
+
+	s.log.Info("Email not sent (dev mode)",
+		zap.String("from", msg.From),
+		zap.Strings("to", msg.To),
+		zap.Strings("cc", msg.Cc),
+		zap.Strings("bcc", msg.Bcc),
+		zap.String("subject", msg.Subject),
+		zap.String("text", msg.Text),
+		zap.Int("html_length", len(msg.HTML)),
+		zap.Strings("attachments", attachments),
+	)
+
+	s.mu.Lock()
+	s.messages = append(s.messages, *msg)
+	s.mu.Unlock()
+
+	return nil
+}
+
+// Messages returns the messages sent so far
+func (s *DevSender) Messages() []Message {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return append([]Message(nil), s.messages...)
+}
+
+var _ Sender = (*DevSender)(nil)
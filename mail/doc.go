@@ -0,0 +1,81 @@
+/*
+Package mail provides transactional email sending with SMTP and Amazon SES backends.
+
+Messages are built once and delivered through the Sender interface, so services can
+log messages locally with DevSender and deliver them through SMTP or SES in production.
+
+# Features
+
+  - Sender interface with SMTP, SES, and development implementations
+  - Plain text and HTML bodies sent as multipart/alternative
+  - File and inline attachments
+  - Subject and body templates loaded from an embed.FS
+  - Retry with jittered exponential backoff for transient failures
+  - Permanent failures (rejected recipients, bad credentials) reported as ErrPermanent
+
+# Basic Usage
+
+Sending a message through SMTP:
+
+	sender, err := mail.NewSMTP(mail.SMTPConfig{
+		Host:     "smtp.example.com",
+		Username: cfg.SMTPUser,
+		Password: cfg.SMTPPassword,
+	})
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	err = sender.Send(ctx, &mail.Message{
+		From:    "Orders <orders@example.com>",
+		To:      []string{"jane@example.com"},
+		Subject: "Your order has shipped",
+		Text:    "Your order #1001 is on its way.",
+		HTML:    "<p>Your order <b>#1001</b> is on its way.</p>",
+	})
+
+Sending through Amazon SES instead:
+
+	sender, err := mail.NewSES(mail.SESConfig{
+		Region:          "us-east-1",
+		AccessKeyID:     cfg.AWSAccessKeyID,
+		SecretAccessKey: cfg.AWSSecretAccessKey,
+	})
+
+# Templates
+
+Templates are looked up by name from files following the convention
+<name>.subject.tmpl, <name>.txt.tmpl, and <name>.html.tmpl. HTML templates are
+rendered with html/template and can share layouts defined in other .html.tmpl files:
+
+	//go:embed templates/*.tmpl
+	var templateFS embed.FS
+
+	sub, _ := fs.Sub(templateFS, "templates")
+	templates, err := mail.ParseTemplates(sub)
+
+	msg, err := templates.Render("order_shipped", order)
+	msg.From = "Orders <orders@example.com>"
+	msg.To = []string{order.CustomerEmail}
+	err = sender.Send(ctx, msg)
+
+# Retries
+
+Wrap any sender to retry transient failures such as dropped connections or throttling.
+Errors wrapping ErrPermanent or ErrInvalidMessage are not retried:
+
+	sender = mail.NewRetrySender(sender, mail.RetryConfig{
+		MaxAttempts: 5,
+		BaseDelay:   time.Second,
+	})
+
+# Development Mode
+
+DevSender logs each message with the go-core/logger package instead of sending it,
+and records messages so tests can inspect them:
+
+	sender := mail.NewDevSender(log)
+	sender.Send(ctx, msg)
+	sent := sender.Messages()
+*/
+package mail
@@ -0,0 +1,72 @@
+package mail_test
+
+import (
+	"context"
+	"fmt"
+	"testing/fstest"
+
+	"github.com/StairSupplies/go-core/logger"
+	"github.com/StairSupplies/go-core/mail"
+)
+
+func ExampleDevSender() {
+	sender := mail.NewDevSender(logger.NewNopLogger())
+
+	err := sender.Send(context.Background(), &mail.Message{
+		From:    "orders@example.com",
+		To:      []string{"jane@example.com"},
+		Subject: "Your order has shipped",
+		Text:    "Your order #1001 is on its way.",
+	})
+	if err != nil {
+		fmt.Printf("Error sending email: %v\n", err)
+		return
+	}
+
+	for _, msg := range sender.Messages() {
+		fmt.Printf("%s -> %v: %s\n", msg.From, msg.To, msg.Subject)
+	}
+	// Output: orders@example.com -> [jane@example.com]: Your order has shipped
+}
+
+func ExampleTemplates_Render() {
+	// In a real application the templates would come from an embed.FS
+	templates, err := mail.ParseTemplates(fstest.MapFS{
+		"order_shipped.subject.tmpl": {Data: []byte("Order #{{.ID}} has shipped")},
+		"order_shipped.txt.tmpl":     {Data: []byte("Hi {{.Name}}, your order is on its way.")},
+	})
+	if err != nil {
+		fmt.Printf("Error parsing templates: %v\n", err)
+		return
+	}
+
+	msg, err := templates.Render("order_shipped", map[string]any{"ID": 1001, "Name": "Jane"})
+	if err != nil {
+		fmt.Printf("Error rendering template: %v\n", err)
+		return
+	}
+
+	fmt.Println(msg.Subject)
+	fmt.Println(msg.Text)
+	// Output:
+	// Order #1001 has shipped
+	// Hi Jane, your order is on its way.
+}
+
+func ExampleNewSMTP() {
+	sender, err := mail.NewSMTP(mail.SMTPConfig{
+		Host:     "smtp.example.com",
+		Username: "apikey",
+		Password: "secret",
+	})
+	if err != nil {
+		fmt.Printf("Error creating sender: %v\n", err)
+		return
+	}
+
+	// Wrap the sender so transient failures are retried
+	_ = mail.NewRetrySender(sender, mail.RetryConfig{MaxAttempts: 5})
+
+	fmt.Println("SMTP sender created successfully")
+	// Output: SMTP sender created successfully
+}
@@ -0,0 +1,318 @@
+package mail
+
+import (
+	"bytes"
+	"context"
+	"crypto/rand"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"mime"
+	"mime/multipart"
+	"mime/quotedprintable"
+	"net/mail"
+	"net/textproto"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+)
+
+// Common mail errors
+var (
+	// ErrInvalidMessage indicates that a message is missing required fields or has malformed addresses.
+	ErrInvalidMessage = errors.New("invalid message")
+
+	// ErrPermanent indicates a delivery failure that will not succeed on retry,
+	// such as a rejected recipient or invalid credentials.
+	ErrPermanent = errors.New("permanent delivery failure")
+)
+
+// Sender delivers email messages
+type Sender interface {
+	// Send delivers msg, returning an error wrapping ErrPermanent when retrying cannot help.
+	Send(ctx context.Context, msg *Message) error
+}
+
+// Message is an email message with optional plain text and HTML bodies
+type Message struct {
+	From        string
+	To          []string
+	Cc          []string
+	Bcc         []string
+	ReplyTo     string
+	Subject     string
+	Text        string
+	HTML        string
+	Headers     map[string]string
+	Attachments []Attachment
+}
+
+// Attachment is a file attached to a message
+type Attachment struct {
+	// Filename is the name shown to the recipient
+	Filename string
+	// ContentType is the MIME type. Inferred from Filename when empty.
+	ContentType string
+	// Data is the file content
+	Data []byte
+	// ContentID marks the attachment as inline so HTML can reference it as cid:<ContentID>
+	ContentID string
+}
+
+// Validate checks that the message has a sender, at least one recipient,
+// a subject, and a body, and that all addresses parse
+func (m *Message) Validate() error {
+	if m.From == "" {
+		return fmt.Errorf("%w: from address is required", ErrInvalidMessage)
+	}
+	if len(m.To)+len(m.Cc)+len(m.Bcc) == 0 {
+		return fmt.Errorf("%w: at least one recipient is required", ErrInvalidMessage)
+	}
+	if m.Subject == "" {
+		return fmt.Errorf("%w: subject is required", ErrInvalidMessage)
+	}
+	if m.Text == "" && m.HTML == "" {
+		return fmt.Errorf("%w: text or HTML body is required", ErrInvalidMessage)
+	}
+
+	addresses := append([]string{m.From}, m.allRecipients()...)
+	if m.ReplyTo != "" {
+		addresses = append(addresses, m.ReplyTo)
+	}
+	for _, addr := range addresses {
+		if _, err := mail.ParseAddress(addr); err != nil {
+			return fmt.Errorf("%w: invalid address %q", ErrInvalidMessage, addr)
+		}
+	}
+
+	return nil
+}
+
+// allRecipients returns To, Cc, and Bcc addresses in that order
+func (m *Message) allRecipients() []string {
+	all := make([]string, 0, len(m.To)+len(m.Cc)+len(m.Bcc))
+	all = append(all, m.To...)
+	all = append(all, m.Cc...)
+	return append(all, m.Bcc...)
+}
+
+// envelopeRecipients returns the bare addresses of every recipient
+func (m *Message) envelopeRecipients() ([]string, error) {
+	recipients := m.allRecipients()
+	addrs := make([]string, 0, len(recipients))
+	for _, r := range recipients {
+		a, err := mail.ParseAddress(r)
+		if err != nil {
+			return nil, fmt.Errorf("%w: invalid address %q", ErrInvalidMessage, r)
+		}
+		addrs = append(addrs, a.Address)
+	}
+	return addrs, nil
+}
+
+// envelopeSender returns the bare address of the sender
+func (m *Message) envelopeSender() (string, error) {
+	a, err := mail.ParseAddress(m.From)
+	if err != nil {
+		return "", fmt.Errorf("%w: invalid address %q", ErrInvalidMessage, m.From)
+	}
+	return a.Address, nil
+}
+
+// Bytes renders the message in RFC 5322 format with MIME bodies and attachments.
+// Bcc recipients are not included in the headers.
+func (m *Message) Bytes() ([]byte, error) {
+	if err := m.Validate(); err != nil {
+		return nil, err
+	}
+
+	var buf bytes.Buffer
+	header := textproto.MIMEHeader{}
+
+	from, _ := mail.ParseAddress(m.From)
+	header.Set("From", from.String())
+	if len(m.To) > 0 {
+		header.Set("To", formatAddressList(m.To))
+	}
+	if len(m.Cc) > 0 {
+		header.Set("Cc", formatAddressList(m.Cc))
+	}
+	if m.ReplyTo != "" {
+		header.Set("Reply-To", formatAddressList([]string{m.ReplyTo}))
+	}
+	header.Set("Subject", mime.QEncoding.Encode("utf-8", m.Subject))
+	header.Set("Date", time.Now().Format(time.RFC1123Z))
+	header.Set("Message-ID", messageID(from.Address))
+	header.Set("MIME-Version", "1.0")
+	for k, v := range m.Headers {
+		header.Set(k, v)
+	}
+
+	// Build the body first so its content type is known when writing headers
+	var body bytes.Buffer
+	contentType, err := m.writeBody(&body)
+	if err != nil {
+		return nil, err
+	}
+	header.Set("Content-Type", contentType)
+	if !strings.HasPrefix(contentType, "multipart/") {
+		header.Set("Content-Transfer-Encoding", "quoted-printable")
+	}
+
+	writeHeader(&buf, header)
+	buf.Write(body.Bytes())
+
+	return buf.Bytes(), nil
+}
+
+// writeBody writes the message body and returns its content type
+func (m *Message) writeBody(w *bytes.Buffer) (string, error) {
+	if len(m.Attachments) == 0 {
+		return m.writeAlternative(w)
+	}
+
+	mixed := multipart.NewWriter(w)
+
+	var content bytes.Buffer
+	contentType, err := m.writeAlternative(&content)
+	if err != nil {
+		return "", err
+	}
+	partHeader := textproto.MIMEHeader{"Content-Type": {contentType}}
+	if !strings.HasPrefix(contentType, "multipart/") {
+		partHeader.Set("Content-Transfer-Encoding", "quoted-printable")
+	}
+	part, err := mixed.CreatePart(partHeader)
+	if err != nil {
+		return "", err
+	}
+	part.Write(content.Bytes())
+
+	for _, a := range m.Attachments {
+		if err := writeAttachment(mixed, a); err != nil {
+			return "", err
+		}
+	}
+
+	if err := mixed.Close(); err != nil {
+		return "", err
+	}
+	return "multipart/mixed; boundary=" + mixed.Boundary(), nil
+}
+
+// writeAlternative writes the text and/or HTML bodies and returns their content type
+func (m *Message) writeAlternative(w *bytes.Buffer) (string, error) {
+	switch {
+	case m.HTML == "":
+		return "text/plain; charset=utf-8", writeQuotedPrintable(w, m.Text)
+	case m.Text == "":
+		return "text/html; charset=utf-8", writeQuotedPrintable(w, m.HTML)
+	}
+
+	alt := multipart.NewWriter(w)
+	for _, body := range []struct{ contentType, content string }{
+		{"text/plain; charset=utf-8", m.Text},
+		{"text/html; charset=utf-8", m.HTML},
+	} {
+		part, err := alt.CreatePart(textproto.MIMEHeader{
+			"Content-Type":              {body.contentType},
+			"Content-Transfer-Encoding": {"quoted-printable"},
+		})
+		if err != nil {
+			return "", err
+		}
+		qp := quotedprintable.NewWriter(part)
+		qp.Write([]byte(body.content))
+		if err := qp.Close(); err != nil {
+			return "", err
+		}
+	}
+
+	if err := alt.Close(); err != nil {
+		return "", err
+	}
+	return "multipart/alternative; boundary=" + alt.Boundary(), nil
+}
+
+// writeAttachment writes a as a base64-encoded part
+func writeAttachment(w *multipart.Writer, a Attachment) error {
+	contentType := a.ContentType
+	if contentType == "" {
+		contentType = mime.TypeByExtension(filepath.Ext(a.Filename))
+	}
+	if contentType == "" {
+		contentType = "application/octet-stream"
+	}
+
+	disposition := "attachment"
+	header := textproto.MIMEHeader{}
+	if a.ContentID != "" {
+		disposition = "inline"
+		header.Set("Content-ID", "<"+a.ContentID+">")
+	}
+	header.Set("Content-Type", contentType)
+	header.Set("Content-Transfer-Encoding", "base64")
+	header.Set("Content-Disposition", mime.FormatMediaType(disposition, map[string]string{"filename": a.Filename}))
+
+	part, err := w.CreatePart(header)
+	if err != nil {
+		return err
+	}
+
+	// Wrap base64 output at 76 characters per RFC 2045
+	encoded := base64.StdEncoding.EncodeToString(a.Data)
+	for len(encoded) > 76 {
+		part.Write([]byte(encoded[:76] + "\r\n"))
+		encoded = encoded[76:]
+	}
+	_, err = part.Write([]byte(encoded + "\r\n"))
+	return err
+}
+
+// writeQuotedPrintable writes s using quoted-printable encoding
+func writeQuotedPrintable(w *bytes.Buffer, s string) error {
+	qp := quotedprintable.NewWriter(w)
+	qp.Write([]byte(s))
+	return qp.Close()
+}
+
+// writeHeader writes header fields in a stable order followed by a blank line
+func writeHeader(w *bytes.Buffer, header textproto.MIMEHeader) {
+	keys := make([]string, 0, len(header))
+	for k := range header {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	for _, k := range keys {
+		for _, v := range header[k] {
+			fmt.Fprintf(w, "%s: %s\r\n", k, v)
+		}
+	}
+	w.WriteString("\r\n")
+}
+
+// formatAddressList encodes addresses for a header, preserving display names
+func formatAddressList(addrs []string) string {
+	formatted := make([]string, 0, len(addrs))
+	for _, addr := range addrs {
+		if a, err := mail.ParseAddress(addr); err == nil {
+			formatted = append(formatted, a.String())
+		}
+	}
+	return strings.Join(formatted, ", ")
+}
+
+// messageID generates a unique Message-ID in the sender's domain
+func messageID(from string) string {
+	domain := "localhost"
+	if at := strings.LastIndex(from, "@"); at >= 0 {
+		domain = from[at+1:]
+	}
+
+	b := make([]byte, 16)
+	rand.Read(b)
+	return "<" + hex.EncodeToString(b) + "@" + domain + ">"
+}
@@ -0,0 +1,137 @@
+package mail
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"mime"
+	"mime/multipart"
+	"net/mail"
+	"strings"
+	"testing"
+)
+
+func validMessage() *Message {
+	return &Message{
+		From:    "Orders <orders@example.com>",
+		To:      []string{"Jane Doe <jane@example.com>"},
+		Subject: "Your order has shipped",
+		Text:    "Hello Jane",
+	}
+}
+
+func TestMessage_Validate(t *testing.T) {
+	tests := []struct {
+		name    string
+		modify  func(m *Message)
+		wantErr bool
+	}{
+		{"valid", func(m *Message) {}, false},
+		{"missing from", func(m *Message) { m.From = "" }, true},
+		{"no recipients", func(m *Message) { m.To = nil }, true},
+		{"bcc only", func(m *Message) { m.To = nil; m.Bcc = []string{"audit@example.com"} }, false},
+		{"missing subject", func(m *Message) { m.Subject = "" }, true},
+		{"missing body", func(m *Message) { m.Text = "" }, true},
+		{"html only", func(m *Message) { m.Text = ""; m.HTML = "<p>Hi</p>" }, false},
+		{"invalid address", func(m *Message) { m.To = []string{"not-an-address"} }, true},
+		{"invalid reply-to", func(m *Message) { m.ReplyTo = "nope" }, true},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			m := validMessage()
+			tc.modify(m)
+			err := m.Validate()
+			if (err != nil) != tc.wantErr {
+				t.Fatalf("Validate() error = %v, wantErr %v", err, tc.wantErr)
+			}
+			if err != nil && !errors.Is(err, ErrInvalidMessage) {
+				t.Errorf("Expected error to wrap ErrInvalidMessage, got %v", err)
+			}
+		})
+	}
+}
+
+func TestMessage_Bytes(t *testing.T) {
+	t.Run("plain text", func(t *testing.T) {
+		m := validMessage()
+		m.Subject = "Café order ✓"
+		m.Bcc = []string{"hidden@example.com"}
+
+		data, err := m.Bytes()
+		if err != nil {
+			t.Fatalf("Bytes() error = %v", err)
+		}
+
+		parsed, err := mail.ReadMessage(bytes.NewReader(data))
+		if err != nil {
+			t.Fatalf("Failed to parse generated message: %v", err)
+		}
+
+		dec := new(mime.WordDecoder)
+		subject, _ := dec.DecodeHeader(parsed.Header.Get("Subject"))
+		if subject != "Café order ✓" {
+			t.Errorf("Expected decoded subject 'Café order ✓', got %q", subject)
+		}
+		if parsed.Header.Get("Bcc") != "" {
+			t.Error("Expected Bcc header to be omitted")
+		}
+		if !strings.Contains(parsed.Header.Get("To"), "jane@example.com") {
+			t.Errorf("Unexpected To header %q", parsed.Header.Get("To"))
+		}
+		if !strings.HasPrefix(parsed.Header.Get("Content-Type"), "text/plain") {
+			t.Errorf("Expected text/plain, got %q", parsed.Header.Get("Content-Type"))
+		}
+		if !strings.HasSuffix(parsed.Header.Get("Message-ID"), "@example.com>") {
+			t.Errorf("Expected Message-ID in sender domain, got %q", parsed.Header.Get("Message-ID"))
+		}
+	})
+
+	t.Run("alternative with attachment", func(t *testing.T) {
+		m := validMessage()
+		m.HTML = "<p>Hello Jane</p>"
+		m.Attachments = []Attachment{{Filename: "invoice.pdf", Data: bytes.Repeat([]byte("x"), 200)}}
+
+		data, err := m.Bytes()
+		if err != nil {
+			t.Fatalf("Bytes() error = %v", err)
+		}
+
+		parsed, err := mail.ReadMessage(bytes.NewReader(data))
+		if err != nil {
+			t.Fatalf("Failed to parse generated message: %v", err)
+		}
+
+		mediaType, params, err := mime.ParseMediaType(parsed.Header.Get("Content-Type"))
+		if err != nil || mediaType != "multipart/mixed" {
+			t.Fatalf("Expected multipart/mixed, got %q (%v)", mediaType, err)
+		}
+
+		reader := multipart.NewReader(parsed.Body, params["boundary"])
+		var types []string
+		for {
+			part, err := reader.NextPart()
+			if err == io.EOF {
+				break
+			}
+			if err != nil {
+				t.Fatalf("Failed to read part: %v", err)
+			}
+			mt, _, _ := mime.ParseMediaType(part.Header.Get("Content-Type"))
+			types = append(types, mt)
+			if mt == "application/pdf" && part.FileName() != "invoice.pdf" {
+				t.Errorf("Expected attachment filename invoice.pdf, got %q", part.FileName())
+			}
+		}
+
+		if len(types) != 2 || types[0] != "multipart/alternative" || types[1] != "application/pdf" {
+			t.Errorf("Expected [multipart/alternative application/pdf], got %v", types)
+		}
+	})
+
+	t.Run("invalid message", func(t *testing.T) {
+		if _, err := (&Message{}).Bytes(); !errors.Is(err, ErrInvalidMessage) {
+			t.Errorf("Expected ErrInvalidMessage, got %v", err)
+		}
+	})
+}
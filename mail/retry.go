@@ -0,0 +1,90 @@
+package mail
+
+import (
+	"context"
+	"errors"
+	"math/rand"
+	"time"
+
+	"github.com/StairSupplies/go-core/logger"
+	"go.uber.org/zap"
+)
+
+// RetryConfig configures retries for transient delivery failures
+type RetryConfig struct {
+	// MaxAttempts is the total number of delivery attempts. Defaults to 3.
+	MaxAttempts int
+	// BaseDelay is the delay before the first retry. Defaults to 500ms.
+	BaseDelay time.Duration
+	// MaxDelay caps the exponential backoff. Defaults to 30 seconds.
+	MaxDelay time.Duration
+	// Logger records failed attempts. Defaults to a no-op logger.
+	Logger *logger.Logger
+}
+
+// retrySender retries a wrapped Sender with exponential backoff
+type retrySender struct {
+	next Sender
+	cfg  RetryConfig
+}
+
+// NewRetrySender wraps next so that transient failures are retried with
+// jittered exponential backoff. Errors wrapping ErrPermanent or
+// ErrInvalidMessage are returned immediately.
+func NewRetrySender(next Sender, cfg RetryConfig) Sender {
+	if cfg.MaxAttempts <= 0 {
+		cfg.MaxAttempts = 3
+	}
+	if cfg.BaseDelay <= 0 {
+		cfg.BaseDelay = 500 * time.Millisecond
+	}
+	if cfg.MaxDelay <= 0 {
+		cfg.MaxDelay = 30 * time.Second
+	}
+	if cfg.Logger == nil {
+		cfg.Logger = logger.NewNopLogger()
+	}
+
+	return &retrySender{next: next, cfg: cfg}
+}
+
+// Send delivers msg, retrying transient failures
+func (s *retrySender) Send(ctx context.Context, msg *Message) error {
+	var err error
+	for attempt := 1; attempt <= s.cfg.MaxAttempts; attempt++ {
+		err = s.next.Send(ctx, msg)
+		if err == nil || errors.Is(err, ErrPermanent) || errors.Is(err, ErrInvalidMessage) {
+			return err
+		}
+		if attempt == s.cfg.MaxAttempts {
+			break
+		}
+
+		delay := s.backoff(attempt)
+		s.cfg.Logger.Warn("Email delivery failed, retrying",
+			zap.Int("attempt", attempt),
+			zap.Duration("backoff", delay),
+			zap.String("subject", msg.Subject),
+			zap.String("err", err.Error()),
+		)
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(delay):
+		}
+	}
+
+	return err
+}
+
+// backoff returns the jittered delay before the given retry
+func (s *retrySender) backoff(attempt int) time.Duration {
+	delay := s.cfg.BaseDelay << (attempt - 1)
+	if delay > s.cfg.MaxDelay || delay <= 0 {
+		delay = s.cfg.MaxDelay
+	}
+	// Full jitter between half and the whole delay
+	half := delay / 2
+	return half + time.Duration(rand.Int63n(int64(half)+1))
+}
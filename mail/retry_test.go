@@ -0,0 +1,82 @@
+package mail
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+)
+
+// flakySender fails a fixed number of times before succeeding
+type flakySender struct {
+	failures int
+	err      error
+	calls    int
+}
+
+func (s *flakySender) Send(ctx context.Context, msg *Message) error {
+	s.calls++
+	if s.calls <= s.failures {
+		return s.err
+	}
+	return nil
+}
+
+func TestRetrySender(t *testing.T) {
+	cfg := RetryConfig{MaxAttempts: 3, BaseDelay: time.Millisecond, MaxDelay: 5 * time.Millisecond}
+
+	t.Run("recovers from transient errors", func(t *testing.T) {
+		next := &flakySender{failures: 2, err: errors.New("connection reset")}
+		if err := NewRetrySender(next, cfg).Send(context.Background(), validMessage()); err != nil {
+			t.Fatalf("Send() error = %v", err)
+		}
+		if next.calls != 3 {
+			t.Errorf("Expected 3 calls, got %d", next.calls)
+		}
+	})
+
+	t.Run("gives up after max attempts", func(t *testing.T) {
+		next := &flakySender{failures: 5, err: errors.New("connection reset")}
+		if err := NewRetrySender(next, cfg).Send(context.Background(), validMessage()); err == nil {
+			t.Fatal("Expected error, got nil")
+		}
+		if next.calls != 3 {
+			t.Errorf("Expected 3 calls, got %d", next.calls)
+		}
+	})
+
+	t.Run("does not retry permanent errors", func(t *testing.T) {
+		next := &flakySender{failures: 5, err: fmt.Errorf("%w: mailbox unavailable", ErrPermanent)}
+		NewRetrySender(next, cfg).Send(context.Background(), validMessage())
+		if next.calls != 1 {
+			t.Errorf("Expected 1 call, got %d", next.calls)
+		}
+	})
+
+	t.Run("stops when context is cancelled", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+		next := &flakySender{failures: 5, err: errors.New("connection reset")}
+		slow := NewRetrySender(next, RetryConfig{MaxAttempts: 3, BaseDelay: time.Hour})
+		if err := slow.Send(ctx, validMessage()); !errors.Is(err, context.Canceled) {
+			t.Errorf("Expected context.Canceled, got %v", err)
+		}
+	})
+}
+
+func TestDevSender(t *testing.T) {
+	sender := NewDevSender(nil)
+
+	if err := sender.Send(context.Background(), validMessage()); err != nil {
+		t.Fatalf("Send() error = %v", err)
+	}
+	if err := sender.Send(context.Background(), &Message{}); !errors.Is(err, ErrInvalidMessage) {
+		t.Errorf("Expected ErrInvalidMessage, got %v", err)
+	}
+
+	msgs := sender.Messages()
+	if len(msgs) != 1 || msgs[0].Subject != "Your order has shipped" {
+		t.Errorf("Expected one recorded message, got %+v", msgs)
+	}
+}
@@ -0,0 +1,152 @@
+package mail
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/mail"
+	"time"
+
+	"github.com/StairSupplies/go-core/internal/sigv4"
+)
+
+// SESConfig configures delivery through the Amazon SES v2 API
+type SESConfig struct {
+	// Region is the AWS region of the SES endpoint, e.g. "us-east-1"
+	Region string
+	// AccessKeyID is the AWS access key
+	AccessKeyID string
+	// SecretAccessKey is the AWS secret key
+	SecretAccessKey string
+	// SessionToken is the optional token for temporary credentials
+	SessionToken string
+	// ConfigurationSet is the optional SES configuration set applied to each message
+	ConfigurationSet string
+	// Endpoint overrides the service endpoint. Defaults to https://email.<region>.amazonaws.com.
+	Endpoint string
+	// HTTPClient is the client used for requests. Defaults to a client with a 30 second timeout.
+	HTTPClient *http.Client
+}
+
+// SESSender delivers messages through Amazon SES
+type SESSender struct {
+	endpoint         string
+	configurationSet string
+	httpClient       *http.Client
+	signer           *sigv4.Signer
+	now              func() time.Time
+}
+
+// NewSES creates a sender for the configured SES region
+func NewSES(cfg SESConfig) (*SESSender, error) {
+	if cfg.Region == "" {
+		return nil, errors.New("mail: SES region is required")
+	}
+	if cfg.AccessKeyID == "" || cfg.SecretAccessKey == "" {
+		return nil, errors.New("mail: SES credentials are required")
+	}
+
+	if cfg.Endpoint == "" {
+		cfg.Endpoint = "https://email." + cfg.Region + ".amazonaws.com"
+	}
+	if cfg.HTTPClient == nil {
+		cfg.HTTPClient = &http.Client{Timeout: 30 * time.Second}
+	}
+
+	return &SESSender{
+		endpoint:         cfg.Endpoint,
+		configurationSet: cfg.ConfigurationSet,
+		httpClient:       cfg.HTTPClient,
+		signer:           sigv4.NewAWS(cfg.AccessKeyID, cfg.SecretAccessKey, cfg.SessionToken, cfg.Region, "ses"),
+		now:              time.Now,
+	}, nil
+}
+
+// sesRequest is the SendEmail request body for a raw message
+type sesRequest struct {
+	FromEmailAddress string `json:"FromEmailAddress"`
+	Destination      struct {
+		ToAddresses  []string `json:"ToAddresses,omitempty"`
+		CcAddresses  []string `json:"CcAddresses,omitempty"`
+		BccAddresses []string `json:"BccAddresses,omitempty"`
+	} `json:"Destination"`
+	Content struct {
+		Raw struct {
+			Data []byte `json:"Data"`
+		} `json:"Raw"`
+	} `json:"Content"`
+	ConfigurationSetName string `json:"ConfigurationSetName,omitempty"`
+}
+
+// Send delivers msg as a raw MIME message using the SendEmail API
+func (s *SESSender) Send(ctx context.Context, msg *Message) error {
+	data, err := msg.Bytes()
+	if err != nil {
+		return err
+	}
+
+	var payload sesRequest
+	payload.FromEmailAddress, _ = msg.envelopeSender()
+	payload.Destination.ToAddresses = bareAddresses(msg.To)
+	payload.Destination.CcAddresses = bareAddresses(msg.Cc)
+	payload.Destination.BccAddresses = bareAddresses(msg.Bcc)
+	payload.Content.Raw.Data = data
+	payload.ConfigurationSetName = s.configurationSet
+
+	body, err := json.Marshal(payload)
+	if err != nil {
+		return fmt.Errorf("failed to marshal SES request: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, s.endpoint+"/v2/email/outbound-emails", bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("failed to create request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	s.signer.Sign(req, sigv4.HashHex(body), s.now())
+
+	resp, err := s.httpClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("SES request failed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode >= 200 && resp.StatusCode < 300 {
+		return nil
+	}
+
+	respBody, _ := io.ReadAll(io.LimitReader(resp.Body, 64<<10))
+	var errResp struct {
+		Message string `json:"message"`
+	}
+	json.Unmarshal(respBody, &errResp)
+	if errResp.Message == "" {
+		errResp.Message = http.StatusText(resp.StatusCode)
+	}
+
+	sesErr := fmt.Errorf("SES %s (%d): %s", resp.Header.Get("X-Amzn-ErrorType"), resp.StatusCode, errResp.Message)
+	if resp.StatusCode >= 400 && resp.StatusCode < 500 && resp.StatusCode != http.StatusTooManyRequests {
+		return fmt.Errorf("%w: %w", ErrPermanent, sesErr)
+	}
+	return sesErr
+}
+
+// bareAddresses strips display names from addrs
+func bareAddresses(addrs []string) []string {
+	if len(addrs) == 0 {
+		return nil
+	}
+	bare := make([]string, 0, len(addrs))
+	for _, addr := range addrs {
+		if a, err := mail.ParseAddress(addr); err == nil {
+			bare = append(bare, a.Address)
+		}
+	}
+	return bare
+}
+
+var _ Sender = (*SESSender)(nil)
@@ -0,0 +1,104 @@
+package mail
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestNewSES(t *testing.T) {
+	if _, err := NewSES(SESConfig{AccessKeyID: "a", SecretAccessKey: "s"}); err == nil {
+		t.Error("Expected error for missing region")
+	}
+	if _, err := NewSES(SESConfig{Region: "us-east-1"}); err == nil {
+		t.Error("Expected error for missing credentials")
+	}
+}
+
+func TestSESSender_Send(t *testing.T) {
+	var got sesRequest
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/v2/email/outbound-emails" {
+			t.Errorf("Unexpected path %s", r.URL.Path)
+		}
+		auth := r.Header.Get("Authorization")
+		if !strings.Contains(auth, "/us-west-2/ses/aws4_request") {
+			t.Errorf("Expected SES credential scope in Authorization, got %q", auth)
+		}
+		if r.Header.Get("X-Amz-Content-Sha256") == "UNSIGNED-PAYLOAD" {
+			t.Error("Expected signed payload hash for SES")
+		}
+		json.NewDecoder(r.Body).Decode(&got)
+		w.Write([]byte(`{"MessageId":"abc"}`))
+	}))
+	defer server.Close()
+
+	sender, err := NewSES(SESConfig{
+		Region:           "us-west-2",
+		AccessKeyID:      "AKID",
+		SecretAccessKey:  "secret",
+		ConfigurationSet: "transactional",
+		Endpoint:         server.URL,
+	})
+	if err != nil {
+		t.Fatalf("NewSES() error = %v", err)
+	}
+
+	msg := validMessage()
+	msg.Bcc = []string{"Audit <audit@example.com>"}
+	if err := sender.Send(context.Background(), msg); err != nil {
+		t.Fatalf("Send() error = %v", err)
+	}
+
+	if got.FromEmailAddress != "orders@example.com" {
+		t.Errorf("Expected FromEmailAddress orders@example.com, got %q", got.FromEmailAddress)
+	}
+	if len(got.Destination.BccAddresses) != 1 || got.Destination.BccAddresses[0] != "audit@example.com" {
+		t.Errorf("Expected bare Bcc address, got %v", got.Destination.BccAddresses)
+	}
+	if got.ConfigurationSetName != "transactional" {
+		t.Errorf("Expected configuration set, got %q", got.ConfigurationSetName)
+	}
+	if !strings.Contains(string(got.Content.Raw.Data), "Subject: Your order has shipped") {
+		t.Error("Expected raw MIME message in request")
+	}
+}
+
+func TestSESSender_Errors(t *testing.T) {
+	tests := []struct {
+		name          string
+		status        int
+		wantPermanent bool
+	}{
+		{"rejected", http.StatusBadRequest, true},
+		{"throttled", http.StatusTooManyRequests, false},
+		{"server error", http.StatusInternalServerError, false},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.Header().Set("X-Amzn-ErrorType", "MessageRejected")
+				w.WriteHeader(tc.status)
+				w.Write([]byte(`{"message":"Email address is not verified."}`))
+			}))
+			defer server.Close()
+
+			sender, _ := NewSES(SESConfig{Region: "us-east-1", AccessKeyID: "a", SecretAccessKey: "s", Endpoint: server.URL})
+			err := sender.Send(context.Background(), validMessage())
+			if err == nil {
+				t.Fatal("Expected error, got nil")
+			}
+			if errors.Is(err, ErrPermanent) != tc.wantPermanent {
+				t.Errorf("errors.Is(err, ErrPermanent) = %v, want %v (err: %v)", !tc.wantPermanent, tc.wantPermanent, err)
+			}
+			if !strings.Contains(err.Error(), "Email address is not verified.") {
+				t.Errorf("Expected upstream message in error, got %v", err)
+			}
+		})
+	}
+}
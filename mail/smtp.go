@@ -0,0 +1,153 @@
+package mail
+
+import (
+	"context"
+	"crypto/tls"
+	"errors"
+	"fmt"
+	"net"
+	"net/smtp"
+	"net/textproto"
+	"strconv"
+	"time"
+)
+
+// SMTPConfig configures delivery through an SMTP server
+type SMTPConfig struct {
+	// Host is the SMTP server host name
+	Host string
+	// Port is the SMTP server port. Defaults to 587, or 465 when ImplicitTLS is set.
+	Port int
+	// Username and Password enable PLAIN authentication when set
+	Username string
+	Password string
+	// ImplicitTLS connects over TLS from the start (SMTPS) instead of upgrading with STARTTLS
+	ImplicitTLS bool
+	// TLSConfig overrides the TLS settings. Defaults to verifying Host.
+	TLSConfig *tls.Config
+	// LocalName is the host name sent in the HELO/EHLO greeting. Defaults to "localhost".
+	LocalName string
+	// Timeout bounds the whole SMTP conversation when the context has no deadline. Defaults to 30 seconds.
+	Timeout time.Duration
+}
+
+// SMTPSender delivers messages through an SMTP server
+type SMTPSender struct {
+	cfg SMTPConfig
+}
+
+// NewSMTP creates a sender for the configured SMTP server
+func NewSMTP(cfg SMTPConfig) (*SMTPSender, error) {
+	if cfg.Host == "" {
+		return nil, errors.New("mail: SMTP host is required")
+	}
+
+	if cfg.Port == 0 {
+		cfg.Port = 587
+		if cfg.ImplicitTLS {
+			cfg.Port = 465
+		}
+	}
+	if cfg.TLSConfig == nil {
+		cfg.TLSConfig = &tls.Config{ServerName: cfg.Host}
+	}
+	if cfg.Timeout == 0 {
+		cfg.Timeout = 30 * time.Second
+	}
+
+	return &SMTPSender{cfg: cfg}, nil
+}
+
+// Send delivers msg over a new SMTP connection
+func (s *SMTPSender) Send(ctx context.Context, msg *Message) error {
+	data, err := msg.Bytes()
+	if err != nil {
+		return err
+	}
+	from, _ := msg.envelopeSender()
+	recipients, _ := msg.envelopeRecipients()
+
+	addr := net.JoinHostPort(s.cfg.Host, strconv.Itoa(s.cfg.Port))
+	dialer := &net.Dialer{Timeout: s.cfg.Timeout}
+	conn, err := dialer.DialContext(ctx, "tcp", addr)
+	if err != nil {
+		return fmt.Errorf("failed to connect to SMTP server: %w", err)
+	}
+
+	deadline, ok := ctx.Deadline()
+	if !ok {
+		deadline = time.Now().Add(s.cfg.Timeout)
+	}
+	conn.SetDeadline(deadline)
+
+	if s.cfg.ImplicitTLS {
+		conn = tls.Client(conn, s.cfg.TLSConfig)
+	}
+
+	client, err := smtp.NewClient(conn, s.cfg.Host)
+	if err != nil {
+		conn.Close()
+		return smtpError("greeting", err)
+	}
+	defer client.Close()
+
+	// Abort the conversation if the context is cancelled mid-send
+	stop := context.AfterFunc(ctx, func() { conn.Close() })
+	defer stop()
+
+	if s.cfg.LocalName != "" {
+		if err := client.Hello(s.cfg.LocalName); err != nil {
+			return smtpError("HELO", err)
+		}
+	}
+
+	if !s.cfg.ImplicitTLS {
+		if ok, _ := client.Extension("STARTTLS"); ok {
+			if err := client.StartTLS(s.cfg.TLSConfig); err != nil {
+				return smtpError("STARTTLS", err)
+			}
+		}
+	}
+
+	if s.cfg.Username != "" {
+		if ok, _ := client.Extension("AUTH"); !ok {
+			return fmt.Errorf("%w: SMTP server does not support authentication", ErrPermanent)
+		}
+		if err := client.Auth(smtp.PlainAuth("", s.cfg.Username, s.cfg.Password, s.cfg.Host)); err != nil {
+			return smtpError("AUTH", err)
+		}
+	}
+
+	if err := client.Mail(from); err != nil {
+		return smtpError("MAIL FROM", err)
+	}
+	for _, rcpt := range recipients {
+		if err := client.Rcpt(rcpt); err != nil {
+			return smtpError("RCPT TO "+rcpt, err)
+		}
+	}
+
+	w, err := client.Data()
+	if err != nil {
+		return smtpError("DATA", err)
+	}
+	if _, err := w.Write(data); err != nil {
+		return smtpError("DATA", err)
+	}
+	if err := w.Close(); err != nil {
+		return smtpError("DATA", err)
+	}
+
+	return client.Quit()
+}
+
+// smtpError wraps err, marking 5xx replies as permanent failures
+func smtpError(stage string, err error) error {
+	var protoErr *textproto.Error
+	if errors.As(err, &protoErr) && protoErr.Code >= 500 {
+		return fmt.Errorf("%w: SMTP %s: %w", ErrPermanent, stage, err)
+	}
+	return fmt.Errorf("SMTP %s: %w", stage, err)
+}
+
+var _ Sender = (*SMTPSender)(nil)
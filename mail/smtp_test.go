@@ -0,0 +1,150 @@
+package mail
+
+import (
+	"context"
+	"errors"
+	"net"
+	"net/textproto"
+	"strconv"
+	"strings"
+	"testing"
+)
+
+// fakeSMTPServer accepts a single SMTP conversation and records it
+type fakeSMTPServer struct {
+	addr       string
+	rejectRcpt string
+	from       string
+	rcpts      []string
+	data       string
+	done       chan struct{}
+}
+
+func newFakeSMTPServer(t *testing.T) *fakeSMTPServer {
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("Failed to listen: %v", err)
+	}
+	t.Cleanup(func() { ln.Close() })
+
+	s := &fakeSMTPServer{addr: ln.Addr().String(), done: make(chan struct{})}
+	go func() {
+		defer close(s.done)
+		conn, err := ln.Accept()
+		if err != nil {
+			return
+		}
+		defer conn.Close()
+		s.serve(textproto.NewConn(conn))
+	}()
+
+	return s
+}
+
+func (s *fakeSMTPServer) serve(c *textproto.Conn) {
+	c.PrintfLine("220 localhost ESMTP")
+	for {
+		line, err := c.ReadLine()
+		if err != nil {
+			return
+		}
+		cmd := strings.ToUpper(line)
+		switch {
+		case strings.HasPrefix(cmd, "EHLO"), strings.HasPrefix(cmd, "HELO"):
+			c.PrintfLine("250-localhost")
+			c.PrintfLine("250 8BITMIME")
+		case strings.HasPrefix(cmd, "MAIL FROM:"):
+			s.from = strings.Trim(strings.Fields(line[len("MAIL FROM:"):])[0], "<>")
+			c.PrintfLine("250 OK")
+		case strings.HasPrefix(cmd, "RCPT TO:"):
+			rcpt := strings.Trim(line[len("RCPT TO:"):], "<> ")
+			if rcpt == s.rejectRcpt {
+				c.PrintfLine("550 mailbox unavailable")
+				continue
+			}
+			s.rcpts = append(s.rcpts, rcpt)
+			c.PrintfLine("250 OK")
+		case cmd == "DATA":
+			c.PrintfLine("354 go ahead")
+			data, _ := c.ReadDotBytes()
+			s.data = string(data)
+			c.PrintfLine("250 queued")
+		case cmd == "QUIT":
+			c.PrintfLine("221 bye")
+			return
+		default:
+			c.PrintfLine("502 not implemented")
+		}
+	}
+}
+
+func newTestSMTPSender(t *testing.T, addr string) *SMTPSender {
+	host, port, _ := net.SplitHostPort(addr)
+	p, _ := strconv.Atoi(port)
+	sender, err := NewSMTP(SMTPConfig{Host: host, Port: p})
+	if err != nil {
+		t.Fatalf("NewSMTP() error = %v", err)
+	}
+	return sender
+}
+
+func TestNewSMTP(t *testing.T) {
+	if _, err := NewSMTP(SMTPConfig{}); err == nil {
+		t.Error("Expected error for missing host")
+	}
+
+	sender, err := NewSMTP(SMTPConfig{Host: "smtp.example.com", ImplicitTLS: true})
+	if err != nil {
+		t.Fatalf("NewSMTP() error = %v", err)
+	}
+	if sender.cfg.Port != 465 {
+		t.Errorf("Expected default implicit TLS port 465, got %d", sender.cfg.Port)
+	}
+}
+
+func TestSMTPSender_Send(t *testing.T) {
+	server := newFakeSMTPServer(t)
+	sender := newTestSMTPSender(t, server.addr)
+
+	msg := validMessage()
+	msg.Cc = []string{"cc@example.com"}
+	msg.Bcc = []string{"bcc@example.com"}
+
+	if err := sender.Send(context.Background(), msg); err != nil {
+		t.Fatalf("Send() error = %v", err)
+	}
+	<-server.done
+
+	if server.from != "orders@example.com" {
+		t.Errorf("Expected envelope sender orders@example.com, got %q", server.from)
+	}
+	want := []string{"jane@example.com", "cc@example.com", "bcc@example.com"}
+	if strings.Join(server.rcpts, ",") != strings.Join(want, ",") {
+		t.Errorf("Expected recipients %v, got %v", want, server.rcpts)
+	}
+	if !strings.Contains(server.data, "Subject: Your order has shipped") {
+		t.Errorf("Expected subject in message data, got:\n%s", server.data)
+	}
+}
+
+func TestSMTPSender_PermanentFailure(t *testing.T) {
+	server := newFakeSMTPServer(t)
+	server.rejectRcpt = "jane@example.com"
+	sender := newTestSMTPSender(t, server.addr)
+
+	err := sender.Send(context.Background(), validMessage())
+	if !errors.Is(err, ErrPermanent) {
+		t.Errorf("Expected ErrPermanent for 550 reply, got %v", err)
+	}
+}
+
+func TestSMTPSender_ConnectionFailure(t *testing.T) {
+	ln, _ := net.Listen("tcp", "127.0.0.1:0")
+	addr := ln.Addr().String()
+	ln.Close()
+
+	err := newTestSMTPSender(t, addr).Send(context.Background(), validMessage())
+	if err == nil || errors.Is(err, ErrPermanent) {
+		t.Errorf("Expected transient connection error, got %v", err)
+	}
+}
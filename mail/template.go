@@ -0,0 +1,107 @@
+package mail
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	htmltemplate "html/template"
+	"io/fs"
+	"strings"
+	texttemplate "text/template"
+)
+
+// ErrTemplateNotFound indicates that no body template exists for the requested name
+var ErrTemplateNotFound = errors.New("template not found")
+
+// Template file name suffixes. A template named "welcome" consists of
+// welcome.subject.tmpl, welcome.txt.tmpl, and/or welcome.html.tmpl.
+const (
+	subjectSuffix = ".subject.tmpl"
+	textSuffix    = ".txt.tmpl"
+	htmlSuffix    = ".html.tmpl"
+)
+
+// Templates renders message subjects and bodies from a set of template files
+type Templates struct {
+	text *texttemplate.Template
+	html *htmltemplate.Template
+}
+
+// ParseTemplates parses all templates at the root of fsys, typically an embed.FS.
+// Subject and text templates use text/template; HTML templates use html/template
+// and may share layouts and partials defined in any other .html.tmpl file.
+// Use fs.Sub to parse templates from a subdirectory.
+func ParseTemplates(fsys fs.FS) (*Templates, error) {
+	t := &Templates{
+		text: texttemplate.New(""),
+		html: htmltemplate.New(""),
+	}
+
+	for _, pattern := range []string{"*" + subjectSuffix, "*" + textSuffix} {
+		matches, err := fs.Glob(fsys, pattern)
+		if err != nil {
+			return nil, err
+		}
+		if len(matches) > 0 {
+			if _, err := t.text.ParseFS(fsys, pattern); err != nil {
+				return nil, fmt.Errorf("failed to parse templates: %w", err)
+			}
+		}
+	}
+
+	matches, err := fs.Glob(fsys, "*"+htmlSuffix)
+	if err != nil {
+		return nil, err
+	}
+	if len(matches) > 0 {
+		if _, err := t.html.ParseFS(fsys, "*"+htmlSuffix); err != nil {
+			return nil, fmt.Errorf("failed to parse templates: %w", err)
+		}
+	}
+
+	return t, nil
+}
+
+// Render executes the templates for name with data and returns a message with
+// Subject, Text, and HTML populated. The caller fills in the addresses.
+func (t *Templates) Render(name string, data any) (*Message, error) {
+	msg := &Message{}
+
+	subject, err := t.executeText(name+subjectSuffix, data)
+	if err != nil {
+		return nil, err
+	}
+	msg.Subject = strings.TrimSpace(subject)
+
+	if msg.Text, err = t.executeText(name+textSuffix, data); err != nil {
+		return nil, err
+	}
+
+	if tmpl := t.html.Lookup(name + htmlSuffix); tmpl != nil {
+		var buf bytes.Buffer
+		if err := tmpl.Execute(&buf, data); err != nil {
+			return nil, fmt.Errorf("failed to render %s: %w", name+htmlSuffix, err)
+		}
+		msg.HTML = buf.String()
+	}
+
+	if msg.Text == "" && msg.HTML == "" {
+		return nil, fmt.Errorf("%w: %s", ErrTemplateNotFound, name)
+	}
+
+	return msg, nil
+}
+
+// executeText renders the named text template, returning "" if it does not exist
+func (t *Templates) executeText(name string, data any) (string, error) {
+	tmpl := t.text.Lookup(name)
+	if tmpl == nil {
+		return "", nil
+	}
+
+	var buf bytes.Buffer
+	if err := tmpl.Execute(&buf, data); err != nil {
+		return "", fmt.Errorf("failed to render %s: %w", name, err)
+	}
+	return buf.String(), nil
+}
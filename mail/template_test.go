@@ -0,0 +1,67 @@
+package mail
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"testing/fstest"
+)
+
+func TestTemplates_Render(t *testing.T) {
+	fsys := fstest.MapFS{
+		"welcome.subject.tmpl": {Data: []byte("Welcome, {{.Name}}!\n")},
+		"welcome.txt.tmpl":     {Data: []byte("Hi {{.Name}}, thanks for signing up.")},
+		"welcome.html.tmpl":    {Data: []byte(`{{template "layout" .}}`)},
+		"layout.html.tmpl":     {Data: []byte(`{{define "layout"}}<p>Hi {{.Name}}</p>{{end}}`)},
+		"reset.txt.tmpl":       {Data: []byte("Reset link: {{.URL}}")},
+	}
+
+	tmpl, err := ParseTemplates(fsys)
+	if err != nil {
+		t.Fatalf("ParseTemplates() error = %v", err)
+	}
+
+	t.Run("all parts", func(t *testing.T) {
+		msg, err := tmpl.Render("welcome", map[string]string{"Name": "<Jane>"})
+		if err != nil {
+			t.Fatalf("Render() error = %v", err)
+		}
+		if msg.Subject != "Welcome, <Jane>!" {
+			t.Errorf("Expected trimmed subject, got %q", msg.Subject)
+		}
+		if msg.Text != "Hi <Jane>, thanks for signing up." {
+			t.Errorf("Unexpected text body %q", msg.Text)
+		}
+		if msg.HTML != "<p>Hi &lt;Jane&gt;</p>" {
+			t.Errorf("Expected escaped HTML from shared layout, got %q", msg.HTML)
+		}
+	})
+
+	t.Run("text only", func(t *testing.T) {
+		msg, err := tmpl.Render("reset", map[string]string{"URL": "https://example.com/r?a=1&b=2"})
+		if err != nil {
+			t.Fatalf("Render() error = %v", err)
+		}
+		if !strings.Contains(msg.Text, "a=1&b=2") {
+			t.Errorf("Expected text body to be unescaped, got %q", msg.Text)
+		}
+		if msg.HTML != "" {
+			t.Errorf("Expected no HTML body, got %q", msg.HTML)
+		}
+	})
+
+	t.Run("missing", func(t *testing.T) {
+		if _, err := tmpl.Render("missing", nil); !errors.Is(err, ErrTemplateNotFound) {
+			t.Errorf("Expected ErrTemplateNotFound, got %v", err)
+		}
+	})
+}
+
+func TestParseTemplates_Invalid(t *testing.T) {
+	fsys := fstest.MapFS{
+		"broken.txt.tmpl": {Data: []byte("{{.Name")},
+	}
+	if _, err := ParseTemplates(fsys); err == nil {
+		t.Error("Expected parse error, got nil")
+	}
+}
@@ -0,0 +1,53 @@
+/*
+Package maputils provides generic helpers for working with maps, and a type-safe
+concurrent map.
+
+It complements sliceutils for the collection plumbing that services otherwise
+write by hand: extracting keys, merging configuration maps, and trimming maps
+before they are logged or returned from an API.
+
+# Features
+
+  - Keys, SortedKeys, and Values
+  - Merge with a pluggable ConflictFunc (KeepFirst, KeepLast, or custom)
+  - Invert for building reverse lookups
+  - Pick and Omit for selecting or dropping keys
+  - MapValues for transforming values
+  - SyncMap, a mutex-guarded generic map with Update for read-modify-write
+
+# Basic Usage
+
+Merging maps:
+
+	headers := maputils.Merge(maputils.KeepLast[string, string], defaultHeaders, requestHeaders)
+
+Merging with a custom conflict strategy:
+
+	totals := maputils.Merge(func(_ string, a, b int) int { return a + b }, east, west)
+
+Removing sensitive fields before logging:
+
+	safe := maputils.Omit(params, "password", "token")
+
+Deterministic iteration:
+
+	for _, k := range maputils.SortedKeys(m) {
+		fmt.Println(k, m[k])
+	}
+
+# Concurrent Access
+
+SyncMap guards a plain map with a sync.RWMutex. The zero value is ready to use:
+
+	var sessions maputils.SyncMap[string, *Session]
+
+	sessions.Store(id, session)
+	session, ok := sessions.Load(id)
+
+Read-modify-write updates happen under a single lock:
+
+	var hits maputils.SyncMap[string, int]
+
+	hits.Update(path, func(current int, _ bool) int { return current + 1 })
+*/
+package maputils
@@ -0,0 +1,39 @@
+package maputils_test
+
+import (
+	"fmt"
+
+	"github.com/StairSupplies/go-core/maputils"
+)
+
+func ExampleMerge() {
+	defaults := map[string]string{"color": "oak", "finish": "satin"}
+	overrides := map[string]string{"finish": "gloss"}
+
+	merged := maputils.Merge(maputils.KeepLast[string, string], defaults, overrides)
+	fmt.Println(merged["color"], merged["finish"])
+	// Output: oak gloss
+}
+
+func ExampleSortedKeys() {
+	stock := map[string]int{"TRD-42": 12, "RSR-36": 40, "TRD-36": 7}
+
+	for _, sku := range maputils.SortedKeys(stock) {
+		fmt.Println(sku, stock[sku])
+	}
+	// Output:
+	// RSR-36 40
+	// TRD-36 7
+	// TRD-42 12
+}
+
+func ExampleSyncMap() {
+	var counts maputils.SyncMap[string, int]
+
+	counts.Update("orders", func(current int, _ bool) int { return current + 1 })
+	counts.Update("orders", func(current int, _ bool) int { return current + 1 })
+
+	v, _ := counts.Load("orders")
+	fmt.Println(v)
+	// Output: 2
+}
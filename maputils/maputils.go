@@ -0,0 +1,106 @@
+package maputils
+
+import (
+	"cmp"
+	"slices"
+)
+
+// Keys returns the keys of m in unspecified order
+func Keys[M ~map[K]V, K comparable, V any](m M) []K {
+	keys := make([]K, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	return keys
+}
+
+// SortedKeys returns the keys of m in ascending order
+func SortedKeys[M ~map[K]V, K cmp.Ordered, V any](m M) []K {
+	keys := Keys(m)
+	slices.Sort(keys)
+	return keys
+}
+
+// Values returns the values of m in unspecified order
+func Values[M ~map[K]V, K comparable, V any](m M) []V {
+	values := make([]V, 0, len(m))
+	for _, v := range m {
+		values = append(values, v)
+	}
+	return values
+}
+
+// ConflictFunc decides the merged value for a key present in more than one map
+type ConflictFunc[K comparable, V any] func(key K, existing, incoming V) V
+
+// KeepFirst is a ConflictFunc that keeps the value from the earliest map
+func KeepFirst[K comparable, V any](_ K, existing, _ V) V {
+	return existing
+}
+
+// KeepLast is a ConflictFunc that keeps the value from the latest map
+func KeepLast[K comparable, V any](_ K, _, incoming V) V {
+	return incoming
+}
+
+// Merge combines maps into a new map, calling resolve for keys present in more than one.
+// A nil resolve behaves like KeepLast.
+func Merge[M ~map[K]V, K comparable, V any](resolve ConflictFunc[K, V], maps ...M) M {
+	size := 0
+	for _, m := range maps {
+		size += len(m)
+	}
+
+	out := make(M, size)
+	for _, m := range maps {
+		for k, v := range m {
+			if existing, ok := out[k]; ok && resolve != nil {
+				v = resolve(k, existing, v)
+			}
+			out[k] = v
+		}
+	}
+	return out
+}
+
+// Invert returns a map from values to keys. When several keys share a value,
+// which key is kept is unspecified.
+func Invert[M ~map[K]V, K, V comparable](m M) map[V]K {
+	out := make(map[V]K, len(m))
+	for k, v := range m {
+		out[v] = k
+	}
+	return out
+}
+
+// Pick returns a new map containing only the given keys that are present in m
+func Pick[M ~map[K]V, K comparable, V any](m M, keys ...K) M {
+	out := make(M, len(keys))
+	for _, k := range keys {
+		if v, ok := m[k]; ok {
+			out[k] = v
+		}
+	}
+	return out
+}
+
+// Omit returns a new map containing every entry of m except the given keys
+func Omit[M ~map[K]V, K comparable, V any](m M, keys ...K) M {
+	out := make(M, len(m))
+	for k, v := range m {
+		out[k] = v
+	}
+	for _, k := range keys {
+		delete(out, k)
+	}
+	return out
+}
+
+// MapValues returns a new map with fn applied to every value
+func MapValues[M ~map[K]V, K comparable, V, U any](m M, fn func(V) U) map[K]U {
+	out := make(map[K]U, len(m))
+	for k, v := range m {
+		out[k] = fn(v)
+	}
+	return out
+}
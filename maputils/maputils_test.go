@@ -0,0 +1,85 @@
+package maputils
+
+import (
+	"reflect"
+	"slices"
+	"strings"
+	"testing"
+)
+
+func TestKeysAndValues(t *testing.T) {
+	m := map[string]int{"b": 2, "a": 1, "c": 3}
+
+	if got := SortedKeys(m); !reflect.DeepEqual(got, []string{"a", "b", "c"}) {
+		t.Errorf("SortedKeys() = %v", got)
+	}
+
+	keys := Keys(m)
+	slices.Sort(keys)
+	if !reflect.DeepEqual(keys, []string{"a", "b", "c"}) {
+		t.Errorf("Keys() = %v", keys)
+	}
+
+	values := Values(m)
+	slices.Sort(values)
+	if !reflect.DeepEqual(values, []int{1, 2, 3}) {
+		t.Errorf("Values() = %v", values)
+	}
+}
+
+func TestMerge(t *testing.T) {
+	a := map[string]int{"x": 1, "y": 2}
+	b := map[string]int{"y": 20, "z": 30}
+
+	tests := []struct {
+		name    string
+		resolve ConflictFunc[string, int]
+		want    map[string]int
+	}{
+		{"nil keeps last", nil, map[string]int{"x": 1, "y": 20, "z": 30}},
+		{"keep first", KeepFirst[string, int], map[string]int{"x": 1, "y": 2, "z": 30}},
+		{"keep last", KeepLast[string, int], map[string]int{"x": 1, "y": 20, "z": 30}},
+		{"sum", func(_ string, existing, incoming int) int { return existing + incoming }, map[string]int{"x": 1, "y": 22, "z": 30}},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			got := Merge(tc.resolve, a, b)
+			if !reflect.DeepEqual(got, tc.want) {
+				t.Errorf("Expected %v, got %v", tc.want, got)
+			}
+		})
+	}
+
+	if a["y"] != 2 {
+		t.Error("Expected Merge not to modify its inputs")
+	}
+}
+
+func TestInvert(t *testing.T) {
+	got := Invert(map[string]int{"one": 1, "two": 2})
+	if !reflect.DeepEqual(got, map[int]string{1: "one", 2: "two"}) {
+		t.Errorf("Invert() = %v", got)
+	}
+}
+
+func TestPickAndOmit(t *testing.T) {
+	m := map[string]string{"name": "Jane", "email": "jane@example.com", "password": "secret"}
+
+	if got := Pick(m, "name", "email", "missing"); !reflect.DeepEqual(got, map[string]string{"name": "Jane", "email": "jane@example.com"}) {
+		t.Errorf("Pick() = %v", got)
+	}
+	if got := Omit(m, "password"); !reflect.DeepEqual(got, map[string]string{"name": "Jane", "email": "jane@example.com"}) {
+		t.Errorf("Omit() = %v", got)
+	}
+	if len(m) != 3 {
+		t.Error("Expected Omit not to modify its input")
+	}
+}
+
+func TestMapValues(t *testing.T) {
+	got := MapValues(map[string]string{"a": "x", "b": "y"}, strings.ToUpper)
+	if !reflect.DeepEqual(got, map[string]string{"a": "X", "b": "Y"}) {
+		t.Errorf("MapValues() = %v", got)
+	}
+}
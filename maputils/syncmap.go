@@ -0,0 +1,103 @@
+package maputils
+
+import "sync"
+
+// SyncMap is a map guarded by a read-write mutex. The zero value is ready to use.
+// Unlike sync.Map it is type-safe and suits maps with frequent writes to the same keys.
+type SyncMap[K comparable, V any] struct {
+	mu sync.RWMutex
+	m  map[K]V
+}
+
+// NewSyncMap returns a SyncMap initialized with a copy of the entries in m
+func NewSyncMap[K comparable, V any](m map[K]V) *SyncMap[K, V] {
+	sm := &SyncMap[K, V]{m: make(map[K]V, len(m))}
+	for k, v := range m {
+		sm.m[k] = v
+	}
+	return sm
+}
+
+// Load returns the value stored for key and whether it was present
+func (sm *SyncMap[K, V]) Load(key K) (V, bool) {
+	sm.mu.RLock()
+	defer sm.mu.RUnlock()
+	v, ok := sm.m[key]
+	return v, ok
+}
+
+// Store sets the value for key
+func (sm *SyncMap[K, V]) Store(key K, value V) {
+	sm.mu.Lock()
+	defer sm.mu.Unlock()
+	if sm.m == nil {
+		sm.m = make(map[K]V)
+	}
+	sm.m[key] = value
+}
+
+// LoadOrStore returns the existing value for key if present. Otherwise it stores
+// and returns value. loaded reports whether the value was already present.
+func (sm *SyncMap[K, V]) LoadOrStore(key K, value V) (actual V, loaded bool) {
+	sm.mu.Lock()
+	defer sm.mu.Unlock()
+	if v, ok := sm.m[key]; ok {
+		return v, true
+	}
+	if sm.m == nil {
+		sm.m = make(map[K]V)
+	}
+	sm.m[key] = value
+	return value, false
+}
+
+// Update atomically replaces the value for key with fn's result. fn receives the
+// current value and whether it was present.
+func (sm *SyncMap[K, V]) Update(key K, fn func(current V, ok bool) V) V {
+	sm.mu.Lock()
+	defer sm.mu.Unlock()
+	if sm.m == nil {
+		sm.m = make(map[K]V)
+	}
+	current, ok := sm.m[key]
+	v := fn(current, ok)
+	sm.m[key] = v
+	return v
+}
+
+// Delete removes key
+func (sm *SyncMap[K, V]) Delete(key K) {
+	sm.mu.Lock()
+	defer sm.mu.Unlock()
+	delete(sm.m, key)
+}
+
+// Len returns the number of entries
+func (sm *SyncMap[K, V]) Len() int {
+	sm.mu.RLock()
+	defer sm.mu.RUnlock()
+	return len(sm.m)
+}
+
+// Range calls fn for each entry until fn returns false. fn must not modify the
+// map; use Snapshot to iterate while writing.
+func (sm *SyncMap[K, V]) Range(fn func(key K, value V) bool) {
+	sm.mu.RLock()
+	defer sm.mu.RUnlock()
+	for k, v := range sm.m {
+		if !fn(k, v) {
+			return
+		}
+	}
+}
+
+// Snapshot returns a copy of the current entries
+func (sm *SyncMap[K, V]) Snapshot() map[K]V {
+	sm.mu.RLock()
+	defer sm.mu.RUnlock()
+	out := make(map[K]V, len(sm.m))
+	for k, v := range sm.m {
+		out[k] = v
+	}
+	return out
+}
@@ -0,0 +1,56 @@
+package maputils
+
+import (
+	"sync"
+	"testing"
+)
+
+func TestSyncMap(t *testing.T) {
+	var sm SyncMap[string, int]
+
+	if _, ok := sm.Load("a"); ok {
+		t.Error("Expected zero value map to be empty")
+	}
+
+	sm.Store("a", 1)
+	if v, ok := sm.Load("a"); !ok || v != 1 {
+		t.Errorf("Expected 1, got %d (%v)", v, ok)
+	}
+
+	if v, loaded := sm.LoadOrStore("a", 5); !loaded || v != 1 {
+		t.Errorf("Expected existing value 1, got %d (%v)", v, loaded)
+	}
+	if v, loaded := sm.LoadOrStore("b", 2); loaded || v != 2 {
+		t.Errorf("Expected stored value 2, got %d (%v)", v, loaded)
+	}
+
+	sm.Delete("a")
+	if sm.Len() != 1 {
+		t.Errorf("Expected 1 entry, got %d", sm.Len())
+	}
+
+	snapshot := sm.Snapshot()
+	snapshot["c"] = 3
+	if sm.Len() != 1 {
+		t.Error("Expected Snapshot to return a copy")
+	}
+}
+
+func TestSyncMap_Concurrent(t *testing.T) {
+	sm := NewSyncMap(map[string]int{"count": 0})
+
+	var wg sync.WaitGroup
+	for i := 0; i < 50; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			sm.Update("count", func(current int, _ bool) int { return current + 1 })
+			sm.Range(func(string, int) bool { return true })
+		}()
+	}
+	wg.Wait()
+
+	if v, _ := sm.Load("count"); v != 50 {
+		t.Errorf("Expected 50, got %d", v)
+	}
+}
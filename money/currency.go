@@ -0,0 +1,63 @@
+package money
+
+import "strings"
+
+// Currency describes an ISO 4217 currency
+type Currency struct {
+	// Code is the three-letter ISO 4217 code, e.g. "USD"
+	Code string
+	// Symbol is used when formatting amounts, e.g. "$"
+	Symbol string
+	// Digits is the number of minor unit digits, e.g. 2 for cents
+	Digits int
+}
+
+// Common currencies
+var (
+	USD = Currency{Code: "USD", Symbol: "$", Digits: 2}
+	CAD = Currency{Code: "CAD", Symbol: "CA$", Digits: 2}
+	MXN = Currency{Code: "MXN", Symbol: "MX$", Digits: 2}
+	EUR = Currency{Code: "EUR", Symbol: "€", Digits: 2}
+	GBP = Currency{Code: "GBP", Symbol: "£", Digits: 2}
+	AUD = Currency{Code: "AUD", Symbol: "A$", Digits: 2}
+	JPY = Currency{Code: "JPY", Symbol: "¥", Digits: 0}
+	CHF = Currency{Code: "CHF", Symbol: "CHF ", Digits: 2}
+	KWD = Currency{Code: "KWD", Symbol: "KD ", Digits: 3}
+)
+
+var currencies = map[string]Currency{}
+
+func init() {
+	for _, c := range []Currency{USD, CAD, MXN, EUR, GBP, AUD, JPY, CHF, KWD} {
+		currencies[c.Code] = c
+	}
+}
+
+// LookupCurrency returns the registered currency for an ISO 4217 code
+func LookupCurrency(code string) (Currency, error) {
+	c, ok := currencies[strings.ToUpper(strings.TrimSpace(code))]
+	if !ok {
+		return Currency{}, ErrUnknownCurrency
+	}
+	return c, nil
+}
+
+// RegisterCurrency adds or replaces a currency so it can be found by LookupCurrency.
+// It is not safe to call concurrently with LookupCurrency and should be called during init.
+func RegisterCurrency(c Currency) {
+	currencies[strings.ToUpper(c.Code)] = c
+}
+
+// String returns the currency code
+func (c Currency) String() string {
+	return c.Code
+}
+
+// scale returns 10^Digits
+func (c Currency) scale() int64 {
+	s := int64(1)
+	for i := 0; i < c.Digits; i++ {
+		s *= 10
+	}
+	return s
+}
@@ -0,0 +1,70 @@
+/*
+Package money provides exact monetary arithmetic using integer minor units.
+
+Prices stored as float64 accumulate rounding errors (0.1 + 0.2 != 0.3), which show
+up as off-by-one-cent totals on invoices. Money stores an int64 count of minor
+units (cents for USD, yen for JPY) together with its Currency, so arithmetic is
+exact and amounts in different currencies cannot be mixed by accident.
+
+# Features
+
+  - Money type with currency-checked Add, Sub, Compare, and Sum
+  - Overflow detection on all arithmetic
+  - MultiplyFraction for tax rates and discounts with half-away-from-zero rounding
+  - Allocate and Split that never lose or invent a minor unit
+  - Parse and Format with currency symbols and thousands separators
+  - JSON encoding with string amounts so values never pass through a float
+
+# Basic Usage
+
+Creating and combining amounts:
+
+	price := money.New(1999, money.USD)         // $19.99
+	shipping := money.MustParse("4.95", money.USD)
+
+	total, err := price.Add(shipping)
+	if err != nil {
+		return err
+	}
+	fmt.Println(total) // $24.94
+
+Calculating tax:
+
+	tax, err := total.MultiplyFraction(825, 10000) // 8.25%
+
+Splitting a payment into installments:
+
+	installments, err := total.Split(3) // $8.32, $8.31, $8.31
+
+# Parsing and Formatting
+
+Parse accepts amounts with or without the currency symbol or code and with comma
+thousands separators. Amounts with more decimal places than the currency allows
+are rejected rather than silently rounded:
+
+	money.Parse("$1,234.56", money.USD) // 123456 cents
+	money.Parse("12.50 USD", money.USD) // 1250 cents
+	money.Parse("1.234", money.USD)     // ErrInvalidAmount
+
+Format includes the symbol and separators; Decimal returns a plain number suitable
+for CSV exports and database columns:
+
+	m.Format()  // "$1,234.56"
+	m.Decimal() // "1234.56"
+
+# JSON
+
+Money encodes as an object with a string amount:
+
+	{"amount":"19.99","currency":"USD"}
+
+Decoding also accepts a numeric amount, which is parsed from its text.
+
+# Migrating From Floats
+
+FromFloat converts an existing float64 price by rounding to the nearest minor unit.
+Convert once at the boundary and keep the value as Money from then on:
+
+	price, err := money.FromFloat(product.Price, money.USD)
+*/
+package money
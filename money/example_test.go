@@ -0,0 +1,54 @@
+package money_test
+
+import (
+	"fmt"
+
+	"github.com/StairSupplies/go-core/money"
+)
+
+func ExampleParse() {
+	price, err := money.Parse("$1,299.99", money.USD)
+	if err != nil {
+		fmt.Printf("Error parsing price: %v\n", err)
+		return
+	}
+
+	fmt.Println(price.Amount())
+	fmt.Println(price)
+	// Output:
+	// 129999
+	// $1,299.99
+}
+
+func ExampleMoney_Split() {
+	total := money.New(10000, money.USD)
+
+	parts, err := total.Split(3)
+	if err != nil {
+		fmt.Printf("Error splitting: %v\n", err)
+		return
+	}
+
+	for _, p := range parts {
+		fmt.Println(p)
+	}
+	// Output:
+	// $33.34
+	// $33.33
+	// $33.33
+}
+
+func ExampleMoney_MultiplyFraction() {
+	subtotal := money.New(4999, money.USD)
+
+	// 8.25% sales tax
+	tax, err := subtotal.MultiplyFraction(825, 10000)
+	if err != nil {
+		fmt.Printf("Error calculating tax: %v\n", err)
+		return
+	}
+
+	total, _ := subtotal.Add(tax)
+	fmt.Printf("tax %s, total %s\n", tax, total)
+	// Output: tax $4.12, total $54.11
+}
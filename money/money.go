@@ -0,0 +1,419 @@
+package money
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"math/big"
+	"strconv"
+	"strings"
+)
+
+var (
+	// ErrCurrencyMismatch is returned when combining amounts in different currencies
+	ErrCurrencyMismatch = errors.New("currency mismatch")
+
+	// ErrUnknownCurrency is returned when a currency code is not registered
+	ErrUnknownCurrency = errors.New("unknown currency")
+
+	// ErrInvalidAmount is returned when an amount cannot be parsed
+	ErrInvalidAmount = errors.New("invalid amount")
+
+	// ErrOverflow is returned when a result does not fit in 64 bits of minor units
+	ErrOverflow = errors.New("amount overflows")
+
+	// ErrInvalidRatio is returned by Allocate and Split for unusable ratios
+	ErrInvalidRatio = errors.New("invalid allocation ratio")
+)
+
+// Money is an amount of a currency stored as an integer number of minor units
+// (e.g. cents). The zero value has no currency and adopts the currency of the
+// other operand in Add and Sub, so it can be used as an accumulator.
+type Money struct {
+	amount   int64
+	currency Currency
+}
+
+// New returns an amount of minor units in currency c, e.g. New(1999, USD) is $19.99
+func New(minor int64, c Currency) Money {
+	return Money{amount: minor, currency: c}
+}
+
+// FromFloat converts a float64 major-unit amount, rounding half away from zero.
+// It exists for migrating float prices; prefer New or Parse for new code.
+func FromFloat(f float64, c Currency) (Money, error) {
+	v := math.Round(f * float64(c.scale()))
+	if math.IsNaN(v) || v > math.MaxInt64 || v < math.MinInt64 {
+		return Money{}, ErrOverflow
+	}
+	return Money{amount: int64(v), currency: c}, nil
+}
+
+// Parse parses a decimal major-unit amount such as "1,234.56", "$1,234.56", "-12.5",
+// or "12.50 USD". Amounts with more fractional digits than the currency allows are rejected.
+func Parse(s string, c Currency) (Money, error) {
+	orig := s
+	s = strings.TrimSpace(s)
+
+	neg := false
+	if strings.HasPrefix(s, "-") {
+		neg = true
+		s = strings.TrimSpace(s[1:])
+	}
+	s = strings.TrimSpace(strings.TrimSuffix(s, c.Code))
+	s = strings.TrimSpace(strings.TrimPrefix(s, c.Code))
+	if sym := strings.TrimSpace(c.Symbol); sym != "" {
+		s = strings.TrimSpace(strings.TrimPrefix(s, sym))
+	}
+	if !neg && strings.HasPrefix(s, "-") {
+		neg = true
+		s = strings.TrimSpace(s[1:])
+	}
+
+	whole, frac, hasFrac := strings.Cut(s, ".")
+	whole = strings.ReplaceAll(whole, ",", "")
+	if whole == "" && (!hasFrac || frac == "") {
+		return Money{}, fmt.Errorf("%w: %q", ErrInvalidAmount, orig)
+	}
+	if len(frac) > c.Digits {
+		return Money{}, fmt.Errorf("%w: %q has more than %d decimal places", ErrInvalidAmount, orig, c.Digits)
+	}
+	if !isDigits(whole) || !isDigits(frac) {
+		return Money{}, fmt.Errorf("%w: %q", ErrInvalidAmount, orig)
+	}
+
+	digits := whole + frac + strings.Repeat("0", c.Digits-len(frac))
+	if neg {
+		digits = "-" + digits
+	}
+	amount, err := strconv.ParseInt(digits, 10, 64)
+	if err != nil {
+		return Money{}, fmt.Errorf("%w: %q", ErrOverflow, orig)
+	}
+
+	return Money{amount: amount, currency: c}, nil
+}
+
+// MustParse is like Parse but panics on error. It is intended for constants and tests.
+func MustParse(s string, c Currency) Money {
+	m, err := Parse(s, c)
+	if err != nil {
+		panic(err)
+	}
+	return m
+}
+
+// Amount returns the amount in minor units
+func (m Money) Amount() int64 {
+	return m.amount
+}
+
+// Currency returns the currency of the amount
+func (m Money) Currency() Currency {
+	return m.currency
+}
+
+// IsZero reports whether the amount is zero
+func (m Money) IsZero() bool {
+	return m.amount == 0
+}
+
+// IsNegative reports whether the amount is less than zero
+func (m Money) IsNegative() bool {
+	return m.amount < 0
+}
+
+// IsPositive reports whether the amount is greater than zero
+func (m Money) IsPositive() bool {
+	return m.amount > 0
+}
+
+// Add returns m + o
+func (m Money) Add(o Money) (Money, error) {
+	c, err := m.sameCurrency(o)
+	if err != nil {
+		return Money{}, err
+	}
+	sum := m.amount + o.amount
+	if (sum > m.amount) != (o.amount > 0) {
+		return Money{}, ErrOverflow
+	}
+	return Money{amount: sum, currency: c}, nil
+}
+
+// Sub returns m - o
+func (m Money) Sub(o Money) (Money, error) {
+	if o.amount == math.MinInt64 {
+		return Money{}, ErrOverflow
+	}
+	return m.Add(Money{amount: -o.amount, currency: o.currency})
+}
+
+// Multiply returns m * n
+func (m Money) Multiply(n int64) (Money, error) {
+	if m.amount == 0 || n == 0 {
+		return Money{currency: m.currency}, nil
+	}
+	product := m.amount * n
+	if product/n != m.amount || (m.amount == -1 && n == math.MinInt64) || (n == -1 && m.amount == math.MinInt64) {
+		return Money{}, ErrOverflow
+	}
+	return Money{amount: product, currency: m.currency}, nil
+}
+
+// MultiplyFraction returns m * num / den rounded half away from zero to the nearest
+// minor unit. It is useful for tax rates and discounts, e.g. MultiplyFraction(825, 10000)
+// for 8.25%.
+func (m Money) MultiplyFraction(num, den int64) (Money, error) {
+	if den == 0 {
+		return Money{}, fmt.Errorf("%w: zero denominator", ErrInvalidRatio)
+	}
+
+	n := new(big.Int).Mul(big.NewInt(m.amount), big.NewInt(num))
+	d := big.NewInt(den)
+	if d.Sign() < 0 {
+		n.Neg(n)
+		d.Neg(d)
+	}
+
+	q, r := new(big.Int).QuoRem(n, d, new(big.Int))
+	// Round half away from zero: |2r| >= d
+	if new(big.Int).Abs(new(big.Int).Lsh(r, 1)).Cmp(d) >= 0 {
+		if n.Sign() < 0 {
+			q.Sub(q, big.NewInt(1))
+		} else {
+			q.Add(q, big.NewInt(1))
+		}
+	}
+
+	if !q.IsInt64() {
+		return Money{}, ErrOverflow
+	}
+	return Money{amount: q.Int64(), currency: m.currency}, nil
+}
+
+// Negate returns -m
+func (m Money) Negate() Money {
+	return Money{amount: -m.amount, currency: m.currency}
+}
+
+// Abs returns the absolute value of m
+func (m Money) Abs() Money {
+	if m.amount < 0 {
+		return m.Negate()
+	}
+	return m
+}
+
+// Compare returns -1, 0, or 1 if m is less than, equal to, or greater than o
+func (m Money) Compare(o Money) (int, error) {
+	if _, err := m.sameCurrency(o); err != nil {
+		return 0, err
+	}
+	switch {
+	case m.amount < o.amount:
+		return -1, nil
+	case m.amount > o.amount:
+		return 1, nil
+	}
+	return 0, nil
+}
+
+// Equal reports whether m and o have the same currency and amount
+func (m Money) Equal(o Money) bool {
+	return m.currency.Code == o.currency.Code && m.amount == o.amount
+}
+
+// Allocate splits m into parts proportional to ratios without losing minor units.
+// Leftover units are distributed one at a time to the earliest parts, so
+// New(100, USD).Allocate(1, 1, 1) returns $0.34, $0.33, $0.33.
+func (m Money) Allocate(ratios ...int) ([]Money, error) {
+	if len(ratios) == 0 {
+		return nil, fmt.Errorf("%w: no ratios", ErrInvalidRatio)
+	}
+
+	var total int64
+	for _, r := range ratios {
+		if r < 0 {
+			return nil, fmt.Errorf("%w: negative ratio %d", ErrInvalidRatio, r)
+		}
+		total += int64(r)
+	}
+	if total == 0 {
+		return nil, fmt.Errorf("%w: ratios sum to zero", ErrInvalidRatio)
+	}
+
+	parts := make([]Money, len(ratios))
+	remainder := m.amount
+	amount := big.NewInt(m.amount)
+	for i, r := range ratios {
+		share := new(big.Int).Mul(amount, big.NewInt(int64(r)))
+		share.Quo(share, big.NewInt(total))
+		parts[i] = Money{amount: share.Int64(), currency: m.currency}
+		remainder -= parts[i].amount
+	}
+
+	unit := int64(1)
+	if remainder < 0 {
+		unit = -1
+	}
+	for i := 0; remainder != 0; i = (i + 1) % len(parts) {
+		if ratios[i] == 0 {
+			continue
+		}
+		parts[i].amount += unit
+		remainder -= unit
+	}
+
+	return parts, nil
+}
+
+// Split divides m into n parts as evenly as possible without losing minor units
+func (m Money) Split(n int) ([]Money, error) {
+	if n <= 0 {
+		return nil, fmt.Errorf("%w: cannot split into %d parts", ErrInvalidRatio, n)
+	}
+	ratios := make([]int, n)
+	for i := range ratios {
+		ratios[i] = 1
+	}
+	return m.Allocate(ratios...)
+}
+
+// Sum adds amounts of the same currency. The sum of no amounts is the zero Money.
+func Sum(amounts ...Money) (Money, error) {
+	var total Money
+	for _, a := range amounts {
+		var err error
+		if total, err = total.Add(a); err != nil {
+			return Money{}, err
+		}
+	}
+	return total, nil
+}
+
+// Decimal returns the amount in major units without a symbol or grouping, e.g. "-1234.56"
+func (m Money) Decimal() string {
+	return m.format(false)
+}
+
+// Format returns the amount with the currency symbol and thousands separators, e.g. "-$1,234.56"
+func (m Money) Format() string {
+	s := m.format(true)
+	if strings.HasPrefix(s, "-") {
+		return "-" + m.currency.Symbol + s[1:]
+	}
+	return m.currency.Symbol + s
+}
+
+// String implements fmt.Stringer using Format
+func (m Money) String() string {
+	return m.Format()
+}
+
+// format renders the major-unit amount, optionally grouping thousands
+func (m Money) format(group bool) string {
+	// Work on the unsigned magnitude so math.MinInt64 formats correctly
+	mag := uint64(m.amount)
+	if m.amount < 0 {
+		mag = -mag
+	}
+	digits := strconv.FormatUint(mag, 10)
+	if pad := m.currency.Digits + 1 - len(digits); pad > 0 {
+		digits = strings.Repeat("0", pad) + digits
+	}
+
+	whole := digits[:len(digits)-m.currency.Digits]
+	frac := digits[len(digits)-m.currency.Digits:]
+
+	if group && len(whole) > 3 {
+		var b strings.Builder
+		lead := len(whole) % 3
+		if lead > 0 {
+			b.WriteString(whole[:lead])
+		}
+		for i := lead; i < len(whole); i += 3 {
+			if b.Len() > 0 {
+				b.WriteByte(',')
+			}
+			b.WriteString(whole[i : i+3])
+		}
+		whole = b.String()
+	}
+
+	s := whole
+	if frac != "" {
+		s += "." + frac
+	}
+	if m.amount < 0 {
+		s = "-" + s
+	}
+	return s
+}
+
+// moneyJSON is the wire format for Money
+type moneyJSON struct {
+	Amount   json.RawMessage `json:"amount"`
+	Currency string          `json:"currency"`
+}
+
+// MarshalJSON encodes m as {"amount":"12.34","currency":"USD"}. The amount is a
+// string so consumers never round-trip it through a float.
+func (m Money) MarshalJSON() ([]byte, error) {
+	amount, _ := json.Marshal(m.Decimal())
+	return json.Marshal(moneyJSON{Amount: amount, Currency: m.currency.Code})
+}
+
+// UnmarshalJSON decodes {"amount":"12.34","currency":"USD"}. The amount may also be
+// a JSON number, which is parsed from its text rather than as a float.
+func (m *Money) UnmarshalJSON(data []byte) error {
+	var v moneyJSON
+	if err := json.Unmarshal(data, &v); err != nil {
+		return err
+	}
+
+	c, err := LookupCurrency(v.Currency)
+	if err != nil {
+		return fmt.Errorf("%w: %q", err, v.Currency)
+	}
+
+	var amount string
+	if err := json.Unmarshal(v.Amount, &amount); err != nil {
+		var n json.Number
+		if err := json.Unmarshal(v.Amount, &n); err != nil {
+			return fmt.Errorf("%w: %s", ErrInvalidAmount, v.Amount)
+		}
+		amount = n.String()
+	}
+
+	parsed, err := Parse(amount, c)
+	if err != nil {
+		return err
+	}
+	*m = parsed
+	return nil
+}
+
+// sameCurrency returns the shared currency of m and o, treating the zero Money as currency-less
+func (m Money) sameCurrency(o Money) (Currency, error) {
+	switch {
+	case m.currency.Code == o.currency.Code:
+		return m.currency, nil
+	case m == Money{}:
+		return o.currency, nil
+	case o == Money{}:
+		return m.currency, nil
+	}
+	return Currency{}, fmt.Errorf("%w: %s and %s", ErrCurrencyMismatch, m.currency.Code, o.currency.Code)
+}
+
+// isDigits reports whether s contains only ASCII digits
+func isDigits(s string) bool {
+	for i := 0; i < len(s); i++ {
+		if s[i] < '0' || s[i] > '9' {
+			return false
+		}
+	}
+	return true
+}
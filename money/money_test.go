@@ -0,0 +1,229 @@
+package money
+
+import (
+	"encoding/json"
+	"errors"
+	"math"
+	"testing"
+)
+
+func TestParse(t *testing.T) {
+	tests := []struct {
+		input    string
+		currency Currency
+		want     int64
+		wantErr  error
+	}{
+		{"12.34", USD, 1234, nil},
+		{"$1,234.56", USD, 123456, nil},
+		{"-$5", USD, -500, nil},
+		{"$-5.5", USD, -550, nil},
+		{"12.50 USD", USD, 1250, nil},
+		{".99", USD, 99, nil},
+		{"1000", JPY, 1000, nil},
+		{"1.234", KWD, 1234, nil},
+		{"1.234", USD, 0, ErrInvalidAmount},
+		{"12.3.4", USD, 0, ErrInvalidAmount},
+		{"abc", USD, 0, ErrInvalidAmount},
+		{"", USD, 0, ErrInvalidAmount},
+		{"99999999999999999999", USD, 0, ErrOverflow},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.input, func(t *testing.T) {
+			got, err := Parse(tc.input, tc.currency)
+			if !errors.Is(err, tc.wantErr) {
+				t.Fatalf("Parse(%q) error = %v, want %v", tc.input, err, tc.wantErr)
+			}
+			if err == nil && got.Amount() != tc.want {
+				t.Errorf("Expected %d, got %d", tc.want, got.Amount())
+			}
+		})
+	}
+}
+
+func TestFromFloat(t *testing.T) {
+	// 0.1 + 0.2 is the classic float rounding bug
+	m, err := FromFloat(0.1+0.2, USD)
+	if err != nil {
+		t.Fatalf("FromFloat() error = %v", err)
+	}
+	if m.Amount() != 30 {
+		t.Errorf("Expected 30, got %d", m.Amount())
+	}
+
+	if _, err := FromFloat(math.Inf(1), USD); !errors.Is(err, ErrOverflow) {
+		t.Errorf("Expected ErrOverflow, got %v", err)
+	}
+}
+
+func TestArithmetic(t *testing.T) {
+	a := New(1050, USD)
+	b := New(250, USD)
+
+	sum, err := a.Add(b)
+	if err != nil || sum.Amount() != 1300 {
+		t.Errorf("Add() = %v, %v", sum, err)
+	}
+
+	diff, err := b.Sub(a)
+	if err != nil || diff.Amount() != -800 {
+		t.Errorf("Sub() = %v, %v", diff, err)
+	}
+
+	product, err := a.Multiply(3)
+	if err != nil || product.Amount() != 3150 {
+		t.Errorf("Multiply() = %v, %v", product, err)
+	}
+
+	if _, err := a.Add(New(100, EUR)); !errors.Is(err, ErrCurrencyMismatch) {
+		t.Errorf("Expected ErrCurrencyMismatch, got %v", err)
+	}
+	if _, err := New(math.MaxInt64, USD).Add(New(1, USD)); !errors.Is(err, ErrOverflow) {
+		t.Errorf("Expected ErrOverflow from Add, got %v", err)
+	}
+	if _, err := New(math.MinInt64, USD).Sub(New(1, USD)); !errors.Is(err, ErrOverflow) {
+		t.Errorf("Expected ErrOverflow from Sub, got %v", err)
+	}
+	if _, err := New(math.MaxInt64/2+1, USD).Multiply(2); !errors.Is(err, ErrOverflow) {
+		t.Errorf("Expected ErrOverflow from Multiply, got %v", err)
+	}
+
+	var total Money
+	if total, err = total.Add(a); err != nil || !total.Equal(a) {
+		t.Errorf("Expected zero Money to adopt currency, got %v, %v", total, err)
+	}
+}
+
+func TestMultiplyFraction(t *testing.T) {
+	tests := []struct {
+		name     string
+		amount   int64
+		num, den int64
+		want     int64
+	}{
+		{"tax", 1999, 825, 10000, 165},
+		{"round half up", 50, 1, 100, 1},
+		{"round half away from zero", -50, 1, 100, -1},
+		{"negative denominator", 1000, 1, -4, -250},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			got, err := New(tc.amount, USD).MultiplyFraction(tc.num, tc.den)
+			if err != nil {
+				t.Fatalf("MultiplyFraction() error = %v", err)
+			}
+			if got.Amount() != tc.want {
+				t.Errorf("Expected %d, got %d", tc.want, got.Amount())
+			}
+		})
+	}
+
+	if _, err := New(1, USD).MultiplyFraction(1, 0); !errors.Is(err, ErrInvalidRatio) {
+		t.Errorf("Expected ErrInvalidRatio, got %v", err)
+	}
+}
+
+func TestAllocate(t *testing.T) {
+	tests := []struct {
+		name   string
+		amount int64
+		ratios []int
+		want   []int64
+	}{
+		{"even thirds", 100, []int{1, 1, 1}, []int64{34, 33, 33}},
+		{"weighted", 1000, []int{70, 20, 10}, []int64{700, 200, 100}},
+		{"uneven weighted", 5, []int{3, 7}, []int64{2, 3}},
+		{"negative", -100, []int{1, 1, 1}, []int64{-34, -33, -33}},
+		{"zero ratio skipped", 101, []int{0, 1, 1}, []int64{0, 51, 50}},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			parts, err := New(tc.amount, USD).Allocate(tc.ratios...)
+			if err != nil {
+				t.Fatalf("Allocate() error = %v", err)
+			}
+
+			var total int64
+			for i, p := range parts {
+				if p.Amount() != tc.want[i] {
+					t.Errorf("Part %d: expected %d, got %d", i, tc.want[i], p.Amount())
+				}
+				total += p.Amount()
+			}
+			if total != tc.amount {
+				t.Errorf("Expected parts to sum to %d, got %d", tc.amount, total)
+			}
+		})
+	}
+
+	if _, err := New(100, USD).Allocate(0, 0); !errors.Is(err, ErrInvalidRatio) {
+		t.Errorf("Expected ErrInvalidRatio, got %v", err)
+	}
+	if _, err := New(100, USD).Split(0); !errors.Is(err, ErrInvalidRatio) {
+		t.Errorf("Expected ErrInvalidRatio, got %v", err)
+	}
+}
+
+func TestFormat(t *testing.T) {
+	tests := []struct {
+		money       Money
+		wantFormat  string
+		wantDecimal string
+	}{
+		{New(123456, USD), "$1,234.56", "1234.56"},
+		{New(-5, USD), "-$0.05", "-0.05"},
+		{New(0, USD), "$0.00", "0.00"},
+		{New(123456789, JPY), "¥123,456,789", "123456789"},
+		{New(1500, KWD), "KD 1.500", "1.500"},
+		{New(math.MinInt64, USD), "-$92,233,720,368,547,758.08", "-92233720368547758.08"},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.wantFormat, func(t *testing.T) {
+			if got := tc.money.Format(); got != tc.wantFormat {
+				t.Errorf("Format() = %q, want %q", got, tc.wantFormat)
+			}
+			if got := tc.money.Decimal(); got != tc.wantDecimal {
+				t.Errorf("Decimal() = %q, want %q", got, tc.wantDecimal)
+			}
+		})
+	}
+}
+
+func TestJSON(t *testing.T) {
+	type order struct {
+		Total Money `json:"total"`
+	}
+
+	data, err := json.Marshal(order{Total: New(1999, USD)})
+	if err != nil {
+		t.Fatalf("Marshal() error = %v", err)
+	}
+	if string(data) != `{"total":{"amount":"19.99","currency":"USD"}}` {
+		t.Errorf("Unexpected JSON %s", data)
+	}
+
+	var decoded order
+	if err := json.Unmarshal(data, &decoded); err != nil {
+		t.Fatalf("Unmarshal() error = %v", err)
+	}
+	if !decoded.Total.Equal(New(1999, USD)) {
+		t.Errorf("Expected round trip, got %v", decoded.Total)
+	}
+
+	var fromNumber Money
+	if err := json.Unmarshal([]byte(`{"amount":0.3,"currency":"eur"}`), &fromNumber); err != nil {
+		t.Fatalf("Unmarshal() error = %v", err)
+	}
+	if !fromNumber.Equal(New(30, EUR)) {
+		t.Errorf("Expected EUR 0.30, got %v", fromNumber)
+	}
+
+	var bad Money
+	if err := json.Unmarshal([]byte(`{"amount":"1","currency":"XXX"}`), &bad); !errors.Is(err, ErrUnknownCurrency) {
+		t.Errorf("Expected ErrUnknownCurrency, got %v", err)
+	}
+}
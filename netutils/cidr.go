@@ -0,0 +1,79 @@
+package netutils
+
+import (
+	"fmt"
+	"net/netip"
+	"strings"
+)
+
+// CIDRSet is an immutable set of IP prefixes, safe for concurrent use
+type CIDRSet struct {
+	prefixes []netip.Prefix
+}
+
+// ParseCIDRs parses CIDR blocks such as "10.0.0.0/8" or "2001:db8::/32". Bare
+// addresses are treated as single-host prefixes.
+func ParseCIDRs(cidrs ...string) (*CIDRSet, error) {
+	set := &CIDRSet{prefixes: make([]netip.Prefix, 0, len(cidrs))}
+	for _, c := range cidrs {
+		c = strings.TrimSpace(c)
+		if c == "" {
+			continue
+		}
+
+		if !strings.Contains(c, "/") {
+			addr, err := netip.ParseAddr(c)
+			if err != nil {
+				return nil, fmt.Errorf("invalid CIDR %q: %w", c, err)
+			}
+			set.prefixes = append(set.prefixes, netip.PrefixFrom(addr.Unmap(), addr.Unmap().BitLen()))
+			continue
+		}
+
+		p, err := netip.ParsePrefix(c)
+		if err != nil {
+			return nil, fmt.Errorf("invalid CIDR %q: %w", c, err)
+		}
+		set.prefixes = append(set.prefixes, p.Masked())
+	}
+	return set, nil
+}
+
+// Contains reports whether ip is in any prefix of the set. Invalid addresses are not contained.
+func (s *CIDRSet) Contains(ip string) bool {
+	addr, err := netip.ParseAddr(strings.TrimSpace(ip))
+	if err != nil {
+		return false
+	}
+	return s.ContainsAddr(addr)
+}
+
+// ContainsAddr reports whether addr is in any prefix of the set
+func (s *CIDRSet) ContainsAddr(addr netip.Addr) bool {
+	addr = addr.Unmap()
+	for _, p := range s.prefixes {
+		if p.Contains(addr) {
+			return true
+		}
+	}
+	return false
+}
+
+// InCIDR reports whether ip is in any of the given CIDR blocks
+func InCIDR(ip string, cidrs ...string) (bool, error) {
+	set, err := ParseCIDRs(cidrs...)
+	if err != nil {
+		return false, err
+	}
+	return set.Contains(ip), nil
+}
+
+// IsPrivateIP reports whether ip is a loopback, link-local, or private (RFC 1918 / RFC 4193) address
+func IsPrivateIP(ip string) bool {
+	addr, err := netip.ParseAddr(strings.TrimSpace(ip))
+	if err != nil {
+		return false
+	}
+	addr = addr.Unmap()
+	return addr.IsPrivate() || addr.IsLoopback() || addr.IsLinkLocalUnicast()
+}
@@ -0,0 +1,27 @@
+package netutils
+
+import (
+	"net"
+	"time"
+)
+
+const (
+	// DefaultDialTimeout bounds how long establishing a connection may take
+	DefaultDialTimeout = 5 * time.Second
+
+	// DefaultKeepAlive is the TCP keep-alive period for dialed connections
+	DefaultKeepAlive = 30 * time.Second
+)
+
+// NewDialer returns a net.Dialer with the given connect timeout and a 30 second
+// keep-alive. A timeout of zero uses DefaultDialTimeout; the zero net.Dialer has
+// no timeout at all and can hang for minutes on unreachable hosts.
+func NewDialer(timeout time.Duration) *net.Dialer {
+	if timeout <= 0 {
+		timeout = DefaultDialTimeout
+	}
+	return &net.Dialer{
+		Timeout:   timeout,
+		KeepAlive: DefaultKeepAlive,
+	}
+}
@@ -0,0 +1,56 @@
+/*
+Package netutils provides networking helpers for integration tests and service
+bootstrapping.
+
+# Features
+
+  - FreePort for picking a port in tests
+  - WaitForPort and WaitForHTTP readiness checks that respect context deadlines
+  - CIDRSet for allowlists and trusted proxy checks
+  - IsPrivateIP for detecting internal addresses
+  - NewDialer with connect and keep-alive timeouts
+
+# Readiness Checks
+
+Waiting for a dependency started by docker compose before running tests:
+
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+
+	if err := netutils.WaitForPort(ctx, "localhost:5432"); err != nil {
+		log.Fatal(err)
+	}
+
+	if err := netutils.WaitForHTTP(ctx, "http://localhost:8080/healthz"); err != nil {
+		log.Fatal(err)
+	}
+
+Both helpers retry every DefaultPollInterval until the check succeeds or the
+context is done, returning ErrNotReady wrapped with the last failure.
+
+# CIDR Checks
+
+Parse allowlists once at startup and share the set between goroutines:
+
+	allowed, err := netutils.ParseCIDRs(cfg.AllowedCIDRs...)
+	if err != nil {
+		return err
+	}
+
+	if !allowed.Contains(clientIP) {
+		return api.ForbiddenError(errors.New("address not allowed"))
+	}
+
+Bare addresses such as "203.0.113.7" are accepted as single-host blocks, and
+IPv4-mapped IPv6 addresses match their IPv4 blocks.
+
+# Dialers
+
+The zero net.Dialer has no connect timeout. NewDialer returns one with a bounded
+timeout and TCP keep-alives:
+
+	transport := &http.Transport{
+		DialContext: netutils.NewDialer(3 * time.Second).DialContext,
+	}
+*/
+package netutils
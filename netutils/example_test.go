@@ -0,0 +1,21 @@
+package netutils_test
+
+import (
+	"fmt"
+
+	"github.com/StairSupplies/go-core/netutils"
+)
+
+func ExampleParseCIDRs() {
+	trusted, err := netutils.ParseCIDRs("10.0.0.0/8", "192.168.0.0/16")
+	if err != nil {
+		fmt.Printf("Error parsing CIDRs: %v\n", err)
+		return
+	}
+
+	fmt.Println(trusted.Contains("10.20.30.40"))
+	fmt.Println(trusted.Contains("8.8.8.8"))
+	// Output:
+	// true
+	// false
+}
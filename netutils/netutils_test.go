@@ -0,0 +1,145 @@
+package netutils
+
+import (
+	"context"
+	"errors"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestFreePort(t *testing.T) {
+	port, err := FreePort()
+	if err != nil {
+		t.Fatalf("FreePort() error = %v", err)
+	}
+
+	l, err := net.Listen("tcp", "127.0.0.1:"+strconv.Itoa(port))
+	if err != nil {
+		t.Fatalf("Expected port %d to be free: %v", port, err)
+	}
+	l.Close()
+}
+
+func TestWaitForPort(t *testing.T) {
+	port, _ := FreePort()
+	addr := "127.0.0.1:" + strconv.Itoa(port)
+
+	go func() {
+		time.Sleep(50 * time.Millisecond)
+		l, err := net.Listen("tcp", addr)
+		if err != nil {
+			return
+		}
+		t.Cleanup(func() { l.Close() })
+	}()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+	defer cancel()
+
+	if err := WaitForPort(ctx, addr, WithPollInterval(10*time.Millisecond)); err != nil {
+		t.Fatalf("WaitForPort() error = %v", err)
+	}
+}
+
+func TestWaitForPort_Timeout(t *testing.T) {
+	port, _ := FreePort()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+
+	err := WaitForPort(ctx, "127.0.0.1:"+strconv.Itoa(port), WithPollInterval(10*time.Millisecond))
+	if !errors.Is(err, ErrNotReady) {
+		t.Errorf("Expected ErrNotReady, got %v", err)
+	}
+}
+
+func TestWaitForHTTP(t *testing.T) {
+	var calls atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if calls.Add(1) < 3 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+	defer cancel()
+
+	if err := WaitForHTTP(ctx, server.URL+"/healthz", WithPollInterval(10*time.Millisecond)); err != nil {
+		t.Fatalf("WaitForHTTP() error = %v", err)
+	}
+	if calls.Load() != 3 {
+		t.Errorf("Expected 3 calls, got %d", calls.Load())
+	}
+}
+
+func TestCIDRSet(t *testing.T) {
+	set, err := ParseCIDRs("10.0.0.0/8", "192.168.1.0/24", "2001:db8::/32", "203.0.113.7", " ")
+	if err != nil {
+		t.Fatalf("ParseCIDRs() error = %v", err)
+	}
+
+	tests := []struct {
+		ip   string
+		want bool
+	}{
+		{"10.1.2.3", true},
+		{"192.168.1.254", true},
+		{"192.168.2.1", false},
+		{"2001:db8::1", true},
+		{"::ffff:10.0.0.1", true},
+		{"203.0.113.7", true},
+		{"203.0.113.8", false},
+		{"not-an-ip", false},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.ip, func(t *testing.T) {
+			if got := set.Contains(tc.ip); got != tc.want {
+				t.Errorf("Contains(%q) = %v, want %v", tc.ip, got, tc.want)
+			}
+		})
+	}
+
+	if _, err := ParseCIDRs("10.0.0.0/33"); err == nil {
+		t.Error("Expected error for invalid CIDR")
+	}
+	if ok, err := InCIDR("172.16.5.4", "172.16.0.0/12"); !ok || err != nil {
+		t.Errorf("InCIDR() = %v, %v", ok, err)
+	}
+}
+
+func TestIsPrivateIP(t *testing.T) {
+	tests := map[string]bool{
+		"10.0.0.1":    true,
+		"172.16.0.1":  true,
+		"192.168.0.1": true,
+		"127.0.0.1":   true,
+		"169.254.1.1": true,
+		"fd00::1":     true,
+		"8.8.8.8":     false,
+		"garbage":     false,
+	}
+
+	for ip, want := range tests {
+		if got := IsPrivateIP(ip); got != want {
+			t.Errorf("IsPrivateIP(%q) = %v, want %v", ip, got, want)
+		}
+	}
+}
+
+func TestNewDialer(t *testing.T) {
+	if d := NewDialer(0); d.Timeout != DefaultDialTimeout || d.KeepAlive != DefaultKeepAlive {
+		t.Errorf("Unexpected defaults %+v", d)
+	}
+	if d := NewDialer(time.Second); d.Timeout != time.Second {
+		t.Errorf("Expected 1s timeout, got %v", d.Timeout)
+	}
+}
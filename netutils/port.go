@@ -0,0 +1,116 @@
+package netutils
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"time"
+)
+
+// DefaultPollInterval is the delay between readiness checks
+const DefaultPollInterval = 100 * time.Millisecond
+
+// ErrNotReady is returned when a readiness check gives up
+var ErrNotReady = errors.New("not ready")
+
+// FreePort returns a TCP port on the loopback interface that is free at the time of the call.
+// Another process may claim it before it is used, so prefer listening on port 0 when possible.
+func FreePort() (int, error) {
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		return 0, fmt.Errorf("failed to find a free port: %w", err)
+	}
+	defer l.Close()
+	return l.Addr().(*net.TCPAddr).Port, nil
+}
+
+// waitOptions configures the readiness helpers
+type waitOptions struct {
+	interval time.Duration
+	client   *http.Client
+}
+
+// WaitOption configures WaitForPort and WaitForHTTP
+type WaitOption func(*waitOptions)
+
+// WithPollInterval sets the delay between attempts. Defaults to DefaultPollInterval.
+func WithPollInterval(d time.Duration) WaitOption {
+	return func(o *waitOptions) {
+		o.interval = d
+	}
+}
+
+// WithHTTPClient sets the client used by WaitForHTTP
+func WithHTTPClient(c *http.Client) WaitOption {
+	return func(o *waitOptions) {
+		o.client = c
+	}
+}
+
+// WaitForPort blocks until a TCP connection to addr succeeds or ctx is done
+func WaitForPort(ctx context.Context, addr string, opts ...WaitOption) error {
+	o := newWaitOptions(opts)
+	dialer := NewDialer(0)
+
+	return poll(ctx, o.interval, func() error {
+		conn, err := dialer.DialContext(ctx, "tcp", addr)
+		if err != nil {
+			return err
+		}
+		return conn.Close()
+	}, addr)
+}
+
+// WaitForHTTP blocks until a GET request to url returns a 2xx status or ctx is done
+func WaitForHTTP(ctx context.Context, url string, opts ...WaitOption) error {
+	o := newWaitOptions(opts)
+
+	return poll(ctx, o.interval, func() error {
+		req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+		if err != nil {
+			return err
+		}
+		resp, err := o.client.Do(req)
+		if err != nil {
+			return err
+		}
+		resp.Body.Close()
+		if resp.StatusCode < 200 || resp.StatusCode > 299 {
+			return fmt.Errorf("unexpected status %d", resp.StatusCode)
+		}
+		return nil
+	}, url)
+}
+
+// newWaitOptions applies opts over the defaults
+func newWaitOptions(opts []WaitOption) waitOptions {
+	o := waitOptions{interval: DefaultPollInterval}
+	for _, opt := range opts {
+		opt(&o)
+	}
+	if o.client == nil {
+		o.client = &http.Client{Timeout: 5 * time.Second}
+	}
+	return o
+}
+
+// poll calls check every interval until it succeeds or ctx is done
+func poll(ctx context.Context, interval time.Duration, check func() error, target string) error {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		err := check()
+		if err == nil {
+			return nil
+		}
+
+		select {
+		case <-ctx.Done():
+			return fmt.Errorf("%w: %s: %w", ErrNotReady, target, err)
+		case <-ticker.C:
+		}
+	}
+}
@@ -0,0 +1,216 @@
+package rest
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+)
+
+// AuthProvider authenticates outgoing requests. Authenticate is called before
+// every attempt, so implementations can refresh tokens, rotate keys or sign
+// the request.
+type AuthProvider interface {
+	Authenticate(ctx context.Context, req *http.Request) error
+}
+
+// AuthInvalidator is implemented by providers that cache credentials. When the
+// server responds with 401 the client calls Invalidate and retries once.
+type AuthInvalidator interface {
+	Invalidate()
+}
+
+// AuthProviderFunc adapts a function to the AuthProvider interface
+type AuthProviderFunc func(ctx context.Context, req *http.Request) error
+
+// Authenticate calls f(ctx, req)
+func (f AuthProviderFunc) Authenticate(ctx context.Context, req *http.Request) error {
+	return f(ctx, req)
+}
+
+// BearerToken returns a provider that sets a static bearer token
+func BearerToken(token string) AuthProvider {
+	return AuthProviderFunc(func(ctx context.Context, req *http.Request) error {
+		req.Header.Set("Authorization", "Bearer "+token)
+		return nil
+	})
+}
+
+// APIKeyProvider sets an API key header. The key can be rotated at runtime
+// without rebuilding the client.
+type APIKeyProvider struct {
+	header string
+	mu     sync.RWMutex
+	key    string
+}
+
+// NewAPIKeyProvider creates a provider that sends key in the given header
+func NewAPIKeyProvider(header, key string) *APIKeyProvider {
+	return &APIKeyProvider{header: header, key: key}
+}
+
+// Rotate replaces the key used for subsequent requests
+func (p *APIKeyProvider) Rotate(key string) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.key = key
+}
+
+// Authenticate sets the API key header
+func (p *APIKeyProvider) Authenticate(ctx context.Context, req *http.Request) error {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+	req.Header.Set(p.header, p.key)
+	return nil
+}
+
+// ClientCredentialsConfig configures an OAuth2 client credentials provider
+type ClientCredentialsConfig struct {
+	// TokenURL is the authorization server's token endpoint
+	TokenURL string
+	// ClientID and ClientSecret identify the client
+	ClientID     string
+	ClientSecret string
+	// Scopes are requested with every token
+	Scopes []string
+	// EndpointParams are extra form values sent to the token endpoint, e.g. audience
+	EndpointParams url.Values
+	// AuthInParams sends the client credentials in the form body instead of
+	// HTTP basic auth
+	AuthInParams bool
+	// ExpiryMargin refreshes the token this long before it expires (default 30s)
+	ExpiryMargin time.Duration
+	// HTTPClient is used for token requests (default http.DefaultClient)
+	HTTPClient *http.Client
+}
+
+// ClientCredentialsProvider fetches and caches OAuth2 access tokens using the
+// client credentials grant
+type ClientCredentialsProvider struct {
+	cfg ClientCredentialsConfig
+	now func() time.Time
+
+	mu      sync.Mutex
+	token   string
+	expires time.Time
+}
+
+// NewClientCredentials creates an OAuth2 client credentials provider
+func NewClientCredentials(cfg ClientCredentialsConfig) (*ClientCredentialsProvider, error) {
+	if cfg.TokenURL == "" {
+		return nil, fmt.Errorf("token URL is required")
+	}
+	if cfg.ClientID == "" {
+		return nil, fmt.Errorf("client ID is required")
+	}
+	if cfg.ExpiryMargin == 0 {
+		cfg.ExpiryMargin = 30 * time.Second
+	}
+	if cfg.HTTPClient == nil {
+		cfg.HTTPClient = http.DefaultClient
+	}
+
+	return &ClientCredentialsProvider{cfg: cfg, now: time.Now}, nil
+}
+
+// Authenticate sets a bearer token, fetching a new one if the cached token
+// is missing or about to expire
+func (p *ClientCredentialsProvider) Authenticate(ctx context.Context, req *http.Request) error {
+	token, err := p.Token(ctx)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Authorization", "Bearer "+token)
+	return nil
+}
+
+// Invalidate drops the cached token so the next request fetches a new one
+func (p *ClientCredentialsProvider) Invalidate() {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.token = ""
+}
+
+// Token returns a valid access token
+func (p *ClientCredentialsProvider) Token(ctx context.Context) (string, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if p.token != "" && (p.expires.IsZero() || p.now().Add(p.cfg.ExpiryMargin).Before(p.expires)) {
+		return p.token, nil
+	}
+
+	token, expiresIn, err := p.fetch(ctx)
+	if err != nil {
+		return "", err
+	}
+
+	p.token = token
+	p.expires = time.Time{}
+	if expiresIn > 0 {
+		p.expires = p.now().Add(time.Duration(expiresIn) * time.Second)
+	}
+	return token, nil
+}
+
+// fetch requests a new token from the token endpoint
+func (p *ClientCredentialsProvider) fetch(ctx context.Context) (string, int64, error) {
+	form := url.Values{}
+	for k, v := range p.cfg.EndpointParams {
+		form[k] = v
+	}
+	form.Set("grant_type", "client_credentials")
+	if len(p.cfg.Scopes) > 0 {
+		form.Set("scope", strings.Join(p.cfg.Scopes, " "))
+	}
+	if p.cfg.AuthInParams {
+		form.Set("client_id", p.cfg.ClientID)
+		form.Set("client_secret", p.cfg.ClientSecret)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, p.cfg.TokenURL, strings.NewReader(form.Encode()))
+	if err != nil {
+		return "", 0, fmt.Errorf("failed to create token request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	req.Header.Set("Accept", "application/json")
+	if !p.cfg.AuthInParams {
+		req.SetBasicAuth(url.QueryEscape(p.cfg.ClientID), url.QueryEscape(p.cfg.ClientSecret))
+	}
+
+	resp, err := p.cfg.HTTPClient.Do(req)
+	if err != nil {
+		return "", 0, fmt.Errorf("token request failed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(io.LimitReader(resp.Body, 1<<20))
+	if err != nil {
+		return "", 0, fmt.Errorf("failed to read token response: %w", err)
+	}
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return "", 0, fmt.Errorf("token endpoint returned %d: %s", resp.StatusCode, strings.TrimSpace(string(body)))
+	}
+
+	var tok struct {
+		AccessToken string `json:"access_token"`
+		TokenType   string `json:"token_type"`
+		ExpiresIn   int64  `json:"expires_in"`
+	}
+	if err := json.Unmarshal(body, &tok); err != nil {
+		return "", 0, fmt.Errorf("failed to parse token response: %w", err)
+	}
+	if tok.AccessToken == "" {
+		return "", 0, fmt.Errorf("token response has no access_token")
+	}
+	if tok.TokenType != "" && !strings.EqualFold(tok.TokenType, "bearer") {
+		return "", 0, fmt.Errorf("unsupported token type %q", tok.TokenType)
+	}
+
+	return tok.AccessToken, tok.ExpiresIn, nil
+}
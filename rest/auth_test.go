@@ -0,0 +1,171 @@
+package rest
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/StairSupplies/go-core/logger"
+)
+
+func newTokenServer(t *testing.T, issued *int32) *httptest.Server {
+	t.Helper()
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if err := r.ParseForm(); err != nil {
+			t.Errorf("Failed to parse token form: %v", err)
+		}
+		if r.Form.Get("grant_type") != "client_credentials" {
+			t.Errorf("Expected client_credentials grant, got %q", r.Form.Get("grant_type"))
+		}
+		if r.Form.Get("scope") != "orders:read orders:write" {
+			t.Errorf("Unexpected scope %q", r.Form.Get("scope"))
+		}
+		if id, secret, ok := r.BasicAuth(); !ok || id != "client" || secret != "s3cret" {
+			w.WriteHeader(http.StatusUnauthorized)
+			w.Write([]byte(`{"error":"invalid_client"}`))
+			return
+		}
+
+		n := atomic.AddInt32(issued, 1)
+		w.Header().Set("Content-Type", "application/json")
+		fmt.Fprintf(w, `{"access_token":"token-%d","token_type":"Bearer","expires_in":3600}`, n)
+	}))
+	t.Cleanup(server.Close)
+	return server
+}
+
+func TestBearerToken(t *testing.T) {
+	req := httptest.NewRequest(http.MethodGet, "/", nil)
+	if err := BearerToken("abc").Authenticate(context.Background(), req); err != nil {
+		t.Fatalf("Authenticate() error = %v", err)
+	}
+	if got := req.Header.Get("Authorization"); got != "Bearer abc" {
+		t.Errorf("Expected 'Bearer abc', got %q", got)
+	}
+}
+
+func TestAPIKeyProvider(t *testing.T) {
+	provider := NewAPIKeyProvider("X-API-Key", "key-1")
+
+	var seen []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		seen = append(seen, r.Header.Get("X-API-Key"))
+	}))
+	defer server.Close()
+
+	client, _ := NewClient(WithBaseURL(server.URL), WithAuthProvider(provider), WithLogger(logger.NewNopLogger()))
+
+	client.Get(context.Background(), "/", nil)
+	provider.Rotate("key-2")
+	client.Get(context.Background(), "/", nil)
+
+	if len(seen) != 2 || seen[0] != "key-1" || seen[1] != "key-2" {
+		t.Errorf("Expected keys [key-1 key-2], got %v", seen)
+	}
+}
+
+func TestClientCredentialsProvider(t *testing.T) {
+	var issued int32
+	tokenServer := newTokenServer(t, &issued)
+
+	provider, err := NewClientCredentials(ClientCredentialsConfig{
+		TokenURL:     tokenServer.URL,
+		ClientID:     "client",
+		ClientSecret: "s3cret",
+		Scopes:       []string{"orders:read", "orders:write"},
+	})
+	if err != nil {
+		t.Fatalf("NewClientCredentials() error = %v", err)
+	}
+	now := time.Unix(1700000000, 0)
+	provider.now = func() time.Time { return now }
+
+	t.Run("token is cached", func(t *testing.T) {
+		for i := 0; i < 3; i++ {
+			token, err := provider.Token(context.Background())
+			if err != nil {
+				t.Fatalf("Token() error = %v", err)
+			}
+			if token != "token-1" {
+				t.Errorf("Expected token-1, got %q", token)
+			}
+		}
+	})
+
+	t.Run("token is refreshed before expiry", func(t *testing.T) {
+		now = now.Add(3600*time.Second - 10*time.Second)
+		token, _ := provider.Token(context.Background())
+		if token != "token-2" {
+			t.Errorf("Expected token-2, got %q", token)
+		}
+	})
+
+	t.Run("invalid credentials", func(t *testing.T) {
+		bad, _ := NewClientCredentials(ClientCredentialsConfig{
+			TokenURL: tokenServer.URL,
+			ClientID: "client",
+			Scopes:   []string{"orders:read", "orders:write"},
+		})
+		client, _ := NewClient(WithBaseURL(tokenServer.URL), WithAuthProvider(bad), WithLogger(logger.NewNopLogger()))
+		err := client.Get(context.Background(), "/orders", nil)
+		if !errors.Is(err, ErrUnauthorized) {
+			t.Errorf("Expected ErrUnauthorized, got %v", err)
+		}
+	})
+}
+
+func TestNewClientCredentials_Validation(t *testing.T) {
+	if _, err := NewClientCredentials(ClientCredentialsConfig{ClientID: "client"}); err == nil {
+		t.Error("Expected error for missing token URL")
+	}
+	if _, err := NewClientCredentials(ClientCredentialsConfig{TokenURL: "http://example.com"}); err == nil {
+		t.Error("Expected error for missing client ID")
+	}
+}
+
+func TestClient_AuthRetryOnUnauthorized(t *testing.T) {
+	var issued int32
+	tokenServer := newTokenServer(t, &issued)
+
+	provider, _ := NewClientCredentials(ClientCredentialsConfig{
+		TokenURL:     tokenServer.URL,
+		ClientID:     "client",
+		ClientSecret: "s3cret",
+		Scopes:       []string{"orders:read", "orders:write"},
+	})
+
+	// The API only accepts the second token, as if the first had been revoked
+	var bodies []string
+	api := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		buf := make([]byte, 64)
+		n, _ := r.Body.Read(buf)
+		bodies = append(bodies, string(buf[:n]))
+
+		if r.Header.Get("Authorization") != "Bearer token-2" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		w.Write([]byte(`{"id":1}`))
+	}))
+	defer api.Close()
+
+	client, _ := NewClient(WithBaseURL(api.URL), WithAuthProvider(provider), WithLogger(logger.NewNopLogger()))
+
+	var out struct {
+		ID int `json:"id"`
+	}
+	if err := client.Post(context.Background(), "/orders", map[string]int{"qty": 2}, &out); err != nil {
+		t.Fatalf("Post() error = %v", err)
+	}
+	if out.ID != 1 {
+		t.Errorf("Expected id 1, got %d", out.ID)
+	}
+	if len(bodies) != 2 || bodies[0] != `{"qty":2}` || bodies[1] != `{"qty":2}` {
+		t.Errorf("Expected the body to be resent, got %q", bodies)
+	}
+}
@@ -0,0 +1,141 @@
+package rest
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"sync/atomic"
+)
+
+// DefaultBatchConcurrency is how many requests Batch runs at once when no
+// concurrency is given
+const DefaultBatchConcurrency = 8
+
+// ErrBatchCanceled is the error of requests that were canceled or never
+// started because an earlier request in a fail-fast batch failed
+var ErrBatchCanceled = errors.New("batch canceled after a request failed")
+
+// BatchRequest is one request made by Batch
+type BatchRequest struct {
+	Method string
+	Path   string
+	// Body, if not nil, is sent as JSON
+	Body interface{}
+	// Response, if not nil, is decoded from a successful JSON response
+	Response interface{}
+}
+
+// BatchResult is the outcome of one BatchRequest
+type BatchResult struct {
+	// Request is the request this result belongs to
+	Request BatchRequest
+	// Err is the error returned by the request, nil on success
+	Err error
+}
+
+// batchSettings holds the settings applied by BatchOption
+type batchSettings struct {
+	failFast bool
+}
+
+// BatchOption configures Batch
+type BatchOption func(*batchSettings)
+
+// WithFailFast stops a batch at the first failed request: requests in
+// flight are canceled and requests not yet started are skipped, all with
+// ErrBatchCanceled. Without it every request runs and all errors are
+// collected.
+func WithFailFast() BatchOption {
+	return func(s *batchSettings) {
+		s.failFast = true
+	}
+}
+
+// Batch makes requests with client, at most concurrency at a time,
+// DefaultBatchConcurrency if zero or less, and returns one result per
+// request in the same order. Each request goes through client.Request, so
+// it is retried, authenticated and decoded like any other.
+//
+//	results := rest.Batch(ctx, client, []rest.BatchRequest{
+//		{Method: http.MethodGet, Path: "/products/1", Response: &p1},
+//		{Method: http.MethodGet, Path: "/products/2", Response: &p2},
+//	}, 4)
+//	if err := rest.BatchError(results); err != nil {
+//		return err
+//	}
+func Batch(ctx context.Context, client *Client, requests []BatchRequest, concurrency int, opts ...BatchOption) []BatchResult {
+	var settings batchSettings
+	for _, opt := range opts {
+		opt(&settings)
+	}
+	if concurrency <= 0 {
+		concurrency = DefaultBatchConcurrency
+	}
+
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	// aborted is set when a fail-fast batch cancels ctx itself
+	var aborted atomic.Bool
+
+	results := make([]BatchResult, len(requests))
+	sem := make(chan struct{}, concurrency)
+	var wg sync.WaitGroup
+
+	for i, req := range requests {
+		results[i].Request = req
+
+		select {
+		case sem <- struct{}{}:
+		case <-ctx.Done():
+			results[i].Err = batchCanceled(ctx, &aborted)
+			continue
+		}
+		// A slot may be free even though the batch was just canceled
+		if ctx.Err() != nil {
+			<-sem
+			results[i].Err = batchCanceled(ctx, &aborted)
+			continue
+		}
+
+		wg.Add(1)
+		go func(i int, req BatchRequest) {
+			defer wg.Done()
+			defer func() { <-sem }()
+
+			err := client.Request(ctx, req.Method, req.Path, req.Body, req.Response)
+			if errors.Is(err, context.Canceled) && aborted.Load() {
+				err = ErrBatchCanceled
+			}
+			results[i].Err = err
+			if err != nil && settings.failFast && !errors.Is(err, ErrBatchCanceled) {
+				aborted.Store(true)
+				cancel()
+			}
+		}(i, req)
+	}
+
+	wg.Wait()
+	return results
+}
+
+// batchCanceled returns the error for a request skipped because ctx is done
+func batchCanceled(ctx context.Context, aborted *atomic.Bool) error {
+	if aborted.Load() {
+		return ErrBatchCanceled
+	}
+	return ctx.Err()
+}
+
+// BatchError joins the errors of the failed results, each prefixed with its
+// request, or returns nil if every request succeeded. Use errors.Is and
+// errors.As on it to check for particular failures.
+func BatchError(results []BatchResult) error {
+	var errs []error
+	for _, r := range results {
+		if r.Err != nil {
+			errs = append(errs, fmt.Errorf("%s %s: %w", r.Request.Method, r.Request.Path, r.Err))
+		}
+	}
+	return errors.Join(errs...)
+}
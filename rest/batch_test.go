@@ -0,0 +1,149 @@
+package rest
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/StairSupplies/go-core/logger"
+)
+
+func newBatchTestClient(t *testing.T, h http.HandlerFunc) *Client {
+	t.Helper()
+	server := httptest.NewServer(h)
+	t.Cleanup(server.Close)
+	client, err := NewClient(WithBaseURL(server.URL), WithLogger(logger.NewNopLogger()), WithRetries(0))
+	if err != nil {
+		t.Fatal(err)
+	}
+	return client
+}
+
+func TestBatch(t *testing.T) {
+	var inFlight, maxInFlight atomic.Int32
+	client := newBatchTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		n := inFlight.Add(1)
+		defer inFlight.Add(-1)
+		for {
+			m := maxInFlight.Load()
+			if n <= m || maxInFlight.CompareAndSwap(m, n) {
+				break
+			}
+		}
+		time.Sleep(10 * time.Millisecond)
+
+		if r.URL.Path == "/products/3" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		fmt.Fprintf(w, `{"id":%q}`, strings.TrimPrefix(r.URL.Path, "/products/"))
+	})
+
+	type product struct {
+		ID string `json:"id"`
+	}
+	products := make([]product, 10)
+	requests := make([]BatchRequest, 10)
+	for i := range requests {
+		requests[i] = BatchRequest{Method: http.MethodGet, Path: fmt.Sprintf("/products/%d", i), Response: &products[i]}
+	}
+
+	results := Batch(context.Background(), client, requests, 3)
+
+	if len(results) != len(requests) {
+		t.Fatalf("Expected %d results, got %d", len(requests), len(results))
+	}
+	if got := maxInFlight.Load(); got > 3 {
+		t.Errorf("Expected at most 3 requests at once, got %d", got)
+	}
+	for i, r := range results {
+		if r.Request.Path != requests[i].Path {
+			t.Errorf("Expected result %d for %s, got %s", i, requests[i].Path, r.Request.Path)
+		}
+		if i == 3 {
+			if !errors.Is(r.Err, ErrResourceNotFound) {
+				t.Errorf("Expected ErrResourceNotFound for request 3, got %v", r.Err)
+			}
+			continue
+		}
+		if r.Err != nil {
+			t.Errorf("Expected no error for request %d, got %v", i, r.Err)
+		}
+		if products[i].ID != fmt.Sprint(i) {
+			t.Errorf("Expected product %d to be decoded, got %+v", i, products[i])
+		}
+	}
+
+	err := BatchError(results)
+	if !errors.Is(err, ErrResourceNotFound) || !strings.Contains(err.Error(), "GET /products/3") {
+		t.Errorf("Expected the joined error to name the failed request, got %v", err)
+	}
+	if BatchError(results[:3]) != nil {
+		t.Error("Expected no error when every request succeeded")
+	}
+}
+
+func TestBatch_FailFast(t *testing.T) {
+	var started atomic.Int32
+	client := newBatchTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		started.Add(1)
+		if r.URL.Path == "/fail" {
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+		select {
+		case <-r.Context().Done():
+		case <-time.After(2 * time.Second):
+		}
+		w.Write([]byte(`{}`))
+	})
+
+	requests := []BatchRequest{
+		{Method: http.MethodGet, Path: "/slow"},
+		{Method: http.MethodGet, Path: "/fail"},
+	}
+	for i := 0; i < 5; i++ {
+		requests = append(requests, BatchRequest{Method: http.MethodGet, Path: "/later"})
+	}
+
+	start := time.Now()
+	results := Batch(context.Background(), client, requests, 2, WithFailFast())
+	if elapsed := time.Since(start); elapsed > time.Second {
+		t.Errorf("Expected the batch to stop early, took %v", elapsed)
+	}
+
+	if !errors.Is(results[1].Err, ErrServerError) {
+		t.Errorf("Expected the failure to be reported, got %v", results[1].Err)
+	}
+	if !errors.Is(results[0].Err, ErrBatchCanceled) {
+		t.Errorf("Expected the in-flight request to be canceled, got %v", results[0].Err)
+	}
+	for i, r := range results[2:] {
+		if !errors.Is(r.Err, ErrBatchCanceled) {
+			t.Errorf("Expected request %d to be skipped, got %v", i+2, r.Err)
+		}
+	}
+	if n := started.Load(); n != 2 {
+		t.Errorf("Expected only 2 requests to reach the server, got %d", n)
+	}
+}
+
+func TestBatch_ParentCanceled(t *testing.T) {
+	client := newBatchTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{}`))
+	})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	results := Batch(ctx, client, []BatchRequest{{Method: http.MethodGet, Path: "/"}}, 0)
+	if !errors.Is(results[0].Err, context.Canceled) || errors.Is(results[0].Err, ErrBatchCanceled) {
+		t.Errorf("Expected context.Canceled, got %v", results[0].Err)
+	}
+}
@@ -0,0 +1,294 @@
+package rest
+
+import (
+	"bytes"
+	"container/list"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"io"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+)
+
+// ErrCacheMiss is returned by a CacheStore when a key is not cached
+var ErrCacheMiss = errors.New("cache miss")
+
+// maxCacheableBody is the largest response body the cache will store.
+// Larger responses are passed through uncached.
+const maxCacheableBody = 10 << 20
+
+// CachedResponse is a GET response held in a CacheStore
+type CachedResponse struct {
+	StatusCode int
+	Header     http.Header
+	Body       []byte
+	// ExpiresAt is when the response must be revalidated
+	ExpiresAt time.Time
+}
+
+// CacheStore stores cached responses. Implementations must be safe for
+// concurrent use. A shared store such as Redis can serialize CachedResponse
+// as JSON.
+type CacheStore interface {
+	// Get returns the response stored under key, or ErrCacheMiss
+	Get(ctx context.Context, key string) (*CachedResponse, error)
+	Set(ctx context.Context, key string, resp *CachedResponse) error
+	Delete(ctx context.Context, key string) error
+}
+
+// MemoryCache is an in-memory CacheStore that evicts the least recently
+// used entry once it holds maxEntries responses
+type MemoryCache struct {
+	mu         sync.Mutex
+	maxEntries int
+	ll         *list.List
+	items      map[string]*list.Element
+}
+
+type memoryCacheEntry struct {
+	key  string
+	resp *CachedResponse
+}
+
+// NewMemoryCache creates a MemoryCache. A maxEntries of 0 means no limit.
+func NewMemoryCache(maxEntries int) *MemoryCache {
+	return &MemoryCache{
+		maxEntries: maxEntries,
+		ll:         list.New(),
+		items:      make(map[string]*list.Element),
+	}
+}
+
+// Get implements CacheStore
+func (m *MemoryCache) Get(_ context.Context, key string) (*CachedResponse, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	el, ok := m.items[key]
+	if !ok {
+		return nil, ErrCacheMiss
+	}
+	m.ll.MoveToFront(el)
+	return el.Value.(*memoryCacheEntry).resp, nil
+}
+
+// Set implements CacheStore
+func (m *MemoryCache) Set(_ context.Context, key string, resp *CachedResponse) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if el, ok := m.items[key]; ok {
+		el.Value.(*memoryCacheEntry).resp = resp
+		m.ll.MoveToFront(el)
+		return nil
+	}
+
+	m.items[key] = m.ll.PushFront(&memoryCacheEntry{key: key, resp: resp})
+	if m.maxEntries > 0 && m.ll.Len() > m.maxEntries {
+		oldest := m.ll.Back()
+		m.ll.Remove(oldest)
+		delete(m.items, oldest.Value.(*memoryCacheEntry).key)
+	}
+	return nil
+}
+
+// Delete implements CacheStore
+func (m *MemoryCache) Delete(_ context.Context, key string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if el, ok := m.items[key]; ok {
+		m.ll.Remove(el)
+		delete(m.items, key)
+	}
+	return nil
+}
+
+// Len returns the number of cached responses
+func (m *MemoryCache) Len() int {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return m.ll.Len()
+}
+
+// responseCache is the transport layer behind WithCache
+type responseCache struct {
+	store CacheStore
+	ttl   time.Duration
+	now   func() time.Time
+}
+
+// wrap returns a RoundTripper serving GET requests from the cache
+func (rc *responseCache) wrap(next http.RoundTripper) http.RoundTripper {
+	if next == nil {
+		next = http.DefaultTransport
+	}
+	return RoundTripperFunc(func(req *http.Request) (*http.Response, error) {
+		// Leave requests that manage their own caching alone
+		if req.Method != http.MethodGet || req.Header.Get("If-None-Match") != "" ||
+			req.Header.Get("If-Modified-Since") != "" || hasDirective(req.Header, "no-store") {
+			return next.RoundTrip(req)
+		}
+		return rc.roundTrip(next, req)
+	})
+}
+
+func (rc *responseCache) roundTrip(next http.RoundTripper, req *http.Request) (*http.Response, error) {
+	ctx := req.Context()
+	key := cacheKey(req)
+
+	cached, err := rc.store.Get(ctx, key)
+	if err != nil {
+		cached = nil
+	}
+
+	if cached != nil {
+		if rc.now().Before(cached.ExpiresAt) && !hasDirective(req.Header, "no-cache") {
+			return cached.response(req), nil
+		}
+
+		// Stale: revalidate if the server gave us a validator
+		etag, lastModified := cached.Header.Get("ETag"), cached.Header.Get("Last-Modified")
+		if etag != "" || lastModified != "" {
+			req = req.Clone(ctx)
+			if etag != "" {
+				req.Header.Set("If-None-Match", etag)
+			}
+			if lastModified != "" {
+				req.Header.Set("If-Modified-Since", lastModified)
+			}
+		}
+	}
+
+	resp, err := next.RoundTrip(req)
+	if err != nil {
+		return nil, err
+	}
+
+	if resp.StatusCode == http.StatusNotModified && cached != nil {
+		resp.Body.Close()
+		// The store may hand the same entry to other requests, so update a copy
+		updated := *cached
+		updated.Header = cached.Header.Clone()
+		for k, v := range resp.Header {
+			if k == "Etag" || k == "Last-Modified" || k == "Cache-Control" || k == "Expires" || k == "Date" {
+				updated.Header[k] = v
+			}
+		}
+		updated.ExpiresAt = rc.expiry(resp.Header)
+		rc.store.Set(ctx, key, &updated)
+		return updated.response(req), nil
+	}
+
+	if resp.StatusCode != http.StatusOK || hasDirective(resp.Header, "no-store") || !keyCoversVary(resp.Header) {
+		return resp, nil
+	}
+
+	// Buffer the body; if it is too large to cache, hand back what was read
+	// followed by the rest of the stream
+	body, err := io.ReadAll(io.LimitReader(resp.Body, maxCacheableBody+1))
+	if err != nil {
+		resp.Body.Close()
+		return nil, err
+	}
+	if len(body) > maxCacheableBody {
+		resp.Body = struct {
+			io.Reader
+			io.Closer
+		}{io.MultiReader(bytes.NewReader(body), resp.Body), resp.Body}
+		return resp, nil
+	}
+	resp.Body.Close()
+	resp.Body = io.NopCloser(bytes.NewReader(body))
+
+	rc.store.Set(ctx, key, &CachedResponse{
+		StatusCode: resp.StatusCode,
+		Header:     resp.Header.Clone(),
+		Body:       body,
+		ExpiresAt:  rc.expiry(resp.Header),
+	})
+	return resp, nil
+}
+
+// expiry returns when a response with the given headers becomes stale
+func (rc *responseCache) expiry(header http.Header) time.Time {
+	if hasDirective(header, "no-cache") {
+		return rc.now()
+	}
+	return rc.now().Add(rc.ttl)
+}
+
+// response builds a fresh *http.Response from the cached copy
+func (cr *CachedResponse) response(req *http.Request) *http.Response {
+	return &http.Response{
+		Status:        http.StatusText(cr.StatusCode),
+		StatusCode:    cr.StatusCode,
+		Proto:         "HTTP/1.1",
+		ProtoMajor:    1,
+		ProtoMinor:    1,
+		Header:        cr.Header.Clone(),
+		Body:          io.NopCloser(bytes.NewReader(cr.Body)),
+		ContentLength: int64(len(cr.Body)),
+		Request:       req,
+	}
+}
+
+// keyHeaders are the request headers, besides Authorization, that cacheKey
+// includes
+var keyHeaders = []string{"Accept", "Accept-Encoding", "Accept-Language"}
+
+// cacheKey identifies a GET response by URL and the headers that select
+// its representation. Credentials are hashed so they never reach the store.
+func cacheKey(req *http.Request) string {
+	var b strings.Builder
+	b.WriteString(req.URL.String())
+	for _, name := range keyHeaders {
+		b.WriteString("\n" + name + ": ")
+		b.WriteString(req.Header.Get(name))
+	}
+	if auth := req.Header.Get("Authorization"); auth != "" {
+		sum := sha256.Sum256([]byte(auth))
+		b.WriteString("\nAuthorization: ")
+		b.WriteString(hex.EncodeToString(sum[:8]))
+	}
+	return b.String()
+}
+
+// keyCoversVary reports whether every request header named by the
+// response's Vary header is part of cacheKey. Responses that vary on other
+// headers, such as Cookie or an API key, could otherwise be served to a
+// different caller, so they are not cached.
+func keyCoversVary(header http.Header) bool {
+	for _, v := range header.Values("Vary") {
+		for _, name := range strings.Split(v, ",") {
+			name = http.CanonicalHeaderKey(strings.TrimSpace(name))
+			if name == "" || name == "Authorization" {
+				continue
+			}
+			covered := false
+			for _, k := range keyHeaders {
+				covered = covered || name == k
+			}
+			if !covered {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+// hasDirective reports whether the Cache-Control header contains directive
+func hasDirective(header http.Header, directive string) bool {
+	for _, v := range header.Values("Cache-Control") {
+		for _, d := range strings.Split(v, ",") {
+			if strings.EqualFold(strings.TrimSpace(d), directive) {
+				return true
+			}
+		}
+	}
+	return false
+}
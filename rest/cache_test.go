@@ -0,0 +1,247 @@
+package rest
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestMemoryCache_LRU(t *testing.T) {
+	ctx := context.Background()
+	cache := NewMemoryCache(2)
+
+	cache.Set(ctx, "a", &CachedResponse{Body: []byte("a")})
+	cache.Set(ctx, "b", &CachedResponse{Body: []byte("b")})
+	cache.Get(ctx, "a") // a is now most recently used
+	cache.Set(ctx, "c", &CachedResponse{Body: []byte("c")})
+
+	if _, err := cache.Get(ctx, "b"); !errors.Is(err, ErrCacheMiss) {
+		t.Errorf("Expected b to be evicted, got %v", err)
+	}
+	for _, key := range []string{"a", "c"} {
+		if _, err := cache.Get(ctx, key); err != nil {
+			t.Errorf("Expected %s to be cached, got %v", key, err)
+		}
+	}
+
+	cache.Delete(ctx, "a")
+	if cache.Len() != 1 {
+		t.Errorf("Expected 1 entry after delete, got %d", cache.Len())
+	}
+}
+
+// newCachingClient returns a client with a controllable clock
+func newCachingClient(t *testing.T, url string, ttl time.Duration) (*Client, *time.Time) {
+	t.Helper()
+	client, err := NewClient(WithBaseURL(url), WithCache(NewMemoryCache(10), ttl))
+	if err != nil {
+		t.Fatalf("NewClient() error = %v", err)
+	}
+	now := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	client.cache.now = func() time.Time { return now }
+	return client, &now
+}
+
+func TestClient_CacheFresh(t *testing.T) {
+	var hits atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		n := hits.Add(1)
+		fmt.Fprintf(w, `{"n":%d}`, n)
+	}))
+	defer server.Close()
+
+	client, now := newCachingClient(t, server.URL, time.Minute)
+	ctx := context.Background()
+
+	var first, second, third map[string]int
+	client.Get(ctx, "/products/1", &first)
+	client.Get(ctx, "/products/1", &second)
+	*now = now.Add(2 * time.Minute)
+	client.Get(ctx, "/products/1", &third)
+
+	if first["n"] != 1 || second["n"] != 1 {
+		t.Errorf("Expected second request to be served from cache, got %v and %v", first, second)
+	}
+	if third["n"] != 2 {
+		t.Errorf("Expected expired entry without validators to be refetched, got %v", third)
+	}
+}
+
+func TestClient_CacheRevalidate(t *testing.T) {
+	var hits, notModified atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		hits.Add(1)
+		w.Header().Set("ETag", `"v1"`)
+		if r.Header.Get("If-None-Match") == `"v1"` {
+			notModified.Add(1)
+			w.WriteHeader(http.StatusNotModified)
+			return
+		}
+		w.Write([]byte(`{"name":"Oak Tread"}`))
+	}))
+	defer server.Close()
+
+	client, _ := newCachingClient(t, server.URL, 0)
+	ctx := context.Background()
+
+	for i := 0; i < 3; i++ {
+		var product map[string]string
+		if err := client.Get(ctx, "/products/1", &product); err != nil {
+			t.Fatalf("Get() error = %v", err)
+		}
+		if product["name"] != "Oak Tread" {
+			t.Errorf("Expected cached body, got %v", product)
+		}
+	}
+
+	if hits.Load() != 3 || notModified.Load() != 2 {
+		t.Errorf("Expected 3 requests with 2 revalidations, got %d and %d", hits.Load(), notModified.Load())
+	}
+}
+
+func TestClient_CacheSkipped(t *testing.T) {
+	tests := []struct {
+		name    string
+		method  string
+		status  int
+		header  string
+		reqHdr  string
+		wantHit bool
+	}{
+		{"GET 200 cached", http.MethodGet, http.StatusOK, "", "", true},
+		{"POST not cached", http.MethodPost, http.StatusOK, "", "", false},
+		{"no-store response", http.MethodGet, http.StatusOK, "no-store", "", false},
+		{"no-cache request", http.MethodGet, http.StatusOK, "", "no-cache", false},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			var hits atomic.Int32
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				hits.Add(1)
+				if tc.header != "" {
+					w.Header().Set("Cache-Control", tc.header)
+				}
+				w.WriteHeader(tc.status)
+				w.Write([]byte(`{}`))
+			}))
+			defer server.Close()
+
+			client, _ := newCachingClient(t, server.URL, time.Minute)
+			if tc.reqHdr != "" {
+				client.Headers["Cache-Control"] = tc.reqHdr
+			}
+			client.Request(context.Background(), tc.method, "/", nil, nil)
+			client.Request(context.Background(), tc.method, "/", nil, nil)
+
+			want := int32(2)
+			if tc.wantHit {
+				want = 1
+			}
+			if hits.Load() != want {
+				t.Errorf("Expected %d server hits, got %d", want, hits.Load())
+			}
+		})
+	}
+}
+
+func TestClient_CacheVary(t *testing.T) {
+	tests := []struct {
+		vary    string
+		wantHit bool
+	}{
+		{"", true},
+		{"Accept, Accept-Encoding", true},
+		{"authorization", true},
+		{"X-Api-Key", false},
+		{"Accept, Cookie", false},
+		{"*", false},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.vary, func(t *testing.T) {
+			var hits atomic.Int32
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				hits.Add(1)
+				if tc.vary != "" {
+					w.Header().Set("Vary", tc.vary)
+				}
+				w.Write([]byte(`{}`))
+			}))
+			defer server.Close()
+
+			client, _ := newCachingClient(t, server.URL, time.Minute)
+			client.Get(context.Background(), "/", nil)
+			client.Get(context.Background(), "/", nil)
+
+			want := int32(2)
+			if tc.wantHit {
+				want = 1
+			}
+			if hits.Load() != want {
+				t.Errorf("Expected %d server hits, got %d", want, hits.Load())
+			}
+		})
+	}
+}
+
+func TestClient_CacheConcurrentRevalidation(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("ETag", `"v1"`)
+		if r.Header.Get("If-None-Match") == `"v1"` {
+			w.WriteHeader(http.StatusNotModified)
+			return
+		}
+		w.Write([]byte(`{"name":"Oak Tread"}`))
+	}))
+	defer server.Close()
+
+	// With no TTL every request revalidates the shared entry
+	client, _ := newCachingClient(t, server.URL, 0)
+	ctx := context.Background()
+	client.Get(ctx, "/products/1", nil)
+
+	var wg sync.WaitGroup
+	for i := 0; i < 8; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for j := 0; j < 10; j++ {
+				var product map[string]string
+				if err := client.Get(ctx, "/products/1", &product); err != nil || product["name"] != "Oak Tread" {
+					t.Errorf("Expected the cached body, got %v (err %v)", product, err)
+					return
+				}
+			}
+		}()
+	}
+	wg.Wait()
+}
+
+func TestCacheKey(t *testing.T) {
+	newReq := func(auth string) *http.Request {
+		req := httptest.NewRequest(http.MethodGet, "https://api.example.com/orders", nil)
+		req.Header.Set("Accept", "application/json")
+		if auth != "" {
+			req.Header.Set("Authorization", auth)
+		}
+		return req
+	}
+
+	if cacheKey(newReq("Bearer a")) == cacheKey(newReq("Bearer b")) {
+		t.Error("Expected different credentials to produce different keys")
+	}
+	if cacheKey(newReq("Bearer a")) != cacheKey(newReq("Bearer a")) {
+		t.Error("Expected identical requests to produce the same key")
+	}
+	if key := cacheKey(newReq("Bearer secret")); strings.Contains(key, "secret") {
+		t.Errorf("Expected credentials to be hashed, got %q", key)
+	}
+}
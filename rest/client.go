@@ -22,6 +22,54 @@ type Client struct {
 	Timeout     time.Duration
 	Logger      *logger.Logger
 	ServiceName string
+	middleware  []Middleware
+	cache       *responseCache
+	transport   TransportOptions
+	Auth        AuthProvider
+
+	// Metrics, if set, is notified of each request's start, retries and result
+	Metrics MetricsCollector
+
+	// EnvelopeMode unwraps the data member of api.SuccessResponse bodies and
+	// reads api error envelopes, for calling other go-core services
+	EnvelopeMode bool
+
+	// RetryPolicy overrides the default retry behavior. When nil,
+	// DefaultRetryPolicy is used with Retries retries.
+	RetryPolicy *RetryPolicy
+
+	// RequestInterceptors run in order on every outgoing request, after
+	// headers and authentication have been applied
+	RequestInterceptors []RequestInterceptor
+	// ResponseInterceptors run in order on every response before its
+	// status is checked
+	ResponseInterceptors []ResponseInterceptor
+
+	// propagateHeaders lists inbound headers copied onto each request
+	propagateHeaders []string
+
+	// progress, if set, is called as request and response bodies are transferred
+	progress ProgressFunc
+
+	// rateLimiter, if set, spaces out requests to stay under a rate limit
+	rateLimiter *rateLimiter
+
+	// stats counts requests, retries and failures for Stats
+	stats *clientStats
+
+	// fallbackBaseURLs are tried in order when the base URL is down
+	fallbackBaseURLs []string
+
+	// hedgeDelay, if positive, is how long a read waits before it is also
+	// sent to the next base URL
+	hedgeDelay time.Duration
+
+	// jar, if set, stores cookies from responses and sends them on later
+	// requests
+	jar http.CookieJar
+
+	// breaker, if set, stops requests to a host that keeps failing
+	breaker *circuitBreaker
 }
 
 // NewClient creates a new rest client with the provided options
@@ -31,15 +79,18 @@ func NewClient(opts ...ClientOption) (*Client, error) {
 		HTTPClient: &http.Client{
 			Timeout: 30 * time.Second,
 		},
-		Headers: make(map[string]string),
-		Retries: 3,
-		Timeout: 30 * time.Second,
+		Headers:   make(map[string]string),
+		Retries:   3,
+		Timeout:   30 * time.Second,
+		transport: DefaultTransportOptions(),
+		stats:     newClientStats(),
 	}
 
 	// Apply options
 	for _, opt := range opts {
 		opt(c)
 	}
+	c.stats.breaker = c.breaker
 
 	// Create default logger if not provided
 	if c.Logger == nil {
@@ -59,123 +110,385 @@ func NewClient(opts ...ClientOption) (*Client, error) {
 		c.Logger = defaultLogger
 	}
 
+	// Give clients without a transport of their own a tuned connection pool
+	if c.HTTPClient.Transport == nil {
+		httpClient := *c.HTTPClient
+		httpClient.Transport = NewTransport(c.transport)
+		c.HTTPClient = &httpClient
+	}
+
 	// Configure client timeout
 	c.HTTPClient.Timeout = c.Timeout
 
+	// Hold cookies without changing a client passed to WithHTTPClient
+	if c.jar != nil {
+		httpClient := *c.HTTPClient
+		httpClient.Jar = c.jar
+		c.HTTPClient = &httpClient
+	}
+
+	// Wrap the transport with any middleware, first registered outermost,
+	// and the response cache outside that so cache hits skip the network
+	if len(c.middleware) > 0 || c.cache != nil {
+		httpClient := *c.HTTPClient
+		httpClient.Transport = chainMiddleware(httpClient.Transport, c.middleware)
+		if c.cache != nil {
+			httpClient.Transport = c.cache.wrap(httpClient.Transport)
+		}
+		c.HTTPClient = &httpClient
+	}
+
 	return c, nil
 }
 
 // Request performs an HTTP request and returns the response
 func (c *Client) Request(ctx context.Context, method, path string, body interface{}, response interface{}) error {
-	url := path
-	if c.BaseURL != "" {
-		url = c.BaseURL + path
+	resp, err := c.do(ctx, method, path, body, "application/json")
+	if err != nil {
+		return err
+	}
+	return c.decodeResponse(resp, response)
+}
+
+// decodeResponse reads a successful response and unmarshals it into response
+func (c *Client) decodeResponse(resp *http.Response, response interface{}) error {
+	defer resp.Body.Close()
+
+	// Read the response body
+	respBody, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return &ClientError{
+			Err:     ErrConnectionFailed,
+			Message: fmt.Sprintf("failed to read response body: %s", err),
+		}
+	}
+
+	// If no response is expected, return nil
+	if response == nil {
+		return nil
+	}
+
+	if c.EnvelopeMode {
+		data, err := unwrapEnvelope(resp.StatusCode, respBody)
+		if err != nil {
+			return err
+		}
+		respBody = data
+	}
+
+	// Parse the response
+	if err := json.Unmarshal(respBody, response); err != nil {
+		return &ClientError{
+			Err:     ErrInvalidRequest,
+			Message: fmt.Sprintf("failed to parse response: %s", err),
+		}
+	}
+
+	return nil
+}
+
+// payload is a request body that is opened afresh for each attempt
+type payload struct {
+	contentType string
+	open        func() (io.Reader, error)
+	// once marks bodies that are streamed and cannot be sent again
+	once bool
+}
+
+// jsonPayload marshals body, returning nil for a nil body
+func jsonPayload(body interface{}) (*payload, error) {
+	if body == nil {
+		return nil, nil
+	}
+	bodyBytes, err := json.Marshal(body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal request body: %w", err)
 	}
+	return &payload{
+		contentType: "application/json",
+		open: func() (io.Reader, error) {
+			return bytes.NewReader(bodyBytes), nil
+		},
+	}, nil
+}
+
+// do builds and sends a request with a JSON body. Non-2xx responses are
+// consumed and returned as a *ClientError; on success the caller owns resp.Body.
+func (c *Client) do(ctx context.Context, method, path string, body interface{}, accept string) (*http.Response, error) {
+	p, err := jsonPayload(body)
+	if err != nil {
+		return nil, err
+	}
+	return c.doPayload(ctx, method, path, p, accept)
+}
+
+// doPayload is like do for an already encoded body
+func (c *Client) doPayload(ctx context.Context, method, path string, body *payload, accept string) (*http.Response, error) {
+	resp, err := c.sendFailover(ctx, method, path, body, accept)
+	if err != nil {
+		return nil, err
+	}
+
+	// A rejected credential may just be stale; refresh it and try once more
+	if resp.StatusCode == http.StatusUnauthorized && (body == nil || !body.once) {
+		if inv, ok := c.Auth.(AuthInvalidator); ok {
+			resp.Body.Close()
+			inv.Invalidate()
+			if resp, err = c.sendFailover(ctx, method, path, body, accept); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	// Check for non-2xx responses
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		defer resp.Body.Close()
+		return nil, newRateLimitError(resp, newStatusError(resp, c.EnvelopeMode))
+	}
+
+	return resp, nil
+}
 
+// newRequest creates a request with the default, custom and auth headers applied
+func (c *Client) newRequest(ctx context.Context, method, url string, body *payload, accept string) (*http.Request, error) {
 	var bodyReader io.Reader
+	contentType := "application/json"
 	if body != nil {
-		bodyBytes, err := json.Marshal(body)
-		if err != nil {
-			return fmt.Errorf("failed to marshal request body: %w", err)
+		var err error
+		if bodyReader, err = body.open(); err != nil {
+			return nil, fmt.Errorf("failed to open request body: %w", err)
 		}
-		bodyReader = bytes.NewReader(bodyBytes)
+		contentType = body.contentType
 	}
 
 	req, err := http.NewRequestWithContext(ctx, method, url, bodyReader)
 	if err != nil {
-		return fmt.Errorf("failed to create request: %w", err)
+		if rc, ok := bodyReader.(io.Closer); ok {
+			rc.Close()
+		}
+		return nil, fmt.Errorf("failed to create request: %w", err)
 	}
 
+	// Release a streamed body if the request is never sent
+	built := false
+	defer func() {
+		if !built && req.Body != nil {
+			req.Body.Close()
+		}
+	}()
+
 	// Set default headers
-	req.Header.Set("Content-Type", "application/json")
-	req.Header.Set("Accept", "application/json")
+	req.Header.Set("Content-Type", contentType)
+	req.Header.Set("Accept", accept)
 
 	// Set custom headers
 	for k, v := range c.Headers {
 		req.Header.Set(k, v)
 	}
 
-	// Perform the request with retries
+	if len(c.propagateHeaders) > 0 {
+		propagateHeaders(ctx, req, c.propagateHeaders)
+	}
+
+	if c.Auth != nil {
+		if err := c.Auth.Authenticate(ctx, req); err != nil {
+			if errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded) {
+				return nil, err
+			}
+			return nil, &ClientError{
+				Err:     ErrUnauthorized,
+				Message: fmt.Sprintf("failed to authenticate request: %s", err),
+			}
+		}
+	}
+
+	for _, intercept := range c.RequestInterceptors {
+		if err := intercept(req); err != nil {
+			return nil, fmt.Errorf("request interceptor: %w", err)
+		}
+	}
+
+	trackUpload(req, c.progressFor(ctx).upload)
+
+	built = true
+	return req, nil
+}
+
+// send performs a single logical request, retrying according to the
+// client's RetryPolicy, and reports it to the client's Stats and
+// MetricsCollector
+func (c *Client) send(ctx context.Context, method, url string, body *payload, accept string) (*http.Response, error) {
+	info := newRequestInfo(ctx, method, url)
+	allowed, probe := c.stats.allow(info.Host)
+	if !allowed {
+		return nil, &ClientError{
+			Err:     ErrCircuitOpen,
+			Message: info.Host,
+		}
+	}
+	c.stats.started(info.Host)
+	if c.Metrics != nil {
+		c.Metrics.RequestStarted(info)
+	}
+	start := time.Now()
+
+	resp, err := c.sendAttempts(ctx, method, url, body, accept, info)
+
+	status := 0
+	if resp != nil {
+		status = resp.StatusCode
+	}
+	c.stats.finished(info.Host, status, err, probe)
+	if c.Metrics != nil {
+		c.Metrics.RequestFinished(info, status, time.Since(start), err)
+	}
+
+	return resp, err
+}
+
+// sendAttempts makes each attempt of a request until one succeeds or the
+// RetryPolicy gives up
+func (c *Client) sendAttempts(ctx context.Context, method, url string, body *payload, accept string, info RequestInfo) (*http.Response, error) {
+	policy := c.retryPolicy()
+	if body != nil && body.once {
+		policy.MaxAttempts = 1
+	}
+
 	var resp *http.Response
+	var err error
 
-	for attempt := 0; attempt <= c.Retries; attempt++ {
+	for attempt := 0; ; attempt++ {
+		if c.rateLimiter != nil {
+			waitStart := time.Now()
+			err := c.rateLimiter.wait(ctx)
+			c.stats.rateLimited(time.Since(waitStart))
+			if err != nil {
+				return nil, err
+			}
+		}
+
+		// Build a fresh request each attempt so the body is readable again
+		req, reqErr := c.newRequest(ctx, method, url, body, accept)
+		if reqErr != nil {
+			return nil, reqErr
+		}
+
+		c.stats.attempted(info.Host, attempt > 0)
 		resp, err = c.HTTPClient.Do(req)
+
+		// Check if the error is due to context cancellation
+		if err != nil && (errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded)) {
+			return nil, err
+		}
+
+		var limits rateLimitInfo
 		if err == nil {
-			break
+			limits = parseRateLimit(resp.Header, time.Now())
+			// Hold back the client's other requests until the server is ready
+			if resp.StatusCode == http.StatusTooManyRequests && limits.hasWait && c.rateLimiter != nil {
+				c.rateLimiter.pause(limits.retryAfter)
+			}
 		}
 
-		// Check if the error is due to context cancellation
-		if errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded) {
-			return err
+		if attempt+1 >= policy.MaxAttempts || !policy.canRetry(req) {
+			break
 		}
 
-		if attempt < c.Retries {
-			// Exponential backoff
-			backoffTime := time.Duration(attempt+1) * 100 * time.Millisecond
-			select {
-			case <-ctx.Done():
-				return ctx.Err()
-			case <-time.After(backoffTime):
-				// Continue with retry
+		wait := policy.backoff(attempt)
+		status := 0
+		if err == nil {
+			status = resp.StatusCode
+			if !policy.retryableStatus(resp.StatusCode) {
+				break
+			}
+			if limits.hasWait {
+				if policy.MaxRetryAfter > 0 && limits.retryAfter > policy.MaxRetryAfter {
+					break
+				}
+				wait = limits.retryAfter
 			}
+			// Drain a little of the body so the connection can be reused
+			io.CopyN(io.Discard, resp.Body, 4<<10)
+			resp.Body.Close()
+		}
+
+		if c.Metrics != nil {
+			c.Metrics.RequestRetried(info, attempt+1, status, err)
+		}
+
+		waitStart := time.Now()
+		select {
+		case <-ctx.Done():
+			c.stats.waited(info.Host, time.Since(waitStart))
+			return nil, ctx.Err()
+		case <-time.After(wait):
+			// Continue with retry
+			c.stats.waited(info.Host, time.Since(waitStart))
 		}
 	}
 
 	if err != nil {
-		return &ClientError{
+		return nil, &ClientError{
 			Err:     ErrConnectionFailed,
 			Message: err.Error(),
 		}
 	}
-	defer resp.Body.Close()
 
-	// Read the response body
-	respBody, err := io.ReadAll(resp.Body)
-	if err != nil {
-		return &ClientError{
-			Err:     ErrConnectionFailed,
-			Message: fmt.Sprintf("failed to read response body: %s", err),
+	for _, intercept := range c.ResponseInterceptors {
+		if err := intercept(resp); err != nil {
+			resp.Body.Close()
+			return nil, fmt.Errorf("response interceptor: %w", err)
 		}
 	}
 
-	// Check for non-2xx responses
-	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
-		// Try to parse as error response
-		var errResp struct {
-			Message string `json:"message,omitempty"`
-			Code    string `json:"code,omitempty"`
-		}
+	trackDownload(resp, c.progressFor(ctx).download)
 
-		if jsonErr := json.Unmarshal(respBody, &errResp); jsonErr == nil && errResp.Message != "" {
-			return &ClientError{
-				Err:     getErrorByStatusCode(resp.StatusCode),
-				Message: errResp.Message,
-				Code:    errResp.Code,
-			}
-		}
+	return resp, nil
+}
 
-		// Fall back to generic error
+// newStatusError reads a non-2xx response body and converts it to a *ClientError.
+// With envelope set, an api error envelope supplies the message and code.
+func newStatusError(resp *http.Response, envelope bool) error {
+	respBody, err := io.ReadAll(resp.Body)
+	if err != nil {
 		return &ClientError{
-			Err:     getErrorByStatusCode(resp.StatusCode),
-			Message: string(respBody),
-			Code:    fmt.Sprintf("%d", resp.StatusCode),
+			Err:        ErrConnectionFailed,
+			Message:    fmt.Sprintf("failed to read response body: %s", err),
+			StatusCode: resp.StatusCode,
 		}
 	}
 
-	// If no response is expected, return nil
-	if response == nil {
-		return nil
+	if envelope {
+		if clientErr, ok := envelopeError(resp.StatusCode, respBody); ok {
+			clientErr.Body = respBody
+			return clientErr
+		}
 	}
 
-	// Parse the response
-	if err := json.Unmarshal(respBody, response); err != nil {
+	// Try to parse as error response
+	var errResp struct {
+		Message string `json:"message,omitempty"`
+		Code    string `json:"code,omitempty"`
+	}
+
+	if jsonErr := json.Unmarshal(respBody, &errResp); jsonErr == nil && errResp.Message != "" {
 		return &ClientError{
-			Err:     ErrInvalidRequest,
-			Message: fmt.Sprintf("failed to parse response: %s", err),
+			Err:        getErrorByStatusCode(resp.StatusCode),
+			Message:    errResp.Message,
+			Code:       errResp.Code,
+			StatusCode: resp.StatusCode,
+			Body:       respBody,
 		}
 	}
 
-	return nil
+	// Fall back to generic error
+	return &ClientError{
+		Err:        getErrorByStatusCode(resp.StatusCode),
+		Message:    string(respBody),
+		Code:       fmt.Sprintf("%d", resp.StatusCode),
+		StatusCode: resp.StatusCode,
+		Body:       respBody,
+	}
 }
 
 // Get makes a GET request
@@ -0,0 +1,91 @@
+package rest
+
+import (
+	"context"
+	"net/http"
+	"net/http/cookiejar"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+)
+
+// sessionServer sets a session cookie on POST /login and requires it on
+// GET /me
+func sessionServer(t *testing.T) *httptest.Server {
+	t.Helper()
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/login":
+			http.SetCookie(w, &http.Cookie{Name: "sid", Value: "abc123", Path: "/"})
+			w.WriteHeader(http.StatusNoContent)
+		case "/me":
+			if c, err := r.Cookie("sid"); err != nil || c.Value != "abc123" {
+				w.WriteHeader(http.StatusUnauthorized)
+				return
+			}
+			w.Write([]byte(`{"user":"partner"}`))
+		}
+	}))
+	t.Cleanup(server.Close)
+	return server
+}
+
+func TestWithSessionCookies(t *testing.T) {
+	server := sessionServer(t)
+	ctx := context.Background()
+
+	client, _ := NewClient(WithBaseURL(server.URL), WithSessionCookies(), WithRetries(0))
+
+	if err := client.Get(ctx, "/me", nil); err == nil {
+		t.Fatal("Expected request before login to fail")
+	}
+	if err := client.Post(ctx, "/login", map[string]string{"user": "partner"}, nil); err != nil {
+		t.Fatalf("Login failed: %v", err)
+	}
+
+	var me struct{ User string }
+	if err := client.Get(ctx, "/me", &me); err != nil {
+		t.Fatalf("Expected session cookie to be sent, got %v", err)
+	}
+	if me.User != "partner" {
+		t.Errorf("Expected user partner, got %q", me.User)
+	}
+
+	// Each client has its own jar
+	other, _ := NewClient(WithBaseURL(server.URL), WithSessionCookies(), WithRetries(0))
+	if err := other.Get(ctx, "/me", nil); err == nil {
+		t.Error("Expected cookies not to be shared between clients")
+	}
+}
+
+func TestWithCookieJar(t *testing.T) {
+	server := sessionServer(t)
+	ctx := context.Background()
+
+	jar, _ := cookiejar.New(nil)
+	httpClient := &http.Client{}
+	client, _ := NewClient(
+		WithBaseURL(server.URL),
+		WithHTTPClient(httpClient),
+		WithCookieJar(jar),
+		WithRetries(0),
+	)
+
+	if err := client.Post(ctx, "/login", nil, nil); err != nil {
+		t.Fatalf("Login failed: %v", err)
+	}
+
+	u, _ := url.Parse(server.URL)
+	if cookies := jar.Cookies(u); len(cookies) != 1 || cookies[0].Value != "abc123" {
+		t.Errorf("Expected session cookie in the jar, got %v", cookies)
+	}
+	if httpClient.Jar != nil {
+		t.Error("Expected the client passed to WithHTTPClient to be left unchanged")
+	}
+
+	// A client sharing the jar shares the session
+	shared, _ := NewClient(WithBaseURL(server.URL), WithCookieJar(jar), WithRetries(0))
+	if err := shared.Get(ctx, "/me", nil); err != nil {
+		t.Errorf("Expected shared jar to carry the session, got %v", err)
+	}
+}
@@ -8,12 +8,31 @@ It's designed to make API integration more reliable and consistent.
 # Features
 
   - Fluent interface for HTTP methods (GET, POST, PUT, PATCH, DELETE)
+  - Generic helpers that return typed responses and decode typed error bodies
   - Automatic JSON request/response serialization
+  - Query parameter encoding for slices, pointers and times
   - Configurable with functional options pattern
-  - Automatic retry with exponential backoff
+  - Automatic retry with jittered exponential backoff and Retry-After support
+  - Failover to fallback base URLs and hedged reads for multi-region deployments
+  - Typed 429 errors from rate limit headers and an optional client-side rate limiter
   - Standardized error handling with typed errors
   - Integration with the go-core/logger package
   - Context support for cancellation and timeouts
+  - Pluggable authentication (bearer tokens, rotating API keys, OAuth2 client credentials)
+  - Cookie jars for APIs with cookie-based sessions
+  - HMAC request signing with a matching verification middleware
+  - Request/response interceptors and transport middleware
+  - Streaming responses for large downloads and NDJSON feeds
+  - Opt-in GET response caching with ETag/Last-Modified revalidation
+  - Streaming multipart/form-data file uploads
+  - Upload and download progress callbacks
+  - Bounded-concurrency batch requests with fail-fast or collect-all errors
+  - Latency, retry and status metrics with a built-in Prometheus collector
+  - Per-host retry, backoff and failure counters through Stats, expvar or Prometheus
+  - Per-host circuit breaker that fails fast while a dependency is down
+  - A connection pool tuned for service-to-service traffic
+  - Envelope mode for calling services that respond with the api package envelopes
+  - Request ID and W3C trace context propagation from inbound requests
 
 # Basic Usage
 
@@ -43,6 +62,42 @@ Creating a client and making requests:
 		log.Fatal(err)
 	}
 
+# Typed Requests
+
+The generic functions Get, Post, Put, Patch, Delete and Do return the
+decoded response instead of filling a pointer:
+
+	user, err := rest.Get[User](ctx, client, "/users/123")
+
+	created, err := rest.Post[NewUser, User](ctx, client, "/users", newUser)
+
+They honor every client option, including envelope mode. When a service
+returns structured errors, ErrorBody decodes the error response into a type
+of your choosing:
+
+	type QuotaError struct {
+		Message string `json:"message"`
+		Limit   int    `json:"limit"`
+	}
+	if quota, ok := rest.ErrorBody[QuotaError](err); ok {
+		log.Printf("over quota: limit %d", quota.Limit)
+	}
+
+# Query Parameters
+
+AppendQuery adds escaped query parameters to a path, keeping any already in
+it. Slices repeat the key, and nil pointers are left out so optional filters
+need no special casing:
+
+	var status *string // set only when the caller filters by status
+	path := rest.AppendQuery("/users", rest.Query{
+		"status": status,
+		"id":     []int{3, 7},
+		"since":  since, // time.Time, encoded as RFC 3339
+		"q":      "O'Brien & Sons",
+	})
+	err := client.Get(ctx, path, &users) // /users?id=3&id=7&q=O%27Brien+%26+Sons&since=...
+
 # Client Configuration
 
 The client can be configured with various options:
@@ -54,6 +109,306 @@ The client can be configured with various options:
 		rest.WithServiceName("user-service"),
 	)
 
+# Retries
+
+Transport errors and 429, 502, 503 and 504 responses are retried with
+jittered exponential backoff. A Retry-After header on the response replaces
+the computed wait, as does X-RateLimit-Reset when X-RateLimit-Remaining is 0. Only idempotent methods are retried by default; POST and
+PATCH requests are retried when they carry an Idempotency-Key header or the
+policy sets RetryNonIdempotent.
+
+	policy := rest.DefaultRetryPolicy()
+	policy.MaxAttempts = 5
+	policy.MaxBackoff = 10 * time.Second
+
+	client, err := rest.NewClient(
+		rest.WithBaseURL("https://api.example.com"),
+		rest.WithRetryPolicy(policy),
+	)
+
+Use rest.WithRetries(0) to disable retries.
+
+# Fallbacks and Hedging
+
+WithFallbackBaseURLs lists other endpoints for the same API, such as a
+secondary region. When an idempotent request to the base URL gets no
+response, or a 5xx or 429 response, after its retries, it is sent to each
+fallback in turn. Other responses, including 4xx errors, are returned as
+they are, and POST and PATCH requests are never sent to a fallback:
+
+	client, err := rest.NewClient(
+		rest.WithBaseURL("https://inventory.us-east.internal"),
+		rest.WithFallbackBaseURLs("https://inventory.us-west.internal"),
+		rest.WithHedging(200*time.Millisecond),
+	)
+
+WithHedging cuts the tail latency of reads. A GET, HEAD or OPTIONS request
+that has not been answered within the delay is also sent to the next
+fallback, or again to the base URL when there are none, and the first
+response that is not a failure wins; the other copies are canceled. A copy
+that fails starts the next one immediately. Pick a delay near the 95th
+percentile latency, since every hedge is an extra request.
+
+# Rate Limits
+
+A 429 response that is not retried, or still fails after its retries, is
+returned as a *RateLimitError with the X-RateLimit-Limit, -Remaining and
+-Reset headers and the wait the server asked for. It wraps the *ClientError,
+so errors.Is(err, rest.ErrInvalidRequest) still matches:
+
+	var rateErr *rest.RateLimitError
+	if errors.As(err, &rateErr) {
+		requeue(job, rateErr.RetryAfter)
+	}
+
+WithRateLimit keeps a client under a partner API's limit by spacing out its
+requests with a token bucket. After a 429 the client holds back all its
+requests until the server's Retry-After or reset time has passed:
+
+	client, err := rest.NewClient(
+		rest.WithBaseURL("https://api.partner.com"),
+		rest.WithRateLimit(5, 10), // 5 requests per second, bursts of 10
+	)
+
+# Authentication
+
+An AuthProvider is consulted before every request. Built-in providers cover
+static bearer tokens, API keys that can be rotated at runtime, and OAuth2
+client credentials:
+
+	auth, err := rest.NewClientCredentials(rest.ClientCredentialsConfig{
+		TokenURL:     "https://auth.example.com/oauth/token",
+		ClientID:     "orders-service",
+		ClientSecret: secret,
+		Scopes:       []string{"orders:read"},
+	})
+
+	client, err := rest.NewClient(rest.WithAuthProvider(auth))
+
+Providers that cache credentials can implement AuthInvalidator; when the
+server answers 401 the client invalidates the credential and retries once.
+Custom schemes can be written with AuthProviderFunc.
+
+APIs that authenticate with a session cookie after a login request need
+the client to keep cookies. WithSessionCookies gives the client its own
+in-memory jar, and WithCookieJar uses any http.CookieJar, for example one
+shared between clients:
+
+	client, err := rest.NewClient(
+		rest.WithBaseURL("https://legacy-partner.example.com"),
+		rest.WithSessionCookies(),
+	)
+
+	err = client.Post(ctx, "/login", credentials, nil)
+	err = client.Get(ctx, "/orders", &orders) // sends the session cookie
+
+# Request Signing
+
+WithHMACSigner signs every request with a shared secret. The signature covers
+the method, path and query, a timestamp and a SHA-256 of the body, and is
+sent in the X-Signature-Key-Id, X-Signature-Timestamp, X-Content-Sha256 and
+X-Signature headers:
+
+	client, err := rest.NewClient(
+		rest.WithBaseURL("https://gateway.internal"),
+		rest.WithHMACSigner("orders", secret, rest.HMACSHA256),
+	)
+
+The receiving service checks signatures with an HMACVerifier, usually as
+router middleware:
+
+	verifier := rest.NewHMACVerifier(map[string][]byte{"orders": secret})
+	r.With(verifier.Middleware).Post("/internal/orders", createOrder)
+
+Requests with a missing, invalid or expired signature are rejected with 401.
+Handlers can read the signing key ID with HMACKeyID. Streamed bodies, such as
+multipart uploads, are sent as UNSIGNED-PAYLOAD and are only accepted when
+AllowUnsignedPayload is set.
+
+# Interceptors and Middleware
+
+Request interceptors run on every outgoing request after headers and
+authentication are applied; response interceptors run before the status is
+checked. Either can abort the call by returning an error:
+
+	client, err := rest.NewClient(
+		rest.WithRequestInterceptor(func(req *http.Request) error {
+			req.Header.Set("X-Request-ID", ctxutils.RequestID(req.Context()))
+			return nil
+		}),
+		rest.WithResponseInterceptor(func(resp *http.Response) error {
+			metrics.Observe(resp.StatusCode)
+			return nil
+		}),
+	)
+
+For concerns that wrap the whole round trip, such as timing, use
+WithMiddleware. The first middleware registered is the outermost:
+
+	timing := func(next http.RoundTripper) http.RoundTripper {
+		return rest.RoundTripperFunc(func(req *http.Request) (*http.Response, error) {
+			start := time.Now()
+			resp, err := next.RoundTrip(req)
+			log.Infow("upstream call", "path", req.URL.Path, "duration", time.Since(start))
+			return resp, err
+		})
+	}
+
+	client, err := rest.NewClient(rest.WithMiddleware(timing))
+
+# Header Propagation
+
+A client created with WithPropagateHeaders forwards the X-Request-Id,
+traceparent and tracestate headers of the inbound request, so a call made
+from a handler can be correlated with the request that caused it:
+
+	client, err := rest.NewClient(
+		rest.WithBaseURL("https://inventory.internal"),
+		rest.WithPropagateHeaders(),
+	)
+
+	func getOrder(w http.ResponseWriter, r *http.Request) {
+		// Carries the inbound request ID and trace context
+		err := client.Get(r.Context(), "/stock/"+sku, &stock)
+	}
+
+A router with Options.PropagateHeaders set stores these headers in the
+request context; see
+ctxutils.WithPropagatedHeaders to set them elsewhere. Without stored headers
+the request ID comes from ctxutils.RequestID and traceparent from the span
+in logger.SpanFromContext. Pass names to forward other headers instead, and
+set a header with WithHeader to keep it from being replaced.
+
+# File Uploads
+
+PostMultipart sends form fields and files as multipart/form-data. Files are
+streamed as they are read, so large uploads are never held in memory:
+
+	f, err := os.Open("drawing.pdf")
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	var upload UploadResult
+	err = client.PostMultipart(ctx, "/orders/42/attachments",
+		map[string]string{"description": "Stair drawing"},
+		[]rest.File{{FieldName: "file", FileName: "drawing.pdf", ContentType: "application/pdf", Reader: f}},
+		&upload,
+	)
+
+Because the body can only be read once, multipart uploads are not retried.
+
+# Transfer Progress
+
+WithProgress reports the bytes sent and received for every request body and
+response body, with the total size or -1 when it is unknown:
+
+	client, err := rest.NewClient(
+	    rest.WithBaseURL("https://warehouse.example.com"),
+	    rest.WithProgress(func(n, total int64) {
+	        log.Debugf("Transferred %d of %d bytes", n, total)
+	    }),
+	)
+
+WithProgressContext sets separate upload and download callbacks for the
+requests made with a context, overriding the client's:
+
+	ctx = rest.WithProgressContext(ctx, func(n, total int64) {
+	    bar.Set(n)
+	}, nil)
+	err = client.PostMultipart(ctx, "/inventory/import", nil, files, &result)
+
+Downloads are reported as the response body is read, so for Stream the
+callback runs as the caller reads Body.
+
+# Batch Requests
+
+Batch makes many requests in parallel, at most concurrency at a time, and
+returns one result per request in the same order:
+
+	var products [3]Product
+	results := rest.Batch(ctx, client, []rest.BatchRequest{
+		{Method: http.MethodGet, Path: "/products/1", Response: &products[0]},
+		{Method: http.MethodGet, Path: "/products/2", Response: &products[1]},
+		{Method: http.MethodGet, Path: "/products/3", Response: &products[2]},
+	}, 4)
+	if err := rest.BatchError(results); err != nil {
+		return err
+	}
+
+By default every request runs and its error is kept in its result.
+WithFailFast cancels the rest of the batch after the first failure; the
+requests canceled or skipped report ErrBatchCanceled.
+
+# Response Caching
+
+WithCache caches successful GET responses for a TTL. Once an entry is stale,
+the client revalidates it with If-None-Match or If-Modified-Since and reuses
+the cached body on 304 Not Modified:
+
+	client, err := rest.NewClient(
+		rest.WithBaseURL("https://catalog.example.com"),
+		rest.WithCache(rest.NewMemoryCache(1000), 5*time.Minute),
+	)
+
+Responses marked Cache-Control: no-store are never cached, and a request
+sending Cache-Control: no-cache always goes to the server. Entries are keyed
+by URL, Accept headers and a hash of the Authorization header, so responses
+whose Vary header names any other request header, such as Cookie or an API
+key, are not cached. Implement CacheStore to share a cache between
+instances, e.g. in Redis.
+
+# Metrics
+
+WithMetrics reports every request to a MetricsCollector when it starts, on
+each retry, and when it finishes with its status and total duration.
+PrometheusMetrics implements the interface without extra dependencies and
+serves the Prometheus text format:
+
+	metrics := rest.NewPrometheusMetrics("orders")
+	client, err := rest.NewClient(
+		rest.WithBaseURL("https://inventory.internal"),
+		rest.WithMetrics(metrics),
+	)
+	router.Handle("/metrics", metrics)
+
+Requests are labelled with their method, host and path. Paths containing IDs
+should be reported under a template to keep cardinality low:
+
+	ctx = rest.WithPathTemplate(ctx, "/products/{id}")
+	err := client.Get(ctx, "/products/"+id, &product)
+
+# Client Stats
+
+Every client counts its requests, attempts, retries, failures and time spent
+backing off, in total and per host. Stats returns a snapshot, which helps
+tell a degraded dependency apart from a slow one:
+
+	stats := client.Stats()
+	for host, h := range stats.Hosts {
+		if h.ConsecutiveFailures > 5 {
+			log.Warn("Dependency degraded", zap.String("host", host), zap.String("last_error", h.LastError))
+		}
+	}
+
+A request fails when it gets no response, or a 5xx or 429 response after its
+last attempt; requests canceled by their context are not counted.
+WithCircuitBreaker stops calling a host after a run of failures, failing
+requests at once with ErrCircuitOpen until a trial request succeeds after the
+cooldown. Open circuits are reported by CircuitOpen and OpenCircuits:
+
+	client, err := rest.NewClient(
+		rest.WithBaseURL("https://inventory.internal"),
+		rest.WithCircuitBreaker(5, 30*time.Second),
+	)
+
+The stats can also be published with expvar, served at /debug/vars, or added
+to a PrometheusMetrics:
+
+	client.PublishExpvar("inventory_client")
+	metrics.AddClient("inventory", client)
+
 # Error Handling
 
 The package provides standardized error handling:
@@ -73,9 +428,71 @@ The package provides standardized error handling:
 		}
 	}
 
+Every status error records the response's StatusCode and Body.
+
+# Calling go-core Services
+
+Services built on the api package wrap responses in a success or error
+envelope. WithEnvelopeMode decodes the data member into the response value
+and converts error envelopes into a *ClientError with the upstream status,
+code and message:
+
+	client, err := rest.NewClient(
+		rest.WithBaseURL("https://inventory.internal"),
+		rest.WithEnvelopeMode(),
+	)
+
+	var product Product
+	err = client.Get(ctx, "/products/42", &product) // {"data": {...}} decoded into product
+
+	var clientErr *rest.ClientError
+	if errors.As(err, &clientErr) && clientErr.Code == "PRODUCT_LOCKED" {
+		// {"error": {"status_code": 409, "code": "PRODUCT_LOCKED", ...}}
+	}
+
+A successful response without a data member fails with ErrInvalidResponse.
+
+# Streaming Responses
+
+Stream and GetStream return the response body unread, so large exports and
+newline-delimited JSON feeds can be consumed without buffering. The caller
+must close the response. Non-2xx responses are returned as errors, exactly
+like Request:
+
+	resp, err := client.GetStream(ctx, "/exports/orders.csv")
+	if err != nil {
+		return err
+	}
+	defer resp.Close()
+
+	_, err = io.Copy(file, resp.Body)
+
+DecodeNDJSON decodes one value per line:
+
+	err = rest.DecodeNDJSON(resp.Body, func(e Event) error {
+		return process(e)
+	})
+
+# Connection Pooling
+
+NewClient gives each client its own transport with a connection pool tuned
+for service-to-service traffic: up to 32 idle connections per host instead
+of the standard library's 2, a 90 second idle timeout, and HTTP/2 enabled.
+Each setting can be changed on its own:
+
+	client, err := rest.NewClient(
+		rest.WithBaseURL("https://inventory.internal"),
+		rest.WithMaxIdleConnsPerHost(64),
+		rest.WithMaxConnsPerHost(128), // queue requests beyond 128 connections
+		rest.WithIdleConnTimeout(30*time.Second),
+	)
+
+NewTransport builds the same transport for use outside the client.
+
 # Advanced HTTP Client Configuration
 
-For more control, you can provide a custom HTTP client:
+For more control, you can provide a custom HTTP client. Its transport is
+used as is, so the pool options above do not apply:
 
 	httpClient := &http.Client{
 		Timeout: 30 * time.Second,
@@ -0,0 +1,59 @@
+package rest
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/StairSupplies/go-core/api"
+)
+
+// unwrapEnvelope returns the data member of an api.SuccessResponse body.
+// An error envelope is returned as a *ClientError.
+func unwrapEnvelope(status int, body []byte) (json.RawMessage, error) {
+	data, _, err := api.ParseSuccess[json.RawMessage](bytes.NewReader(body))
+	if err == nil {
+		return data, nil
+	}
+
+	var apiErr api.Error
+	if errors.As(err, &apiErr) {
+		return nil, clientErrorFromAPI(status, apiErr)
+	}
+	return nil, &ClientError{
+		Err:        ErrInvalidResponse,
+		Message:    fmt.Sprintf("failed to read response envelope: %s", err),
+		StatusCode: status,
+	}
+}
+
+// envelopeError converts an api error envelope body into a *ClientError,
+// reporting false if body is not an error envelope
+func envelopeError(status int, body []byte) (*ClientError, bool) {
+	var env struct {
+		Error *api.Error `json:"error"`
+	}
+	if err := json.Unmarshal(body, &env); err != nil || env.Error == nil {
+		return nil, false
+	}
+	return clientErrorFromAPI(status, *env.Error), true
+}
+
+// clientErrorFromAPI converts an upstream api.Error, preferring the status
+// code it reports over the status of the response that carried it
+func clientErrorFromAPI(status int, apiErr api.Error) *ClientError {
+	if apiErr.StatusCode != 0 {
+		status = apiErr.StatusCode
+	}
+	code := apiErr.Code
+	if code == "" {
+		code = fmt.Sprintf("%d", status)
+	}
+	return &ClientError{
+		Err:        getErrorByStatusCode(status),
+		Message:    apiErr.Message,
+		Code:       code,
+		StatusCode: status,
+	}
+}
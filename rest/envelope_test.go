@@ -0,0 +1,104 @@
+package rest
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/StairSupplies/go-core/api"
+)
+
+func TestClient_EnvelopeMode(t *testing.T) {
+	type product struct {
+		ID   int    `json:"id"`
+		Name string `json:"name"`
+	}
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/products/1":
+			api.WriteSuccess(w, product{ID: 1, Name: "Oak Tread"}, map[string]int{"version": 3})
+		case "/products/2":
+			api.WriteError(w, api.NewCodedError(http.StatusConflict, "PRODUCT_LOCKED", errors.New("product is locked"), nil))
+		case "/products/3":
+			api.WriteError(w, api.NotFoundError(errors.New("product not found")))
+		case "/plain":
+			w.Write([]byte(`{"id":4}`))
+		case "/plain-error":
+			w.WriteHeader(http.StatusBadGateway)
+			w.Write([]byte("upstream unavailable"))
+		}
+	}))
+	defer server.Close()
+
+	client, err := NewClient(WithBaseURL(server.URL), WithRetries(0), WithEnvelopeMode())
+	if err != nil {
+		t.Fatalf("NewClient() error = %v", err)
+	}
+	ctx := context.Background()
+
+	t.Run("unwraps data", func(t *testing.T) {
+		var got product
+		if err := client.Get(ctx, "/products/1", &got); err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+		if got.ID != 1 || got.Name != "Oak Tread" {
+			t.Errorf("Expected product 1, got %+v", got)
+		}
+	})
+
+	t.Run("coded error envelope", func(t *testing.T) {
+		err := client.Get(ctx, "/products/2", nil)
+		var clientErr *ClientError
+		if !errors.As(err, &clientErr) {
+			t.Fatalf("Expected ClientError, got %v", err)
+		}
+		if clientErr.StatusCode != http.StatusConflict {
+			t.Errorf("Expected status 409, got %d", clientErr.StatusCode)
+		}
+		if clientErr.Code != "PRODUCT_LOCKED" {
+			t.Errorf("Expected code PRODUCT_LOCKED, got %q", clientErr.Code)
+		}
+		if clientErr.Message != "product is locked" {
+			t.Errorf("Expected upstream message, got %q", clientErr.Message)
+		}
+		if !errors.Is(err, ErrInvalidRequest) {
+			t.Errorf("Expected ErrInvalidRequest, got %v", clientErr.Err)
+		}
+	})
+
+	t.Run("error envelope without code", func(t *testing.T) {
+		err := client.Get(ctx, "/products/3", nil)
+		var clientErr *ClientError
+		if !errors.As(err, &clientErr) {
+			t.Fatalf("Expected ClientError, got %v", err)
+		}
+		if !errors.Is(err, ErrResourceNotFound) {
+			t.Errorf("Expected ErrResourceNotFound, got %v", clientErr.Err)
+		}
+		if clientErr.Code != "404" || clientErr.Message != "product not found" {
+			t.Errorf("Expected code 404 and upstream message, got %q %q", clientErr.Code, clientErr.Message)
+		}
+	})
+
+	t.Run("body without envelope", func(t *testing.T) {
+		var got product
+		err := client.Get(ctx, "/plain", &got)
+		if !errors.Is(err, ErrInvalidResponse) {
+			t.Errorf("Expected ErrInvalidResponse, got %v", err)
+		}
+	})
+
+	t.Run("non-envelope error body", func(t *testing.T) {
+		err := client.Get(ctx, "/plain-error", nil)
+		var clientErr *ClientError
+		if !errors.As(err, &clientErr) {
+			t.Fatalf("Expected ClientError, got %v", err)
+		}
+		if clientErr.StatusCode != http.StatusBadGateway || clientErr.Message != "upstream unavailable" {
+			t.Errorf("Expected status 502 with the raw body, got %d %q", clientErr.StatusCode, clientErr.Message)
+		}
+	})
+}
@@ -31,13 +31,18 @@ var (
 
 	// ErrInvalidResponse indicates that the response from the server was invalid or malformed.
 	ErrInvalidResponse = errors.New("invalid response")
+
+	// ErrCircuitOpen indicates that the request was not sent because the host's circuit is open.
+	ErrCircuitOpen = errors.New("circuit open")
 )
 
 // ClientError represents an error from the client.
 type ClientError struct {
-	Err     error  // Underlying error, typically one of the sentinel errors
-	Message string // Detailed error message explaining what went wrong
-	Code    string // Optional error code, typically derived from the API response
+	Err        error  // Underlying error, typically one of the sentinel errors
+	Message    string // Detailed error message explaining what went wrong
+	Code       string // Optional error code, typically derived from the API response
+	StatusCode int    // HTTP status of the response, or 0 if none was received
+	Body       []byte // Body of the error response, if one was received
 }
 
 // Error returns the error message.
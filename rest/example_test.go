@@ -1,8 +1,12 @@
 package rest_test
 
 import (
+	"context"
+	"errors"
 	"fmt"
 	"net/http"
+	"net/http/httptest"
+	"strings"
 	"time"
 
 	"github.com/StairSupplies/go-core/logger"
@@ -286,4 +290,479 @@ func ExampleNewClientError() {
 	fmt.Printf("Error type: %T\n", err)
 
 	// Output: Error type: *rest.ClientError
-}
\ No newline at end of file
+}
+
+func ExampleDecodeNDJSON() {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprintln(w, `{"sku":"TREAD-36"}`)
+		fmt.Fprintln(w, `{"sku":"RAIL-8"}`)
+	}))
+	defer server.Close()
+
+	client, err := rest.NewClient(rest.WithBaseURL(server.URL), rest.WithLogger(logger.NewNopLogger()))
+	if err != nil {
+		fmt.Printf("Error creating client: %v\n", err)
+		return
+	}
+
+	// Stream the feed instead of buffering the whole response
+	resp, err := client.GetStream(context.Background(), "/products.ndjson")
+	if err != nil {
+		fmt.Printf("Error streaming: %v\n", err)
+		return
+	}
+	defer resp.Close()
+
+	type product struct {
+		SKU string `json:"sku"`
+	}
+	err = rest.DecodeNDJSON(resp.Body, func(p product) error {
+		fmt.Println(p.SKU)
+		return nil
+	})
+	if err != nil {
+		fmt.Printf("Error decoding: %v\n", err)
+	}
+
+	// Output:
+	// TREAD-36
+	// RAIL-8
+}
+
+func ExampleNewClientCredentials() {
+	// Tokens are fetched on first use and refreshed shortly before they expire
+	auth, err := rest.NewClientCredentials(rest.ClientCredentialsConfig{
+		TokenURL:     "https://auth.example.com/oauth/token",
+		ClientID:     "orders-service",
+		ClientSecret: "secret",
+		Scopes:       []string{"orders:read"},
+	})
+	if err != nil {
+		fmt.Printf("Error creating provider: %v\n", err)
+		return
+	}
+
+	client, err := rest.NewClient(
+		rest.WithBaseURL("https://api.example.com"),
+		rest.WithAuthProvider(auth),
+	)
+	if err != nil {
+		fmt.Printf("Error creating client: %v\n", err)
+		return
+	}
+
+	fmt.Println("Client created successfully")
+	_ = client
+
+	// Output: Client created successfully
+}
+
+func ExampleWithRequestInterceptor() {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fmt.Println("X-Tenant-ID:", r.Header.Get("X-Tenant-ID"))
+	}))
+	defer server.Close()
+
+	client, err := rest.NewClient(
+		rest.WithBaseURL(server.URL),
+		rest.WithLogger(logger.NewNopLogger()),
+		rest.WithRequestInterceptor(func(req *http.Request) error {
+			req.Header.Set("X-Tenant-ID", "acme")
+			return nil
+		}),
+	)
+	if err != nil {
+		fmt.Printf("Error creating client: %v\n", err)
+		return
+	}
+
+	if err := client.Get(context.Background(), "/orders", nil); err != nil {
+		fmt.Printf("Error: %v\n", err)
+	}
+
+	// Output: X-Tenant-ID: acme
+}
+
+func ExampleWithRetryPolicy() {
+	attempts := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		attempts++
+		if attempts < 3 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		w.Write([]byte(`{"status":"ok"}`))
+	}))
+	defer server.Close()
+
+	policy := rest.DefaultRetryPolicy()
+	policy.MaxAttempts = 3
+	policy.BaseBackoff = 10 * time.Millisecond
+
+	client, _ := rest.NewClient(
+		rest.WithBaseURL(server.URL),
+		rest.WithRetryPolicy(policy),
+	)
+
+	var resp map[string]string
+	if err := client.Get(context.Background(), "/health", &resp); err != nil {
+		fmt.Println("Error:", err)
+		return
+	}
+	fmt.Println(resp["status"], "after", attempts, "attempts")
+
+	// Output: ok after 3 attempts
+}
+
+func ExampleWithCache() {
+	requests := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		w.Header().Set("ETag", `"v1"`)
+		if r.Header.Get("If-None-Match") == `"v1"` {
+			w.WriteHeader(http.StatusNotModified)
+			return
+		}
+		w.Write([]byte(`{"name":"Oak Tread"}`))
+	}))
+	defer server.Close()
+
+	client, _ := rest.NewClient(
+		rest.WithBaseURL(server.URL),
+		rest.WithCache(rest.NewMemoryCache(100), time.Minute),
+	)
+
+	for i := 0; i < 3; i++ {
+		var product map[string]string
+		client.Get(context.Background(), "/products/1", &product)
+		fmt.Println(product["name"])
+	}
+	fmt.Println("server requests:", requests)
+
+	// Output:
+	// Oak Tread
+	// Oak Tread
+	// Oak Tread
+	// server requests: 1
+}
+
+func ExampleClient_PostMultipart() {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		r.ParseMultipartForm(1 << 20)
+		_, header, _ := r.FormFile("file")
+		fmt.Fprintf(w, `{"name":%q,"size":%d}`, header.Filename, header.Size)
+	}))
+	defer server.Close()
+
+	client, _ := rest.NewClient(rest.WithBaseURL(server.URL))
+
+	var upload struct {
+		Name string `json:"name"`
+		Size int64  `json:"size"`
+	}
+	err := client.PostMultipart(context.Background(), "/attachments",
+		map[string]string{"description": "Stair drawing"},
+		[]rest.File{{FieldName: "file", FileName: "drawing.txt", Reader: strings.NewReader("36in tread")}},
+		&upload,
+	)
+	if err != nil {
+		fmt.Println("Error:", err)
+		return
+	}
+	fmt.Println(upload.Name, upload.Size)
+
+	// Output: drawing.txt 10
+}
+
+func ExampleWithMetrics() {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{}`))
+	}))
+	defer server.Close()
+
+	metrics := rest.NewPrometheusMetrics("orders")
+	client, _ := rest.NewClient(rest.WithBaseURL(server.URL), rest.WithMetrics(metrics))
+
+	ctx := rest.WithPathTemplate(context.Background(), "/products/{id}")
+	client.Get(ctx, "/products/42", nil)
+
+	// Print the request counter from the /metrics output
+	var out strings.Builder
+	metrics.WriteTo(&out)
+	for _, line := range strings.Split(out.String(), "\n") {
+		if strings.HasPrefix(line, "orders_http_client_requests_total{") {
+			fmt.Println(strings.Replace(line, server.URL[len("http://"):], "HOST", 1))
+		}
+	}
+
+	// Output: orders_http_client_requests_total{method="GET",host="HOST",path="/products/{id}",status="200"} 1
+}
+
+func ExampleWithHMACSigner() {
+	secret := []byte("shared-secret")
+	verifier := rest.NewHMACVerifier(map[string][]byte{"orders": secret})
+
+	server := httptest.NewServer(verifier.Middleware(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		keyID, _ := rest.HMACKeyID(r.Context())
+		fmt.Println("verified request from", keyID)
+		w.Write([]byte(`{}`))
+	})))
+	defer server.Close()
+
+	client, _ := rest.NewClient(
+		rest.WithBaseURL(server.URL),
+		rest.WithHMACSigner("orders", secret, rest.HMACSHA256),
+	)
+	client.Post(context.Background(), "/internal/orders", map[string]int{"qty": 2}, nil)
+
+	// Output: verified request from orders
+}
+
+func ExampleWithEnvelopeMode() {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/products/1" {
+			w.Write([]byte(`{"status_code":200,"data":{"name":"Oak Tread"}}`))
+			return
+		}
+		w.WriteHeader(http.StatusConflict)
+		w.Write([]byte(`{"error":{"status_code":409,"code":"PRODUCT_LOCKED","message":"product is locked"}}`))
+	}))
+	defer server.Close()
+
+	client, _ := rest.NewClient(rest.WithBaseURL(server.URL), rest.WithEnvelopeMode())
+
+	var product struct {
+		Name string `json:"name"`
+	}
+	client.Get(context.Background(), "/products/1", &product)
+	fmt.Println(product.Name)
+
+	err := client.Get(context.Background(), "/products/2", nil)
+	var clientErr *rest.ClientError
+	if errors.As(err, &clientErr) {
+		fmt.Println(clientErr.StatusCode, clientErr.Code, clientErr.Message)
+	}
+
+	// Output:
+	// Oak Tread
+	// 409 PRODUCT_LOCKED product is locked
+}
+
+func ExampleWithMaxIdleConnsPerHost() {
+	client, err := rest.NewClient(
+		rest.WithBaseURL("https://inventory.internal"),
+		rest.WithMaxIdleConnsPerHost(64),
+		rest.WithMaxConnsPerHost(128),
+		rest.WithIdleConnTimeout(30*time.Second),
+	)
+	if err != nil {
+		fmt.Println("Error:", err)
+		return
+	}
+
+	transport := client.HTTPClient.Transport.(*http.Transport)
+	fmt.Println(transport.MaxIdleConnsPerHost, transport.MaxConnsPerHost, transport.IdleConnTimeout)
+
+	// Output: 64 128 30s
+}
+
+func ExampleAppendQuery() {
+	var region *string // no region filter
+	since := time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC)
+
+	path := rest.AppendQuery("/orders?status=open", rest.Query{
+		"sku":    []string{"TREAD-36", "RISER 7"},
+		"region": region,
+		"since":  since,
+	})
+	fmt.Println(path)
+
+	// Output: /orders?status=open&since=2024-03-01T00%3A00%3A00Z&sku=TREAD-36&sku=RISER+7
+}
+
+func ExampleGet() {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/orders/A-1" {
+			w.Write([]byte(`{"id":"A-1","total":120}`))
+			return
+		}
+		w.WriteHeader(http.StatusConflict)
+		w.Write([]byte(`{"message":"order is locked","locked_by":"warehouse"}`))
+	}))
+	defer server.Close()
+
+	client, _ := rest.NewClient(rest.WithBaseURL(server.URL))
+
+	type Order struct {
+		ID    string `json:"id"`
+		Total int    `json:"total"`
+	}
+	order, err := rest.Get[Order](context.Background(), client, "/orders/A-1")
+	if err != nil {
+		fmt.Println("Error:", err)
+		return
+	}
+	fmt.Println(order.ID, order.Total)
+
+	// Error responses can be decoded into a type of their own
+	_, err = rest.Get[Order](context.Background(), client, "/orders/A-2")
+	type LockedError struct {
+		Message  string `json:"message"`
+		LockedBy string `json:"locked_by"`
+	}
+	if locked, ok := rest.ErrorBody[LockedError](err); ok {
+		fmt.Println(locked.Message, "by", locked.LockedBy)
+	}
+
+	// Output:
+	// A-1 120
+	// order is locked by warehouse
+}
+
+func ExampleWithProgress() {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Length", "26")
+		w.Write([]byte(`{"sku":"OAK-36","qty":120}`))
+	}))
+	defer server.Close()
+
+	client, _ := rest.NewClient(
+		rest.WithBaseURL(server.URL),
+		rest.WithLogger(logger.NewNopLogger()),
+		rest.WithProgress(func(n, total int64) {
+			fmt.Printf("%d of %d bytes\n", n, total)
+		}),
+	)
+
+	var stock map[string]any
+	client.Get(context.Background(), "/inventory/OAK-36", &stock)
+
+	// Output: 26 of 26 bytes
+}
+
+func ExampleBatch() {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/products/3" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		w.Write([]byte(`{"sku":"` + strings.TrimPrefix(r.URL.Path, "/products/") + `"}`))
+	}))
+	defer server.Close()
+
+	client, _ := rest.NewClient(
+		rest.WithBaseURL(server.URL),
+		rest.WithLogger(logger.NewNopLogger()),
+	)
+
+	var products [3]map[string]string
+	results := rest.Batch(context.Background(), client, []rest.BatchRequest{
+		{Method: http.MethodGet, Path: "/products/1", Response: &products[0]},
+		{Method: http.MethodGet, Path: "/products/2", Response: &products[1]},
+		{Method: http.MethodGet, Path: "/products/3", Response: &products[2]},
+	}, 2)
+
+	for _, r := range results {
+		fmt.Println(r.Request.Path, errors.Is(r.Err, rest.ErrResourceNotFound))
+	}
+	fmt.Println(products[0]["sku"], products[1]["sku"])
+
+	// Output:
+	// /products/1 false
+	// /products/2 false
+	// /products/3 true
+	// 1 2
+}
+
+func ExampleRateLimitError() {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("X-RateLimit-Limit", "100")
+		w.Header().Set("X-RateLimit-Remaining", "0")
+		w.Header().Set("Retry-After", "120")
+		w.WriteHeader(http.StatusTooManyRequests)
+	}))
+	defer server.Close()
+
+	client, _ := rest.NewClient(
+		rest.WithBaseURL(server.URL),
+		rest.WithLogger(logger.NewNopLogger()),
+		rest.WithRateLimit(5, 10),
+	)
+
+	err := client.Get(context.Background(), "/orders", nil)
+
+	var rateErr *rest.RateLimitError
+	if errors.As(err, &rateErr) {
+		fmt.Println("Limit:", rateErr.Limit)
+		fmt.Println("Retry after:", rateErr.RetryAfter)
+	}
+
+	// Output:
+	// Limit: 100
+	// Retry after: 2m0s
+}
+
+func ExampleClient_Stats() {
+	attempts := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		attempts++
+		if attempts < 3 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		w.Write([]byte(`{"status":"ok"}`))
+	}))
+	defer server.Close()
+
+	policy := rest.DefaultRetryPolicy()
+	policy.BaseBackoff = time.Millisecond
+
+	client, _ := rest.NewClient(
+		rest.WithBaseURL(server.URL),
+		rest.WithLogger(logger.NewNopLogger()),
+		rest.WithRetryPolicy(policy),
+	)
+
+	client.Get(context.Background(), "/health", nil)
+
+	stats := client.Stats()
+	fmt.Println("Requests:", stats.Requests)
+	fmt.Println("Attempts:", stats.Attempts)
+	fmt.Println("Retries:", stats.Retries)
+	fmt.Println("Failures:", stats.Failures)
+
+	// Output:
+	// Requests: 1
+	// Attempts: 3
+	// Retries: 2
+	// Failures: 0
+}
+
+func ExampleWithFallbackBaseURLs() {
+	primary := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer primary.Close()
+	secondary := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"region":"us-west"}`))
+	}))
+	defer secondary.Close()
+
+	client, _ := rest.NewClient(
+		rest.WithBaseURL(primary.URL),
+		rest.WithFallbackBaseURLs(secondary.URL),
+		rest.WithLogger(logger.NewNopLogger()),
+		rest.WithRetries(0),
+	)
+
+	var resp struct {
+		Region string `json:"region"`
+	}
+	if err := client.Get(context.Background(), "/stock/STAIR-01", &resp); err != nil {
+		fmt.Println("Error:", err)
+		return
+	}
+	fmt.Println("Answered by", resp.Region)
+
+	// Output: Answered by us-west
+}
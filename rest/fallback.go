@@ -0,0 +1,176 @@
+package rest
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"time"
+)
+
+// baseURLs returns the client's base URL followed by its fallbacks
+func (c *Client) baseURLs() []string {
+	return append([]string{c.BaseURL}, c.fallbackBaseURLs...)
+}
+
+// canFailover reports whether a request may be sent to a fallback base URL:
+// it must be idempotent and its body must be readable again
+func canFailover(method string, body *payload) bool {
+	if body != nil && body.once {
+		return false
+	}
+	switch method {
+	case http.MethodGet, http.MethodHead, http.MethodOptions, http.MethodPut, http.MethodDelete:
+		return true
+	}
+	return false
+}
+
+// canHedge reports whether a request may be raced against a hedged copy.
+// Only reads are hedged, since both copies may reach a server.
+func canHedge(method string) bool {
+	return method == http.MethodGet || method == http.MethodHead || method == http.MethodOptions
+}
+
+// sendFailover sends a request to the base URL, then to each fallback base
+// URL while the previous one is down, or races them when hedging is enabled
+func (c *Client) sendFailover(ctx context.Context, method, path string, body *payload, accept string) (*http.Response, error) {
+	bases := c.baseURLs()
+	if c.hedgeDelay > 0 && canHedge(method) && canFailover(method, body) {
+		if len(bases) == 1 {
+			// Without fallbacks, hedge against the same server
+			bases = append(bases, bases[0])
+		}
+		return c.sendHedged(ctx, method, path, bases, body, accept)
+	}
+	if len(bases) == 1 || !canFailover(method, body) {
+		return c.send(ctx, method, bases[0]+path, body, accept)
+	}
+
+	var resp *http.Response
+	var err error
+	for i, base := range bases {
+		resp, err = c.send(ctx, method, base+path, body, accept)
+		if i == len(bases)-1 || !failed(responseStatus(resp), err) {
+			break
+		}
+		if resp != nil {
+			resp.Body.Close()
+		}
+	}
+	return resp, err
+}
+
+// hedgeResult is the outcome of one copy of a hedged request
+type hedgeResult struct {
+	index int
+	resp  *http.Response
+	err   error
+}
+
+// sendHedged sends the request to bases[0], then to each following base
+// URL when the copies already sent have not answered within the hedge delay
+// or have failed. The first response that is not a failure wins and the
+// other copies are canceled. If every copy fails, the first copy's result is
+// returned.
+func (c *Client) sendHedged(ctx context.Context, method, path string, bases []string, body *payload, accept string) (*http.Response, error) {
+	results := make(chan hedgeResult, len(bases))
+	cancels := make([]context.CancelFunc, 0, len(bases))
+	launch := func() {
+		i := len(cancels)
+		hctx, cancel := context.WithCancel(ctx)
+		cancels = append(cancels, cancel)
+		go func() {
+			resp, err := c.send(hctx, method, bases[i]+path, body, accept)
+			results <- hedgeResult{index: i, resp: resp, err: err}
+		}()
+	}
+
+	launch()
+	timer := time.NewTimer(c.hedgeDelay)
+	defer timer.Stop()
+
+	var first *hedgeResult
+	for done := 0; done < len(cancels); {
+		select {
+		case res := <-results:
+			done++
+			if !failed(responseStatus(res.resp), res.err) {
+				c.finishHedged(res, cancels, len(cancels)-done, results)
+				return res.resp, res.err
+			}
+			if res.index == 0 {
+				first = &res
+			} else if res.resp != nil {
+				res.resp.Body.Close()
+			}
+			// Do not wait out the delay once a copy has failed
+			if len(cancels) < len(bases) {
+				launch()
+				resetTimer(timer, c.hedgeDelay)
+			}
+		case <-timer.C:
+			if len(cancels) < len(bases) {
+				launch()
+				resetTimer(timer, c.hedgeDelay)
+			}
+		}
+	}
+
+	c.finishHedged(*first, cancels, 0, results)
+	return first.resp, first.err
+}
+
+// resetTimer stops and drains timer before resetting it to d, so a tick from
+// the previous delay is not mistaken for the new one
+func resetTimer(timer *time.Timer, d time.Duration) {
+	if !timer.Stop() {
+		select {
+		case <-timer.C:
+		default:
+		}
+	}
+	timer.Reset(d)
+}
+
+// finishHedged cancels every copy but the winner, discards the pending
+// results, and ties the winner's context to its response body
+func (c *Client) finishHedged(winner hedgeResult, cancels []context.CancelFunc, pending int, results <-chan hedgeResult) {
+	for i, cancel := range cancels {
+		if i != winner.index {
+			cancel()
+		}
+	}
+	go func() {
+		for ; pending > 0; pending-- {
+			if res := <-results; res.resp != nil {
+				res.resp.Body.Close()
+			}
+		}
+	}()
+
+	if winner.resp == nil {
+		cancels[winner.index]()
+		return
+	}
+	winner.resp.Body = &cancelOnClose{ReadCloser: winner.resp.Body, cancel: cancels[winner.index]}
+}
+
+// cancelOnClose cancels a request's context once its body is closed
+type cancelOnClose struct {
+	io.ReadCloser
+	cancel context.CancelFunc
+}
+
+func (b *cancelOnClose) Close() error {
+	err := b.ReadCloser.Close()
+	b.cancel()
+	return err
+}
+
+// responseStatus returns the status of resp, or 0 if there is none
+func responseStatus(resp *http.Response) int {
+	if resp == nil {
+		return 0
+	}
+	return resp.StatusCode
+}
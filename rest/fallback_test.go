@@ -0,0 +1,256 @@
+package rest
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// regionServer answers with its name after delay, or with status if set
+func regionServer(t *testing.T, name string, status int, delay time.Duration, calls *atomic.Int32) *httptest.Server {
+	t.Helper()
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls.Add(1)
+		if delay > 0 {
+			select {
+			case <-time.After(delay):
+			case <-r.Context().Done():
+				return
+			}
+		}
+		if status != 0 {
+			w.WriteHeader(status)
+			return
+		}
+		w.Write([]byte(`{"region":"` + name + `"}`))
+	}))
+	t.Cleanup(server.Close)
+	return server
+}
+
+func TestWithFallbackBaseURLs(t *testing.T) {
+	var primaryCalls, secondaryCalls atomic.Int32
+	primary := regionServer(t, "primary", http.StatusServiceUnavailable, 0, &primaryCalls)
+	secondary := regionServer(t, "secondary", 0, 0, &secondaryCalls)
+
+	policy := fastRetryPolicy()
+	policy.MaxAttempts = 2
+	client, _ := NewClient(
+		WithBaseURL(primary.URL),
+		WithFallbackBaseURLs(secondary.URL),
+		WithRetryPolicy(policy),
+	)
+
+	var resp struct{ Region string }
+	if err := client.Get(context.Background(), "/items", &resp); err != nil {
+		t.Fatalf("Get() error = %v", err)
+	}
+	if resp.Region != "secondary" {
+		t.Errorf("Expected the fallback to answer, got %q", resp.Region)
+	}
+	if primaryCalls.Load() != 2 || secondaryCalls.Load() != 1 {
+		t.Errorf("Expected the primary to be retried before failing over, got %d and %d calls", primaryCalls.Load(), secondaryCalls.Load())
+	}
+}
+
+func TestWithFallbackBaseURLs_ConnectionError(t *testing.T) {
+	down := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	down.Close()
+	var calls atomic.Int32
+	secondary := regionServer(t, "secondary", 0, 0, &calls)
+
+	policy := fastRetryPolicy()
+	policy.MaxAttempts = 1
+	client, _ := NewClient(WithBaseURL(down.URL), WithFallbackBaseURLs(secondary.URL), WithRetryPolicy(policy))
+
+	var resp struct{ Region string }
+	if err := client.Get(context.Background(), "/items", &resp); err != nil || resp.Region != "secondary" {
+		t.Errorf("Expected the fallback to answer, got %q (err %v)", resp.Region, err)
+	}
+}
+
+func TestWithFallbackBaseURLs_NoFailover(t *testing.T) {
+	var primaryCalls, secondaryCalls atomic.Int32
+	notFound := regionServer(t, "primary", http.StatusNotFound, 0, &primaryCalls)
+	secondary := regionServer(t, "secondary", 0, 0, &secondaryCalls)
+
+	policy := fastRetryPolicy()
+	policy.MaxAttempts = 1
+	client, _ := NewClient(WithBaseURL(notFound.URL), WithFallbackBaseURLs(secondary.URL), WithRetryPolicy(policy))
+
+	// A 404 is an answer, not an outage
+	if err := client.Get(context.Background(), "/items", nil); !errors.Is(err, ErrResourceNotFound) {
+		t.Errorf("Expected ErrResourceNotFound, got %v", err)
+	}
+
+	// POST is not idempotent
+	down := regionServer(t, "primary", http.StatusServiceUnavailable, 0, &primaryCalls)
+	client.BaseURL = down.URL
+	client.Post(context.Background(), "/items", map[string]string{"sku": "A"}, nil)
+
+	if secondaryCalls.Load() != 0 {
+		t.Errorf("Expected no calls to the fallback, got %d", secondaryCalls.Load())
+	}
+}
+
+func TestWithFallbackBaseURLs_AllDown(t *testing.T) {
+	var calls atomic.Int32
+	primary := regionServer(t, "primary", http.StatusBadGateway, 0, &calls)
+	secondary := regionServer(t, "secondary", http.StatusServiceUnavailable, 0, &calls)
+
+	policy := fastRetryPolicy()
+	policy.MaxAttempts = 1
+	client, _ := NewClient(WithBaseURL(primary.URL), WithFallbackBaseURLs(secondary.URL), WithRetryPolicy(policy))
+
+	err := client.Get(context.Background(), "/items", nil)
+	var clientErr *ClientError
+	if !errors.As(err, &clientErr) || clientErr.StatusCode != http.StatusServiceUnavailable {
+		t.Errorf("Expected the last fallback's 503, got %v", err)
+	}
+}
+
+func TestWithHedging(t *testing.T) {
+	var slowCalls, fastCalls atomic.Int32
+	slow := regionServer(t, "primary", 0, 2*time.Second, &slowCalls)
+	fast := regionServer(t, "secondary", 0, 0, &fastCalls)
+
+	client, _ := NewClient(
+		WithBaseURL(slow.URL),
+		WithFallbackBaseURLs(fast.URL),
+		WithHedging(20*time.Millisecond),
+	)
+
+	start := time.Now()
+	var resp struct{ Region string }
+	if err := client.Get(context.Background(), "/items", &resp); err != nil {
+		t.Fatalf("Get() error = %v", err)
+	}
+	if resp.Region != "secondary" {
+		t.Errorf("Expected the hedged request to win, got %q", resp.Region)
+	}
+	if elapsed := time.Since(start); elapsed > time.Second {
+		t.Errorf("Expected the hedge to answer quickly, took %v", elapsed)
+	}
+}
+
+func TestWithHedging_PrimaryAnswers(t *testing.T) {
+	var primaryCalls, secondaryCalls atomic.Int32
+	primary := regionServer(t, "primary", 0, 0, &primaryCalls)
+	secondary := regionServer(t, "secondary", 0, 0, &secondaryCalls)
+
+	client, _ := NewClient(WithBaseURL(primary.URL), WithFallbackBaseURLs(secondary.URL), WithHedging(time.Second))
+
+	var resp struct{ Region string }
+	if err := client.Get(context.Background(), "/items", &resp); err != nil || resp.Region != "primary" {
+		t.Errorf("Expected the primary to answer, got %q (err %v)", resp.Region, err)
+	}
+	if secondaryCalls.Load() != 0 {
+		t.Errorf("Expected no hedged request, got %d", secondaryCalls.Load())
+	}
+}
+
+func TestWithHedging_FailureStartsHedge(t *testing.T) {
+	var calls atomic.Int32
+	down := regionServer(t, "primary", http.StatusServiceUnavailable, 0, &calls)
+	secondary := regionServer(t, "secondary", 0, 0, &calls)
+
+	policy := fastRetryPolicy()
+	policy.MaxAttempts = 1
+	client, _ := NewClient(
+		WithBaseURL(down.URL),
+		WithFallbackBaseURLs(secondary.URL),
+		WithRetryPolicy(policy),
+		WithHedging(time.Minute),
+	)
+
+	var resp struct{ Region string }
+	if err := client.Get(context.Background(), "/items", &resp); err != nil || resp.Region != "secondary" {
+		t.Errorf("Expected the fallback to answer without waiting, got %q (err %v)", resp.Region, err)
+	}
+}
+
+func TestWithHedging_AllFail(t *testing.T) {
+	var calls atomic.Int32
+	primary := regionServer(t, "primary", http.StatusBadGateway, 0, &calls)
+	secondary := regionServer(t, "secondary", http.StatusServiceUnavailable, 0, &calls)
+
+	policy := fastRetryPolicy()
+	policy.MaxAttempts = 1
+	client, _ := NewClient(
+		WithBaseURL(primary.URL),
+		WithFallbackBaseURLs(secondary.URL),
+		WithRetryPolicy(policy),
+		WithHedging(time.Millisecond),
+	)
+
+	err := client.Get(context.Background(), "/items", nil)
+	var clientErr *ClientError
+	if !errors.As(err, &clientErr) || clientErr.StatusCode != http.StatusBadGateway {
+		t.Errorf("Expected the primary's 502, got %v", err)
+	}
+}
+
+func TestWithHedging_SameServer(t *testing.T) {
+	var calls atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		// The first request stalls; the hedged copy answers
+		if calls.Add(1) == 1 {
+			<-r.Context().Done()
+			return
+		}
+		w.Write([]byte(`{"region":"hedge"}`))
+	}))
+	defer server.Close()
+
+	client, _ := NewClient(WithBaseURL(server.URL), WithHedging(10*time.Millisecond))
+
+	var resp struct{ Region string }
+	if err := client.Get(context.Background(), "/items", &resp); err != nil || resp.Region != "hedge" {
+		t.Errorf("Expected the hedged copy to answer, got %q (err %v)", resp.Region, err)
+	}
+}
+
+func TestWithHedging_StreamedBody(t *testing.T) {
+	var calls atomic.Int32
+	slow := regionServer(t, "primary", 0, 50*time.Millisecond, &calls)
+
+	client, _ := NewClient(WithBaseURL(slow.URL), WithHedging(time.Millisecond))
+
+	// The response body stays readable after the hedge is resolved
+	stream, err := client.Stream(context.Background(), http.MethodGet, "/items", nil)
+	if err != nil {
+		t.Fatalf("Stream() error = %v", err)
+	}
+	data, err := io.ReadAll(stream.Body)
+	stream.Close()
+	if err != nil || !strings.Contains(string(data), "primary") {
+		t.Errorf("Expected the response body, got %q (err %v)", data, err)
+	}
+}
+
+func TestCanFailover(t *testing.T) {
+	tests := []struct {
+		method string
+		body   *payload
+		want   bool
+	}{
+		{http.MethodGet, nil, true},
+		{http.MethodPut, &payload{}, true},
+		{http.MethodDelete, nil, true},
+		{http.MethodPost, nil, false},
+		{http.MethodPatch, nil, false},
+		{http.MethodPut, &payload{once: true}, false},
+	}
+
+	for _, tt := range tests {
+		if got := canFailover(tt.method, tt.body); got != tt.want {
+			t.Errorf("canFailover(%s, %+v): expected %v, got %v", tt.method, tt.body, tt.want, got)
+		}
+	}
+}
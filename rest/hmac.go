@@ -0,0 +1,276 @@
+package rest
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"crypto/sha512"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"hash"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/StairSupplies/go-core/api"
+)
+
+// Header names used for HMAC request signing
+const (
+	// HMACKeyIDHeader identifies the secret used to sign the request
+	HMACKeyIDHeader = "X-Signature-Key-Id"
+	// HMACTimestampHeader carries the signing time in Unix seconds
+	HMACTimestampHeader = "X-Signature-Timestamp"
+	// HMACContentHeader carries the hex SHA-256 of the body, or UnsignedPayload
+	HMACContentHeader = "X-Content-Sha256"
+	// HMACSignatureHeader carries the signature as "<algorithm>=<hex>"
+	HMACSignatureHeader = "X-Signature"
+)
+
+// UnsignedPayload is sent in HMACContentHeader for streamed bodies that
+// cannot be hashed before sending
+const UnsignedPayload = "UNSIGNED-PAYLOAD"
+
+// DefaultHMACTolerance is the maximum accepted age of a signature timestamp
+const DefaultHMACTolerance = 5 * time.Minute
+
+// HMACAlgorithm names the hash used for a request signature
+type HMACAlgorithm string
+
+// Supported signing algorithms
+const (
+	HMACSHA256 HMACAlgorithm = "hmac-sha256"
+	HMACSHA512 HMACAlgorithm = "hmac-sha512"
+)
+
+// hash returns the constructor for the algorithm
+func (a HMACAlgorithm) hash() (func() hash.Hash, bool) {
+	switch a {
+	case HMACSHA256:
+		return sha256.New, true
+	case HMACSHA512:
+		return sha512.New, true
+	}
+	return nil, false
+}
+
+// Common HMAC verification errors
+var (
+	// ErrMissingSignature indicates that the request carries no signature headers
+	ErrMissingSignature = errors.New("missing request signature")
+
+	// ErrInvalidSignature indicates that the signature does not match the request
+	ErrInvalidSignature = errors.New("invalid request signature")
+
+	// ErrExpiredSignature indicates that the timestamp is outside the tolerance window
+	ErrExpiredSignature = errors.New("request signature timestamp outside tolerance")
+)
+
+// HMACSigner signs outgoing requests with a shared secret. The signature
+// covers the method, the path and query, the timestamp and a SHA-256 of the
+// body, one per line:
+//
+//	POST
+//	/v1/orders?dry_run=true
+//	1700000000
+//	9f86d081884c7d659a2feaa0c55ad015a3bf4f1b2b0b822cd15d6c15b0f00a08
+type HMACSigner struct {
+	KeyID     string
+	Secret    []byte
+	Algorithm HMACAlgorithm
+	// Now returns the signing time. Defaults to time.Now.
+	Now func() time.Time
+}
+
+// NewHMACSigner creates a signer. An empty algorithm means HMACSHA256.
+func NewHMACSigner(keyID string, secret []byte, algo HMACAlgorithm) *HMACSigner {
+	if algo == "" {
+		algo = HMACSHA256
+	}
+	return &HMACSigner{KeyID: keyID, Secret: secret, Algorithm: algo}
+}
+
+// Sign sets the signature headers on req. It can be used as a
+// RequestInterceptor. Bodies that cannot be re-read, such as multipart
+// uploads, are signed as UnsignedPayload.
+func (s *HMACSigner) Sign(req *http.Request) error {
+	newHash, ok := s.Algorithm.hash()
+	if !ok {
+		return fmt.Errorf("unsupported HMAC algorithm %q", s.Algorithm)
+	}
+
+	contentHash, err := requestContentHash(req)
+	if err != nil {
+		return err
+	}
+
+	now := time.Now
+	if s.Now != nil {
+		now = s.Now
+	}
+	ts := strconv.FormatInt(now().Unix(), 10)
+
+	req.Header.Set(HMACKeyIDHeader, s.KeyID)
+	req.Header.Set(HMACTimestampHeader, ts)
+	req.Header.Set(HMACContentHeader, contentHash)
+	req.Header.Set(HMACSignatureHeader, string(s.Algorithm)+"="+
+		computeHMAC(newHash, s.Secret, req.Method, req.URL.RequestURI(), ts, contentHash))
+	return nil
+}
+
+// requestContentHash hashes a copy of the request body
+func requestContentHash(req *http.Request) (string, error) {
+	if req.Body == nil || req.Body == http.NoBody {
+		return hashBytes(nil), nil
+	}
+	if req.GetBody == nil {
+		return UnsignedPayload, nil
+	}
+
+	body, err := req.GetBody()
+	if err != nil {
+		return "", fmt.Errorf("failed to read body for signing: %w", err)
+	}
+	defer body.Close()
+
+	h := sha256.New()
+	if _, err := io.Copy(h, body); err != nil {
+		return "", fmt.Errorf("failed to read body for signing: %w", err)
+	}
+	return hex.EncodeToString(h.Sum(nil)), nil
+}
+
+// hashBytes returns the hex SHA-256 of b
+func hashBytes(b []byte) string {
+	sum := sha256.Sum256(b)
+	return hex.EncodeToString(sum[:])
+}
+
+// computeHMAC returns the hex HMAC of the canonical request
+func computeHMAC(newHash func() hash.Hash, secret []byte, method, uri, ts, contentHash string) string {
+	mac := hmac.New(newHash, secret)
+	io.WriteString(mac, method+"\n"+uri+"\n"+ts+"\n"+contentHash)
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+// hmacKeyIDKey is the context key for the verified key ID
+type hmacKeyIDKey struct{}
+
+// HMACKeyID returns the key ID of a request verified by HMACVerifier.Middleware
+func HMACKeyID(ctx context.Context) (string, bool) {
+	keyID, ok := ctx.Value(hmacKeyIDKey{}).(string)
+	return keyID, ok
+}
+
+// HMACVerifier checks requests signed by an HMACSigner
+type HMACVerifier struct {
+	// Keys maps key IDs to their secrets. Several keys allow rotating
+	// secrets without downtime.
+	Keys map[string][]byte
+	// Tolerance is the maximum age of a signature. Defaults to DefaultHMACTolerance.
+	Tolerance time.Duration
+	// MaxBodyBytes limits the body read for verification. Defaults to 10 MiB.
+	MaxBodyBytes int64
+	// AllowUnsignedPayload accepts requests whose body was not hashed
+	AllowUnsignedPayload bool
+	// Now returns the current time. Defaults to time.Now.
+	Now func() time.Time
+}
+
+// NewHMACVerifier creates a verifier for the given key IDs and secrets
+func NewHMACVerifier(keys map[string][]byte) *HMACVerifier {
+	return &HMACVerifier{Keys: keys}
+}
+
+// Verify checks the signature of r and returns the key ID that signed it.
+// The body is read and replaced so handlers can still read it.
+func (v *HMACVerifier) Verify(r *http.Request) (string, error) {
+	keyID := r.Header.Get(HMACKeyIDHeader)
+	ts := r.Header.Get(HMACTimestampHeader)
+	contentHash := r.Header.Get(HMACContentHeader)
+	signature := r.Header.Get(HMACSignatureHeader)
+	if keyID == "" || ts == "" || contentHash == "" || signature == "" {
+		return "", ErrMissingSignature
+	}
+
+	algo, sig, ok := strings.Cut(signature, "=")
+	newHash, known := HMACAlgorithm(algo).hash()
+	secret, hasKey := v.Keys[keyID]
+	if !ok || !known || !hasKey {
+		return "", ErrInvalidSignature
+	}
+
+	unix, err := strconv.ParseInt(ts, 10, 64)
+	if err != nil {
+		return "", ErrInvalidSignature
+	}
+	tolerance := v.Tolerance
+	if tolerance <= 0 {
+		tolerance = DefaultHMACTolerance
+	}
+	now := time.Now
+	if v.Now != nil {
+		now = v.Now
+	}
+	if age := now().Sub(time.Unix(unix, 0)); age > tolerance || age < -tolerance {
+		return "", ErrExpiredSignature
+	}
+
+	if contentHash == UnsignedPayload {
+		if !v.AllowUnsignedPayload {
+			return "", ErrInvalidSignature
+		}
+	} else if err := v.checkBody(r, contentHash); err != nil {
+		return "", err
+	}
+
+	expected := computeHMAC(newHash, secret, r.Method, r.URL.RequestURI(), ts, contentHash)
+	if !hmac.Equal([]byte(sig), []byte(expected)) {
+		return "", ErrInvalidSignature
+	}
+	return keyID, nil
+}
+
+// checkBody reads the body, compares its hash and restores it
+func (v *HMACVerifier) checkBody(r *http.Request, contentHash string) error {
+	var body []byte
+	if r.Body != nil && r.Body != http.NoBody {
+		limit := v.MaxBodyBytes
+		if limit <= 0 {
+			limit = 10 << 20
+		}
+		var err error
+		body, err = io.ReadAll(io.LimitReader(r.Body, limit+1))
+		r.Body.Close()
+		if err != nil {
+			return fmt.Errorf("failed to read body: %w", err)
+		}
+		if int64(len(body)) > limit {
+			return fmt.Errorf("%w: body larger than %d bytes", ErrInvalidSignature, limit)
+		}
+		r.Body = io.NopCloser(bytes.NewReader(body))
+	}
+
+	if !hmac.Equal([]byte(hashBytes(body)), []byte(contentHash)) {
+		return ErrInvalidSignature
+	}
+	return nil
+}
+
+// Middleware rejects requests without a valid signature with a 401 api error
+// envelope. The verified key ID is available to handlers through HMACKeyID.
+func (v *HMACVerifier) Middleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		keyID, err := v.Verify(r)
+		if err != nil {
+			api.WriteError(w, api.UnauthorizedError(err))
+			return
+		}
+		ctx := context.WithValue(r.Context(), hmacKeyIDKey{}, keyID)
+		next.ServeHTTP(w, r.WithContext(ctx))
+	})
+}
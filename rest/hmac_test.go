@@ -0,0 +1,159 @@
+package rest
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestHMAC_RoundTrip(t *testing.T) {
+	verifier := NewHMACVerifier(map[string][]byte{"orders": []byte("s3cret")})
+
+	var gotKey, gotBody string
+	server := httptest.NewServer(verifier.Middleware(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotKey, _ = HMACKeyID(r.Context())
+		body, _ := io.ReadAll(r.Body)
+		gotBody = string(body)
+		w.Write([]byte(`{}`))
+	})))
+	defer server.Close()
+
+	for _, algo := range []HMACAlgorithm{"", HMACSHA256, HMACSHA512} {
+		t.Run(string(algo), func(t *testing.T) {
+			client, _ := NewClient(WithBaseURL(server.URL), WithHMACSigner("orders", []byte("s3cret"), algo))
+
+			if err := client.Post(context.Background(), "/v1/orders?dry_run=true", map[string]int{"qty": 2}, nil); err != nil {
+				t.Fatalf("Post() error = %v", err)
+			}
+			if gotKey != "orders" {
+				t.Errorf("Expected key ID orders, got %q", gotKey)
+			}
+			if gotBody != `{"qty":2}` {
+				t.Errorf("Expected handler to read the body, got %q", gotBody)
+			}
+
+			if err := client.Get(context.Background(), "/v1/orders", nil); err != nil {
+				t.Errorf("Get() error = %v", err)
+			}
+		})
+	}
+
+	t.Run("wrong secret", func(t *testing.T) {
+		client, _ := NewClient(WithBaseURL(server.URL), WithRetries(0), WithHMACSigner("orders", []byte("wrong"), HMACSHA256))
+		err := client.Get(context.Background(), "/v1/orders", nil)
+		if !errors.Is(err, ErrUnauthorized) {
+			t.Errorf("Expected ErrUnauthorized, got %v", err)
+		}
+	})
+}
+
+func TestHMACVerifier_Verify(t *testing.T) {
+	now := time.Unix(1700000000, 0)
+	signer := &HMACSigner{KeyID: "k1", Secret: []byte("s3cret"), Algorithm: HMACSHA256, Now: func() time.Time { return now }}
+	verifier := &HMACVerifier{
+		Keys: map[string][]byte{"k1": []byte("s3cret")},
+		Now:  func() time.Time { return now.Add(time.Minute) },
+	}
+
+	// signed signs a client request and returns it as the server receives it
+	signed := func(method, target, body string) *http.Request {
+		var clientReq *http.Request
+		if body == "" {
+			clientReq, _ = http.NewRequest(method, "http://example.com"+target, nil)
+		} else {
+			clientReq, _ = http.NewRequest(method, "http://example.com"+target, strings.NewReader(body))
+		}
+		if err := signer.Sign(clientReq); err != nil {
+			t.Fatalf("Sign() error = %v", err)
+		}
+
+		req := httptest.NewRequest(method, target, strings.NewReader(body))
+		req.Header = clientReq.Header
+		return req
+	}
+
+	tests := []struct {
+		name    string
+		req     func() *http.Request
+		wantErr error
+	}{
+		{"valid", func() *http.Request { return signed("POST", "/orders?a=1", `{"qty":1}`) }, nil},
+		{"valid without body", func() *http.Request { return signed("GET", "/orders", "") }, nil},
+		{"missing headers", func() *http.Request { return httptest.NewRequest("GET", "/orders", nil) }, ErrMissingSignature},
+		{"tampered body", func() *http.Request {
+			req := signed("POST", "/orders", `{"qty":1}`)
+			req.Body = io.NopCloser(strings.NewReader(`{"qty":9}`))
+			return req
+		}, ErrInvalidSignature},
+		{"tampered path", func() *http.Request {
+			req := signed("GET", "/orders?a=1", "")
+			req.URL.RawQuery = "a=2"
+			return req
+		}, ErrInvalidSignature},
+		{"tampered method", func() *http.Request {
+			req := signed("GET", "/orders", "")
+			req.Method = "DELETE"
+			return req
+		}, ErrInvalidSignature},
+		{"unknown key", func() *http.Request {
+			req := signed("GET", "/orders", "")
+			req.Header.Set(HMACKeyIDHeader, "k2")
+			return req
+		}, ErrInvalidSignature},
+		{"unknown algorithm", func() *http.Request {
+			req := signed("GET", "/orders", "")
+			req.Header.Set(HMACSignatureHeader, "md5=abc")
+			return req
+		}, ErrInvalidSignature},
+		{"expired", func() *http.Request {
+			req := signed("GET", "/orders", "")
+			req.Header.Set(HMACTimestampHeader, "1699999000")
+			return req
+		}, ErrExpiredSignature},
+		{"unsigned payload rejected", func() *http.Request {
+			req := signed("POST", "/orders", `{}`)
+			req.Header.Set(HMACContentHeader, UnsignedPayload)
+			return req
+		}, ErrInvalidSignature},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			_, err := verifier.Verify(tc.req())
+			if !errors.Is(err, tc.wantErr) {
+				t.Errorf("Expected %v, got %v", tc.wantErr, err)
+			}
+		})
+	}
+}
+
+func TestHMACSigner_UnsignedPayload(t *testing.T) {
+	signer := NewHMACSigner("k1", []byte("s3cret"), HMACSHA256)
+	req, _ := http.NewRequest(http.MethodPost, "http://example.com/upload", io.NopCloser(strings.NewReader("data")))
+
+	if err := signer.Sign(req); err != nil {
+		t.Fatalf("Sign() error = %v", err)
+	}
+	if got := req.Header.Get(HMACContentHeader); got != UnsignedPayload {
+		t.Errorf("Expected %s for a streamed body, got %q", UnsignedPayload, got)
+	}
+
+	serverReq := httptest.NewRequest(http.MethodPost, "/upload", strings.NewReader("data"))
+	serverReq.Header = req.Header
+
+	verifier := NewHMACVerifier(map[string][]byte{"k1": []byte("s3cret")})
+	verifier.AllowUnsignedPayload = true
+	if _, err := verifier.Verify(serverReq); err != nil {
+		t.Errorf("Expected unsigned payload to be accepted, got %v", err)
+	}
+
+	signer.Algorithm = "md5"
+	if err := signer.Sign(req); err == nil {
+		t.Error("Expected error for unsupported algorithm")
+	}
+}
@@ -0,0 +1,35 @@
+package rest
+
+import "net/http"
+
+// RequestInterceptor inspects or mutates an outgoing request. Returning an
+// error aborts the request.
+type RequestInterceptor func(req *http.Request) error
+
+// ResponseInterceptor inspects a response before the client handles it.
+// Returning an error aborts the request and closes the body.
+type ResponseInterceptor func(resp *http.Response) error
+
+// Middleware wraps the client's transport, for concerns that need to see
+// both the request and the response of every attempt, such as tracing or
+// metrics.
+type Middleware func(next http.RoundTripper) http.RoundTripper
+
+// RoundTripperFunc adapts a function to the http.RoundTripper interface
+type RoundTripperFunc func(req *http.Request) (*http.Response, error)
+
+// RoundTrip calls f(req)
+func (f RoundTripperFunc) RoundTrip(req *http.Request) (*http.Response, error) {
+	return f(req)
+}
+
+// chainMiddleware wraps base so the first middleware is the outermost
+func chainMiddleware(base http.RoundTripper, middleware []Middleware) http.RoundTripper {
+	if base == nil {
+		base = http.DefaultTransport
+	}
+	for i := len(middleware) - 1; i >= 0; i-- {
+		base = middleware[i](base)
+	}
+	return base
+}
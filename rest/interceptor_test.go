@@ -0,0 +1,135 @@
+package rest
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/StairSupplies/go-core/logger"
+)
+
+func TestClientInterceptors(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("X-Trace-Id", r.Header.Get("X-Trace-Id"))
+		w.Write([]byte(`{"ok":true}`))
+	}))
+	defer server.Close()
+
+	var order []string
+	var traced string
+	client, err := NewClient(
+		WithBaseURL(server.URL),
+		WithLogger(logger.NewNopLogger()),
+		WithRequestInterceptor(func(req *http.Request) error {
+			order = append(order, "first")
+			req.Header.Set("X-Trace-Id", "trace-1")
+			return nil
+		}),
+		WithRequestInterceptor(func(req *http.Request) error {
+			order = append(order, "second:"+req.Header.Get("X-Trace-Id"))
+			return nil
+		}),
+		WithResponseInterceptor(func(resp *http.Response) error {
+			traced = resp.Header.Get("X-Trace-Id")
+			return nil
+		}),
+	)
+	if err != nil {
+		t.Fatalf("NewClient() error = %v", err)
+	}
+
+	if err := client.Get(context.Background(), "/", nil); err != nil {
+		t.Fatalf("Get() error = %v", err)
+	}
+	if strings.Join(order, ",") != "first,second:trace-1" {
+		t.Errorf("Unexpected interceptor order %v", order)
+	}
+	if traced != "trace-1" {
+		t.Errorf("Expected response interceptor to see trace-1, got %q", traced)
+	}
+}
+
+func TestClientInterceptors_Errors(t *testing.T) {
+	calls := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls++
+	}))
+	defer server.Close()
+
+	errBlocked := errors.New("blocked")
+
+	t.Run("request interceptor", func(t *testing.T) {
+		client, _ := NewClient(
+			WithBaseURL(server.URL),
+			WithLogger(logger.NewNopLogger()),
+			WithRequestInterceptor(func(*http.Request) error { return errBlocked }),
+		)
+		err := client.Get(context.Background(), "/", nil)
+		if !errors.Is(err, errBlocked) {
+			t.Errorf("Expected errBlocked, got %v", err)
+		}
+		if calls != 0 {
+			t.Errorf("Expected no request to be sent, got %d", calls)
+		}
+	})
+
+	t.Run("response interceptor", func(t *testing.T) {
+		client, _ := NewClient(
+			WithBaseURL(server.URL),
+			WithLogger(logger.NewNopLogger()),
+			WithResponseInterceptor(func(*http.Response) error { return errBlocked }),
+		)
+		err := client.Get(context.Background(), "/", nil)
+		if !errors.Is(err, errBlocked) {
+			t.Errorf("Expected errBlocked, got %v", err)
+		}
+	})
+}
+
+func TestClientMiddleware(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(strings.Join(r.Header.Values("X-Layers"), ",")))
+	}))
+	defer server.Close()
+
+	layer := func(name string) Middleware {
+		return func(next http.RoundTripper) http.RoundTripper {
+			return RoundTripperFunc(func(req *http.Request) (*http.Response, error) {
+				req.Header.Add("X-Layers", name)
+				return next.RoundTrip(req)
+			})
+		}
+	}
+
+	original := &http.Client{}
+	client, err := NewClient(
+		WithBaseURL(server.URL),
+		WithLogger(logger.NewNopLogger()),
+		WithMiddleware(layer("outer"), layer("inner")),
+		WithHTTPClient(original),
+	)
+	if err != nil {
+		t.Fatalf("NewClient() error = %v", err)
+	}
+	if original.Transport != nil {
+		t.Error("Expected the caller's http.Client not to be modified")
+	}
+
+	resp, err := client.GetStream(context.Background(), "/")
+	if err != nil {
+		t.Fatalf("GetStream() error = %v", err)
+	}
+	defer resp.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		t.Fatalf("Failed to read body: %v", err)
+	}
+	if string(body) != "outer,inner" {
+		t.Errorf("Expected outermost layer first, got %q", body)
+	}
+}
@@ -0,0 +1,56 @@
+package rest
+
+import (
+	"context"
+	"net/url"
+	"time"
+)
+
+// RequestInfo identifies an outgoing request to a MetricsCollector
+type RequestInfo struct {
+	Method string
+	Host   string
+	// Path is the route template set with WithPathTemplate, such as
+	// "/orders/{id}", or the request path if none was set
+	Path string
+}
+
+// MetricsCollector receives events for each logical request made by a
+// Client. A logical request spans all of its retry attempts. Implementations
+// must be safe for concurrent use.
+type MetricsCollector interface {
+	// RequestStarted is called before the first attempt
+	RequestStarted(info RequestInfo)
+	// RequestRetried is called before each retry with the number of the
+	// attempt that failed, starting at 1, and its status or error
+	RequestRetried(info RequestInfo, attempt int, status int, err error)
+	// RequestFinished is called once the request completes. Status is 0 when
+	// no response was received, in which case err is set.
+	RequestFinished(info RequestInfo, status int, duration time.Duration, err error)
+}
+
+// pathTemplateKey is the context key for WithPathTemplate
+type pathTemplateKey struct{}
+
+// WithPathTemplate returns a context that reports requests to the client's
+// MetricsCollector under template instead of the request path. Use it for
+// paths containing IDs to keep metric cardinality low:
+//
+//	ctx = rest.WithPathTemplate(ctx, "/orders/{id}")
+//	err := client.Get(ctx, "/orders/"+id, &order)
+func WithPathTemplate(ctx context.Context, template string) context.Context {
+	return context.WithValue(ctx, pathTemplateKey{}, template)
+}
+
+// newRequestInfo describes a request to rawURL for metrics
+func newRequestInfo(ctx context.Context, method, rawURL string) RequestInfo {
+	info := RequestInfo{Method: method}
+	if u, err := url.Parse(rawURL); err == nil {
+		info.Host = u.Host
+		info.Path = u.Path
+	}
+	if template, ok := ctx.Value(pathTemplateKey{}).(string); ok && template != "" {
+		info.Path = template
+	}
+	return info
+}
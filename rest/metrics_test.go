@@ -0,0 +1,102 @@
+package rest
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// recordingMetrics records MetricsCollector events as strings
+type recordingMetrics struct {
+	mu     sync.Mutex
+	events []string
+	infos  []RequestInfo
+}
+
+func (m *recordingMetrics) record(info RequestInfo, event string) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.events = append(m.events, event)
+	m.infos = append(m.infos, info)
+}
+
+func (m *recordingMetrics) RequestStarted(info RequestInfo) {
+	m.record(info, "started")
+}
+
+func (m *recordingMetrics) RequestRetried(info RequestInfo, attempt int, status int, err error) {
+	m.record(info, "retried "+strconv.Itoa(attempt)+" "+strconv.Itoa(status))
+}
+
+func (m *recordingMetrics) RequestFinished(info RequestInfo, status int, duration time.Duration, err error) {
+	event := "finished " + strconv.Itoa(status)
+	if err != nil {
+		event += " error"
+	}
+	m.record(info, event)
+}
+
+func TestClient_Metrics(t *testing.T) {
+	var attempts atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if attempts.Add(1) == 1 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		w.Write([]byte(`{}`))
+	}))
+	defer server.Close()
+
+	metrics := &recordingMetrics{}
+	client, _ := NewClient(
+		WithBaseURL(server.URL),
+		WithRetryPolicy(fastRetryPolicy()),
+		WithMetrics(metrics),
+	)
+
+	ctx := WithPathTemplate(context.Background(), "/orders/{id}")
+	if err := client.Get(ctx, "/orders/42", nil); err != nil {
+		t.Fatalf("Get() error = %v", err)
+	}
+
+	want := []string{"started", "retried 1 503", "finished 200"}
+	if strings.Join(metrics.events, ",") != strings.Join(want, ",") {
+		t.Errorf("Expected events %v, got %v", want, metrics.events)
+	}
+
+	host := strings.TrimPrefix(server.URL, "http://")
+	for _, info := range metrics.infos {
+		if info != (RequestInfo{Method: http.MethodGet, Host: host, Path: "/orders/{id}"}) {
+			t.Errorf("Unexpected request info %+v", info)
+		}
+	}
+}
+
+func TestClient_Metrics_Error(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	url := server.URL
+	server.Close()
+
+	metrics := &recordingMetrics{}
+	policy := fastRetryPolicy()
+	policy.MaxAttempts = 1
+	client, _ := NewClient(WithBaseURL(url), WithRetryPolicy(policy), WithMetrics(metrics))
+
+	if err := client.Get(context.Background(), "/items", nil); err == nil {
+		t.Fatal("Expected connection error")
+	}
+
+	want := []string{"started", "finished 0 error"}
+	if strings.Join(metrics.events, ",") != strings.Join(want, ",") {
+		t.Errorf("Expected events %v, got %v", want, metrics.events)
+	}
+	if metrics.infos[0].Path != "/items" {
+		t.Errorf("Expected request path without a template, got %q", metrics.infos[0].Path)
+	}
+}
@@ -0,0 +1,98 @@
+package rest
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"mime/multipart"
+	"net/http"
+	"net/textproto"
+	"sort"
+	"strings"
+)
+
+// File is a file part of a multipart/form-data request
+type File struct {
+	// FieldName is the form field the file is sent as
+	FieldName string
+	// FileName is the file name reported to the server
+	FileName string
+	// ContentType defaults to application/octet-stream
+	ContentType string
+	// Reader supplies the file contents. It is read once and not closed.
+	Reader io.Reader
+}
+
+// quoteEscaper escapes a value for a quoted MIME header parameter
+var quoteEscaper = strings.NewReplacer("\\", "\\\\", `"`, "\\\"")
+
+// PostMultipart sends fields and files as a multipart/form-data POST and
+// decodes the JSON response into response. Files are streamed to the server
+// as they are read rather than buffered in memory, so the request is never
+// retried.
+func (c *Client) PostMultipart(ctx context.Context, path string, fields map[string]string, files []File, response interface{}) error {
+	body := multipartPayload(fields, files)
+	resp, err := c.doPayload(ctx, http.MethodPost, path, body, "application/json")
+	if err != nil {
+		return err
+	}
+	return c.decodeResponse(resp, response)
+}
+
+// multipartPayload encodes fields and files through a pipe as the transport
+// reads the request body
+func multipartPayload(fields map[string]string, files []File) *payload {
+	pr, pw := io.Pipe()
+	mw := multipart.NewWriter(pw)
+
+	return &payload{
+		contentType: mw.FormDataContentType(),
+		once:        true,
+		open: func() (io.Reader, error) {
+			go func() {
+				pw.CloseWithError(writeMultipart(mw, fields, files))
+			}()
+			return pr, nil
+		},
+	}
+}
+
+// writeMultipart writes fields in key order, then files in the order given
+func writeMultipart(mw *multipart.Writer, fields map[string]string, files []File) error {
+	keys := make([]string, 0, len(fields))
+	for k := range fields {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	for _, k := range keys {
+		if err := mw.WriteField(k, fields[k]); err != nil {
+			return err
+		}
+	}
+
+	for _, f := range files {
+		if f.Reader == nil {
+			return fmt.Errorf("file %q has no reader", f.FieldName)
+		}
+		contentType := f.ContentType
+		if contentType == "" {
+			contentType = "application/octet-stream"
+		}
+
+		h := make(textproto.MIMEHeader)
+		h.Set("Content-Disposition", fmt.Sprintf(`form-data; name="%s"; filename="%s"`,
+			quoteEscaper.Replace(f.FieldName), quoteEscaper.Replace(f.FileName)))
+		h.Set("Content-Type", contentType)
+
+		part, err := mw.CreatePart(h)
+		if err != nil {
+			return err
+		}
+		if _, err := io.Copy(part, f.Reader); err != nil {
+			return fmt.Errorf("failed to read file %q: %w", f.FileName, err)
+		}
+	}
+
+	return mw.Close()
+}
@@ -0,0 +1,117 @@
+package rest
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+)
+
+func TestClient_PostMultipart(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if err := r.ParseMultipartForm(1 << 20); err != nil {
+			t.Errorf("ParseMultipartForm() error = %v", err)
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+
+		if got := r.FormValue("order_id"); got != "42" {
+			t.Errorf("Expected order_id 42, got %q", got)
+		}
+
+		file, header, err := r.FormFile("drawing")
+		if err != nil {
+			t.Errorf("FormFile() error = %v", err)
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		defer file.Close()
+		data, _ := io.ReadAll(file)
+
+		if header.Filename != `stair "A".pdf` {
+			t.Errorf("Expected filename to round-trip, got %q", header.Filename)
+		}
+		if ct := header.Header.Get("Content-Type"); ct != "application/pdf" {
+			t.Errorf("Expected Content-Type application/pdf, got %q", ct)
+		}
+
+		notes, notesHeader, _ := r.FormFile("notes")
+		if notesHeader.Header.Get("Content-Type") != "application/octet-stream" {
+			t.Errorf("Expected default content type, got %q", notesHeader.Header.Get("Content-Type"))
+		}
+		notes.Close()
+
+		fmt.Fprintf(w, `{"size":%d}`, len(data))
+	}))
+	defer server.Close()
+
+	client, _ := NewClient(WithBaseURL(server.URL))
+
+	var resp map[string]int
+	err := client.PostMultipart(context.Background(), "/uploads",
+		map[string]string{"order_id": "42"},
+		[]File{
+			{FieldName: "drawing", FileName: `stair "A".pdf`, ContentType: "application/pdf", Reader: strings.NewReader("%PDF")},
+			{FieldName: "notes", FileName: "notes.txt", Reader: strings.NewReader("n")},
+		},
+		&resp,
+	)
+	if err != nil {
+		t.Fatalf("PostMultipart() error = %v", err)
+	}
+	if resp["size"] != 4 {
+		t.Errorf("Expected size 4, got %v", resp)
+	}
+}
+
+// failingReader fails after returning some data
+type failingReader struct{ read bool }
+
+func (r *failingReader) Read(p []byte) (int, error) {
+	if r.read {
+		return 0, errors.New("disk error")
+	}
+	r.read = true
+	return copy(p, "partial"), nil
+}
+
+func TestClient_PostMultipartReadError(t *testing.T) {
+	var attempts atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		attempts.Add(1)
+		io.Copy(io.Discard, r.Body)
+		w.WriteHeader(http.StatusBadRequest)
+	}))
+	defer server.Close()
+
+	client, _ := NewClient(WithBaseURL(server.URL))
+	err := client.PostMultipart(context.Background(), "/uploads", nil,
+		[]File{{FieldName: "f", FileName: "f.bin", Reader: &failingReader{}}}, nil)
+
+	if err == nil {
+		t.Fatal("Expected an error")
+	}
+	if n := attempts.Load(); n > 1 {
+		t.Errorf("Expected streamed upload not to be retried, got %d attempts", n)
+	}
+}
+
+func TestClient_PostMultipartAuthFailureReleasesPipe(t *testing.T) {
+	client, _ := NewClient(
+		WithBaseURL("http://127.0.0.1:1"),
+		WithAuthProvider(AuthProviderFunc(func(ctx context.Context, req *http.Request) error {
+			return errors.New("no token")
+		})),
+	)
+
+	err := client.PostMultipart(context.Background(), "/uploads", map[string]string{"a": "b"},
+		[]File{{FieldName: "f", FileName: "f.bin", Reader: strings.NewReader(strings.Repeat("x", 1<<20))}}, nil)
+	if !errors.Is(err, ErrUnauthorized) {
+		t.Errorf("Expected ErrUnauthorized, got %v", err)
+	}
+}
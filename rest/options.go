@@ -2,11 +2,13 @@ package rest
 
 import (
 	"net/http"
+	"net/http/cookiejar"
 	"reflect"
 	"runtime"
 	"strings"
 	"time"
 
+	"github.com/StairSupplies/go-core/ctxutils"
 	"github.com/StairSupplies/go-core/logger"
 )
 
@@ -64,6 +66,44 @@ func WithHTTPClient(httpClient *http.Client) ClientOption {
 	}, "WithHTTPClient")
 }
 
+// WithTransportOptions replaces the connection pool settings of the
+// transport NewClient creates. Pool options have no effect when WithHTTPClient
+// supplies a client with its own Transport.
+func WithTransportOptions(opts TransportOptions) ClientOption {
+	return registerOption(func(c *Client) {
+		c.transport = opts
+	}, "WithTransportOptions")
+}
+
+// WithMaxIdleConnsPerHost sets how many idle connections are kept for each host
+func WithMaxIdleConnsPerHost(n int) ClientOption {
+	return registerOption(func(c *Client) {
+		c.transport.MaxIdleConnsPerHost = n
+	}, "WithMaxIdleConnsPerHost")
+}
+
+// WithMaxConnsPerHost limits the connections to each host, including those in
+// use. Zero means no limit.
+func WithMaxConnsPerHost(n int) ClientOption {
+	return registerOption(func(c *Client) {
+		c.transport.MaxConnsPerHost = n
+	}, "WithMaxConnsPerHost")
+}
+
+// WithIdleConnTimeout sets how long idle connections are kept open
+func WithIdleConnTimeout(d time.Duration) ClientOption {
+	return registerOption(func(c *Client) {
+		c.transport.IdleConnTimeout = d
+	}, "WithIdleConnTimeout")
+}
+
+// WithHTTP2 enables or disables HTTP/2 negotiation. It is enabled by default.
+func WithHTTP2(enabled bool) ClientOption {
+	return registerOption(func(c *Client) {
+		c.transport.DisableHTTP2 = !enabled
+	}, "WithHTTP2")
+}
+
 // WithHeader adds a header to all requests
 func WithHeader(key, value string) ClientOption {
 	return registerOption(func(c *Client) {
@@ -100,3 +140,183 @@ func WithServiceName(name string) ClientOption {
 		c.ServiceName = name
 	}, "WithServiceName")
 }
+
+// WithRetries sets how many times a failed request is retried.
+// Zero disables retries.
+func WithRetries(retries int) ClientOption {
+	return registerOption(func(c *Client) {
+		c.Retries = retries
+		if c.RetryPolicy != nil {
+			c.RetryPolicy.MaxAttempts = retries + 1
+		}
+	}, "WithRetries")
+}
+
+// WithRetryPolicy sets the policy deciding which requests are retried and
+// how long to wait between attempts.
+func WithRetryPolicy(policy RetryPolicy) ClientOption {
+	return registerOption(func(c *Client) {
+		c.RetryPolicy = &policy
+	}, "WithRetryPolicy")
+}
+
+// WithAuthProvider sets the provider used to authenticate every request.
+func WithAuthProvider(provider AuthProvider) ClientOption {
+	return registerOption(func(c *Client) {
+		c.Auth = provider
+	}, "WithAuthProvider")
+}
+
+// WithRequestInterceptor adds an interceptor that runs on every outgoing request.
+func WithRequestInterceptor(interceptor RequestInterceptor) ClientOption {
+	return registerOption(func(c *Client) {
+		c.RequestInterceptors = append(c.RequestInterceptors, interceptor)
+	}, "WithRequestInterceptor")
+}
+
+// WithResponseInterceptor adds an interceptor that runs on every response.
+func WithResponseInterceptor(interceptor ResponseInterceptor) ClientOption {
+	return registerOption(func(c *Client) {
+		c.ResponseInterceptors = append(c.ResponseInterceptors, interceptor)
+	}, "WithResponseInterceptor")
+}
+
+// WithMiddleware wraps the HTTP transport. Middleware is applied when the
+// client is created, with the first registered middleware outermost.
+func WithMiddleware(middleware ...Middleware) ClientOption {
+	return registerOption(func(c *Client) {
+		c.middleware = append(c.middleware, middleware...)
+	}, "WithMiddleware")
+}
+
+// WithCache caches successful GET responses in store for ttl. Stale entries
+// with an ETag or Last-Modified header are revalidated with a conditional
+// request, so a ttl of 0 revalidates on every request.
+func WithCache(store CacheStore, ttl time.Duration) ClientOption {
+	return registerOption(func(c *Client) {
+		c.cache = &responseCache{store: store, ttl: ttl, now: time.Now}
+	}, "WithCache")
+}
+
+// WithMetrics reports every request to collector, such as a PrometheusMetrics
+func WithMetrics(collector MetricsCollector) ClientOption {
+	return registerOption(func(c *Client) {
+		c.Metrics = collector
+	}, "WithMetrics")
+}
+
+// WithEnvelopeMode unwraps api.SuccessResponse envelopes into the response
+// value and converts api error envelopes into a *ClientError carrying the
+// upstream status code, error code and message
+func WithEnvelopeMode() ClientOption {
+	return registerOption(func(c *Client) {
+		c.EnvelopeMode = true
+	}, "WithEnvelopeMode")
+}
+
+// WithHMACSigner signs every request with an HMACSigner, after
+// authentication and any earlier request interceptors have run
+func WithHMACSigner(keyID string, secret []byte, algo HMACAlgorithm) ClientOption {
+	return registerOption(func(c *Client) {
+		c.RequestInterceptors = append(c.RequestInterceptors, NewHMACSigner(keyID, secret, algo).Sign)
+	}, "WithHMACSigner")
+}
+
+// WithPropagateHeaders copies the named headers of the inbound request onto
+// every outgoing request, as stored in the request context by the router's
+// PropagateHeaders middleware. With no names it forwards
+// ctxutils.DefaultPropagatedHeaders: X-Request-Id, traceparent and
+// tracestate. Headers already set on the request are kept.
+func WithPropagateHeaders(names ...string) ClientOption {
+	return registerOption(func(c *Client) {
+		if len(names) == 0 {
+			names = ctxutils.DefaultPropagatedHeaders
+		}
+		c.propagateHeaders = append(c.propagateHeaders, names...)
+	}, "WithPropagateHeaders")
+}
+
+// WithProgress reports the transfer of every request and response body to
+// fn, for visibility into large uploads and downloads. The upload is
+// reported as the transport sends the request body and the download as the
+// response body is read. Use WithProgressContext for a single request.
+func WithProgress(fn ProgressFunc) ClientOption {
+	return registerOption(func(c *Client) {
+		c.progress = fn
+	}, "WithProgress")
+}
+
+// WithFallbackBaseURLs sets base URLs, such as a secondary region, that
+// idempotent requests are sent to in order when the base URL is down: when
+// a request gets no response, or a 5xx or 429 response, after its retries.
+// Requests with streamed bodies are never sent to a fallback.
+func WithFallbackBaseURLs(urls ...string) ClientOption {
+	return registerOption(func(c *Client) {
+		c.fallbackBaseURLs = append([]string(nil), urls...)
+	}, "WithFallbackBaseURLs")
+}
+
+// WithHedging races GET, HEAD and OPTIONS requests that are slow to answer:
+// if no response arrives within delay, the request is also sent to the next
+// fallback base URL, or again to the base URL if there are none, and the
+// first successful response wins. A copy that fails starts the next one
+// immediately. Hedging trades extra load on the servers for lower tail
+// latency. A delay of zero or less disables it.
+func WithHedging(delay time.Duration) ClientOption {
+	return registerOption(func(c *Client) {
+		c.hedgeDelay = delay
+	}, "WithHedging")
+}
+
+// WithRateLimit limits the client to rps requests per second, with bursts of
+// up to burst requests; burst defaults to rps rounded up. Requests over the
+// limit wait their turn, and a 429 response with Retry-After or an exhausted
+// X-RateLimit-Remaining holds back all requests until the server is ready.
+// Retries count against the limit. A rate of zero or less disables limiting.
+func WithRateLimit(rps float64, burst int) ClientOption {
+	return registerOption(func(c *Client) {
+		if rps <= 0 {
+			c.rateLimiter = nil
+			return
+		}
+		c.rateLimiter = newRateLimiter(rps, burst)
+	}, "WithRateLimit")
+}
+
+// WithCircuitBreaker stops sending requests to a host once threshold
+// requests to it have failed in a row, failing them at once with
+// ErrCircuitOpen. After cooldown one request is let through: the circuit
+// closes if it succeeds and stays open for another cooldown if it fails.
+// With fallback base URLs, requests move on to the next one while a circuit
+// is open. A threshold below 1 disables the breaker.
+func WithCircuitBreaker(threshold int, cooldown time.Duration) ClientOption {
+	return registerOption(func(c *Client) {
+		if threshold < 1 {
+			c.breaker = nil
+			return
+		}
+		c.breaker = &circuitBreaker{threshold: int64(threshold), cooldown: cooldown}
+	}, "WithCircuitBreaker")
+}
+
+// WithCookieJar stores cookies set by responses in jar and sends them on
+// later requests, for APIs that authenticate with a session cookie after a
+// login request. It overrides any Jar of a client passed to WithHTTPClient.
+func WithCookieJar(jar http.CookieJar) ClientOption {
+	return registerOption(func(c *Client) {
+		c.jar = jar
+	}, "WithCookieJar")
+}
+
+// WithSessionCookies gives the client its own in-memory cookie jar, so
+// session cookies last as long as the client:
+//
+//	client, _ := rest.NewClient(rest.WithBaseURL(partnerURL), rest.WithSessionCookies())
+//	err := client.Post(ctx, "/login", credentials, nil)
+//	// later requests carry the session cookie
+func WithSessionCookies() ClientOption {
+	return registerOption(func(c *Client) {
+		// cookiejar.New only fails for invalid options
+		c.jar, _ = cookiejar.New(nil)
+	}, "WithSessionCookies")
+}
@@ -97,6 +97,31 @@ func TestWithServiceName(t *testing.T) {
 	}
 }
 
+func TestWithAuthProvider(t *testing.T) {
+	client := &Client{}
+	provider := BearerToken("abc")
+
+	WithAuthProvider(provider)(client)
+
+	if client.Auth == nil {
+		t.Error("WithAuthProvider() did not set Auth")
+	}
+}
+
+func TestWithRetryPolicy(t *testing.T) {
+	client := &Client{}
+
+	WithRetryPolicy(RetryPolicy{MaxAttempts: 5})(client)
+	WithRetries(1)(client)
+
+	if client.RetryPolicy == nil || client.RetryPolicy.MaxAttempts != 2 {
+		t.Errorf("Expected MaxAttempts 2, got %+v", client.RetryPolicy)
+	}
+	if client.Retries != 1 {
+		t.Errorf("Expected Retries 1, got %d", client.Retries)
+	}
+}
+
 func TestOptionToString_MoreOptions(t *testing.T) {
 	// Additional tests for OptionToString beyond what's in client_test.go
 	tests := []struct {
@@ -108,6 +133,11 @@ func TestOptionToString_MoreOptions(t *testing.T) {
 		{"WithHeaders", WithHeaders(map[string]string{"key": "value"}), "WithHeaders"},
 		{"WithLogger", WithLogger(nil), "WithLogger"},
 		{"WithServiceName", WithServiceName("test"), "WithServiceName"},
+		{"WithAuthProvider", WithAuthProvider(nil), "WithAuthProvider"},
+		{"WithRetries", WithRetries(2), "WithRetries"},
+		{"WithRetryPolicy", WithRetryPolicy(RetryPolicy{}), "WithRetryPolicy"},
+		{"WithCookieJar", WithCookieJar(nil), "WithCookieJar"},
+		{"WithSessionCookies", WithSessionCookies(), "WithSessionCookies"},
 	}
 
 	for _, tt := range tests {
@@ -0,0 +1,85 @@
+package rest
+
+import (
+	"context"
+	"io"
+	"net/http"
+)
+
+// ProgressFunc is called as a request or response body is transferred with
+// the number of bytes transferred so far and the body's total size, or -1
+// when the size is not known. It is called after every read, possibly from
+// a transport goroutine, so it should be cheap and safe for concurrent use.
+type ProgressFunc func(transferred, total int64)
+
+// progressKey is the context key for WithProgressContext
+type progressKey struct{}
+
+// progressFuncs are the callbacks for one request's upload and download
+type progressFuncs struct {
+	upload   ProgressFunc
+	download ProgressFunc
+}
+
+// WithProgressContext returns a context that reports the upload of request
+// bodies to upload and the download of response bodies to download,
+// overriding the client's WithProgress callback for requests made with it.
+// Either may be nil. Uploads start again from zero if a request is retried.
+//
+//	ctx = rest.WithProgressContext(ctx, func(n, total int64) {
+//		log.Infof("Uploaded %d of %d bytes", n, total)
+//	}, nil)
+//	err := client.PostMultipart(ctx, "/inventory", nil, files, &result)
+func WithProgressContext(ctx context.Context, upload, download ProgressFunc) context.Context {
+	return context.WithValue(ctx, progressKey{}, progressFuncs{upload: upload, download: download})
+}
+
+// progressFor returns the progress callbacks for a request made with ctx
+func (c *Client) progressFor(ctx context.Context) progressFuncs {
+	if p, ok := ctx.Value(progressKey{}).(progressFuncs); ok {
+		return p
+	}
+	return progressFuncs{upload: c.progress, download: c.progress}
+}
+
+// trackUpload reports the reading of req's body to fn
+func trackUpload(req *http.Request, fn ProgressFunc) {
+	if fn == nil || req.Body == nil || req.Body == http.NoBody {
+		return
+	}
+	total := req.ContentLength
+	if total <= 0 {
+		total = -1
+	}
+	req.Body = &progressReader{ReadCloser: req.Body, total: total, fn: fn}
+}
+
+// trackDownload reports the reading of resp's body to fn
+func trackDownload(resp *http.Response, fn ProgressFunc) {
+	if fn == nil || resp.Body == nil || resp.Body == http.NoBody {
+		return
+	}
+	total := resp.ContentLength
+	if total < 0 {
+		total = -1
+	}
+	resp.Body = &progressReader{ReadCloser: resp.Body, total: total, fn: fn}
+}
+
+// progressReader counts the bytes read from a body and reports them to fn
+type progressReader struct {
+	io.ReadCloser
+	total int64
+	read  int64
+	fn    ProgressFunc
+}
+
+// Read implements io.Reader
+func (r *progressReader) Read(p []byte) (int, error) {
+	n, err := r.ReadCloser.Read(p)
+	if n > 0 {
+		r.read += int64(n)
+		r.fn(r.read, r.total)
+	}
+	return n, err
+}
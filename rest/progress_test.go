@@ -0,0 +1,133 @@
+package rest
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/StairSupplies/go-core/logger"
+)
+
+// progressLog records the calls made to a ProgressFunc
+type progressLog struct {
+	mu    sync.Mutex
+	calls [][2]int64
+}
+
+func (p *progressLog) record(transferred, total int64) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.calls = append(p.calls, [2]int64{transferred, total})
+}
+
+func (p *progressLog) reset() {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.calls = nil
+}
+
+func (p *progressLog) last() [2]int64 {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if len(p.calls) == 0 {
+		return [2]int64{}
+	}
+	return p.calls[len(p.calls)-1]
+}
+
+func TestWithProgress(t *testing.T) {
+	download := strings.Repeat("x", 100000)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		io.Copy(io.Discard, r.Body)
+		w.Header().Set("Content-Length", strconv.Itoa(len(download)))
+		w.Write([]byte(download))
+	}))
+	defer server.Close()
+
+	var progress progressLog
+	client, _ := NewClient(WithBaseURL(server.URL), WithLogger(logger.NewNopLogger()), WithProgress(progress.record))
+
+	upload := bytes.Repeat([]byte("y"), 50000)
+	stream, err := client.Stream(context.Background(), http.MethodPost, "/inventory", string(upload))
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	// The JSON encoded upload is the string plus two quotes
+	uploadSize := int64(len(upload) + 2)
+	if got := progress.last(); got != [2]int64{uploadSize, uploadSize} {
+		t.Errorf("Expected upload progress %d of %d, got %v", uploadSize, uploadSize, got)
+	}
+
+	progress.reset()
+	body, _ := io.ReadAll(stream.Body)
+	stream.Close()
+	if len(body) != len(download) {
+		t.Fatalf("Expected %d bytes, got %d", len(download), len(body))
+	}
+	if len(progress.calls) < 2 {
+		t.Errorf("Expected several download progress calls, got %d", len(progress.calls))
+	}
+	want := int64(len(download))
+	if got := progress.last(); got != [2]int64{want, want} {
+		t.Errorf("Expected download progress %d of %d, got %v", want, want, got)
+	}
+	for i := 1; i < len(progress.calls); i++ {
+		if progress.calls[i][0] <= progress.calls[i-1][0] {
+			t.Errorf("Expected increasing progress, got %v", progress.calls)
+			break
+		}
+	}
+}
+
+func TestWithProgress_UnknownLength(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		io.Copy(io.Discard, r.Body)
+		w.Write([]byte(`{}`))
+	}))
+	defer server.Close()
+
+	var progress progressLog
+	client, _ := NewClient(WithBaseURL(server.URL), WithLogger(logger.NewNopLogger()), WithProgress(progress.record))
+
+	files := []File{{FieldName: "file", FileName: "inventory.csv", Reader: strings.NewReader("sku,qty\nA1,5\n")}}
+	if err := client.PostMultipart(context.Background(), "/inventory", nil, files, nil); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	if len(progress.calls) == 0 {
+		t.Fatal("Expected upload progress")
+	}
+	if total := progress.calls[0][1]; total != -1 {
+		t.Errorf("Expected unknown total -1 for a streamed upload, got %d", total)
+	}
+}
+
+func TestWithProgressContext(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"ok":true}`))
+	}))
+	defer server.Close()
+
+	var clientProgress, downloads progressLog
+	client, _ := NewClient(WithBaseURL(server.URL), WithLogger(logger.NewNopLogger()), WithProgress(clientProgress.record))
+
+	ctx := WithProgressContext(context.Background(), nil, downloads.record)
+	var out map[string]bool
+	if err := client.Get(ctx, "/status", &out); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	if len(clientProgress.calls) != 0 {
+		t.Errorf("Expected the context to override the client callback, got %v", clientProgress.calls)
+	}
+	if got := downloads.last(); got != [2]int64{11, 11} {
+		t.Errorf("Expected download progress 11 of 11, got %v", got)
+	}
+}
@@ -0,0 +1,298 @@
+package rest
+
+import (
+	"fmt"
+	"io"
+	"math"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// DefaultLatencyBuckets are the histogram buckets, in seconds, used by
+// NewPrometheusMetrics when none are given
+var DefaultLatencyBuckets = []float64{0.005, 0.01, 0.025, 0.05, 0.1, 0.25, 0.5, 1, 2.5, 5, 10}
+
+// PrometheusMetrics is a MetricsCollector that exposes client metrics in the
+// Prometheus text format. Serve it on a metrics endpoint:
+//
+//	metrics := rest.NewPrometheusMetrics("orders")
+//	client, _ := rest.NewClient(rest.WithMetrics(metrics))
+//	router.Handle("/metrics", metrics)
+//
+// It records:
+//
+//   - <namespace>_http_client_requests_total{method,host,path,status}
+//   - <namespace>_http_client_request_duration_seconds{method,host,path}
+//   - <namespace>_http_client_retries_total{method,host,path}
+//   - <namespace>_http_client_requests_in_flight{method,host}
+//
+// The status label is "error" for requests that received no response.
+// Clients added with AddClient also export their Stats.
+type PrometheusMetrics struct {
+	namespace string
+	buckets   []float64
+
+	mu        sync.Mutex
+	requests  map[requestKey]float64
+	durations map[RequestInfo]*histogram
+	retries   map[RequestInfo]float64
+	inFlight  map[RequestInfo]float64
+	clients   map[string]*Client
+}
+
+// requestKey labels the requests counter
+type requestKey struct {
+	RequestInfo
+	status string
+}
+
+// histogram holds cumulative bucket counts for one label set
+type histogram struct {
+	counts []uint64
+	count  uint64
+	sum    float64
+}
+
+// NewPrometheusMetrics creates a PrometheusMetrics whose metric names are
+// prefixed with namespace, if not empty. Buckets default to
+// DefaultLatencyBuckets.
+func NewPrometheusMetrics(namespace string, buckets ...float64) *PrometheusMetrics {
+	if len(buckets) == 0 {
+		buckets = DefaultLatencyBuckets
+	}
+	buckets = append([]float64(nil), buckets...)
+	sort.Float64s(buckets)
+
+	return &PrometheusMetrics{
+		namespace: namespace,
+		buckets:   buckets,
+		requests:  make(map[requestKey]float64),
+		durations: make(map[RequestInfo]*histogram),
+		retries:   make(map[RequestInfo]float64),
+		inFlight:  make(map[RequestInfo]float64),
+		clients:   make(map[string]*Client),
+	}
+}
+
+// AddClient exports the Stats of c under the client label name, adding:
+//
+//   - <namespace>_http_client_attempts_total{client,host}
+//   - <namespace>_http_client_failures_total{client,host}
+//   - <namespace>_http_client_consecutive_failures{client,host}
+//   - <namespace>_http_client_backoff_seconds_total{client,host}
+//   - <namespace>_http_client_rate_limit_wait_seconds_total{client}
+//
+// Adding another client under the same name replaces it.
+func (m *PrometheusMetrics) AddClient(name string, c *Client) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.clients[name] = c
+}
+
+// RequestStarted implements MetricsCollector
+func (m *PrometheusMetrics) RequestStarted(info RequestInfo) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.inFlight[RequestInfo{Method: info.Method, Host: info.Host}]++
+}
+
+// RequestRetried implements MetricsCollector
+func (m *PrometheusMetrics) RequestRetried(info RequestInfo, attempt int, status int, err error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.retries[info]++
+}
+
+// RequestFinished implements MetricsCollector
+func (m *PrometheusMetrics) RequestFinished(info RequestInfo, status int, duration time.Duration, err error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.inFlight[RequestInfo{Method: info.Method, Host: info.Host}]--
+
+	label := "error"
+	if status != 0 {
+		label = strconv.Itoa(status)
+	}
+	m.requests[requestKey{RequestInfo: info, status: label}]++
+
+	h, ok := m.durations[info]
+	if !ok {
+		h = &histogram{counts: make([]uint64, len(m.buckets))}
+		m.durations[info] = h
+	}
+	seconds := duration.Seconds()
+	for i, upper := range m.buckets {
+		if seconds <= upper {
+			h.counts[i]++
+		}
+	}
+	h.count++
+	h.sum += seconds
+}
+
+// ServeHTTP writes the metrics in the Prometheus text exposition format
+func (m *PrometheusMetrics) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "text/plain; version=0.0.4; charset=utf-8")
+	m.WriteTo(w)
+}
+
+// WriteTo writes the metrics in the Prometheus text exposition format
+func (m *PrometheusMetrics) WriteTo(w io.Writer) (int64, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	var b strings.Builder
+
+	name := m.name("http_client_requests_total")
+	fmt.Fprintf(&b, "# HELP %s Outbound HTTP requests by status.\n# TYPE %s counter\n", name, name)
+	requestKeys := make([]requestKey, 0, len(m.requests))
+	for k := range m.requests {
+		requestKeys = append(requestKeys, k)
+	}
+	sort.Slice(requestKeys, func(i, j int) bool {
+		if requestKeys[i].RequestInfo != requestKeys[j].RequestInfo {
+			return infoLess(requestKeys[i].RequestInfo, requestKeys[j].RequestInfo)
+		}
+		return requestKeys[i].status < requestKeys[j].status
+	})
+	for _, k := range requestKeys {
+		fmt.Fprintf(&b, "%s{%s,status=%s} %s\n", name, infoLabels(k.RequestInfo), quoteLabel(k.status), formatFloat(m.requests[k]))
+	}
+
+	name = m.name("http_client_request_duration_seconds")
+	fmt.Fprintf(&b, "# HELP %s Outbound HTTP request latency, including retries.\n# TYPE %s histogram\n", name, name)
+	for _, info := range sortedInfos(m.durations) {
+		h := m.durations[info]
+		labels := infoLabels(info)
+		for i, upper := range m.buckets {
+			fmt.Fprintf(&b, "%s_bucket{%s,le=%s} %d\n", name, labels, quoteLabel(formatFloat(upper)), h.counts[i])
+		}
+		fmt.Fprintf(&b, "%s_bucket{%s,le=\"+Inf\"} %d\n", name, labels, h.count)
+		fmt.Fprintf(&b, "%s_sum{%s} %s\n", name, labels, formatFloat(h.sum))
+		fmt.Fprintf(&b, "%s_count{%s} %d\n", name, labels, h.count)
+	}
+
+	name = m.name("http_client_retries_total")
+	fmt.Fprintf(&b, "# HELP %s Outbound HTTP request retries.\n# TYPE %s counter\n", name, name)
+	for _, info := range sortedInfos(m.retries) {
+		fmt.Fprintf(&b, "%s{%s} %s\n", name, infoLabels(info), formatFloat(m.retries[info]))
+	}
+
+	name = m.name("http_client_requests_in_flight")
+	fmt.Fprintf(&b, "# HELP %s Outbound HTTP requests in progress.\n# TYPE %s gauge\n", name, name)
+	for _, info := range sortedInfos(m.inFlight) {
+		fmt.Fprintf(&b, "%s{method=%s,host=%s} %s\n", name, quoteLabel(info.Method), quoteLabel(info.Host), formatFloat(m.inFlight[info]))
+	}
+
+	if len(m.clients) > 0 {
+		m.writeClientStats(&b)
+	}
+
+	n, err := io.WriteString(w, b.String())
+	return int64(n), err
+}
+
+// writeClientStats writes the Stats of the clients added with AddClient;
+// m.mu must be held
+func (m *PrometheusMetrics) writeClientStats(b *strings.Builder) {
+	names := make([]string, 0, len(m.clients))
+	stats := make(map[string]Stats, len(m.clients))
+	for name, c := range m.clients {
+		names = append(names, name)
+		stats[name] = c.Stats()
+	}
+	sort.Strings(names)
+
+	hostMetrics := []struct {
+		metric, help, kind string
+		value              func(HostStats) float64
+	}{
+		{"http_client_attempts_total", "Outbound HTTP request attempts, including retries.", "counter",
+			func(h HostStats) float64 { return float64(h.Attempts) }},
+		{"http_client_failures_total", "Outbound HTTP requests that failed after their last attempt.", "counter",
+			func(h HostStats) float64 { return float64(h.Failures) }},
+		{"http_client_consecutive_failures", "Outbound HTTP requests failed in a row.", "gauge",
+			func(h HostStats) float64 { return float64(h.ConsecutiveFailures) }},
+		{"http_client_circuit_open", "Whether the circuit breaker has stopped requests to the host.", "gauge",
+			func(h HostStats) float64 {
+				if h.CircuitOpen {
+					return 1
+				}
+				return 0
+			}},
+		{"http_client_circuit_rejected_total", "Outbound HTTP requests failed without being sent while the circuit was open.", "counter",
+			func(h HostStats) float64 { return float64(h.Rejected) }},
+		{"http_client_backoff_seconds_total", "Time spent waiting between attempts.", "counter",
+			func(h HostStats) float64 { return h.BackoffTime.Seconds() }},
+	}
+	for _, hm := range hostMetrics {
+		name := m.name(hm.metric)
+		fmt.Fprintf(b, "# HELP %s %s\n# TYPE %s %s\n", name, hm.help, name, hm.kind)
+		for _, client := range names {
+			for _, host := range sortedHosts(stats[client]) {
+				fmt.Fprintf(b, "%s{client=%s,host=%s} %s\n", name, quoteLabel(client), quoteLabel(host), formatFloat(hm.value(stats[client].Hosts[host])))
+			}
+		}
+	}
+
+	name := m.name("http_client_rate_limit_wait_seconds_total")
+	fmt.Fprintf(b, "# HELP %s Time spent waiting for the client rate limit.\n# TYPE %s counter\n", name, name)
+	for _, client := range names {
+		fmt.Fprintf(b, "%s{client=%s} %s\n", name, quoteLabel(client), formatFloat(stats[client].RateLimitWait.Seconds()))
+	}
+}
+
+// name prefixes a metric name with the namespace
+func (m *PrometheusMetrics) name(metric string) string {
+	if m.namespace == "" {
+		return metric
+	}
+	return m.namespace + "_" + metric
+}
+
+// sortedInfos returns the keys of a map sorted for stable output
+func sortedInfos[V any](values map[RequestInfo]V) []RequestInfo {
+	infos := make([]RequestInfo, 0, len(values))
+	for info := range values {
+		infos = append(infos, info)
+	}
+	sort.Slice(infos, func(i, j int) bool { return infoLess(infos[i], infos[j]) })
+	return infos
+}
+
+// infoLess orders RequestInfo by method, host, then path
+func infoLess(a, b RequestInfo) bool {
+	if a.Method != b.Method {
+		return a.Method < b.Method
+	}
+	if a.Host != b.Host {
+		return a.Host < b.Host
+	}
+	return a.Path < b.Path
+}
+
+// infoLabels formats the method, host and path labels
+func infoLabels(info RequestInfo) string {
+	return fmt.Sprintf("method=%s,host=%s,path=%s", quoteLabel(info.Method), quoteLabel(info.Host), quoteLabel(info.Path))
+}
+
+// labelEscaper escapes label values as the text format requires
+var labelEscaper = strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", `\n`)
+
+// quoteLabel quotes a label value
+func quoteLabel(value string) string {
+	return `"` + labelEscaper.Replace(value) + `"`
+}
+
+// formatFloat formats a sample value
+func formatFloat(v float64) string {
+	if math.IsInf(v, 1) {
+		return "+Inf"
+	}
+	return strconv.FormatFloat(v, 'g', -1, 64)
+}
@@ -0,0 +1,69 @@
+package rest
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestPrometheusMetrics(t *testing.T) {
+	m := NewPrometheusMetrics("svc", 0.1, 1)
+	orders := RequestInfo{Method: http.MethodGet, Host: "api.example.com", Path: "/orders/{id}"}
+	quoted := RequestInfo{Method: http.MethodPost, Host: "api.example.com", Path: `/a"b`}
+
+	m.RequestStarted(orders)
+	m.RequestRetried(orders, 1, http.StatusServiceUnavailable, nil)
+	m.RequestFinished(orders, http.StatusOK, 50*time.Millisecond, nil)
+	m.RequestStarted(orders)
+	m.RequestFinished(orders, http.StatusOK, 500*time.Millisecond, nil)
+	m.RequestStarted(quoted)
+	m.RequestFinished(quoted, 0, 2*time.Second, errors.New("connection refused"))
+	m.RequestStarted(orders)
+
+	w := httptest.NewRecorder()
+	m.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/metrics", nil))
+
+	if ct := w.Header().Get("Content-Type"); !strings.HasPrefix(ct, "text/plain; version=0.0.4") {
+		t.Errorf("Expected Prometheus content type, got %q", ct)
+	}
+
+	body := w.Body.String()
+	labels := `method="GET",host="api.example.com",path="/orders/{id}"`
+	for _, line := range []string{
+		"# TYPE svc_http_client_requests_total counter",
+		`svc_http_client_requests_total{` + labels + `,status="200"} 2`,
+		`svc_http_client_requests_total{method="POST",host="api.example.com",path="/a\"b",status="error"} 1`,
+		"# TYPE svc_http_client_request_duration_seconds histogram",
+		`svc_http_client_request_duration_seconds_bucket{` + labels + `,le="0.1"} 1`,
+		`svc_http_client_request_duration_seconds_bucket{` + labels + `,le="1"} 2`,
+		`svc_http_client_request_duration_seconds_bucket{` + labels + `,le="+Inf"} 2`,
+		`svc_http_client_request_duration_seconds_sum{` + labels + `} 0.55`,
+		`svc_http_client_request_duration_seconds_count{` + labels + `} 2`,
+		`svc_http_client_retries_total{` + labels + `} 1`,
+		`svc_http_client_requests_in_flight{method="GET",host="api.example.com"} 1`,
+		`svc_http_client_requests_in_flight{method="POST",host="api.example.com"} 0`,
+	} {
+		if !strings.Contains(body, line+"\n") {
+			t.Errorf("Expected output to contain %q, got:\n%s", line, body)
+		}
+	}
+}
+
+func TestPrometheusMetrics_NoNamespace(t *testing.T) {
+	m := NewPrometheusMetrics("")
+	m.RequestFinished(RequestInfo{Method: http.MethodGet}, http.StatusOK, time.Millisecond, nil)
+
+	var b strings.Builder
+	if _, err := m.WriteTo(&b); err != nil {
+		t.Fatalf("WriteTo() error = %v", err)
+	}
+	if !strings.Contains(b.String(), "\nhttp_client_requests_total{") {
+		t.Errorf("Expected unprefixed metric names, got:\n%s", b.String())
+	}
+	if strings.Count(b.String(), "_bucket{") != len(DefaultLatencyBuckets)+1 {
+		t.Errorf("Expected default buckets, got:\n%s", b.String())
+	}
+}
@@ -0,0 +1,39 @@
+package rest
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/StairSupplies/go-core/ctxutils"
+	"github.com/StairSupplies/go-core/logger"
+	"github.com/go-chi/chi/v5/middleware"
+)
+
+// propagateHeaders sets the named headers on req from the inbound headers in
+// ctx. Without stored headers, the request ID falls back to
+// ctxutils.RequestID and traceparent to the active logger span.
+func propagateHeaders(ctx context.Context, req *http.Request, names []string) {
+	inbound := ctxutils.PropagatedHeaders(ctx)
+	for _, name := range names {
+		if req.Header.Get(name) != "" {
+			continue
+		}
+		if values := inbound.Values(name); len(values) > 0 {
+			req.Header[http.CanonicalHeaderKey(name)] = append([]string(nil), values...)
+			continue
+		}
+
+		switch http.CanonicalHeaderKey(name) {
+		case http.CanonicalHeaderKey(middleware.RequestIDHeader):
+			if id := ctxutils.RequestID(ctx); id != "" {
+				req.Header.Set(name, id)
+			}
+		case "Traceparent":
+			if sc, ok := logger.SpanFromContext(ctx); ok {
+				if tp := sc.Traceparent(); tp != "" {
+					req.Header.Set(name, tp)
+				}
+			}
+		}
+	}
+}
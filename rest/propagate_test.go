@@ -0,0 +1,105 @@
+package rest
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/StairSupplies/go-core/ctxutils"
+	"github.com/StairSupplies/go-core/logger"
+)
+
+func TestWithPropagateHeaders(t *testing.T) {
+	var got http.Header
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		got = r.Header.Clone()
+		w.Write([]byte(`{}`))
+	}))
+	defer server.Close()
+
+	const traceparent = "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01"
+
+	tests := []struct {
+		name    string
+		opts    []ClientOption
+		ctx     func() context.Context
+		want    map[string]string
+		wantOff []string
+	}{
+		{
+			name: "inbound headers",
+			opts: []ClientOption{WithPropagateHeaders()},
+			ctx: func() context.Context {
+				return ctxutils.WithPropagatedHeaders(context.Background(), http.Header{
+					"X-Request-Id": {"req-1"},
+					"Traceparent":  {traceparent},
+					"Tracestate":   {"vendor=1"},
+					"X-Tenant":     {"not-forwarded"},
+				})
+			},
+			want:    map[string]string{"X-Request-Id": "req-1", "Traceparent": traceparent, "Tracestate": "vendor=1"},
+			wantOff: []string{"X-Tenant"},
+		},
+		{
+			name: "named headers",
+			opts: []ClientOption{WithPropagateHeaders("X-Tenant")},
+			ctx: func() context.Context {
+				return ctxutils.WithPropagatedHeaders(context.Background(), http.Header{
+					"X-Request-Id": {"req-1"},
+					"X-Tenant":     {"acme"},
+				})
+			},
+			want:    map[string]string{"X-Tenant": "acme"},
+			wantOff: []string{"X-Request-Id"},
+		},
+		{
+			name: "fallback to context values",
+			opts: []ClientOption{WithPropagateHeaders()},
+			ctx: func() context.Context {
+				ctx := ctxutils.WithRequestID(context.Background(), "req-2")
+				sc, _ := logger.ParseTraceparent(traceparent)
+				return logger.ContextWithSpan(ctx, sc)
+			},
+			want: map[string]string{"X-Request-Id": "req-2", "Traceparent": traceparent},
+		},
+		{
+			name: "explicit header wins",
+			opts: []ClientOption{WithHeader("X-Request-ID", "fixed"), WithPropagateHeaders()},
+			ctx: func() context.Context {
+				return ctxutils.WithRequestID(context.Background(), "req-3")
+			},
+			want: map[string]string{"X-Request-Id": "fixed"},
+		},
+		{
+			name: "disabled",
+			ctx: func() context.Context {
+				return ctxutils.WithRequestID(context.Background(), "req-4")
+			},
+			wantOff: []string{"X-Request-Id"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			opts := append([]ClientOption{WithBaseURL(server.URL), WithLogger(logger.NewNopLogger())}, tt.opts...)
+			client, err := NewClient(opts...)
+			if err != nil {
+				t.Fatalf("NewClient() error = %v", err)
+			}
+			if err := client.Get(tt.ctx(), "/", nil); err != nil {
+				t.Fatalf("Get() error = %v", err)
+			}
+			for k, v := range tt.want {
+				if got.Get(k) != v {
+					t.Errorf("Expected %s = %q, got %q", k, v, got.Get(k))
+				}
+			}
+			for _, k := range tt.wantOff {
+				if got.Get(k) != "" {
+					t.Errorf("Expected %s not to be sent, got %q", k, got.Get(k))
+				}
+			}
+		})
+	}
+}
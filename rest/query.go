@@ -0,0 +1,120 @@
+package rest
+
+import (
+	"encoding"
+	"fmt"
+	"net/url"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Query holds query parameters for AppendQuery. Values may be strings,
+// booleans, numbers, time.Time (encoded as RFC 3339), fmt.Stringer or
+// encoding.TextMarshaler values, pointers to any of these, or slices of them,
+// which repeat the key once per element. Nil values are left out, so optional
+// filters can be passed as pointers.
+type Query map[string]any
+
+// Values encodes q as url.Values
+func (q Query) Values() url.Values {
+	values := make(url.Values, len(q))
+	for key, value := range q {
+		addQueryValue(values, key, reflect.ValueOf(value))
+	}
+	return values
+}
+
+// Encode encodes q in URL query form, sorted by key
+func (q Query) Encode() string {
+	return q.Values().Encode()
+}
+
+// AppendQuery returns path with q added to its query string. Parameters
+// already in path are kept as they are.
+//
+//	client.Get(ctx, rest.AppendQuery("/users", rest.Query{"status": "active", "id": ids}), &users)
+func AppendQuery(path string, q Query) string {
+	encoded := q.Encode()
+	if encoded == "" {
+		return path
+	}
+
+	base, fragment, hasFragment := strings.Cut(path, "#")
+	switch {
+	case !strings.Contains(base, "?"):
+		base += "?" + encoded
+	case strings.HasSuffix(base, "?") || strings.HasSuffix(base, "&"):
+		base += encoded
+	default:
+		base += "&" + encoded
+	}
+	if hasFragment {
+		base += "#" + fragment
+	}
+	return base
+}
+
+// addQueryValue adds the encoding of v to values under key
+func addQueryValue(values url.Values, key string, v reflect.Value) {
+	for v.Kind() == reflect.Pointer || v.Kind() == reflect.Interface {
+		if v.IsNil() {
+			return
+		}
+		v = v.Elem()
+	}
+	if !v.IsValid() {
+		return
+	}
+
+	if s, ok := formatQueryValue(v); ok {
+		values.Add(key, s)
+		return
+	}
+
+	if v.Kind() == reflect.Slice || v.Kind() == reflect.Array {
+		for i := 0; i < v.Len(); i++ {
+			addQueryValue(values, key, v.Index(i))
+		}
+		return
+	}
+
+	values.Add(key, fmt.Sprint(v.Interface()))
+}
+
+// formatQueryValue formats scalar values, reporting false for anything else
+func formatQueryValue(v reflect.Value) (string, bool) {
+	if v.CanInterface() {
+		switch x := v.Interface().(type) {
+		case time.Time:
+			return x.Format(time.RFC3339), true
+		case encoding.TextMarshaler:
+			if b, err := x.MarshalText(); err == nil {
+				return string(b), true
+			}
+		case fmt.Stringer:
+			return x.String(), true
+		}
+	}
+
+	switch v.Kind() {
+	case reflect.String:
+		return v.String(), true
+	case reflect.Bool:
+		return strconv.FormatBool(v.Bool()), true
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return strconv.FormatInt(v.Int(), 10), true
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return strconv.FormatUint(v.Uint(), 10), true
+	case reflect.Float32:
+		return strconv.FormatFloat(v.Float(), 'f', -1, 32), true
+	case reflect.Float64:
+		return strconv.FormatFloat(v.Float(), 'f', -1, 64), true
+	case reflect.Slice:
+		if v.Type().Elem().Kind() == reflect.Uint8 {
+			return string(v.Bytes()), true
+		}
+	}
+	return "", false
+}
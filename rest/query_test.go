@@ -0,0 +1,92 @@
+package rest
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+type sortOrder int
+
+func (s sortOrder) String() string {
+	if s < 0 {
+		return "desc"
+	}
+	return "asc"
+}
+
+func TestQuery_Encode(t *testing.T) {
+	status := "active"
+	var missing *string
+	since := time.Date(2024, 3, 4, 15, 4, 5, 0, time.UTC)
+
+	tests := []struct {
+		name  string
+		query Query
+		want  string
+	}{
+		{"empty", Query{}, ""},
+		{"escaping", Query{"q": "oak & maple/pine"}, "q=oak+%26+maple%2Fpine"},
+		{"scalars", Query{"page": 2, "active": true, "min": 1.5, "limit": uint8(10)}, "active=true&limit=10&min=1.5&page=2"},
+		{"pointers", Query{"status": &status, "region": missing, "other": nil}, "status=active"},
+		{"slices", Query{"id": []int{3, 1, 2}, "tag": []string{"a b", "c"}}, "id=3&id=1&id=2&tag=a+b&tag=c"},
+		{"slice of pointers", Query{"status": []*string{&status, missing}}, "status=active"},
+		{"time", Query{"since": since}, "since=2024-03-04T15%3A04%3A05Z"},
+		{"stringer", Query{"order": sortOrder(-1)}, "order=desc"},
+		{"bytes", Query{"cursor": []byte("abc")}, "cursor=abc"},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			if got := tc.query.Encode(); got != tc.want {
+				t.Errorf("Expected %q, got %q", tc.want, got)
+			}
+		})
+	}
+}
+
+func TestAppendQuery(t *testing.T) {
+	q := Query{"page": 2}
+
+	tests := []struct {
+		path string
+		want string
+	}{
+		{"/users", "/users?page=2"},
+		{"/users?status=active", "/users?status=active&page=2"},
+		{"/users?", "/users?page=2"},
+		{"/users?status=active&", "/users?status=active&page=2"},
+		{"/docs#section", "/docs?page=2#section"},
+	}
+
+	for _, tc := range tests {
+		if got := AppendQuery(tc.path, q); got != tc.want {
+			t.Errorf("AppendQuery(%q) = %q, want %q", tc.path, got, tc.want)
+		}
+	}
+
+	if got := AppendQuery("/users", Query{"status": (*string)(nil)}); got != "/users" {
+		t.Errorf("Expected the path unchanged for an empty query, got %q", got)
+	}
+}
+
+func TestClient_GetWithQuery(t *testing.T) {
+	var gotQuery string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotQuery = r.URL.RawQuery
+		w.Write([]byte(`{}`))
+	}))
+	defer server.Close()
+
+	client, _ := NewClient(WithBaseURL(server.URL))
+	path := AppendQuery("/users", Query{"name": "O'Brien & Sons", "id": []int{1, 2}})
+	if err := client.Get(context.Background(), path, nil); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	if gotQuery != "id=1&id=2&name=O%27Brien+%26+Sons" {
+		t.Errorf("Unexpected query sent: %q", gotQuery)
+	}
+}
@@ -0,0 +1,192 @@
+package rest
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+)
+
+// RateLimitError is returned for 429 Too Many Requests responses. It wraps
+// the *ClientError for the response, so errors.As finds either, and adds
+// what the rate limit headers said.
+type RateLimitError struct {
+	*ClientError
+	// Limit is the X-RateLimit-Limit header, or -1 if missing
+	Limit int
+	// Remaining is the X-RateLimit-Remaining header, or -1 if missing
+	Remaining int
+	// Reset is when the limit resets according to X-RateLimit-Reset, or the
+	// zero time if missing
+	Reset time.Time
+	// RetryAfter is how long the server asked the client to wait, from
+	// Retry-After or else Reset, or zero if neither was sent
+	RetryAfter time.Duration
+}
+
+// Error returns the error message with the wait the server asked for
+func (e *RateLimitError) Error() string {
+	if e.RetryAfter > 0 {
+		return fmt.Sprintf("%s (retry after %s)", e.ClientError.Error(), e.RetryAfter)
+	}
+	return e.ClientError.Error()
+}
+
+// Unwrap returns the *ClientError for the response
+func (e *RateLimitError) Unwrap() error {
+	return e.ClientError
+}
+
+// rateLimitInfo is what a response's rate limit headers say
+type rateLimitInfo struct {
+	limit      int
+	remaining  int
+	reset      time.Time
+	retryAfter time.Duration
+	hasWait    bool
+}
+
+// parseRateLimit reads Retry-After and the X-RateLimit-Limit, -Remaining
+// and -Reset headers, or the unprefixed RateLimit-* headers some APIs send.
+// Reset may be a Unix time or a number of seconds from now.
+func parseRateLimit(h http.Header, now time.Time) rateLimitInfo {
+	info := rateLimitInfo{
+		limit:     rateLimitHeader(h, "Limit"),
+		remaining: rateLimitHeader(h, "Remaining"),
+	}
+	if reset := rateLimitHeader(h, "Reset"); reset >= 0 {
+		// Values this large are Unix times rather than seconds to wait
+		if reset > 1e9 {
+			info.reset = time.Unix(int64(reset), 0)
+		} else {
+			info.reset = now.Add(time.Duration(reset) * time.Second)
+		}
+	}
+
+	if d, ok := parseRetryAfter(h.Get("Retry-After"), now); ok {
+		info.retryAfter, info.hasWait = d, true
+	} else if !info.reset.IsZero() && info.remaining <= 0 {
+		info.retryAfter, info.hasWait = info.reset.Sub(now), true
+		if info.retryAfter < 0 {
+			info.retryAfter = 0
+		}
+	}
+	return info
+}
+
+// rateLimitHeader returns the X-RateLimit-name or RateLimit-name header as
+// an integer, or -1 if it is missing or not a number
+func rateLimitHeader(h http.Header, name string) int {
+	value := h.Get("X-RateLimit-" + name)
+	if value == "" {
+		value = h.Get("RateLimit-" + name)
+	}
+	n, err := strconv.Atoi(value)
+	if err != nil || n < 0 {
+		return -1
+	}
+	return n
+}
+
+// newRateLimitError wraps err, the status error of a 429 response, in a
+// *RateLimitError; other errors are returned as they are
+func newRateLimitError(resp *http.Response, err error) error {
+	var clientErr *ClientError
+	if resp.StatusCode != http.StatusTooManyRequests || !errors.As(err, &clientErr) {
+		return err
+	}
+	info := parseRateLimit(resp.Header, time.Now())
+	return &RateLimitError{
+		ClientError: clientErr,
+		Limit:       info.limit,
+		Remaining:   info.remaining,
+		Reset:       info.reset,
+		RetryAfter:  info.retryAfter,
+	}
+}
+
+// rateLimiter is a token bucket shared by all requests of a client
+type rateLimiter struct {
+	mu     sync.Mutex
+	rate   float64
+	burst  float64
+	tokens float64
+	last   time.Time
+	// pausedUntil holds every request back after a 429 asked the client to wait
+	pausedUntil time.Time
+	now         func() time.Time
+}
+
+// newRateLimiter creates a full bucket allowing rps requests per second with
+// bursts of up to burst requests
+func newRateLimiter(rps float64, burst int) *rateLimiter {
+	if burst <= 0 {
+		burst = int(math.Ceil(rps))
+	}
+	return &rateLimiter{
+		rate:   rps,
+		burst:  float64(burst),
+		tokens: float64(burst),
+		now:    time.Now,
+	}
+}
+
+// reserve takes a token and returns how long to wait before using it
+func (l *rateLimiter) reserve() time.Duration {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	now := l.now()
+	if !l.last.IsZero() {
+		l.tokens = math.Min(l.burst, l.tokens+now.Sub(l.last).Seconds()*l.rate)
+	}
+	l.last = now
+	l.tokens--
+
+	var wait time.Duration
+	if l.tokens < 0 {
+		wait = time.Duration(-l.tokens / l.rate * float64(time.Second))
+	}
+	if pause := l.pausedUntil.Sub(now); pause > wait {
+		wait = pause
+	}
+	return wait
+}
+
+// cancel returns a token taken by reserve that was not used
+func (l *rateLimiter) cancel() {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.tokens = math.Min(l.burst, l.tokens+1)
+}
+
+// pause holds every request back for d
+func (l *rateLimiter) pause(d time.Duration) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if until := l.now().Add(d); until.After(l.pausedUntil) {
+		l.pausedUntil = until
+	}
+}
+
+// wait blocks until a request may be sent or ctx is done
+func (l *rateLimiter) wait(ctx context.Context) error {
+	d := l.reserve()
+	if d <= 0 {
+		return nil
+	}
+
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+	select {
+	case <-ctx.Done():
+		l.cancel()
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
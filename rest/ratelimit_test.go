@@ -0,0 +1,211 @@
+package rest
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/StairSupplies/go-core/logger"
+)
+
+func TestParseRateLimit(t *testing.T) {
+	now := time.Date(2024, 3, 1, 12, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name          string
+		headers       map[string]string
+		wantLimit     int
+		wantRemaining int
+		wantReset     time.Time
+		wantWait      time.Duration
+		wantHasWait   bool
+	}{
+		{
+			name:          "no headers",
+			wantLimit:     -1,
+			wantRemaining: -1,
+		},
+		{
+			name:          "retry after",
+			headers:       map[string]string{"Retry-After": "30", "X-RateLimit-Remaining": "0", "X-RateLimit-Reset": "60"},
+			wantLimit:     -1,
+			wantRemaining: 0,
+			wantReset:     now.Add(time.Minute),
+			wantWait:      30 * time.Second,
+			wantHasWait:   true,
+		},
+		{
+			name:          "reset in seconds",
+			headers:       map[string]string{"X-RateLimit-Limit": "100", "X-RateLimit-Remaining": "0", "X-RateLimit-Reset": "15"},
+			wantLimit:     100,
+			wantRemaining: 0,
+			wantReset:     now.Add(15 * time.Second),
+			wantWait:      15 * time.Second,
+			wantHasWait:   true,
+		},
+		{
+			name:          "reset as unix time",
+			headers:       map[string]string{"X-RateLimit-Remaining": "0", "X-RateLimit-Reset": strconv.FormatInt(now.Add(2*time.Minute).Unix(), 10)},
+			wantLimit:     -1,
+			wantRemaining: 0,
+			wantReset:     now.Add(2 * time.Minute),
+			wantWait:      2 * time.Minute,
+			wantHasWait:   true,
+		},
+		{
+			name:          "requests remaining",
+			headers:       map[string]string{"X-RateLimit-Remaining": "5", "X-RateLimit-Reset": "15"},
+			wantLimit:     -1,
+			wantRemaining: 5,
+			wantReset:     now.Add(15 * time.Second),
+		},
+		{
+			name:          "unprefixed headers",
+			headers:       map[string]string{"RateLimit-Limit": "10", "RateLimit-Remaining": "0", "RateLimit-Reset": "5"},
+			wantLimit:     10,
+			wantRemaining: 0,
+			wantReset:     now.Add(5 * time.Second),
+			wantWait:      5 * time.Second,
+			wantHasWait:   true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			h := http.Header{}
+			for k, v := range tt.headers {
+				h.Set(k, v)
+			}
+			got := parseRateLimit(h, now)
+			if got.limit != tt.wantLimit || got.remaining != tt.wantRemaining {
+				t.Errorf("Expected limit %d remaining %d, got %d %d", tt.wantLimit, tt.wantRemaining, got.limit, got.remaining)
+			}
+			if !got.reset.Equal(tt.wantReset) {
+				t.Errorf("Expected reset %v, got %v", tt.wantReset, got.reset)
+			}
+			if got.retryAfter != tt.wantWait || got.hasWait != tt.wantHasWait {
+				t.Errorf("Expected wait %v (%v), got %v (%v)", tt.wantWait, tt.wantHasWait, got.retryAfter, got.hasWait)
+			}
+		})
+	}
+}
+
+func TestClient_RateLimitError(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("X-RateLimit-Limit", "100")
+		w.Header().Set("X-RateLimit-Remaining", "0")
+		w.Header().Set("X-RateLimit-Reset", "60")
+		w.WriteHeader(http.StatusTooManyRequests)
+		w.Write([]byte(`{"message":"slow down"}`))
+	}))
+	defer server.Close()
+
+	policy := fastRetryPolicy()
+	policy.MaxRetryAfter = time.Second
+	client, _ := NewClient(WithBaseURL(server.URL), WithLogger(logger.NewNopLogger()), WithRetryPolicy(policy))
+
+	err := client.Get(context.Background(), "/", nil)
+
+	var rateErr *RateLimitError
+	if !errors.As(err, &rateErr) {
+		t.Fatalf("Expected RateLimitError, got %v", err)
+	}
+	if rateErr.Limit != 100 || rateErr.Remaining != 0 {
+		t.Errorf("Expected limit 100 and none remaining, got %d %d", rateErr.Limit, rateErr.Remaining)
+	}
+	if rateErr.RetryAfter < 59*time.Second || rateErr.RetryAfter > time.Minute {
+		t.Errorf("Expected a wait of about a minute from X-RateLimit-Reset, got %v", rateErr.RetryAfter)
+	}
+
+	var clientErr *ClientError
+	if !errors.As(err, &clientErr) || clientErr.StatusCode != http.StatusTooManyRequests || clientErr.Message != "slow down" {
+		t.Errorf("Expected the ClientError to be reachable, got %+v", clientErr)
+	}
+	if !errors.Is(err, ErrInvalidRequest) {
+		t.Errorf("Expected ErrInvalidRequest, got %v", err)
+	}
+}
+
+func TestClient_RetryHonorsRateLimitReset(t *testing.T) {
+	var attempts atomic.Int32
+	var first, second time.Time
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if attempts.Add(1) == 1 {
+			first = time.Now()
+			w.Header().Set("X-RateLimit-Remaining", "0")
+			w.Header().Set("X-RateLimit-Reset", "1")
+			w.WriteHeader(http.StatusTooManyRequests)
+			return
+		}
+		second = time.Now()
+		w.Write([]byte(`{}`))
+	}))
+	defer server.Close()
+
+	client, _ := NewClient(WithBaseURL(server.URL), WithLogger(logger.NewNopLogger()), WithRetryPolicy(fastRetryPolicy()))
+	if err := client.Get(context.Background(), "/", nil); err != nil {
+		t.Fatalf("Get() error = %v", err)
+	}
+	if wait := second.Sub(first); wait < 900*time.Millisecond {
+		t.Errorf("Expected X-RateLimit-Reset to delay the retry by ~1s, got %v", wait)
+	}
+}
+
+func TestRateLimiter(t *testing.T) {
+	now := time.Date(2024, 3, 1, 12, 0, 0, 0, time.UTC)
+	l := newRateLimiter(10, 2)
+	l.now = func() time.Time { return now }
+
+	for i, want := range []time.Duration{0, 0, 100 * time.Millisecond, 200 * time.Millisecond} {
+		if got := l.reserve(); got != want {
+			t.Errorf("Reservation %d: expected wait %v, got %v", i, want, got)
+		}
+	}
+
+	l.cancel()
+	l.cancel()
+	now = now.Add(200 * time.Millisecond)
+	if got := l.reserve(); got != 0 {
+		t.Errorf("Expected a token after refilling, got wait %v", got)
+	}
+
+	l.pause(5 * time.Second)
+	if got := l.reserve(); got != 5*time.Second {
+		t.Errorf("Expected a pause of 5s, got %v", got)
+	}
+}
+
+func TestRateLimiter_WaitCanceled(t *testing.T) {
+	l := newRateLimiter(1, 1)
+	l.reserve()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	if err := l.wait(ctx); !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("Expected deadline exceeded, got %v", err)
+	}
+}
+
+func TestWithRateLimit(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{}`))
+	}))
+	defer server.Close()
+
+	client, _ := NewClient(WithBaseURL(server.URL), WithLogger(logger.NewNopLogger()), WithRateLimit(20, 1))
+
+	start := time.Now()
+	for i := 0; i < 3; i++ {
+		if err := client.Get(context.Background(), "/", nil); err != nil {
+			t.Fatalf("Get() error = %v", err)
+		}
+	}
+	if elapsed := time.Since(start); elapsed < 90*time.Millisecond {
+		t.Errorf("Expected 3 requests at 20/s to take ~100ms, took %v", elapsed)
+	}
+}
@@ -0,0 +1,114 @@
+package rest
+
+import (
+	"math/rand"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+// RetryPolicy controls when and how often a request is retried
+type RetryPolicy struct {
+	// MaxAttempts is the total number of attempts, including the first.
+	// Values below 2 disable retries.
+	MaxAttempts int
+	// BaseBackoff is the wait before the first retry; it doubles on each
+	// subsequent retry up to MaxBackoff
+	BaseBackoff time.Duration
+	MaxBackoff  time.Duration
+	// Jitter randomizes each wait by up to this fraction (0 to 1) to avoid
+	// synchronized retries from many clients
+	Jitter float64
+	// RetryableStatusCodes lists the response statuses that are retried.
+	// Transport errors are always retryable.
+	RetryableStatusCodes []int
+	// MaxRetryAfter caps how long a Retry-After header may delay a retry.
+	// A longer Retry-After returns the response instead. Zero means no cap.
+	MaxRetryAfter time.Duration
+	// RetryNonIdempotent allows retrying POST and PATCH requests. By default
+	// they are only retried when they carry an Idempotency-Key header.
+	RetryNonIdempotent bool
+}
+
+// DefaultRetryPolicy returns the policy used when Client.RetryPolicy is nil,
+// with MaxAttempts derived from Client.Retries
+func DefaultRetryPolicy() RetryPolicy {
+	return RetryPolicy{
+		MaxAttempts: 4,
+		BaseBackoff: 100 * time.Millisecond,
+		MaxBackoff:  5 * time.Second,
+		Jitter:      0.2,
+		RetryableStatusCodes: []int{
+			http.StatusTooManyRequests,
+			http.StatusBadGateway,
+			http.StatusServiceUnavailable,
+			http.StatusGatewayTimeout,
+		},
+		MaxRetryAfter: time.Minute,
+	}
+}
+
+// retryPolicy returns the policy in effect for c
+func (c *Client) retryPolicy() RetryPolicy {
+	if c.RetryPolicy != nil {
+		return *c.RetryPolicy
+	}
+	p := DefaultRetryPolicy()
+	p.MaxAttempts = c.Retries + 1
+	return p
+}
+
+// canRetry reports whether req may be sent again
+func (p RetryPolicy) canRetry(req *http.Request) bool {
+	switch req.Method {
+	case http.MethodGet, http.MethodHead, http.MethodOptions, http.MethodTrace,
+		http.MethodPut, http.MethodDelete:
+		return true
+	}
+	return p.RetryNonIdempotent || req.Header.Get("Idempotency-Key") != ""
+}
+
+// retryableStatus reports whether a response with the given status is retried
+func (p RetryPolicy) retryableStatus(status int) bool {
+	for _, code := range p.RetryableStatusCodes {
+		if code == status {
+			return true
+		}
+	}
+	return false
+}
+
+// backoff returns the wait before retry number n (starting at 0)
+func (p RetryPolicy) backoff(n int) time.Duration {
+	d := p.BaseBackoff
+	for i := 0; i < n && (p.MaxBackoff <= 0 || d < p.MaxBackoff); i++ {
+		d *= 2
+	}
+	if p.MaxBackoff > 0 && d > p.MaxBackoff {
+		d = p.MaxBackoff
+	}
+	if p.Jitter > 0 && d > 0 {
+		d -= time.Duration(rand.Float64() * p.Jitter * float64(d))
+	}
+	return d
+}
+
+// parseRetryAfter reads a Retry-After header given in seconds or as an HTTP date
+func parseRetryAfter(value string, now time.Time) (time.Duration, bool) {
+	if value == "" {
+		return 0, false
+	}
+	if secs, err := strconv.Atoi(value); err == nil {
+		if secs < 0 {
+			return 0, false
+		}
+		return time.Duration(secs) * time.Second, true
+	}
+	if t, err := http.ParseTime(value); err == nil {
+		if d := t.Sub(now); d > 0 {
+			return d, true
+		}
+		return 0, true
+	}
+	return 0, false
+}
@@ -0,0 +1,188 @@
+package rest
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// fastRetryPolicy retries quickly so tests do not sleep
+func fastRetryPolicy() RetryPolicy {
+	p := DefaultRetryPolicy()
+	p.BaseBackoff = time.Millisecond
+	p.MaxBackoff = 5 * time.Millisecond
+	return p
+}
+
+func TestClient_RetryStatusCodes(t *testing.T) {
+	tests := []struct {
+		name         string
+		method       string
+		status       int
+		header       http.Header
+		wantAttempts int32
+		wantErr      bool
+	}{
+		{"GET retries 503", http.MethodGet, http.StatusServiceUnavailable, nil, 3, false},
+		{"GET retries 429", http.MethodGet, http.StatusTooManyRequests, nil, 3, false},
+		{"GET does not retry 500", http.MethodGet, http.StatusInternalServerError, nil, 1, true},
+		{"GET does not retry 404", http.MethodGet, http.StatusNotFound, nil, 1, true},
+		{"PUT retries 502", http.MethodPut, http.StatusBadGateway, nil, 3, false},
+		{"POST is not retried", http.MethodPost, http.StatusServiceUnavailable, nil, 1, true},
+		{"POST with idempotency key", http.MethodPost, http.StatusServiceUnavailable, http.Header{"Idempotency-Key": {"k1"}}, 3, false},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			var attempts atomic.Int32
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if attempts.Add(1) < 3 {
+					w.WriteHeader(tc.status)
+					return
+				}
+				w.Write([]byte(`{}`))
+			}))
+			defer server.Close()
+
+			client, _ := NewClient(WithBaseURL(server.URL), WithRetryPolicy(fastRetryPolicy()))
+			for k, v := range tc.header {
+				client.Headers[k] = v[0]
+			}
+
+			err := client.Request(context.Background(), tc.method, "/", nil, nil)
+			if (err != nil) != tc.wantErr {
+				t.Errorf("Request() error = %v, wantErr %v", err, tc.wantErr)
+			}
+			if got := attempts.Load(); got != tc.wantAttempts {
+				t.Errorf("Expected %d attempts, got %d", tc.wantAttempts, got)
+			}
+		})
+	}
+}
+
+func TestClient_RetryNonIdempotent(t *testing.T) {
+	var attempts atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		attempts.Add(1)
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer server.Close()
+
+	policy := fastRetryPolicy()
+	policy.MaxAttempts = 2
+	policy.RetryNonIdempotent = true
+	client, _ := NewClient(WithBaseURL(server.URL), WithRetryPolicy(policy))
+
+	err := client.Post(context.Background(), "/", map[string]string{"a": "b"}, nil)
+	if !errors.Is(err, ErrServerError) {
+		t.Errorf("Expected ErrServerError, got %v", err)
+	}
+	if got := attempts.Load(); got != 2 {
+		t.Errorf("Expected 2 attempts, got %d", got)
+	}
+}
+
+func TestClient_RetryAfter(t *testing.T) {
+	var attempts atomic.Int32
+	var first, second time.Time
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if attempts.Add(1) == 1 {
+			first = time.Now()
+			w.Header().Set("Retry-After", "1")
+			w.WriteHeader(http.StatusTooManyRequests)
+			return
+		}
+		second = time.Now()
+		w.Write([]byte(`{}`))
+	}))
+	defer server.Close()
+
+	client, _ := NewClient(WithBaseURL(server.URL), WithRetryPolicy(fastRetryPolicy()))
+	if err := client.Get(context.Background(), "/", nil); err != nil {
+		t.Fatalf("Get() error = %v", err)
+	}
+	if wait := second.Sub(first); wait < 900*time.Millisecond {
+		t.Errorf("Expected Retry-After to delay the retry by ~1s, got %v", wait)
+	}
+}
+
+func TestClient_RetryAfterTooLong(t *testing.T) {
+	var attempts atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		attempts.Add(1)
+		w.Header().Set("Retry-After", "3600")
+		w.WriteHeader(http.StatusTooManyRequests)
+	}))
+	defer server.Close()
+
+	client, _ := NewClient(WithBaseURL(server.URL), WithRetryPolicy(fastRetryPolicy()))
+	err := client.Get(context.Background(), "/", nil)
+	if !errors.Is(err, ErrInvalidRequest) {
+		t.Errorf("Expected ErrInvalidRequest, got %v", err)
+	}
+	if got := attempts.Load(); got != 1 {
+		t.Errorf("Expected 1 attempt, got %d", got)
+	}
+}
+
+func TestClient_RetriesDisabled(t *testing.T) {
+	var attempts atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		attempts.Add(1)
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer server.Close()
+
+	client, _ := NewClient(WithBaseURL(server.URL), WithRetries(0))
+	client.Get(context.Background(), "/", nil)
+
+	if got := attempts.Load(); got != 1 {
+		t.Errorf("Expected 1 attempt, got %d", got)
+	}
+}
+
+func TestRetryPolicy_Backoff(t *testing.T) {
+	p := RetryPolicy{BaseBackoff: 100 * time.Millisecond, MaxBackoff: time.Second}
+
+	want := []time.Duration{100 * time.Millisecond, 200 * time.Millisecond, 400 * time.Millisecond, 800 * time.Millisecond, time.Second, time.Second}
+	for n, w := range want {
+		if got := p.backoff(n); got != w {
+			t.Errorf("backoff(%d) = %v, want %v", n, got, w)
+		}
+	}
+
+	p.Jitter = 0.5
+	for i := 0; i < 100; i++ {
+		if got := p.backoff(1); got < 100*time.Millisecond || got > 200*time.Millisecond {
+			t.Fatalf("backoff(1) with jitter = %v, want within [100ms, 200ms]", got)
+		}
+	}
+}
+
+func TestParseRetryAfter(t *testing.T) {
+	now := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		value  string
+		want   time.Duration
+		wantOK bool
+	}{
+		{"", 0, false},
+		{"120", 2 * time.Minute, true},
+		{"-1", 0, false},
+		{"soon", 0, false},
+		{now.Add(30 * time.Second).Format(http.TimeFormat), 30 * time.Second, true},
+		{now.Add(-time.Minute).Format(http.TimeFormat), 0, true},
+	}
+
+	for _, tc := range tests {
+		got, ok := parseRetryAfter(tc.value, now)
+		if got != tc.want || ok != tc.wantOK {
+			t.Errorf("parseRetryAfter(%q) = %v, %v; want %v, %v", tc.value, got, ok, tc.want, tc.wantOK)
+		}
+	}
+}
@@ -0,0 +1,263 @@
+package rest
+
+import (
+	"context"
+	"errors"
+	"expvar"
+	"net/http"
+	"sort"
+	"sync"
+	"time"
+)
+
+// Stats is a snapshot of a Client's request and retry counters, returned by
+// Client.Stats. The totals are the sums over Hosts.
+type Stats struct {
+	// Requests is the number of logical requests started, each spanning all
+	// of its attempts
+	Requests int64
+	// Attempts is the number of times a request was sent
+	Attempts int64
+	// Retries is the number of attempts after the first
+	Retries int64
+	// Failures is the number of requests that got no response, or a 5xx or
+	// 429 response after their last attempt
+	Failures int64
+	// InFlight is the number of requests in progress
+	InFlight int64
+	// BackoffTime is the total time spent waiting between attempts
+	BackoffTime time.Duration
+	// RateLimitWait is the total time requests waited for WithRateLimit
+	RateLimitWait time.Duration
+	// OpenCircuits is the number of hosts whose circuit is open
+	OpenCircuits int
+	// Hosts holds the counters of each host the client has called
+	Hosts map[string]HostStats
+}
+
+// HostStats holds the request and retry counters for one host
+type HostStats struct {
+	Requests    int64
+	Attempts    int64
+	Retries     int64
+	Failures    int64
+	InFlight    int64
+	BackoffTime time.Duration
+	// ConsecutiveFailures is the number of failed requests since the last
+	// one that succeeded. A growing value means the host is degraded.
+	ConsecutiveFailures int64
+	// LastStatus is the status of the last request that got a response
+	LastStatus int
+	// LastFailure is when the last failure finished, and LastError why it
+	// failed
+	LastFailure time.Time
+	LastError   string
+	// CircuitOpen reports whether WithCircuitBreaker has stopped sending
+	// requests to the host, and CircuitOpenedAt when it last opened
+	CircuitOpen     bool
+	CircuitOpenedAt time.Time
+	// Rejected is the number of requests failed with ErrCircuitOpen
+	// without being sent
+	Rejected int64
+
+	// probing is set while the trial request let through after the
+	// cooldown is in flight
+	probing bool
+}
+
+// circuitBreaker is the configuration set by WithCircuitBreaker
+type circuitBreaker struct {
+	threshold int64
+	cooldown  time.Duration
+}
+
+// failed reports whether a request with the given status and error counts
+// as a failure of the host. Requests canceled by their context do not.
+func failed(status int, err error) bool {
+	if errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded) {
+		return false
+	}
+	if status == 0 {
+		return err != nil
+	}
+	return status >= 500 || status == http.StatusTooManyRequests
+}
+
+// clientStats records a Client's counters; a nil clientStats records nothing
+type clientStats struct {
+	mu            sync.Mutex
+	hosts         map[string]*HostStats
+	rateLimitWait time.Duration
+	// breaker, if set, opens a host's circuit after repeated failures
+	breaker *circuitBreaker
+}
+
+func newClientStats() *clientStats {
+	return &clientStats{hosts: make(map[string]*HostStats)}
+}
+
+// host returns the counters for host; s.mu must be held
+func (s *clientStats) host(host string) *HostStats {
+	h, ok := s.hosts[host]
+	if !ok {
+		h = &HostStats{}
+		s.hosts[host] = h
+	}
+	return h
+}
+
+// allow reports whether a request may be sent to host. While the host's
+// circuit is open it only lets one trial request through after each
+// cooldown, reported by probe, which must be passed on to finished.
+func (s *clientStats) allow(host string) (ok, probe bool) {
+	if s == nil || s.breaker == nil {
+		return true, false
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	h := s.host(host)
+	if !h.CircuitOpen {
+		return true, false
+	}
+	if h.probing || time.Since(h.CircuitOpenedAt) < s.breaker.cooldown {
+		h.Rejected++
+		return false, false
+	}
+	h.probing = true
+	return true, true
+}
+
+// started records the start of a logical request
+func (s *clientStats) started(host string) {
+	if s == nil {
+		return
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	h := s.host(host)
+	h.Requests++
+	h.InFlight++
+}
+
+// attempted records one attempt of a request
+func (s *clientStats) attempted(host string, retry bool) {
+	if s == nil {
+		return
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	h := s.host(host)
+	h.Attempts++
+	if retry {
+		h.Retries++
+	}
+}
+
+// waited records time spent backing off before a retry
+func (s *clientStats) waited(host string, d time.Duration) {
+	if s == nil {
+		return
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.host(host).BackoffTime += d
+}
+
+// rateLimited records time spent waiting for the rate limiter
+func (s *clientStats) rateLimited(d time.Duration) {
+	if s == nil {
+		return
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.rateLimitWait += d
+}
+
+// finished records the outcome of a logical request; probe reports whether
+// it was the circuit's trial request. Requests started before the circuit
+// opened may finish while the trial is in flight and must not end it.
+func (s *clientStats) finished(host string, status int, err error, probe bool) {
+	if s == nil {
+		return
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	h := s.host(host)
+	h.InFlight--
+	if probe {
+		h.probing = false
+	}
+	if status != 0 {
+		h.LastStatus = status
+	}
+
+	switch {
+	case failed(status, err):
+		h.Failures++
+		h.ConsecutiveFailures++
+		h.LastFailure = time.Now()
+		if err != nil {
+			h.LastError = err.Error()
+		} else {
+			h.LastError = http.StatusText(status)
+		}
+		if s.breaker != nil && h.ConsecutiveFailures >= s.breaker.threshold {
+			h.CircuitOpen = true
+			h.CircuitOpenedAt = h.LastFailure
+		}
+	case err == nil:
+		h.ConsecutiveFailures = 0
+		h.CircuitOpen = false
+	}
+}
+
+// snapshot copies the counters into a Stats
+func (s *clientStats) snapshot() Stats {
+	stats := Stats{Hosts: make(map[string]HostStats)}
+	if s == nil {
+		return stats
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	stats.RateLimitWait = s.rateLimitWait
+	for name, h := range s.hosts {
+		stats.Hosts[name] = *h
+		stats.Requests += h.Requests
+		stats.Attempts += h.Attempts
+		stats.Retries += h.Retries
+		stats.Failures += h.Failures
+		stats.InFlight += h.InFlight
+		stats.BackoffTime += h.BackoffTime
+		if h.CircuitOpen {
+			stats.OpenCircuits++
+		}
+	}
+	return stats
+}
+
+// Stats returns a snapshot of the client's request, retry and backoff
+// counters and circuit state, in total and per host, for diagnosing a
+// degraded dependency
+func (c *Client) Stats() Stats {
+	return c.stats.snapshot()
+}
+
+// PublishExpvar publishes the client's Stats as the expvar variable name,
+// served as JSON at /debug/vars by the expvar handler. Like expvar.Publish,
+// it panics if name is already in use.
+func (c *Client) PublishExpvar(name string) {
+	expvar.Publish(name, expvar.Func(func() any {
+		return c.Stats()
+	}))
+}
+
+// sortedHosts returns the host names in stats in order
+func sortedHosts(stats Stats) []string {
+	hosts := make([]string, 0, len(stats.Hosts))
+	for host := range stats.Hosts {
+		hosts = append(hosts, host)
+	}
+	sort.Strings(hosts)
+	return hosts
+}
@@ -0,0 +1,285 @@
+package rest
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"expvar"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestFailed(t *testing.T) {
+	tests := []struct {
+		name   string
+		status int
+		err    error
+		want   bool
+	}{
+		{"success", http.StatusOK, nil, false},
+		{"client error", http.StatusNotFound, nil, false},
+		{"server error", http.StatusBadGateway, nil, true},
+		{"too many requests", http.StatusTooManyRequests, nil, true},
+		{"connection error", 0, errors.New("connection refused"), true},
+		{"canceled", 0, context.Canceled, false},
+		{"deadline", 0, fmt.Errorf("get: %w", context.DeadlineExceeded), false},
+	}
+
+	for _, tt := range tests {
+		if got := failed(tt.status, tt.err); got != tt.want {
+			t.Errorf("%s: expected %v, got %v", tt.name, tt.want, got)
+		}
+	}
+}
+
+func TestClient_Stats(t *testing.T) {
+	var attempts atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/down" || attempts.Add(1) == 1 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		w.Write([]byte(`{}`))
+	}))
+	defer server.Close()
+
+	client, _ := NewClient(WithBaseURL(server.URL), WithRetryPolicy(fastRetryPolicy()))
+	host := strings.TrimPrefix(server.URL, "http://")
+
+	if err := client.Get(context.Background(), "/orders", nil); err != nil {
+		t.Fatalf("Get() error = %v", err)
+	}
+	client.Get(context.Background(), "/down", nil)
+	client.Get(context.Background(), "/down", nil)
+
+	stats := client.Stats()
+	policy := fastRetryPolicy()
+	wantAttempts := int64(2 + 2*policy.MaxAttempts)
+	if stats.Requests != 3 || stats.Attempts != wantAttempts || stats.Retries != wantAttempts-3 {
+		t.Errorf("Expected 3 requests, %d attempts and %d retries, got %+v", wantAttempts, wantAttempts-3, stats)
+	}
+	if stats.Failures != 2 || stats.InFlight != 0 {
+		t.Errorf("Expected 2 failures and none in flight, got %+v", stats)
+	}
+	if stats.BackoffTime <= 0 {
+		t.Errorf("Expected backoff time to be recorded, got %v", stats.BackoffTime)
+	}
+
+	h, ok := stats.Hosts[host]
+	if !ok {
+		t.Fatalf("Expected stats for %s, got %v", host, stats.Hosts)
+	}
+	if h.ConsecutiveFailures != 2 || h.LastStatus != http.StatusServiceUnavailable {
+		t.Errorf("Expected 2 consecutive failures with status 503, got %+v", h)
+	}
+	if h.LastError == "" || h.LastFailure.IsZero() {
+		t.Errorf("Expected the last failure to be recorded, got %+v", h)
+	}
+
+	// A success resets the failure streak
+	client.Get(context.Background(), "/orders", nil)
+	if got := client.Stats().Hosts[host].ConsecutiveFailures; got != 0 {
+		t.Errorf("Expected consecutive failures to reset, got %d", got)
+	}
+}
+
+func TestClient_Stats_ConnectionError(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	url := server.URL
+	server.Close()
+
+	policy := fastRetryPolicy()
+	policy.MaxAttempts = 1
+	client, _ := NewClient(WithBaseURL(url), WithRetryPolicy(policy))
+	client.Get(context.Background(), "/items", nil)
+
+	h := client.Stats().Hosts[strings.TrimPrefix(url, "http://")]
+	if h.Failures != 1 || h.LastStatus != 0 || h.LastError == "" {
+		t.Errorf("Expected a connection failure, got %+v", h)
+	}
+}
+
+func TestClient_Stats_RateLimitWait(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	defer server.Close()
+
+	client, _ := NewClient(WithBaseURL(server.URL), WithRateLimit(50, 1))
+	client.Get(context.Background(), "/", nil)
+	client.Get(context.Background(), "/", nil)
+
+	if wait := client.Stats().RateLimitWait; wait < 5*time.Millisecond {
+		t.Errorf("Expected the second request to wait for the rate limiter, got %v", wait)
+	}
+}
+
+func TestClient_CircuitBreaker(t *testing.T) {
+	var calls, down atomic.Int32
+	down.Store(1)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls.Add(1)
+		if down.Load() == 1 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		w.Write([]byte(`{}`))
+	}))
+	defer server.Close()
+
+	policy := fastRetryPolicy()
+	policy.MaxAttempts = 1
+	client, _ := NewClient(WithBaseURL(server.URL), WithRetryPolicy(policy), WithCircuitBreaker(2, 20*time.Millisecond))
+	host := strings.TrimPrefix(server.URL, "http://")
+	ctx := context.Background()
+
+	client.Get(ctx, "/", nil)
+	client.Get(ctx, "/", nil)
+	err := client.Get(ctx, "/", nil)
+	if !errors.Is(err, ErrCircuitOpen) {
+		t.Fatalf("Expected ErrCircuitOpen, got %v", err)
+	}
+	if n := calls.Load(); n != 2 {
+		t.Errorf("Expected 2 requests to reach the server, got %d", n)
+	}
+	stats := client.Stats()
+	if h := stats.Hosts[host]; !h.CircuitOpen || h.Rejected != 1 || stats.OpenCircuits != 1 {
+		t.Errorf("Expected an open circuit with 1 rejected request, got %+v", stats)
+	}
+
+	// A failed trial after the cooldown keeps the circuit open
+	time.Sleep(30 * time.Millisecond)
+	client.Get(ctx, "/", nil)
+	if err := client.Get(ctx, "/", nil); !errors.Is(err, ErrCircuitOpen) {
+		t.Errorf("Expected the circuit to stay open, got %v", err)
+	}
+
+	// A successful trial closes it
+	down.Store(0)
+	time.Sleep(30 * time.Millisecond)
+	if err := client.Get(ctx, "/", nil); err != nil {
+		t.Fatalf("Expected the trial request to succeed, got %v", err)
+	}
+	if err := client.Get(ctx, "/", nil); err != nil {
+		t.Errorf("Expected the circuit to close, got %v", err)
+	}
+	if stats := client.Stats(); stats.OpenCircuits != 0 || stats.Hosts[host].CircuitOpen {
+		t.Errorf("Expected no open circuits, got %+v", stats)
+	}
+}
+
+func TestClientStats_CircuitBreakerProbe(t *testing.T) {
+	const host = "api.example.com"
+	s := newClientStats()
+	s.breaker = &circuitBreaker{threshold: 1, cooldown: 0}
+
+	// A request started before the circuit opens is still in flight
+	s.started(host)
+	s.started(host)
+	s.finished(host, http.StatusBadGateway, nil, false)
+
+	ok, probe := s.allow(host)
+	if !ok || !probe {
+		t.Fatalf("Expected a trial request after the cooldown, got ok=%v probe=%v", ok, probe)
+	}
+	s.started(host)
+
+	// The older request failing must not end the trial
+	s.finished(host, http.StatusBadGateway, nil, false)
+	if ok, _ := s.allow(host); ok {
+		t.Error("Expected requests to be rejected while the trial is in flight")
+	}
+
+	s.finished(host, http.StatusOK, nil, true)
+	if ok, probe := s.allow(host); !ok || probe {
+		t.Errorf("Expected the circuit to close after a successful trial, got ok=%v probe=%v", ok, probe)
+	}
+}
+
+func TestClient_CircuitBreakerFailover(t *testing.T) {
+	primary := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusBadGateway)
+	}))
+	defer primary.Close()
+	var fallbackCalls atomic.Int32
+	fallback := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fallbackCalls.Add(1)
+		w.Write([]byte(`{}`))
+	}))
+	defer fallback.Close()
+
+	policy := fastRetryPolicy()
+	policy.MaxAttempts = 1
+	client, _ := NewClient(
+		WithBaseURL(primary.URL),
+		WithFallbackBaseURLs(fallback.URL),
+		WithRetryPolicy(policy),
+		WithCircuitBreaker(1, time.Minute),
+	)
+
+	for i := 0; i < 3; i++ {
+		if err := client.Get(context.Background(), "/", nil); err != nil {
+			t.Fatalf("Expected the fallback to answer, got %v", err)
+		}
+	}
+	h := client.Stats().Hosts[strings.TrimPrefix(primary.URL, "http://")]
+	if h.Attempts != 1 || h.Rejected != 2 {
+		t.Errorf("Expected the primary to be skipped once its circuit opened, got %+v", h)
+	}
+}
+
+// expvarRuns numbers the expvar names published by tests, since expvar
+// names cannot be reused when tests run more than once
+var expvarRuns atomic.Int32
+
+func TestClient_PublishExpvar(t *testing.T) {
+	client, _ := NewClient()
+	client.stats.started("api.example.com")
+	name := fmt.Sprintf("%s_%d", t.Name(), expvarRuns.Add(1))
+	client.PublishExpvar(name)
+
+	var stats Stats
+	if err := json.Unmarshal([]byte(expvar.Get(name).String()), &stats); err != nil {
+		t.Fatalf("Expected JSON stats, got error %v", err)
+	}
+	if stats.Hosts["api.example.com"].InFlight != 1 {
+		t.Errorf("Expected published stats, got %+v", stats)
+	}
+}
+
+func TestPrometheusMetrics_AddClient(t *testing.T) {
+	client, _ := NewClient()
+	client.stats.started("api.example.com")
+	client.stats.attempted("api.example.com", false)
+	client.stats.attempted("api.example.com", true)
+	client.stats.waited("api.example.com", 1500*time.Millisecond)
+	client.stats.finished("api.example.com", http.StatusBadGateway, nil, false)
+	client.stats.rateLimited(250 * time.Millisecond)
+
+	m := NewPrometheusMetrics("svc")
+	m.AddClient("inventory", client)
+
+	var b strings.Builder
+	m.WriteTo(&b)
+	body := b.String()
+
+	labels := `client="inventory",host="api.example.com"`
+	for _, line := range []string{
+		"# TYPE svc_http_client_attempts_total counter",
+		`svc_http_client_attempts_total{` + labels + `} 2`,
+		`svc_http_client_failures_total{` + labels + `} 1`,
+		"# TYPE svc_http_client_consecutive_failures gauge",
+		`svc_http_client_consecutive_failures{` + labels + `} 1`,
+		"# TYPE svc_http_client_circuit_open gauge",
+		`svc_http_client_circuit_open{` + labels + `} 0`,
+		`svc_http_client_backoff_seconds_total{` + labels + `} 1.5`,
+		`svc_http_client_rate_limit_wait_seconds_total{client="inventory"} 0.25`,
+	} {
+		if !strings.Contains(body, line+"\n") {
+			t.Errorf("Expected line %q in output:\n%s", line, body)
+		}
+	}
+}
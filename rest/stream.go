@@ -0,0 +1,82 @@
+package rest
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+)
+
+// StreamResponse is a successful response whose body has not been read.
+// Callers must close Body when done.
+type StreamResponse struct {
+	StatusCode    int
+	Header        http.Header
+	ContentLength int64
+	Body          io.ReadCloser
+}
+
+// Close closes the response body
+func (s *StreamResponse) Close() error {
+	return s.Body.Close()
+}
+
+// Stream performs an HTTP request and returns the unread response body, for
+// large downloads or line-delimited feeds that should not be buffered.
+// Non-2xx responses are returned as a *ClientError, exactly like Request.
+func (c *Client) Stream(ctx context.Context, method, path string, body interface{}) (*StreamResponse, error) {
+	resp, err := c.do(ctx, method, path, body, "*/*")
+	if err != nil {
+		return nil, err
+	}
+
+	return &StreamResponse{
+		StatusCode:    resp.StatusCode,
+		Header:        resp.Header,
+		ContentLength: resp.ContentLength,
+		Body:          resp.Body,
+	}, nil
+}
+
+// GetStream makes a streaming GET request
+func (c *Client) GetStream(ctx context.Context, path string) (*StreamResponse, error) {
+	return c.Stream(ctx, http.MethodGet, path, nil)
+}
+
+// DecodeNDJSON decodes newline-delimited JSON from r, calling fn once per
+// value. Blank lines are skipped. Decoding stops at the first error returned
+// by fn.
+func DecodeNDJSON[T any](r io.Reader, fn func(T) error) error {
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 0, 64*1024), maxNDJSONLine)
+
+	for scanner.Scan() {
+		line := scanner.Bytes()
+		if len(line) == 0 {
+			continue
+		}
+
+		var v T
+		if err := json.Unmarshal(line, &v); err != nil {
+			return &ClientError{
+				Err:     ErrInvalidResponse,
+				Message: "failed to parse stream line: " + err.Error(),
+			}
+		}
+		if err := fn(v); err != nil {
+			return err
+		}
+	}
+
+	if err := scanner.Err(); err != nil {
+		return &ClientError{
+			Err:     ErrConnectionFailed,
+			Message: "failed to read stream: " + err.Error(),
+		}
+	}
+	return nil
+}
+
+// maxNDJSONLine is the longest single record DecodeNDJSON accepts
+const maxNDJSONLine = 10 << 20
@@ -0,0 +1,133 @@
+package rest
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/StairSupplies/go-core/logger"
+)
+
+func newStreamTestClient(t *testing.T, handler http.HandlerFunc) *Client {
+	t.Helper()
+	server := httptest.NewServer(handler)
+	t.Cleanup(server.Close)
+
+	client, err := NewClient(WithBaseURL(server.URL), WithLogger(logger.NewNopLogger()))
+	if err != nil {
+		t.Fatalf("NewClient() error = %v", err)
+	}
+	return client
+}
+
+func TestClientStream(t *testing.T) {
+	payload := strings.Repeat("0123456789", 100000)
+
+	client := newStreamTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet || r.URL.Path != "/exports/orders.csv" {
+			t.Errorf("Unexpected request %s %s", r.Method, r.URL.Path)
+		}
+		if r.Header.Get("Accept") != "*/*" {
+			t.Errorf("Expected Accept */*, got %q", r.Header.Get("Accept"))
+		}
+		w.Header().Set("Content-Type", "text/csv")
+		io.WriteString(w, payload)
+	})
+
+	resp, err := client.GetStream(context.Background(), "/exports/orders.csv")
+	if err != nil {
+		t.Fatalf("GetStream() error = %v", err)
+	}
+	defer resp.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		t.Errorf("Expected status 200, got %d", resp.StatusCode)
+	}
+	if resp.Header.Get("Content-Type") != "text/csv" {
+		t.Errorf("Expected Content-Type text/csv, got %q", resp.Header.Get("Content-Type"))
+	}
+
+	n, err := io.Copy(io.Discard, resp.Body)
+	if err != nil {
+		t.Fatalf("Failed to read stream: %v", err)
+	}
+	if n != int64(len(payload)) {
+		t.Errorf("Expected %d bytes, got %d", len(payload), n)
+	}
+}
+
+func TestClientStream_Error(t *testing.T) {
+	client := newStreamTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+		io.WriteString(w, `{"message":"export not found","code":"NOT_FOUND"}`)
+	})
+
+	resp, err := client.Stream(context.Background(), http.MethodPost, "/exports", map[string]string{"type": "orders"})
+	if resp != nil {
+		t.Error("Expected nil response on error")
+	}
+	if !errors.Is(err, ErrResourceNotFound) {
+		t.Fatalf("Expected ErrResourceNotFound, got %v", err)
+	}
+
+	var clientErr *ClientError
+	if !errors.As(err, &clientErr) || clientErr.Code != "NOT_FOUND" || clientErr.Message != "export not found" {
+		t.Errorf("Unexpected client error %+v", clientErr)
+	}
+}
+
+func TestDecodeNDJSON(t *testing.T) {
+	type event struct {
+		ID int `json:"id"`
+	}
+
+	client := newStreamTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/x-ndjson")
+		for i := 1; i <= 3; i++ {
+			fmt.Fprintf(w, "{\"id\":%d}\n\n", i)
+			w.(http.Flusher).Flush()
+		}
+	})
+
+	resp, err := client.GetStream(context.Background(), "/events")
+	if err != nil {
+		t.Fatalf("GetStream() error = %v", err)
+	}
+	defer resp.Close()
+
+	var ids []int
+	err = DecodeNDJSON(resp.Body, func(e event) error {
+		ids = append(ids, e.ID)
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("DecodeNDJSON() error = %v", err)
+	}
+	if fmt.Sprint(ids) != "[1 2 3]" {
+		t.Errorf("Expected ids [1 2 3], got %v", ids)
+	}
+
+	t.Run("invalid line", func(t *testing.T) {
+		err := DecodeNDJSON(strings.NewReader("{\"id\":1}\nnot json\n"), func(event) error { return nil })
+		if !errors.Is(err, ErrInvalidResponse) {
+			t.Errorf("Expected ErrInvalidResponse, got %v", err)
+		}
+	})
+
+	t.Run("callback error stops decoding", func(t *testing.T) {
+		stop := errors.New("stop")
+		calls := 0
+		err := DecodeNDJSON(strings.NewReader("{\"id\":1}\n{\"id\":2}\n"), func(event) error {
+			calls++
+			return stop
+		})
+		if !errors.Is(err, stop) || calls != 1 {
+			t.Errorf("Expected to stop after first value, got %v after %d calls", err, calls)
+		}
+	})
+}
@@ -0,0 +1,51 @@
+package rest
+
+import (
+	"crypto/tls"
+	"net/http"
+	"time"
+)
+
+// TransportOptions tunes the connection pool of the transport NewClient
+// creates. The zero value of a limit means no limit, as in http.Transport.
+type TransportOptions struct {
+	// MaxIdleConns limits idle connections across all hosts
+	MaxIdleConns int
+	// MaxIdleConnsPerHost limits idle connections kept for each host.
+	// http.Transport defaults to 2, which causes connection churn when a
+	// service makes concurrent calls to the same host.
+	MaxIdleConnsPerHost int
+	// MaxConnsPerHost limits all connections to each host, including those
+	// in use; further requests wait for a free connection
+	MaxConnsPerHost int
+	// IdleConnTimeout is how long an idle connection is kept open
+	IdleConnTimeout time.Duration
+	// DisableHTTP2 stops the transport from negotiating HTTP/2
+	DisableHTTP2 bool
+}
+
+// DefaultTransportOptions returns pool settings tuned for service-to-service
+// traffic, where most requests go to a handful of hosts
+func DefaultTransportOptions() TransportOptions {
+	return TransportOptions{
+		MaxIdleConns:        100,
+		MaxIdleConnsPerHost: 32,
+		IdleConnTimeout:     90 * time.Second,
+	}
+}
+
+// NewTransport returns a clone of http.DefaultTransport, keeping its proxy,
+// dial and TLS handshake settings, with the pool configured by opts
+func NewTransport(opts TransportOptions) *http.Transport {
+	t := http.DefaultTransport.(*http.Transport).Clone()
+	t.MaxIdleConns = opts.MaxIdleConns
+	t.MaxIdleConnsPerHost = opts.MaxIdleConnsPerHost
+	t.MaxConnsPerHost = opts.MaxConnsPerHost
+	t.IdleConnTimeout = opts.IdleConnTimeout
+	t.ForceAttemptHTTP2 = !opts.DisableHTTP2
+	if opts.DisableHTTP2 {
+		// A non-nil empty map turns off the transport's HTTP/2 upgrade
+		t.TLSNextProto = make(map[string]func(string, *tls.Conn) http.RoundTripper)
+	}
+	return t
+}
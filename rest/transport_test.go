@@ -0,0 +1,97 @@
+package rest
+
+import (
+	"net/http"
+	"testing"
+	"time"
+)
+
+func TestNewClient_DefaultTransport(t *testing.T) {
+	client, err := NewClient()
+	if err != nil {
+		t.Fatalf("NewClient() error = %v", err)
+	}
+
+	transport, ok := client.HTTPClient.Transport.(*http.Transport)
+	if !ok {
+		t.Fatalf("Expected *http.Transport, got %T", client.HTTPClient.Transport)
+	}
+	if transport == http.DefaultTransport {
+		t.Error("Expected a transport of the client's own, got http.DefaultTransport")
+	}
+	if transport.MaxIdleConnsPerHost != 32 {
+		t.Errorf("Expected MaxIdleConnsPerHost 32, got %d", transport.MaxIdleConnsPerHost)
+	}
+	if transport.MaxIdleConns != 100 {
+		t.Errorf("Expected MaxIdleConns 100, got %d", transport.MaxIdleConns)
+	}
+	if transport.IdleConnTimeout != 90*time.Second {
+		t.Errorf("Expected IdleConnTimeout 90s, got %v", transport.IdleConnTimeout)
+	}
+	if !transport.ForceAttemptHTTP2 {
+		t.Error("Expected HTTP/2 to be enabled")
+	}
+	if transport.Proxy == nil {
+		t.Error("Expected proxy settings to be kept from http.DefaultTransport")
+	}
+}
+
+func TestNewClient_TransportOptions(t *testing.T) {
+	client, err := NewClient(
+		WithMaxIdleConnsPerHost(64),
+		WithMaxConnsPerHost(128),
+		WithIdleConnTimeout(30*time.Second),
+		WithHTTP2(false),
+	)
+	if err != nil {
+		t.Fatalf("NewClient() error = %v", err)
+	}
+
+	transport := client.HTTPClient.Transport.(*http.Transport)
+	if transport.MaxIdleConnsPerHost != 64 {
+		t.Errorf("Expected MaxIdleConnsPerHost 64, got %d", transport.MaxIdleConnsPerHost)
+	}
+	if transport.MaxConnsPerHost != 128 {
+		t.Errorf("Expected MaxConnsPerHost 128, got %d", transport.MaxConnsPerHost)
+	}
+	if transport.IdleConnTimeout != 30*time.Second {
+		t.Errorf("Expected IdleConnTimeout 30s, got %v", transport.IdleConnTimeout)
+	}
+	if transport.ForceAttemptHTTP2 || transport.TLSNextProto == nil {
+		t.Error("Expected HTTP/2 to be disabled")
+	}
+	if transport.MaxIdleConns != 100 {
+		t.Errorf("Expected untouched settings to keep their defaults, got MaxIdleConns %d", transport.MaxIdleConns)
+	}
+
+	client, _ = NewClient(WithTransportOptions(TransportOptions{MaxIdleConnsPerHost: 4}))
+	transport = client.HTTPClient.Transport.(*http.Transport)
+	if transport.MaxIdleConnsPerHost != 4 || transport.MaxIdleConns != 0 {
+		t.Errorf("Expected WithTransportOptions to replace all settings, got %d and %d",
+			transport.MaxIdleConnsPerHost, transport.MaxIdleConns)
+	}
+}
+
+func TestNewClient_CustomTransportKept(t *testing.T) {
+	custom := &http.Transport{MaxIdleConnsPerHost: 7}
+	client, err := NewClient(
+		WithHTTPClient(&http.Client{Transport: custom}),
+		WithMaxIdleConnsPerHost(64),
+	)
+	if err != nil {
+		t.Fatalf("NewClient() error = %v", err)
+	}
+	if client.HTTPClient.Transport != custom {
+		t.Error("Expected the custom transport to be used unchanged")
+	}
+	if custom.MaxIdleConnsPerHost != 7 {
+		t.Errorf("Expected the custom transport not to be modified, got %d", custom.MaxIdleConnsPerHost)
+	}
+
+	// A client without a transport gets the tuned one, without being modified
+	bare := &http.Client{}
+	client, _ = NewClient(WithHTTPClient(bare))
+	if _, ok := client.HTTPClient.Transport.(*http.Transport); !ok || bare.Transport != nil {
+		t.Error("Expected a tuned transport on a copy of the supplied client")
+	}
+}
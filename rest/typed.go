@@ -0,0 +1,60 @@
+package rest
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+)
+
+// Do performs a request like Client.Request and returns the response decoded
+// into a new T. A nil body sends no request body.
+func Do[T any](ctx context.Context, c *Client, method, path string, body any) (T, error) {
+	var response T
+	if err := c.Request(ctx, method, path, body, &response); err != nil {
+		var zero T
+		return zero, err
+	}
+	return response, nil
+}
+
+// Get makes a GET request and returns the response decoded into a T
+func Get[T any](ctx context.Context, c *Client, path string) (T, error) {
+	return Do[T](ctx, c, http.MethodGet, path, nil)
+}
+
+// Post makes a POST request with body and returns the response decoded into a Resp
+func Post[Req, Resp any](ctx context.Context, c *Client, path string, body Req) (Resp, error) {
+	return Do[Resp](ctx, c, http.MethodPost, path, body)
+}
+
+// Put makes a PUT request with body and returns the response decoded into a Resp
+func Put[Req, Resp any](ctx context.Context, c *Client, path string, body Req) (Resp, error) {
+	return Do[Resp](ctx, c, http.MethodPut, path, body)
+}
+
+// Patch makes a PATCH request with body and returns the response decoded into a Resp
+func Patch[Req, Resp any](ctx context.Context, c *Client, path string, body Req) (Resp, error) {
+	return Do[Resp](ctx, c, http.MethodPatch, path, body)
+}
+
+// Delete makes a DELETE request and returns the response decoded into a T
+func Delete[T any](ctx context.Context, c *Client, path string) (T, error) {
+	return Do[T](ctx, c, http.MethodDelete, path, nil)
+}
+
+// ErrorBody decodes the body of the error response behind err into an E,
+// for services whose errors carry more than a message and code. It reports
+// false if err is not a *ClientError with a JSON body that decodes into E.
+func ErrorBody[E any](err error) (E, bool) {
+	var body E
+	var clientErr *ClientError
+	if !errors.As(err, &clientErr) || len(clientErr.Body) == 0 {
+		return body, false
+	}
+	if json.Unmarshal(clientErr.Body, &body) != nil {
+		var zero E
+		return zero, false
+	}
+	return body, true
+}
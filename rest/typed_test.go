@@ -0,0 +1,120 @@
+package rest
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+type typedOrder struct {
+	ID    string `json:"id"`
+	Total int    `json:"total"`
+}
+
+func TestTypedMethods(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var in typedOrder
+		if r.Body != nil {
+			json.NewDecoder(r.Body).Decode(&in)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(map[string]any{"method": r.Method, "path": r.URL.Path, "id": in.ID})
+	}))
+	defer server.Close()
+
+	client, _ := NewClient(WithBaseURL(server.URL))
+	ctx := context.Background()
+	type echo struct {
+		Method string `json:"method"`
+		Path   string `json:"path"`
+		ID     string `json:"id"`
+	}
+
+	tests := []struct {
+		name   string
+		method string
+		id     string
+		call   func() (echo, error)
+	}{
+		{"Get", http.MethodGet, "", func() (echo, error) { return Get[echo](ctx, client, "/orders") }},
+		{"Post", http.MethodPost, "A-1", func() (echo, error) {
+			return Post[typedOrder, echo](ctx, client, "/orders", typedOrder{ID: "A-1"})
+		}},
+		{"Put", http.MethodPut, "A-2", func() (echo, error) {
+			return Put[typedOrder, echo](ctx, client, "/orders", typedOrder{ID: "A-2"})
+		}},
+		{"Patch", http.MethodPatch, "A-3", func() (echo, error) {
+			return Patch[*typedOrder, echo](ctx, client, "/orders", &typedOrder{ID: "A-3"})
+		}},
+		{"Delete", http.MethodDelete, "", func() (echo, error) { return Delete[echo](ctx, client, "/orders") }},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := tt.call()
+			if err != nil {
+				t.Fatalf("Expected no error, got %v", err)
+			}
+			want := echo{Method: tt.method, Path: "/orders", ID: tt.id}
+			if got != want {
+				t.Errorf("Expected %+v, got %+v", want, got)
+			}
+		})
+	}
+}
+
+func TestDo_Error(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusConflict)
+		w.Write([]byte(`{"message":"order already shipped","code":"ORDER_SHIPPED","order_id":"A-1","shipped_at":"2024-03-04"}`))
+	}))
+	defer server.Close()
+
+	client, _ := NewClient(WithBaseURL(server.URL), WithRetries(0))
+
+	got, err := Get[typedOrder](context.Background(), client, "/orders/A-1")
+	if got != (typedOrder{}) {
+		t.Errorf("Expected zero value on error, got %+v", got)
+	}
+	if !errors.Is(err, ErrInvalidRequest) {
+		t.Fatalf("Expected ErrInvalidRequest, got %v", err)
+	}
+
+	type conflict struct {
+		Code      string `json:"code"`
+		OrderID   string `json:"order_id"`
+		ShippedAt string `json:"shipped_at"`
+	}
+	body, ok := ErrorBody[conflict](err)
+	if !ok {
+		t.Fatal("Expected error body to decode")
+	}
+	want := conflict{Code: "ORDER_SHIPPED", OrderID: "A-1", ShippedAt: "2024-03-04"}
+	if body != want {
+		t.Errorf("Expected %+v, got %+v", want, body)
+	}
+}
+
+func TestErrorBody_NoBody(t *testing.T) {
+	tests := []struct {
+		name string
+		err  error
+	}{
+		{"nil", nil},
+		{"other error", errors.New("boom")},
+		{"no body", &ClientError{Err: ErrConnectionFailed}},
+		{"not JSON", &ClientError{Err: ErrServerError, Body: []byte("<html>")}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, ok := ErrorBody[map[string]any](tt.err); ok {
+				t.Error("Expected ok = false")
+			}
+		})
+	}
+}
@@ -0,0 +1,179 @@
+package router
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strings"
+
+	"github.com/StairSupplies/go-core/api"
+	"github.com/StairSupplies/go-core/cryptoutils"
+	"github.com/StairSupplies/go-core/ctxutils"
+)
+
+// Authentication errors. Authenticators return them, possibly wrapped, and
+// Auth reports them as 401 api errors.
+var (
+	// ErrMissingCredentials means the request carried no credentials
+	ErrMissingCredentials = errors.New("missing credentials")
+	// ErrInvalidCredentials means the credentials were not recognized
+	ErrInvalidCredentials = errors.New("invalid credentials")
+)
+
+// Principal is the authenticated caller of a request
+type Principal struct {
+	// ID identifies the caller, such as a user ID or the name of an API key
+	ID string
+	// TenantID is the tenant the caller belongs to, if any
+	TenantID string
+	// Scopes lists the permissions granted to the caller
+	Scopes []string
+	// Claims holds any other attributes, such as the claims of a JWT
+	Claims map[string]any
+}
+
+// HasScope reports whether the principal was granted scope
+func (p Principal) HasScope(scope string) bool {
+	for _, s := range p.Scopes {
+		if s == scope {
+			return true
+		}
+	}
+	return false
+}
+
+// Authenticator identifies the caller of a request from its credentials
+type Authenticator interface {
+	// Authenticate returns the caller of r, or an error if r carries no
+	// valid credentials. Returning an api.Error controls the response.
+	Authenticate(r *http.Request) (Principal, error)
+}
+
+// AuthenticatorFunc adapts a function to the Authenticator interface
+type AuthenticatorFunc func(r *http.Request) (Principal, error)
+
+// Authenticate implements Authenticator
+func (f AuthenticatorFunc) Authenticate(r *http.Request) (Principal, error) {
+	return f(r)
+}
+
+// principalKey is the context key for the authenticated Principal
+var principalKey = ctxutils.NewKey[Principal]("principal")
+
+// WithPrincipal returns a copy of ctx carrying p, with its ID and tenant also
+// stored with ctxutils.WithUserID and ctxutils.WithTenantID
+func WithPrincipal(ctx context.Context, p Principal) context.Context {
+	ctx = principalKey.WithValue(ctx, p)
+	if p.ID != "" {
+		ctx = ctxutils.WithUserID(ctx, p.ID)
+	}
+	if p.TenantID != "" {
+		ctx = ctxutils.WithTenantID(ctx, p.TenantID)
+	}
+	return ctx
+}
+
+// PrincipalFromContext returns the Principal stored by Auth
+func PrincipalFromContext(ctx context.Context) (Principal, bool) {
+	return principalKey.Value(ctx)
+}
+
+// Auth is a middleware that authenticates every request with a and stores
+// the caller in the request context, where handlers read it with
+// PrincipalFromContext. Requests that fail authentication get a 401 api
+// error, or the api.Error returned by a. Apply it to the routes that need
+// it:
+//
+//	jwtAuth, err := router.NewJWTAuthenticator(router.JWTOptions{Secret: secret})
+//	...
+//	r.Mount("/api", r.Group(func(r chi.Router) {
+//		r.Use(router.Auth(jwtAuth))
+//		r.Get("/orders", listOrders)
+//	}))
+func Auth(a Authenticator) func(next http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			p, err := a.Authenticate(r)
+			if err != nil {
+				var apiErr api.Error
+				if !errors.As(err, &apiErr) {
+					apiErr = api.UnauthorizedError(err)
+				}
+				if apiErr.StatusCode == http.StatusUnauthorized {
+					w.Header().Set("WWW-Authenticate", challenge(a))
+				}
+				api.WriteError(w, apiErr)
+				return
+			}
+			next.ServeHTTP(w, r.WithContext(WithPrincipal(r.Context(), p)))
+		})
+	}
+}
+
+// challenge returns the WWW-Authenticate value for a 401 from a
+func challenge(a Authenticator) string {
+	if c, ok := a.(interface{ challenge() string }); ok {
+		return c.challenge()
+	}
+	return "Bearer"
+}
+
+// bearerToken returns the token of an "Authorization: Bearer" header
+func bearerToken(r *http.Request) (string, bool) {
+	scheme, token, ok := strings.Cut(r.Header.Get("Authorization"), " ")
+	if !ok || !strings.EqualFold(scheme, "Bearer") {
+		return "", false
+	}
+	token = strings.TrimSpace(token)
+	return token, token != ""
+}
+
+// DefaultAPIKeyHeader is the header APIKeyAuthenticator reads by default
+const DefaultAPIKeyHeader = "X-Api-Key"
+
+// APIKeyAuthenticator authenticates requests by a static API key sent in a
+// header, for service-to-service calls and integrations
+type APIKeyAuthenticator struct {
+	header string
+	keys   map[string]Principal
+}
+
+// NewAPIKeyAuthenticator creates an APIKeyAuthenticator that reads the key
+// from header, DefaultAPIKeyHeader if empty, and maps each accepted key to
+// its Principal. Keys are compared in constant time.
+func NewAPIKeyAuthenticator(header string, keys map[string]Principal) *APIKeyAuthenticator {
+	if header == "" {
+		header = DefaultAPIKeyHeader
+	}
+	copied := make(map[string]Principal, len(keys))
+	for k, p := range keys {
+		copied[k] = p
+	}
+	return &APIKeyAuthenticator{header: header, keys: copied}
+}
+
+// Authenticate implements Authenticator
+func (a *APIKeyAuthenticator) Authenticate(r *http.Request) (Principal, error) {
+	key := r.Header.Get(a.header)
+	if key == "" {
+		return Principal{}, ErrMissingCredentials
+	}
+
+	// Compare against every key so timing does not reveal which matched
+	var principal Principal
+	found := false
+	for k, p := range a.keys {
+		if cryptoutils.Equal(k, key) {
+			principal, found = p, true
+		}
+	}
+	if !found {
+		return Principal{}, ErrInvalidCredentials
+	}
+	return principal, nil
+}
+
+// challenge names the API key header in WWW-Authenticate
+func (a *APIKeyAuthenticator) challenge() string {
+	return `APIKey header="` + a.header + `"`
+}
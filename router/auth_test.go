@@ -0,0 +1,143 @@
+package router
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/StairSupplies/go-core/api"
+	"github.com/StairSupplies/go-core/ctxutils"
+)
+
+// principalHandler records the principal and user ID of each request
+func principalHandler(got *Principal, userID *string) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		*got, _ = PrincipalFromContext(r.Context())
+		*userID = ctxutils.UserID(r.Context())
+		w.WriteHeader(http.StatusOK)
+	})
+}
+
+func TestAuth(t *testing.T) {
+	var got Principal
+	var userID string
+	a := AuthenticatorFunc(func(r *http.Request) (Principal, error) {
+		switch r.Header.Get("X-Test") {
+		case "ok":
+			return Principal{ID: "u1", TenantID: "acme", Scopes: []string{"orders:read"}}, nil
+		case "forbidden":
+			return Principal{}, api.ForbiddenError(errors.New("account suspended"))
+		}
+		return Principal{}, ErrMissingCredentials
+	})
+	h := Auth(a)(principalHandler(&got, &userID))
+
+	t.Run("authenticated", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodGet, "/", nil)
+		req.Header.Set("X-Test", "ok")
+		w := httptest.NewRecorder()
+		h.ServeHTTP(w, req)
+
+		if w.Code != http.StatusOK {
+			t.Fatalf("Expected status 200, got %d", w.Code)
+		}
+		if got.ID != "u1" || !got.HasScope("orders:read") || got.HasScope("orders:write") {
+			t.Errorf("Expected principal u1 with orders:read, got %+v", got)
+		}
+		if userID != "u1" {
+			t.Errorf("Expected ctxutils user ID u1, got %q", userID)
+		}
+	})
+
+	t.Run("unauthenticated", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		h.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/", nil))
+
+		if w.Code != http.StatusUnauthorized {
+			t.Fatalf("Expected status 401, got %d", w.Code)
+		}
+		if got := w.Header().Get("WWW-Authenticate"); got != "Bearer" {
+			t.Errorf("Expected WWW-Authenticate Bearer, got %q", got)
+		}
+		var body struct {
+			Error api.Error `json:"error"`
+		}
+		if err := json.Unmarshal(w.Body.Bytes(), &body); err != nil {
+			t.Fatalf("Expected an api error envelope, got %s", w.Body.String())
+		}
+		if body.Error.Message != "missing credentials" {
+			t.Errorf("Expected message %q, got %q", "missing credentials", body.Error.Message)
+		}
+	})
+
+	t.Run("api error", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodGet, "/", nil)
+		req.Header.Set("X-Test", "forbidden")
+		w := httptest.NewRecorder()
+		h.ServeHTTP(w, req)
+
+		if w.Code != http.StatusForbidden {
+			t.Errorf("Expected status 403, got %d", w.Code)
+		}
+		if w.Header().Get("WWW-Authenticate") != "" {
+			t.Error("Expected no WWW-Authenticate header on 403")
+		}
+	})
+}
+
+func TestAPIKeyAuthenticator(t *testing.T) {
+	a := NewAPIKeyAuthenticator("", map[string]Principal{
+		"key-1": {ID: "warehouse"},
+		"key-2": {ID: "erp"},
+	})
+
+	tests := []struct {
+		name    string
+		key     string
+		wantID  string
+		wantErr error
+	}{
+		{"valid", "key-2", "erp", nil},
+		{"missing", "", "", ErrMissingCredentials},
+		{"unknown", "key-3", "", ErrInvalidCredentials},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodGet, "/", nil)
+			if tc.key != "" {
+				req.Header.Set(DefaultAPIKeyHeader, tc.key)
+			}
+			p, err := a.Authenticate(req)
+			if !errors.Is(err, tc.wantErr) {
+				t.Fatalf("Expected error %v, got %v", tc.wantErr, err)
+			}
+			if p.ID != tc.wantID {
+				t.Errorf("Expected principal %q, got %q", tc.wantID, p.ID)
+			}
+		})
+	}
+
+	w := httptest.NewRecorder()
+	Auth(a)(http.NotFoundHandler()).ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/", nil))
+	if got := w.Header().Get("WWW-Authenticate"); got != `APIKey header="X-Api-Key"` {
+		t.Errorf("Expected API key challenge, got %q", got)
+	}
+}
+
+func TestAuth_PerRoute(t *testing.T) {
+	r := New()
+	r.Get("/public", func(w http.ResponseWriter, r *http.Request) {})
+	r.With(Auth(NewAPIKeyAuthenticator("", map[string]Principal{"k": {ID: "svc"}}))).
+		Get("/private", func(w http.ResponseWriter, r *http.Request) {})
+
+	for path, want := range map[string]int{"/public": http.StatusOK, "/private": http.StatusUnauthorized} {
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, httptest.NewRequest(http.MethodGet, path, nil))
+		if w.Code != want {
+			t.Errorf("Expected %s to return %d, got %d", path, want, w.Code)
+		}
+	}
+}
@@ -0,0 +1,146 @@
+package router
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"mime"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/StairSupplies/go-core/logger"
+	"go.uber.org/zap"
+)
+
+// DefaultMaxBodyLogSize is the most of a request body the logger middleware
+// captures when LoggerOptions.MaxBodyLogSize is zero
+const DefaultMaxBodyLogSize = 4 << 10
+
+// DefaultRedactedBodyFields are never logged in clear when request bodies
+// are logged. Fields match case-insensitively at any depth.
+var DefaultRedactedBodyFields = []string{
+	"password",
+	"token",
+	"access_token",
+	"refresh_token",
+	"secret",
+	"client_secret",
+	"api_key",
+}
+
+// bodyLogFields reads up to the configured maximum of r's body, restores the
+// body for the handler, and returns the log fields describing it
+func bodyLogFields(opts LoggerOptions, r *http.Request) []zap.Field {
+	if r.Body == nil || r.Body == http.NoBody {
+		return nil
+	}
+
+	limit := opts.MaxBodyLogSize
+	if limit <= 0 {
+		limit = DefaultMaxBodyLogSize
+	}
+
+	// Read one byte past the limit to tell whether the body was truncated
+	captured, err := io.ReadAll(io.LimitReader(r.Body, limit+1))
+	r.Body = &restoredBody{Reader: io.MultiReader(bytes.NewReader(captured), r.Body), Closer: r.Body}
+	if err != nil || len(captured) == 0 {
+		return nil
+	}
+
+	truncated := int64(len(captured)) > limit
+	if truncated {
+		captured = captured[:limit]
+	}
+
+	fields := []zap.Field{zap.String("req_body", redactBody(opts, r.Header.Get("Content-Type"), captured, truncated))}
+	if truncated {
+		fields = append(fields, zap.Bool("req_body_truncated", true))
+	}
+	return fields
+}
+
+// restoredBody replays the captured prefix of a body before the rest of it
+type restoredBody struct {
+	io.Reader
+	io.Closer
+}
+
+// redactBody returns body as it should be logged. JSON and form bodies have
+// sensitive fields replaced; a JSON body cut off by the size limit cannot be
+// parsed, so it is withheld entirely. Bodies of other types are described
+// rather than logged.
+func redactBody(opts LoggerOptions, contentType string, body []byte, truncated bool) string {
+	mediaType, _, _ := mime.ParseMediaType(contentType)
+	redacted := func(key string) bool {
+		for _, list := range [][]string{DefaultRedactedBodyFields, opts.RedactedBodyFields} {
+			for _, field := range list {
+				if strings.EqualFold(field, key) {
+					return true
+				}
+			}
+		}
+		return false
+	}
+
+	switch {
+	case mediaType == "application/json" || strings.HasSuffix(mediaType, "+json"):
+		if truncated {
+			return logger.RedactedValue
+		}
+		var v any
+		if err := json.Unmarshal(body, &v); err != nil {
+			return logger.RedactedValue
+		}
+		out, err := json.Marshal(redactJSON(v, redacted))
+		if err != nil {
+			return logger.RedactedValue
+		}
+		return string(out)
+
+	case mediaType == "application/x-www-form-urlencoded":
+		values, err := url.ParseQuery(string(body))
+		if err != nil {
+			return logger.RedactedValue
+		}
+		for key := range values {
+			if redacted(key) {
+				values[key] = []string{logger.RedactedValue}
+			}
+		}
+		return values.Encode()
+
+	case strings.HasPrefix(mediaType, "text/"):
+		return string(body)
+
+	default:
+		return "[" + mediaTypeOrUnknown(mediaType) + " body omitted]"
+	}
+}
+
+// redactJSON replaces the values of redacted keys in a decoded JSON value
+func redactJSON(v any, redacted func(string) bool) any {
+	switch x := v.(type) {
+	case map[string]any:
+		for key, value := range x {
+			if redacted(key) {
+				x[key] = logger.RedactedValue
+			} else {
+				x[key] = redactJSON(value, redacted)
+			}
+		}
+	case []any:
+		for i, value := range x {
+			x[i] = redactJSON(value, redacted)
+		}
+	}
+	return v
+}
+
+// mediaTypeOrUnknown names a media type for log output
+func mediaTypeOrUnknown(mediaType string) string {
+	if mediaType == "" {
+		return "untyped"
+	}
+	return mediaType
+}
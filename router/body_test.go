@@ -0,0 +1,88 @@
+package router
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/StairSupplies/go-core/logger"
+)
+
+func TestBodyLogFields(t *testing.T) {
+	opts := LoggerOptions{MaxBodyLogSize: 128, RedactedBodyFields: []string{"card_number"}}
+
+	tests := []struct {
+		name          string
+		contentType   string
+		body          string
+		wantBody      string
+		wantTruncated bool
+	}{
+		{
+			"json redacted at any depth",
+			"application/json; charset=utf-8",
+			`{"user":"ada","Password":"hunter2","payment":{"card_number":"4111"},"items":[{"token":"t"}]}`,
+			`{"Password":"[REDACTED]","items":[{"token":"[REDACTED]"}],"payment":{"card_number":"[REDACTED]"},"user":"ada"}`,
+			false,
+		},
+		{"json suffix type", "application/merge-patch+json", `{"secret":"s"}`, `{"secret":"[REDACTED]"}`, false},
+		{"truncated json withheld", "application/json", `{"note":"` + strings.Repeat("x", 150) + `"}`, logger.RedactedValue, true},
+		{"invalid json withheld", "application/json", `{"password":`, logger.RedactedValue, false},
+		{"form", "application/x-www-form-urlencoded", "user=ada&password=hunter2", "password=%5BREDACTED%5D&user=ada", false},
+		{"text", "text/plain", "hello", "hello", false},
+		{"truncated text", "text/csv", strings.Repeat("a", 130), strings.Repeat("a", 128), true},
+		{"binary", "application/octet-stream", "\x00\x01", "[application/octet-stream body omitted]", false},
+		{"no content type", "", "data", "[untyped body omitted]", false},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodPost, "/", strings.NewReader(tc.body))
+			req.Header.Set("Content-Type", tc.contentType)
+
+			fields := bodyLogFields(opts, req)
+			if len(fields) == 0 {
+				t.Fatal("Expected body fields")
+			}
+			if fields[0].String != tc.wantBody {
+				t.Errorf("Expected body %q, got %q", tc.wantBody, fields[0].String)
+			}
+			if truncated := len(fields) == 2; truncated != tc.wantTruncated {
+				t.Errorf("Expected truncated %v, got %v", tc.wantTruncated, truncated)
+			}
+
+			// The handler still sees the whole body
+			restored, _ := io.ReadAll(req.Body)
+			if string(restored) != tc.body {
+				t.Errorf("Expected the body to be restored, got %q", restored)
+			}
+		})
+	}
+}
+
+func TestBodyLogFields_NoBody(t *testing.T) {
+	req := httptest.NewRequest(http.MethodGet, "/", nil)
+	if fields := bodyLogFields(LoggerOptions{}, req); fields != nil {
+		t.Errorf("Expected no fields for a request without a body, got %v", fields)
+	}
+}
+
+func TestLogger_RequestBodyRestored(t *testing.T) {
+	body := strings.Repeat("z", DefaultMaxBodyLogSize*2)
+
+	var got string
+	handler := Logger(LoggerOptions{LogRequestBody: true})(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		b, _ := io.ReadAll(r.Body)
+		got = string(b)
+	}))
+
+	req := httptest.NewRequest(http.MethodPost, "/upload", strings.NewReader(body))
+	req.Header.Set("Content-Type", "text/plain")
+	handler.ServeHTTP(httptest.NewRecorder(), req)
+
+	if got != body {
+		t.Errorf("Expected the handler to read all %d bytes, got %d", len(body), len(got))
+	}
+}
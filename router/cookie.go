@@ -0,0 +1,179 @@
+package router
+
+import (
+	"encoding/base64"
+	"errors"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/StairSupplies/go-core/cryptoutils"
+)
+
+// MaxCookieSize is the largest cookie, name and value together, that
+// SetSignedCookie writes; browsers drop larger cookies
+const MaxCookieSize = 4096
+
+// Signed cookie errors
+var (
+	// ErrInvalidCookie is returned by GetSignedCookie for a cookie that was
+	// tampered with, signed with an unknown key or has expired
+	ErrInvalidCookie = errors.New("invalid signed cookie")
+	// ErrCookieTooLarge is returned for a cookie larger than MaxCookieSize
+	ErrCookieTooLarge = errors.New("cookie too large")
+	// ErrNoCookieKeys is returned when CookieOptions has no Keys
+	ErrNoCookieKeys = errors.New("no cookie signing keys")
+)
+
+// CookieOptions configures signed cookies. The zero value, apart from Keys,
+// gives secure defaults: Path "/", HttpOnly, Secure and SameSite=Lax.
+type CookieOptions struct {
+	// Keys sign cookies with the first key and verify them with any of
+	// them, so a key can be rotated without logging everyone out. Use at
+	// least 32 random bytes per key.
+	Keys [][]byte
+	// Path defaults to "/"
+	Path   string
+	Domain string
+	// MaxAge is how long the cookie lasts; zero makes a session cookie that
+	// lasts until the browser closes. The expiry is also signed, so an
+	// expired cookie is rejected even if the browser sends it.
+	MaxAge time.Duration
+	// SameSite defaults to http.SameSiteLaxMode
+	SameSite http.SameSite
+	// Insecure allows the cookie over plain HTTP, for local development
+	Insecure bool
+	// AllowScripts lets JavaScript read the cookie, which is HttpOnly otherwise
+	AllowScripts bool
+}
+
+// cookie returns a cookie with the options applied
+func (o CookieOptions) cookie(name, value string) *http.Cookie {
+	c := &http.Cookie{
+		Name:     name,
+		Value:    value,
+		Path:     o.Path,
+		Domain:   o.Domain,
+		Secure:   !o.Insecure,
+		HttpOnly: !o.AllowScripts,
+		SameSite: o.SameSite,
+	}
+	if c.Path == "" {
+		c.Path = "/"
+	}
+	if c.SameSite == 0 {
+		c.SameSite = http.SameSiteLaxMode
+	}
+	if o.MaxAge > 0 {
+		c.MaxAge = int(o.MaxAge.Seconds())
+		c.Expires = time.Now().Add(o.MaxAge).UTC()
+	}
+	return c
+}
+
+// SetSignedCookie sets a cookie holding value with an HMAC-SHA256 signature,
+// so GetSignedCookie can tell whether the client changed it. The value is
+// readable by the client; do not store secrets in it. Call it before the
+// response is written.
+func SetSignedCookie(w http.ResponseWriter, name, value string, opts CookieOptions) error {
+	if len(opts.Keys) == 0 {
+		return ErrNoCookieKeys
+	}
+
+	var expires int64
+	if opts.MaxAge > 0 {
+		expires = time.Now().Add(opts.MaxAge).Unix()
+	}
+	cookie := opts.cookie(name, signCookie(opts.Keys[0], name, value, expires))
+	if len(cookie.Name)+len(cookie.Value) > MaxCookieSize {
+		return ErrCookieTooLarge
+	}
+
+	replaceCookie(w.Header(), cookie)
+	return nil
+}
+
+// GetSignedCookie returns the value of a cookie set with SetSignedCookie. It
+// returns http.ErrNoCookie if the request has no such cookie, and
+// ErrInvalidCookie if its signature does not match or it has expired.
+func GetSignedCookie(r *http.Request, name string, opts CookieOptions) (string, error) {
+	if len(opts.Keys) == 0 {
+		return "", ErrNoCookieKeys
+	}
+	cookie, err := r.Cookie(name)
+	if err != nil {
+		return "", err
+	}
+	return verifyCookie(opts.Keys, name, cookie.Value, time.Now())
+}
+
+// DeleteCookie tells the browser to remove a cookie. opts must have the
+// Path and Domain the cookie was set with.
+func DeleteCookie(w http.ResponseWriter, name string, opts CookieOptions) {
+	opts.MaxAge = 0
+	cookie := opts.cookie(name, "")
+	cookie.MaxAge = -1
+	cookie.Expires = time.Unix(0, 0)
+	replaceCookie(w.Header(), cookie)
+}
+
+// replaceCookie adds a Set-Cookie header for cookie, replacing any set
+// earlier for the same name
+func replaceCookie(h http.Header, cookie *http.Cookie) {
+	prefix := cookie.Name + "="
+	kept := h["Set-Cookie"][:0]
+	for _, v := range h["Set-Cookie"] {
+		if !strings.HasPrefix(v, prefix) {
+			kept = append(kept, v)
+		}
+	}
+	h["Set-Cookie"] = append(kept, cookie.String())
+}
+
+// signCookie encodes value as "<value>.<expires>.<signature>", with the
+// value in base64 and the signature covering the cookie name as well, so a
+// value cannot be moved to another cookie
+func signCookie(key []byte, name, value string, expires int64) string {
+	payload := base64.RawURLEncoding.EncodeToString([]byte(value)) + "." + strconv.FormatInt(expires, 10)
+	mac := cryptoutils.Sign(key, []byte(name+"="+payload))
+	return payload + "." + base64.RawURLEncoding.EncodeToString(mac)
+}
+
+// verifyCookie checks a signed cookie value against keys and returns the value
+func verifyCookie(keys [][]byte, name, signed string, now time.Time) (string, error) {
+	i := strings.LastIndexByte(signed, '.')
+	if i < 0 {
+		return "", ErrInvalidCookie
+	}
+	payload := signed[:i]
+	mac, err := base64.RawURLEncoding.DecodeString(signed[i+1:])
+	if err != nil {
+		return "", ErrInvalidCookie
+	}
+
+	valid := false
+	for _, key := range keys {
+		if cryptoutils.Verify(key, []byte(name+"="+payload), mac) {
+			valid = true
+			break
+		}
+	}
+	if !valid {
+		return "", ErrInvalidCookie
+	}
+
+	encoded, expiresText, ok := strings.Cut(payload, ".")
+	if !ok {
+		return "", ErrInvalidCookie
+	}
+	expires, err := strconv.ParseInt(expiresText, 10, 64)
+	if err != nil || (expires > 0 && now.Unix() >= expires) {
+		return "", ErrInvalidCookie
+	}
+	value, err := base64.RawURLEncoding.DecodeString(encoded)
+	if err != nil {
+		return "", ErrInvalidCookie
+	}
+	return string(value), nil
+}
@@ -0,0 +1,153 @@
+package router
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+var testCookieKey = []byte("0123456789abcdef0123456789abcdef")
+
+// sendCookies returns a request carrying the cookies set on w
+func sendCookies(w *httptest.ResponseRecorder) *http.Request {
+	r := httptest.NewRequest(http.MethodGet, "/", nil)
+	for _, c := range w.Result().Cookies() {
+		r.AddCookie(c)
+	}
+	return r
+}
+
+func TestSignedCookie(t *testing.T) {
+	opts := CookieOptions{Keys: [][]byte{testCookieKey}}
+	w := httptest.NewRecorder()
+
+	if err := SetSignedCookie(w, "cart", "order=42; id=7", opts); err != nil {
+		t.Fatalf("SetSignedCookie() error = %v", err)
+	}
+
+	got, err := GetSignedCookie(sendCookies(w), "cart", opts)
+	if err != nil {
+		t.Fatalf("GetSignedCookie() error = %v", err)
+	}
+	if got != "order=42; id=7" {
+		t.Errorf("Expected value %q, got %q", "order=42; id=7", got)
+	}
+}
+
+func TestSignedCookie_Defaults(t *testing.T) {
+	w := httptest.NewRecorder()
+	SetSignedCookie(w, "cart", "1", CookieOptions{Keys: [][]byte{testCookieKey}})
+
+	c := w.Result().Cookies()[0]
+	if c.Path != "/" || !c.HttpOnly || !c.Secure || c.SameSite != http.SameSiteLaxMode {
+		t.Errorf("Expected Path=/, HttpOnly, Secure and SameSite=Lax, got %+v", c)
+	}
+	if c.MaxAge != 0 || !c.Expires.IsZero() {
+		t.Errorf("Expected a session cookie, got MaxAge %d and Expires %v", c.MaxAge, c.Expires)
+	}
+
+	w = httptest.NewRecorder()
+	SetSignedCookie(w, "prefs", "1", CookieOptions{
+		Keys:         [][]byte{testCookieKey},
+		Path:         "/admin",
+		MaxAge:       time.Hour,
+		SameSite:     http.SameSiteStrictMode,
+		Insecure:     true,
+		AllowScripts: true,
+	})
+	c = w.Result().Cookies()[0]
+	if c.Path != "/admin" || c.HttpOnly || c.Secure || c.SameSite != http.SameSiteStrictMode || c.MaxAge != 3600 {
+		t.Errorf("Expected options to be applied, got %+v", c)
+	}
+}
+
+func TestSignedCookie_Invalid(t *testing.T) {
+	opts := CookieOptions{Keys: [][]byte{testCookieKey}}
+	signed := signCookie(testCookieKey, "cart", "42", 0)
+	now := time.Now()
+
+	tests := []struct {
+		name   string
+		cookie string
+		value  string
+	}{
+		{"tampered value", "cart", "NDM" + signed[3:]},
+		{"other cookie", "wishlist", signed},
+		{"other key", "cart", signCookie([]byte("another key"), "cart", "42", 0)},
+		{"expired", "cart", signCookie(testCookieKey, "cart", "42", now.Add(-time.Second).Unix())},
+		{"unsigned", "cart", "42"},
+		{"bad signature", "cart", signed[:strings.LastIndexByte(signed, '.')] + ".!!"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := httptest.NewRequest(http.MethodGet, "/", nil)
+			r.AddCookie(&http.Cookie{Name: tt.cookie, Value: tt.value})
+			if _, err := GetSignedCookie(r, tt.cookie, opts); !errors.Is(err, ErrInvalidCookie) {
+				t.Errorf("Expected ErrInvalidCookie, got %v", err)
+			}
+		})
+	}
+}
+
+func TestSignedCookie_Errors(t *testing.T) {
+	r := httptest.NewRequest(http.MethodGet, "/", nil)
+	if _, err := GetSignedCookie(r, "cart", CookieOptions{Keys: [][]byte{testCookieKey}}); !errors.Is(err, http.ErrNoCookie) {
+		t.Errorf("Expected http.ErrNoCookie, got %v", err)
+	}
+	if _, err := GetSignedCookie(r, "cart", CookieOptions{}); !errors.Is(err, ErrNoCookieKeys) {
+		t.Errorf("Expected ErrNoCookieKeys, got %v", err)
+	}
+
+	w := httptest.NewRecorder()
+	if err := SetSignedCookie(w, "cart", "1", CookieOptions{}); !errors.Is(err, ErrNoCookieKeys) {
+		t.Errorf("Expected ErrNoCookieKeys, got %v", err)
+	}
+	err := SetSignedCookie(w, "cart", strings.Repeat("x", MaxCookieSize), CookieOptions{Keys: [][]byte{testCookieKey}})
+	if !errors.Is(err, ErrCookieTooLarge) {
+		t.Errorf("Expected ErrCookieTooLarge, got %v", err)
+	}
+	if len(w.Header()["Set-Cookie"]) != 0 {
+		t.Errorf("Expected no cookie to be set, got %v", w.Header()["Set-Cookie"])
+	}
+}
+
+func TestSignedCookie_KeyRotation(t *testing.T) {
+	oldKey := []byte("old key old key old key old key!")
+	w := httptest.NewRecorder()
+	SetSignedCookie(w, "cart", "42", CookieOptions{Keys: [][]byte{oldKey}})
+
+	rotated := CookieOptions{Keys: [][]byte{testCookieKey, oldKey}}
+	if got, err := GetSignedCookie(sendCookies(w), "cart", rotated); err != nil || got != "42" {
+		t.Errorf("Expected a cookie signed with an old key to verify, got %q (err %v)", got, err)
+	}
+}
+
+func TestSetSignedCookie_Replaces(t *testing.T) {
+	opts := CookieOptions{Keys: [][]byte{testCookieKey}}
+	w := httptest.NewRecorder()
+	http.SetCookie(w, &http.Cookie{Name: "other", Value: "1"})
+	SetSignedCookie(w, "cart", "1", opts)
+	SetSignedCookie(w, "cart", "2", opts)
+
+	cookies := w.Result().Cookies()
+	if len(cookies) != 2 {
+		t.Fatalf("Expected 2 cookies, got %d", len(cookies))
+	}
+	if got, _ := GetSignedCookie(sendCookies(w), "cart", opts); got != "2" {
+		t.Errorf("Expected the latest value, got %q", got)
+	}
+}
+
+func TestDeleteCookie(t *testing.T) {
+	w := httptest.NewRecorder()
+	DeleteCookie(w, "cart", CookieOptions{Path: "/shop"})
+
+	c := w.Result().Cookies()[0]
+	if c.Name != "cart" || c.MaxAge != -1 || c.Path != "/shop" {
+		t.Errorf("Expected an expired cart cookie on /shop, got %+v", c)
+	}
+}
@@ -0,0 +1,172 @@
+package router
+
+import (
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// CORSOptions configures the CORS middleware.
+type CORSOptions struct {
+	// AllowedOrigins lists origins that may make cross-origin requests.
+	// "*" allows any origin and a single wildcard such as
+	// "https://*.example.com" matches subdomains.
+	AllowedOrigins []string
+	// AllowOriginFunc, if set, is consulted for origins not in AllowedOrigins
+	AllowOriginFunc func(origin string) bool
+	// AllowedMethods lists methods allowed in preflight requests
+	AllowedMethods []string
+	// AllowedHeaders lists request headers allowed in preflight requests.
+	// "*" allows any header.
+	AllowedHeaders []string
+	// ExposedHeaders lists response headers the browser may read
+	ExposedHeaders []string
+	// AllowCredentials allows cookies and authorization headers
+	AllowCredentials bool
+	// MaxAge is how long browsers may cache a preflight response
+	MaxAge time.Duration
+}
+
+// DefaultCORSOptions returns permissive CORS options suitable for public APIs
+// that do not rely on cookies.
+func DefaultCORSOptions() CORSOptions {
+	return CORSOptions{
+		AllowedOrigins: []string{"*"},
+		AllowedMethods: []string{
+			http.MethodGet, http.MethodPost, http.MethodPut,
+			http.MethodPatch, http.MethodDelete, http.MethodOptions,
+		},
+		AllowedHeaders: []string{"Accept", "Authorization", "Content-Type", "X-Request-Id"},
+		ExposedHeaders: []string{"X-Request-Id"},
+		MaxAge:         5 * time.Minute,
+	}
+}
+
+// CORS is a middleware that applies a cross-origin resource sharing policy.
+// Preflight requests are answered directly; other requests get the
+// appropriate headers and continue down the chain.
+func CORS(opts CORSOptions) func(next http.Handler) http.Handler {
+	allowAnyOrigin := false
+	for _, o := range opts.AllowedOrigins {
+		if o == "*" {
+			allowAnyOrigin = true
+		}
+	}
+
+	allowAnyHeader := false
+	allowedHeaders := make(map[string]bool, len(opts.AllowedHeaders))
+	for _, h := range opts.AllowedHeaders {
+		if h == "*" {
+			allowAnyHeader = true
+		}
+		allowedHeaders[http.CanonicalHeaderKey(h)] = true
+	}
+
+	allowedMethods := strings.Join(opts.AllowedMethods, ", ")
+	exposedHeaders := strings.Join(opts.ExposedHeaders, ", ")
+	maxAge := strconv.Itoa(int(opts.MaxAge / time.Second))
+
+	originAllowed := func(origin string) bool {
+		if allowAnyOrigin {
+			return true
+		}
+		for _, o := range opts.AllowedOrigins {
+			if matchOrigin(o, origin) {
+				return true
+			}
+		}
+		return opts.AllowOriginFunc != nil && opts.AllowOriginFunc(origin)
+	}
+
+	methodAllowed := func(method string) bool {
+		for _, m := range opts.AllowedMethods {
+			if strings.EqualFold(m, method) {
+				return true
+			}
+		}
+		return false
+	}
+
+	headersAllowed := func(requested string) bool {
+		if allowAnyHeader {
+			return true
+		}
+		for _, h := range strings.Split(requested, ",") {
+			h = strings.TrimSpace(h)
+			if h != "" && !allowedHeaders[http.CanonicalHeaderKey(h)] {
+				return false
+			}
+		}
+		return true
+	}
+
+	setOrigin := func(h http.Header, origin string) {
+		// Credentialed requests may not use the "*" wildcard
+		if allowAnyOrigin && !opts.AllowCredentials {
+			h.Set("Access-Control-Allow-Origin", "*")
+		} else {
+			h.Set("Access-Control-Allow-Origin", origin)
+		}
+		if opts.AllowCredentials {
+			h.Set("Access-Control-Allow-Credentials", "true")
+		}
+	}
+
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			origin := r.Header.Get("Origin")
+			headers := w.Header()
+
+			// Preflight request
+			if r.Method == http.MethodOptions && r.Header.Get("Access-Control-Request-Method") != "" {
+				headers.Add("Vary", "Origin")
+				headers.Add("Vary", "Access-Control-Request-Method")
+				headers.Add("Vary", "Access-Control-Request-Headers")
+
+				requestedHeaders := r.Header.Get("Access-Control-Request-Headers")
+				if origin == "" || !originAllowed(origin) ||
+					!methodAllowed(r.Header.Get("Access-Control-Request-Method")) ||
+					!headersAllowed(requestedHeaders) {
+					w.WriteHeader(http.StatusForbidden)
+					return
+				}
+
+				setOrigin(headers, origin)
+				headers.Set("Access-Control-Allow-Methods", allowedMethods)
+				if requestedHeaders != "" {
+					headers.Set("Access-Control-Allow-Headers", requestedHeaders)
+				}
+				if opts.MaxAge > 0 {
+					headers.Set("Access-Control-Max-Age", maxAge)
+				}
+				w.WriteHeader(http.StatusNoContent)
+				return
+			}
+
+			// Actual request
+			headers.Add("Vary", "Origin")
+			if origin != "" && originAllowed(origin) {
+				setOrigin(headers, origin)
+				if exposedHeaders != "" {
+					headers.Set("Access-Control-Expose-Headers", exposedHeaders)
+				}
+			}
+
+			next.ServeHTTP(w, r)
+		})
+	}
+}
+
+// matchOrigin reports whether origin matches pattern, which may contain a
+// single "*" wildcard
+func matchOrigin(pattern, origin string) bool {
+	prefix, suffix, found := strings.Cut(pattern, "*")
+	if !found {
+		return strings.EqualFold(pattern, origin)
+	}
+	origin = strings.ToLower(origin)
+	prefix, suffix = strings.ToLower(prefix), strings.ToLower(suffix)
+	return len(origin) > len(prefix)+len(suffix) &&
+		strings.HasPrefix(origin, prefix) && strings.HasSuffix(origin, suffix)
+}
@@ -0,0 +1,160 @@
+package router
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestCORS_Preflight(t *testing.T) {
+	handler := CORS(CORSOptions{
+		AllowedOrigins:   []string{"https://app.example.com", "https://*.stairs.dev"},
+		AllowedMethods:   []string{http.MethodGet, http.MethodPost},
+		AllowedHeaders:   []string{"Content-Type", "Authorization"},
+		AllowCredentials: true,
+		MaxAge:           10 * time.Minute,
+	})(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		t.Error("Preflight should not reach the handler")
+	}))
+
+	tests := []struct {
+		name       string
+		origin     string
+		method     string
+		headers    string
+		wantStatus int
+	}{
+		{"allowed origin", "https://app.example.com", "POST", "content-type, authorization", http.StatusNoContent},
+		{"wildcard subdomain", "https://shop.stairs.dev", "GET", "", http.StatusNoContent},
+		{"disallowed origin", "https://evil.example.com", "POST", "", http.StatusForbidden},
+		{"wildcard does not match apex", "https://.stairs.dev", "GET", "", http.StatusForbidden},
+		{"disallowed method", "https://app.example.com", "DELETE", "", http.StatusForbidden},
+		{"disallowed header", "https://app.example.com", "POST", "X-Secret", http.StatusForbidden},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodOptions, "/orders", nil)
+			req.Header.Set("Origin", tc.origin)
+			req.Header.Set("Access-Control-Request-Method", tc.method)
+			if tc.headers != "" {
+				req.Header.Set("Access-Control-Request-Headers", tc.headers)
+			}
+			w := httptest.NewRecorder()
+			handler.ServeHTTP(w, req)
+
+			if w.Code != tc.wantStatus {
+				t.Fatalf("Expected status %d, got %d", tc.wantStatus, w.Code)
+			}
+			if tc.wantStatus != http.StatusNoContent {
+				if got := w.Header().Get("Access-Control-Allow-Origin"); got != "" {
+					t.Errorf("Expected no Allow-Origin header, got %q", got)
+				}
+				return
+			}
+
+			if got := w.Header().Get("Access-Control-Allow-Origin"); got != tc.origin {
+				t.Errorf("Expected Allow-Origin %q, got %q", tc.origin, got)
+			}
+			if got := w.Header().Get("Access-Control-Allow-Credentials"); got != "true" {
+				t.Errorf("Expected Allow-Credentials true, got %q", got)
+			}
+			if got := w.Header().Get("Access-Control-Allow-Methods"); got != "GET, POST" {
+				t.Errorf("Expected Allow-Methods 'GET, POST', got %q", got)
+			}
+			if got := w.Header().Get("Access-Control-Max-Age"); got != "600" {
+				t.Errorf("Expected Max-Age 600, got %q", got)
+			}
+		})
+	}
+}
+
+func TestCORS_ActualRequest(t *testing.T) {
+	next := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte("OK"))
+	})
+
+	t.Run("any origin without credentials", func(t *testing.T) {
+		handler := CORS(DefaultCORSOptions())(next)
+		req := httptest.NewRequest(http.MethodGet, "/orders", nil)
+		req.Header.Set("Origin", "https://anywhere.example")
+		w := httptest.NewRecorder()
+		handler.ServeHTTP(w, req)
+
+		if got := w.Header().Get("Access-Control-Allow-Origin"); got != "*" {
+			t.Errorf("Expected Allow-Origin *, got %q", got)
+		}
+		if got := w.Header().Get("Access-Control-Expose-Headers"); got != "X-Request-Id" {
+			t.Errorf("Expected Expose-Headers X-Request-Id, got %q", got)
+		}
+		if w.Body.String() != "OK" {
+			t.Errorf("Expected handler to run, got %q", w.Body.String())
+		}
+	})
+
+	t.Run("any origin with credentials echoes origin", func(t *testing.T) {
+		opts := DefaultCORSOptions()
+		opts.AllowCredentials = true
+		handler := CORS(opts)(next)
+		req := httptest.NewRequest(http.MethodGet, "/orders", nil)
+		req.Header.Set("Origin", "https://anywhere.example")
+		w := httptest.NewRecorder()
+		handler.ServeHTTP(w, req)
+
+		if got := w.Header().Get("Access-Control-Allow-Origin"); got != "https://anywhere.example" {
+			t.Errorf("Expected origin to be echoed, got %q", got)
+		}
+	})
+
+	t.Run("origin func", func(t *testing.T) {
+		handler := CORS(CORSOptions{
+			AllowOriginFunc: func(origin string) bool { return origin == "https://partner.example" },
+		})(next)
+		req := httptest.NewRequest(http.MethodGet, "/orders", nil)
+		req.Header.Set("Origin", "https://partner.example")
+		w := httptest.NewRecorder()
+		handler.ServeHTTP(w, req)
+
+		if got := w.Header().Get("Access-Control-Allow-Origin"); got != "https://partner.example" {
+			t.Errorf("Expected partner origin, got %q", got)
+		}
+	})
+
+	t.Run("same origin request", func(t *testing.T) {
+		handler := CORS(DefaultCORSOptions())(next)
+		req := httptest.NewRequest(http.MethodGet, "/orders", nil)
+		w := httptest.NewRecorder()
+		handler.ServeHTTP(w, req)
+
+		if got := w.Header().Get("Access-Control-Allow-Origin"); got != "" {
+			t.Errorf("Expected no CORS headers, got %q", got)
+		}
+		if got := w.Header().Get("Vary"); got != "Origin" {
+			t.Errorf("Expected Vary Origin, got %q", got)
+		}
+	})
+}
+
+func TestRouter_EnableCORS(t *testing.T) {
+	opts := DefaultOptions()
+	opts.EnableCORS = true
+	opts.EnableLogging = false
+	r := NewWithOptions(opts)
+	r.Get("/orders", func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte("OK"))
+	})
+
+	req := httptest.NewRequest(http.MethodOptions, "/orders", nil)
+	req.Header.Set("Origin", "https://app.example.com")
+	req.Header.Set("Access-Control-Request-Method", http.MethodGet)
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, req)
+
+	if w.Code != http.StatusNoContent {
+		t.Errorf("Expected preflight status 204, got %d", w.Code)
+	}
+	if got := w.Header().Get("Access-Control-Allow-Origin"); got != "*" {
+		t.Errorf("Expected Allow-Origin *, got %q", got)
+	}
+}
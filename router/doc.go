@@ -8,11 +8,23 @@ a consistent approach to building HTTP services with sensible defaults.
 # Features
 
   - Integration with the go-core/api package for error handling
-  - Structured logging with the go-core/logger package
+  - 404 and 405 responses in the api error envelope
+  - Structured logging with the go-core/logger package, with slow request
+    warnings and per-route latency histograms
   - Request tracing with unique request IDs
-  - Standard panic recovery via Chi's Recoverer middleware
-  - Optional healthcheck endpoint at /healthz
-  - Timeout handling
+  - Request ID and trace header propagation to rest clients
+  - Panic recovery with stack trace logging and a pluggable PanicHandler
+  - Optional healthcheck endpoint at /healthz, or /healthz and /readyz
+    backed by a health.Checker
+  - Timeout handling with per-route overrides
+  - Optional CORS policy
+  - Optional per-client rate limiting with pluggable stores
+  - Per-route authentication with JWT bearer tokens or static API keys
+  - HMAC-signed cookies with secure defaults and cookie-backed sessions
+  - Websocket endpoints with request-scoped logging and panic recovery
+  - Optional ETags and 304 Not Modified for JSON responses
+  - Route introspection and an optional /debug/routes listing
+  - Static file and single-page application serving with cache headers
   - Configurable middleware options
 
 # Basic Usage
@@ -45,6 +57,29 @@ The router integrates with the api package for standardized error handling:
 	// Wrap the handler in the router
 	r.Get("/api/users/{id}", router.WithErrorHandler(getUserHandler))
 
+Set Options.ErrorHandler to customize how those errors become responses for
+every route on the router:
+
+	opts := router.DefaultOptions()
+	opts.ErrorHandler = func(w http.ResponseWriter, r *http.Request, err error) {
+	    var apiErr api.Error
+	    if errors.As(err, &apiErr) && apiErr.StatusCode < 500 {
+	        api.DefaultErrorHandler(w, r, err)
+	        return
+	    }
+	    api.WriteError(w, api.ServerError(errors.New("internal server error")))
+	}
+	r := router.NewWithOptions(opts)
+
+Requests that match no route get a 404 in the same error envelope, and
+requests for a known path with the wrong method get a 405 with an Allow
+header, instead of chi's plain text responses:
+
+	{"error": {"status_code": 405, "message": "method DELETE is not allowed for /orders"}}
+
+Set Options.NotFoundHandler or Options.MethodNotAllowedHandler to replace
+DefaultNotFoundHandler or DefaultMethodNotAllowedHandler.
+
 # Route Groups and Middleware
 
 You can create route groups with shared middleware:
@@ -65,6 +100,21 @@ You can create route groups with shared middleware:
 	// Mount the API router
 	r.Mount("/api", apiRouter)
 
+A group is reached through the router it is mounted on, so the router's
+middleware chain, such as logging, recovery and the request timeout, runs
+once before the group's own middleware, and 404 and 405 responses use the
+router's handlers.
+
+GroupWithOptions registers a group on the router itself, with no Mount, and
+takes per-group overrides such as extra middleware and a different timeout:
+
+	r.GroupWithOptions(router.GroupOptions{
+	    Middleware: []func(http.Handler) http.Handler{adminOnly},
+	    Timeout:    2 * time.Minute,
+	}, func(r chi.Router) {
+	    r.Post("/admin/reindex", router.WithErrorHandler(reindex))
+	})
+
 # Custom Configuration
 
 You can customize the router's middleware:
@@ -79,10 +129,244 @@ You can customize the router's middleware:
 	    LoggerOptions: router.LoggerOptions{
 	        LogRequestHeaders:  true,
 	        LogResponseHeaders: false,
-	        SkipPaths:          []string{"/healthz", "/metrics"},
+	        SkipPaths:          []string{"/healthz", "/readyz", "/metrics"},
 	    },
 	})
 
+# Health Checks
+
+EnableHealthcheck serves a plain "OK" at /healthz. For dependency checks,
+set HealthChecker instead; /healthz then serves the checker's liveness
+report and /readyz its readiness report, as JSON with a status and latency
+for each check:
+
+	checker := health.NewChecker()
+	checker.Register("database", db.PingContext, health.WithTimeout(2*time.Second))
+
+	opts := router.DefaultOptions()
+	opts.HealthChecker = checker
+	r := router.NewWithOptions(opts)
+
+# Route Listing
+
+ListRoutes walks the router, including groups and mounted routers, and
+returns each route's method, pattern and middleware count. It is handy for
+API inventories and for finding out why a path does not match:
+
+	for _, route := range r.ListRoutes() {
+	    fmt.Println(route.Method, route.Pattern)
+	}
+
+Set EnableRouteListing to serve the same list as JSON at /debug/routes.
+Only enable it on internal listeners.
+
+# Static Files
+
+Static and StaticFS serve files from a directory or an fs.FS such as an
+embed.FS. SPA and SPAFS do the same for single-page applications, serving
+the index file for extensionless paths the client-side router handles:
+
+	//go:embed dist
+	var dist embed.FS
+
+	app, _ := fs.Sub(dist, "dist")
+	r.SPAFS("/", app, "index.html")
+	r.Static("/downloads", "/var/lib/exports")
+
+Routes registered on the router take precedence, so an SPA mounted at "/"
+does not hide API routes. HTML files are sent with Cache-Control: no-cache so
+new deployments are picked up, fingerprinted assets such as app.3f9a1c2e.js
+are cached for a year, and other files for an hour. Missing files get the
+router's NotFound handler, the api error envelope by default.
+
+# Conditional Requests
+
+Set EnableETag to add a strong ETag to JSON GET responses and answer
+304 Not Modified when the client already has the current version:
+
+	opts := router.DefaultOptions()
+	opts.EnableETag = true
+	opts.ETagMaxBodySize = 512 << 10 // don't buffer responses over 512KB
+
+The ETag is a hash of the response body, so handlers need no changes.
+Handlers that know their version cheaply can set the ETag header themselves.
+
+# Timeouts
+
+TimeoutDuration applies to every route. Slow endpoints can be given a
+different limit without disabling the global timeout; a nested timeout
+replaces the router default rather than shortening it:
+
+	r.WithTimeout(5 * time.Minute).Get("/reports", router.WithErrorHandler(generateReport))
+
+	r.GroupWithOptions(router.GroupOptions{Timeout: 2 * time.Minute}, func(r chi.Router) {
+	    r.Get("/exports/orders", router.WithErrorHandler(exportOrders))
+	    r.Get("/exports/customers", router.WithErrorHandler(exportCustomers))
+	})
+
+Requests that exceed their limit receive 504 Gateway Timeout.
+
+# Panic Recovery
+
+With EnableRecovery set, a panicking handler is logged with the panic value
+and full stack trace as structured fields, and the client receives a 500 api
+error envelope. Set PanicHandler to also report panics elsewhere:
+
+	opts := router.DefaultOptions()
+	opts.PanicHandler = func(w http.ResponseWriter, r *http.Request, recovered any) {
+	    sentry.CurrentHub().Recover(recovered)
+	}
+
+If the PanicHandler writes a response, the default 500 is not sent.
+
+# CORS
+
+Set EnableCORS to answer browser preflight requests and add CORS headers to
+responses. DefaultOptions fills CORSOptions with a permissive policy for
+public APIs; restrict it for anything that uses cookies:
+
+	opts := router.DefaultOptions()
+	opts.EnableCORS = true
+	opts.CORSOptions.AllowedOrigins = []string{"https://app.example.com", "https://*.example.dev"}
+	opts.CORSOptions.AllowCredentials = true
+
+	r := router.NewWithOptions(opts)
+
+# Rate Limiting
+
+Set RateLimit to limit each client with a token bucket. Clients are keyed by
+IP unless another KeyFunc is given, and requests over the limit receive a
+429 api error envelope with a Retry-After header:
+
+	opts := router.DefaultOptions()
+	opts.RateLimit = &router.RateLimitOptions{
+	    RequestsPerSecond: 10,
+	    Burst:             20,
+	    KeyFunc:           router.KeyByHeader("X-Api-Key"),
+	    SkipPaths:         []string{"/healthz", "/readyz"},
+	}
+
+Buckets are kept in memory by default, so each instance limits on its own.
+Implement RateLimitStore, for example with Redis, to share limits across
+instances. The RateLimit middleware can also be applied to single routes:
+
+	r.With(router.RateLimit(router.RateLimitOptions{RequestsPerSecond: 0.2, Burst: 5})).
+	    Post("/login", router.WithErrorHandler(login))
+
+# Authentication
+
+Auth is a middleware that authenticates requests with an Authenticator and
+stores the caller's Principal in the request context. Requests without
+valid credentials get a 401 api error envelope and a WWW-Authenticate
+header. Apply it to the routes that need it:
+
+	jwtAuth, err := router.NewJWTAuthenticator(router.JWTOptions{
+	    Secret:   []byte(cfg.JWTSecret),
+	    Issuer:   "https://auth.example.com",
+	    Audience: "orders",
+	})
+	if err != nil {
+	    return err
+	}
+
+	r.GroupWithOptions(router.GroupOptions{
+	    Middleware: []func(http.Handler) http.Handler{router.Auth(jwtAuth)},
+	}, func(r chi.Router) {
+	    r.Get("/orders", router.WithErrorHandler(listOrders))
+	})
+
+	r.With(router.Auth(router.NewAPIKeyAuthenticator("", map[string]router.Principal{
+	    cfg.WarehouseKey: {ID: "warehouse", Scopes: []string{"inventory:write"}},
+	}))).Post("/inventory", router.WithErrorHandler(importInventory))
+
+Handlers read the caller with PrincipalFromContext; its ID and tenant are
+also available from ctxutils.UserID and ctxutils.TenantID:
+
+	p, _ := router.PrincipalFromContext(r.Context())
+	if !p.HasScope("orders:write") {
+	    return api.ForbiddenError(errors.New("missing scope orders:write"))
+	}
+
+JWTAuthenticator accepts HS256, RS256 and ES256 tokens, only with the
+algorithm its key is for. It checks the exp, nbf, iss and aud claims and
+returns ErrTokenExpired for expired tokens. Implement Authenticator, or use
+AuthenticatorFunc, for other schemes; returning an api.Error such as a 403
+controls the response.
+
+# Cookies and Sessions
+
+SetSignedCookie writes a cookie with an HMAC-SHA256 signature, and
+GetSignedCookie returns its value only if the signature matches and it has
+not expired. Cookies are HttpOnly, Secure, SameSite=Lax and scoped to "/"
+unless CookieOptions says otherwise:
+
+	opts := router.CookieOptions{
+	    Keys:   [][]byte{[]byte(cfg.CookieKey)},
+	    MaxAge: 30 * 24 * time.Hour,
+	}
+	router.SetSignedCookie(w, "cart", cartID, opts)
+
+	cartID, err := router.GetSignedCookie(r, "cart", opts)
+	if err != nil {
+	    // http.ErrNoCookie, or ErrInvalidCookie if it was tampered with
+	}
+
+Set Insecure for local development over plain HTTP. Cookies are signed with
+the first key and verified with any of them, so a new key can be added in
+front of the old one to rotate it.
+
+Sessions is a middleware that keeps a map of strings in a signed session
+cookie and loads it into the request context. Changes are written to the
+response as they are made, so make them before writing the body:
+
+	r.Use(router.Sessions(router.SessionOptions{
+	    Cookie: router.CookieOptions{Keys: [][]byte{[]byte(cfg.CookieKey)}, MaxAge: 12 * time.Hour},
+	}))
+
+	session := router.SessionFromContext(r.Context())
+	session.Set("user_id", user.ID)
+	...
+	session.Clear() // on logout
+
+Signed cookies can be read by the client, so store IDs rather than secrets.
+
+# Websockets
+
+Websocket upgrades requests to websocket connections, implemented by the
+websocket subpackage, and serves each with a handler:
+
+	r.Get("/ws/orders", router.Websocket(func(ctx context.Context, conn *websocket.Conn) {
+	    for {
+	        var sub Subscription
+	        if err := conn.ReadJSON(&sub); err != nil {
+	            return
+	        }
+	        logger.WithContext(ctx).Info("Subscribed", zap.String("order_id", sub.OrderID))
+	        conn.WriteJSON(snapshot(ctx, sub))
+	    }
+	}))
+
+The handler's context keeps the request's values, such as its logger with
+request_id and path fields, but not the request timeout; it is canceled when
+the connection closes. A panic closes only its connection, with code 1011.
+The end of each connection is logged with its duration and close code, at
+warn level if the client went away without closing. Use WebsocketWithOptions
+to set the Upgrader, for example to allow other origins.
+
+# Header Propagation
+
+Set Options.PropagateHeaders to store the named headers of each request in
+its context with ctxutils.WithPropagatedHeaders, where a rest.Client created
+with rest.WithPropagateHeaders finds them. Propagation is off by default.
+ctxutils.DefaultPropagatedHeaders names the request ID and the W3C
+traceparent and tracestate headers; the request ID generated for requests
+without an X-Request-Id header is stored too:
+
+	opts := router.DefaultOptions()
+	opts.PropagateHeaders = append(ctxutils.DefaultPropagatedHeaders, "X-Tenant-Id")
+
+The PropagateHeaders middleware does the same for a single route group.
+
 # Logging
 
 The router uses the go-core/logger package for structured logging of requests:
@@ -96,5 +380,39 @@ The router uses the go-core/logger package for structured logging of requests:
 
 	// Create a new router (logging is enabled by default)
 	r := router.New()
+
+When header logging is enabled, Authorization, Cookie, Set-Cookie and other
+DefaultRedactedHeaders are logged as "[REDACTED]". Add service-specific
+headers with LoggerOptions.RedactedHeaders:
+
+	opts := router.DefaultOptions()
+	opts.LoggerOptions.LogRequestHeaders = true
+	opts.LoggerOptions.RedactedHeaders = []string{"X-Session-Token"}
+
+LogRequestBody adds the start of each request body to the "HTTP request
+started" entry, up to MaxBodyLogSize bytes (DefaultMaxBodyLogSize when zero),
+and leaves the full body readable by the handler. Fields named in
+DefaultRedactedBodyFields or RedactedBodyFields are redacted at any depth in
+JSON and form bodies; a JSON body cut off by the limit is withheld, since it
+cannot be redacted reliably:
+
+	opts.LoggerOptions.LogRequestBody = true
+	opts.LoggerOptions.MaxBodyLogSize = 16 << 10
+	opts.LoggerOptions.RedactedBodyFields = []string{"card_number", "cvv"}
+
+Requests taking at least LoggerOptions.SlowRequestThreshold complete at warn
+level with slow_request set to true, so slow endpoints stand out from the
+info-level traffic. DefaultOptions sets DefaultSlowRequestThreshold; zero
+disables it. A LatencyHistogram set as LoggerOptions.Latency counts request
+durations per method and route pattern, such as "/orders/{id}", and adds
+the bucket of each request to its entry as latency_bucket:
+
+	latency := router.NewLatencyHistogram()
+	opts.LoggerOptions.SlowRequestThreshold = 500 * time.Millisecond
+	opts.LoggerOptions.Latency = latency
+
+	for _, rl := range latency.Snapshot() {
+	    fmt.Println(rl.Method, rl.Route, rl.Count, rl.Sum/time.Duration(rl.Count))
+	}
 */
 package router
\ No newline at end of file
@@ -0,0 +1,150 @@
+package router
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/base64"
+	"mime"
+	"net/http"
+	"strings"
+)
+
+// DefaultETagMaxBodySize is the largest response body ETag buffers by default
+const DefaultETagMaxBodySize = 1 << 20
+
+// ETag is a middleware that adds a strong ETag to successful JSON responses
+// to GET and HEAD requests and answers 304 Not Modified when the request's
+// If-None-Match matches. Responses are buffered to hash them; bodies larger
+// than maxBodySize are streamed through without an ETag.
+//
+// An ETag set by the handler is kept and still used for If-None-Match.
+func ETag(maxBodySize int64) func(next http.Handler) http.Handler {
+	if maxBodySize <= 0 {
+		maxBodySize = DefaultETagMaxBodySize
+	}
+
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if r.Method != http.MethodGet && r.Method != http.MethodHead {
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			ew := &etagWriter{ResponseWriter: w, status: http.StatusOK, max: maxBodySize}
+			next.ServeHTTP(ew, r)
+			ew.finish(r)
+		})
+	}
+}
+
+// etagWriter buffers a response until it is complete or too large to hash
+type etagWriter struct {
+	http.ResponseWriter
+	status      int
+	wroteHeader bool
+	passthrough bool
+	buf         bytes.Buffer
+	max         int64
+}
+
+func (ew *etagWriter) WriteHeader(code int) {
+	if ew.wroteHeader {
+		return
+	}
+	ew.wroteHeader = true
+	ew.status = code
+	if code != http.StatusOK {
+		ew.passthrough = true
+		ew.ResponseWriter.WriteHeader(code)
+	}
+}
+
+func (ew *etagWriter) Write(p []byte) (int, error) {
+	if !ew.wroteHeader {
+		ew.WriteHeader(http.StatusOK)
+	}
+	if ew.passthrough {
+		return ew.ResponseWriter.Write(p)
+	}
+	if int64(ew.buf.Len()+len(p)) > ew.max {
+		if err := ew.flushBuffer(); err != nil {
+			return 0, err
+		}
+		return ew.ResponseWriter.Write(p)
+	}
+	return ew.buf.Write(p)
+}
+
+// Flush sends anything buffered and stops buffering, for streaming handlers
+func (ew *etagWriter) Flush() {
+	if !ew.passthrough {
+		if !ew.wroteHeader {
+			ew.WriteHeader(http.StatusOK)
+		}
+		ew.flushBuffer()
+	}
+	if f, ok := ew.ResponseWriter.(http.Flusher); ok {
+		f.Flush()
+	}
+}
+
+// Unwrap lets http.ResponseController reach the underlying writer
+func (ew *etagWriter) Unwrap() http.ResponseWriter {
+	return ew.ResponseWriter
+}
+
+// flushBuffer switches to passthrough, writing the status and buffered body
+func (ew *etagWriter) flushBuffer() error {
+	ew.passthrough = true
+	ew.ResponseWriter.WriteHeader(ew.status)
+	_, err := ew.ResponseWriter.Write(ew.buf.Bytes())
+	ew.buf.Reset()
+	return err
+}
+
+// finish writes the buffered response, or 304 if the client's copy is current
+func (ew *etagWriter) finish(r *http.Request) {
+	if ew.passthrough {
+		return
+	}
+
+	h := ew.Header()
+	etag := h.Get("ETag")
+	if etag == "" && isJSON(h.Get("Content-Type")) {
+		sum := sha256.Sum256(ew.buf.Bytes())
+		etag = `"` + base64.RawURLEncoding.EncodeToString(sum[:16]) + `"`
+		h.Set("ETag", etag)
+	}
+
+	if etag != "" && etagMatches(r.Header.Get("If-None-Match"), etag) {
+		for _, k := range []string{"Content-Type", "Content-Length", "Content-Encoding"} {
+			h.Del(k)
+		}
+		ew.ResponseWriter.WriteHeader(http.StatusNotModified)
+		return
+	}
+
+	ew.ResponseWriter.WriteHeader(ew.status)
+	ew.ResponseWriter.Write(ew.buf.Bytes())
+}
+
+// isJSON reports whether contentType is application/json or a +json type
+func isJSON(contentType string) bool {
+	mediaType, _, err := mime.ParseMediaType(contentType)
+	return err == nil && (mediaType == "application/json" || strings.HasSuffix(mediaType, "+json"))
+}
+
+// etagMatches applies the weak comparison If-None-Match requires
+func etagMatches(ifNoneMatch, etag string) bool {
+	if ifNoneMatch == "" {
+		return false
+	}
+	etag = strings.TrimPrefix(etag, "W/")
+	for _, candidate := range strings.Split(ifNoneMatch, ",") {
+		candidate = strings.TrimSpace(candidate)
+		if candidate == "*" || strings.TrimPrefix(candidate, "W/") == etag {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,126 @@
+package router
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func jsonHandler(body string) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(body))
+	}
+}
+
+func TestETag(t *testing.T) {
+	handler := ETag(0)(jsonHandler(`{"id":42}`))
+
+	w := httptest.NewRecorder()
+	handler.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/orders/42", nil))
+
+	etag := w.Header().Get("ETag")
+	if w.Code != http.StatusOK || etag == "" || !strings.HasPrefix(etag, `"`) {
+		t.Fatalf("Expected 200 with a strong ETag, got %d %q", w.Code, etag)
+	}
+	if w.Body.String() != `{"id":42}` {
+		t.Errorf("Expected body to be passed through, got %q", w.Body.String())
+	}
+
+	tests := []struct {
+		name        string
+		ifNoneMatch string
+		wantStatus  int
+	}{
+		{"matching", etag, http.StatusNotModified},
+		{"weak matching", "W/" + etag, http.StatusNotModified},
+		{"in list", `"other", ` + etag, http.StatusNotModified},
+		{"wildcard", "*", http.StatusNotModified},
+		{"stale", `"other"`, http.StatusOK},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodGet, "/orders/42", nil)
+			req.Header.Set("If-None-Match", tc.ifNoneMatch)
+			w := httptest.NewRecorder()
+			handler.ServeHTTP(w, req)
+
+			if w.Code != tc.wantStatus {
+				t.Errorf("Expected status code %d, got %d", tc.wantStatus, w.Code)
+			}
+			if tc.wantStatus == http.StatusNotModified && (w.Body.Len() != 0 || w.Header().Get("Content-Type") != "") {
+				t.Errorf("Expected empty 304, got body %q and Content-Type %q", w.Body.String(), w.Header().Get("Content-Type"))
+			}
+			if w.Header().Get("ETag") != etag {
+				t.Errorf("Expected ETag %s, got %s", etag, w.Header().Get("ETag"))
+			}
+		})
+	}
+}
+
+func TestETag_Skipped(t *testing.T) {
+	tests := []struct {
+		name    string
+		method  string
+		handler http.HandlerFunc
+	}{
+		{"POST", http.MethodPost, jsonHandler(`{}`)},
+		{"non-JSON", http.MethodGet, func(w http.ResponseWriter, r *http.Request) {
+			w.Header().Set("Content-Type", "text/plain")
+			w.Write([]byte("ok"))
+		}},
+		{"error status", http.MethodGet, func(w http.ResponseWriter, r *http.Request) {
+			w.Header().Set("Content-Type", "application/json")
+			w.WriteHeader(http.StatusNotFound)
+			w.Write([]byte(`{"error":"not found"}`))
+		}},
+		{"too large", http.MethodGet, jsonHandler(`{"data":"` + strings.Repeat("x", 100) + `"}`)},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			ETag(64)(tc.handler).ServeHTTP(w, httptest.NewRequest(tc.method, "/", nil))
+
+			if etag := w.Header().Get("ETag"); etag != "" {
+				t.Errorf("Expected no ETag, got %s", etag)
+			}
+			if w.Body.Len() == 0 {
+				t.Error("Expected body to be written")
+			}
+		})
+	}
+}
+
+func TestETag_HandlerSetETag(t *testing.T) {
+	handler := ETag(0)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("ETag", `"v7"`)
+		w.Write([]byte("file contents"))
+	}))
+
+	req := httptest.NewRequest(http.MethodGet, "/", nil)
+	req.Header.Set("If-None-Match", `"v7"`)
+	w := httptest.NewRecorder()
+	handler.ServeHTTP(w, req)
+
+	if w.Code != http.StatusNotModified {
+		t.Errorf("Expected status code %d, got %d", http.StatusNotModified, w.Code)
+	}
+}
+
+func TestRouter_EnableETag(t *testing.T) {
+	opts := DefaultOptions()
+	opts.EnableLogging = false
+	opts.EnableETag = true
+	r := NewWithOptions(opts)
+	r.Get("/orders", jsonHandler(`[]`))
+
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/orders", nil))
+
+	if w.Header().Get("ETag") == "" {
+		t.Error("Expected router to add an ETag")
+	}
+}
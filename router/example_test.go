@@ -1,12 +1,17 @@
 package router_test
 
 import (
+	"context"
 	"fmt"
 	"net/http"
+	"net/http/httptest"
+	"testing/fstest"
 	"time"
 
 	"github.com/StairSupplies/go-core/api"
+	"github.com/StairSupplies/go-core/logger"
 	"github.com/StairSupplies/go-core/router"
+	"github.com/StairSupplies/go-core/router/websocket"
 	"github.com/go-chi/chi/v5"
 )
 
@@ -159,7 +164,7 @@ func ExampleTimeout() {
 	// Define a custom timeout handler
 	customTimeout := router.Timeout(5 * time.Second)
 
-	// Apply to specific routes
+	// Apply to specific routes; this replaces the router's default timeout
 	r.With(customTimeout).Get("/slow-operation", func(w http.ResponseWriter, r *http.Request) {
 		// This operation will timeout if it takes longer than 5 seconds
 		time.Sleep(1 * time.Second)
@@ -188,4 +193,225 @@ func ExampleRequestID() {
 	fmt.Println("Route with RequestID middleware registered")
 
 	// Output: Route with RequestID middleware registered
-}
\ No newline at end of file
+}
+
+func ExampleCORS() {
+	opts := router.DefaultOptions()
+	opts.EnableLogging = false
+	opts.EnableCORS = true
+	opts.CORSOptions.AllowedOrigins = []string{"https://app.example.com"}
+	opts.CORSOptions.AllowCredentials = true
+
+	r := router.NewWithOptions(opts)
+	r.Get("/api/orders", func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte("[]"))
+	})
+
+	// A browser preflight from an allowed origin
+	req := httptest.NewRequest(http.MethodOptions, "/api/orders", nil)
+	req.Header.Set("Origin", "https://app.example.com")
+	req.Header.Set("Access-Control-Request-Method", http.MethodGet)
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, req)
+
+	fmt.Println(w.Code, w.Header().Get("Access-Control-Allow-Origin"))
+
+	// Output: 204 https://app.example.com
+}
+
+func ExampleRouter_WithTimeout() {
+	opts := router.DefaultOptions()
+	opts.EnableLogging = false
+	opts.TimeoutDuration = 10 * time.Second
+	r := router.NewWithOptions(opts)
+
+	// Report generation gets longer than the 10 second default
+	r.WithTimeout(5*time.Minute).Get("/reports", func(w http.ResponseWriter, r *http.Request) {
+		deadline, _ := r.Context().Deadline()
+		fmt.Println(time.Until(deadline) > time.Minute)
+	})
+
+	r.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/reports", nil))
+
+	// Output: true
+}
+
+func ExampleETag() {
+	r := router.NewWithOptions(router.Options{EnableETag: true})
+	r.Get("/products/1", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"name":"Oak Tread"}`))
+	})
+
+	first := httptest.NewRecorder()
+	r.ServeHTTP(first, httptest.NewRequest(http.MethodGet, "/products/1", nil))
+
+	// The client sends back the ETag it received
+	req := httptest.NewRequest(http.MethodGet, "/products/1", nil)
+	req.Header.Set("If-None-Match", first.Header().Get("ETag"))
+	second := httptest.NewRecorder()
+	r.ServeHTTP(second, req)
+
+	fmt.Println(first.Code, second.Code)
+
+	// Output: 200 304
+}
+
+func ExampleRecoverer() {
+	// Report panics to an error tracker; the client still gets a 500 envelope
+	reportPanic := func(w http.ResponseWriter, r *http.Request, recovered any) {
+		fmt.Println("reported:", recovered)
+	}
+
+	handler := router.Recoverer(reportPanic)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		panic("nil map write")
+	}))
+
+	req := httptest.NewRequest(http.MethodGet, "/orders", nil)
+	req = req.WithContext(logger.NewContext(req.Context(), logger.NewNopLogger()))
+	w := httptest.NewRecorder()
+	handler.ServeHTTP(w, req)
+
+	fmt.Println(w.Code)
+
+	// Output:
+	// reported: nil map write
+	// 500
+}
+
+func ExampleRouter_ListRoutes() {
+	r := router.NewWithOptions(router.Options{})
+	r.Get("/orders", func(w http.ResponseWriter, r *http.Request) {})
+	r.Post("/orders", func(w http.ResponseWriter, r *http.Request) {})
+	r.Route("/admin", func(r chi.Router) {
+		r.Delete("/users/{id}", func(w http.ResponseWriter, r *http.Request) {})
+	})
+
+	for _, route := range r.ListRoutes() {
+		fmt.Println(route.Method, route.Pattern)
+	}
+
+	// Output:
+	// DELETE /admin/users/{id}
+	// GET /orders
+	// POST /orders
+}
+
+func ExampleRateLimit() {
+	r := router.NewWithOptions(router.Options{
+		RateLimit: &router.RateLimitOptions{
+			RequestsPerSecond: 1,
+			Burst:             2,
+			KeyFunc:           router.KeyByHeader("X-Api-Key"),
+		},
+	})
+	r.Get("/orders", func(w http.ResponseWriter, r *http.Request) {})
+
+	for i := 0; i < 3; i++ {
+		req := httptest.NewRequest(http.MethodGet, "/orders", nil)
+		req.Header.Set("X-Api-Key", "key-123")
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, req)
+		fmt.Println(w.Code)
+		if w.Code == http.StatusTooManyRequests {
+			fmt.Println("Retry-After:", w.Header().Get("Retry-After"))
+		}
+	}
+
+	// Output:
+	// 200
+	// 200
+	// 429
+	// Retry-After: 1
+}
+
+func ExampleAuth() {
+	auth := router.NewAPIKeyAuthenticator("X-Api-Key", map[string]router.Principal{
+		"key-123": {ID: "warehouse", Scopes: []string{"inventory:write"}},
+	})
+
+	r := router.NewWithOptions(router.Options{})
+	r.With(router.Auth(auth)).Post("/inventory", func(w http.ResponseWriter, r *http.Request) {
+		p, _ := router.PrincipalFromContext(r.Context())
+		fmt.Println("caller:", p.ID, p.HasScope("inventory:write"))
+	})
+
+	for _, key := range []string{"key-123", "wrong"} {
+		req := httptest.NewRequest(http.MethodPost, "/inventory", nil)
+		req.Header.Set("X-Api-Key", key)
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, req)
+		fmt.Println(w.Code)
+	}
+
+	// Output:
+	// caller: warehouse true
+	// 200
+	// 401
+}
+
+func ExampleRouter_SPAFS() {
+	// In a real service this would be an embed.FS holding the built app
+	dist := fstest.MapFS{
+		"index.html":             {Data: []byte("<html>dashboard</html>")},
+		"assets/app.9c1e4b7a.js": {Data: []byte("render()")},
+	}
+
+	r := router.NewWithOptions(router.Options{})
+	r.SPAFS("/", dist, "index.html")
+
+	for _, path := range []string{"/orders/42", "/assets/app.9c1e4b7a.js"} {
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, httptest.NewRequest(http.MethodGet, path, nil))
+		fmt.Println(path, w.Code, w.Header().Get("Cache-Control"))
+	}
+
+	// Output:
+	// /orders/42 200 no-cache
+	// /assets/app.9c1e4b7a.js 200 public, max-age=31536000, immutable
+}
+
+func ExampleWebsocket() {
+	r := router.New()
+
+	r.Get("/ws/echo", router.Websocket(func(ctx context.Context, conn *websocket.Conn) {
+		for {
+			messageType, p, err := conn.ReadMessage()
+			if err != nil {
+				return
+			}
+			logger.WithContext(ctx).Debug("Echoing message")
+			conn.WriteMessage(messageType, p)
+		}
+	}))
+}
+
+func ExampleSessions() {
+	r := router.NewWithOptions(router.Options{})
+	r.Use(router.Sessions(router.SessionOptions{
+		Cookie: router.CookieOptions{Keys: [][]byte{[]byte("use-32-random-bytes-in-production")}},
+	}))
+	r.Post("/login", func(w http.ResponseWriter, r *http.Request) {
+		router.SessionFromContext(r.Context()).Set("user_id", "42")
+		w.WriteHeader(http.StatusNoContent)
+	})
+	r.Get("/me", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, "user ", router.SessionFromContext(r.Context()).Get("user_id"))
+	})
+
+	// Log in, then send the session cookie back
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, httptest.NewRequest(http.MethodPost, "/login", nil))
+	cookie := w.Result().Cookies()[0]
+	fmt.Println("HttpOnly:", cookie.HttpOnly, "Secure:", cookie.Secure)
+
+	req := httptest.NewRequest(http.MethodGet, "/me", nil)
+	req.AddCookie(cookie)
+	w = httptest.NewRecorder()
+	r.ServeHTTP(w, req)
+	fmt.Println(w.Body.String())
+
+	// Output:
+	// HttpOnly: true Secure: true
+	// user 42
+}
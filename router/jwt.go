@@ -0,0 +1,230 @@
+package router
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/hmac"
+	"crypto/rsa"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/big"
+	"net/http"
+	"strings"
+	"time"
+)
+
+// ErrTokenExpired is returned by JWTAuthenticator for tokens past their exp
+// claim, so clients know to refresh rather than sign in again
+var ErrTokenExpired = errors.New("token expired")
+
+// JWTOptions configures a JWTAuthenticator. Set Secret, PublicKey or both;
+// a token is accepted only with the algorithm its key is for, never "none".
+type JWTOptions struct {
+	// Secret verifies HS256 tokens
+	Secret []byte
+	// PublicKey verifies RS256 tokens when it is an *rsa.PublicKey and ES256
+	// tokens when it is a P-256 *ecdsa.PublicKey
+	PublicKey crypto.PublicKey
+	// Issuer, if set, must equal the iss claim
+	Issuer string
+	// Audience, if set, must equal the aud claim or one of its values
+	Audience string
+	// Leeway allows for clock skew when checking the exp and nbf claims
+	Leeway time.Duration
+	// TenantClaim names the claim holding the tenant ID; empty means "tenant_id"
+	TenantClaim string
+}
+
+// JWTAuthenticator authenticates requests by a JSON Web Token sent as an
+// "Authorization: Bearer" header. The principal's ID is the sub claim, its
+// scopes come from the space-separated scope claim or the scp claim, and all
+// claims are kept in Principal.Claims.
+type JWTAuthenticator struct {
+	opts JWTOptions
+	now  func() time.Time
+}
+
+// NewJWTAuthenticator creates a JWTAuthenticator. It returns an error if
+// opts has no key or PublicKey is of an unsupported type.
+func NewJWTAuthenticator(opts JWTOptions) (*JWTAuthenticator, error) {
+	switch key := opts.PublicKey.(type) {
+	case nil:
+		if len(opts.Secret) == 0 {
+			return nil, errors.New("router: JWTOptions needs a Secret or PublicKey")
+		}
+	case *rsa.PublicKey:
+	case *ecdsa.PublicKey:
+		if key.Curve != elliptic.P256() {
+			return nil, errors.New("router: only P-256 ECDSA keys are supported")
+		}
+	default:
+		return nil, fmt.Errorf("router: unsupported JWT public key type %T", opts.PublicKey)
+	}
+	if opts.TenantClaim == "" {
+		opts.TenantClaim = "tenant_id"
+	}
+	return &JWTAuthenticator{opts: opts, now: time.Now}, nil
+}
+
+// Authenticate implements Authenticator
+func (a *JWTAuthenticator) Authenticate(r *http.Request) (Principal, error) {
+	token, ok := bearerToken(r)
+	if !ok {
+		return Principal{}, ErrMissingCredentials
+	}
+
+	claims, err := a.verify(token)
+	if err != nil {
+		return Principal{}, err
+	}
+	if err := a.checkClaims(claims); err != nil {
+		return Principal{}, err
+	}
+
+	p := Principal{Claims: claims}
+	p.ID, _ = claims["sub"].(string)
+	p.TenantID, _ = claims[a.opts.TenantClaim].(string)
+	p.Scopes = scopes(claims)
+	return p, nil
+}
+
+// verify checks the token's signature and returns its claims
+func (a *JWTAuthenticator) verify(token string) (map[string]any, error) {
+	parts := strings.Split(token, ".")
+	if len(parts) != 3 {
+		return nil, invalidToken("malformed token")
+	}
+
+	var header struct {
+		Alg string `json:"alg"`
+	}
+	if err := decodeSegment(parts[0], &header); err != nil {
+		return nil, invalidToken("malformed header")
+	}
+	sig, err := base64.RawURLEncoding.DecodeString(parts[2])
+	if err != nil {
+		return nil, invalidToken("malformed signature")
+	}
+
+	signed := []byte(parts[0] + "." + parts[1])
+	digest := sha256.Sum256(signed)
+	valid := false
+	switch header.Alg {
+	case "HS256":
+		if len(a.opts.Secret) == 0 {
+			return nil, invalidToken("unsupported algorithm HS256")
+		}
+		mac := hmac.New(sha256.New, a.opts.Secret)
+		mac.Write(signed)
+		valid = hmac.Equal(mac.Sum(nil), sig)
+	case "RS256":
+		key, ok := a.opts.PublicKey.(*rsa.PublicKey)
+		if !ok {
+			return nil, invalidToken("unsupported algorithm RS256")
+		}
+		valid = rsa.VerifyPKCS1v15(key, crypto.SHA256, digest[:], sig) == nil
+	case "ES256":
+		key, ok := a.opts.PublicKey.(*ecdsa.PublicKey)
+		if !ok {
+			return nil, invalidToken("unsupported algorithm ES256")
+		}
+		if len(sig) == 64 {
+			r := new(big.Int).SetBytes(sig[:32])
+			s := new(big.Int).SetBytes(sig[32:])
+			valid = ecdsa.Verify(key, digest[:], r, s)
+		}
+	default:
+		return nil, invalidToken("unsupported algorithm " + header.Alg)
+	}
+	if !valid {
+		return nil, invalidToken("bad signature")
+	}
+
+	var claims map[string]any
+	if err := decodeSegment(parts[1], &claims); err != nil || claims == nil {
+		return nil, invalidToken("malformed claims")
+	}
+	return claims, nil
+}
+
+// checkClaims checks the registered time, issuer and audience claims
+func (a *JWTAuthenticator) checkClaims(claims map[string]any) error {
+	now := a.now()
+	if exp, ok := numericDate(claims["exp"]); ok && !now.Before(exp.Add(a.opts.Leeway)) {
+		return ErrTokenExpired
+	}
+	if nbf, ok := numericDate(claims["nbf"]); ok && now.Add(a.opts.Leeway).Before(nbf) {
+		return invalidToken("token not valid yet")
+	}
+	if a.opts.Issuer != "" && claims["iss"] != a.opts.Issuer {
+		return invalidToken("wrong issuer")
+	}
+	if a.opts.Audience != "" && !hasAudience(claims["aud"], a.opts.Audience) {
+		return invalidToken("wrong audience")
+	}
+	return nil
+}
+
+// invalidToken wraps ErrInvalidCredentials with the reason a token was rejected
+func invalidToken(reason string) error {
+	return fmt.Errorf("%w: %s", ErrInvalidCredentials, reason)
+}
+
+// decodeSegment decodes a base64url JSON segment of a token into v
+func decodeSegment(seg string, v any) error {
+	data, err := base64.RawURLEncoding.DecodeString(seg)
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(data, v)
+}
+
+// numericDate converts a JWT NumericDate claim to a time
+func numericDate(v any) (time.Time, bool) {
+	f, ok := v.(float64)
+	if !ok {
+		return time.Time{}, false
+	}
+	sec := int64(f)
+	return time.Unix(sec, int64((f-float64(sec))*float64(time.Second))), true
+}
+
+// hasAudience reports whether the aud claim, a string or array, contains want
+func hasAudience(aud any, want string) bool {
+	switch v := aud.(type) {
+	case string:
+		return v == want
+	case []any:
+		for _, a := range v {
+			if a == want {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// scopes reads the scope claim, a space-separated string, or the scp claim,
+// a string or array
+func scopes(claims map[string]any) []string {
+	if s, ok := claims["scope"].(string); ok {
+		return strings.Fields(s)
+	}
+	switch v := claims["scp"].(type) {
+	case string:
+		return strings.Fields(v)
+	case []any:
+		var out []string
+		for _, s := range v {
+			if str, ok := s.(string); ok {
+				out = append(out, str)
+			}
+		}
+		return out
+	}
+	return nil
+}
@@ -0,0 +1,190 @@
+package router
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+var jwtTestNow = time.Date(2024, 3, 1, 12, 0, 0, 0, time.UTC)
+
+// signJWT builds a token for claims signed with key using alg
+func signJWT(t *testing.T, alg string, key any, claims map[string]any) string {
+	t.Helper()
+	header, _ := json.Marshal(map[string]string{"alg": alg, "typ": "JWT"})
+	payload, _ := json.Marshal(claims)
+	signed := base64.RawURLEncoding.EncodeToString(header) + "." + base64.RawURLEncoding.EncodeToString(payload)
+	digest := sha256.Sum256([]byte(signed))
+
+	var sig []byte
+	switch alg {
+	case "HS256":
+		mac := hmac.New(sha256.New, key.([]byte))
+		mac.Write([]byte(signed))
+		sig = mac.Sum(nil)
+	case "RS256":
+		var err error
+		if sig, err = rsa.SignPKCS1v15(rand.Reader, key.(*rsa.PrivateKey), crypto.SHA256, digest[:]); err != nil {
+			t.Fatal(err)
+		}
+	case "ES256":
+		r, s, err := ecdsa.Sign(rand.Reader, key.(*ecdsa.PrivateKey), digest[:])
+		if err != nil {
+			t.Fatal(err)
+		}
+		sig = make([]byte, 64)
+		r.FillBytes(sig[:32])
+		s.FillBytes(sig[32:])
+	}
+	return signed + "." + base64.RawURLEncoding.EncodeToString(sig)
+}
+
+func newTestJWTAuthenticator(t *testing.T, opts JWTOptions) *JWTAuthenticator {
+	t.Helper()
+	a, err := NewJWTAuthenticator(opts)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	a.now = func() time.Time { return jwtTestNow }
+	return a
+}
+
+func bearerRequest(token string) *http.Request {
+	req := httptest.NewRequest(http.MethodGet, "/", nil)
+	if token != "" {
+		req.Header.Set("Authorization", "Bearer "+token)
+	}
+	return req
+}
+
+func TestJWTAuthenticator(t *testing.T) {
+	secret := []byte("s3cret")
+	a := newTestJWTAuthenticator(t, JWTOptions{
+		Secret:   secret,
+		Issuer:   "https://auth.example.com",
+		Audience: "orders",
+		Leeway:   30 * time.Second,
+	})
+
+	valid := func() map[string]any {
+		return map[string]any{
+			"sub":       "u1",
+			"tenant_id": "acme",
+			"scope":     "orders:read orders:write",
+			"iss":       "https://auth.example.com",
+			"aud":       []string{"billing", "orders"},
+			"exp":       jwtTestNow.Add(time.Hour).Unix(),
+			"nbf":       jwtTestNow.Add(-time.Minute).Unix(),
+		}
+	}
+	with := func(key string, value any) map[string]any {
+		c := valid()
+		if value == nil {
+			delete(c, key)
+		} else {
+			c[key] = value
+		}
+		return c
+	}
+
+	tests := []struct {
+		name    string
+		token   string
+		wantErr error
+	}{
+		{"valid", signJWT(t, "HS256", secret, valid()), nil},
+		{"within leeway", signJWT(t, "HS256", secret, with("exp", jwtTestNow.Add(-10*time.Second).Unix())), nil},
+		{"no exp", signJWT(t, "HS256", secret, with("exp", nil)), nil},
+		{"missing", "", ErrMissingCredentials},
+		{"expired", signJWT(t, "HS256", secret, with("exp", jwtTestNow.Add(-time.Minute).Unix())), ErrTokenExpired},
+		{"not yet valid", signJWT(t, "HS256", secret, with("nbf", jwtTestNow.Add(time.Hour).Unix())), ErrInvalidCredentials},
+		{"wrong issuer", signJWT(t, "HS256", secret, with("iss", "https://evil.example.com")), ErrInvalidCredentials},
+		{"wrong audience", signJWT(t, "HS256", secret, with("aud", "billing")), ErrInvalidCredentials},
+		{"wrong secret", signJWT(t, "HS256", []byte("other"), valid()), ErrInvalidCredentials},
+		{"alg none", signJWT(t, "none", nil, valid()), ErrInvalidCredentials},
+		{"malformed", "not.a.jwt", ErrInvalidCredentials},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			p, err := a.Authenticate(bearerRequest(tc.token))
+			if !errors.Is(err, tc.wantErr) {
+				t.Fatalf("Expected error %v, got %v", tc.wantErr, err)
+			}
+			if tc.wantErr != nil {
+				return
+			}
+			if p.ID != "u1" || p.TenantID != "acme" {
+				t.Errorf("Expected principal u1 of acme, got %+v", p)
+			}
+			if !p.HasScope("orders:write") {
+				t.Errorf("Expected scope orders:write, got %v", p.Scopes)
+			}
+			if p.Claims["iss"] != "https://auth.example.com" {
+				t.Errorf("Expected claims to be kept, got %v", p.Claims)
+			}
+		})
+	}
+}
+
+func TestJWTAuthenticator_PublicKeys(t *testing.T) {
+	rsaKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatal(err)
+	}
+	ecKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	claims := map[string]any{"sub": "svc", "scp": []string{"inventory:sync"}}
+
+	rsaAuth := newTestJWTAuthenticator(t, JWTOptions{PublicKey: &rsaKey.PublicKey})
+	p, err := rsaAuth.Authenticate(bearerRequest(signJWT(t, "RS256", rsaKey, claims)))
+	if err != nil || p.ID != "svc" || !p.HasScope("inventory:sync") {
+		t.Errorf("Expected RS256 token to be accepted, got %+v, %v", p, err)
+	}
+
+	ecAuth := newTestJWTAuthenticator(t, JWTOptions{PublicKey: &ecKey.PublicKey})
+	if _, err := ecAuth.Authenticate(bearerRequest(signJWT(t, "ES256", ecKey, claims))); err != nil {
+		t.Errorf("Expected ES256 token to be accepted, got %v", err)
+	}
+
+	// A token must use the algorithm of the configured key
+	if _, err := rsaAuth.Authenticate(bearerRequest(signJWT(t, "HS256", []byte("x"), claims))); !errors.Is(err, ErrInvalidCredentials) {
+		t.Errorf("Expected HS256 token to be rejected by an RSA key, got %v", err)
+	}
+	if _, err := ecAuth.Authenticate(bearerRequest(signJWT(t, "RS256", rsaKey, claims))); !errors.Is(err, ErrInvalidCredentials) {
+		t.Errorf("Expected RS256 token to be rejected by an ECDSA key, got %v", err)
+	}
+}
+
+func TestNewJWTAuthenticator_Errors(t *testing.T) {
+	p384, _ := ecdsa.GenerateKey(elliptic.P384(), rand.Reader)
+
+	tests := []struct {
+		name string
+		opts JWTOptions
+	}{
+		{"no key", JWTOptions{}},
+		{"unsupported key", JWTOptions{PublicKey: "key"}},
+		{"unsupported curve", JWTOptions{PublicKey: &p384.PublicKey}},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			if _, err := NewJWTAuthenticator(tc.opts); err == nil {
+				t.Error("Expected an error")
+			}
+		})
+	}
+}
@@ -0,0 +1,163 @@
+package router
+
+import (
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/StairSupplies/go-core/logger"
+	"go.uber.org/zap"
+)
+
+// DefaultSlowRequestThreshold is the LoggerOptions.SlowRequestThreshold set
+// by DefaultOptions
+const DefaultSlowRequestThreshold = 2 * time.Second
+
+// DefaultLatencyBuckets are the upper bounds used by NewLatencyHistogram when
+// none are given
+var DefaultLatencyBuckets = []time.Duration{
+	5 * time.Millisecond,
+	10 * time.Millisecond,
+	25 * time.Millisecond,
+	50 * time.Millisecond,
+	100 * time.Millisecond,
+	250 * time.Millisecond,
+	500 * time.Millisecond,
+	time.Second,
+	2500 * time.Millisecond,
+	5 * time.Second,
+	10 * time.Second,
+}
+
+// LatencyHistogram counts request durations in buckets per method and route
+// pattern. Set it as LoggerOptions.Latency to record every logged request,
+// and read it with Snapshot to export or aggregate it:
+//
+//	latency := router.NewLatencyHistogram()
+//	opts.LoggerOptions.Latency = latency
+//
+// It is safe for concurrent use.
+type LatencyHistogram struct {
+	buckets []time.Duration
+
+	mu     sync.Mutex
+	routes map[routeKey]*RouteLatency
+}
+
+// routeKey identifies one route in a LatencyHistogram
+type routeKey struct {
+	method string
+	route  string
+}
+
+// RouteLatency is the latency distribution of one route
+type RouteLatency struct {
+	Method string
+	// Route is the chi route pattern, such as "/orders/{id}", or empty for
+	// requests that matched no route
+	Route string
+	// Buckets are the upper bounds of the histogram, in increasing order
+	Buckets []time.Duration
+	// Counts holds, for each bucket, the number of requests that took at
+	// most its upper bound; it is cumulative like a Prometheus histogram
+	Counts []uint64
+	// Count is the number of requests, including those slower than the
+	// largest bucket
+	Count uint64
+	// Sum is the total duration of the requests
+	Sum time.Duration
+}
+
+// NewLatencyHistogram creates a LatencyHistogram with buckets as upper bounds,
+// or DefaultLatencyBuckets if none are given
+func NewLatencyHistogram(buckets ...time.Duration) *LatencyHistogram {
+	if len(buckets) == 0 {
+		buckets = DefaultLatencyBuckets
+	}
+	buckets = append([]time.Duration(nil), buckets...)
+	sort.Slice(buckets, func(i, j int) bool { return buckets[i] < buckets[j] })
+
+	return &LatencyHistogram{
+		buckets: buckets,
+		routes:  make(map[routeKey]*RouteLatency),
+	}
+}
+
+// Observe records a request to route that took d
+func (h *LatencyHistogram) Observe(method, route string, d time.Duration) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	key := routeKey{method: method, route: route}
+	rl, ok := h.routes[key]
+	if !ok {
+		rl = &RouteLatency{
+			Method:  method,
+			Route:   route,
+			Buckets: h.buckets,
+			Counts:  make([]uint64, len(h.buckets)),
+		}
+		h.routes[key] = rl
+	}
+
+	for i, bound := range h.buckets {
+		if d <= bound {
+			rl.Counts[i]++
+		}
+	}
+	rl.Count++
+	rl.Sum += d
+}
+
+// Snapshot returns a copy of the distribution of each route, sorted by route
+// and method
+func (h *LatencyHistogram) Snapshot() []RouteLatency {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	out := make([]RouteLatency, 0, len(h.routes))
+	for _, rl := range h.routes {
+		c := *rl
+		c.Counts = append([]uint64(nil), rl.Counts...)
+		out = append(out, c)
+	}
+	sort.Slice(out, func(i, j int) bool {
+		if out[i].Route != out[j].Route {
+			return out[i].Route < out[j].Route
+		}
+		return out[i].Method < out[j].Method
+	})
+	return out
+}
+
+// bucket returns the smallest upper bound d fits in, formatted as for the
+// latency_bucket log field, or "+Inf" if d exceeds them all
+func (h *LatencyHistogram) bucket(d time.Duration) string {
+	for _, bound := range h.buckets {
+		if d <= bound {
+			return bound.String()
+		}
+	}
+	return "+Inf"
+}
+
+// logRequestCompleted logs the end of a request at info level, or at warn
+// level with slow_request set if it took at least opts.SlowRequestThreshold,
+// and records it in opts.Latency
+func logRequestCompleted(log *logger.Logger, opts LoggerOptions, method, route string, duration time.Duration) {
+	var fields []zap.Field
+	if opts.Latency != nil {
+		opts.Latency.Observe(method, route, duration)
+		fields = append(fields, zap.String("latency_bucket", opts.Latency.bucket(duration)))
+	}
+
+	if opts.SlowRequestThreshold > 0 && duration >= opts.SlowRequestThreshold {
+		fields = append(fields,
+			zap.Bool("slow_request", true),
+			zap.Duration("slow_request_threshold", opts.SlowRequestThreshold),
+		)
+		log.Warn("HTTP request completed", fields...)
+		return
+	}
+	log.Info("HTTP request completed", fields...)
+}
@@ -0,0 +1,134 @@
+package router
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/StairSupplies/go-core/logger/logtest"
+	"go.uber.org/zap/zapcore"
+)
+
+func TestLatencyHistogram(t *testing.T) {
+	h := NewLatencyHistogram(100*time.Millisecond, 10*time.Millisecond)
+
+	h.Observe("GET", "/orders/{id}", 5*time.Millisecond)
+	h.Observe("GET", "/orders/{id}", 50*time.Millisecond)
+	h.Observe("GET", "/orders/{id}", time.Second)
+	h.Observe("POST", "/orders", 10*time.Millisecond)
+
+	got := h.Snapshot()
+	want := []RouteLatency{
+		{
+			Method:  "POST",
+			Route:   "/orders",
+			Buckets: []time.Duration{10 * time.Millisecond, 100 * time.Millisecond},
+			Counts:  []uint64{1, 1},
+			Count:   1,
+			Sum:     10 * time.Millisecond,
+		},
+		{
+			Method:  "GET",
+			Route:   "/orders/{id}",
+			Buckets: []time.Duration{10 * time.Millisecond, 100 * time.Millisecond},
+			Counts:  []uint64{1, 2},
+			Count:   3,
+			Sum:     1055 * time.Millisecond,
+		},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("Expected %+v, got %+v", want, got)
+	}
+
+	// Snapshots are copies
+	got[0].Counts[0] = 99
+	if h.Snapshot()[0].Counts[0] != 1 {
+		t.Error("Expected snapshot to be independent of the histogram")
+	}
+}
+
+func TestLatencyHistogram_Bucket(t *testing.T) {
+	h := NewLatencyHistogram()
+
+	tests := []struct {
+		d    time.Duration
+		want string
+	}{
+		{time.Millisecond, "5ms"},
+		{5 * time.Millisecond, "5ms"},
+		{300 * time.Millisecond, "500ms"},
+		{time.Minute, "+Inf"},
+	}
+	for _, tt := range tests {
+		if got := h.bucket(tt.d); got != tt.want {
+			t.Errorf("bucket(%v): expected %q, got %q", tt.d, tt.want, got)
+		}
+	}
+}
+
+func TestLogRequestCompleted(t *testing.T) {
+	opts := LoggerOptions{SlowRequestThreshold: time.Second, Latency: NewLatencyHistogram()}
+
+	t.Run("fast", func(t *testing.T) {
+		log, rec := logtest.NewTestLogger(t)
+		logRequestCompleted(log, opts, "GET", "/orders", 20*time.Millisecond)
+
+		rec.AssertLogged(zapcore.InfoLevel, "HTTP request completed", map[string]interface{}{
+			"latency_bucket": "25ms",
+		})
+		if entries := rec.FilterField("slow_request", true); len(entries) != 0 {
+			t.Errorf("Expected no slow_request field, got %v", entries)
+		}
+	})
+
+	t.Run("slow", func(t *testing.T) {
+		log, rec := logtest.NewTestLogger(t)
+		logRequestCompleted(log, opts, "GET", "/reports", 3*time.Second)
+
+		rec.AssertLogged(zapcore.WarnLevel, "HTTP request completed", map[string]interface{}{
+			"slow_request":   true,
+			"latency_bucket": "5s",
+		})
+	})
+
+	t.Run("threshold disabled", func(t *testing.T) {
+		log, rec := logtest.NewTestLogger(t)
+		logRequestCompleted(log, LoggerOptions{}, "GET", "/reports", time.Hour)
+
+		rec.AssertLogged(zapcore.InfoLevel, "HTTP request completed", nil)
+		rec.AssertNotLogged(zapcore.WarnLevel, "HTTP request completed")
+	})
+
+	snapshot := opts.Latency.Snapshot()
+	if len(snapshot) != 2 || snapshot[0].Route != "/orders" || snapshot[1].Route != "/reports" {
+		t.Errorf("Expected both requests recorded, got %+v", snapshot)
+	}
+}
+
+func TestLogger_Latency(t *testing.T) {
+	latency := NewLatencyHistogram()
+	r := NewWithOptions(Options{
+		EnableLogging: true,
+		LoggerOptions: LoggerOptions{Latency: latency},
+	})
+	r.Get("/orders/{id}", func(w http.ResponseWriter, req *http.Request) {
+		w.WriteHeader(http.StatusNoContent)
+	})
+
+	for _, path := range []string{"/orders/1", "/orders/2", "/missing"} {
+		r.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, path, nil))
+	}
+
+	snapshot := latency.Snapshot()
+	if len(snapshot) != 2 {
+		t.Fatalf("Expected 2 routes, got %+v", snapshot)
+	}
+	if snapshot[0].Route != "" || snapshot[0].Count != 1 {
+		t.Errorf("Expected unmatched request under empty route, got %+v", snapshot[0])
+	}
+	if snapshot[1].Route != "/orders/{id}" || snapshot[1].Count != 2 {
+		t.Errorf("Expected requests grouped by route pattern, got %+v", snapshot[1])
+	}
+}
@@ -2,9 +2,13 @@ package router
 
 import (
 	"net/http"
+	"strings"
 	"time"
 
+	"github.com/StairSupplies/go-core/api"
+	"github.com/StairSupplies/go-core/ctxutils"
 	"github.com/StairSupplies/go-core/logger"
+	"github.com/go-chi/chi/v5"
 	"github.com/go-chi/chi/v5/middleware"
 	"go.uber.org/zap"
 )
@@ -36,7 +40,7 @@ func Logger(opts LoggerOptions) func(next http.Handler) http.Handler {
 			requestLog := reqLogger.With(
 				zap.String("method", r.Method),
 				zap.String("path", r.URL.Path),
-				zap.String("request_id", middleware.GetReqID(r.Context())),
+				zap.String("request_id", ctxutils.RequestID(r.Context())),
 				zap.String("remote_addr", r.RemoteAddr),
 			)
 
@@ -44,24 +48,30 @@ func Logger(opts LoggerOptions) func(next http.Handler) http.Handler {
 			if opts.LogRequestHeaders {
 				for k, v := range r.Header {
 					if len(v) > 0 {
-						requestLog = requestLog.With(zap.String("req_header_"+k, v[0]))
+						requestLog = requestLog.With(zap.String("req_header_"+k, headerValue(opts, k, v[0])))
 					}
 				}
 			}
 
+			// Capture the start of the request body if enabled, leaving it readable
+			var bodyFields []zap.Field
+			if opts.LogRequestBody {
+				bodyFields = bodyLogFields(opts, r)
+			}
+
 			// Add logger to request context
 			ctx := logger.NewContext(r.Context(), requestLog)
 			r = r.WithContext(ctx)
 
-			// Log request start
-			requestLog.Info("HTTP request started")
+			// Log request start, correlated with the active trace if any
+			requestLog.WithTrace(ctx).Info("HTTP request started", bodyFields...)
 
 			// Process request
 			next.ServeHTTP(ww, r)
 
 			// Log response
 			duration := time.Since(start)
-			responseLog := requestLog.With(
+			responseLog := requestLog.WithTrace(ctx).With(
 				zap.Int("status", ww.Status()),
 				zap.Duration("duration", duration),
 				zap.Int("size", ww.BytesWritten()),
@@ -71,16 +81,33 @@ func Logger(opts LoggerOptions) func(next http.Handler) http.Handler {
 			if opts.LogResponseHeaders {
 				for k, v := range ww.Header() {
 					if len(v) > 0 {
-						responseLog = responseLog.With(zap.String("resp_header_"+k, v[0]))
+						responseLog = responseLog.With(zap.String("resp_header_"+k, headerValue(opts, k, v[0])))
 					}
 				}
 			}
 
-			responseLog.Info("HTTP request completed")
+			// The route pattern is only known once chi has routed the request
+			var route string
+			if rctx := chi.RouteContext(r.Context()); rctx != nil {
+				route = rctx.RoutePattern()
+			}
+			logRequestCompleted(responseLog, opts, r.Method, route, duration)
 		})
 	}
 }
 
+// headerValue returns value, or logger.RedactedValue for sensitive headers
+func headerValue(opts LoggerOptions, name, value string) string {
+	for _, lists := range [][]string{DefaultRedactedHeaders, opts.RedactedHeaders} {
+		for _, h := range lists {
+			if strings.EqualFold(h, name) {
+				return logger.RedactedValue
+			}
+		}
+	}
+	return value
+}
+
 // RequestID sets a unique ID for each request.
 // This is a wrapper around chi's RequestID middleware.
 // Request IDs are used for tracing requests in logs and responses.
@@ -88,9 +115,13 @@ func RequestID(next http.Handler) http.Handler {
 	return middleware.RequestID(next)
 }
 
-// Timeout sets a timeout for the request.
-// This is a wrapper around chi's Timeout middleware.
-// It helps prevent long-running requests from consuming resources indefinitely.
-func Timeout(duration time.Duration) func(next http.Handler) http.Handler {
-	return middleware.Timeout(duration)
-}
\ No newline at end of file
+// ErrorHandler makes h report errors from handlers wrapped with
+// WithErrorHandler for every request passing through the middleware.
+// It is applied automatically when Options.ErrorHandler is set.
+func ErrorHandler(h api.ErrorHandler) func(next http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			next.ServeHTTP(w, r.WithContext(api.ContextWithErrorHandler(r.Context(), h)))
+		})
+	}
+}
@@ -7,6 +7,7 @@ import (
 	"testing"
 	"time"
 
+	"github.com/StairSupplies/go-core/logger"
 	"github.com/go-chi/chi/v5/middleware"
 )
 
@@ -175,4 +176,26 @@ func TestTimeout(t *testing.T) {
 			t.Error("Expected timeout middleware to be created")
 		}
 	})
-}
\ No newline at end of file
+}
+
+func TestHeaderValue_Redacted(t *testing.T) {
+	opts := LoggerOptions{RedactedHeaders: []string{"X-Session"}}
+
+	tests := []struct {
+		name  string
+		value string
+		want  string
+	}{
+		{"Authorization", "Bearer abc", logger.RedactedValue},
+		{"Cookie", "session=abc", logger.RedactedValue},
+		{"x-api-key", "key", logger.RedactedValue},
+		{"X-Session", "abc", logger.RedactedValue},
+		{"Accept", "application/json", "application/json"},
+	}
+
+	for _, tc := range tests {
+		if got := headerValue(opts, tc.name, tc.value); got != tc.want {
+			t.Errorf("headerValue(%q) = %q, want %q", tc.name, got, tc.want)
+		}
+	}
+}
@@ -0,0 +1,89 @@
+package router
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/StairSupplies/go-core/api"
+	"github.com/go-chi/chi/v5"
+)
+
+// routeMethods are the methods checked when listing the methods a path allows
+var routeMethods = []string{
+	http.MethodGet,
+	http.MethodHead,
+	http.MethodPost,
+	http.MethodPut,
+	http.MethodPatch,
+	http.MethodDelete,
+	http.MethodConnect,
+	http.MethodOptions,
+	http.MethodTrace,
+}
+
+// DefaultNotFoundHandler writes a 404 api error for requests that match no
+// route. It is used when Options.NotFoundHandler is nil.
+func DefaultNotFoundHandler(w http.ResponseWriter, r *http.Request) {
+	api.WriteError(w, api.NotFoundError(fmt.Errorf("no route for %s %s", r.Method, r.URL.Path)))
+}
+
+// DefaultMethodNotAllowedHandler writes a 405 api error, with an Allow
+// header listing the methods the path supports, for requests whose path
+// matches a route but not its method. It is used when
+// Options.MethodNotAllowedHandler is nil.
+func DefaultMethodNotAllowedHandler(w http.ResponseWriter, r *http.Request) {
+	allowed := allowedMethods(r)
+	if len(allowed) > 0 {
+		w.Header().Set("Allow", strings.Join(allowed, ", "))
+	}
+	api.WriteError(w, api.NewError(http.StatusMethodNotAllowed,
+		fmt.Errorf("method %s is not allowed for %s", r.Method, r.URL.Path)))
+}
+
+// allowedMethods returns the methods with a route for the request's path
+func allowedMethods(r *http.Request) []string {
+	rctx := chi.RouteContext(r.Context())
+	if rctx == nil || rctx.Routes == nil {
+		return nil
+	}
+	path := r.URL.RawPath
+	if path == "" {
+		path = r.URL.Path
+	}
+
+	var allowed []string
+	for _, m := range routeMethods {
+		if rctx.Routes.Match(chi.NewRouteContext(), m, path) {
+			allowed = append(allowed, m)
+		}
+	}
+	return allowed
+}
+
+// notFoundHandler returns the handler the router uses for requests that
+// match no route, including one set with NotFound after it was created
+func (r *Router) notFoundHandler() http.HandlerFunc {
+	if mux, ok := r.Router.(interface{ NotFoundHandler() http.HandlerFunc }); ok {
+		return mux.NotFoundHandler()
+	}
+	if r.options.NotFoundHandler != nil {
+		return r.options.NotFoundHandler
+	}
+	return DefaultNotFoundHandler
+}
+
+// registerNotFoundHandlers installs the 404 and 405 handlers set in options,
+// or the api error defaults
+func registerNotFoundHandlers(r chi.Router, options Options) {
+	notFound := options.NotFoundHandler
+	if notFound == nil {
+		notFound = DefaultNotFoundHandler
+	}
+	methodNotAllowed := options.MethodNotAllowedHandler
+	if methodNotAllowed == nil {
+		methodNotAllowed = DefaultMethodNotAllowedHandler
+	}
+	r.NotFound(notFound)
+	r.MethodNotAllowed(methodNotAllowed)
+}
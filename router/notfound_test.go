@@ -0,0 +1,89 @@
+package router
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/go-chi/chi/v5"
+)
+
+// errorEnvelope is the body written by api.WriteError
+type errorEnvelope struct {
+	Error struct {
+		StatusCode int    `json:"status_code"`
+		Message    string `json:"message"`
+	} `json:"error"`
+}
+
+func TestNotFoundHandlers(t *testing.T) {
+	r := New()
+	r.Get("/orders", func(w http.ResponseWriter, r *http.Request) {})
+	r.Post("/orders", func(w http.ResponseWriter, r *http.Request) {})
+	r.Route("/admin", func(r chi.Router) {
+		r.Delete("/cache", func(w http.ResponseWriter, r *http.Request) {})
+	})
+
+	tests := []struct {
+		name      string
+		method    string
+		path      string
+		wantCode  int
+		wantAllow string
+	}{
+		{"unknown path", http.MethodGet, "/missing", http.StatusNotFound, ""},
+		{"wrong method", http.MethodDelete, "/orders", http.StatusMethodNotAllowed, "GET, POST"},
+		{"wrong method in sub-router", http.MethodGet, "/admin/cache", http.StatusMethodNotAllowed, "DELETE"},
+		{"unknown path in sub-router", http.MethodGet, "/admin/missing", http.StatusNotFound, ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rec := httptest.NewRecorder()
+			r.ServeHTTP(rec, httptest.NewRequest(tt.method, tt.path, nil))
+
+			if rec.Code != tt.wantCode {
+				t.Fatalf("Expected status %d, got %d", tt.wantCode, rec.Code)
+			}
+			if got := rec.Header().Get("Allow"); got != tt.wantAllow {
+				t.Errorf("Expected Allow %q, got %q", tt.wantAllow, got)
+			}
+			if ct := rec.Header().Get("Content-Type"); ct != "application/json" {
+				t.Errorf("Expected JSON response, got %q", ct)
+			}
+
+			var body errorEnvelope
+			if err := json.Unmarshal(rec.Body.Bytes(), &body); err != nil {
+				t.Fatalf("Expected api error envelope, got %s", rec.Body.String())
+			}
+			if body.Error.StatusCode != tt.wantCode || body.Error.Message == "" {
+				t.Errorf("Unexpected error body %s", rec.Body.String())
+			}
+		})
+	}
+}
+
+func TestNotFoundHandlers_Override(t *testing.T) {
+	opts := DefaultOptions()
+	opts.NotFoundHandler = func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusTeapot)
+	}
+	opts.MethodNotAllowedHandler = func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusConflict)
+	}
+	r := NewWithOptions(opts).WithMiddleware()
+	r.Get("/orders", func(w http.ResponseWriter, r *http.Request) {})
+
+	rec := httptest.NewRecorder()
+	r.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/missing", nil))
+	if rec.Code != http.StatusTeapot {
+		t.Errorf("Expected custom 404 handler, got %d", rec.Code)
+	}
+
+	rec = httptest.NewRecorder()
+	r.ServeHTTP(rec, httptest.NewRequest(http.MethodPut, "/orders", nil))
+	if rec.Code != http.StatusConflict {
+		t.Errorf("Expected custom 405 handler, got %d", rec.Code)
+	}
+}
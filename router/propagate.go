@@ -0,0 +1,44 @@
+package router
+
+import (
+	"net/http"
+
+	"github.com/StairSupplies/go-core/ctxutils"
+	"github.com/go-chi/chi/v5/middleware"
+)
+
+// PropagateHeaders is a middleware that stores the named inbound headers in
+// the request context with ctxutils.WithPropagatedHeaders, so rest clients
+// created with rest.WithPropagateHeaders forward them. With no names it uses
+// ctxutils.DefaultPropagatedHeaders. The request ID set by the RequestID
+// middleware is stored even when the request did not carry one.
+func PropagateHeaders(names ...string) func(next http.Handler) http.Handler {
+	if len(names) == 0 {
+		names = ctxutils.DefaultPropagatedHeaders
+	}
+	canonical := make([]string, len(names))
+	for i, name := range names {
+		canonical[i] = http.CanonicalHeaderKey(name)
+	}
+	requestIDHeader := http.CanonicalHeaderKey(middleware.RequestIDHeader)
+
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			headers := make(http.Header, len(canonical))
+			for _, name := range canonical {
+				if values := r.Header.Values(name); len(values) > 0 {
+					headers[name] = values
+				} else if name == requestIDHeader {
+					if id := ctxutils.RequestID(r.Context()); id != "" {
+						headers.Set(name, id)
+					}
+				}
+			}
+			if len(headers) == 0 {
+				next.ServeHTTP(w, r)
+				return
+			}
+			next.ServeHTTP(w, r.WithContext(ctxutils.WithPropagatedHeaders(r.Context(), headers)))
+		})
+	}
+}
@@ -0,0 +1,65 @@
+package router
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/StairSupplies/go-core/ctxutils"
+)
+
+func TestPropagateHeaders(t *testing.T) {
+	var got http.Header
+	opts := DefaultOptions()
+	opts.PropagateHeaders = ctxutils.DefaultPropagatedHeaders
+	r := NewWithOptions(opts)
+	r.Get("/", func(w http.ResponseWriter, r *http.Request) {
+		got = ctxutils.PropagatedHeaders(r.Context())
+	})
+
+	req := httptest.NewRequest(http.MethodGet, "/", nil)
+	req.Header.Set("traceparent", "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01")
+	req.Header.Set("X-Other", "ignored")
+	r.ServeHTTP(httptest.NewRecorder(), req)
+
+	if got.Get("Traceparent") == "" {
+		t.Error("Expected traceparent to be stored")
+	}
+	if got.Get("X-Request-Id") == "" {
+		t.Error("Expected the generated request ID to be stored")
+	}
+	if got.Get("X-Other") != "" {
+		t.Error("Expected unlisted headers not to be stored")
+	}
+
+	t.Run("named headers", func(t *testing.T) {
+		var got http.Header
+		h := PropagateHeaders("X-Tenant")(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			got = ctxutils.PropagatedHeaders(r.Context())
+		}))
+
+		req := httptest.NewRequest(http.MethodGet, "/", nil)
+		h.ServeHTTP(httptest.NewRecorder(), req)
+		if got != nil {
+			t.Errorf("Expected nothing stored without the header, got %v", got)
+		}
+
+		req.Header.Set("x-tenant", "acme")
+		h.ServeHTTP(httptest.NewRecorder(), req)
+		if got.Get("X-Tenant") != "acme" {
+			t.Errorf("Expected X-Tenant = acme, got %v", got)
+		}
+	})
+
+	t.Run("disabled by default", func(t *testing.T) {
+		r := New()
+		got = http.Header{"Sentinel": {"1"}}
+		r.Get("/", func(w http.ResponseWriter, r *http.Request) {
+			got = ctxutils.PropagatedHeaders(r.Context())
+		})
+		r.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/", nil))
+		if got != nil {
+			t.Errorf("Expected no headers when disabled, got %v", got)
+		}
+	})
+}
@@ -0,0 +1,199 @@
+package router
+
+import (
+	"context"
+	"errors"
+	"math"
+	"net"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/StairSupplies/go-core/api"
+	"github.com/StairSupplies/go-core/logger"
+	"go.uber.org/zap"
+)
+
+// RateLimitOptions configures the rate limit middleware
+type RateLimitOptions struct {
+	// RequestsPerSecond is the sustained rate allowed for each key
+	RequestsPerSecond float64
+	// Burst is how many requests a key may make at once; zero means
+	// RequestsPerSecond rounded up
+	Burst int
+	// KeyFunc identifies the client a request counts against; nil means KeyByIP
+	KeyFunc KeyFunc
+	// Store holds the token buckets; nil means a store created with
+	// NewMemoryRateLimitStore, which only limits within one process
+	Store RateLimitStore
+	// SkipPaths lists paths that are never limited, such as health checks
+	SkipPaths []string
+}
+
+// KeyFunc returns the key a request is rate limited by
+type KeyFunc func(r *http.Request) string
+
+// RateLimitStore takes tokens from per-key token buckets. Implement it on
+// top of Redis or a similar store to share limits between instances.
+type RateLimitStore interface {
+	// Take removes one token from the bucket for key, which refills at rate
+	// tokens per second up to burst tokens
+	Take(ctx context.Context, key string, rate float64, burst int) (RateLimitResult, error)
+}
+
+// RateLimitResult is the outcome of RateLimitStore.Take
+type RateLimitResult struct {
+	// Allowed reports whether a token was taken
+	Allowed bool
+	// Remaining is the number of whole tokens left in the bucket
+	Remaining int
+	// RetryAfter is how long until a token is available when not allowed
+	RetryAfter time.Duration
+}
+
+// KeyByIP keys requests by the client IP in r.RemoteAddr. Behind a proxy,
+// use chi's middleware.RealIP first so RemoteAddr holds the client address.
+func KeyByIP(r *http.Request) string {
+	host, _, err := net.SplitHostPort(r.RemoteAddr)
+	if err != nil {
+		return r.RemoteAddr
+	}
+	return host
+}
+
+// KeyByHeader keys requests by the value of header, such as "X-Api-Key".
+// Requests without the header are keyed by client IP.
+func KeyByHeader(header string) KeyFunc {
+	return func(r *http.Request) string {
+		if v := r.Header.Get(header); v != "" {
+			return header + ":" + v
+		}
+		return KeyByIP(r)
+	}
+}
+
+// RateLimit is a middleware that limits each client to opts.RequestsPerSecond
+// with a token bucket. Requests over the limit get a 429 api error with a
+// Retry-After header. If the store fails, the error is logged and the
+// request is let through. It is applied automatically when Options.RateLimit
+// is set, and can also be used on its own for stricter limits on some routes:
+//
+//	r.With(router.RateLimit(router.RateLimitOptions{RequestsPerSecond: 1})).Post("/login", login)
+func RateLimit(opts RateLimitOptions) func(next http.Handler) http.Handler {
+	if opts.RequestsPerSecond <= 0 {
+		return func(next http.Handler) http.Handler { return next }
+	}
+	if opts.Burst <= 0 {
+		opts.Burst = int(math.Ceil(opts.RequestsPerSecond))
+	}
+	if opts.KeyFunc == nil {
+		opts.KeyFunc = KeyByIP
+	}
+	if opts.Store == nil {
+		opts.Store = NewMemoryRateLimitStore()
+	}
+	skip := make(map[string]bool, len(opts.SkipPaths))
+	for _, p := range opts.SkipPaths {
+		skip[p] = true
+	}
+	limit := strconv.Itoa(opts.Burst)
+
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if skip[r.URL.Path] {
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			res, err := opts.Store.Take(r.Context(), opts.KeyFunc(r), opts.RequestsPerSecond, opts.Burst)
+			if err != nil {
+				logger.WithContext(r.Context()).Warn("Rate limit store failed",
+					zap.Error(err),
+					zap.String("path", r.URL.Path),
+				)
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			w.Header().Set("X-RateLimit-Limit", limit)
+			w.Header().Set("X-RateLimit-Remaining", strconv.Itoa(res.Remaining))
+			if !res.Allowed {
+				seconds := int(math.Ceil(res.RetryAfter.Seconds()))
+				if seconds < 1 {
+					seconds = 1
+				}
+				w.Header().Set("Retry-After", strconv.Itoa(seconds))
+				api.WriteError(w, api.NewError(http.StatusTooManyRequests, errors.New("rate limit exceeded")))
+				return
+			}
+
+			next.ServeHTTP(w, r)
+		})
+	}
+}
+
+// MemoryRateLimitStore is a RateLimitStore that keeps buckets in memory.
+// Buckets that have refilled are discarded periodically.
+type MemoryRateLimitStore struct {
+	mu        sync.Mutex
+	buckets   map[string]*tokenBucket
+	lastSweep time.Time
+	now       func() time.Time
+}
+
+// tokenBucket is the state of one key's bucket
+type tokenBucket struct {
+	tokens float64
+	last   time.Time
+	// full is when the bucket will have refilled to burst
+	full time.Time
+}
+
+// rateLimitSweepInterval is how often MemoryRateLimitStore discards full buckets
+const rateLimitSweepInterval = time.Minute
+
+// NewMemoryRateLimitStore creates an empty in-memory store
+func NewMemoryRateLimitStore() *MemoryRateLimitStore {
+	return &MemoryRateLimitStore{
+		buckets: make(map[string]*tokenBucket),
+		now:     time.Now,
+	}
+}
+
+// Take implements RateLimitStore
+func (s *MemoryRateLimitStore) Take(_ context.Context, key string, rate float64, burst int) (RateLimitResult, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	now := s.now()
+	if now.Sub(s.lastSweep) >= rateLimitSweepInterval {
+		for k, b := range s.buckets {
+			if !now.Before(b.full) {
+				delete(s.buckets, k)
+			}
+		}
+		s.lastSweep = now
+	}
+
+	b, ok := s.buckets[key]
+	if !ok {
+		b = &tokenBucket{tokens: float64(burst), last: now}
+		s.buckets[key] = b
+	}
+
+	b.tokens = math.Min(float64(burst), b.tokens+now.Sub(b.last).Seconds()*rate)
+	b.last = now
+
+	res := RateLimitResult{}
+	if b.tokens >= 1 {
+		b.tokens--
+		res.Allowed = true
+	} else {
+		res.RetryAfter = time.Duration((1 - b.tokens) / rate * float64(time.Second))
+	}
+	res.Remaining = int(b.tokens)
+	b.full = now.Add(time.Duration((float64(burst) - b.tokens) / rate * float64(time.Second)))
+
+	return res, nil
+}
@@ -0,0 +1,147 @@
+package router
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestMemoryRateLimitStore(t *testing.T) {
+	now := time.Date(2024, 3, 4, 12, 0, 0, 0, time.UTC)
+	store := NewMemoryRateLimitStore()
+	store.now = func() time.Time { return now }
+	ctx := context.Background()
+
+	// A burst of 2 at 1 request per second
+	for i, want := range []bool{true, true, false} {
+		res, _ := store.Take(ctx, "a", 1, 2)
+		if res.Allowed != want {
+			t.Fatalf("Take %d: expected Allowed = %v, got %v", i, want, res.Allowed)
+		}
+	}
+
+	res, _ := store.Take(ctx, "a", 1, 2)
+	if res.RetryAfter != time.Second || res.Remaining != 0 {
+		t.Errorf("Expected RetryAfter 1s and Remaining 0, got %v and %d", res.RetryAfter, res.Remaining)
+	}
+
+	if res, _ := store.Take(ctx, "b", 1, 2); !res.Allowed || res.Remaining != 1 {
+		t.Errorf("Expected other keys to have their own bucket, got %+v", res)
+	}
+
+	now = now.Add(1500 * time.Millisecond)
+	if res, _ := store.Take(ctx, "a", 1, 2); !res.Allowed {
+		t.Error("Expected a token after refilling")
+	}
+
+	now = now.Add(time.Hour)
+	store.Take(ctx, "c", 1, 2)
+	if _, ok := store.buckets["a"]; ok {
+		t.Error("Expected refilled buckets to be swept")
+	}
+}
+
+func TestRateLimit(t *testing.T) {
+	store := NewMemoryRateLimitStore()
+	handler := RateLimit(RateLimitOptions{
+		RequestsPerSecond: 0.5,
+		Burst:             1,
+		Store:             store,
+		SkipPaths:         []string{"/healthz"},
+	})(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+
+	serve := func(path, remoteAddr string) *httptest.ResponseRecorder {
+		r := httptest.NewRequest(http.MethodGet, path, nil)
+		r.RemoteAddr = remoteAddr
+		w := httptest.NewRecorder()
+		handler.ServeHTTP(w, r)
+		return w
+	}
+
+	w := serve("/orders", "10.0.0.1:1234")
+	if w.Code != http.StatusOK {
+		t.Fatalf("Expected first request to pass, got %d", w.Code)
+	}
+	if w.Header().Get("X-RateLimit-Limit") != "1" || w.Header().Get("X-RateLimit-Remaining") != "0" {
+		t.Errorf("Expected limit headers, got %v", w.Header())
+	}
+
+	// Same IP, different port
+	w = serve("/orders", "10.0.0.1:5678")
+	if w.Code != http.StatusTooManyRequests {
+		t.Fatalf("Expected 429, got %d", w.Code)
+	}
+	if got := w.Header().Get("Retry-After"); got != "2" {
+		t.Errorf("Expected Retry-After 2, got %q", got)
+	}
+	if !strings.Contains(w.Body.String(), `"status_code": 429`) || !strings.Contains(w.Body.String(), "rate limit exceeded") {
+		t.Errorf("Expected api error envelope, got %s", w.Body.String())
+	}
+
+	if w := serve("/orders", "10.0.0.2:1234"); w.Code != http.StatusOK {
+		t.Errorf("Expected other clients to pass, got %d", w.Code)
+	}
+	if w := serve("/healthz", "10.0.0.1:1234"); w.Code != http.StatusOK {
+		t.Errorf("Expected skipped path to pass, got %d", w.Code)
+	}
+}
+
+func TestKeyByHeader(t *testing.T) {
+	key := KeyByHeader("X-Api-Key")
+
+	r := httptest.NewRequest(http.MethodGet, "/", nil)
+	r.RemoteAddr = "10.0.0.1:1234"
+	if got := key(r); got != "10.0.0.1" {
+		t.Errorf("Expected IP without the header, got %q", got)
+	}
+
+	r.Header.Set("X-Api-Key", "abc")
+	if got := key(r); got != "X-Api-Key:abc" {
+		t.Errorf("Expected header key, got %q", got)
+	}
+}
+
+type failingStore struct{}
+
+func (failingStore) Take(context.Context, string, float64, int) (RateLimitResult, error) {
+	return RateLimitResult{}, errors.New("connection refused")
+}
+
+func TestRateLimit_StoreError(t *testing.T) {
+	handler := RateLimit(RateLimitOptions{RequestsPerSecond: 1, Store: failingStore{}})(
+		http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusNoContent)
+		}))
+
+	w := httptest.NewRecorder()
+	handler.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/", nil))
+	if w.Code != http.StatusNoContent {
+		t.Errorf("Expected request to pass when the store fails, got %d", w.Code)
+	}
+}
+
+func TestNewWithOptions_RateLimit(t *testing.T) {
+	opts := DefaultOptions()
+	opts.EnableLogging = false
+	opts.RateLimit = &RateLimitOptions{RequestsPerSecond: 1, Burst: 1, KeyFunc: KeyByHeader("X-Api-Key")}
+	r := NewWithOptions(opts)
+	r.Get("/orders", func(w http.ResponseWriter, r *http.Request) {})
+
+	codes := make([]int, 2)
+	for i := range codes {
+		req := httptest.NewRequest(http.MethodGet, "/orders", nil)
+		req.Header.Set("X-Api-Key", "abc")
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, req)
+		codes[i] = w.Code
+	}
+	if codes[0] != http.StatusOK || codes[1] != http.StatusTooManyRequests {
+		t.Errorf("Expected 200 then 429, got %v", codes)
+	}
+}
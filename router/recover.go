@@ -0,0 +1,66 @@
+package router
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"runtime/debug"
+
+	"github.com/StairSupplies/go-core/api"
+	"github.com/StairSupplies/go-core/ctxutils"
+	"github.com/StairSupplies/go-core/logger"
+	"github.com/go-chi/chi/v5/middleware"
+	"go.uber.org/zap"
+)
+
+// PanicHandler is called with the value recovered from a panicking handler,
+// after the panic has been logged, for example to report it to an error
+// tracker. If it writes a response, the default 500 response is not sent.
+type PanicHandler func(w http.ResponseWriter, r *http.Request, recovered any)
+
+// Recoverer recovers from panics in later handlers. The panic value and the
+// full stack trace are logged as structured fields, handler is called if not
+// nil, and a 500 api error envelope is written unless a response was already
+// started. It is applied automatically when Options.EnableRecovery is set,
+// with Options.PanicHandler.
+//
+// Panics with http.ErrAbortHandler are re-raised so net/http can abort the
+// response as intended.
+func Recoverer(handler PanicHandler) func(next http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			ww := middleware.NewWrapResponseWriter(w, r.ProtoMajor)
+
+			defer func() {
+				rvr := recover()
+				if rvr == nil {
+					return
+				}
+				if rvr == http.ErrAbortHandler {
+					panic(rvr)
+				}
+
+				logger.WithContext(r.Context()).Error("HTTP handler panicked",
+					zap.String("panic", fmt.Sprint(rvr)),
+					zap.String("stack", string(debug.Stack())),
+					zap.String("method", r.Method),
+					zap.String("path", r.URL.Path),
+					zap.String("request_id", ctxutils.RequestID(r.Context())),
+				)
+
+				if handler != nil {
+					handler(ww, r, rvr)
+				}
+
+				// Nothing can be sent once the handler has started the
+				// response, and upgraded connections have no response to write
+				if ww.Status() != 0 || r.Header.Get("Connection") == "Upgrade" {
+					return
+				}
+				api.WriteError(ww, api.ServerError(errors.New("internal server error")))
+			}()
+
+			next.ServeHTTP(ww, r)
+		})
+	}
+}
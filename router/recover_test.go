@@ -0,0 +1,145 @@
+package router
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/StairSupplies/go-core/logger"
+	"github.com/StairSupplies/go-core/logger/logtest"
+	"go.uber.org/zap/zapcore"
+)
+
+func TestRecoverer(t *testing.T) {
+	log, rec := logtest.NewTestLoggerAt(t, zapcore.ErrorLevel)
+	withLogger := func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			ctx := logger.NewContext(r.Context(), log)
+			next.ServeHTTP(w, r.WithContext(ctx))
+		})
+	}
+
+	handler := withLogger(Recoverer(nil)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		panic("boom")
+	})))
+
+	w := httptest.NewRecorder()
+	handler.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/orders", nil))
+
+	if w.Code != http.StatusInternalServerError {
+		t.Errorf("Expected status %d, got %d", http.StatusInternalServerError, w.Code)
+	}
+
+	var body struct {
+		Error struct {
+			StatusCode int    `json:"status_code"`
+			Message    string `json:"message"`
+		} `json:"error"`
+	}
+	if err := json.Unmarshal(w.Body.Bytes(), &body); err != nil {
+		t.Fatalf("Expected an api error envelope, got %q", w.Body.String())
+	}
+	if body.Error.StatusCode != http.StatusInternalServerError || body.Error.Message != "internal server error" {
+		t.Errorf("Unexpected error envelope %+v", body.Error)
+	}
+
+	if rec.Len() != 1 {
+		t.Fatalf("Expected one log entry, got %d", rec.Len())
+	}
+	fields := rec.Entries()[0].Fields
+	if fields["panic"] != "boom" || fields["path"] != "/orders" {
+		t.Errorf("Expected panic and path fields, got %v", fields)
+	}
+	if stack, _ := fields["stack"].(string); !strings.Contains(stack, "recover_test.go") {
+		t.Errorf("Expected stack trace to include the panicking handler, got %q", stack)
+	}
+}
+
+func TestRecoverer_PanicHandler(t *testing.T) {
+	t.Run("handler reports only", func(t *testing.T) {
+		var recovered any
+		handler := Recoverer(func(w http.ResponseWriter, r *http.Request, rvr any) {
+			recovered = rvr
+		})(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			panic(42)
+		}))
+
+		w := httptest.NewRecorder()
+		handler.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/", nil))
+
+		if recovered != 42 {
+			t.Errorf("Expected recovered value 42, got %v", recovered)
+		}
+		if w.Code != http.StatusInternalServerError {
+			t.Errorf("Expected default 500 response, got %d", w.Code)
+		}
+	})
+
+	t.Run("handler writes response", func(t *testing.T) {
+		handler := Recoverer(func(w http.ResponseWriter, r *http.Request, rvr any) {
+			w.WriteHeader(http.StatusServiceUnavailable)
+		})(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			panic("boom")
+		}))
+
+		w := httptest.NewRecorder()
+		handler.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/", nil))
+
+		if w.Code != http.StatusServiceUnavailable {
+			t.Errorf("Expected handler's status, got %d", w.Code)
+		}
+		if w.Body.Len() != 0 {
+			t.Errorf("Expected no default body, got %q", w.Body.String())
+		}
+	})
+}
+
+func TestRecoverer_ResponseStarted(t *testing.T) {
+	handler := Recoverer(nil)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+		w.Write([]byte("partial"))
+		panic("boom")
+	}))
+
+	w := httptest.NewRecorder()
+	handler.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/", nil))
+
+	if w.Code != http.StatusOK || w.Body.String() != "partial" {
+		t.Errorf("Expected the started response to be left alone, got %d %q", w.Code, w.Body.String())
+	}
+}
+
+func TestRecoverer_AbortHandler(t *testing.T) {
+	handler := Recoverer(nil)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		panic(http.ErrAbortHandler)
+	}))
+
+	defer func() {
+		if rvr := recover(); rvr != http.ErrAbortHandler {
+			t.Errorf("Expected http.ErrAbortHandler to be re-raised, got %v", rvr)
+		}
+	}()
+	handler.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/", nil))
+}
+
+func TestRouter_PanicHandlerOption(t *testing.T) {
+	opts := DefaultOptions()
+	opts.EnableLogging = false
+	called := false
+	opts.PanicHandler = func(w http.ResponseWriter, r *http.Request, rvr any) { called = true }
+
+	r := NewWithOptions(opts)
+	r.Get("/panic", func(w http.ResponseWriter, r *http.Request) { panic("boom") })
+
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/panic", nil))
+
+	if !called {
+		t.Error("Expected Options.PanicHandler to be called")
+	}
+	if w.Code != http.StatusInternalServerError {
+		t.Errorf("Expected status %d, got %d", http.StatusInternalServerError, w.Code)
+	}
+}
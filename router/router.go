@@ -5,6 +5,7 @@ import (
 	"time"
 
 	"github.com/StairSupplies/go-core/api"
+	"github.com/StairSupplies/go-core/health"
 	"github.com/go-chi/chi/v5"
 	"github.com/go-chi/chi/v5/middleware"
 )
@@ -30,10 +31,43 @@ type Options struct {
 	EnableTimeout bool
 	// EnableHealthcheck enables the healthcheck middleware
 	EnableHealthcheck bool
+	// HealthChecker, if set, serves its liveness report at /healthz and its
+	// readiness report at /readyz in place of the plain healthcheck
+	HealthChecker *health.Checker
+	// EnableCORS enables the CORS middleware
+	EnableCORS bool
+	// EnableETag enables ETags and 304 responses for JSON GET responses
+	EnableETag bool
+	// RateLimit, if set, limits how often each client may call the router
+	RateLimit *RateLimitOptions
+	// PropagateHeaders lists inbound headers stored in the request context
+	// for rest clients to forward, such as ctxutils.DefaultPropagatedHeaders;
+	// empty, the default, disables propagation
+	PropagateHeaders []string
+	// EnableRouteListing serves the registered routes as JSON at
+	// DefaultRoutesPath. Do not expose it publicly.
+	EnableRouteListing bool
 	// TimeoutDuration sets the timeout for requests
 	TimeoutDuration time.Duration
 	// LoggerOptions configures the logger middleware
 	LoggerOptions LoggerOptions
+	// CORSOptions configures the CORS middleware
+	CORSOptions CORSOptions
+	// ETagMaxBodySize is the largest response the ETag middleware buffers;
+	// larger responses are sent without an ETag
+	ETagMaxBodySize int64
+	// PanicHandler, if set, is called by the recovery middleware with each
+	// recovered panic, for example to report it to an error tracker
+	PanicHandler PanicHandler
+	// ErrorHandler, if set, reports errors returned by handlers wrapped with
+	// WithErrorHandler instead of the api package default
+	ErrorHandler api.ErrorHandler
+	// NotFoundHandler, if set, replaces DefaultNotFoundHandler for requests
+	// that match no route
+	NotFoundHandler http.HandlerFunc
+	// MethodNotAllowedHandler, if set, replaces DefaultMethodNotAllowedHandler
+	// for requests that match a route's path but not its method
+	MethodNotAllowedHandler http.HandlerFunc
 }
 
 // LoggerOptions configures the logger middleware.
@@ -43,10 +77,36 @@ type LoggerOptions struct {
 	LogRequestHeaders bool
 	// LogResponseHeaders determines if response headers should be logged
 	LogResponseHeaders bool
-	// LogRequestBody determines if request body should be logged
+	// LogRequestBody determines if request body should be logged. JSON and
+	// form bodies are logged with sensitive fields redacted, text bodies as
+	// they are, and other bodies are only described.
 	LogRequestBody bool
+	// MaxBodyLogSize is the most of each request body captured for logging;
+	// zero means DefaultMaxBodyLogSize
+	MaxBodyLogSize int64
+	// RedactedBodyFields lists JSON and form fields logged as "[REDACTED]"
+	// in addition to DefaultRedactedBodyFields
+	RedactedBodyFields []string
 	// SkipPaths lists paths that should not be logged
 	SkipPaths []string
+	// RedactedHeaders lists headers logged as "[REDACTED]" in addition to
+	// DefaultRedactedHeaders
+	RedactedHeaders []string
+	// SlowRequestThreshold logs requests taking at least this long at warn
+	// level with slow_request set, instead of at info level; zero disables it
+	SlowRequestThreshold time.Duration
+	// Latency, if set, records the duration of each logged request by method
+	// and route, and adds its bucket to the log as latency_bucket
+	Latency *LatencyHistogram
+}
+
+// DefaultRedactedHeaders are never logged in clear by the logger middleware
+var DefaultRedactedHeaders = []string{
+	"Authorization",
+	"Proxy-Authorization",
+	"Cookie",
+	"Set-Cookie",
+	"X-Api-Key",
 }
 
 // DefaultOptions returns the default router options.
@@ -60,11 +120,14 @@ func DefaultOptions() Options {
 		EnableHealthcheck: true,
 		TimeoutDuration:   60 * time.Second,
 		LoggerOptions: LoggerOptions{
-			LogRequestHeaders:  false,
-			LogResponseHeaders: false,
-			LogRequestBody:     false,
-			SkipPaths:          []string{"/healthz", "/metrics"},
+			LogRequestHeaders:    false,
+			LogResponseHeaders:   false,
+			LogRequestBody:       false,
+			SkipPaths:            []string{"/healthz", "/readyz", "/metrics"},
+			SlowRequestThreshold: DefaultSlowRequestThreshold,
 		},
+		CORSOptions:     DefaultCORSOptions(),
+		ETagMaxBodySize: DefaultETagMaxBodySize,
 	}
 }
 
@@ -80,27 +143,47 @@ func NewWithOptions(options Options) *Router {
 	r := chi.NewRouter()
 
 	// Apply middleware based on options
+	if options.ErrorHandler != nil {
+		r.Use(ErrorHandler(options.ErrorHandler))
+	}
+
 	if options.EnableRequestID {
 		r.Use(middleware.RequestID)
 	}
 
+	if len(options.PropagateHeaders) > 0 {
+		r.Use(PropagateHeaders(options.PropagateHeaders...))
+	}
+
 	if options.EnableRecovery {
-		r.Use(middleware.Recoverer)
+		r.Use(Recoverer(options.PanicHandler))
+	}
+
+	if options.EnableCORS {
+		r.Use(CORS(options.CORSOptions))
 	}
 
 	if options.EnableLogging {
 		r.Use(Logger(options.LoggerOptions))
 	}
 
+	if options.RateLimit != nil {
+		r.Use(RateLimit(*options.RateLimit))
+	}
+
+	if options.EnableETag {
+		r.Use(ETag(options.ETagMaxBodySize))
+	}
+
 	if options.EnableTimeout {
-		r.Use(middleware.Timeout(options.TimeoutDuration))
+		r.Use(Timeout(options.TimeoutDuration))
 	}
 
-	if options.EnableHealthcheck {
-		r.Get("/healthz", func(w http.ResponseWriter, r *http.Request) {
-			w.WriteHeader(http.StatusOK)
-			w.Write([]byte("OK"))
-		})
+	registerHealthRoutes(r, options)
+	registerNotFoundHandlers(r, options)
+
+	if options.EnableRouteListing {
+		r.Get(DefaultRoutesPath, routesHandler(r))
 	}
 
 	return &Router{
@@ -109,51 +192,55 @@ func NewWithOptions(options Options) *Router {
 	}
 }
 
+// registerHealthRoutes adds the healthcheck routes enabled by options
+func registerHealthRoutes(r chi.Router, options Options) {
+	if options.HealthChecker != nil {
+		r.Method(http.MethodGet, "/healthz", options.HealthChecker.LivenessHandler())
+		r.Method(http.MethodGet, "/readyz", options.HealthChecker.ReadinessHandler())
+		return
+	}
+
+	if options.EnableHealthcheck {
+		r.Get("/healthz", func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusOK)
+			w.Write([]byte("OK"))
+		})
+	}
+}
+
 // WithErrorHandler wraps an api.HandlerFunc to handle errors.
 // It converts functions that return errors into standard http.HandlerFunc.
 func WithErrorHandler(h api.HandlerFunc) http.HandlerFunc {
 	return api.WrapHandler(h)
 }
 
-// Group creates a new router group with the same options.
-// This is useful for applying middleware to a group of routes.
+// Group creates a router for a group of routes, to be attached with Mount.
+// Requests reach the group through r, so r's middleware chain already
+// applies and is not repeated; middleware added in fn applies only to the
+// group. Use GroupWithOptions for a group registered on r directly.
+//
+//	api := r.Group(func(g chi.Router) {
+//	    g.Use(authMiddleware)
+//	    g.Get("/users", listUsers)
+//	})
+//	r.Mount("/api", api)
 func (r *Router) Group(fn func(r chi.Router)) chi.Router {
 	subRouter := &Router{
 		Router:  chi.NewRouter(),
 		options: r.options,
 	}
 
-	// Apply middleware to subRouter if needed
-	if r.options.EnableRequestID {
-		subRouter.Use(middleware.RequestID)
-	}
-
-	if r.options.EnableRecovery {
-		subRouter.Use(middleware.Recoverer)
-	}
-
-	if r.options.EnableLogging {
-		subRouter.Use(Logger(r.options.LoggerOptions))
-	}
-
-	if r.options.EnableTimeout {
-		subRouter.Use(middleware.Timeout(r.options.TimeoutDuration))
-	}
-
-	if r.options.EnableHealthcheck {
-		r.Get("/healthz", func(w http.ResponseWriter, r *http.Request) {
-			w.WriteHeader(http.StatusOK)
-			w.Write([]byte("OK"))
-		})
-	}
-
 	fn(subRouter)
 	return subRouter
 }
 
 // Mount attaches another router to the specified pattern.
-// This is useful for organizing routes into separate modules.
+// This is useful for organizing routes into separate modules. A group
+// created with Group inherits r's 404 and 405 handlers unless it sets its own.
 func (r *Router) Mount(pattern string, h http.Handler) {
+	if sub, ok := h.(*Router); ok {
+		h = sub.Router
+	}
 	r.Router.Mount(pattern, h)
 }
 
@@ -168,20 +255,40 @@ func (r *Router) WithMiddleware(middlewares ...func(http.Handler) http.Handler)
 	router := chi.NewRouter()
 	
 	// Apply built-in middleware based on options first
+	if opts.ErrorHandler != nil {
+		router.Use(ErrorHandler(opts.ErrorHandler))
+	}
+
 	if opts.EnableRequestID {
 		router.Use(middleware.RequestID)
 	}
+
+	if len(opts.PropagateHeaders) > 0 {
+		router.Use(PropagateHeaders(opts.PropagateHeaders...))
+	}
 	
 	if opts.EnableRecovery {
-		router.Use(middleware.Recoverer)
+		router.Use(Recoverer(opts.PanicHandler))
+	}
+
+	if opts.EnableCORS {
+		router.Use(CORS(opts.CORSOptions))
 	}
 	
 	if opts.EnableLogging {
 		router.Use(Logger(opts.LoggerOptions))
 	}
 	
+	if opts.RateLimit != nil {
+		router.Use(RateLimit(*opts.RateLimit))
+	}
+
+	if opts.EnableETag {
+		router.Use(ETag(opts.ETagMaxBodySize))
+	}
+
 	if opts.EnableTimeout {
-		router.Use(middleware.Timeout(opts.TimeoutDuration))
+		router.Use(Timeout(opts.TimeoutDuration))
 	}
 	
 	// Apply additional custom middleware
@@ -190,11 +297,11 @@ func (r *Router) WithMiddleware(middlewares ...func(http.Handler) http.Handler)
 	}
 	
 	// Add healthcheck route if enabled
-	if opts.EnableHealthcheck {
-		router.Get("/healthz", func(w http.ResponseWriter, r *http.Request) {
-			w.WriteHeader(http.StatusOK)
-			w.Write([]byte("OK"))
-		})
+	registerHealthRoutes(router, opts)
+	registerNotFoundHandlers(router, opts)
+
+	if opts.EnableRouteListing {
+		router.Get(DefaultRoutesPath, routesHandler(router))
 	}
 	
 	return &Router{
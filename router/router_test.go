@@ -1,15 +1,18 @@
 package router
 
 import (
+	"context"
 	"encoding/json"
 	"errors"
 	"io"
 	"net/http"
 	"net/http/httptest"
+	"strings"
 	"testing"
 	"time"
 
 	"github.com/StairSupplies/go-core/api"
+	"github.com/StairSupplies/go-core/health"
 	"github.com/go-chi/chi/v5"
 	"github.com/go-chi/chi/v5/middleware"
 )
@@ -93,7 +96,6 @@ func TestWithErrorHandler(t *testing.T) {
 
 	panicHandler := func(w http.ResponseWriter, r *http.Request) error {
 		panic("test panic")
-		return nil
 	}
 
 	// Create a router with the handlers
@@ -225,6 +227,55 @@ func TestRouterGroup(t *testing.T) {
 	})
 }
 
+func TestRouterGroup_InheritsParentChain(t *testing.T) {
+	r := NewWithOptions(Options{EnableRequestID: true})
+
+	var parentID string
+	r.Use(func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+			parentID = middleware.GetReqID(req.Context())
+			next.ServeHTTP(w, req)
+		})
+	})
+
+	var groupID string
+	api := r.Group(func(g chi.Router) {
+		g.Use(func(next http.Handler) http.Handler {
+			return http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+				w.Header().Set("X-Group", "api")
+				next.ServeHTTP(w, req)
+			})
+		})
+		g.Get("/orders", func(w http.ResponseWriter, req *http.Request) {
+			groupID = middleware.GetReqID(req.Context())
+		})
+	})
+	r.Mount("/api", api)
+
+	if n := len(api.Middlewares()); n != 1 {
+		t.Errorf("Expected only the group's own middleware, got %d", n)
+	}
+
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/api/orders", nil))
+
+	if w.Header().Get("X-Group") != "api" {
+		t.Error("Expected group middleware to run")
+	}
+	if parentID == "" || groupID != parentID {
+		t.Errorf("Expected request ID %q from the parent chain, got %q", parentID, groupID)
+	}
+
+	w = httptest.NewRecorder()
+	r.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/api/missing", nil))
+	if w.Code != http.StatusNotFound {
+		t.Errorf("Expected status code %d, got %d", http.StatusNotFound, w.Code)
+	}
+	if !strings.Contains(w.Header().Get("Content-Type"), "application/json") {
+		t.Errorf("Expected the parent's JSON 404 handler, got Content-Type %q", w.Header().Get("Content-Type"))
+	}
+}
+
 func TestWithMiddleware(t *testing.T) {
 	// Create a custom middleware
 	testMiddleware := func(next http.Handler) http.Handler {
@@ -365,6 +416,12 @@ func TestDefaultOptions(t *testing.T) {
 	if opts.TimeoutDuration != 60*time.Second {
 		t.Errorf("Expected TimeoutDuration to be 60s, got %v", opts.TimeoutDuration)
 	}
+	if opts.EnableCORS {
+		t.Error("Expected EnableCORS to be false by default")
+	}
+	if len(opts.CORSOptions.AllowedMethods) == 0 {
+		t.Error("Expected default CORSOptions to be populated")
+	}
 }
 
 func TestServeHTTP(t *testing.T) {
@@ -388,4 +445,59 @@ func TestServeHTTP(t *testing.T) {
 	if string(body) != "test" {
 		t.Errorf("Expected body '%s', got '%s'", "test", string(body))
 	}
-}
\ No newline at end of file
+}
+
+func TestOptionsErrorHandler(t *testing.T) {
+	opts := DefaultOptions()
+	opts.EnableLogging = false
+	opts.ErrorHandler = func(w http.ResponseWriter, r *http.Request, err error) {
+		api.WriteError(w, api.NewError(http.StatusInternalServerError, errors.New("something went wrong")))
+	}
+
+	r := NewWithOptions(opts)
+	r.Get("/fail", WithErrorHandler(func(w http.ResponseWriter, r *http.Request) error {
+		return errors.New("pq: password authentication failed")
+	}))
+
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, httptest.NewRequest("GET", "/fail", nil))
+
+	if w.Code != http.StatusInternalServerError {
+		t.Errorf("Expected status code %d, got %d", http.StatusInternalServerError, w.Code)
+	}
+	if strings.Contains(w.Body.String(), "pq:") {
+		t.Errorf("Expected internal message to be hidden, got %s", w.Body.String())
+	}
+	if !strings.Contains(w.Body.String(), "something went wrong") {
+		t.Errorf("Expected custom message, got %s", w.Body.String())
+	}
+}
+
+func TestHealthChecker(t *testing.T) {
+	checker := health.NewChecker()
+	checker.Register("database", func(ctx context.Context) error { return errors.New("ping failed") })
+
+	opts := DefaultOptions()
+	opts.EnableLogging = false
+	opts.HealthChecker = checker
+	r := NewWithOptions(opts)
+
+	tests := []struct {
+		path string
+		want int
+	}{
+		{"/healthz", http.StatusOK},
+		{"/readyz", http.StatusServiceUnavailable},
+	}
+
+	for _, tc := range tests {
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, httptest.NewRequest(http.MethodGet, tc.path, nil))
+		if w.Code != tc.want {
+			t.Errorf("Expected %s to return %d, got %d", tc.path, tc.want, w.Code)
+		}
+		if got := w.Header().Get("Content-Type"); got != "application/json" {
+			t.Errorf("Expected %s to return JSON, got %q", tc.path, got)
+		}
+	}
+}
@@ -0,0 +1,55 @@
+package router
+
+import (
+	"net/http"
+	"sort"
+
+	"github.com/StairSupplies/go-core/api"
+	"github.com/go-chi/chi/v5"
+)
+
+// DefaultRoutesPath is where the route listing is served when
+// Options.EnableRouteListing is set
+const DefaultRoutesPath = "/debug/routes"
+
+// RouteInfo describes one registered route
+type RouteInfo struct {
+	// Method is the HTTP method, or "*" for routes registered with Handle
+	Method string `json:"method"`
+	// Pattern is the full route pattern, including any mount prefixes
+	Pattern string `json:"pattern"`
+	// Middlewares is the number of middlewares that wrap the handler
+	Middlewares int `json:"middlewares"`
+}
+
+// ListRoutes returns every route registered on the router, including those
+// of groups and mounted routers, sorted by pattern and then method. It is not
+// named Routes because chi.Router already defines Routes() []chi.Route.
+func (r *Router) ListRoutes() []RouteInfo {
+	return walkRoutes(r.Router)
+}
+
+// walkRoutes lists the routes of a chi router tree
+func walkRoutes(routes chi.Routes) []RouteInfo {
+	var infos []RouteInfo
+	chi.Walk(routes, func(method, pattern string, _ http.Handler, middlewares ...func(http.Handler) http.Handler) error {
+		infos = append(infos, RouteInfo{Method: method, Pattern: pattern, Middlewares: len(middlewares)})
+		return nil
+	})
+
+	sort.Slice(infos, func(i, j int) bool {
+		if infos[i].Pattern != infos[j].Pattern {
+			return infos[i].Pattern < infos[j].Pattern
+		}
+		return infos[i].Method < infos[j].Method
+	})
+	return infos
+}
+
+// routesHandler serves the routes of mux as JSON. The routes are listed
+// when requested, so routes registered after the handler are included.
+func routesHandler(mux chi.Routes) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		api.WriteSuccess(w, walkRoutes(mux))
+	}
+}
@@ -0,0 +1,76 @@
+package router
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/go-chi/chi/v5"
+)
+
+func TestRouter_ListRoutes(t *testing.T) {
+	r := NewWithOptions(Options{EnableHealthcheck: true})
+	noop := func(next http.Handler) http.Handler { return next }
+	handler := func(w http.ResponseWriter, r *http.Request) {}
+
+	r.Get("/orders", handler)
+	r.Post("/orders", handler)
+	r.With(noop).Get("/orders/{id}", handler)
+
+	admin := chi.NewRouter()
+	admin.Use(noop, noop)
+	admin.Delete("/users/{id}", handler)
+	r.Mount("/admin", admin)
+
+	want := []RouteInfo{
+		{Method: "DELETE", Pattern: "/admin/users/{id}", Middlewares: 2},
+		{Method: "GET", Pattern: "/healthz", Middlewares: 0},
+		{Method: "GET", Pattern: "/orders", Middlewares: 0},
+		{Method: "POST", Pattern: "/orders", Middlewares: 0},
+		{Method: "GET", Pattern: "/orders/{id}", Middlewares: 1},
+	}
+
+	got := r.ListRoutes()
+	if len(got) != len(want) {
+		t.Fatalf("Expected %d routes, got %d: %+v", len(want), len(got), got)
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Errorf("Expected route %d to be %+v, got %+v", i, want[i], got[i])
+		}
+	}
+}
+
+func TestRouteListingEndpoint(t *testing.T) {
+	t.Run("disabled by default", func(t *testing.T) {
+		r := New()
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, httptest.NewRequest(http.MethodGet, DefaultRoutesPath, nil))
+		if w.Code != http.StatusNotFound {
+			t.Errorf("Expected status %d, got %d", http.StatusNotFound, w.Code)
+		}
+	})
+
+	t.Run("enabled", func(t *testing.T) {
+		r := NewWithOptions(Options{EnableRouteListing: true})
+		// Routes registered after the router is created are listed
+		r.Get("/orders", func(w http.ResponseWriter, r *http.Request) {})
+
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, httptest.NewRequest(http.MethodGet, DefaultRoutesPath, nil))
+		if w.Code != http.StatusOK {
+			t.Fatalf("Expected status %d, got %d", http.StatusOK, w.Code)
+		}
+
+		var body struct {
+			Data []RouteInfo `json:"data"`
+		}
+		if err := json.Unmarshal(w.Body.Bytes(), &body); err != nil {
+			t.Fatalf("Failed to decode response: %v", err)
+		}
+		if len(body.Data) != 2 || body.Data[0].Pattern != DefaultRoutesPath || body.Data[1].Pattern != "/orders" {
+			t.Errorf("Expected %s and /orders, got %+v", DefaultRoutesPath, body.Data)
+		}
+	})
+}
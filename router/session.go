@@ -0,0 +1,157 @@
+package router
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"sort"
+	"sync"
+
+	"github.com/StairSupplies/go-core/ctxutils"
+)
+
+// DefaultSessionCookie is the cookie Sessions uses when no name is given
+const DefaultSessionCookie = "session"
+
+// SessionOptions configures the Sessions middleware
+type SessionOptions struct {
+	// CookieName defaults to DefaultSessionCookie
+	CookieName string
+	// Cookie sets the signing keys and attributes of the session cookie
+	Cookie CookieOptions
+}
+
+// Session holds the values of a client's session, kept in a signed cookie.
+// Changes are written to the response immediately, so make them before the
+// response is written. It is safe for concurrent use.
+type Session struct {
+	mu     sync.Mutex
+	values map[string]string
+	w      http.ResponseWriter
+	name   string
+	opts   CookieOptions
+}
+
+// sessionKey is the context key for the request's Session
+var sessionKey = ctxutils.NewKey[*Session]("session")
+
+// Sessions is a middleware that loads the session stored in a signed cookie
+// into the request context, for handlers to read with SessionFromContext.
+// A missing, tampered or expired cookie starts an empty session. The session
+// is readable by the client, so do not store secrets in it. Sessions panics
+// if opts.Cookie has no Keys.
+//
+//	r.Use(router.Sessions(router.SessionOptions{
+//		Cookie: router.CookieOptions{Keys: [][]byte{key}, MaxAge: 12 * time.Hour},
+//	}))
+func Sessions(opts SessionOptions) func(next http.Handler) http.Handler {
+	if len(opts.Cookie.Keys) == 0 {
+		panic("router: Sessions requires at least one cookie key")
+	}
+	if opts.CookieName == "" {
+		opts.CookieName = DefaultSessionCookie
+	}
+
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			s := &Session{
+				values: make(map[string]string),
+				w:      w,
+				name:   opts.CookieName,
+				opts:   opts.Cookie,
+			}
+			if data, err := GetSignedCookie(r, opts.CookieName, opts.Cookie); err == nil {
+				if json.Unmarshal([]byte(data), &s.values) != nil || s.values == nil {
+					s.values = make(map[string]string)
+				}
+			}
+
+			next.ServeHTTP(w, r.WithContext(sessionKey.WithValue(r.Context(), s)))
+		})
+	}
+}
+
+// SessionFromContext returns the Session loaded by Sessions, or nil if the
+// middleware is not in use
+func SessionFromContext(ctx context.Context) *Session {
+	s, _ := sessionKey.Value(ctx)
+	return s
+}
+
+// Get returns the value stored under key, or "" if there is none
+func (s *Session) Get(key string) string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.values[key]
+}
+
+// Lookup returns the value stored under key and whether it was set
+func (s *Session) Lookup(key string) (string, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	v, ok := s.values[key]
+	return v, ok
+}
+
+// Keys returns the keys in the session, sorted
+func (s *Session) Keys() []string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	keys := make([]string, 0, len(s.values))
+	for k := range s.values {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// Set stores value under key. It returns ErrCookieTooLarge, leaving the
+// session unchanged, if the session no longer fits in a cookie.
+func (s *Session) Set(key, value string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	prev, had := s.values[key]
+	s.values[key] = value
+	if err := s.save(); err != nil {
+		if had {
+			s.values[key] = prev
+		} else {
+			delete(s.values, key)
+		}
+		return err
+	}
+	return nil
+}
+
+// Delete removes key from the session
+func (s *Session) Delete(key string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if _, ok := s.values[key]; ok {
+		delete(s.values, key)
+		s.save()
+	}
+}
+
+// Clear removes every value and deletes the session cookie, for example on
+// logout
+func (s *Session) Clear() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.values = make(map[string]string)
+	DeleteCookie(s.w, s.name, s.opts)
+}
+
+// save writes the session cookie; s.mu must be held
+func (s *Session) save() error {
+	if len(s.values) == 0 {
+		DeleteCookie(s.w, s.name, s.opts)
+		return nil
+	}
+	data, err := json.Marshal(s.values)
+	if err != nil {
+		return err
+	}
+	return SetSignedCookie(s.w, s.name, string(data), s.opts)
+}
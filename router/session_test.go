@@ -0,0 +1,114 @@
+package router
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestSessions(t *testing.T) {
+	opts := SessionOptions{Cookie: CookieOptions{Keys: [][]byte{testCookieKey}}}
+	handler := Sessions(opts)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		s := SessionFromContext(r.Context())
+		switch r.URL.Path {
+		case "/login":
+			s.Set("user_id", "42")
+			s.Set("theme", "dark")
+		case "/theme":
+			s.Delete("theme")
+		case "/logout":
+			s.Clear()
+		}
+		w.Write([]byte(strings.Join(s.Keys(), ",") + "|" + s.Get("user_id")))
+	}))
+
+	serve := func(path string, cookies []*http.Cookie) *httptest.ResponseRecorder {
+		r := httptest.NewRequest(http.MethodGet, path, nil)
+		for _, c := range cookies {
+			r.AddCookie(c)
+		}
+		w := httptest.NewRecorder()
+		handler.ServeHTTP(w, r)
+		return w
+	}
+
+	w := serve("/login", nil)
+	if got := w.Body.String(); got != "theme,user_id|42" {
+		t.Errorf("Expected the new session values, got %q", got)
+	}
+	cookies := w.Result().Cookies()
+	if len(cookies) != 1 || cookies[0].Name != DefaultSessionCookie {
+		t.Fatalf("Expected one session cookie, got %v", cookies)
+	}
+
+	if got := serve("/", cookies).Body.String(); got != "theme,user_id|42" {
+		t.Errorf("Expected the session to be loaded from the cookie, got %q", got)
+	}
+
+	w = serve("/theme", cookies)
+	if got := w.Body.String(); got != "user_id|42" {
+		t.Errorf("Expected theme to be deleted, got %q", got)
+	}
+	if got := serve("/", w.Result().Cookies()).Body.String(); got != "user_id|42" {
+		t.Errorf("Expected the updated cookie, got %q", got)
+	}
+
+	w = serve("/logout", cookies)
+	if c := w.Result().Cookies(); len(c) != 1 || c[0].MaxAge != -1 {
+		t.Errorf("Expected the session cookie to be deleted, got %v", c)
+	}
+}
+
+func TestSessions_InvalidCookie(t *testing.T) {
+	opts := SessionOptions{CookieName: "sid", Cookie: CookieOptions{Keys: [][]byte{testCookieKey}}}
+
+	var got *Session
+	handler := Sessions(opts)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		got = SessionFromContext(r.Context())
+	}))
+
+	r := httptest.NewRequest(http.MethodGet, "/", nil)
+	r.AddCookie(&http.Cookie{Name: "sid", Value: signCookie([]byte("forged key"), "sid", `{"user_id":"1"}`, 0)})
+	handler.ServeHTTP(httptest.NewRecorder(), r)
+
+	if got == nil || len(got.Keys()) != 0 {
+		t.Errorf("Expected an empty session for a forged cookie, got %v", got.Keys())
+	}
+}
+
+func TestSession_SetTooLarge(t *testing.T) {
+	opts := SessionOptions{Cookie: CookieOptions{Keys: [][]byte{testCookieKey}}}
+	var err error
+	var session *Session
+	handler := Sessions(opts)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		session = SessionFromContext(r.Context())
+		session.Set("user_id", "42")
+		err = session.Set("blob", strings.Repeat("x", MaxCookieSize))
+	}))
+	handler.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/", nil))
+
+	if !errors.Is(err, ErrCookieTooLarge) {
+		t.Errorf("Expected ErrCookieTooLarge, got %v", err)
+	}
+	if _, ok := session.Lookup("blob"); ok {
+		t.Error("Expected the session to be left unchanged")
+	}
+}
+
+func TestSessions_NoKeys(t *testing.T) {
+	defer func() {
+		if recover() == nil {
+			t.Error("Expected Sessions to panic without keys")
+		}
+	}()
+	Sessions(SessionOptions{})
+}
+
+func TestSessionFromContext_Missing(t *testing.T) {
+	r := httptest.NewRequest(http.MethodGet, "/", nil)
+	if s := SessionFromContext(r.Context()); s != nil {
+		t.Errorf("Expected nil session, got %v", s)
+	}
+}
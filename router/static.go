@@ -0,0 +1,143 @@
+package router
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"io/fs"
+	"net/http"
+	"os"
+	"path"
+	"regexp"
+	"strings"
+)
+
+// Cache-Control values used for static files
+const (
+	// CacheControlNoCache makes browsers revalidate HTML pages, so a new
+	// deployment is picked up immediately
+	CacheControlNoCache = "no-cache"
+	// CacheControlImmutable is used for fingerprinted assets such as
+	// app.3f9a1c2e.js, whose content never changes under the same name
+	CacheControlImmutable = "public, max-age=31536000, immutable"
+	// CacheControlDefault is used for other static files
+	CacheControlDefault = "public, max-age=3600"
+)
+
+// fingerprintRX matches file names containing a content hash, such as
+// app.3f9a1c2e.js or index-B7x_k2Qd.css
+var fingerprintRX = regexp.MustCompile(`[.-]([0-9A-Za-z_]{8,})\.[0-9A-Za-z]+$`)
+
+// Static serves the files in dir under pattern, such as "/assets".
+// Directory listings are not served.
+func (r *Router) Static(pattern, dir string) {
+	r.StaticFS(pattern, os.DirFS(dir))
+}
+
+// StaticFS serves the files in fsys, which may be an embed.FS, under pattern.
+// Use fs.Sub to serve a subdirectory of an embedded tree. Missing files get
+// the router's NotFound handler.
+func (r *Router) StaticFS(pattern string, fsys fs.FS) {
+	r.mountStatic(pattern, fsys, "")
+}
+
+// SPA serves a single-page application from dir under pattern. Requests for
+// files that exist are served as they are, and other paths without a file
+// extension get indexFile, "index.html" if empty, so the client-side router
+// can handle them. Missing assets such as /app.js still return 404.
+func (r *Router) SPA(pattern, dir, indexFile string) {
+	r.SPAFS(pattern, os.DirFS(dir), indexFile)
+}
+
+// SPAFS is like SPA but serves the application from fsys, which may be an embed.FS
+func (r *Router) SPAFS(pattern string, fsys fs.FS, indexFile string) {
+	if indexFile == "" {
+		indexFile = "index.html"
+	}
+	r.mountStatic(pattern, fsys, indexFile)
+}
+
+// mountStatic routes GET and HEAD requests under pattern to a staticHandler
+func (r *Router) mountStatic(pattern string, fsys fs.FS, indexFile string) {
+	prefix := strings.TrimSuffix(pattern, "/")
+	h := staticHandler(fsys, prefix, indexFile, r.notFoundHandler)
+	if prefix != "" {
+		r.Get(prefix, http.RedirectHandler(prefix+"/", http.StatusMovedPermanently).ServeHTTP)
+	}
+	r.Method(http.MethodGet, prefix+"/*", h)
+	r.Method(http.MethodHead, prefix+"/*", h)
+}
+
+// staticHandler serves files from fsys for paths under prefix, falling back
+// to indexFile for extensionless paths when indexFile is set. Misses are
+// passed to the handler returned by notFound, with the request unchanged.
+func staticHandler(fsys fs.FS, prefix, indexFile string, notFound func() http.HandlerFunc) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		name := strings.TrimPrefix(path.Clean("/"+strings.TrimPrefix(r.URL.Path, prefix)), "/")
+		if name == "" {
+			name = "."
+		}
+
+		err := serveFile(w, r, fsys, name)
+		if errors.Is(err, fs.ErrNotExist) && indexFile != "" && path.Ext(name) == "" {
+			err = serveFile(w, r, fsys, indexFile)
+		}
+		if err != nil {
+			notFound()(w, r)
+		}
+	})
+}
+
+// serveFile serves name from fsys, or the index.html of a directory. It
+// returns fs.ErrNotExist if there is nothing to serve and writes nothing.
+func serveFile(w http.ResponseWriter, r *http.Request, fsys fs.FS, name string) error {
+	info, err := fs.Stat(fsys, name)
+	if err == nil && info.IsDir() {
+		name = path.Join(name, "index.html")
+		info, err = fs.Stat(fsys, name)
+	}
+	if err != nil {
+		return fs.ErrNotExist
+	}
+	if info.IsDir() {
+		return fs.ErrNotExist
+	}
+
+	f, err := fsys.Open(name)
+	if err != nil {
+		return fs.ErrNotExist
+	}
+	defer f.Close()
+
+	content, ok := f.(io.ReadSeeker)
+	if !ok {
+		data, err := io.ReadAll(f)
+		if err != nil {
+			return err
+		}
+		content = bytes.NewReader(data)
+	}
+
+	w.Header().Set("Cache-Control", cacheControl(name))
+	http.ServeContent(w, r, info.Name(), info.ModTime(), content)
+	return nil
+}
+
+// cacheControl returns the Cache-Control header for a file name
+func cacheControl(name string) string {
+	switch ext := path.Ext(name); {
+	case ext == ".html" || ext == ".htm":
+		return CacheControlNoCache
+	case isFingerprinted(path.Base(name)):
+		return CacheControlImmutable
+	default:
+		return CacheControlDefault
+	}
+}
+
+// isFingerprinted reports whether name contains a content hash. The hash must
+// contain a digit, so names like bootstrap-material.css do not match.
+func isFingerprinted(name string) bool {
+	m := fingerprintRX.FindStringSubmatch(name)
+	return m != nil && strings.ContainsAny(m[1], "0123456789")
+}
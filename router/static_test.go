@@ -0,0 +1,174 @@
+package router
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"testing/fstest"
+)
+
+func staticFS() fstest.MapFS {
+	return fstest.MapFS{
+		"index.html":               {Data: []byte("<html>app</html>")},
+		"assets/app.3f9a1c2e.js":   {Data: []byte("console.log('app')")},
+		"assets/logo.svg":          {Data: []byte("<svg/>")},
+		"docs/index.html":          {Data: []byte("<html>docs</html>")},
+		"assets/fonts/inter.woff2": {Data: []byte("font")},
+	}
+}
+
+func serveStatic(r *Router, method, target string) *httptest.ResponseRecorder {
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, httptest.NewRequest(method, target, nil))
+	return w
+}
+
+func TestRouter_StaticFS(t *testing.T) {
+	r := NewWithOptions(Options{})
+	r.StaticFS("/static/", staticFS())
+
+	tests := []struct {
+		name         string
+		target       string
+		status       int
+		body         string
+		cacheControl string
+	}{
+		{"fingerprinted asset", "/static/assets/app.3f9a1c2e.js", http.StatusOK, "console.log('app')", CacheControlImmutable},
+		{"plain asset", "/static/assets/logo.svg", http.StatusOK, "<svg/>", CacheControlDefault},
+		{"html", "/static/index.html", http.StatusOK, "<html>app</html>", CacheControlNoCache},
+		{"directory index", "/static/docs/", http.StatusOK, "<html>docs</html>", CacheControlNoCache},
+		{"no listing", "/static/assets/", http.StatusNotFound, "", ""},
+		{"missing", "/static/missing", http.StatusNotFound, "", ""},
+		{"traversal", "/static/../router.go", http.StatusNotFound, "", ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := serveStatic(r, http.MethodGet, tt.target)
+			if w.Code != tt.status {
+				t.Fatalf("Expected status %d, got %d", tt.status, w.Code)
+			}
+			if tt.body != "" && w.Body.String() != tt.body {
+				t.Errorf("Expected body %q, got %q", tt.body, w.Body.String())
+			}
+			if got := w.Header().Get("Cache-Control"); got != tt.cacheControl {
+				t.Errorf("Expected Cache-Control %q, got %q", tt.cacheControl, got)
+			}
+		})
+	}
+
+	if w := serveStatic(r, http.MethodGet, "/static"); w.Code != http.StatusMovedPermanently || w.Header().Get("Location") != "/static/" {
+		t.Errorf("Expected redirect to /static/, got %d %q", w.Code, w.Header().Get("Location"))
+	}
+	if w := serveStatic(r, http.MethodHead, "/static/assets/logo.svg"); w.Code != http.StatusOK {
+		t.Errorf("Expected HEAD to be served, got %d", w.Code)
+	}
+}
+
+func TestRouter_SPAFS(t *testing.T) {
+	r := NewWithOptions(Options{})
+	r.Get("/api/orders", func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte("orders"))
+	})
+	r.SPAFS("/", staticFS(), "")
+
+	tests := []struct {
+		name   string
+		target string
+		status int
+		body   string
+	}{
+		{"root", "/", http.StatusOK, "<html>app</html>"},
+		{"client route", "/orders/42/edit", http.StatusOK, "<html>app</html>"},
+		{"asset", "/assets/logo.svg", http.StatusOK, "<svg/>"},
+		{"missing asset", "/assets/missing.js", http.StatusNotFound, ""},
+		{"api route wins", "/api/orders", http.StatusOK, "orders"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := serveStatic(r, http.MethodGet, tt.target)
+			if w.Code != tt.status {
+				t.Fatalf("Expected status %d, got %d", tt.status, w.Code)
+			}
+			if tt.body != "" && w.Body.String() != tt.body {
+				t.Errorf("Expected body %q, got %q", tt.body, w.Body.String())
+			}
+		})
+	}
+}
+
+func TestRouter_StaticNotFound(t *testing.T) {
+	t.Run("default", func(t *testing.T) {
+		r := NewWithOptions(Options{})
+		r.StaticFS("/static", staticFS())
+
+		w := serveStatic(r, http.MethodGet, "/static/missing.js")
+		if w.Code != http.StatusNotFound {
+			t.Fatalf("Expected status %d, got %d", http.StatusNotFound, w.Code)
+		}
+		var body struct {
+			Error struct {
+				Message string `json:"message"`
+			} `json:"error"`
+		}
+		if err := json.Unmarshal(w.Body.Bytes(), &body); err != nil {
+			t.Fatalf("Expected an api error envelope, got %q", w.Body.String())
+		}
+		if body.Error.Message != "no route for GET /static/missing.js" {
+			t.Errorf("Expected the full request path in the message, got %q", body.Error.Message)
+		}
+	})
+
+	t.Run("configured handler", func(t *testing.T) {
+		r := NewWithOptions(Options{NotFoundHandler: func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusNotFound)
+			w.Write([]byte("custom " + r.URL.Path))
+		}})
+		r.SPAFS("/", staticFS(), "")
+
+		w := serveStatic(r, http.MethodGet, "/assets/missing.js")
+		if w.Code != http.StatusNotFound || w.Body.String() != "custom /assets/missing.js" {
+			t.Errorf("Expected the configured NotFound handler, got %d %q", w.Code, w.Body.String())
+		}
+	})
+}
+
+func TestRouter_SPA_Dir(t *testing.T) {
+	dir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(dir, "app.html"), []byte("<html>dashboard</html>"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	r := NewWithOptions(Options{})
+	r.SPA("/dashboard", dir, "app.html")
+
+	w := serveStatic(r, http.MethodGet, "/dashboard/reports")
+	if w.Code != http.StatusOK || !strings.Contains(w.Body.String(), "dashboard") {
+		t.Errorf("Expected index fallback, got %d %q", w.Code, w.Body.String())
+	}
+}
+
+func TestIsFingerprinted(t *testing.T) {
+	tests := []struct {
+		name string
+		want bool
+	}{
+		{"app.3f9a1c2e.js", true},
+		{"index-B7x_k2Qd.css", true},
+		{"bootstrap-material.css", false},
+		{"jquery-3.7.1.min.js", false},
+		{"logo.svg", false},
+	}
+
+	for _, tt := range tests {
+		if got := isFingerprinted(tt.name); got != tt.want {
+			t.Errorf("isFingerprinted(%q) = %v, want %v", tt.name, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,91 @@
+package router
+
+import (
+	"context"
+	"net/http"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+)
+
+// GroupOptions configures a route group created with GroupWithOptions
+type GroupOptions struct {
+	// Timeout, if set, replaces the router's request timeout for the group
+	Timeout time.Duration
+	// Middleware is applied to the group's routes after the router's own
+	// middleware and the group timeout
+	Middleware []func(http.Handler) http.Handler
+}
+
+// timeoutState records the context a Timeout started from so a nested
+// Timeout can replace the deadline instead of only shortening it
+type timeoutState struct {
+	parent     context.Context
+	overridden bool
+}
+
+type timeoutStateKey struct{}
+
+// valueContext takes its deadline and cancellation from Context but its
+// values from values
+type valueContext struct {
+	context.Context
+	values context.Context
+}
+
+func (c valueContext) Value(key any) any {
+	return c.values.Value(key)
+}
+
+// Timeout sets a timeout for the request, responding with 504 Gateway
+// Timeout if the deadline passes before the handler returns.
+//
+// A Timeout nested inside another replaces the outer limit rather than
+// shortening it, so a route can allow longer than the router default:
+//
+//	r.With(router.Timeout(5 * time.Minute)).Get("/reports", generateReport)
+func Timeout(duration time.Duration) func(next http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			parent := r.Context()
+			if outer, ok := parent.Value(timeoutStateKey{}).(*timeoutState); ok {
+				outer.overridden = true
+				// Drop the outer deadline but keep values added since
+				parent = valueContext{Context: outer.parent, values: parent}
+			}
+
+			state := &timeoutState{parent: parent}
+			ctx, cancel := context.WithTimeout(parent, duration)
+			defer func() {
+				cancel()
+				if !state.overridden && ctx.Err() == context.DeadlineExceeded {
+					w.WriteHeader(http.StatusGatewayTimeout)
+				}
+			}()
+
+			ctx = context.WithValue(ctx, timeoutStateKey{}, state)
+			next.ServeHTTP(w, r.WithContext(ctx))
+		})
+	}
+}
+
+// WithTimeout returns an inline router whose routes use duration instead of
+// the router's default timeout.
+//
+//	r.WithTimeout(5 * time.Minute).Get("/reports", generateReport)
+func (r *Router) WithTimeout(duration time.Duration) chi.Router {
+	return r.With(Timeout(duration))
+}
+
+// GroupWithOptions creates an inline route group sharing the router's
+// middleware, with the per-group overrides in opts applied. Its routes are
+// registered on r, so it needs no Mount.
+func (r *Router) GroupWithOptions(opts GroupOptions, fn func(r chi.Router)) chi.Router {
+	return r.Router.Group(func(g chi.Router) {
+		if opts.Timeout > 0 {
+			g.Use(Timeout(opts.Timeout))
+		}
+		g.Use(opts.Middleware...)
+		fn(g)
+	})
+}
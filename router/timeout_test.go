@@ -0,0 +1,181 @@
+package router
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+)
+
+// deadlineHandler writes how long remains until the request deadline
+func deadlineHandler(t *testing.T, got *time.Duration) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		deadline, ok := r.Context().Deadline()
+		if !ok {
+			t.Error("Expected request context to have a deadline")
+			return
+		}
+		*got = time.Until(deadline)
+	}
+}
+
+func TestTimeout_Expires(t *testing.T) {
+	handler := Timeout(10 * time.Millisecond)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		<-r.Context().Done()
+	}))
+
+	w := httptest.NewRecorder()
+	handler.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/", nil))
+
+	if w.Code != http.StatusGatewayTimeout {
+		t.Errorf("Expected status code %d, got %d", http.StatusGatewayTimeout, w.Code)
+	}
+}
+
+func TestTimeout_NestedOverride(t *testing.T) {
+	tests := []struct {
+		name  string
+		outer time.Duration
+		inner time.Duration
+	}{
+		{"longer route timeout", time.Second, time.Minute},
+		{"shorter route timeout", time.Minute, time.Second},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			var remaining time.Duration
+			handler := Timeout(tc.outer)(Timeout(tc.inner)(deadlineHandler(t, &remaining)))
+
+			handler.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/", nil))
+
+			if remaining <= tc.inner-time.Second/2 || remaining > tc.inner {
+				t.Errorf("Expected deadline about %v away, got %v", tc.inner, remaining)
+			}
+		})
+	}
+}
+
+func TestTimeout_OverrideOutlivesOuter(t *testing.T) {
+	handler := Timeout(10 * time.Millisecond)(Timeout(time.Second)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		time.Sleep(30 * time.Millisecond)
+		if r.Context().Err() != nil {
+			t.Errorf("Expected context to still be live, got %v", r.Context().Err())
+		}
+		w.Write([]byte("done"))
+	})))
+
+	w := httptest.NewRecorder()
+	handler.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/", nil))
+
+	if w.Code != http.StatusOK {
+		t.Errorf("Expected status code %d, got %d", http.StatusOK, w.Code)
+	}
+}
+
+func TestTimeout_OverrideKeepsValuesAndCancellation(t *testing.T) {
+	type key struct{}
+
+	parent, cancel := context.WithCancel(context.Background())
+	var gotValue any
+	var canceled bool
+
+	inner := Timeout(time.Minute)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotValue = r.Context().Value(key{})
+		cancel()
+		select {
+		case <-r.Context().Done():
+			canceled = true
+		case <-time.After(time.Second):
+		}
+	}))
+	addValue := func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			next.ServeHTTP(w, r.WithContext(context.WithValue(r.Context(), key{}, "v")))
+		})
+	}
+	handler := Timeout(time.Second)(addValue(inner))
+
+	req := httptest.NewRequest(http.MethodGet, "/", nil).WithContext(parent)
+	handler.ServeHTTP(httptest.NewRecorder(), req)
+
+	if gotValue != "v" {
+		t.Errorf("Expected context value %q, got %v", "v", gotValue)
+	}
+	if !canceled {
+		t.Error("Expected client cancellation to reach the handler")
+	}
+}
+
+func TestRouter_WithTimeout(t *testing.T) {
+	options := DefaultOptions()
+	options.EnableLogging = false
+	options.TimeoutDuration = time.Second
+	r := NewWithOptions(options)
+
+	var defaultRemaining, reportRemaining time.Duration
+	r.Get("/orders", deadlineHandler(t, &defaultRemaining))
+	r.WithTimeout(time.Minute).Get("/reports", deadlineHandler(t, &reportRemaining))
+
+	r.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/orders", nil))
+	r.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/reports", nil))
+
+	if defaultRemaining > time.Second {
+		t.Errorf("Expected default deadline within 1s, got %v", defaultRemaining)
+	}
+	if reportRemaining <= time.Second {
+		t.Errorf("Expected extended deadline, got %v", reportRemaining)
+	}
+}
+
+func TestRouter_GroupWithOptions(t *testing.T) {
+	options := DefaultOptions()
+	options.EnableLogging = false
+	options.TimeoutDuration = time.Second
+	r := NewWithOptions(options)
+
+	var remaining time.Duration
+	r.GroupWithOptions(GroupOptions{Timeout: time.Minute}, func(g chi.Router) {
+		g.Get("/exports", deadlineHandler(t, &remaining))
+	})
+
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/exports", nil))
+
+	if w.Code != http.StatusOK {
+		t.Fatalf("Expected status code %d, got %d", http.StatusOK, w.Code)
+	}
+	if remaining <= time.Second {
+		t.Errorf("Expected group deadline to be extended, got %v", remaining)
+	}
+}
+
+func TestRouter_GroupWithOptions_Middleware(t *testing.T) {
+	r := NewWithOptions(Options{})
+
+	tag := func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+			w.Header().Set("X-Group", "admin")
+			next.ServeHTTP(w, req)
+		})
+	}
+	r.GroupWithOptions(GroupOptions{Middleware: []func(http.Handler) http.Handler{tag}}, func(g chi.Router) {
+		g.Get("/admin", func(w http.ResponseWriter, req *http.Request) {})
+	})
+	r.Get("/public", func(w http.ResponseWriter, req *http.Request) {})
+
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/admin", nil))
+	if w.Header().Get("X-Group") != "admin" {
+		t.Error("Expected group middleware on group routes")
+	}
+
+	w = httptest.NewRecorder()
+	r.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/public", nil))
+	if w.Header().Get("X-Group") != "" {
+		t.Error("Expected group middleware not to apply outside the group")
+	}
+}
@@ -0,0 +1,131 @@
+package router
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"runtime/debug"
+	"time"
+
+	"github.com/StairSupplies/go-core/ctxutils"
+	"github.com/StairSupplies/go-core/logger"
+	"github.com/StairSupplies/go-core/router/websocket"
+	"go.uber.org/zap"
+)
+
+// WebsocketHandler serves one websocket connection. ctx carries the
+// request's values, including its logger and request ID, but not its
+// deadline, and is canceled when the connection closes or the handler
+// returns. The connection is closed when the handler returns.
+type WebsocketHandler func(ctx context.Context, conn *websocket.Conn)
+
+// WebsocketOptions configures WebsocketWithOptions
+type WebsocketOptions struct {
+	// Upgrader performs the handshake. Its zero value only accepts browser
+	// requests from the same origin.
+	Upgrader websocket.Upgrader
+	// ResponseHeader is added to the handshake response
+	ResponseHeader http.Header
+}
+
+// Websocket returns a handler that upgrades requests to websocket
+// connections and serves them with handler:
+//
+//	r.Get("/ws/orders", router.Websocket(func(ctx context.Context, conn *websocket.Conn) {
+//		for {
+//			var msg Subscription
+//			if err := conn.ReadJSON(&msg); err != nil {
+//				return
+//			}
+//			// ...
+//		}
+//	}))
+//
+// The connection is not subject to the router's request timeout. A panic in
+// handler is logged and closes only its connection, with
+// CloseInternalServerError. Opening and closing are logged with the request
+// logger, at warn level if the connection ended unexpectedly.
+func Websocket(handler WebsocketHandler) http.HandlerFunc {
+	return WebsocketWithOptions(WebsocketOptions{}, handler)
+}
+
+// WebsocketWithOptions is like Websocket but upgrades with opts
+func WebsocketWithOptions(opts WebsocketOptions, handler WebsocketHandler) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		// Keep the Timeout middleware from answering a long-lived connection
+		if state, ok := r.Context().Value(timeoutStateKey{}).(*timeoutState); ok {
+			state.overridden = true
+		}
+
+		conn, err := opts.Upgrader.Upgrade(w, r, opts.ResponseHeader)
+		if err != nil {
+			logger.WithContext(r.Context()).Warn("Websocket upgrade failed",
+				zap.Error(err),
+				zap.String("path", r.URL.Path),
+			)
+			return
+		}
+
+		requestID := ctxutils.RequestID(r.Context())
+		ctx, cancel := context.WithCancel(context.WithoutCancel(r.Context()))
+		defer cancel()
+		if requestID != "" {
+			ctx = ctxutils.WithRequestID(ctx, requestID)
+		}
+		ctx = logger.AppendFields(ctx,
+			zap.String("request_id", requestID),
+			zap.String("path", r.URL.Path),
+		)
+		go func() {
+			select {
+			case <-conn.Done():
+				cancel()
+			case <-ctx.Done():
+			}
+		}()
+
+		log := logger.WithContext(ctx)
+		log.Debug("Websocket connection opened",
+			zap.String("remote_addr", r.RemoteAddr),
+			zap.String("subprotocol", conn.Subprotocol()),
+		)
+
+		start := time.Now()
+		defer func() {
+			if rvr := recover(); rvr != nil {
+				log.Error("Websocket handler panicked",
+					zap.String("panic", fmt.Sprint(rvr)),
+					zap.String("stack", string(debug.Stack())),
+				)
+				conn.WriteClose(websocket.CloseInternalServerError, "internal server error")
+			} else {
+				conn.WriteClose(websocket.CloseNormalClosure, "")
+			}
+			conn.Close()
+			logWebsocketClosed(log, conn.Err(), time.Since(start))
+		}()
+
+		handler(ctx, conn)
+	}
+}
+
+// logWebsocketClosed logs the end of a connection, at warn level if it ended
+// unexpectedly
+func logWebsocketClosed(log *logger.Logger, err error, duration time.Duration) {
+	fields := []zap.Field{zap.Duration("duration", duration)}
+	if err == nil {
+		log.Info("Websocket connection closed", fields...)
+		return
+	}
+
+	var closeErr *websocket.CloseError
+	if errors.As(err, &closeErr) {
+		fields = append(fields, zap.Int("close_code", closeErr.Code))
+	}
+	if websocket.IsUnexpectedCloseError(err) {
+		log.Warn("Websocket connection closed unexpectedly", append(fields, zap.Error(err))...)
+		return
+	}
+	log.Info("Websocket connection closed", fields...)
+}
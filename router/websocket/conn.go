@@ -0,0 +1,427 @@
+package websocket
+
+import (
+	"bufio"
+	"encoding/binary"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"sync"
+	"time"
+	"unicode/utf8"
+)
+
+// Message types, the opcodes of RFC 6455
+const (
+	TextMessage   = 1
+	BinaryMessage = 2
+	CloseMessage  = 8
+	PingMessage   = 9
+	PongMessage   = 10
+)
+
+// continuationFrame is the opcode of the later frames of a fragmented message
+const continuationFrame = 0
+
+// Close codes defined by RFC 6455
+const (
+	CloseNormalClosure           = 1000
+	CloseGoingAway               = 1001
+	CloseProtocolError           = 1002
+	CloseUnsupportedData         = 1003
+	CloseNoStatusReceived        = 1005
+	CloseAbnormalClosure         = 1006
+	CloseInvalidFramePayloadData = 1007
+	ClosePolicyViolation         = 1008
+	CloseMessageTooBig           = 1009
+	CloseInternalServerError     = 1011
+)
+
+// maxControlPayload is the largest payload of a close, ping or pong frame
+const maxControlPayload = 125
+
+var (
+	// ErrReadLimit is returned when a message is larger than the read limit
+	ErrReadLimit = errors.New("websocket: message exceeds read limit")
+	// ErrCloseSent is returned when writing after a close frame was sent
+	ErrCloseSent = errors.New("websocket: close sent")
+)
+
+// CloseError is returned by ReadMessage when the peer closes the connection
+type CloseError struct {
+	// Code is the close code sent by the peer, CloseNoStatusReceived if it
+	// sent none, or CloseAbnormalClosure if the connection dropped
+	Code int
+	// Text is the reason sent by the peer, if any
+	Text string
+}
+
+// Error implements the error interface
+func (e *CloseError) Error() string {
+	if e.Text != "" {
+		return fmt.Sprintf("websocket: close %d: %s", e.Code, e.Text)
+	}
+	return fmt.Sprintf("websocket: close %d", e.Code)
+}
+
+// IsCloseError reports whether err is a *CloseError with one of codes, or
+// any *CloseError if no codes are given
+func IsCloseError(err error, codes ...int) bool {
+	var ce *CloseError
+	if !errors.As(err, &ce) {
+		return false
+	}
+	if len(codes) == 0 {
+		return true
+	}
+	for _, code := range codes {
+		if ce.Code == code {
+			return true
+		}
+	}
+	return false
+}
+
+// IsUnexpectedCloseError reports whether err ends the connection for a reason
+// other than a normal closure or the client going away, such as a protocol
+// error or a dropped connection
+func IsUnexpectedCloseError(err error) bool {
+	if err == nil {
+		return false
+	}
+	return !IsCloseError(err, CloseNormalClosure, CloseGoingAway, CloseNoStatusReceived)
+}
+
+// Conn is a server-side websocket connection. One goroutine may read and any
+// number may write at the same time.
+type Conn struct {
+	conn        net.Conn
+	br          *bufio.Reader
+	subprotocol string
+	readLimit   int64
+
+	writeMu   sync.Mutex
+	closeSent bool
+
+	// readErr is the error that ended reading, kept so every later read
+	// returns it
+	readErr error
+	// pongHandler, if set, is called with the payload of each pong
+	pongHandler func(appData string)
+
+	closeOnce sync.Once
+	done      chan struct{}
+}
+
+// newConn wraps an upgraded connection
+func newConn(conn net.Conn, br *bufio.Reader, subprotocol string, readLimit int64) *Conn {
+	return &Conn{
+		conn:        conn,
+		br:          br,
+		subprotocol: subprotocol,
+		readLimit:   readLimit,
+		done:        make(chan struct{}),
+	}
+}
+
+// Subprotocol returns the subprotocol selected during the handshake, if any
+func (c *Conn) Subprotocol() string {
+	return c.subprotocol
+}
+
+// RemoteAddr returns the address of the peer
+func (c *Conn) RemoteAddr() net.Addr {
+	return c.conn.RemoteAddr()
+}
+
+// SetReadLimit sets the largest message ReadMessage accepts. A larger
+// message closes the connection with CloseMessageTooBig.
+func (c *Conn) SetReadLimit(limit int64) {
+	c.readLimit = limit
+}
+
+// SetReadDeadline sets the deadline for reads; the zero time means none
+func (c *Conn) SetReadDeadline(t time.Time) error {
+	return c.conn.SetReadDeadline(t)
+}
+
+// SetWriteDeadline sets the deadline for writes; the zero time means none
+func (c *Conn) SetWriteDeadline(t time.Time) error {
+	return c.conn.SetWriteDeadline(t)
+}
+
+// SetPongHandler sets a function called with the payload of each pong the
+// peer sends, for example to extend the read deadline of a keep-alive
+func (c *Conn) SetPongHandler(h func(appData string)) {
+	c.pongHandler = h
+}
+
+// ReadMessage reads the next text or binary message. Pings are answered and
+// pongs passed to the pong handler while waiting. When the peer closes the
+// connection the close is acknowledged and a *CloseError returned.
+func (c *Conn) ReadMessage() (messageType int, p []byte, err error) {
+	if c.readErr != nil {
+		return 0, nil, c.readErr
+	}
+	messageType, p, err = c.readMessage()
+	if err != nil {
+		err = c.failRead(err)
+		c.writeMu.Lock()
+		c.readErr = err
+		c.writeMu.Unlock()
+		c.Close()
+		return 0, nil, err
+	}
+	return messageType, p, nil
+}
+
+// readMessage reads frames until a complete data message has arrived
+func (c *Conn) readMessage() (int, []byte, error) {
+	messageType := 0
+	var message []byte
+
+	for {
+		fin, opcode, payload, err := c.readFrame()
+		if err != nil {
+			return 0, nil, err
+		}
+
+		switch opcode {
+		case PingMessage:
+			if err := c.writeFrame(PongMessage, payload); err != nil && !errors.Is(err, ErrCloseSent) {
+				return 0, nil, err
+			}
+			continue
+		case PongMessage:
+			if c.pongHandler != nil {
+				c.pongHandler(string(payload))
+			}
+			continue
+		case CloseMessage:
+			return 0, nil, c.handleClose(payload)
+		case TextMessage, BinaryMessage:
+			if messageType != 0 {
+				return 0, nil, protocolError("new message started before the previous one finished")
+			}
+			messageType = opcode
+		case continuationFrame:
+			if messageType == 0 {
+				return 0, nil, protocolError("continuation frame without a message")
+			}
+		default:
+			return 0, nil, protocolError(fmt.Sprintf("unknown opcode %d", opcode))
+		}
+
+		if int64(len(message)+len(payload)) > c.readLimit {
+			return 0, nil, ErrReadLimit
+		}
+		message = append(message, payload...)
+
+		if fin {
+			if messageType == TextMessage && !utf8.Valid(message) {
+				return 0, nil, &CloseError{Code: CloseInvalidFramePayloadData, Text: "invalid UTF-8 in text message"}
+			}
+			return messageType, message, nil
+		}
+	}
+}
+
+// readFrame reads one frame and unmasks its payload
+func (c *Conn) readFrame() (fin bool, opcode int, payload []byte, err error) {
+	var header [2]byte
+	if _, err = io.ReadFull(c.br, header[:]); err != nil {
+		return false, 0, nil, err
+	}
+
+	fin = header[0]&0x80 != 0
+	if header[0]&0x70 != 0 {
+		return false, 0, nil, protocolError("reserved bits set")
+	}
+	opcode = int(header[0] & 0x0f)
+	masked := header[1]&0x80 != 0
+	length := uint64(header[1] & 0x7f)
+
+	switch length {
+	case 126:
+		var ext [2]byte
+		if _, err = io.ReadFull(c.br, ext[:]); err != nil {
+			return false, 0, nil, err
+		}
+		length = uint64(binary.BigEndian.Uint16(ext[:]))
+	case 127:
+		var ext [8]byte
+		if _, err = io.ReadFull(c.br, ext[:]); err != nil {
+			return false, 0, nil, err
+		}
+		length = binary.BigEndian.Uint64(ext[:])
+	}
+
+	if opcode >= CloseMessage && (!fin || length > maxControlPayload) {
+		return false, 0, nil, protocolError("invalid control frame")
+	}
+	if !masked {
+		return false, 0, nil, protocolError("client frame is not masked")
+	}
+	if length > uint64(c.readLimit) {
+		return false, 0, nil, ErrReadLimit
+	}
+
+	var mask [4]byte
+	if _, err = io.ReadFull(c.br, mask[:]); err != nil {
+		return false, 0, nil, err
+	}
+	payload = make([]byte, length)
+	if _, err = io.ReadFull(c.br, payload); err != nil {
+		return false, 0, nil, err
+	}
+	for i := range payload {
+		payload[i] ^= mask[i%4]
+	}
+	return fin, opcode, payload, nil
+}
+
+// handleClose acknowledges a close frame and returns it as a *CloseError
+func (c *Conn) handleClose(payload []byte) error {
+	closeErr := &CloseError{Code: CloseNoStatusReceived}
+	if len(payload) >= 2 {
+		closeErr.Code = int(binary.BigEndian.Uint16(payload))
+		closeErr.Text = string(payload[2:])
+	}
+	if closeErr.Code == CloseNoStatusReceived {
+		c.WriteClose(CloseNormalClosure, "")
+	} else {
+		c.WriteClose(closeErr.Code, "")
+	}
+	return closeErr
+}
+
+// failRead tells the peer why reading failed when the error is theirs, and
+// returns the error ReadMessage reports
+func (c *Conn) failRead(err error) error {
+	var closeErr *CloseError
+	switch {
+	case errors.As(err, &closeErr):
+		if closeErr.Code == CloseInvalidFramePayloadData {
+			c.WriteClose(closeErr.Code, closeErr.Text)
+		}
+	case errors.Is(err, ErrReadLimit):
+		c.WriteClose(CloseMessageTooBig, "")
+	case errors.Is(err, errProtocol):
+		c.WriteClose(CloseProtocolError, "")
+	case errors.Is(err, io.EOF), errors.Is(err, io.ErrUnexpectedEOF), errors.Is(err, net.ErrClosed):
+		err = &CloseError{Code: CloseAbnormalClosure, Text: err.Error()}
+	}
+	return err
+}
+
+// errProtocol marks frames that break RFC 6455
+var errProtocol = errors.New("websocket: protocol error")
+
+// protocolError returns an errProtocol with a reason
+func protocolError(reason string) error {
+	return fmt.Errorf("%w: %s", errProtocol, reason)
+}
+
+// WriteMessage sends a message of messageType in a single frame. It is safe
+// to call from several goroutines.
+func (c *Conn) WriteMessage(messageType int, data []byte) error {
+	switch messageType {
+	case TextMessage, BinaryMessage:
+	case PingMessage, PongMessage:
+		if len(data) > maxControlPayload {
+			return errors.New("websocket: control message payload too long")
+		}
+	case CloseMessage:
+		return errors.New("websocket: use WriteClose to send a close message")
+	default:
+		return fmt.Errorf("websocket: unknown message type %d", messageType)
+	}
+	return c.writeFrame(messageType, data)
+}
+
+// WriteClose sends a close frame with code and text. Nothing may be written
+// after it; the peer is expected to reply with its own close frame.
+func (c *Conn) WriteClose(code int, text string) error {
+	payload := make([]byte, 2, 2+len(text))
+	binary.BigEndian.PutUint16(payload, uint16(code))
+	payload = append(payload, text...)
+	if len(payload) > maxControlPayload {
+		payload = payload[:maxControlPayload]
+	}
+	return c.writeFrame(CloseMessage, payload)
+}
+
+// writeFrame writes one unmasked frame with the FIN bit set
+func (c *Conn) writeFrame(opcode int, payload []byte) error {
+	c.writeMu.Lock()
+	defer c.writeMu.Unlock()
+
+	if c.closeSent {
+		return ErrCloseSent
+	}
+	if opcode == CloseMessage {
+		c.closeSent = true
+	}
+
+	frame := make([]byte, 0, 10+len(payload))
+	frame = append(frame, 0x80|byte(opcode))
+	switch n := len(payload); {
+	case n <= 125:
+		frame = append(frame, byte(n))
+	case n <= 0xffff:
+		frame = append(frame, 126)
+		frame = binary.BigEndian.AppendUint16(frame, uint16(n))
+	default:
+		frame = append(frame, 127)
+		frame = binary.BigEndian.AppendUint64(frame, uint64(n))
+	}
+	frame = append(frame, payload...)
+
+	_, err := c.conn.Write(frame)
+	return err
+}
+
+// ReadJSON reads the next message and decodes it as JSON into v
+func (c *Conn) ReadJSON(v any) error {
+	_, p, err := c.ReadMessage()
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(p, v)
+}
+
+// WriteJSON encodes v as JSON and sends it as a text message
+func (c *Conn) WriteJSON(v any) error {
+	p, err := json.Marshal(v)
+	if err != nil {
+		return err
+	}
+	return c.WriteMessage(TextMessage, p)
+}
+
+// Err returns the error that ended reading: a *CloseError when the peer
+// closed the connection, or nil if reading has not failed
+func (c *Conn) Err() error {
+	c.writeMu.Lock()
+	defer c.writeMu.Unlock()
+	return c.readErr
+}
+
+// Done returns a channel that is closed when the connection is closed, so
+// goroutines writing to it can stop
+func (c *Conn) Done() <-chan struct{} {
+	return c.done
+}
+
+// Close closes the underlying connection without sending a close frame.
+// Use WriteClose first for a clean shutdown.
+func (c *Conn) Close() error {
+	var err error
+	c.closeOnce.Do(func() {
+		err = c.conn.Close()
+		close(c.done)
+	})
+	return err
+}
@@ -0,0 +1,64 @@
+/*
+Package websocket implements the server side of the WebSocket protocol
+(RFC 6455) on the standard library.
+
+Most services use it through router.Websocket, which adds logging, panic
+recovery and the request context. The Upgrader and Conn can also be used on
+their own in any http.Handler.
+
+# Features
+
+  - Handshake validation with same-origin checking and subprotocol selection
+  - Text and binary messages, reassembled from fragments
+  - Automatic pong replies and close acknowledgements
+  - Message size limits, UTF-8 validation and protocol error closes
+  - JSON helpers and concurrent-safe writes
+
+# Upgrading Requests
+
+Upgrade completes the handshake and takes over the connection:
+
+	upgrader := &websocket.Upgrader{Subprotocols: []string{"orders.v1"}}
+
+	func serveOrders(w http.ResponseWriter, r *http.Request) {
+		conn, err := upgrader.Upgrade(w, r, nil)
+		if err != nil {
+			return // an error response was written
+		}
+		defer conn.Close()
+		...
+	}
+
+Browser requests must come from the same host unless CheckOrigin allows them.
+Messages larger than ReadLimit, DefaultReadLimit by default, close the
+connection with CloseMessageTooBig.
+
+# Reading and Writing
+
+One goroutine reads while any number write:
+
+	for {
+		var sub Subscription
+		if err := conn.ReadJSON(&sub); err != nil {
+			if websocket.IsUnexpectedCloseError(err) {
+				log.Warn("Subscription stream failed", zap.Error(err))
+			}
+			return
+		}
+		conn.WriteJSON(snapshot(sub))
+	}
+
+ReadMessage answers pings and returns a *CloseError once the peer closes the
+connection. Done is closed when the connection closes, for goroutines that
+push messages:
+
+	select {
+	case event := <-events:
+		conn.WriteJSON(event)
+	case <-conn.Done():
+		return
+	}
+
+WriteClose starts a clean shutdown with a close code and reason.
+*/
+package websocket
@@ -0,0 +1,42 @@
+package websocket_test
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/StairSupplies/go-core/router/websocket"
+)
+
+func ExampleUpgrader() {
+	upgrader := &websocket.Upgrader{Subprotocols: []string{"orders.v1"}}
+
+	http.HandleFunc("/ws/echo", func(w http.ResponseWriter, r *http.Request) {
+		conn, err := upgrader.Upgrade(w, r, nil)
+		if err != nil {
+			return
+		}
+		defer conn.Close()
+
+		for {
+			messageType, p, err := conn.ReadMessage()
+			if err != nil {
+				return
+			}
+			if err := conn.WriteMessage(messageType, p); err != nil {
+				return
+			}
+		}
+	})
+}
+
+func ExampleIsUnexpectedCloseError() {
+	goingAway := &websocket.CloseError{Code: websocket.CloseGoingAway}
+	tooBig := &websocket.CloseError{Code: websocket.CloseMessageTooBig}
+
+	fmt.Println(websocket.IsUnexpectedCloseError(goingAway))
+	fmt.Println(websocket.IsUnexpectedCloseError(tooBig))
+
+	// Output:
+	// false
+	// true
+}
@@ -0,0 +1,179 @@
+package websocket
+
+import (
+	"crypto/sha1"
+	"encoding/base64"
+	"errors"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+)
+
+// DefaultReadLimit is the largest message a connection reads when the
+// Upgrader sets no limit
+const DefaultReadLimit = 1 << 20
+
+// acceptGUID is appended to the client's key to compute Sec-WebSocket-Accept
+const acceptGUID = "258EAFA5-E914-47DA-95CA-C5AB0DC85B11"
+
+// ErrBadHandshake is returned by Upgrade when the request is not a valid
+// websocket handshake; an error response has already been written
+var ErrBadHandshake = errors.New("websocket: bad handshake")
+
+// Upgrader upgrades HTTP requests to websocket connections
+type Upgrader struct {
+	// CheckOrigin reports whether a browser request from the request's
+	// Origin is allowed. Nil allows requests without an Origin header and
+	// requests whose Origin host matches the Host header.
+	CheckOrigin func(r *http.Request) bool
+	// Subprotocols lists the supported subprotocols in order of preference.
+	// The first one the client also offers is selected.
+	Subprotocols []string
+	// ReadLimit is the largest message a connection reads; zero means
+	// DefaultReadLimit
+	ReadLimit int64
+	// HandshakeTimeout bounds writing the handshake response; zero means no limit
+	HandshakeTimeout time.Duration
+}
+
+// Upgrade completes the websocket handshake for r and takes over its
+// connection. responseHeader, which may be nil, is added to the handshake
+// response. If the handshake fails an error response is written and the
+// returned error wraps ErrBadHandshake.
+func (u *Upgrader) Upgrade(w http.ResponseWriter, r *http.Request, responseHeader http.Header) (*Conn, error) {
+	if r.Method != http.MethodGet {
+		return nil, u.fail(w, http.StatusMethodNotAllowed, "request method is not GET")
+	}
+	if !headerContainsToken(r.Header, "Connection", "upgrade") {
+		return nil, u.fail(w, http.StatusBadRequest, "Connection header does not contain upgrade")
+	}
+	if !headerContainsToken(r.Header, "Upgrade", "websocket") {
+		return nil, u.fail(w, http.StatusBadRequest, "Upgrade header is not websocket")
+	}
+	if r.Header.Get("Sec-WebSocket-Version") != "13" {
+		w.Header().Set("Sec-WebSocket-Version", "13")
+		return nil, u.fail(w, http.StatusUpgradeRequired, "unsupported websocket version")
+	}
+	key := r.Header.Get("Sec-WebSocket-Key")
+	if decoded, err := base64.StdEncoding.DecodeString(key); err != nil || len(decoded) != 16 {
+		return nil, u.fail(w, http.StatusBadRequest, "invalid Sec-WebSocket-Key")
+	}
+	checkOrigin := u.CheckOrigin
+	if checkOrigin == nil {
+		checkOrigin = sameOrigin
+	}
+	if !checkOrigin(r) {
+		return nil, u.fail(w, http.StatusForbidden, "origin not allowed")
+	}
+
+	netConn, brw, err := http.NewResponseController(w).Hijack()
+	if err != nil {
+		return nil, u.fail(w, http.StatusInternalServerError, "connection cannot be hijacked: "+err.Error())
+	}
+	subprotocol := u.selectSubprotocol(r)
+
+	var b strings.Builder
+	b.WriteString("HTTP/1.1 101 Switching Protocols\r\nUpgrade: websocket\r\nConnection: Upgrade\r\nSec-WebSocket-Accept: ")
+	b.WriteString(acceptKey(key))
+	b.WriteString("\r\n")
+	if subprotocol != "" {
+		b.WriteString("Sec-WebSocket-Protocol: " + subprotocol + "\r\n")
+	}
+	for k, values := range responseHeader {
+		if k == "Sec-Websocket-Protocol" {
+			continue
+		}
+		for _, v := range values {
+			b.WriteString(k + ": " + strings.NewReplacer("\r", "", "\n", "").Replace(v) + "\r\n")
+		}
+	}
+	b.WriteString("\r\n")
+
+	if u.HandshakeTimeout > 0 {
+		netConn.SetWriteDeadline(time.Now().Add(u.HandshakeTimeout))
+	}
+	if _, err := netConn.Write([]byte(b.String())); err != nil {
+		netConn.Close()
+		return nil, err
+	}
+	if u.HandshakeTimeout > 0 {
+		netConn.SetWriteDeadline(time.Time{})
+	}
+
+	readLimit := u.ReadLimit
+	if readLimit <= 0 {
+		readLimit = DefaultReadLimit
+	}
+	// brw.Reader may already hold the client's first frames
+	return newConn(netConn, brw.Reader, subprotocol, readLimit), nil
+}
+
+// fail writes an error response for a failed handshake
+func (u *Upgrader) fail(w http.ResponseWriter, status int, reason string) error {
+	http.Error(w, http.StatusText(status), status)
+	return errors.Join(ErrBadHandshake, errors.New(reason))
+}
+
+// selectSubprotocol returns the first of u.Subprotocols the client offers
+func (u *Upgrader) selectSubprotocol(r *http.Request) string {
+	offered := headerTokens(r.Header, "Sec-WebSocket-Protocol")
+	for _, p := range u.Subprotocols {
+		for _, o := range offered {
+			if p == o {
+				return p
+			}
+		}
+	}
+	return ""
+}
+
+// IsWebsocketUpgrade reports whether r asks to upgrade to a websocket
+func IsWebsocketUpgrade(r *http.Request) bool {
+	return headerContainsToken(r.Header, "Connection", "upgrade") &&
+		headerContainsToken(r.Header, "Upgrade", "websocket")
+}
+
+// sameOrigin allows requests without an Origin and requests whose Origin
+// host matches the Host header
+func sameOrigin(r *http.Request) bool {
+	origin := r.Header.Get("Origin")
+	if origin == "" {
+		return true
+	}
+	u, err := url.Parse(origin)
+	if err != nil {
+		return false
+	}
+	return strings.EqualFold(u.Host, r.Host)
+}
+
+// acceptKey computes Sec-WebSocket-Accept for a client key
+func acceptKey(key string) string {
+	h := sha1.New()
+	h.Write([]byte(key + acceptGUID))
+	return base64.StdEncoding.EncodeToString(h.Sum(nil))
+}
+
+// headerTokens returns the comma-separated tokens of every value of header
+func headerTokens(h http.Header, header string) []string {
+	var tokens []string
+	for _, v := range h.Values(header) {
+		for _, t := range strings.Split(v, ",") {
+			if t = strings.TrimSpace(t); t != "" {
+				tokens = append(tokens, t)
+			}
+		}
+	}
+	return tokens
+}
+
+// headerContainsToken reports whether header lists token, ignoring case
+func headerContainsToken(h http.Header, header, token string) bool {
+	for _, t := range headerTokens(h, header) {
+		if strings.EqualFold(t, token) {
+			return true
+		}
+	}
+	return false
+}
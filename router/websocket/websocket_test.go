@@ -0,0 +1,343 @@
+package websocket
+
+import (
+	"bufio"
+	"encoding/binary"
+	"errors"
+	"io"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+// testClient is a minimal websocket client speaking raw frames
+type testClient struct {
+	t    *testing.T
+	conn net.Conn
+	br   *bufio.Reader
+	resp *http.Response
+}
+
+// dial performs the handshake against server with extra request headers
+func dial(t *testing.T, server *httptest.Server, header http.Header) *testClient {
+	t.Helper()
+	conn, err := net.Dial("tcp", strings.TrimPrefix(server.URL, "http://"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { conn.Close() })
+	conn.SetDeadline(time.Now().Add(5 * time.Second))
+
+	req, _ := http.NewRequest(http.MethodGet, server.URL+"/ws", nil)
+	req.Header.Set("Connection", "Upgrade")
+	req.Header.Set("Upgrade", "websocket")
+	req.Header.Set("Sec-WebSocket-Version", "13")
+	req.Header.Set("Sec-WebSocket-Key", "dGhlIHNhbXBsZSBub25jZQ==")
+	for k, v := range header {
+		req.Header[k] = v
+	}
+	if err := req.Write(conn); err != nil {
+		t.Fatal(err)
+	}
+
+	br := bufio.NewReader(conn)
+	resp, err := http.ReadResponse(br, req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return &testClient{t: t, conn: conn, br: br, resp: resp}
+}
+
+// writeFrame sends a masked frame
+func (c *testClient) writeFrame(fin bool, opcode int, payload []byte) {
+	c.t.Helper()
+	b0 := byte(opcode)
+	if fin {
+		b0 |= 0x80
+	}
+	frame := []byte{b0}
+	switch n := len(payload); {
+	case n <= 125:
+		frame = append(frame, 0x80|byte(n))
+	case n <= 0xffff:
+		frame = append(frame, 0x80|126)
+		frame = binary.BigEndian.AppendUint16(frame, uint16(n))
+	default:
+		frame = append(frame, 0x80|127)
+		frame = binary.BigEndian.AppendUint64(frame, uint64(n))
+	}
+	mask := [4]byte{1, 2, 3, 4}
+	frame = append(frame, mask[:]...)
+	for i, b := range payload {
+		frame = append(frame, b^mask[i%4])
+	}
+	if _, err := c.conn.Write(frame); err != nil {
+		c.t.Fatal(err)
+	}
+}
+
+// readFrame reads an unmasked server frame
+func (c *testClient) readFrame() (int, []byte) {
+	c.t.Helper()
+	var header [2]byte
+	if _, err := io.ReadFull(c.br, header[:]); err != nil {
+		c.t.Fatalf("reading frame: %v", err)
+	}
+	length := int(header[1] & 0x7f)
+	switch length {
+	case 126:
+		var ext [2]byte
+		io.ReadFull(c.br, ext[:])
+		length = int(binary.BigEndian.Uint16(ext[:]))
+	case 127:
+		var ext [8]byte
+		io.ReadFull(c.br, ext[:])
+		length = int(binary.BigEndian.Uint64(ext[:]))
+	}
+	payload := make([]byte, length)
+	if _, err := io.ReadFull(c.br, payload); err != nil {
+		c.t.Fatalf("reading payload: %v", err)
+	}
+	return int(header[0] & 0x0f), payload
+}
+
+// closePayload builds a close frame payload
+func closePayload(code int, text string) []byte {
+	return append(binary.BigEndian.AppendUint16(nil, uint16(code)), text...)
+}
+
+// serve starts a server upgrading /ws with u and running fn on the connection
+func serve(t *testing.T, u *Upgrader, fn func(*Conn)) *httptest.Server {
+	t.Helper()
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		conn, err := u.Upgrade(w, r, http.Header{"X-Served-By": {"test"}})
+		if err != nil {
+			return
+		}
+		defer conn.Close()
+		fn(conn)
+	}))
+	t.Cleanup(server.Close)
+	return server
+}
+
+func TestUpgrade_Handshake(t *testing.T) {
+	server := serve(t, &Upgrader{Subprotocols: []string{"orders.v2", "orders.v1"}}, func(c *Conn) {
+		c.WriteMessage(TextMessage, []byte(c.Subprotocol()))
+	})
+
+	client := dial(t, server, http.Header{"Sec-Websocket-Protocol": {"orders.v1, orders.v2"}})
+	if client.resp.StatusCode != http.StatusSwitchingProtocols {
+		t.Fatalf("Expected status 101, got %d", client.resp.StatusCode)
+	}
+	if got := client.resp.Header.Get("Sec-WebSocket-Accept"); got != "s3pPLMBiTxaQ9kYGzzhZRbK+xOo=" {
+		t.Errorf("Expected RFC 6455 accept key, got %q", got)
+	}
+	if got := client.resp.Header.Get("X-Served-By"); got != "test" {
+		t.Errorf("Expected response header, got %q", got)
+	}
+	if _, payload := client.readFrame(); string(payload) != "orders.v2" {
+		t.Errorf("Expected preferred subprotocol orders.v2, got %q", payload)
+	}
+}
+
+func TestUpgrade_Rejected(t *testing.T) {
+	tests := []struct {
+		name       string
+		method     string
+		header     map[string]string
+		wantStatus int
+	}{
+		{"not GET", http.MethodPost, nil, http.StatusMethodNotAllowed},
+		{"missing upgrade", http.MethodGet, map[string]string{"Upgrade": ""}, http.StatusBadRequest},
+		{"wrong version", http.MethodGet, map[string]string{"Sec-WebSocket-Version": "8"}, http.StatusUpgradeRequired},
+		{"bad key", http.MethodGet, map[string]string{"Sec-WebSocket-Key": "short"}, http.StatusBadRequest},
+		{"cross origin", http.MethodGet, map[string]string{"Origin": "https://evil.example.com"}, http.StatusForbidden},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := httptest.NewRequest(tt.method, "http://shop.example.com/ws", nil)
+			r.Header.Set("Connection", "Upgrade")
+			r.Header.Set("Upgrade", "websocket")
+			r.Header.Set("Sec-WebSocket-Version", "13")
+			r.Header.Set("Sec-WebSocket-Key", "dGhlIHNhbXBsZSBub25jZQ==")
+			for k, v := range tt.header {
+				r.Header.Set(k, v)
+			}
+			w := httptest.NewRecorder()
+
+			_, err := (&Upgrader{}).Upgrade(w, r, nil)
+			if !errors.Is(err, ErrBadHandshake) {
+				t.Errorf("Expected ErrBadHandshake, got %v", err)
+			}
+			if w.Code != tt.wantStatus {
+				t.Errorf("Expected status %d, got %d", tt.wantStatus, w.Code)
+			}
+		})
+	}
+}
+
+func TestSameOrigin(t *testing.T) {
+	r := httptest.NewRequest(http.MethodGet, "http://shop.example.com/ws", nil)
+	if !sameOrigin(r) {
+		t.Error("Expected requests without Origin to be allowed")
+	}
+	r.Header.Set("Origin", "https://shop.example.com")
+	if !sameOrigin(r) {
+		t.Error("Expected same-host origin to be allowed")
+	}
+	r.Header.Set("Origin", "https://other.example.com")
+	if sameOrigin(r) {
+		t.Error("Expected other origin to be rejected")
+	}
+}
+
+func TestConn_EchoAndFragments(t *testing.T) {
+	server := serve(t, &Upgrader{}, func(c *Conn) {
+		for {
+			mt, p, err := c.ReadMessage()
+			if err != nil {
+				return
+			}
+			c.WriteMessage(mt, p)
+		}
+	})
+	client := dial(t, server, nil)
+
+	client.writeFrame(true, TextMessage, []byte("hello"))
+	if op, p := client.readFrame(); op != TextMessage || string(p) != "hello" {
+		t.Errorf("Expected text echo, got %d %q", op, p)
+	}
+
+	// A ping between fragments is answered without disturbing the message
+	client.writeFrame(false, BinaryMessage, []byte("frag"))
+	client.writeFrame(true, PingMessage, []byte("keepalive"))
+	client.writeFrame(true, continuationFrame, []byte("mented"))
+	if op, p := client.readFrame(); op != PongMessage || string(p) != "keepalive" {
+		t.Errorf("Expected pong, got %d %q", op, p)
+	}
+	if op, p := client.readFrame(); op != BinaryMessage || string(p) != "fragmented" {
+		t.Errorf("Expected reassembled binary message, got %d %q", op, p)
+	}
+
+	large := strings.Repeat("x", 70000)
+	client.writeFrame(true, TextMessage, []byte(large))
+	if _, p := client.readFrame(); string(p) != large {
+		t.Errorf("Expected %d byte echo, got %d bytes", len(large), len(p))
+	}
+}
+
+func TestConn_PeerClose(t *testing.T) {
+	errs := make(chan error, 1)
+	server := serve(t, &Upgrader{}, func(c *Conn) {
+		_, _, err := c.ReadMessage()
+		errs <- err
+	})
+	client := dial(t, server, nil)
+
+	client.writeFrame(true, CloseMessage, closePayload(CloseGoingAway, "tab closed"))
+	if op, p := client.readFrame(); op != CloseMessage || binary.BigEndian.Uint16(p) != CloseGoingAway {
+		t.Errorf("Expected close acknowledgement, got %d %v", op, p)
+	}
+
+	err := <-errs
+	var closeErr *CloseError
+	if !errors.As(err, &closeErr) || closeErr.Code != CloseGoingAway || closeErr.Text != "tab closed" {
+		t.Errorf("Expected CloseError 1001, got %v", err)
+	}
+	if IsUnexpectedCloseError(err) {
+		t.Error("Expected going away not to be unexpected")
+	}
+}
+
+func TestConn_ProtocolErrors(t *testing.T) {
+	tests := []struct {
+		name     string
+		send     func(*testClient)
+		wantCode int
+	}{
+		{"unmasked frame", func(c *testClient) { c.conn.Write([]byte{0x81, 0x01, 'x'}) }, CloseProtocolError},
+		{"continuation without message", func(c *testClient) { c.writeFrame(true, continuationFrame, []byte("x")) }, CloseProtocolError},
+		{"invalid UTF-8", func(c *testClient) { c.writeFrame(true, TextMessage, []byte{0xff, 0xfe}) }, CloseInvalidFramePayloadData},
+		{"too large", func(c *testClient) { c.writeFrame(true, BinaryMessage, make([]byte, 64)) }, CloseMessageTooBig},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			errs := make(chan error, 1)
+			server := serve(t, &Upgrader{ReadLimit: 32}, func(c *Conn) {
+				_, _, err := c.ReadMessage()
+				errs <- err
+			})
+			client := dial(t, server, nil)
+
+			tt.send(client)
+			op, p := client.readFrame()
+			if op != CloseMessage || int(binary.BigEndian.Uint16(p)) != tt.wantCode {
+				t.Errorf("Expected close %d, got %d %v", tt.wantCode, op, p)
+			}
+			if err := <-errs; !IsUnexpectedCloseError(err) {
+				t.Errorf("Expected an unexpected close error, got %v", err)
+			}
+		})
+	}
+}
+
+func TestConn_AbnormalClosure(t *testing.T) {
+	errs := make(chan error, 1)
+	server := serve(t, &Upgrader{}, func(c *Conn) {
+		c.ReadMessage()
+		<-c.Done()
+		errs <- c.Err()
+	})
+	client := dial(t, server, nil)
+	client.conn.Close()
+
+	if err := <-errs; !IsCloseError(err, CloseAbnormalClosure) {
+		t.Errorf("Expected abnormal closure, got %v", err)
+	}
+}
+
+func TestConn_JSONAndWriteAfterClose(t *testing.T) {
+	type order struct {
+		SKU string `json:"sku"`
+	}
+	server := serve(t, &Upgrader{}, func(c *Conn) {
+		var o order
+		if err := c.ReadJSON(&o); err != nil {
+			return
+		}
+		c.WriteJSON(o)
+		c.WriteClose(CloseNormalClosure, "done")
+		if err := c.WriteMessage(TextMessage, []byte("late")); !errors.Is(err, ErrCloseSent) {
+			c.WriteMessage(TextMessage, []byte("unexpected"))
+		}
+	})
+	client := dial(t, server, nil)
+
+	client.writeFrame(true, TextMessage, []byte(`{"sku":"OAK-36"}`))
+	if _, p := client.readFrame(); string(p) != `{"sku":"OAK-36"}` {
+		t.Errorf("Expected JSON echo, got %q", p)
+	}
+	op, p := client.readFrame()
+	if op != CloseMessage || string(p[2:]) != "done" {
+		t.Errorf("Expected close frame, got %d %q", op, p)
+	}
+}
+
+func TestCloseError(t *testing.T) {
+	err := &CloseError{Code: CloseNormalClosure, Text: "bye"}
+	if err.Error() != "websocket: close 1000: bye" {
+		t.Errorf("Unexpected message %q", err.Error())
+	}
+	if !IsCloseError(err) || !IsCloseError(err, CloseGoingAway, CloseNormalClosure) || IsCloseError(err, CloseGoingAway) {
+		t.Error("IsCloseError did not match codes")
+	}
+	if IsUnexpectedCloseError(nil) || IsUnexpectedCloseError(err) || !IsUnexpectedCloseError(errors.New("reset")) {
+		t.Error("IsUnexpectedCloseError misclassified errors")
+	}
+}
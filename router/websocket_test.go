@@ -0,0 +1,191 @@
+package router
+
+import (
+	"bufio"
+	"context"
+	"encoding/binary"
+	"io"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/StairSupplies/go-core/ctxutils"
+	"github.com/StairSupplies/go-core/logger"
+	"github.com/StairSupplies/go-core/logger/logtest"
+	"github.com/StairSupplies/go-core/router/websocket"
+	"go.uber.org/zap/zapcore"
+)
+
+// dialWebsocket completes a websocket handshake with server at path
+func dialWebsocket(t *testing.T, server *httptest.Server, path string) (net.Conn, *bufio.Reader) {
+	t.Helper()
+	conn, err := net.Dial("tcp", strings.TrimPrefix(server.URL, "http://"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { conn.Close() })
+	conn.SetDeadline(time.Now().Add(5 * time.Second))
+
+	req, _ := http.NewRequest(http.MethodGet, server.URL+path, nil)
+	req.Header.Set("Connection", "Upgrade")
+	req.Header.Set("Upgrade", "websocket")
+	req.Header.Set("Sec-WebSocket-Version", "13")
+	req.Header.Set("Sec-WebSocket-Key", "dGhlIHNhbXBsZSBub25jZQ==")
+	req.Write(conn)
+
+	br := bufio.NewReader(conn)
+	resp, err := http.ReadResponse(br, req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if resp.StatusCode != http.StatusSwitchingProtocols {
+		t.Fatalf("Expected status 101, got %d", resp.StatusCode)
+	}
+	return conn, br
+}
+
+// writeTextFrame sends a masked text frame of up to 125 bytes
+func writeTextFrame(conn net.Conn, text string) {
+	frame := []byte{0x81, 0x80 | byte(len(text)), 0, 0, 0, 0}
+	conn.Write(append(frame, text...))
+}
+
+// readServerFrame reads a short unmasked frame
+func readServerFrame(t *testing.T, br *bufio.Reader) (int, []byte) {
+	t.Helper()
+	var header [2]byte
+	if _, err := io.ReadFull(br, header[:]); err != nil {
+		t.Fatalf("reading frame: %v", err)
+	}
+	payload := make([]byte, header[1]&0x7f)
+	io.ReadFull(br, payload)
+	return int(header[0] & 0x0f), payload
+}
+
+// newWebsocketTestRouter returns a router with a short request timeout whose
+// request logger records to the returned Recorder
+func newWebsocketTestRouter(t *testing.T) (*Router, *logtest.Recorder) {
+	log, rec := logtest.NewTestLogger(t)
+	r := NewWithOptions(Options{
+		EnableRequestID: true,
+		EnableRecovery:  true,
+		EnableTimeout:   true,
+		TimeoutDuration: 50 * time.Millisecond,
+	})
+	r.Use(func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+			next.ServeHTTP(w, req.WithContext(logger.NewContext(req.Context(), log)))
+		})
+	})
+	return r, rec
+}
+
+func TestWebsocket(t *testing.T) {
+	r, rec := newWebsocketTestRouter(t)
+	closed := make(chan struct{})
+	r.Get("/ws", Websocket(func(ctx context.Context, conn *websocket.Conn) {
+		defer close(closed)
+		for {
+			_, msg, err := conn.ReadMessage()
+			if err != nil {
+				return
+			}
+			// Outlive the router's request timeout before answering
+			time.Sleep(100 * time.Millisecond)
+			if ctx.Err() != nil {
+				conn.WriteMessage(websocket.TextMessage, []byte("context canceled"))
+				continue
+			}
+			logger.WithContext(ctx).Info("Message received")
+			conn.WriteMessage(websocket.TextMessage, []byte(string(msg)+" "+ctxutils.RequestID(ctx)))
+		}
+	}))
+	server := httptest.NewServer(r)
+	defer server.Close()
+
+	conn, br := dialWebsocket(t, server, "/ws")
+	writeTextFrame(conn, "ping")
+	_, payload := readServerFrame(t, br)
+	if got := string(payload); !strings.HasPrefix(got, "ping ") || got == "ping " {
+		t.Errorf("Expected echo with request ID, got %q", got)
+	}
+
+	entries := rec.FilterMessage("Message received")
+	if len(entries) != 1 || entries[0].Fields["request_id"] == "" || entries[0].Fields["path"] != "/ws" {
+		t.Errorf("Expected the connection logger to carry request fields, got %v", entries)
+	}
+
+	// Closing normally ends the handler and cancels nothing unexpected
+	conn.Write([]byte{0x88, 0x82, 0, 0, 0, 0, 0x03, 0xe8})
+	if op, _ := readServerFrame(t, br); op != websocket.CloseMessage {
+		t.Errorf("Expected close acknowledgement, got opcode %d", op)
+	}
+	<-closed
+	waitForLog(t, rec, "Websocket connection closed")
+	rec.AssertLogged(zapcore.InfoLevel, "Websocket connection closed", map[string]interface{}{"close_code": websocket.CloseNormalClosure})
+}
+
+func TestWebsocket_Panic(t *testing.T) {
+	r, rec := newWebsocketTestRouter(t)
+	r.Get("/ws", Websocket(func(ctx context.Context, conn *websocket.Conn) {
+		panic("boom")
+	}))
+	server := httptest.NewServer(r)
+	defer server.Close()
+
+	_, br := dialWebsocket(t, server, "/ws")
+	op, payload := readServerFrame(t, br)
+	if op != websocket.CloseMessage || binary.BigEndian.Uint16(payload) != websocket.CloseInternalServerError {
+		t.Errorf("Expected close 1011, got %d %v", op, payload)
+	}
+	waitForLog(t, rec, "Websocket handler panicked")
+	rec.AssertLogged(zapcore.ErrorLevel, "Websocket handler panicked", map[string]interface{}{"panic": "boom"})
+}
+
+func TestWebsocket_ContextCanceledOnClose(t *testing.T) {
+	r, rec := newWebsocketTestRouter(t)
+	r.Get("/ws", Websocket(func(ctx context.Context, conn *websocket.Conn) {
+		go conn.ReadMessage()
+		<-ctx.Done()
+	}))
+	server := httptest.NewServer(r)
+	defer server.Close()
+
+	conn, _ := dialWebsocket(t, server, "/ws")
+	conn.Close()
+
+	waitForLog(t, rec, "Websocket connection closed unexpectedly")
+	rec.AssertLogged(zapcore.WarnLevel, "Websocket connection closed unexpectedly", map[string]interface{}{
+		"close_code": websocket.CloseAbnormalClosure,
+	})
+}
+
+func TestWebsocket_UpgradeFailed(t *testing.T) {
+	r, rec := newWebsocketTestRouter(t)
+	r.Get("/ws", Websocket(func(ctx context.Context, conn *websocket.Conn) {
+		t.Error("Expected handler not to run")
+	}))
+
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/ws", nil))
+
+	if w.Code != http.StatusBadRequest {
+		t.Errorf("Expected status 400, got %d", w.Code)
+	}
+	rec.AssertLogged(zapcore.WarnLevel, "Websocket upgrade failed", map[string]interface{}{"path": "/ws"})
+}
+
+// waitForLog waits for msg to be logged, since connections are logged after
+// the client has seen them close
+func waitForLog(t *testing.T, rec *logtest.Recorder, msg string) {
+	t.Helper()
+	for deadline := time.Now().Add(2 * time.Second); time.Now().Before(deadline); time.Sleep(5 * time.Millisecond) {
+		if len(rec.FilterMessage(msg)) > 0 {
+			return
+		}
+	}
+	t.Fatalf("Expected %q to be logged", msg)
+}
@@ -0,0 +1,267 @@
+package semver
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// ErrInvalidConstraint is returned when a constraint string cannot be parsed
+var ErrInvalidConstraint = errors.New("invalid version constraint")
+
+// bound is a single primitive comparison against a version
+type bound struct {
+	op string
+	v  Version
+}
+
+// matches reports whether v satisfies the bound
+func (b bound) matches(v Version) bool {
+	c := v.Compare(b.v)
+	switch b.op {
+	case "=":
+		return c == 0
+	case "!=":
+		return c != 0
+	case ">":
+		return c > 0
+	case ">=":
+		return c >= 0
+	case "<":
+		return c < 0
+	case "<=":
+		return c <= 0
+	}
+	return false
+}
+
+// Constraint is a set of version requirements such as ">=1.2 <2" or "^1.4 || ^2.0"
+type Constraint struct {
+	raw    string
+	groups [][]bound
+}
+
+// ParseConstraint parses a constraint. Comparators separated by spaces or commas must
+// all match; groups separated by "||" are alternatives. Supported comparators:
+//
+//	=1.2.3  !=1.2.3  >1.2  >=1.2  <2  <=2.1
+//	1.2.x   1.2.*    1.2      (any 1.2 version)
+//	~1.2.3                    (>=1.2.3 <1.3.0)
+//	^1.2.3                    (>=1.2.3 <2.0.0; ^0.2.3 is >=0.2.3 <0.3.0)
+func ParseConstraint(s string) (*Constraint, error) {
+	c := &Constraint{raw: strings.TrimSpace(s)}
+
+	for _, group := range strings.Split(c.raw, "||") {
+		fields := strings.FieldsFunc(group, func(r rune) bool {
+			return r == ' ' || r == ',' || r == '\t' || r == '\n'
+		})
+		if len(fields) == 0 {
+			return nil, fmt.Errorf("%w: %q has an empty group", ErrInvalidConstraint, s)
+		}
+
+		var bounds []bound
+		for i := 0; i < len(fields); i++ {
+			f := fields[i]
+			// Allow a space between the operator and the version, e.g. ">= 1.2"
+			if isOperator(f) && i+1 < len(fields) {
+				i++
+				f += fields[i]
+			}
+
+			bs, err := parseComparator(f)
+			if err != nil {
+				return nil, fmt.Errorf("%w: %q: %v", ErrInvalidConstraint, s, err)
+			}
+			bounds = append(bounds, bs...)
+		}
+		c.groups = append(c.groups, bounds)
+	}
+
+	return c, nil
+}
+
+// MustParseConstraint is like ParseConstraint but panics on error
+func MustParseConstraint(s string) *Constraint {
+	c, err := ParseConstraint(s)
+	if err != nil {
+		panic(err)
+	}
+	return c
+}
+
+// Check reports whether v satisfies the constraint. Prerelease versions only match
+// when a comparator in the same group names a prerelease of the same major.minor.patch,
+// so ">=1.2" does not select "2.0.0-beta.1".
+func (c *Constraint) Check(v Version) bool {
+	for _, group := range c.groups {
+		if groupMatches(group, v) {
+			return true
+		}
+	}
+	return false
+}
+
+// String returns the constraint as it was parsed
+func (c *Constraint) String() string {
+	return c.raw
+}
+
+// Satisfies parses version and constraint and reports whether the version matches
+func Satisfies(version, constraint string) (bool, error) {
+	v, err := Parse(version)
+	if err != nil {
+		return false, err
+	}
+	c, err := ParseConstraint(constraint)
+	if err != nil {
+		return false, err
+	}
+	return c.Check(v), nil
+}
+
+// groupMatches reports whether v satisfies every bound in the group
+func groupMatches(group []bound, v Version) bool {
+	for _, b := range group {
+		if !b.matches(v) {
+			return false
+		}
+	}
+	if !v.IsPrerelease() {
+		return true
+	}
+	for _, b := range group {
+		if b.v.IsPrerelease() && b.v.Major == v.Major && b.v.Minor == v.Minor && b.v.Patch == v.Patch {
+			return true
+		}
+	}
+	return false
+}
+
+// isOperator reports whether s is a bare comparison operator
+func isOperator(s string) bool {
+	switch s {
+	case "=", "==", "!=", ">", ">=", "<", "<=", "~", "^":
+		return true
+	}
+	return false
+}
+
+// parseComparator expands a single comparator into primitive bounds
+func parseComparator(s string) ([]bound, error) {
+	op := ""
+	for _, candidate := range []string{">=", "<=", "!=", "==", ">", "<", "=", "~", "^"} {
+		if strings.HasPrefix(s, candidate) {
+			op = candidate
+			s = s[len(candidate):]
+			break
+		}
+	}
+	if op == "==" {
+		op = "="
+	}
+
+	if s == "*" || s == "x" || s == "X" {
+		if op != "" && op != "=" {
+			return nil, fmt.Errorf("operator %q cannot be used with a wildcard", op)
+		}
+		return []bound{{op: ">=", v: Version{}}}, nil
+	}
+
+	v, specified, err := parsePartial(s)
+	if err != nil {
+		return nil, err
+	}
+
+	// upper returns the exclusive upper bound when only the first n components are fixed
+	upper := func(n int) Version {
+		switch n {
+		case 1:
+			return Version{Major: v.Major + 1}
+		case 2:
+			return Version{Major: v.Major, Minor: v.Minor + 1}
+		}
+		return Version{Major: v.Major, Minor: v.Minor, Patch: v.Patch + 1}
+	}
+
+	switch op {
+	case "", "=":
+		if specified == 3 {
+			return []bound{{"=", v}}, nil
+		}
+		return []bound{{">=", v}, {"<", upper(specified)}}, nil
+	case "!=":
+		if specified != 3 {
+			return nil, errors.New("!= requires a full version")
+		}
+		return []bound{{"!=", v}}, nil
+	case ">":
+		if specified == 3 {
+			return []bound{{">", v}}, nil
+		}
+		return []bound{{">=", upper(specified)}}, nil
+	case ">=":
+		return []bound{{">=", v}}, nil
+	case "<":
+		return []bound{{"<", v}}, nil
+	case "<=":
+		if specified == 3 {
+			return []bound{{"<=", v}}, nil
+		}
+		return []bound{{"<", upper(specified)}}, nil
+	case "~":
+		if specified == 1 {
+			return []bound{{">=", v}, {"<", upper(1)}}, nil
+		}
+		return []bound{{">=", v}, {"<", upper(2)}}, nil
+	case "^":
+		switch {
+		case v.Major > 0 || specified == 1:
+			return []bound{{">=", v}, {"<", upper(1)}}, nil
+		case v.Minor > 0 || specified == 2:
+			return []bound{{">=", v}, {"<", upper(2)}}, nil
+		default:
+			return []bound{{">=", v}, {"<", upper(3)}}, nil
+		}
+	}
+	return nil, fmt.Errorf("unknown operator %q", op)
+}
+
+// parsePartial parses a version that may omit components or use x/* wildcards,
+// returning how many leading components were specified
+func parsePartial(s string) (Version, int, error) {
+	core := strings.TrimPrefix(s, "v")
+	suffix := ""
+	if i := strings.IndexAny(core, "-+"); i >= 0 {
+		core, suffix = core[:i], core[i:]
+	}
+
+	parts := strings.Split(core, ".")
+	if len(parts) > 3 {
+		return Version{}, 0, fmt.Errorf("invalid version %q", s)
+	}
+
+	specified := 0
+	for _, p := range parts {
+		if p == "x" || p == "X" || p == "*" {
+			break
+		}
+		specified++
+	}
+	for _, p := range parts[specified:] {
+		if p != "x" && p != "X" && p != "*" {
+			return Version{}, 0, fmt.Errorf("invalid version %q", s)
+		}
+	}
+	if specified == 0 {
+		return Version{}, 0, fmt.Errorf("invalid version %q", s)
+	}
+	if specified < 3 && suffix != "" {
+		return Version{}, 0, fmt.Errorf("prerelease requires a full version in %q", s)
+	}
+
+	v, err := Parse(strings.Join(parts[:specified], ".") + suffix)
+	if err != nil {
+		return Version{}, 0, err
+	}
+	return v, specified, nil
+}
@@ -0,0 +1,69 @@
+package semver
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestConstraint_Check(t *testing.T) {
+	tests := []struct {
+		constraint string
+		version    string
+		want       bool
+	}{
+		{">=1.2 <2", "1.2.0", true},
+		{">=1.2 <2", "1.9.9", true},
+		{">=1.2 <2", "2.0.0", false},
+		{">=1.2 <2", "1.1.9", false},
+		{"\n>=1.2 <2", "1.5.0", true},
+		{">=1.2, <2", "1.5.0", true},
+		{">= 1.2 < 2", "1.5.0", true},
+		{"=1.2.3", "1.2.3", true},
+		{"1.2.3", "1.2.4", false},
+		{"1.2", "1.2.9", true},
+		{"1.2.x", "1.3.0", false},
+		{"1.*", "1.9.0", true},
+		{"*", "9.9.9", true},
+		{"!=1.2.3", "1.2.3", false},
+		{">1.2", "1.2.9", false},
+		{">1.2", "1.3.0", true},
+		{"<=1.2", "1.2.9", true},
+		{"<=1.2", "1.3.0", false},
+		{"~1.2.3", "1.2.9", true},
+		{"~1.2.3", "1.3.0", false},
+		{"~1", "1.9.0", true},
+		{"^1.2.3", "1.9.0", true},
+		{"^1.2.3", "2.0.0", false},
+		{"^0.2.3", "0.2.9", true},
+		{"^0.2.3", "0.3.0", false},
+		{"^0.0.3", "0.0.4", false},
+		{"^1.4 || ^2.0", "2.5.0", true},
+		{"^1.4 || ^2.0", "3.0.0", false},
+		{">=1.2", "2.0.0-beta.1", false},
+		{">=2.0.0-beta.1", "2.0.0-beta.2", true},
+		{">=2.0.0-beta.1", "2.1.0-beta.1", false},
+		{"^1.0.0-rc.1", "1.0.0", true},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.constraint+" "+tc.version, func(t *testing.T) {
+			got, err := Satisfies(tc.version, tc.constraint)
+			if err != nil {
+				t.Fatalf("Satisfies() error = %v", err)
+			}
+			if got != tc.want {
+				t.Errorf("Expected %s to satisfy %q = %v", tc.version, tc.constraint, tc.want)
+			}
+		})
+	}
+}
+
+func TestParseConstraint_Errors(t *testing.T) {
+	for _, s := range []string{"", ">=", "~>1.2", ">=1.2 ||", "1.x.3", "!=1.2", ">*", "1.2-beta", "banana"} {
+		t.Run(s, func(t *testing.T) {
+			if _, err := ParseConstraint(s); !errors.Is(err, ErrInvalidConstraint) {
+				t.Errorf("Expected ErrInvalidConstraint, got %v", err)
+			}
+		})
+	}
+}
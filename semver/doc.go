@@ -0,0 +1,58 @@
+/*
+Package semver provides semantic version parsing, comparison, sorting, and
+constraint matching following https://semver.org.
+
+# Features
+
+  - Parse versions with an optional "v" prefix, prerelease, and build metadata
+  - Precedence comparison per section 11 of the specification
+  - Constraints with comparison operators, wildcards, tilde, and caret ranges
+  - Alternatives with "||"
+  - Sort and Latest helpers
+  - Text and JSON marshaling
+
+# Basic Usage
+
+Comparing versions:
+
+	current := semver.MustParse("1.4.2")
+	latest, err := semver.Parse(release.Tag) // "v1.5.0"
+	if err != nil {
+		return err
+	}
+	if current.LessThan(latest) {
+		log.Info("Update available")
+	}
+
+Enforcing a minimum client version:
+
+	supported := semver.MustParseConstraint(">=2.3 <4")
+
+	v, err := semver.Parse(r.Header.Get("X-Client-Version"))
+	if err != nil || !supported.Check(v) {
+		return api.NewError(http.StatusUpgradeRequired, errors.New("please update your app"))
+	}
+
+# Constraint Syntax
+
+Comparators separated by spaces or commas must all match. Groups separated by
+"||" are alternatives:
+
+	=1.2.3   !=1.2.3
+	>1.2     >=1.2     <2     <=2.1
+	1.2.x    1.2.*     1.2            any 1.2 version
+	~1.2.3                            >=1.2.3 <1.3.0
+	^1.2.3                            >=1.2.3 <2.0.0
+	^0.2.3                            >=0.2.3 <0.3.0
+	^1.4 || ^2.0                      either major version
+
+Missing components are treated as zero, so ">=1.2" means ">=1.2.0".
+
+# Prereleases
+
+Prerelease versions only satisfy a constraint when a comparator in the same
+group names a prerelease of the same major.minor.patch. ">=1.2" does not match
+"2.0.0-beta.1", but ">=2.0.0-beta.1" matches "2.0.0-beta.2". This keeps
+prereleases from being selected unless they are asked for explicitly.
+*/
+package semver
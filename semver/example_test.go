@@ -0,0 +1,31 @@
+package semver_test
+
+import (
+	"fmt"
+
+	"github.com/StairSupplies/go-core/semver"
+)
+
+func ExampleConstraint_Check() {
+	minimum := semver.MustParseConstraint(">=1.4 <2")
+
+	for _, v := range []string{"1.3.9", "1.4.0", "2.0.0"} {
+		fmt.Println(v, minimum.Check(semver.MustParse(v)))
+	}
+	// Output:
+	// 1.3.9 false
+	// 1.4.0 true
+	// 2.0.0 false
+}
+
+func ExampleSort() {
+	versions := []semver.Version{
+		semver.MustParse("1.10.0"),
+		semver.MustParse("1.2.0"),
+		semver.MustParse("1.2.0-rc.1"),
+	}
+
+	semver.Sort(versions)
+	fmt.Println(versions)
+	// Output: [1.2.0-rc.1 1.2.0 1.10.0]
+}
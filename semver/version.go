@@ -0,0 +1,233 @@
+package semver
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// ErrInvalidVersion is returned when a string is not a valid semantic version
+var ErrInvalidVersion = errors.New("invalid semantic version")
+
+// Version is a semantic version as defined by https://semver.org
+type Version struct {
+	Major      uint64
+	Minor      uint64
+	Patch      uint64
+	Prerelease string
+	Build      string
+}
+
+// Parse parses a semantic version such as "1.4.2", "v2.0.0-rc.1", or "1.0.0+build.5".
+// A leading "v" is allowed. Missing minor and patch numbers ("1", "1.2") default to zero.
+func Parse(s string) (Version, error) {
+	orig := s
+	s = strings.TrimPrefix(strings.TrimSpace(s), "v")
+	if s == "" {
+		return Version{}, fmt.Errorf("%w: %q", ErrInvalidVersion, orig)
+	}
+
+	var v Version
+	if i := strings.IndexByte(s, '+'); i >= 0 {
+		v.Build = s[i+1:]
+		s = s[:i]
+		if !validIdentifiers(v.Build, false) {
+			return Version{}, fmt.Errorf("%w: %q has invalid build metadata", ErrInvalidVersion, orig)
+		}
+	}
+	if i := strings.IndexByte(s, '-'); i >= 0 {
+		v.Prerelease = s[i+1:]
+		s = s[:i]
+		if !validIdentifiers(v.Prerelease, true) {
+			return Version{}, fmt.Errorf("%w: %q has an invalid prerelease", ErrInvalidVersion, orig)
+		}
+	}
+
+	parts := strings.Split(s, ".")
+	if len(parts) > 3 {
+		return Version{}, fmt.Errorf("%w: %q", ErrInvalidVersion, orig)
+	}
+	nums := make([]uint64, 3)
+	for i, p := range parts {
+		n, err := parseNumber(p)
+		if err != nil {
+			return Version{}, fmt.Errorf("%w: %q", ErrInvalidVersion, orig)
+		}
+		nums[i] = n
+	}
+	v.Major, v.Minor, v.Patch = nums[0], nums[1], nums[2]
+
+	return v, nil
+}
+
+// MustParse is like Parse but panics on error
+func MustParse(s string) Version {
+	v, err := Parse(s)
+	if err != nil {
+		panic(err)
+	}
+	return v
+}
+
+// String returns the canonical form without a "v" prefix, e.g. "1.4.2-rc.1+build.5"
+func (v Version) String() string {
+	s := fmt.Sprintf("%d.%d.%d", v.Major, v.Minor, v.Patch)
+	if v.Prerelease != "" {
+		s += "-" + v.Prerelease
+	}
+	if v.Build != "" {
+		s += "+" + v.Build
+	}
+	return s
+}
+
+// Compare returns -1, 0, or 1 if v has lower, equal, or higher precedence than o.
+// Build metadata is ignored, as required by the specification.
+func (v Version) Compare(o Version) int {
+	if c := compareUint(v.Major, o.Major); c != 0 {
+		return c
+	}
+	if c := compareUint(v.Minor, o.Minor); c != 0 {
+		return c
+	}
+	if c := compareUint(v.Patch, o.Patch); c != 0 {
+		return c
+	}
+	return comparePrerelease(v.Prerelease, o.Prerelease)
+}
+
+// LessThan reports whether v has lower precedence than o
+func (v Version) LessThan(o Version) bool {
+	return v.Compare(o) < 0
+}
+
+// GreaterThan reports whether v has higher precedence than o
+func (v Version) GreaterThan(o Version) bool {
+	return v.Compare(o) > 0
+}
+
+// Equal reports whether v and o have the same precedence
+func (v Version) Equal(o Version) bool {
+	return v.Compare(o) == 0
+}
+
+// IsPrerelease reports whether v has a prerelease component
+func (v Version) IsPrerelease() bool {
+	return v.Prerelease != ""
+}
+
+// MarshalText implements encoding.TextMarshaler
+func (v Version) MarshalText() ([]byte, error) {
+	return []byte(v.String()), nil
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler
+func (v *Version) UnmarshalText(text []byte) error {
+	parsed, err := Parse(string(text))
+	if err != nil {
+		return err
+	}
+	*v = parsed
+	return nil
+}
+
+// Sort sorts versions in ascending order of precedence
+func Sort(versions []Version) {
+	sort.SliceStable(versions, func(i, j int) bool {
+		return versions[i].LessThan(versions[j])
+	})
+}
+
+// Latest returns the highest version, or false if versions is empty
+func Latest(versions []Version) (Version, bool) {
+	if len(versions) == 0 {
+		return Version{}, false
+	}
+	latest := versions[0]
+	for _, v := range versions[1:] {
+		if v.GreaterThan(latest) {
+			latest = v
+		}
+	}
+	return latest, true
+}
+
+// parseNumber parses a numeric version component without leading zeros
+func parseNumber(s string) (uint64, error) {
+	if s == "" || (len(s) > 1 && s[0] == '0') {
+		return 0, ErrInvalidVersion
+	}
+	return strconv.ParseUint(s, 10, 64)
+}
+
+// validIdentifiers checks dot-separated prerelease or build identifiers
+func validIdentifiers(s string, prerelease bool) bool {
+	if s == "" {
+		return false
+	}
+	for _, id := range strings.Split(s, ".") {
+		if id == "" {
+			return false
+		}
+		numeric := true
+		for _, r := range id {
+			switch {
+			case r >= '0' && r <= '9':
+			case r >= 'a' && r <= 'z', r >= 'A' && r <= 'Z', r == '-':
+				numeric = false
+			default:
+				return false
+			}
+		}
+		if prerelease && numeric && len(id) > 1 && id[0] == '0' {
+			return false
+		}
+	}
+	return true
+}
+
+// compareUint compares two unsigned integers
+func compareUint(a, b uint64) int {
+	switch {
+	case a < b:
+		return -1
+	case a > b:
+		return 1
+	}
+	return 0
+}
+
+// comparePrerelease implements prerelease precedence from section 11 of the specification
+func comparePrerelease(a, b string) int {
+	switch {
+	case a == b:
+		return 0
+	case a == "":
+		return 1
+	case b == "":
+		return -1
+	}
+
+	as, bs := strings.Split(a, "."), strings.Split(b, ".")
+	for i := 0; i < len(as) && i < len(bs); i++ {
+		an, aErr := strconv.ParseUint(as[i], 10, 64)
+		bn, bErr := strconv.ParseUint(bs[i], 10, 64)
+		switch {
+		case aErr == nil && bErr == nil:
+			if c := compareUint(an, bn); c != 0 {
+				return c
+			}
+		case aErr == nil:
+			return -1
+		case bErr == nil:
+			return 1
+		default:
+			if c := strings.Compare(as[i], bs[i]); c != 0 {
+				return c
+			}
+		}
+	}
+	return compareUint(uint64(len(as)), uint64(len(bs)))
+}
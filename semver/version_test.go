@@ -0,0 +1,119 @@
+package semver
+
+import (
+	"encoding/json"
+	"errors"
+	"reflect"
+	"testing"
+)
+
+func TestParse(t *testing.T) {
+	tests := []struct {
+		input   string
+		want    Version
+		wantErr bool
+	}{
+		{"1.2.3", Version{Major: 1, Minor: 2, Patch: 3}, false},
+		{"v2.0.0", Version{Major: 2}, false},
+		{"1.2", Version{Major: 1, Minor: 2}, false},
+		{"3", Version{Major: 3}, false},
+		{"1.0.0-rc.1", Version{Major: 1, Prerelease: "rc.1"}, false},
+		{"1.0.0-alpha-1+build.5", Version{Major: 1, Prerelease: "alpha-1", Build: "build.5"}, false},
+		{"1.0.0+20240301", Version{Major: 1, Build: "20240301"}, false},
+		{"", Version{}, true},
+		{"01.2.3", Version{}, true},
+		{"1.2.3.4", Version{}, true},
+		{"1.2.x", Version{}, true},
+		{"1.0.0-01", Version{}, true},
+		{"1.0.0-", Version{}, true},
+		{"1.0.0-rc..1", Version{}, true},
+		{"1.0.0+build!", Version{}, true},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.input, func(t *testing.T) {
+			got, err := Parse(tc.input)
+			if tc.wantErr {
+				if !errors.Is(err, ErrInvalidVersion) {
+					t.Errorf("Expected ErrInvalidVersion, got %v", err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Parse() error = %v", err)
+			}
+			if got != tc.want {
+				t.Errorf("Expected %+v, got %+v", tc.want, got)
+			}
+		})
+	}
+}
+
+func TestVersion_Compare(t *testing.T) {
+	// Precedence order from section 11 of the specification
+	ordered := []string{
+		"1.0.0-alpha",
+		"1.0.0-alpha.1",
+		"1.0.0-alpha.beta",
+		"1.0.0-beta",
+		"1.0.0-beta.2",
+		"1.0.0-beta.11",
+		"1.0.0-rc.1",
+		"1.0.0",
+		"1.0.1",
+		"1.1.0",
+		"2.0.0",
+	}
+
+	for i := 0; i < len(ordered)-1; i++ {
+		a, b := MustParse(ordered[i]), MustParse(ordered[i+1])
+		if !a.LessThan(b) || !b.GreaterThan(a) {
+			t.Errorf("Expected %s < %s", a, b)
+		}
+	}
+
+	if !MustParse("1.0.0+build.1").Equal(MustParse("1.0.0+build.2")) {
+		t.Error("Expected build metadata to be ignored")
+	}
+}
+
+func TestSort(t *testing.T) {
+	versions := []Version{MustParse("1.10.0"), MustParse("1.2.0"), MustParse("1.2.0-rc.1"), MustParse("0.9.9")}
+	Sort(versions)
+
+	got := make([]string, len(versions))
+	for i, v := range versions {
+		got[i] = v.String()
+	}
+	want := []string{"0.9.9", "1.2.0-rc.1", "1.2.0", "1.10.0"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("Expected %v, got %v", want, got)
+	}
+
+	if latest, ok := Latest(versions); !ok || latest.String() != "1.10.0" {
+		t.Errorf("Expected latest 1.10.0, got %v", latest)
+	}
+	if _, ok := Latest(nil); ok {
+		t.Error("Expected no latest version for empty input")
+	}
+}
+
+func TestVersion_JSON(t *testing.T) {
+	type release struct {
+		Version Version `json:"version"`
+	}
+
+	var r release
+	if err := json.Unmarshal([]byte(`{"version":"v1.4.2-rc.1"}`), &r); err != nil {
+		t.Fatalf("Unmarshal() error = %v", err)
+	}
+
+	data, _ := json.Marshal(r)
+	if string(data) != `{"version":"1.4.2-rc.1"}` {
+		t.Errorf("Unexpected JSON %s", data)
+	}
+
+	if err := json.Unmarshal([]byte(`{"version":"banana"}`), &r); err == nil {
+		t.Error("Expected error for invalid version")
+	}
+}
@@ -0,0 +1,47 @@
+/*
+Package sliceutils provides generic helpers for working with slices.
+
+These functions replace the hand-written loops and per-service utils.go copies
+that tend to accumulate for mapping, filtering, and batching collections. The
+standard library slices package covers sorting and searching; sliceutils covers
+the transformations it leaves out.
+
+# Features
+
+  - Map, Filter, and Reduce for transforming slices
+  - Unique and UniqueBy for order-preserving deduplication
+  - Chunk for batching work such as bulk inserts or API calls
+  - GroupBy for bucketing elements by key
+  - Difference and Intersect for set-style comparisons
+  - Paginate for serving a page of an in-memory result set
+
+# Basic Usage
+
+Transforming and filtering:
+
+	ids := sliceutils.Map(orders, func(o Order) int { return o.ID })
+	open := sliceutils.Filter(orders, func(o Order) bool { return o.Status == "open" })
+	total := sliceutils.Reduce(orders, 0, func(sum int, o Order) int { return sum + o.Total })
+
+Batching:
+
+	for _, batch := range sliceutils.Chunk(ids, 100) {
+		if err := repo.ArchiveOrders(ctx, batch); err != nil {
+			return err
+		}
+	}
+
+Grouping:
+
+	byCustomer := sliceutils.GroupBy(orders, func(o Order) string { return o.CustomerID })
+
+Comparing:
+
+	added := sliceutils.Difference(newIDs, oldIDs)
+	removed := sliceutils.Difference(oldIDs, newIDs)
+
+Paginating with 1-based page numbers:
+
+	page := sliceutils.Paginate(results, 2, 25) // results 26-50
+*/
+package sliceutils
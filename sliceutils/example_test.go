@@ -0,0 +1,56 @@
+package sliceutils_test
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/StairSupplies/go-core/sliceutils"
+)
+
+type product struct {
+	SKU      string
+	Category string
+	Price    int
+}
+
+func ExampleMap() {
+	skus := sliceutils.Map([]product{
+		{SKU: "TRD-36"},
+		{SKU: "RSR-36"},
+	}, func(p product) string { return p.SKU })
+
+	fmt.Println(strings.Join(skus, ","))
+	// Output: TRD-36,RSR-36
+}
+
+func ExampleGroupBy() {
+	products := []product{
+		{SKU: "TRD-36", Category: "treads", Price: 4500},
+		{SKU: "TRD-42", Category: "treads", Price: 5200},
+		{SKU: "RSR-36", Category: "risers", Price: 1800},
+	}
+
+	byCategory := sliceutils.GroupBy(products, func(p product) string { return p.Category })
+	fmt.Println(len(byCategory["treads"]), len(byCategory["risers"]))
+	// Output: 2 1
+}
+
+func ExampleChunk() {
+	ids := []int{1, 2, 3, 4, 5}
+
+	for _, batch := range sliceutils.Chunk(ids, 2) {
+		fmt.Println(batch)
+	}
+	// Output:
+	// [1 2]
+	// [3 4]
+	// [5]
+}
+
+func ExampleReduce() {
+	prices := []int{4500, 5200, 1800}
+
+	total := sliceutils.Reduce(prices, 0, func(sum, price int) int { return sum + price })
+	fmt.Println(total)
+	// Output: 11500
+}
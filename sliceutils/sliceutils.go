@@ -0,0 +1,144 @@
+package sliceutils
+
+// Map returns a new slice containing fn applied to each element of s
+func Map[T, U any](s []T, fn func(T) U) []U {
+	if s == nil {
+		return nil
+	}
+	out := make([]U, len(s))
+	for i, v := range s {
+		out[i] = fn(v)
+	}
+	return out
+}
+
+// Filter returns a new slice containing the elements of s for which keep returns true
+func Filter[T any](s []T, keep func(T) bool) []T {
+	if s == nil {
+		return nil
+	}
+	out := make([]T, 0, len(s))
+	for _, v := range s {
+		if keep(v) {
+			out = append(out, v)
+		}
+	}
+	return out
+}
+
+// Reduce folds s into a single value, starting from initial
+func Reduce[T, A any](s []T, initial A, fn func(acc A, v T) A) A {
+	acc := initial
+	for _, v := range s {
+		acc = fn(acc, v)
+	}
+	return acc
+}
+
+// Unique returns the elements of s with duplicates removed, preserving first occurrence order
+func Unique[T comparable](s []T) []T {
+	return UniqueBy(s, func(v T) T { return v })
+}
+
+// UniqueBy returns the elements of s with duplicate keys removed, preserving first occurrence order
+func UniqueBy[T any, K comparable](s []T, key func(T) K) []T {
+	if s == nil {
+		return nil
+	}
+	seen := make(map[K]struct{}, len(s))
+	out := make([]T, 0, len(s))
+	for _, v := range s {
+		k := key(v)
+		if _, ok := seen[k]; ok {
+			continue
+		}
+		seen[k] = struct{}{}
+		out = append(out, v)
+	}
+	return out
+}
+
+// Chunk splits s into consecutive slices of at most size elements.
+// The chunks share s's backing array. Chunk panics if size is less than 1.
+func Chunk[T any](s []T, size int) [][]T {
+	if size < 1 {
+		panic("sliceutils: chunk size must be positive")
+	}
+	chunks := make([][]T, 0, (len(s)+size-1)/size)
+	for size < len(s) {
+		chunks = append(chunks, s[:size:size])
+		s = s[size:]
+	}
+	if len(s) > 0 {
+		chunks = append(chunks, s)
+	}
+	return chunks
+}
+
+// GroupBy groups the elements of s by key, preserving the order of elements within each group
+func GroupBy[T any, K comparable](s []T, key func(T) K) map[K][]T {
+	groups := make(map[K][]T)
+	for _, v := range s {
+		k := key(v)
+		groups[k] = append(groups[k], v)
+	}
+	return groups
+}
+
+// Difference returns the elements of a that are not in b, preserving a's order
+func Difference[T comparable](a, b []T) []T {
+	exclude := toSet(b)
+	return Filter(a, func(v T) bool {
+		_, ok := exclude[v]
+		return !ok
+	})
+}
+
+// Intersect returns the unique elements of a that are also in b, preserving a's order
+func Intersect[T comparable](a, b []T) []T {
+	include := toSet(b)
+	return Unique(Filter(a, func(v T) bool {
+		_, ok := include[v]
+		return ok
+	}))
+}
+
+// Contains reports whether any element of s satisfies fn
+func Contains[T any](s []T, fn func(T) bool) bool {
+	for _, v := range s {
+		if fn(v) {
+			return true
+		}
+	}
+	return false
+}
+
+// Paginate returns the elements on the given 1-based page of pageSize elements.
+// Pages past the end return an empty slice. Page and pageSize values below 1 are treated as 1.
+func Paginate[T any](s []T, page, pageSize int) []T {
+	if page < 1 {
+		page = 1
+	}
+	if pageSize < 1 {
+		pageSize = 1
+	}
+
+	start := (page - 1) * pageSize
+	if start >= len(s) || start < 0 {
+		return []T{}
+	}
+	end := start + pageSize
+	if end > len(s) || end < 0 {
+		end = len(s)
+	}
+	return s[start:end]
+}
+
+// toSet builds a set from the elements of s
+func toSet[T comparable](s []T) map[T]struct{} {
+	set := make(map[T]struct{}, len(s))
+	for _, v := range s {
+		set[v] = struct{}{}
+	}
+	return set
+}
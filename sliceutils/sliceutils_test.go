@@ -0,0 +1,144 @@
+package sliceutils
+
+import (
+	"reflect"
+	"strconv"
+	"testing"
+)
+
+func TestMap(t *testing.T) {
+	got := Map([]int{1, 2, 3}, strconv.Itoa)
+	if !reflect.DeepEqual(got, []string{"1", "2", "3"}) {
+		t.Errorf("Expected [1 2 3], got %v", got)
+	}
+	if Map[int, string](nil, strconv.Itoa) != nil {
+		t.Error("Expected nil for nil input")
+	}
+}
+
+func TestFilter(t *testing.T) {
+	got := Filter([]int{1, 2, 3, 4, 5}, func(v int) bool { return v%2 == 1 })
+	if !reflect.DeepEqual(got, []int{1, 3, 5}) {
+		t.Errorf("Expected [1 3 5], got %v", got)
+	}
+}
+
+func TestReduce(t *testing.T) {
+	got := Reduce([]int{1, 2, 3, 4}, 0, func(acc, v int) int { return acc + v })
+	if got != 10 {
+		t.Errorf("Expected 10, got %d", got)
+	}
+}
+
+func TestUnique(t *testing.T) {
+	got := Unique([]string{"b", "a", "b", "c", "a"})
+	if !reflect.DeepEqual(got, []string{"b", "a", "c"}) {
+		t.Errorf("Expected [b a c], got %v", got)
+	}
+
+	type user struct {
+		ID   int
+		Name string
+	}
+	users := UniqueBy([]user{{1, "a"}, {2, "b"}, {1, "c"}}, func(u user) int { return u.ID })
+	if len(users) != 2 || users[0].Name != "a" {
+		t.Errorf("Expected first occurrence kept, got %v", users)
+	}
+}
+
+func TestChunk(t *testing.T) {
+	tests := []struct {
+		name  string
+		input []int
+		size  int
+		want  [][]int
+	}{
+		{"even", []int{1, 2, 3, 4}, 2, [][]int{{1, 2}, {3, 4}}},
+		{"remainder", []int{1, 2, 3, 4, 5}, 2, [][]int{{1, 2}, {3, 4}, {5}}},
+		{"larger than input", []int{1, 2}, 5, [][]int{{1, 2}}},
+		{"empty", []int{}, 3, [][]int{}},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			got := Chunk(tc.input, tc.size)
+			if !reflect.DeepEqual(got, tc.want) {
+				t.Errorf("Expected %v, got %v", tc.want, got)
+			}
+		})
+	}
+
+	// Appending to a chunk must not overwrite the next chunk
+	s := []int{1, 2, 3, 4}
+	chunks := Chunk(s, 2)
+	_ = append(chunks[0], 99)
+	if s[2] != 3 {
+		t.Errorf("Expected append to a chunk not to modify the source, got %v", s)
+	}
+
+	defer func() {
+		if recover() == nil {
+			t.Error("Expected panic for zero size")
+		}
+	}()
+	Chunk(s, 0)
+}
+
+func TestGroupBy(t *testing.T) {
+	got := GroupBy([]string{"apple", "avocado", "banana", "blueberry", "cherry"}, func(s string) byte { return s[0] })
+	want := map[byte][]string{
+		'a': {"apple", "avocado"},
+		'b': {"banana", "blueberry"},
+		'c': {"cherry"},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("Expected %v, got %v", want, got)
+	}
+}
+
+func TestDifferenceAndIntersect(t *testing.T) {
+	a := []int{1, 2, 3, 4, 2}
+	b := []int{2, 4, 6}
+
+	if got := Difference(a, b); !reflect.DeepEqual(got, []int{1, 3}) {
+		t.Errorf("Difference() = %v, want [1 3]", got)
+	}
+	if got := Intersect(a, b); !reflect.DeepEqual(got, []int{2, 4}) {
+		t.Errorf("Intersect() = %v, want [2 4]", got)
+	}
+}
+
+func TestContains(t *testing.T) {
+	if !Contains([]int{1, 2, 3}, func(v int) bool { return v == 2 }) {
+		t.Error("Expected Contains to find 2")
+	}
+	if Contains([]int{1, 2, 3}, func(v int) bool { return v > 3 }) {
+		t.Error("Expected Contains not to match")
+	}
+}
+
+func TestPaginate(t *testing.T) {
+	items := []int{1, 2, 3, 4, 5, 6, 7}
+
+	tests := []struct {
+		name     string
+		page     int
+		pageSize int
+		want     []int
+	}{
+		{"first page", 1, 3, []int{1, 2, 3}},
+		{"last partial page", 3, 3, []int{7}},
+		{"past the end", 4, 3, []int{}},
+		{"page below one", 0, 3, []int{1, 2, 3}},
+		{"page size below one", 2, 0, []int{2}},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			got := Paginate(items, tc.page, tc.pageSize)
+			if !reflect.DeepEqual(got, tc.want) {
+				t.Errorf("Expected %v, got %v", tc.want, got)
+			}
+		})
+	}
+}
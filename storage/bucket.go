@@ -0,0 +1,346 @@
+package storage
+
+import (
+	"bytes"
+	"context"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/StairSupplies/go-core/internal/sigv4"
+)
+
+const (
+	// DefaultPartSize is the part size used for multipart uploads
+	DefaultPartSize = 8 << 20
+	// minPartSize is the smallest part size accepted by S3 and Cloud Storage
+	minPartSize = 5 << 20
+)
+
+// bucketClient talks to an S3-compatible XML object API. It backs both
+// S3Store and GCSStore, which differ only in endpoint and signing dialect.
+type bucketClient struct {
+	endpoint   *url.URL
+	bucket     string
+	pathStyle  bool
+	partSize   int
+	httpClient *http.Client
+	signer     *sigv4.Signer
+	now        func() time.Time
+}
+
+// apiError is the XML error document returned by the object API
+type apiError struct {
+	Code    string `xml:"Code"`
+	Message string `xml:"Message"`
+}
+
+// objectURL returns the URL for key with the given query string
+func (c *bucketClient) objectURL(key string, query url.Values) *url.URL {
+	u := *c.endpoint
+	if c.pathStyle {
+		u.Path = "/" + c.bucket + "/" + key
+	} else {
+		u.Host = c.bucket + "." + u.Host
+		u.Path = "/" + key
+	}
+	u.RawPath = sigv4.EscapePath(u.Path)
+	if query != nil {
+		u.RawQuery = sigv4.CanonicalQuery(query)
+	}
+	return &u
+}
+
+// do signs and sends a request, converting error responses into Go errors
+func (c *bucketClient) do(ctx context.Context, method string, u *url.URL, body io.Reader, size int64, header http.Header) (*http.Response, error) {
+	req, err := http.NewRequestWithContext(ctx, method, u.String(), body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+	for k, v := range header {
+		req.Header[k] = v
+	}
+	if body != nil {
+		req.ContentLength = size
+	}
+
+	payloadHash := sigv4.EmptyPayloadHash
+	if body != nil {
+		payloadHash = sigv4.UnsignedPayload
+	}
+	c.signer.Sign(req, payloadHash, c.now())
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		defer resp.Body.Close()
+		data, _ := io.ReadAll(io.LimitReader(resp.Body, 64<<10))
+
+		var apiErr apiError
+		xml.Unmarshal(data, &apiErr)
+
+		if resp.StatusCode == http.StatusNotFound && apiErr.Code != "NoSuchBucket" {
+			return nil, fmt.Errorf("%w: %s", ErrNotFound, strings.TrimPrefix(u.Path, "/"))
+		}
+		if apiErr.Code != "" {
+			return nil, fmt.Errorf("storage: %s %s: %s: %s (%d)", method, u.Path, apiErr.Code, apiErr.Message, resp.StatusCode)
+		}
+		return nil, fmt.Errorf("storage: %s %s: unexpected status %d", method, u.Path, resp.StatusCode)
+	}
+
+	return resp, nil
+}
+
+// putHeader builds the content-type and metadata headers for an upload
+func (c *bucketClient) putHeader(o PutOptions) http.Header {
+	header := http.Header{}
+	header.Set("Content-Type", o.ContentType)
+	for k, v := range o.Metadata {
+		header.Set(c.signer.HeaderPrefix()+"meta-"+k, v)
+	}
+	return header
+}
+
+// Put uploads the object. Payloads larger than one part are sent as a
+// multipart upload so that at most one part is held in memory.
+func (c *bucketClient) Put(ctx context.Context, key string, r io.Reader, opts ...PutOption) error {
+	key, err := cleanKey(key)
+	if err != nil {
+		return err
+	}
+	o := newPutOptions(key, opts)
+
+	buf := make([]byte, c.partSize)
+	n, err := io.ReadFull(r, buf)
+	switch {
+	case errors.Is(err, io.EOF), errors.Is(err, io.ErrUnexpectedEOF):
+		resp, err := c.do(ctx, http.MethodPut, c.objectURL(key, nil), bytes.NewReader(buf[:n]), int64(n), c.putHeader(o))
+		if err != nil {
+			return err
+		}
+		resp.Body.Close()
+		return nil
+	case err != nil:
+		return fmt.Errorf("failed to read object body: %w", err)
+	}
+
+	return c.putMultipart(ctx, key, buf, r, o)
+}
+
+// putMultipart uploads first followed by the remainder of r in parts
+func (c *bucketClient) putMultipart(ctx context.Context, key string, first []byte, r io.Reader, o PutOptions) error {
+	resp, err := c.do(ctx, http.MethodPost, c.objectURL(key, url.Values{"uploads": {""}}), nil, 0, c.putHeader(o))
+	if err != nil {
+		return err
+	}
+	var initiated struct {
+		UploadID string `xml:"UploadId"`
+	}
+	err = xml.NewDecoder(resp.Body).Decode(&initiated)
+	resp.Body.Close()
+	if err != nil {
+		return fmt.Errorf("failed to decode multipart upload response: %w", err)
+	}
+
+	type part struct {
+		PartNumber int    `xml:"PartNumber"`
+		ETag       string `xml:"ETag"`
+	}
+	var parts []part
+
+	abort := func(cause error) error {
+		// Use a fresh context so cancellation does not leave the upload dangling
+		abortCtx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+		defer cancel()
+		if resp, err := c.do(abortCtx, http.MethodDelete, c.objectURL(key, url.Values{"uploadId": {initiated.UploadID}}), nil, 0, nil); err == nil {
+			resp.Body.Close()
+		}
+		return cause
+	}
+
+	buf := first
+	for number := 1; ; number++ {
+		query := url.Values{
+			"partNumber": {strconv.Itoa(number)},
+			"uploadId":   {initiated.UploadID},
+		}
+		resp, err := c.do(ctx, http.MethodPut, c.objectURL(key, query), bytes.NewReader(buf), int64(len(buf)), nil)
+		if err != nil {
+			return abort(err)
+		}
+		resp.Body.Close()
+		parts = append(parts, part{PartNumber: number, ETag: resp.Header.Get("ETag")})
+
+		buf = first[:cap(first)]
+		n, err := io.ReadFull(r, buf)
+		if n == 0 && (errors.Is(err, io.EOF) || errors.Is(err, io.ErrUnexpectedEOF)) {
+			break
+		}
+		if err != nil && !errors.Is(err, io.ErrUnexpectedEOF) {
+			return abort(fmt.Errorf("failed to read object body: %w", err))
+		}
+		buf = buf[:n]
+	}
+
+	complete, err := xml.Marshal(struct {
+		XMLName xml.Name `xml:"CompleteMultipartUpload"`
+		Parts   []part   `xml:"Part"`
+	}{Parts: parts})
+	if err != nil {
+		return abort(fmt.Errorf("failed to encode multipart completion: %w", err))
+	}
+
+	resp, err = c.do(ctx, http.MethodPost, c.objectURL(key, url.Values{"uploadId": {initiated.UploadID}}),
+		bytes.NewReader(complete), int64(len(complete)), http.Header{"Content-Type": {"application/xml"}})
+	if err != nil {
+		return abort(err)
+	}
+	resp.Body.Close()
+
+	return nil
+}
+
+// Get downloads the object, streaming the response body to the caller
+func (c *bucketClient) Get(ctx context.Context, key string) (io.ReadCloser, *ObjectInfo, error) {
+	key, err := cleanKey(key)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	resp, err := c.do(ctx, http.MethodGet, c.objectURL(key, nil), nil, 0, nil)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	info := &ObjectInfo{
+		Key:         key,
+		Size:        resp.ContentLength,
+		ContentType: resp.Header.Get("Content-Type"),
+		ETag:        resp.Header.Get("ETag"),
+	}
+	if lm, err := http.ParseTime(resp.Header.Get("Last-Modified")); err == nil {
+		info.LastModified = lm
+	}
+
+	metaPrefix := c.signer.HeaderPrefix() + "meta-"
+	for name, values := range resp.Header {
+		lower := strings.ToLower(name)
+		if strings.HasPrefix(lower, metaPrefix) && len(values) > 0 {
+			if info.Metadata == nil {
+				info.Metadata = make(map[string]string)
+			}
+			info.Metadata[strings.TrimPrefix(lower, metaPrefix)] = values[0]
+		}
+	}
+
+	return resp.Body, info, nil
+}
+
+// Delete removes the object
+func (c *bucketClient) Delete(ctx context.Context, key string) error {
+	key, err := cleanKey(key)
+	if err != nil {
+		return err
+	}
+
+	resp, err := c.do(ctx, http.MethodDelete, c.objectURL(key, nil), nil, 0, nil)
+	if err != nil {
+		if errors.Is(err, ErrNotFound) {
+			return nil
+		}
+		return err
+	}
+	resp.Body.Close()
+
+	return nil
+}
+
+// List pages through ListObjectsV2 results for prefix
+func (c *bucketClient) List(ctx context.Context, prefix string) ([]ObjectInfo, error) {
+	prefix = strings.TrimPrefix(prefix, "/")
+
+	var objects []ObjectInfo
+	token := ""
+	for {
+		query := url.Values{"list-type": {"2"}}
+		if prefix != "" {
+			query.Set("prefix", prefix)
+		}
+		if token != "" {
+			query.Set("continuation-token", token)
+		}
+
+		u := *c.endpoint
+		if c.pathStyle {
+			u.Path = "/" + c.bucket + "/"
+		} else {
+			u.Host = c.bucket + "." + u.Host
+			u.Path = "/"
+		}
+		u.RawQuery = sigv4.CanonicalQuery(query)
+
+		resp, err := c.do(ctx, http.MethodGet, &u, nil, 0, nil)
+		if err != nil {
+			return nil, err
+		}
+
+		var result struct {
+			Contents []struct {
+				Key          string    `xml:"Key"`
+				Size         int64     `xml:"Size"`
+				ETag         string    `xml:"ETag"`
+				LastModified time.Time `xml:"LastModified"`
+			} `xml:"Contents"`
+			IsTruncated           bool   `xml:"IsTruncated"`
+			NextContinuationToken string `xml:"NextContinuationToken"`
+		}
+		err = xml.NewDecoder(resp.Body).Decode(&result)
+		resp.Body.Close()
+		if err != nil {
+			return nil, fmt.Errorf("failed to decode list response: %w", err)
+		}
+
+		for _, obj := range result.Contents {
+			objects = append(objects, ObjectInfo{
+				Key:          obj.Key,
+				Size:         obj.Size,
+				ETag:         obj.ETag,
+				LastModified: obj.LastModified,
+			})
+		}
+
+		if !result.IsTruncated || result.NextContinuationToken == "" {
+			break
+		}
+		token = result.NextContinuationToken
+	}
+
+	sort.Slice(objects, func(i, j int) bool {
+		return objects[i].Key < objects[j].Key
+	})
+
+	return objects, nil
+}
+
+// SignedURL returns a presigned URL for the object
+func (c *bucketClient) SignedURL(ctx context.Context, key string, method string, expiry time.Duration) (string, error) {
+	key, err := cleanKey(key)
+	if err != nil {
+		return "", err
+	}
+	if expiry <= 0 || expiry > 7*24*time.Hour {
+		return "", fmt.Errorf("storage: signed URL expiry must be between 1s and 7 days, got %s", expiry)
+	}
+
+	return c.signer.Presign(method, c.objectURL(key, nil), expiry, c.now()), nil
+}
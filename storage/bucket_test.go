@@ -0,0 +1,333 @@
+package storage
+
+import (
+	"bytes"
+	"context"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+// fakeBucket is a minimal in-memory implementation of the S3 XML API
+type fakeBucket struct {
+	mu      sync.Mutex
+	objects map[string][]byte
+	headers map[string]http.Header
+	uploads map[string]map[int][]byte
+	parts   int
+}
+
+func newFakeBucket(t *testing.T, bucket string) (*fakeBucket, *httptest.Server) {
+	fb := &fakeBucket{
+		objects: make(map[string][]byte),
+		headers: make(map[string]http.Header),
+		uploads: make(map[string]map[int][]byte),
+	}
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fb.mu.Lock()
+		defer fb.mu.Unlock()
+
+		if !strings.HasPrefix(r.Header.Get("Authorization"), "AWS4-HMAC-SHA256 ") {
+			w.WriteHeader(http.StatusForbidden)
+			return
+		}
+		if !strings.HasPrefix(r.URL.Path, "/"+bucket+"/") {
+			w.WriteHeader(http.StatusNotFound)
+			fmt.Fprint(w, "<Error><Code>NoSuchBucket</Code></Error>")
+			return
+		}
+
+		key := strings.TrimPrefix(r.URL.Path, "/"+bucket+"/")
+		query := r.URL.Query()
+
+		switch {
+		case r.Method == http.MethodGet && key == "" && query.Get("list-type") == "2":
+			fb.list(w, query)
+
+		case r.Method == http.MethodPost && query.Has("uploads"):
+			id := strconv.Itoa(len(fb.uploads) + 1)
+			fb.uploads[id] = make(map[int][]byte)
+			fb.headers[key] = r.Header.Clone()
+			fmt.Fprintf(w, "<InitiateMultipartUploadResult><UploadId>%s</UploadId></InitiateMultipartUploadResult>", id)
+
+		case r.Method == http.MethodPut && query.Has("uploadId"):
+			n, _ := strconv.Atoi(query.Get("partNumber"))
+			data, _ := io.ReadAll(r.Body)
+			fb.uploads[query.Get("uploadId")][n] = data
+			fb.parts++
+			w.Header().Set("ETag", fmt.Sprintf(`"part-%d"`, n))
+
+		case r.Method == http.MethodPost && query.Has("uploadId"):
+			parts := fb.uploads[query.Get("uploadId")]
+			var buf bytes.Buffer
+			for i := 1; i <= len(parts); i++ {
+				buf.Write(parts[i])
+			}
+			fb.objects[key] = buf.Bytes()
+			fmt.Fprint(w, "<CompleteMultipartUploadResult/>")
+
+		case r.Method == http.MethodPut:
+			data, _ := io.ReadAll(r.Body)
+			fb.objects[key] = data
+			fb.headers[key] = r.Header.Clone()
+
+		case r.Method == http.MethodGet:
+			data, ok := fb.objects[key]
+			if !ok {
+				w.WriteHeader(http.StatusNotFound)
+				fmt.Fprint(w, "<Error><Code>NoSuchKey</Code><Message>The specified key does not exist.</Message></Error>")
+				return
+			}
+			for k, v := range fb.headers[key] {
+				if strings.HasPrefix(strings.ToLower(k), "x-amz-meta-") || k == "Content-Type" {
+					w.Header()[k] = v
+				}
+			}
+			w.Header().Set("ETag", `"etag"`)
+			w.Header().Set("Last-Modified", time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC).Format(http.TimeFormat))
+			w.Write(data)
+
+		case r.Method == http.MethodDelete:
+			delete(fb.objects, key)
+			w.WriteHeader(http.StatusNoContent)
+
+		default:
+			w.WriteHeader(http.StatusMethodNotAllowed)
+		}
+	}))
+	t.Cleanup(server.Close)
+
+	return fb, server
+}
+
+// list serves ListObjectsV2 two keys at a time to exercise pagination
+func (fb *fakeBucket) list(w http.ResponseWriter, query map[string][]string) {
+	prefix := ""
+	if p := query["prefix"]; len(p) > 0 {
+		prefix = p[0]
+	}
+	start := ""
+	if tok := query["continuation-token"]; len(tok) > 0 {
+		start = tok[0]
+	}
+
+	var keys []string
+	for k := range fb.objects {
+		if strings.HasPrefix(k, prefix) && k > start {
+			keys = append(keys, k)
+		}
+	}
+	sort.Strings(keys)
+
+	type content struct {
+		Key          string
+		Size         int
+		LastModified string
+	}
+	result := struct {
+		XMLName               xml.Name `xml:"ListBucketResult"`
+		Contents              []content
+		IsTruncated           bool
+		NextContinuationToken string `xml:",omitempty"`
+	}{}
+	for i, k := range keys {
+		if i == 2 {
+			result.IsTruncated = true
+			result.NextContinuationToken = keys[1]
+			break
+		}
+		result.Contents = append(result.Contents, content{Key: k, Size: len(fb.objects[k]), LastModified: "2024-01-01T00:00:00.000Z"})
+	}
+	xml.NewEncoder(w).Encode(result)
+}
+
+func newTestS3Store(t *testing.T, server *httptest.Server) *S3Store {
+	t.Helper()
+	store, err := NewS3(S3Config{
+		Bucket:          "test-bucket",
+		Region:          "us-east-1",
+		AccessKeyID:     "AKID",
+		SecretAccessKey: "secret",
+		Endpoint:        server.URL,
+		UsePathStyle:    true,
+		PartSize:        minPartSize,
+	})
+	if err != nil {
+		t.Fatalf("NewS3() error = %v", err)
+	}
+	return store
+}
+
+func TestNewS3(t *testing.T) {
+	tests := []struct {
+		name    string
+		cfg     S3Config
+		wantErr bool
+	}{
+		{"valid", S3Config{Bucket: "b", Region: "us-east-1", AccessKeyID: "a", SecretAccessKey: "s"}, false},
+		{"missing bucket", S3Config{Region: "us-east-1", AccessKeyID: "a", SecretAccessKey: "s"}, true},
+		{"missing region", S3Config{Bucket: "b", AccessKeyID: "a", SecretAccessKey: "s"}, true},
+		{"missing credentials", S3Config{Bucket: "b", Region: "us-east-1"}, true},
+		{"small part size", S3Config{Bucket: "b", Region: "us-east-1", AccessKeyID: "a", SecretAccessKey: "s", PartSize: 1024}, true},
+		{"bad endpoint", S3Config{Bucket: "b", Region: "us-east-1", AccessKeyID: "a", SecretAccessKey: "s", Endpoint: "not a url"}, true},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			_, err := NewS3(tc.cfg)
+			if (err != nil) != tc.wantErr {
+				t.Errorf("NewS3() error = %v, wantErr %v", err, tc.wantErr)
+			}
+		})
+	}
+}
+
+func TestS3Store_PutGet(t *testing.T) {
+	fb, server := newFakeBucket(t, "test-bucket")
+	store := newTestS3Store(t, server)
+	ctx := context.Background()
+
+	err := store.Put(ctx, "images/logo one.png", strings.NewReader("png-bytes"),
+		WithMetadata(map[string]string{"Uploaded-By": "tester"}))
+	if err != nil {
+		t.Fatalf("Put() error = %v", err)
+	}
+	if _, ok := fb.objects["images/logo one.png"]; !ok {
+		t.Fatalf("Expected object to be stored under its unescaped key, got %v", fb.objects)
+	}
+	if fb.parts != 0 {
+		t.Errorf("Expected a single PUT for a small object, got %d parts", fb.parts)
+	}
+
+	body, info, err := store.Get(ctx, "images/logo one.png")
+	if err != nil {
+		t.Fatalf("Get() error = %v", err)
+	}
+	defer body.Close()
+
+	data, _ := io.ReadAll(body)
+	if string(data) != "png-bytes" {
+		t.Errorf("Expected body 'png-bytes', got %q", data)
+	}
+	if info.ContentType != "image/png" {
+		t.Errorf("Expected ContentType image/png, got %q", info.ContentType)
+	}
+	if info.Metadata["uploaded-by"] != "tester" {
+		t.Errorf("Expected metadata uploaded-by=tester, got %v", info.Metadata)
+	}
+	if info.LastModified.IsZero() {
+		t.Error("Expected LastModified to be parsed")
+	}
+}
+
+func TestS3Store_MultipartPut(t *testing.T) {
+	fb, server := newFakeBucket(t, "test-bucket")
+	store := newTestS3Store(t, server)
+
+	payload := bytes.Repeat([]byte("0123456789"), minPartSize/10*2+7)
+	if err := store.Put(context.Background(), "large.bin", bytes.NewReader(payload)); err != nil {
+		t.Fatalf("Put() error = %v", err)
+	}
+
+	if fb.parts != 3 {
+		t.Errorf("Expected 3 parts, got %d", fb.parts)
+	}
+	if !bytes.Equal(fb.objects["large.bin"], payload) {
+		t.Errorf("Expected reassembled object to match payload (got %d bytes, want %d)", len(fb.objects["large.bin"]), len(payload))
+	}
+}
+
+func TestS3Store_NotFound(t *testing.T) {
+	_, server := newFakeBucket(t, "test-bucket")
+	store := newTestS3Store(t, server)
+	ctx := context.Background()
+
+	if _, _, err := store.Get(ctx, "missing"); !errors.Is(err, ErrNotFound) {
+		t.Errorf("Expected ErrNotFound, got %v", err)
+	}
+	if err := store.Delete(ctx, "missing"); err != nil {
+		t.Errorf("Expected deleting a missing object to succeed, got %v", err)
+	}
+}
+
+func TestS3Store_ListAndDelete(t *testing.T) {
+	fb, server := newFakeBucket(t, "test-bucket")
+	store := newTestS3Store(t, server)
+	ctx := context.Background()
+
+	for _, key := range []string{"logs/a", "logs/b", "logs/c", "other/d"} {
+		fb.objects[key] = []byte(key)
+	}
+
+	objects, err := store.List(ctx, "logs/")
+	if err != nil {
+		t.Fatalf("List() error = %v", err)
+	}
+	if len(objects) != 3 {
+		t.Fatalf("Expected 3 objects across pages, got %d: %+v", len(objects), objects)
+	}
+	if objects[2].Key != "logs/c" || objects[2].Size != 6 {
+		t.Errorf("Unexpected object %+v", objects[2])
+	}
+
+	if err := store.Delete(ctx, "logs/a"); err != nil {
+		t.Fatalf("Delete() error = %v", err)
+	}
+	if _, ok := fb.objects["logs/a"]; ok {
+		t.Error("Expected logs/a to be deleted")
+	}
+}
+
+func TestS3Store_SignedURL(t *testing.T) {
+	store, err := NewS3(S3Config{Bucket: "b", Region: "eu-west-1", AccessKeyID: "a", SecretAccessKey: "s"})
+	if err != nil {
+		t.Fatalf("NewS3() error = %v", err)
+	}
+
+	signed, err := store.SignedURL(context.Background(), "files/report.pdf", http.MethodGet, 15*time.Minute)
+	if err != nil {
+		t.Fatalf("SignedURL() error = %v", err)
+	}
+	if !strings.HasPrefix(signed, "https://b.s3.eu-west-1.amazonaws.com/files/report.pdf?") {
+		t.Errorf("Unexpected signed URL host or path: %s", signed)
+	}
+	if !strings.Contains(signed, "X-Amz-Expires=900") {
+		t.Errorf("Expected expiry of 900 seconds in %s", signed)
+	}
+
+	if _, err := store.SignedURL(context.Background(), "a", http.MethodGet, 8*24*time.Hour); err == nil {
+		t.Error("Expected error for expiry beyond 7 days")
+	}
+}
+
+func TestNewGCS(t *testing.T) {
+	store, err := NewGCS(GCSConfig{Bucket: "assets", AccessID: "GOOG1", Secret: "s"})
+	if err != nil {
+		t.Fatalf("NewGCS() error = %v", err)
+	}
+
+	signed, err := store.SignedURL(context.Background(), "a.txt", http.MethodGet, time.Minute)
+	if err != nil {
+		t.Fatalf("SignedURL() error = %v", err)
+	}
+	if !strings.HasPrefix(signed, "https://storage.googleapis.com/assets/a.txt?") {
+		t.Errorf("Unexpected signed URL: %s", signed)
+	}
+	if !strings.Contains(signed, "X-Goog-Algorithm=GOOG4-HMAC-SHA256") {
+		t.Errorf("Expected GOOG4 signing in %s", signed)
+	}
+
+	if _, err := NewGCS(GCSConfig{Bucket: "assets"}); err == nil {
+		t.Error("Expected error for missing credentials")
+	}
+}
@@ -0,0 +1,74 @@
+/*
+Package storage provides a blob storage abstraction with Amazon S3, Google Cloud Storage,
+and local filesystem implementations.
+
+All implementations satisfy the Store interface, so services can switch between a local
+directory in development and a cloud bucket in production without code changes. Object
+contents are streamed in both directions rather than buffered in memory.
+
+# Features
+
+  - Single Store interface: Put, Get, Delete, List, and SignedURL
+  - Amazon S3 and S3-compatible servers (MinIO, LocalStack) via NewS3
+  - Google Cloud Storage via the XML API and HMAC keys via NewGCS
+  - Local filesystem store with atomic writes via NewLocal
+  - Content type and user-defined metadata on every object
+  - Multipart uploads for large objects with bounded memory usage
+  - Presigned URLs for direct client uploads and downloads
+
+# Basic Usage
+
+Creating a store and writing an object:
+
+	store, err := storage.NewS3(storage.S3Config{
+		Bucket:          "invoices",
+		Region:          "us-east-1",
+		AccessKeyID:     cfg.AWSAccessKeyID,
+		SecretAccessKey: cfg.AWSSecretAccessKey,
+	})
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	f, _ := os.Open("invoice-1001.pdf")
+	defer f.Close()
+
+	err = store.Put(ctx, "2024/invoice-1001.pdf", f,
+		storage.WithContentType("application/pdf"),
+		storage.WithMetadata(map[string]string{"customer": "42"}),
+	)
+
+Reading an object back:
+
+	body, info, err := store.Get(ctx, "2024/invoice-1001.pdf")
+	if errors.Is(err, storage.ErrNotFound) {
+		// handle missing object
+	}
+	defer body.Close()
+
+	w.Header().Set("Content-Type", info.ContentType)
+	io.Copy(w, body)
+
+# Signed URLs
+
+Signed URLs let browsers transfer objects directly without proxying through the service:
+
+	url, err := store.SignedURL(ctx, "uploads/avatar.png", http.MethodPut, 15*time.Minute)
+
+The local store signs URLs with an HMAC key and serves them through its Handler, which
+should be mounted at the configured BaseURL:
+
+	local, err := storage.NewLocal(storage.LocalConfig{
+		Root:       "./data",
+		BaseURL:    "http://localhost:8080/files",
+		SigningKey: []byte(cfg.FileSigningKey),
+	})
+
+	r.Mount("/files", local.Handler())
+
+# Errors
+
+Missing objects are reported as ErrNotFound and malformed keys (empty, trailing slash,
+or containing "." or ".." segments) as ErrInvalidKey. Both can be checked with errors.Is.
+*/
+package storage
@@ -0,0 +1,55 @@
+package storage
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/StairSupplies/go-core/internal/sigv4"
+)
+
+// GCSConfig configures a Google Cloud Storage store.
+// Requests use the Cloud Storage XML API authenticated with HMAC keys,
+// which can be created for a service account in the Cloud console.
+type GCSConfig struct {
+	// Bucket is the name of the bucket holding objects
+	Bucket string
+	// AccessID is the HMAC key access ID
+	AccessID string
+	// Secret is the HMAC key secret
+	Secret string
+	// Endpoint overrides the service endpoint. Defaults to https://storage.googleapis.com.
+	Endpoint string
+	// PartSize is the multipart upload part size in bytes. Defaults to DefaultPartSize.
+	PartSize int
+	// HTTPClient is the client used for requests. Defaults to a client with a 5 minute timeout.
+	HTTPClient *http.Client
+}
+
+// GCSStore is a Store backed by Google Cloud Storage
+type GCSStore struct {
+	*bucketClient
+}
+
+// NewGCS creates a store for the configured Cloud Storage bucket
+func NewGCS(cfg GCSConfig) (*GCSStore, error) {
+	if cfg.Bucket == "" {
+		return nil, errors.New("storage: GCS bucket is required")
+	}
+	if cfg.AccessID == "" || cfg.Secret == "" {
+		return nil, errors.New("storage: GCS HMAC credentials are required")
+	}
+
+	if cfg.Endpoint == "" {
+		cfg.Endpoint = "https://storage.googleapis.com"
+	}
+
+	client, err := newBucketClient(cfg.Endpoint, cfg.Bucket, true, cfg.PartSize, cfg.HTTPClient,
+		sigv4.NewGoogle(cfg.AccessID, cfg.Secret))
+	if err != nil {
+		return nil, err
+	}
+
+	return &GCSStore{bucketClient: client}, nil
+}
+
+var _ Store = (*GCSStore)(nil)
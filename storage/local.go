@@ -0,0 +1,379 @@
+package storage
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/md5"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/StairSupplies/go-core/internal/sigv4"
+)
+
+// metaDir is the directory under the store root holding object metadata
+const metaDir = ".storage-meta"
+
+// LocalConfig configures a filesystem-backed store
+type LocalConfig struct {
+	// Root is the directory under which objects are stored
+	Root string
+	// BaseURL is the public URL at which Handler is mounted. Required for SignedURL.
+	BaseURL string
+	// SigningKey is the secret used to sign URLs. Required for SignedURL.
+	SigningKey []byte
+}
+
+// LocalStore is a Store that keeps objects on the local filesystem.
+// It is intended for development, tests, and single-host deployments.
+type LocalStore struct {
+	root       string
+	baseURL    string
+	signingKey []byte
+	now        func() time.Time
+}
+
+// localMeta is the sidecar record persisted for each object
+type localMeta struct {
+	ContentType string            `json:"content_type"`
+	ETag        string            `json:"etag"`
+	Metadata    map[string]string `json:"metadata,omitempty"`
+}
+
+// NewLocal creates a filesystem store rooted at cfg.Root, creating the directory if needed
+func NewLocal(cfg LocalConfig) (*LocalStore, error) {
+	if cfg.Root == "" {
+		return nil, errors.New("storage: local root directory is required")
+	}
+
+	root, err := filepath.Abs(cfg.Root)
+	if err != nil {
+		return nil, fmt.Errorf("failed to resolve root directory: %w", err)
+	}
+
+	if err := os.MkdirAll(root, 0o755); err != nil {
+		return nil, fmt.Errorf("failed to create root directory: %w", err)
+	}
+
+	return &LocalStore{
+		root:       root,
+		baseURL:    strings.TrimSuffix(cfg.BaseURL, "/"),
+		signingKey: cfg.SigningKey,
+		now:        time.Now,
+	}, nil
+}
+
+// paths returns the object and metadata file paths for key
+func (s *LocalStore) paths(key string) (string, string, error) {
+	key, err := cleanKey(key)
+	if err != nil {
+		return "", "", err
+	}
+	if strings.HasPrefix(key, metaDir+"/") {
+		return "", "", ErrInvalidKey
+	}
+
+	objPath := filepath.Join(s.root, filepath.FromSlash(key))
+	metaPath := filepath.Join(s.root, metaDir, filepath.FromSlash(key)+".json")
+	return objPath, metaPath, nil
+}
+
+// Put writes the object atomically by streaming into a temporary file and renaming it
+func (s *LocalStore) Put(ctx context.Context, key string, r io.Reader, opts ...PutOption) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	objPath, metaPath, err := s.paths(key)
+	if err != nil {
+		return err
+	}
+	o := newPutOptions(key, opts)
+
+	if err := os.MkdirAll(filepath.Dir(objPath), 0o755); err != nil {
+		return fmt.Errorf("failed to create object directory: %w", err)
+	}
+
+	tmp, err := os.CreateTemp(filepath.Dir(objPath), ".upload-*")
+	if err != nil {
+		return fmt.Errorf("failed to create temporary file: %w", err)
+	}
+	defer os.Remove(tmp.Name())
+
+	hash := md5.New()
+	if _, err := io.Copy(io.MultiWriter(tmp, hash), r); err != nil {
+		tmp.Close()
+		return fmt.Errorf("failed to write object: %w", err)
+	}
+	if err := tmp.Close(); err != nil {
+		return fmt.Errorf("failed to write object: %w", err)
+	}
+
+	meta, err := json.Marshal(localMeta{
+		ContentType: o.ContentType,
+		ETag:        `"` + hex.EncodeToString(hash.Sum(nil)) + `"`,
+		Metadata:    o.Metadata,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to encode metadata: %w", err)
+	}
+
+	if err := os.MkdirAll(filepath.Dir(metaPath), 0o755); err != nil {
+		return fmt.Errorf("failed to create metadata directory: %w", err)
+	}
+	if err := os.WriteFile(metaPath, meta, 0o644); err != nil {
+		return fmt.Errorf("failed to write metadata: %w", err)
+	}
+
+	if err := os.Rename(tmp.Name(), objPath); err != nil {
+		return fmt.Errorf("failed to move object into place: %w", err)
+	}
+
+	return nil
+}
+
+// Get opens the object file for reading
+func (s *LocalStore) Get(ctx context.Context, key string) (io.ReadCloser, *ObjectInfo, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, nil, err
+	}
+
+	objPath, _, err := s.paths(key)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	f, err := os.Open(objPath)
+	if err != nil {
+		if errors.Is(err, fs.ErrNotExist) {
+			return nil, nil, fmt.Errorf("%w: %s", ErrNotFound, key)
+		}
+		return nil, nil, fmt.Errorf("failed to open object: %w", err)
+	}
+
+	info, err := s.stat(key)
+	if err != nil {
+		f.Close()
+		return nil, nil, err
+	}
+
+	return f, info, nil
+}
+
+// stat builds the ObjectInfo for key from the filesystem and its metadata record
+func (s *LocalStore) stat(key string) (*ObjectInfo, error) {
+	objPath, metaPath, err := s.paths(key)
+	if err != nil {
+		return nil, err
+	}
+
+	fi, err := os.Stat(objPath)
+	if err != nil {
+		if errors.Is(err, fs.ErrNotExist) {
+			return nil, fmt.Errorf("%w: %s", ErrNotFound, key)
+		}
+		return nil, fmt.Errorf("failed to stat object: %w", err)
+	}
+
+	info := &ObjectInfo{
+		Key:          strings.TrimPrefix(key, "/"),
+		Size:         fi.Size(),
+		LastModified: fi.ModTime(),
+		ContentType:  "application/octet-stream",
+	}
+
+	data, err := os.ReadFile(metaPath)
+	if err == nil {
+		var meta localMeta
+		if err := json.Unmarshal(data, &meta); err == nil {
+			info.ContentType = meta.ContentType
+			info.ETag = meta.ETag
+			info.Metadata = meta.Metadata
+		}
+	}
+
+	return info, nil
+}
+
+// Delete removes the object and its metadata
+func (s *LocalStore) Delete(ctx context.Context, key string) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	objPath, metaPath, err := s.paths(key)
+	if err != nil {
+		return err
+	}
+
+	if err := os.Remove(objPath); err != nil && !errors.Is(err, fs.ErrNotExist) {
+		return fmt.Errorf("failed to delete object: %w", err)
+	}
+	if err := os.Remove(metaPath); err != nil && !errors.Is(err, fs.ErrNotExist) {
+		return fmt.Errorf("failed to delete metadata: %w", err)
+	}
+
+	return nil
+}
+
+// List walks the store root and returns the objects under prefix
+func (s *LocalStore) List(ctx context.Context, prefix string) ([]ObjectInfo, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	prefix = strings.TrimPrefix(prefix, "/")
+	var objects []ObjectInfo
+
+	err := filepath.WalkDir(s.root, func(p string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+
+		rel, err := filepath.Rel(s.root, p)
+		if err != nil {
+			return err
+		}
+		key := filepath.ToSlash(rel)
+
+		if d.IsDir() {
+			if key == metaDir {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		if strings.HasPrefix(d.Name(), ".upload-") || !strings.HasPrefix(key, prefix) {
+			return nil
+		}
+
+		info, err := s.stat(key)
+		if err != nil {
+			return err
+		}
+		objects = append(objects, *info)
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list objects: %w", err)
+	}
+
+	sort.Slice(objects, func(i, j int) bool {
+		return objects[i].Key < objects[j].Key
+	})
+
+	return objects, nil
+}
+
+// SignedURL returns a URL served by Handler that grants temporary access to the object.
+// GET and PUT are supported.
+func (s *LocalStore) SignedURL(ctx context.Context, key string, method string, expiry time.Duration) (string, error) {
+	if s.baseURL == "" || len(s.signingKey) == 0 {
+		return "", fmt.Errorf("%w: local store requires BaseURL and SigningKey to sign URLs", ErrNotSupported)
+	}
+	if method != http.MethodGet && method != http.MethodPut {
+		return "", fmt.Errorf("%w: cannot sign %s requests", ErrNotSupported, method)
+	}
+
+	key, err := cleanKey(key)
+	if err != nil {
+		return "", err
+	}
+
+	expires := strconv.FormatInt(s.now().Add(expiry).Unix(), 10)
+	query := url.Values{}
+	query.Set("expires", expires)
+	query.Set("method", method)
+	query.Set("signature", s.sign(method, key, expires))
+
+	return s.baseURL + "/" + sigv4.EscapePath(key) + "?" + query.Encode(), nil
+}
+
+// sign computes the URL signature for method, key, and expiry
+func (s *LocalStore) sign(method, key, expires string) string {
+	mac := hmac.New(sha256.New, s.signingKey)
+	mac.Write([]byte(method + "\n" + key + "\n" + expires))
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+// Handler returns an http.Handler that serves URLs produced by SignedURL.
+// Mount it at the path of the configured BaseURL.
+func (s *LocalStore) Handler() http.Handler {
+	basePath := ""
+	if u, err := url.Parse(s.baseURL); err == nil {
+		basePath = strings.TrimSuffix(u.Path, "/")
+	}
+
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		method := r.Method
+		if method == http.MethodHead {
+			method = http.MethodGet
+		}
+
+		query := r.URL.Query()
+		key := strings.TrimPrefix(strings.TrimPrefix(r.URL.Path, basePath), "/")
+		expires := query.Get("expires")
+
+		exp, err := strconv.ParseInt(expires, 10, 64)
+		if err != nil || query.Get("method") != method || len(s.signingKey) == 0 ||
+			!hmac.Equal([]byte(query.Get("signature")), []byte(s.sign(method, key, expires))) {
+			http.Error(w, "invalid signature", http.StatusForbidden)
+			return
+		}
+		if s.now().Unix() > exp {
+			http.Error(w, "signed URL expired", http.StatusForbidden)
+			return
+		}
+
+		switch method {
+		case http.MethodGet:
+			body, info, err := s.Get(r.Context(), key)
+			if err != nil {
+				if errors.Is(err, ErrNotFound) {
+					http.NotFound(w, r)
+					return
+				}
+				http.Error(w, err.Error(), http.StatusInternalServerError)
+				return
+			}
+			defer body.Close()
+
+			w.Header().Set("Content-Type", info.ContentType)
+			if info.ETag != "" {
+				w.Header().Set("ETag", info.ETag)
+			}
+			if f, ok := body.(io.ReadSeeker); ok {
+				http.ServeContent(w, r, "", info.LastModified, f)
+				return
+			}
+			io.Copy(w, body)
+
+		case http.MethodPut:
+			var opts []PutOption
+			if ct := r.Header.Get("Content-Type"); ct != "" {
+				opts = append(opts, WithContentType(ct))
+			}
+			if err := s.Put(r.Context(), key, r.Body, opts...); err != nil {
+				http.Error(w, err.Error(), http.StatusInternalServerError)
+				return
+			}
+			w.WriteHeader(http.StatusOK)
+
+		default:
+			http.Error(w, http.StatusText(http.StatusMethodNotAllowed), http.StatusMethodNotAllowed)
+		}
+	})
+}
+
+var _ Store = (*LocalStore)(nil)
@@ -0,0 +1,202 @@
+package storage
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+func newTestLocalStore(t *testing.T) *LocalStore {
+	t.Helper()
+	store, err := NewLocal(LocalConfig{
+		Root:       t.TempDir(),
+		BaseURL:    "http://files.example.com/blobs",
+		SigningKey: []byte("test-key"),
+	})
+	if err != nil {
+		t.Fatalf("NewLocal() error = %v", err)
+	}
+	return store
+}
+
+func TestNewLocal(t *testing.T) {
+	if _, err := NewLocal(LocalConfig{}); err == nil {
+		t.Error("Expected error for missing root, got nil")
+	}
+}
+
+func TestLocalStore_PutGet(t *testing.T) {
+	store := newTestLocalStore(t)
+	ctx := context.Background()
+
+	err := store.Put(ctx, "reports/2024/summary.json", strings.NewReader(`{"ok":true}`),
+		WithMetadata(map[string]string{"Owner": "finance"}))
+	if err != nil {
+		t.Fatalf("Put() error = %v", err)
+	}
+
+	body, info, err := store.Get(ctx, "reports/2024/summary.json")
+	if err != nil {
+		t.Fatalf("Get() error = %v", err)
+	}
+	defer body.Close()
+
+	data, _ := io.ReadAll(body)
+	if string(data) != `{"ok":true}` {
+		t.Errorf("Expected body %q, got %q", `{"ok":true}`, data)
+	}
+	if info.Size != int64(len(data)) {
+		t.Errorf("Expected Size %d, got %d", len(data), info.Size)
+	}
+	if info.ContentType != "application/json" {
+		t.Errorf("Expected ContentType inferred as application/json, got %q", info.ContentType)
+	}
+	if info.Metadata["owner"] != "finance" {
+		t.Errorf("Expected metadata owner=finance, got %v", info.Metadata)
+	}
+	if info.ETag == "" {
+		t.Error("Expected ETag to be set")
+	}
+}
+
+func TestLocalStore_GetNotFound(t *testing.T) {
+	store := newTestLocalStore(t)
+
+	_, _, err := store.Get(context.Background(), "missing.txt")
+	if !errors.Is(err, ErrNotFound) {
+		t.Errorf("Expected ErrNotFound, got %v", err)
+	}
+}
+
+func TestLocalStore_InvalidKeys(t *testing.T) {
+	store := newTestLocalStore(t)
+	ctx := context.Background()
+
+	for _, key := range []string{"", "../escape.txt", "a/../../b", "dir/", ".storage-meta/x", "a//b"} {
+		if err := store.Put(ctx, key, strings.NewReader("x")); !errors.Is(err, ErrInvalidKey) {
+			t.Errorf("Put(%q) error = %v, want ErrInvalidKey", key, err)
+		}
+	}
+}
+
+func TestLocalStore_DeleteAndList(t *testing.T) {
+	store := newTestLocalStore(t)
+	ctx := context.Background()
+
+	for _, key := range []string{"b/2.txt", "a/1.txt", "b/1.txt", "c.txt"} {
+		if err := store.Put(ctx, key, strings.NewReader(key)); err != nil {
+			t.Fatalf("Put(%q) error = %v", key, err)
+		}
+	}
+
+	objects, err := store.List(ctx, "b/")
+	if err != nil {
+		t.Fatalf("List() error = %v", err)
+	}
+	if len(objects) != 2 || objects[0].Key != "b/1.txt" || objects[1].Key != "b/2.txt" {
+		t.Errorf("Expected [b/1.txt b/2.txt], got %+v", objects)
+	}
+
+	if err := store.Delete(ctx, "b/1.txt"); err != nil {
+		t.Fatalf("Delete() error = %v", err)
+	}
+	if err := store.Delete(ctx, "b/1.txt"); err != nil {
+		t.Errorf("Expected deleting a missing object to succeed, got %v", err)
+	}
+
+	objects, err = store.List(ctx, "")
+	if err != nil {
+		t.Fatalf("List() error = %v", err)
+	}
+	if len(objects) != 3 {
+		t.Errorf("Expected 3 objects after delete, got %d: %+v", len(objects), objects)
+	}
+}
+
+func TestLocalStore_SignedURL(t *testing.T) {
+	store := newTestLocalStore(t)
+	ctx := context.Background()
+	now := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+	store.now = func() time.Time { return now }
+
+	if err := store.Put(ctx, "docs/readme.txt", strings.NewReader("hello")); err != nil {
+		t.Fatalf("Put() error = %v", err)
+	}
+
+	signed, err := store.SignedURL(ctx, "docs/readme.txt", http.MethodGet, time.Minute)
+	if err != nil {
+		t.Fatalf("SignedURL() error = %v", err)
+	}
+	if !strings.HasPrefix(signed, "http://files.example.com/blobs/docs/readme.txt?") {
+		t.Errorf("Unexpected signed URL: %s", signed)
+	}
+
+	handler := store.Handler()
+
+	t.Run("valid signature", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		handler.ServeHTTP(w, httptest.NewRequest(http.MethodGet, signed, nil))
+		if w.Code != http.StatusOK {
+			t.Fatalf("Expected status 200, got %d: %s", w.Code, w.Body.String())
+		}
+		if w.Body.String() != "hello" {
+			t.Errorf("Expected body 'hello', got %q", w.Body.String())
+		}
+	})
+
+	t.Run("tampered key", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		tampered := strings.Replace(signed, "readme.txt", "other.txt", 1)
+		handler.ServeHTTP(w, httptest.NewRequest(http.MethodGet, tampered, nil))
+		if w.Code != http.StatusForbidden {
+			t.Errorf("Expected status 403, got %d", w.Code)
+		}
+	})
+
+	t.Run("expired", func(t *testing.T) {
+		now = now.Add(2 * time.Minute)
+		defer func() { now = now.Add(-2 * time.Minute) }()
+
+		w := httptest.NewRecorder()
+		handler.ServeHTTP(w, httptest.NewRequest(http.MethodGet, signed, nil))
+		if w.Code != http.StatusForbidden {
+			t.Errorf("Expected status 403, got %d", w.Code)
+		}
+	})
+
+	t.Run("signed upload", func(t *testing.T) {
+		uploadURL, err := store.SignedURL(ctx, "uploads/new.txt", http.MethodPut, time.Minute)
+		if err != nil {
+			t.Fatalf("SignedURL() error = %v", err)
+		}
+
+		w := httptest.NewRecorder()
+		req := httptest.NewRequest(http.MethodPut, uploadURL, strings.NewReader("uploaded"))
+		req.Header.Set("Content-Type", "text/plain")
+		handler.ServeHTTP(w, req)
+		if w.Code != http.StatusOK {
+			t.Fatalf("Expected status 200, got %d: %s", w.Code, w.Body.String())
+		}
+
+		body, info, err := store.Get(ctx, "uploads/new.txt")
+		if err != nil {
+			t.Fatalf("Get() error = %v", err)
+		}
+		defer body.Close()
+		if info.ContentType != "text/plain" {
+			t.Errorf("Expected ContentType text/plain, got %q", info.ContentType)
+		}
+	})
+
+	t.Run("not configured", func(t *testing.T) {
+		plain, _ := NewLocal(LocalConfig{Root: t.TempDir()})
+		if _, err := plain.SignedURL(ctx, "a.txt", http.MethodGet, time.Minute); !errors.Is(err, ErrNotSupported) {
+			t.Errorf("Expected ErrNotSupported, got %v", err)
+		}
+	})
+}
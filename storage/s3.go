@@ -0,0 +1,97 @@
+package storage
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"time"
+
+	"github.com/StairSupplies/go-core/internal/sigv4"
+)
+
+// S3Config configures an Amazon S3 (or S3-compatible) store
+type S3Config struct {
+	// Bucket is the name of the bucket holding objects
+	Bucket string
+	// Region is the AWS region of the bucket, e.g. "us-east-1"
+	Region string
+	// AccessKeyID is the AWS access key
+	AccessKeyID string
+	// SecretAccessKey is the AWS secret key
+	SecretAccessKey string
+	// SessionToken is the optional token for temporary credentials
+	SessionToken string
+	// Endpoint overrides the service endpoint, e.g. for MinIO or LocalStack.
+	// Defaults to https://s3.<region>.amazonaws.com.
+	Endpoint string
+	// UsePathStyle addresses the bucket in the path rather than the host name.
+	// Most S3-compatible servers require it.
+	UsePathStyle bool
+	// PartSize is the multipart upload part size in bytes. Defaults to DefaultPartSize.
+	PartSize int
+	// HTTPClient is the client used for requests. Defaults to a client with a 5 minute timeout.
+	HTTPClient *http.Client
+}
+
+// S3Store is a Store backed by Amazon S3 or an S3-compatible service
+type S3Store struct {
+	*bucketClient
+}
+
+// NewS3 creates a store for the configured S3 bucket
+func NewS3(cfg S3Config) (*S3Store, error) {
+	if cfg.Bucket == "" {
+		return nil, errors.New("storage: S3 bucket is required")
+	}
+	if cfg.Region == "" {
+		return nil, errors.New("storage: S3 region is required")
+	}
+	if cfg.AccessKeyID == "" || cfg.SecretAccessKey == "" {
+		return nil, errors.New("storage: S3 credentials are required")
+	}
+
+	if cfg.Endpoint == "" {
+		cfg.Endpoint = "https://s3." + cfg.Region + ".amazonaws.com"
+	}
+
+	client, err := newBucketClient(cfg.Endpoint, cfg.Bucket, cfg.UsePathStyle, cfg.PartSize, cfg.HTTPClient,
+		sigv4.NewAWS(cfg.AccessKeyID, cfg.SecretAccessKey, cfg.SessionToken, cfg.Region, "s3"))
+	if err != nil {
+		return nil, err
+	}
+
+	return &S3Store{bucketClient: client}, nil
+}
+
+// newBucketClient validates shared settings and applies defaults
+func newBucketClient(endpoint, bucket string, pathStyle bool, partSize int, httpClient *http.Client, s *sigv4.Signer) (*bucketClient, error) {
+	u, err := url.Parse(endpoint)
+	if err != nil || u.Scheme == "" || u.Host == "" {
+		return nil, fmt.Errorf("storage: invalid endpoint %q", endpoint)
+	}
+	u.Path = ""
+
+	if partSize == 0 {
+		partSize = DefaultPartSize
+	}
+	if partSize < minPartSize {
+		return nil, fmt.Errorf("storage: part size must be at least %d bytes", minPartSize)
+	}
+
+	if httpClient == nil {
+		httpClient = &http.Client{Timeout: 5 * time.Minute}
+	}
+
+	return &bucketClient{
+		endpoint:   u,
+		bucket:     bucket,
+		pathStyle:  pathStyle,
+		partSize:   partSize,
+		httpClient: httpClient,
+		signer:     s,
+		now:        time.Now,
+	}, nil
+}
+
+var _ Store = (*S3Store)(nil)
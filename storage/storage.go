@@ -0,0 +1,114 @@
+package storage
+
+import (
+	"context"
+	"errors"
+	"io"
+	"mime"
+	"path"
+	"strings"
+	"time"
+)
+
+// Common storage errors
+var (
+	// ErrNotFound indicates that the requested object does not exist.
+	ErrNotFound = errors.New("object not found")
+
+	// ErrInvalidKey indicates that an object key is empty or escapes the store root.
+	ErrInvalidKey = errors.New("invalid object key")
+
+	// ErrNotSupported indicates that the store cannot perform the requested operation.
+	ErrNotSupported = errors.New("operation not supported")
+)
+
+// Store is a blob store that holds objects addressed by slash-separated keys.
+// All implementations stream object contents rather than buffering them in memory.
+type Store interface {
+	// Put writes the contents of r to the object at key, replacing any existing object.
+	Put(ctx context.Context, key string, r io.Reader, opts ...PutOption) error
+	// Get opens the object at key. The caller must close the returned reader.
+	Get(ctx context.Context, key string) (io.ReadCloser, *ObjectInfo, error)
+	// Delete removes the object at key. Deleting a missing object is not an error.
+	Delete(ctx context.Context, key string) error
+	// List returns all objects whose key starts with prefix, ordered by key.
+	List(ctx context.Context, prefix string) ([]ObjectInfo, error)
+	// SignedURL returns a URL granting temporary access to the object at key
+	// for the given HTTP method.
+	SignedURL(ctx context.Context, key string, method string, expiry time.Duration) (string, error)
+}
+
+// ObjectInfo describes a stored object.
+type ObjectInfo struct {
+	Key          string            `json:"key"`
+	Size         int64             `json:"size"`
+	ContentType  string            `json:"content_type,omitempty"`
+	ETag         string            `json:"etag,omitempty"`
+	LastModified time.Time         `json:"last_modified"`
+	Metadata     map[string]string `json:"metadata,omitempty"`
+}
+
+// PutOptions holds the settings applied to an object when it is written.
+type PutOptions struct {
+	// ContentType is the MIME type stored with the object
+	ContentType string
+	// Metadata is user-defined metadata stored with the object.
+	// Keys are normalized to lower case.
+	Metadata map[string]string
+}
+
+// PutOption is a function that configures a Put call
+type PutOption func(*PutOptions)
+
+// WithContentType sets the content type of the object.
+// When omitted, the content type is inferred from the key's file extension.
+func WithContentType(contentType string) PutOption {
+	return func(o *PutOptions) {
+		o.ContentType = contentType
+	}
+}
+
+// WithMetadata adds user-defined metadata to the object
+func WithMetadata(metadata map[string]string) PutOption {
+	return func(o *PutOptions) {
+		if o.Metadata == nil {
+			o.Metadata = make(map[string]string)
+		}
+		for k, v := range metadata {
+			o.Metadata[strings.ToLower(k)] = v
+		}
+	}
+}
+
+// newPutOptions applies opts and fills in the defaults for key
+func newPutOptions(key string, opts []PutOption) PutOptions {
+	var o PutOptions
+	for _, opt := range opts {
+		opt(&o)
+	}
+
+	if o.ContentType == "" {
+		o.ContentType = mime.TypeByExtension(path.Ext(key))
+	}
+	if o.ContentType == "" {
+		o.ContentType = "application/octet-stream"
+	}
+
+	return o
+}
+
+// cleanKey validates an object key and returns it in canonical form
+func cleanKey(key string) (string, error) {
+	key = strings.TrimPrefix(key, "/")
+	if key == "" || strings.HasSuffix(key, "/") {
+		return "", ErrInvalidKey
+	}
+
+	for _, segment := range strings.Split(key, "/") {
+		if segment == "" || segment == "." || segment == ".." {
+			return "", ErrInvalidKey
+		}
+	}
+
+	return key, nil
+}
@@ -0,0 +1,116 @@
+package str
+
+import (
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// Words splits s into words for case conversion. Words are separated by any
+// character other than a letter or digit, by a lowercase letter or digit
+// followed by an uppercase letter, and at the end of an acronym, so
+// "HTTPServer" becomes "HTTP" and "Server" and "userID" becomes "user" and
+// "ID". Digits stay with the word before them.
+func Words(s string) []string {
+	var words []string
+	runes := []rune(s)
+	start := -1
+	for i, r := range runes {
+		if !unicode.IsLetter(r) && !unicode.IsDigit(r) {
+			if start >= 0 {
+				words = append(words, string(runes[start:i]))
+				start = -1
+			}
+			continue
+		}
+		if start < 0 {
+			start = i
+			continue
+		}
+		if unicode.IsUpper(r) {
+			prev := runes[i-1]
+			acronymEnd := unicode.IsUpper(prev) && i+1 < len(runes) && unicode.IsLower(runes[i+1])
+			if unicode.IsLower(prev) || unicode.IsDigit(prev) || acronymEnd {
+				words = append(words, string(runes[start:i]))
+				start = i
+			}
+		}
+	}
+	if start >= 0 {
+		words = append(words, string(runes[start:]))
+	}
+	return words
+}
+
+// ToSnakeCase converts s to lowercase words joined by underscores, e.g.
+// "HTTPServer" becomes "http_server" and "userID" becomes "user_id"
+func ToSnakeCase(s string) string {
+	return strings.ToLower(strings.Join(Words(s), "_"))
+}
+
+// ToKebabCase converts s to lowercase words joined by dashes, e.g.
+// "HTTPServer" becomes "http-server"
+func ToKebabCase(s string) string {
+	return strings.ToLower(strings.Join(Words(s), "-"))
+}
+
+// ToPascalCase converts s to capitalized words with no separator, e.g.
+// "user_id" becomes "UserId". Acronyms written in capitals keep them, so
+// "HTTP server" becomes "HTTPServer".
+func ToPascalCase(s string) string {
+	var b strings.Builder
+	for _, w := range Words(s) {
+		b.WriteString(capitalize(w))
+	}
+	return b.String()
+}
+
+// ToCamelCase is like ToPascalCase but lowercases the first word, e.g.
+// "user_id" becomes "userId" and "HTTPServer" becomes "httpServer"
+func ToCamelCase(s string) string {
+	words := Words(s)
+	if len(words) == 0 {
+		return ""
+	}
+	var b strings.Builder
+	b.WriteString(strings.ToLower(words[0]))
+	for _, w := range words[1:] {
+		b.WriteString(capitalize(w))
+	}
+	return b.String()
+}
+
+// ToTitleCase converts s to capitalized words separated by spaces, e.g.
+// "http_server_error" becomes "Http Server Error" and "HTTPServer" becomes
+// "HTTP Server". It replaces the deprecated strings.Title for identifiers.
+func ToTitleCase(s string) string {
+	words := Words(s)
+	for i, w := range words {
+		words[i] = capitalize(w)
+	}
+	return strings.Join(words, " ")
+}
+
+// capitalize uppercases the first letter of w and lowercases the rest,
+// unless w is an acronym written in capitals
+func capitalize(w string) string {
+	if isAcronym(w) {
+		return w
+	}
+	r, size := utf8.DecodeRuneInString(w)
+	return string(unicode.ToUpper(r)) + strings.ToLower(w[size:])
+}
+
+// isAcronym reports whether w has more than one letter and no lowercase letters
+func isAcronym(w string) bool {
+	letters := 0
+	for _, r := range w {
+		if unicode.IsLower(r) {
+			return false
+		}
+		if unicode.IsLetter(r) {
+			letters++
+		}
+	}
+	return letters > 1
+}
@@ -0,0 +1,76 @@
+package str
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestWords(t *testing.T) {
+	tests := []struct {
+		input string
+		want  []string
+	}{
+		{"HTTPServer", []string{"HTTP", "Server"}},
+		{"userID", []string{"user", "ID"}},
+		{"user_id", []string{"user", "id"}},
+		{"user-id", []string{"user", "id"}},
+		{"  Oak  Stair Tread ", []string{"Oak", "Stair", "Tread"}},
+		{"parseJSONResponse", []string{"parse", "JSON", "Response"}},
+		{"OAuth2Token", []string{"O", "Auth2", "Token"}},
+		{"version2", []string{"version2"}},
+		{"v2Beta", []string{"v2", "Beta"}},
+		{"ID", []string{"ID"}},
+		{"ÜberTread", []string{"Über", "Tread"}},
+		{"__", nil},
+		{"", nil},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.input, func(t *testing.T) {
+			if got := Words(tc.input); !reflect.DeepEqual(got, tc.want) {
+				t.Errorf("Words(%q) = %q, want %q", tc.input, got, tc.want)
+			}
+		})
+	}
+}
+
+func TestCaseConversion(t *testing.T) {
+	tests := []struct {
+		input  string
+		snake  string
+		kebab  string
+		camel  string
+		pascal string
+		title  string
+	}{
+		{"HTTPServer", "http_server", "http-server", "httpServer", "HTTPServer", "HTTP Server"},
+		{"userID", "user_id", "user-id", "userID", "UserID", "User ID"},
+		{"user_id", "user_id", "user-id", "userId", "UserId", "User Id"},
+		{"created-at", "created_at", "created-at", "createdAt", "CreatedAt", "Created At"},
+		{"Stair Tread Width", "stair_tread_width", "stair-tread-width", "stairTreadWidth", "StairTreadWidth", "Stair Tread Width"},
+		{"parseJSONResponse", "parse_json_response", "parse-json-response", "parseJSONResponse", "ParseJSONResponse", "Parse JSON Response"},
+		{"SKU", "sku", "sku", "sku", "SKU", "SKU"},
+		{"address_line2", "address_line2", "address-line2", "addressLine2", "AddressLine2", "Address Line2"},
+		{"", "", "", "", "", ""},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.input, func(t *testing.T) {
+			if got := ToSnakeCase(tc.input); got != tc.snake {
+				t.Errorf("ToSnakeCase(%q) = %q, want %q", tc.input, got, tc.snake)
+			}
+			if got := ToKebabCase(tc.input); got != tc.kebab {
+				t.Errorf("ToKebabCase(%q) = %q, want %q", tc.input, got, tc.kebab)
+			}
+			if got := ToCamelCase(tc.input); got != tc.camel {
+				t.Errorf("ToCamelCase(%q) = %q, want %q", tc.input, got, tc.camel)
+			}
+			if got := ToPascalCase(tc.input); got != tc.pascal {
+				t.Errorf("ToPascalCase(%q) = %q, want %q", tc.input, got, tc.pascal)
+			}
+			if got := ToTitleCase(tc.input); got != tc.title {
+				t.Errorf("ToTitleCase(%q) = %q, want %q", tc.input, got, tc.title)
+			}
+		})
+	}
+}
@@ -0,0 +1,41 @@
+/*
+Package str provides string normalization helpers, such as URL slugs, that
+should behave the same in every service.
+
+# Features
+
+  - Slugify for URL-safe, lowercase ASCII slugs
+  - Transliteration of accented and special Latin letters
+  - RemoveDiacritics for accent-insensitive comparison and search
+  - NormalizeWhitespace for cleaning user input
+  - Acronym-aware snake, kebab, camel, Pascal and title case conversion
+
+# Slugs
+
+	str.Slugify("Über Oak & Maple Treads!")  // "uber-oak-and-maple-treads"
+	str.Slugify("Mike's Handrails")          // "mikes-handrails"
+	str.Slugify("Straße 36\" Tread")         // "strasse-36-tread"
+
+Slugs contain only a-z, 0-9 and single dashes, never start or end with a
+dash, and are empty if s has no Latin letters or digits.
+
+# Normalization
+
+	str.RemoveDiacritics("Crème Brûlée")         // "Creme Brulee"
+	str.NormalizeWhitespace("  Oak \n  Tread ")  // "Oak Tread"
+
+# Case Conversion
+
+	str.ToSnakeCase("HTTPServer")   // "http_server"
+	str.ToKebabCase("userID")       // "user-id"
+	str.ToCamelCase("created_at")   // "createdAt"
+	str.ToPascalCase("created_at")  // "CreatedAt"
+	str.ToTitleCase("created_at")   // "Created At"
+
+Words are split at any character other than a letter or digit, where a
+lowercase letter or digit is followed by an uppercase letter, and at the end
+of an acronym. Acronyms written in capitals stay capitalized in Pascal, camel
+and title case, so "HTTP server" becomes "HTTPServer". Words returns the
+split words for other conventions.
+*/
+package str
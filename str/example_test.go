@@ -0,0 +1,52 @@
+package str_test
+
+import (
+	"fmt"
+
+	"github.com/StairSupplies/go-core/str"
+)
+
+func ExampleSlugify() {
+	fmt.Println(str.Slugify("Über Oak & Maple Treads!"))
+	fmt.Println(str.Slugify("Mike's Handrails"))
+	fmt.Println(str.Slugify("Straße 36\" Tread"))
+
+	// Output:
+	// uber-oak-and-maple-treads
+	// mikes-handrails
+	// strasse-36-tread
+}
+
+func ExampleRemoveDiacritics() {
+	fmt.Println(str.RemoveDiacritics("Crème Brûlée"))
+
+	// Output: Creme Brulee
+}
+
+func ExampleNormalizeWhitespace() {
+	fmt.Printf("%q\n", str.NormalizeWhitespace("  Oak \n\t Tread "))
+
+	// Output: "Oak Tread"
+}
+
+func ExampleToSnakeCase() {
+	fmt.Println(str.ToSnakeCase("HTTPServer"))
+	fmt.Println(str.ToSnakeCase("userID"))
+
+	// Output:
+	// http_server
+	// user_id
+}
+
+func ExampleToCamelCase() {
+	fmt.Println(str.ToCamelCase("created_at"))
+	fmt.Println(str.ToPascalCase("created_at"))
+	fmt.Println(str.ToKebabCase("CreatedAt"))
+	fmt.Println(str.ToTitleCase("created_at"))
+
+	// Output:
+	// createdAt
+	// CreatedAt
+	// created-at
+	// Created At
+}
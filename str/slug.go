@@ -0,0 +1,82 @@
+package str
+
+import (
+	"strings"
+	"unicode"
+
+	"golang.org/x/text/runes"
+	"golang.org/x/text/transform"
+	"golang.org/x/text/unicode/norm"
+)
+
+// transliterations maps letters that do not decompose into an ASCII base
+// letter plus diacritics
+var transliterations = map[rune]string{
+	'ß': "ss", 'ẞ': "SS",
+	'æ': "ae", 'Æ': "AE",
+	'œ': "oe", 'Œ': "OE",
+	'ø': "o", 'Ø': "O",
+	'đ': "d", 'Đ': "D",
+	'ð': "d", 'Ð': "D",
+	'þ': "th", 'Þ': "TH",
+	'ł': "l", 'Ł': "L",
+	'ı': "i",
+	'&': " and ",
+}
+
+// RemoveDiacritics strips accents and other combining marks, e.g.
+// "Crème Brûlée" becomes "Creme Brulee". Letters without a decomposition,
+// such as "ß", are left unchanged.
+func RemoveDiacritics(s string) string {
+	t := transform.Chain(norm.NFD, runes.Remove(runes.In(unicode.Mn)), norm.NFC)
+	result, _, err := transform.String(t, s)
+	if err != nil {
+		return s
+	}
+	return result
+}
+
+// NormalizeWhitespace trims s and collapses each run of Unicode whitespace,
+// including tabs, newlines and non-breaking spaces, into a single space
+func NormalizeWhitespace(s string) string {
+	return strings.Join(strings.FieldsFunc(s, unicode.IsSpace), " ")
+}
+
+// Slugify converts s to a lowercase, URL-safe slug of ASCII letters and
+// digits separated by single dashes, e.g. "Über Oak & Maple Treads!"
+// becomes "uber-oak-and-maple-treads". Accented letters are transliterated,
+// apostrophes are dropped so "Mike's" becomes "mikes", and characters with
+// no ASCII equivalent are treated as separators.
+func Slugify(s string) string {
+	var b strings.Builder
+	for _, r := range s {
+		if t, ok := transliterations[r]; ok {
+			b.WriteString(t)
+			continue
+		}
+		b.WriteRune(r)
+	}
+	s = RemoveDiacritics(b.String())
+
+	b.Reset()
+	b.Grow(len(s))
+	dash := false
+	for _, r := range s {
+		switch {
+		case r >= 'a' && r <= 'z', r >= '0' && r <= '9':
+		case r >= 'A' && r <= 'Z':
+			r = unicode.ToLower(r)
+		case r == '\'' || r == '’':
+			continue
+		default:
+			dash = b.Len() > 0
+			continue
+		}
+		if dash {
+			b.WriteByte('-')
+			dash = false
+		}
+		b.WriteRune(r)
+	}
+	return b.String()
+}
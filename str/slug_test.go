@@ -0,0 +1,72 @@
+package str
+
+import "testing"
+
+func TestSlugify(t *testing.T) {
+	tests := []struct {
+		input string
+		want  string
+	}{
+		{"Oak Stair Tread", "oak-stair-tread"},
+		{"  Oak   Stair\tTread  ", "oak-stair-tread"},
+		{"Über Oak & Maple Treads!", "uber-oak-and-maple-treads"},
+		{"Crème Brûlée", "creme-brulee"},
+		{"Mike's Handrails", "mikes-handrails"},
+		{"Mike’s Handrails", "mikes-handrails"},
+		{"Straße", "strasse"},
+		{"Smørrebrød", "smorrebrod"},
+		{"Łódź", "lodz"},
+		{"36\" x 11-1/2\" Tread", "36-x-11-1-2-tread"},
+		{"--already-a-slug--", "already-a-slug"},
+		{"café_menu.v2", "cafe-menu-v2"},
+		{"日本語", ""},
+		{"Stairs 日本 Parts", "stairs-parts"},
+		{"", ""},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.input, func(t *testing.T) {
+			if got := Slugify(tc.input); got != tc.want {
+				t.Errorf("Slugify(%q) = %q, want %q", tc.input, got, tc.want)
+			}
+		})
+	}
+}
+
+func TestRemoveDiacritics(t *testing.T) {
+	tests := []struct {
+		input string
+		want  string
+	}{
+		{"Crème Brûlée", "Creme Brulee"},
+		{"Ångström", "Angstrom"},
+		{"naïve façade", "naive facade"},
+		{"Straße", "Straße"},
+		{"plain ascii", "plain ascii"},
+	}
+
+	for _, tc := range tests {
+		if got := RemoveDiacritics(tc.input); got != tc.want {
+			t.Errorf("RemoveDiacritics(%q) = %q, want %q", tc.input, got, tc.want)
+		}
+	}
+}
+
+func TestNormalizeWhitespace(t *testing.T) {
+	tests := []struct {
+		input string
+		want  string
+	}{
+		{"  hello   world  ", "hello world"},
+		{"line\none\r\n\ttwo", "line one two"},
+		{"non\u00a0breaking\u2003space", "non breaking space"},
+		{"   ", ""},
+		{"single", "single"},
+	}
+
+	for _, tc := range tests {
+		if got := NormalizeWhitespace(tc.input); got != tc.want {
+			t.Errorf("NormalizeWhitespace(%q) = %q, want %q", tc.input, got, tc.want)
+		}
+	}
+}
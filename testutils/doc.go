@@ -0,0 +1,61 @@
+/*
+Package testutils provides shared fixtures for service test suites.
+
+It collects the helpers that every service otherwise re-implements: building JSON
+requests, asserting on api response envelopes, comparing against golden files,
+controlling time, capturing log output, and scoping environment variables.
+
+# HTTP Helpers
+
+Building a request and asserting on the api success envelope:
+
+	req := testutils.NewJSONRequest(t, http.MethodPost, "/orders", CreateOrderRequest{SKU: "TRD-36"})
+	rec := testutils.Serve(router, req)
+
+	var order Order
+	testutils.AssertSuccess(t, rec, &order)
+
+Asserting on an api error envelope:
+
+	rec := testutils.Serve(router, testutils.NewJSONRequest(t, http.MethodGet, "/orders/999", nil))
+	testutils.AssertError(t, rec, http.StatusNotFound, "order not found")
+
+# Golden Files
+
+Golden compares output with testdata/<name>.golden. Set UPDATE_GOLDEN=1 to write
+the current output instead:
+
+	testutils.GoldenJSON(t, "invoice", invoice)
+
+	UPDATE_GOLDEN=1 go test ./...
+
+# Fake Clock
+
+FakeClock is advanced manually. Pass its Now method to code that accepts a
+func() time.Time:
+
+	clock := testutils.NewFakeClock(time.Date(2024, 1, 1, 9, 0, 0, 0, time.UTC))
+	svc := NewService(WithNow(clock.Now))
+
+	clock.Advance(24 * time.Hour)
+
+# Captured Logger
+
+NewTestLogger is deprecated in favour of logger/logtest, whose Recorder
+queries and asserts on the recorded entries:
+
+	log, rec := logtest.NewTestLogger(t)
+	svc := NewService(log)
+
+	svc.Process(ctx)
+	rec.AssertLogged(zapcore.InfoLevel, "order processed", nil)
+
+# Environment Variables
+
+SetEnv and UnsetEnv change environment variables for the duration of a test and
+restore them afterwards:
+
+	testutils.SetEnv(t, map[string]string{"APP_ENV": "staging", "PORT": "9090"})
+	testutils.UnsetEnv(t, "DATABASE_URL")
+*/
+package testutils
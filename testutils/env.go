@@ -0,0 +1,26 @@
+package testutils
+
+import (
+	"os"
+	"testing"
+)
+
+// SetEnv sets each environment variable for the duration of the test and restores
+// the previous values afterwards. Like t.Setenv, it cannot be used in parallel tests.
+func SetEnv(t *testing.T, vars map[string]string) {
+	t.Helper()
+	for k, v := range vars {
+		t.Setenv(k, v)
+	}
+}
+
+// UnsetEnv unsets each environment variable for the duration of the test and
+// restores the previous values afterwards
+func UnsetEnv(t *testing.T, keys ...string) {
+	t.Helper()
+	for _, k := range keys {
+		// t.Setenv registers the cleanup that restores the original value
+		t.Setenv(k, "")
+		os.Unsetenv(k)
+	}
+}
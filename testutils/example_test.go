@@ -0,0 +1,18 @@
+package testutils_test
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/StairSupplies/go-core/testutils"
+)
+
+func ExampleFakeClock() {
+	clock := testutils.NewFakeClock(time.Date(2024, 1, 1, 9, 0, 0, 0, time.UTC))
+
+	timeout := clock.After(5 * time.Minute)
+	clock.Advance(5 * time.Minute)
+
+	fmt.Println((<-timeout).Format(time.Kitchen))
+	// Output: 9:05AM
+}
@@ -0,0 +1,55 @@
+package testutils
+
+import (
+	"bytes"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+// UpdateGoldenEnv is the environment variable that makes Golden rewrite golden files
+// instead of comparing against them, e.g. UPDATE_GOLDEN=1 go test ./...
+const UpdateGoldenEnv = "UPDATE_GOLDEN"
+
+// Golden compares got with the contents of testdata/<name>.golden. When
+// UPDATE_GOLDEN is set, the file is written with got instead.
+func Golden(t testing.TB, name string, got []byte) {
+	t.Helper()
+
+	path := filepath.Join("testdata", name+".golden")
+
+	if os.Getenv(UpdateGoldenEnv) != "" {
+		if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+			t.Fatalf("Failed to create golden directory: %v", err)
+			return
+		}
+		if err := os.WriteFile(path, got, 0o644); err != nil {
+			t.Fatalf("Failed to update golden file: %v", err)
+		}
+		return
+	}
+
+	want, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("Failed to read golden file (run with %s=1 to create it): %v", UpdateGoldenEnv, err)
+		return
+	}
+
+	if !bytes.Equal(got, want) {
+		t.Errorf("Output does not match %s (run with %s=1 to update)\n--- got ---\n%s\n--- want ---\n%s",
+			path, UpdateGoldenEnv, got, want)
+	}
+}
+
+// GoldenJSON encodes v as indented JSON and compares it with testdata/<name>.golden
+func GoldenJSON(t testing.TB, name string, v any) {
+	t.Helper()
+
+	got, err := json.MarshalIndent(v, "", "  ")
+	if err != nil {
+		t.Fatalf("Failed to encode golden value: %v", err)
+		return
+	}
+	Golden(t, name, append(got, '\n'))
+}
@@ -0,0 +1,127 @@
+package testutils
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+// NewJSONRequest returns an httptest request with body encoded as JSON and the
+// Content-Type header set. A nil body sends no payload; []byte and string bodies
+// are sent as-is.
+func NewJSONRequest(t testing.TB, method, target string, body any) *http.Request {
+	t.Helper()
+
+	var r io.Reader
+	switch b := body.(type) {
+	case nil:
+	case []byte:
+		r = bytes.NewReader(b)
+	case string:
+		r = strings.NewReader(b)
+	default:
+		data, err := json.Marshal(body)
+		if err != nil {
+			t.Fatalf("Failed to encode request body: %v", err)
+			return nil
+		}
+		r = bytes.NewReader(data)
+	}
+
+	req := httptest.NewRequest(method, target, r)
+	if r != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	req.Header.Set("Accept", "application/json")
+	return req
+}
+
+// Serve runs req through h and returns the recorded response
+func Serve(h http.Handler, req *http.Request) *httptest.ResponseRecorder {
+	rec := httptest.NewRecorder()
+	h.ServeHTTP(rec, req)
+	return rec
+}
+
+// DecodeJSON decodes the response body into v
+func DecodeJSON(t testing.TB, rec *httptest.ResponseRecorder, v any) {
+	t.Helper()
+	if err := json.Unmarshal(rec.Body.Bytes(), v); err != nil {
+		t.Fatalf("Failed to decode response body %q: %v", rec.Body.String(), err)
+	}
+}
+
+// AssertStatus fails the test if the response status is not want
+func AssertStatus(t testing.TB, rec *httptest.ResponseRecorder, want int) {
+	t.Helper()
+	if rec.Code != want {
+		t.Errorf("Expected status %d, got %d: %s", want, rec.Code, rec.Body.String())
+	}
+}
+
+// successEnvelope mirrors api.SuccessResponse with a raw payload
+type successEnvelope struct {
+	StatusCode int             `json:"status_code"`
+	Data       json.RawMessage `json:"data"`
+	Meta       json.RawMessage `json:"meta"`
+}
+
+// AssertSuccess checks that the response is a 2xx api success envelope and decodes
+// its data into data, which may be nil to skip decoding
+func AssertSuccess(t testing.TB, rec *httptest.ResponseRecorder, data any) {
+	t.Helper()
+
+	if rec.Code < 200 || rec.Code > 299 {
+		t.Fatalf("Expected a success status, got %d: %s", rec.Code, rec.Body.String())
+		return
+	}
+
+	var env successEnvelope
+	if err := json.Unmarshal(rec.Body.Bytes(), &env); err != nil || env.Data == nil {
+		t.Fatalf("Expected an api success envelope, got %q", rec.Body.String())
+		return
+	}
+	if env.StatusCode != rec.Code {
+		t.Errorf("Expected envelope status_code %d to match response status %d", env.StatusCode, rec.Code)
+	}
+
+	if data != nil {
+		if err := json.Unmarshal(env.Data, data); err != nil {
+			t.Fatalf("Failed to decode envelope data %s: %v", env.Data, err)
+		}
+	}
+}
+
+// errorEnvelope mirrors the body written by api.WriteError
+type errorEnvelope struct {
+	Error *struct {
+		StatusCode int    `json:"status_code"`
+		Message    string `json:"message"`
+	} `json:"error"`
+}
+
+// AssertError checks that the response is an api error envelope with the given
+// status whose message contains msg. An empty msg matches any message.
+func AssertError(t testing.TB, rec *httptest.ResponseRecorder, status int, msg string) {
+	t.Helper()
+
+	if rec.Code != status {
+		t.Errorf("Expected status %d, got %d: %s", status, rec.Code, rec.Body.String())
+	}
+
+	var env errorEnvelope
+	if err := json.Unmarshal(rec.Body.Bytes(), &env); err != nil || env.Error == nil {
+		t.Fatalf("Expected an api error envelope, got %q", rec.Body.String())
+		return
+	}
+	if env.Error.StatusCode != status {
+		t.Errorf("Expected error status_code %d, got %d", status, env.Error.StatusCode)
+	}
+	if !strings.Contains(env.Error.Message, msg) {
+		t.Errorf("Expected error message to contain %q, got %q", msg, env.Error.Message)
+	}
+}
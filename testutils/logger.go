@@ -0,0 +1,20 @@
+package testutils
+
+import (
+	"testing"
+
+	"github.com/StairSupplies/go-core/logger"
+	"github.com/StairSupplies/go-core/logger/logtest"
+	"go.uber.org/zap/zaptest/observer"
+)
+
+// NewTestLogger returns a logger that records every entry at debug level and above.
+// Inspect the returned ObservedLogs to assert on what was logged.
+//
+// Deprecated: Use logtest.NewTestLogger, whose Recorder adds assertions on
+// the recorded entries.
+func NewTestLogger(t testing.TB) (*logger.Logger, *observer.ObservedLogs) {
+	t.Helper()
+	log, rec := logtest.NewTestLogger(t)
+	return log, rec.Observed()
+}
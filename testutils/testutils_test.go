@@ -0,0 +1,161 @@
+package testutils
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/StairSupplies/go-core/api"
+)
+
+// recordingT captures failures instead of failing the enclosing test
+type recordingT struct {
+	testing.TB
+	failures []string
+}
+
+func (r *recordingT) Helper() {}
+
+func (r *recordingT) Errorf(format string, args ...any) {
+	r.failures = append(r.failures, fmt.Sprintf(format, args...))
+}
+
+func (r *recordingT) Fatalf(format string, args ...any) {
+	r.failures = append(r.failures, fmt.Sprintf(format, args...))
+}
+
+func TestHTTPAssertions(t *testing.T) {
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Content-Type") != "application/json" {
+			api.WriteError(w, api.BadRequestError(errors.New("expected JSON")))
+			return
+		}
+		if r.URL.Path == "/missing" {
+			api.WriteError(w, api.NotFoundError(errors.New("order not found")))
+			return
+		}
+		api.WriteSuccess(w, map[string]int{"id": 42})
+	})
+
+	t.Run("success", func(t *testing.T) {
+		rec := Serve(handler, NewJSONRequest(t, http.MethodPost, "/orders", map[string]string{"sku": "TRD-36"}))
+
+		var data struct {
+			ID int `json:"id"`
+		}
+		AssertStatus(t, rec, http.StatusOK)
+		AssertSuccess(t, rec, &data)
+		if data.ID != 42 {
+			t.Errorf("Expected id 42, got %d", data.ID)
+		}
+	})
+
+	t.Run("error", func(t *testing.T) {
+		rec := Serve(handler, NewJSONRequest(t, http.MethodGet, "/missing", "{}"))
+		AssertError(t, rec, http.StatusNotFound, "not found")
+	})
+
+	t.Run("failures are reported", func(t *testing.T) {
+		rec := Serve(handler, NewJSONRequest(t, http.MethodGet, "/missing", "{}"))
+
+		rt := &recordingT{}
+		AssertSuccess(rt, rec, nil)
+		AssertError(rt, rec, http.StatusBadRequest, "expected JSON")
+		if len(rt.failures) != 4 {
+			t.Errorf("Expected 4 failures, got %d: %v", len(rt.failures), rt.failures)
+		}
+	})
+}
+
+func TestGolden(t *testing.T) {
+	dir := t.TempDir()
+	wd, _ := os.Getwd()
+	if err := os.Chdir(dir); err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { os.Chdir(wd) })
+
+	t.Setenv(UpdateGoldenEnv, "1")
+	GoldenJSON(t, "order", map[string]int{"id": 1})
+
+	if _, err := os.Stat(filepath.Join(dir, "testdata", "order.golden")); err != nil {
+		t.Fatalf("Expected golden file to be written: %v", err)
+	}
+
+	os.Unsetenv(UpdateGoldenEnv)
+	GoldenJSON(t, "order", map[string]int{"id": 1})
+
+	rt := &recordingT{}
+	GoldenJSON(rt, "order", map[string]int{"id": 2})
+	if len(rt.failures) != 1 {
+		t.Errorf("Expected mismatch to be reported, got %v", rt.failures)
+	}
+}
+
+func TestFakeClock(t *testing.T) {
+	start := time.Date(2024, 1, 1, 9, 0, 0, 0, time.UTC)
+	clock := NewFakeClock(start)
+
+	ch := clock.After(time.Minute)
+	clock.Advance(30 * time.Second)
+	select {
+	case <-ch:
+		t.Fatal("Expected After not to fire early")
+	default:
+	}
+
+	clock.Advance(30 * time.Second)
+	select {
+	case got := <-ch:
+		if !got.Equal(start.Add(time.Minute)) {
+			t.Errorf("Expected %v, got %v", start.Add(time.Minute), got)
+		}
+	default:
+		t.Fatal("Expected After to fire once due")
+	}
+
+	if clock.Since(start) != time.Minute {
+		t.Errorf("Expected 1m elapsed, got %v", clock.Since(start))
+	}
+}
+
+func TestNewTestLogger(t *testing.T) {
+	log, logs := NewTestLogger(t)
+	log.Debug("debug message")
+	log.Infow("order created", "order_id", 42)
+
+	if logs.Len() != 2 {
+		t.Fatalf("Expected 2 entries, got %d", logs.Len())
+	}
+	if got := logs.FilterMessage("order created").All()[0].ContextMap()["order_id"]; got != int64(42) {
+		t.Errorf("Expected order_id 42, got %v", got)
+	}
+}
+
+func TestEnv(t *testing.T) {
+	os.Setenv("TESTUTILS_EXISTING", "original")
+	defer os.Unsetenv("TESTUTILS_EXISTING")
+
+	t.Run("scoped", func(t *testing.T) {
+		SetEnv(t, map[string]string{"TESTUTILS_NEW": "value"})
+		UnsetEnv(t, "TESTUTILS_EXISTING")
+
+		if os.Getenv("TESTUTILS_NEW") != "value" {
+			t.Error("Expected TESTUTILS_NEW to be set")
+		}
+		if _, ok := os.LookupEnv("TESTUTILS_EXISTING"); ok {
+			t.Error("Expected TESTUTILS_EXISTING to be unset")
+		}
+	})
+
+	if _, ok := os.LookupEnv("TESTUTILS_NEW"); ok {
+		t.Error("Expected TESTUTILS_NEW to be removed after the test")
+	}
+	if os.Getenv("TESTUTILS_EXISTING") != "original" {
+		t.Error("Expected TESTUTILS_EXISTING to be restored after the test")
+	}
+}
@@ -0,0 +1,56 @@
+package timeutils
+
+import "time"
+
+// The Between functions count whole calendar units between the dates of two
+// times, each read in its own location, and ignore the time of day. The
+// result is negative when to is before from. A month is complete when the
+// day of the month is reached again, or the last day of a shorter month:
+// from January 31, one month has passed on February 29 in a leap year, and
+// a year has passed since February 29 on February 28.
+
+// DaysBetween returns the number of calendar days from from to to. Unlike
+// dividing to.Sub(from) by 24 hours, it is not thrown off by daylight
+// saving transitions or the time of day.
+func DaysBetween(from, to time.Time) int {
+	fy, fm, fd := from.Date()
+	ty, tm, td := to.Date()
+	start := time.Date(fy, fm, fd, 0, 0, 0, 0, time.UTC)
+	end := time.Date(ty, tm, td, 0, 0, 0, 0, time.UTC)
+	return int(end.Sub(start) / (24 * time.Hour))
+}
+
+// MonthsBetween returns the number of complete calendar months from from to
+// to, so January 15 to March 14 is one month
+func MonthsBetween(from, to time.Time) int {
+	fy, fm, fd := from.Date()
+	ty, tm, td := to.Date()
+	if ty < fy || ty == fy && (tm < fm || tm == fm && td < fd) {
+		return -MonthsBetween(to, from)
+	}
+
+	months := (ty-fy)*12 + int(tm-fm)
+	if td < min(fd, daysIn(ty, tm)) {
+		months--
+	}
+	return months
+}
+
+// YearsBetween returns the number of complete calendar years from from to to
+func YearsBetween(from, to time.Time) int {
+	return MonthsBetween(from, to) / 12
+}
+
+// Age returns the age in whole years on asOf of someone born on birthdate.
+// Someone born on February 29 turns a year older on February 28 in common
+// years.
+//
+//	timeutils.Age(customer.Birthdate, time.Now()) >= 21
+func Age(birthdate, asOf time.Time) int {
+	return YearsBetween(birthdate, asOf)
+}
+
+// daysIn returns the number of days in month m of year y
+func daysIn(y int, m time.Month) int {
+	return time.Date(y, m+1, 0, 0, 0, 0, 0, time.UTC).Day()
+}
@@ -0,0 +1,110 @@
+package timeutils
+
+import (
+	"testing"
+	"time"
+)
+
+func day(y int, m time.Month, d int) time.Time {
+	return time.Date(y, m, d, 0, 0, 0, 0, time.UTC)
+}
+
+func TestMonthsBetween(t *testing.T) {
+	tests := []struct {
+		name     string
+		from, to time.Time
+		want     int
+	}{
+		{"same day", day(2024, time.May, 15), day(2024, time.May, 15), 0},
+		{"day before anniversary", day(2024, time.January, 15), day(2024, time.March, 14), 1},
+		{"on anniversary", day(2024, time.January, 15), day(2024, time.March, 15), 2},
+		{"end of month to leap February", day(2024, time.January, 31), day(2024, time.February, 29), 1},
+		{"end of month before leap day", day(2024, time.January, 31), day(2024, time.February, 28), 0},
+		{"end of month to common February", day(2023, time.January, 31), day(2023, time.February, 28), 1},
+		{"end of month to April", day(2024, time.March, 31), day(2024, time.April, 30), 1},
+		{"across years", day(2023, time.November, 20), day(2025, time.February, 19), 14},
+		{"backwards", day(2024, time.March, 15), day(2024, time.January, 15), -2},
+		{"backwards partial", day(2024, time.March, 14), day(2024, time.January, 15), -1},
+		{"time of day ignored", time.Date(2024, time.January, 15, 23, 0, 0, 0, time.UTC), time.Date(2024, time.February, 15, 1, 0, 0, 0, time.UTC), 1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := MonthsBetween(tt.from, tt.to); got != tt.want {
+				t.Errorf("Expected %d, got %d", tt.want, got)
+			}
+		})
+	}
+}
+
+func TestYearsBetweenAndAge(t *testing.T) {
+	tests := []struct {
+		name     string
+		from, to time.Time
+		want     int
+	}{
+		{"day before birthday", day(1990, time.June, 10), day(2024, time.June, 9), 33},
+		{"on birthday", day(1990, time.June, 10), day(2024, time.June, 10), 34},
+		{"leap day birthday in common year", day(2000, time.February, 29), day(2023, time.February, 28), 23},
+		{"leap day birthday before", day(2000, time.February, 29), day(2023, time.February, 27), 22},
+		{"leap day birthday in leap year", day(2000, time.February, 29), day(2024, time.February, 28), 23},
+		{"not yet born", day(2024, time.June, 10), day(2023, time.June, 10), -1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := YearsBetween(tt.from, tt.to); got != tt.want {
+				t.Errorf("YearsBetween: expected %d, got %d", tt.want, got)
+			}
+			if got := Age(tt.from, tt.to); got != tt.want {
+				t.Errorf("Age: expected %d, got %d", tt.want, got)
+			}
+		})
+	}
+}
+
+func TestAge_Locations(t *testing.T) {
+	loc, err := time.LoadLocation("America/Los_Angeles")
+	if err != nil {
+		t.Skipf("time zone data not available: %v", err)
+	}
+
+	// A birthdate stored as midnight UTC keeps its date even when compared
+	// with a local time that is still on the previous day in UTC terms
+	birthdate := day(2000, time.March, 10)
+	asOf := time.Date(2021, time.March, 10, 8, 0, 0, 0, loc) // March 10 16:00 UTC
+	if got := Age(birthdate, asOf); got != 21 {
+		t.Errorf("Expected 21, got %d", got)
+	}
+	asOf = time.Date(2021, time.March, 9, 20, 0, 0, 0, loc) // March 10 04:00 UTC
+	if got := Age(birthdate, asOf); got != 20 {
+		t.Errorf("Expected local date to be used, got %d", got)
+	}
+}
+
+func TestDaysBetween(t *testing.T) {
+	loc, err := time.LoadLocation("America/New_York")
+	if err != nil {
+		t.Skipf("time zone data not available: %v", err)
+	}
+
+	tests := []struct {
+		name     string
+		from, to time.Time
+		want     int
+	}{
+		{"same day", day(2024, time.May, 15), time.Date(2024, time.May, 15, 23, 0, 0, 0, time.UTC), 0},
+		{"next day within hours", time.Date(2024, time.May, 15, 23, 0, 0, 0, time.UTC), time.Date(2024, time.May, 16, 1, 0, 0, 0, time.UTC), 1},
+		{"leap year", day(2024, time.January, 1), day(2025, time.January, 1), 366},
+		{"across spring forward", time.Date(2024, time.March, 9, 12, 0, 0, 0, loc), time.Date(2024, time.March, 11, 0, 30, 0, 0, loc), 2},
+		{"backwards", day(2024, time.March, 1), day(2024, time.February, 1), -29},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := DaysBetween(tt.from, tt.to); got != tt.want {
+				t.Errorf("Expected %d, got %d", tt.want, got)
+			}
+		})
+	}
+}
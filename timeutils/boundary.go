@@ -0,0 +1,82 @@
+package timeutils
+
+import "time"
+
+// The End functions return the last nanosecond of the period, so a period
+// can be matched inclusively with !t.Before(start) && !t.After(end). All
+// boundaries are computed in t's location and are correct across daylight
+// saving transitions.
+
+// StartOfDay returns midnight at the start of t's day
+func StartOfDay(t time.Time) time.Time {
+	y, m, d := t.Date()
+	return time.Date(y, m, d, 0, 0, 0, 0, t.Location())
+}
+
+// EndOfDay returns the last nanosecond of t's day
+func EndOfDay(t time.Time) time.Time {
+	y, m, d := t.Date()
+	return time.Date(y, m, d+1, 0, 0, 0, 0, t.Location()).Add(-time.Nanosecond)
+}
+
+// StartOfWeek returns the start of the week containing t, for weeks that
+// begin on weekStart. Use time.Monday for ISO 8601 weeks.
+func StartOfWeek(t time.Time, weekStart time.Weekday) time.Time {
+	y, m, d := t.Date()
+	offset := (int(t.Weekday()) - int(weekStart) + 7) % 7
+	return time.Date(y, m, d-offset, 0, 0, 0, 0, t.Location())
+}
+
+// EndOfWeek returns the last nanosecond of the week containing t, for weeks
+// that begin on weekStart
+func EndOfWeek(t time.Time, weekStart time.Weekday) time.Time {
+	y, m, d := StartOfWeek(t, weekStart).Date()
+	return time.Date(y, m, d+7, 0, 0, 0, 0, t.Location()).Add(-time.Nanosecond)
+}
+
+// StartOfMonth returns the start of the first day of t's month
+func StartOfMonth(t time.Time) time.Time {
+	y, m, _ := t.Date()
+	return time.Date(y, m, 1, 0, 0, 0, 0, t.Location())
+}
+
+// EndOfMonth returns the last nanosecond of t's month
+func EndOfMonth(t time.Time) time.Time {
+	y, m, _ := t.Date()
+	return time.Date(y, m+1, 1, 0, 0, 0, 0, t.Location()).Add(-time.Nanosecond)
+}
+
+// Quarter returns the calendar quarter of t, from 1 to 4
+func Quarter(t time.Time) int {
+	return (int(t.Month())-1)/3 + 1
+}
+
+// StartOfQuarter returns the start of the first day of t's calendar quarter
+func StartOfQuarter(t time.Time) time.Time {
+	first := time.Month((Quarter(t)-1)*3 + 1)
+	return time.Date(t.Year(), first, 1, 0, 0, 0, 0, t.Location())
+}
+
+// EndOfQuarter returns the last nanosecond of t's calendar quarter
+func EndOfQuarter(t time.Time) time.Time {
+	start := StartOfQuarter(t)
+	return time.Date(start.Year(), start.Month()+3, 1, 0, 0, 0, 0, t.Location()).Add(-time.Nanosecond)
+}
+
+// StartOfYear returns the start of January 1 of t's year
+func StartOfYear(t time.Time) time.Time {
+	return time.Date(t.Year(), time.January, 1, 0, 0, 0, 0, t.Location())
+}
+
+// EndOfYear returns the last nanosecond of t's year
+func EndOfYear(t time.Time) time.Time {
+	return time.Date(t.Year()+1, time.January, 1, 0, 0, 0, 0, t.Location()).Add(-time.Nanosecond)
+}
+
+// WeekOfYear returns the ISO 8601 year and week number of t. Weeks start on
+// Monday and week 1 contains the year's first Thursday, so dates in early
+// January can belong to the previous year's last week and dates in late
+// December to the next year's first.
+func WeekOfYear(t time.Time) (year, week int) {
+	return t.ISOWeek()
+}
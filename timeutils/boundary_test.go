@@ -0,0 +1,93 @@
+package timeutils
+
+import (
+	"testing"
+	"time"
+)
+
+func TestBoundaries(t *testing.T) {
+	loc, err := time.LoadLocation("America/New_York")
+	if err != nil {
+		t.Skipf("time zone data not available: %v", err)
+	}
+	date := func(y int, m time.Month, d, h, min int) time.Time {
+		return time.Date(y, m, d, h, min, 0, 0, loc)
+	}
+	end := func(y int, m time.Month, d int) time.Time {
+		return time.Date(y, m, d, 23, 59, 59, 999999999, loc)
+	}
+
+	// Wednesday, May 15 2024
+	ts := date(2024, time.May, 15, 14, 30)
+
+	tests := []struct {
+		name string
+		got  time.Time
+		want time.Time
+	}{
+		{"StartOfDay", StartOfDay(ts), date(2024, time.May, 15, 0, 0)},
+		{"EndOfDay", EndOfDay(ts), end(2024, time.May, 15)},
+		{"StartOfWeek Monday", StartOfWeek(ts, time.Monday), date(2024, time.May, 13, 0, 0)},
+		{"EndOfWeek Monday", EndOfWeek(ts, time.Monday), end(2024, time.May, 19)},
+		{"StartOfWeek Sunday", StartOfWeek(ts, time.Sunday), date(2024, time.May, 12, 0, 0)},
+		{"EndOfWeek Sunday", EndOfWeek(ts, time.Sunday), end(2024, time.May, 18)},
+		{"StartOfWeek on week start", StartOfWeek(date(2024, time.May, 13, 9, 0), time.Monday), date(2024, time.May, 13, 0, 0)},
+		{"StartOfWeek across month", StartOfWeek(date(2024, time.March, 2, 9, 0), time.Monday), date(2024, time.February, 26, 0, 0)},
+		{"StartOfWeek across year", StartOfWeek(date(2025, time.January, 1, 9, 0), time.Monday), date(2024, time.December, 30, 0, 0)},
+		{"StartOfMonth", StartOfMonth(ts), date(2024, time.May, 1, 0, 0)},
+		{"EndOfMonth", EndOfMonth(ts), end(2024, time.May, 31)},
+		{"EndOfMonth leap February", EndOfMonth(date(2024, time.February, 10, 0, 0)), end(2024, time.February, 29)},
+		{"EndOfMonth February", EndOfMonth(date(2023, time.February, 10, 0, 0)), end(2023, time.February, 28)},
+		{"StartOfQuarter", StartOfQuarter(ts), date(2024, time.April, 1, 0, 0)},
+		{"EndOfQuarter", EndOfQuarter(ts), end(2024, time.June, 30)},
+		{"StartOfQuarter Q4", StartOfQuarter(date(2024, time.December, 31, 23, 0)), date(2024, time.October, 1, 0, 0)},
+		{"EndOfQuarter Q4", EndOfQuarter(date(2024, time.November, 1, 0, 0)), end(2024, time.December, 31)},
+		{"StartOfYear", StartOfYear(ts), date(2024, time.January, 1, 0, 0)},
+		{"EndOfYear", EndOfYear(ts), end(2024, time.December, 31)},
+		// Clocks went back on November 3 2024, making it 25 hours long
+		{"EndOfDay fall back", EndOfDay(date(2024, time.November, 3, 12, 0)), end(2024, time.November, 3)},
+		{"StartOfDay spring forward", StartOfDay(date(2024, time.March, 10, 12, 0)), date(2024, time.March, 10, 0, 0)},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			if !tc.got.Equal(tc.want) {
+				t.Errorf("Expected %v, got %v", tc.want, tc.got)
+			}
+			if tc.got.Location() != loc {
+				t.Errorf("Expected location %v, got %v", loc, tc.got.Location())
+			}
+		})
+	}
+}
+
+func TestQuarter(t *testing.T) {
+	for month, want := range map[time.Month]int{
+		time.January: 1, time.March: 1, time.April: 2, time.June: 2,
+		time.July: 3, time.September: 3, time.October: 4, time.December: 4,
+	} {
+		if got := Quarter(time.Date(2024, month, 15, 0, 0, 0, 0, time.UTC)); got != want {
+			t.Errorf("Quarter(%s) = %d, want %d", month, got, want)
+		}
+	}
+}
+
+func TestWeekOfYear(t *testing.T) {
+	tests := []struct {
+		date     time.Time
+		wantYear int
+		wantWeek int
+	}{
+		{time.Date(2024, time.May, 15, 0, 0, 0, 0, time.UTC), 2024, 20},
+		{time.Date(2021, time.January, 3, 0, 0, 0, 0, time.UTC), 2020, 53},
+		{time.Date(2024, time.December, 30, 0, 0, 0, 0, time.UTC), 2025, 1},
+		{time.Date(2026, time.January, 1, 0, 0, 0, 0, time.UTC), 2026, 1},
+	}
+
+	for _, tc := range tests {
+		year, week := WeekOfYear(tc.date)
+		if year != tc.wantYear || week != tc.wantWeek {
+			t.Errorf("WeekOfYear(%s) = %d-W%d, want %d-W%d", tc.date.Format("2006-01-02"), year, week, tc.wantYear, tc.wantWeek)
+		}
+	}
+}
@@ -0,0 +1,209 @@
+package timeutils
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"time"
+)
+
+// ErrInvalidWindow is returned for business hours that do not fit in a day
+var ErrInvalidWindow = errors.New("invalid business hours window")
+
+// maxSearchDays bounds how far ahead NextOpen and AddBusinessTime look, so a
+// schedule with no open hours cannot loop forever
+const maxSearchDays = 400
+
+// Window is a span of opening hours within a day, as offsets from midnight.
+// Close may be 24h for hours that run to the end of the day.
+type Window struct {
+	Open  time.Duration
+	Close time.Duration
+}
+
+// BusinessHours is a weekly schedule of opening hours in a time zone, with
+// optional holidays on which the business is closed all day
+type BusinessHours struct {
+	loc      *time.Location
+	days     [7][]Window
+	holidays map[civilDate]bool
+}
+
+// civilDate is a calendar date independent of time zone
+type civilDate struct {
+	year  int
+	month time.Month
+	day   int
+}
+
+// NewBusinessHours creates an empty schedule in loc; nil means UTC.
+// Use SetHours to add opening hours.
+func NewBusinessHours(loc *time.Location) *BusinessHours {
+	if loc == nil {
+		loc = time.UTC
+	}
+	return &BusinessHours{loc: loc, holidays: make(map[civilDate]bool)}
+}
+
+// WeekdayHours creates a Monday to Friday schedule open from open to close
+func WeekdayHours(loc *time.Location, open, close time.Duration) (*BusinessHours, error) {
+	b := NewBusinessHours(loc)
+	for day := time.Monday; day <= time.Friday; day++ {
+		if err := b.SetHours(day, Window{Open: open, Close: close}); err != nil {
+			return nil, err
+		}
+	}
+	return b, nil
+}
+
+// SetHours replaces the opening hours for day. Overlapping windows are
+// merged; no windows means closed all day.
+func (b *BusinessHours) SetHours(day time.Weekday, windows ...Window) error {
+	sorted := make([]Window, 0, len(windows))
+	for _, w := range windows {
+		if w.Open < 0 || w.Close > 24*time.Hour || w.Open >= w.Close {
+			return fmt.Errorf("%w: %v to %v on %s", ErrInvalidWindow, w.Open, w.Close, day)
+		}
+		sorted = append(sorted, w)
+	}
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i].Open < sorted[j].Open })
+
+	var merged []Window
+	for _, w := range sorted {
+		if n := len(merged); n > 0 && w.Open <= merged[n-1].Close {
+			if w.Close > merged[n-1].Close {
+				merged[n-1].Close = w.Close
+			}
+			continue
+		}
+		merged = append(merged, w)
+	}
+	b.days[day] = merged
+	return nil
+}
+
+// AddHoliday closes the business for the whole calendar date of t, taken in
+// the schedule's location
+func (b *BusinessHours) AddHoliday(t time.Time) {
+	y, m, d := t.In(b.loc).Date()
+	b.holidays[civilDate{y, m, d}] = true
+}
+
+// Location returns the schedule's time zone
+func (b *BusinessHours) Location() *time.Location {
+	return b.loc
+}
+
+// IsOpen reports whether t falls within business hours
+func (b *BusinessHours) IsOpen(t time.Time) bool {
+	for _, s := range b.spans(t, 0) {
+		if !t.Before(s.start) && t.Before(s.end) {
+			return true
+		}
+	}
+	return false
+}
+
+// NextOpen returns t if the business is open at t, otherwise the next time
+// it opens. It returns the zero Time if the schedule has no open hours.
+func (b *BusinessHours) NextOpen(t time.Time) time.Time {
+	for i := 0; i < maxSearchDays; i++ {
+		for _, s := range b.spans(t, i) {
+			if s.end.After(t) {
+				return latest(s.start, t)
+			}
+		}
+	}
+	return time.Time{}
+}
+
+// DurationWithinBusinessHours returns how much of the interval from start to
+// end falls within business hours, e.g. the elapsed business time of an SLA
+func (b *BusinessHours) DurationWithinBusinessHours(start, end time.Time) time.Duration {
+	if !end.After(start) {
+		return 0
+	}
+
+	var total time.Duration
+	for i := 0; ; i++ {
+		spans := b.spans(start, i)
+		if len(spans) == 0 && b.dayStart(start, i).After(end) {
+			return total
+		}
+		for _, s := range spans {
+			if !s.start.Before(end) {
+				return total
+			}
+			from, to := latest(s.start, start), earliest(s.end, end)
+			if to.After(from) {
+				total += to.Sub(from)
+			}
+		}
+	}
+}
+
+// AddBusinessTime returns the time at which d of business time has elapsed
+// after t, e.g. the due date of an SLA. It returns the zero Time if the
+// schedule has no open hours.
+func (b *BusinessHours) AddBusinessTime(t time.Time, d time.Duration) time.Time {
+	for i := 0; i < maxSearchDays; i++ {
+		for _, s := range b.spans(t, i) {
+			from := latest(s.start, t)
+			if !s.end.After(from) {
+				continue
+			}
+			avail := s.end.Sub(from)
+			if d <= avail {
+				return from.Add(d)
+			}
+			d -= avail
+		}
+	}
+	return time.Time{}
+}
+
+// span is an absolute open interval
+type span struct {
+	start, end time.Time
+}
+
+// dayStart returns midnight of the date offset days after t's date
+func (b *BusinessHours) dayStart(t time.Time, offset int) time.Time {
+	y, m, d := t.In(b.loc).Date()
+	return time.Date(y, m, d+offset, 0, 0, 0, 0, b.loc)
+}
+
+// spans returns the open intervals on the date offset days after t's date.
+// Windows are resolved as wall-clock times so they stay correct across
+// daylight saving changes.
+func (b *BusinessHours) spans(t time.Time, offset int) []span {
+	day := b.dayStart(t, offset)
+	y, m, d := day.Date()
+	if b.holidays[civilDate{y, m, d}] {
+		return nil
+	}
+
+	windows := b.days[day.Weekday()]
+	spans := make([]span, len(windows))
+	for i, w := range windows {
+		spans[i] = span{
+			start: time.Date(y, m, d, 0, 0, int(w.Open/time.Second), 0, b.loc),
+			end:   time.Date(y, m, d, 0, 0, int(w.Close/time.Second), 0, b.loc),
+		}
+	}
+	return spans
+}
+
+func latest(a, b time.Time) time.Time {
+	if a.After(b) {
+		return a
+	}
+	return b
+}
+
+func earliest(a, b time.Time) time.Time {
+	if a.Before(b) {
+		return a
+	}
+	return b
+}
@@ -0,0 +1,160 @@
+package timeutils
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+func mustLoad(t *testing.T, name string) *time.Location {
+	t.Helper()
+	loc, err := time.LoadLocation(name)
+	if err != nil {
+		t.Skipf("time zone %s unavailable: %v", name, err)
+	}
+	return loc
+}
+
+// officeHours is 9 to 5, Monday to Friday, in New York
+func officeHours(t *testing.T) (*BusinessHours, *time.Location) {
+	t.Helper()
+	loc := mustLoad(t, "America/New_York")
+	b, err := WeekdayHours(loc, 9*time.Hour, 17*time.Hour)
+	if err != nil {
+		t.Fatalf("WeekdayHours() error = %v", err)
+	}
+	return b, loc
+}
+
+func TestBusinessHours_IsOpen(t *testing.T) {
+	b, loc := officeHours(t)
+
+	tests := []struct {
+		name string
+		t    time.Time
+		want bool
+	}{
+		{"Monday morning", time.Date(2024, 3, 4, 10, 0, 0, 0, loc), true},
+		{"at opening", time.Date(2024, 3, 4, 9, 0, 0, 0, loc), true},
+		{"at closing", time.Date(2024, 3, 4, 17, 0, 0, 0, loc), false},
+		{"before opening", time.Date(2024, 3, 4, 8, 59, 0, 0, loc), false},
+		{"Saturday", time.Date(2024, 3, 9, 12, 0, 0, 0, loc), false},
+		{"other time zone", time.Date(2024, 3, 4, 14, 30, 0, 0, time.UTC), true},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			if got := b.IsOpen(tc.t); got != tc.want {
+				t.Errorf("IsOpen(%v) = %v, want %v", tc.t, got, tc.want)
+			}
+		})
+	}
+}
+
+func TestBusinessHours_NextOpen(t *testing.T) {
+	b, loc := officeHours(t)
+	b.AddHoliday(time.Date(2024, 3, 11, 0, 0, 0, 0, loc))
+
+	tests := []struct {
+		name string
+		t    time.Time
+		want time.Time
+	}{
+		{"already open", time.Date(2024, 3, 4, 10, 0, 0, 0, loc), time.Date(2024, 3, 4, 10, 0, 0, 0, loc)},
+		{"early morning", time.Date(2024, 3, 4, 6, 0, 0, 0, loc), time.Date(2024, 3, 4, 9, 0, 0, 0, loc)},
+		{"evening", time.Date(2024, 3, 4, 18, 0, 0, 0, loc), time.Date(2024, 3, 5, 9, 0, 0, 0, loc)},
+		{"Friday evening", time.Date(2024, 3, 1, 18, 0, 0, 0, loc), time.Date(2024, 3, 4, 9, 0, 0, 0, loc)},
+		{"weekend before holiday", time.Date(2024, 3, 9, 12, 0, 0, 0, loc), time.Date(2024, 3, 12, 9, 0, 0, 0, loc)},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			if got := b.NextOpen(tc.t); !got.Equal(tc.want) {
+				t.Errorf("NextOpen(%v) = %v, want %v", tc.t, got, tc.want)
+			}
+		})
+	}
+
+	if got := NewBusinessHours(nil).NextOpen(time.Now()); !got.IsZero() {
+		t.Errorf("Expected zero time for a schedule with no hours, got %v", got)
+	}
+}
+
+func TestBusinessHours_DurationWithinBusinessHours(t *testing.T) {
+	b, loc := officeHours(t)
+
+	tests := []struct {
+		name       string
+		start, end time.Time
+		want       time.Duration
+	}{
+		{"same day", time.Date(2024, 3, 4, 10, 0, 0, 0, loc), time.Date(2024, 3, 4, 12, 30, 0, 0, loc), 150 * time.Minute},
+		{"outside hours", time.Date(2024, 3, 4, 18, 0, 0, 0, loc), time.Date(2024, 3, 4, 23, 0, 0, 0, loc), 0},
+		{"overnight", time.Date(2024, 3, 4, 16, 0, 0, 0, loc), time.Date(2024, 3, 5, 10, 0, 0, 0, loc), 2 * time.Hour},
+		{"over weekend", time.Date(2024, 3, 8, 16, 0, 0, 0, loc), time.Date(2024, 3, 11, 10, 0, 0, 0, loc), 2 * time.Hour},
+		{"full week", time.Date(2024, 3, 4, 0, 0, 0, 0, loc), time.Date(2024, 3, 11, 0, 0, 0, 0, loc), 40 * time.Hour},
+		{"across DST change", time.Date(2024, 3, 8, 0, 0, 0, 0, loc), time.Date(2024, 3, 12, 0, 0, 0, 0, loc), 16 * time.Hour},
+		{"end before start", time.Date(2024, 3, 5, 0, 0, 0, 0, loc), time.Date(2024, 3, 4, 0, 0, 0, 0, loc), 0},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			if got := b.DurationWithinBusinessHours(tc.start, tc.end); got != tc.want {
+				t.Errorf("DurationWithinBusinessHours() = %v, want %v", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestBusinessHours_AddBusinessTime(t *testing.T) {
+	b, loc := officeHours(t)
+
+	tests := []struct {
+		name string
+		t    time.Time
+		d    time.Duration
+		want time.Time
+	}{
+		{"within the day", time.Date(2024, 3, 4, 10, 0, 0, 0, loc), 2 * time.Hour, time.Date(2024, 3, 4, 12, 0, 0, 0, loc)},
+		{"rolls to next day", time.Date(2024, 3, 4, 16, 0, 0, 0, loc), 4 * time.Hour, time.Date(2024, 3, 5, 12, 0, 0, 0, loc)},
+		{"exactly to closing", time.Date(2024, 3, 4, 16, 0, 0, 0, loc), time.Hour, time.Date(2024, 3, 4, 17, 0, 0, 0, loc)},
+		{"starts after hours", time.Date(2024, 3, 8, 20, 0, 0, 0, loc), 8 * time.Hour, time.Date(2024, 3, 11, 17, 0, 0, 0, loc)},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			if got := b.AddBusinessTime(tc.t, tc.d); !got.Equal(tc.want) {
+				t.Errorf("AddBusinessTime() = %v, want %v", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestBusinessHours_SetHours(t *testing.T) {
+	b := NewBusinessHours(time.UTC)
+
+	if err := b.SetHours(time.Monday, Window{Open: 17 * time.Hour, Close: 9 * time.Hour}); !errors.Is(err, ErrInvalidWindow) {
+		t.Errorf("Expected ErrInvalidWindow, got %v", err)
+	}
+	if err := b.SetHours(time.Monday, Window{Open: 0, Close: 25 * time.Hour}); !errors.Is(err, ErrInvalidWindow) {
+		t.Errorf("Expected ErrInvalidWindow, got %v", err)
+	}
+
+	// Overlapping and split windows
+	err := b.SetHours(time.Monday,
+		Window{Open: 13 * time.Hour, Close: 17 * time.Hour},
+		Window{Open: 8 * time.Hour, Close: 12 * time.Hour},
+		Window{Open: 11 * time.Hour, Close: 12 * time.Hour},
+	)
+	if err != nil {
+		t.Fatalf("SetHours() error = %v", err)
+	}
+
+	monday := time.Date(2024, 3, 4, 0, 0, 0, 0, time.UTC)
+	if got := b.DurationWithinBusinessHours(monday, monday.Add(24*time.Hour)); got != 8*time.Hour {
+		t.Errorf("Expected 8h of business time, got %v", got)
+	}
+	if b.IsOpen(monday.Add(12*time.Hour + 30*time.Minute)) {
+		t.Error("Expected lunch break to be closed")
+	}
+}
@@ -0,0 +1,108 @@
+/*
+Package timeutils provides time helpers that go beyond the standard library,
+such as timezone-aware business hours and humanized relative times.
+
+# Features
+
+  - Weekly business hour schedules with split shifts and holidays
+  - IsOpen and NextOpen for checking availability
+  - Elapsed business time between two instants, for SLA tracking
+  - Business-time deadlines with AddBusinessTime
+  - Correct handling of daylight saving transitions
+  - Relative times such as "3 minutes ago" with configurable thresholds and localization
+  - Day, week, month, quarter and year boundaries, and ISO week numbers
+  - Calendar-correct differences in days, months and years, and ages
+  - ParseAny for timestamps whose exact format is not known in advance
+
+# Business Hours
+
+A schedule is built in the time zone the business operates in. Times passed
+to its methods may be in any zone:
+
+	loc, _ := time.LoadLocation("America/New_York")
+	hours, err := timeutils.WeekdayHours(loc, 9*time.Hour, 17*time.Hour)
+
+	// Saturday mornings, and a lunch break on Fridays
+	hours.SetHours(time.Saturday, timeutils.Window{Open: 9 * time.Hour, Close: 12 * time.Hour})
+	hours.SetHours(time.Friday,
+		timeutils.Window{Open: 9 * time.Hour, Close: 12 * time.Hour},
+		timeutils.Window{Open: 13 * time.Hour, Close: 17 * time.Hour},
+	)
+
+	hours.AddHoliday(time.Date(2024, 12, 25, 0, 0, 0, 0, loc))
+
+# SLA Calculations
+
+	// How long has the ticket been waiting, counting only business hours?
+	waiting := hours.DurationWithinBusinessHours(ticket.CreatedAt, time.Now())
+
+	// When is a four business hour response due?
+	due := hours.AddBusinessTime(ticket.CreatedAt, 4*time.Hour)
+
+	// When will someone next be available?
+	next := hours.NextOpen(time.Now())
+
+# Relative Times
+
+RelativeTime describes a time relative to now, rounded to the nearest unit:
+
+	timeutils.RelativeTime(comment.CreatedAt, time.Now()) // "3 minutes ago"
+	timeutils.RelativeTime(order.ShipsAt, time.Now())     // "in 2 days"
+
+A RelativeFormatter changes the thresholds at which each unit is used, and
+its Phrase function localizes the output:
+
+	f := timeutils.RelativeFormatter{
+		JustNow: time.Minute,
+		Phrase: func(count int, unit timeutils.RelativeUnit, future bool) string {
+			return catalog.Relative(locale, count, unit, future)
+		},
+	}
+	f.Format(t, time.Now())
+
+# Calendar Boundaries
+
+The Start and End functions return the first and last instant of the day,
+week, month, quarter or year containing a time, in that time's location.
+End values are the last nanosecond of the period, so ranges can be matched
+inclusively:
+
+	from := timeutils.StartOfQuarter(now)
+	to := timeutils.EndOfQuarter(now)
+	rows, err := db.QueryContext(ctx, query, from, to) // created_at BETWEEN $1 AND $2
+
+Weeks start on the given weekday; ISO 8601 weeks start on Monday:
+
+	timeutils.StartOfWeek(now, time.Monday)
+	year, week := timeutils.WeekOfYear(now) // ISO year and week, e.g. 2025 and 1 for 2024-12-30
+
+# Date Differences
+
+DaysBetween, MonthsBetween and YearsBetween count whole calendar units
+between the dates of two times, rather than dividing a duration, so daylight
+saving transitions and months of different lengths are handled. Each time's
+date is read in its own location and the time of day is ignored:
+
+	timeutils.MonthsBetween(jan15, mar14) // 1
+	timeutils.DaysBetween(start, end)     // calendar days, even across DST
+
+A month is complete when the day of the month comes round again, or on the
+last day of a shorter month, so one month after January 31 is February 29
+in a leap year. Age counts whole years the same way; someone born on
+February 29 has a birthday on February 28 in common years:
+
+	if timeutils.Age(customer.Birthdate, time.Now()) < 21 {
+		return errUnderage
+	}
+
+# Parsing
+
+ParseAny accepts the formats commonly seen in APIs and exports, from RFC 3339
+timestamps to bare dates, and treats values without a zone as UTC:
+
+	t, err := timeutils.ParseAny("2024-03-04 15:04:05")
+	if errors.Is(err, timeutils.ErrUnrecognizedTime) {
+		// not a format ParseAny knows
+	}
+*/
+package timeutils
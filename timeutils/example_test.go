@@ -0,0 +1,117 @@
+package timeutils_test
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/StairSupplies/go-core/timeutils"
+)
+
+func ExampleBusinessHours_DurationWithinBusinessHours() {
+	hours, _ := timeutils.WeekdayHours(time.UTC, 9*time.Hour, 17*time.Hour)
+
+	// Opened Friday 4pm, resolved Monday 11am
+	opened := time.Date(2024, 3, 8, 16, 0, 0, 0, time.UTC)
+	resolved := time.Date(2024, 3, 11, 11, 0, 0, 0, time.UTC)
+
+	fmt.Println(hours.DurationWithinBusinessHours(opened, resolved))
+
+	// Output: 3h0m0s
+}
+
+func ExampleBusinessHours_AddBusinessTime() {
+	hours, _ := timeutils.WeekdayHours(time.UTC, 9*time.Hour, 17*time.Hour)
+
+	// An 8 business hour SLA on a ticket opened Friday 3pm
+	opened := time.Date(2024, 3, 8, 15, 0, 0, 0, time.UTC)
+	due := hours.AddBusinessTime(opened, 8*time.Hour)
+
+	fmt.Println(due.Format("Mon 15:04"))
+
+	// Output: Mon 15:00
+}
+
+func ExampleBusinessHours_NextOpen() {
+	hours, _ := timeutils.WeekdayHours(time.UTC, 9*time.Hour, 17*time.Hour)
+
+	saturday := time.Date(2024, 3, 9, 10, 0, 0, 0, time.UTC)
+	fmt.Println(hours.IsOpen(saturday))
+	fmt.Println(hours.NextOpen(saturday).Format("Mon 15:04"))
+
+	// Output:
+	// false
+	// Mon 09:00
+}
+
+func ExampleRelativeTime() {
+	now := time.Date(2024, 3, 4, 12, 0, 0, 0, time.UTC)
+
+	fmt.Println(timeutils.RelativeTime(now.Add(-20*time.Second), now))
+	fmt.Println(timeutils.RelativeTime(now.Add(-3*time.Minute), now))
+	fmt.Println(timeutils.RelativeTime(now.Add(50*time.Hour), now))
+
+	// Output:
+	// just now
+	// 3 minutes ago
+	// in 2 days
+}
+
+func ExampleStartOfWeek() {
+	// Wednesday, May 15 2024
+	t := time.Date(2024, 5, 15, 14, 30, 0, 0, time.UTC)
+
+	fmt.Println(timeutils.StartOfWeek(t, time.Monday).Format(time.DateTime))
+	fmt.Println(timeutils.EndOfWeek(t, time.Monday).Format(time.RFC3339Nano))
+	fmt.Println(timeutils.StartOfWeek(t, time.Sunday).Format(time.DateOnly))
+
+	// Output:
+	// 2024-05-13 00:00:00
+	// 2024-05-19T23:59:59.999999999Z
+	// 2024-05-12
+}
+
+func ExampleStartOfQuarter() {
+	t := time.Date(2024, 5, 15, 14, 30, 0, 0, time.UTC)
+
+	fmt.Println("Q", timeutils.Quarter(t))
+	fmt.Println(timeutils.StartOfQuarter(t).Format(time.DateOnly))
+	fmt.Println(timeutils.EndOfQuarter(t).Format(time.DateOnly))
+
+	// Output:
+	// Q 2
+	// 2024-04-01
+	// 2024-06-30
+}
+
+func ExampleWeekOfYear() {
+	// December 30 2024 is in the first ISO week of 2025
+	year, week := timeutils.WeekOfYear(time.Date(2024, 12, 30, 0, 0, 0, 0, time.UTC))
+	fmt.Printf("%d-W%02d\n", year, week)
+
+	// Output: 2025-W01
+}
+
+func ExampleMonthsBetween() {
+	start := time.Date(2024, 1, 31, 0, 0, 0, 0, time.UTC)
+
+	// A month after January 31 ends on the last day of February
+	fmt.Println(timeutils.MonthsBetween(start, time.Date(2024, 2, 28, 0, 0, 0, 0, time.UTC)))
+	fmt.Println(timeutils.MonthsBetween(start, time.Date(2024, 2, 29, 0, 0, 0, 0, time.UTC)))
+	fmt.Println(timeutils.DaysBetween(start, time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC)))
+
+	// Output:
+	// 0
+	// 1
+	// 30
+}
+
+func ExampleAge() {
+	birthdate := time.Date(2000, 2, 29, 0, 0, 0, 0, time.UTC)
+
+	fmt.Println(timeutils.Age(birthdate, time.Date(2021, 2, 27, 0, 0, 0, 0, time.UTC)))
+	fmt.Println(timeutils.Age(birthdate, time.Date(2021, 2, 28, 0, 0, 0, 0, time.UTC)))
+
+	// Output:
+	// 20
+	// 21
+}
@@ -0,0 +1,38 @@
+package timeutils
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// ErrUnrecognizedTime is returned by ParseAny when no known layout matches
+var ErrUnrecognizedTime = errors.New("timeutils: unrecognized time format")
+
+// parseLayouts are the layouts tried by ParseAny, in order
+var parseLayouts = []string{
+	time.RFC3339Nano,
+	"2006-01-02T15:04:05.999999999",
+	"2006-01-02 15:04:05.999999999Z07:00",
+	"2006-01-02 15:04:05.999999999",
+	"2006-01-02",
+	time.RFC1123Z,
+	time.RFC1123,
+	time.RFC850,
+	time.ANSIC,
+}
+
+// ParseAny parses value in any of the common formats: RFC 3339 with or
+// without a zone or fractional seconds, a space instead of the T, a bare
+// date, and the RFC 1123, RFC 850 and ANSI C formats. Values without a zone
+// offset are interpreted as UTC.
+func ParseAny(value string) (time.Time, error) {
+	value = strings.TrimSpace(value)
+	for _, layout := range parseLayouts {
+		if t, err := time.Parse(layout, value); err == nil {
+			return t, nil
+		}
+	}
+	return time.Time{}, fmt.Errorf("%w: %q", ErrUnrecognizedTime, value)
+}
@@ -0,0 +1,43 @@
+package timeutils
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestParseAny(t *testing.T) {
+	est := time.FixedZone("", -5*60*60)
+
+	tests := []struct {
+		value string
+		want  time.Time
+	}{
+		{"2024-03-04T15:04:05Z", time.Date(2024, 3, 4, 15, 4, 5, 0, time.UTC)},
+		{"2024-03-04T15:04:05.123-05:00", time.Date(2024, 3, 4, 15, 4, 5, 123000000, est)},
+		{"2024-03-04T15:04:05", time.Date(2024, 3, 4, 15, 4, 5, 0, time.UTC)},
+		{"2024-03-04 15:04:05", time.Date(2024, 3, 4, 15, 4, 5, 0, time.UTC)},
+		{"2024-03-04 15:04:05-05:00", time.Date(2024, 3, 4, 15, 4, 5, 0, est)},
+		{" 2024-03-04 ", time.Date(2024, 3, 4, 0, 0, 0, 0, time.UTC)},
+		{"Mon, 04 Mar 2024 15:04:05 -0500", time.Date(2024, 3, 4, 15, 4, 5, 0, est)},
+		{"Mon, 04 Mar 2024 15:04:05 UTC", time.Date(2024, 3, 4, 15, 4, 5, 0, time.UTC)},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.value, func(t *testing.T) {
+			got, err := ParseAny(tc.value)
+			if err != nil {
+				t.Fatalf("Expected no error, got %v", err)
+			}
+			if !got.Equal(tc.want) {
+				t.Errorf("ParseAny(%q) = %v, want %v", tc.value, got, tc.want)
+			}
+		})
+	}
+
+	for _, value := range []string{"", "yesterday", "2024-13-01", "03/04/2024"} {
+		if _, err := ParseAny(value); !errors.Is(err, ErrUnrecognizedTime) {
+			t.Errorf("ParseAny(%q): expected ErrUnrecognizedTime, got %v", value, err)
+		}
+	}
+}
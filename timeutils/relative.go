@@ -0,0 +1,151 @@
+package timeutils
+
+import (
+	"fmt"
+	"math"
+	"time"
+)
+
+// RelativeUnit is the unit a relative time is expressed in
+type RelativeUnit int
+
+// Units used by RelativeTime, from smallest to largest
+const (
+	// RelativeNow is used for differences below the JustNow threshold; the
+	// count is always 0
+	RelativeNow RelativeUnit = iota
+	RelativeMinute
+	RelativeHour
+	RelativeDay
+	RelativeMonth
+	RelativeYear
+)
+
+// String returns the English name of the unit
+func (u RelativeUnit) String() string {
+	switch u {
+	case RelativeNow:
+		return "now"
+	case RelativeMinute:
+		return "minute"
+	case RelativeHour:
+		return "hour"
+	case RelativeDay:
+		return "day"
+	case RelativeMonth:
+		return "month"
+	case RelativeYear:
+		return "year"
+	}
+	return fmt.Sprintf("RelativeUnit(%d)", int(u))
+}
+
+// Average lengths used to count months and years
+const (
+	avgMonth = time.Duration(30.436875 * float64(24*time.Hour))
+	avgYear  = time.Duration(365.2425 * float64(24*time.Hour))
+)
+
+// Default thresholds for RelativeFormatter
+const (
+	DefaultJustNowThreshold = 45 * time.Second
+	DefaultMinuteThreshold  = 45 * time.Minute
+	DefaultHourThreshold    = 22 * time.Hour
+	DefaultDayThreshold     = 26 * 24 * time.Hour
+	DefaultMonthThreshold   = 320 * 24 * time.Hour
+)
+
+// RelativeFormatter formats the difference between two times as a phrase
+// such as "3 minutes ago" or "in 2 days". Each threshold is the difference
+// below which that unit is used; zero means the default. The zero value
+// formats in English with the default thresholds.
+type RelativeFormatter struct {
+	// JustNow is the difference below which "just now" is used
+	JustNow time.Duration
+	// Minute is the difference below which minutes are used
+	Minute time.Duration
+	// Hour is the difference below which hours are used
+	Hour time.Duration
+	// Day is the difference below which days are used
+	Day time.Duration
+	// Month is the difference below which months are used; larger
+	// differences are given in years
+	Month time.Duration
+
+	// Phrase, if set, builds the output for count units, so the result can
+	// be localized. Future reports whether the time is after now.
+	Phrase func(count int, unit RelativeUnit, future bool) string
+}
+
+// RelativeTime describes t relative to now in English, such as "just now",
+// "3 minutes ago" or "in 2 days". Amounts are rounded to the nearest unit.
+func RelativeTime(t, now time.Time) string {
+	return RelativeFormatter{}.Format(t, now)
+}
+
+// Format describes t relative to now
+func (f RelativeFormatter) Format(t, now time.Time) string {
+	count, unit, future := f.Relative(t, now)
+	if f.Phrase != nil {
+		return f.Phrase(count, unit, future)
+	}
+	return englishPhrase(count, unit, future)
+}
+
+// Relative returns the rounded count and unit Format would use for t
+// relative to now, and whether t is in the future
+func (f RelativeFormatter) Relative(t, now time.Time) (count int, unit RelativeUnit, future bool) {
+	d := t.Sub(now)
+	future = d > 0
+	if d < 0 {
+		d = -d
+	}
+
+	switch {
+	case d < orDefault(f.JustNow, DefaultJustNowThreshold):
+		return 0, RelativeNow, future
+	case d < orDefault(f.Minute, DefaultMinuteThreshold):
+		return roundUnits(d, time.Minute), RelativeMinute, future
+	case d < orDefault(f.Hour, DefaultHourThreshold):
+		return roundUnits(d, time.Hour), RelativeHour, future
+	case d < orDefault(f.Day, DefaultDayThreshold):
+		return roundUnits(d, 24*time.Hour), RelativeDay, future
+	case d < orDefault(f.Month, DefaultMonthThreshold):
+		return roundUnits(d, avgMonth), RelativeMonth, future
+	default:
+		return roundUnits(d, avgYear), RelativeYear, future
+	}
+}
+
+// orDefault returns d, or def if d is not positive
+func orDefault(d, def time.Duration) time.Duration {
+	if d <= 0 {
+		return def
+	}
+	return d
+}
+
+// roundUnits returns d in units, rounded to the nearest whole unit and at least 1
+func roundUnits(d, unit time.Duration) int {
+	n := int(math.Round(float64(d) / float64(unit)))
+	if n < 1 {
+		n = 1
+	}
+	return n
+}
+
+// englishPhrase is the default RelativeFormatter phrase
+func englishPhrase(count int, unit RelativeUnit, future bool) string {
+	if unit == RelativeNow {
+		return "just now"
+	}
+
+	amount := fmt.Sprintf("%d %s", count, unit)
+	if count != 1 {
+		amount += "s"
+	}
+	if future {
+		return "in " + amount
+	}
+	return amount + " ago"
+}
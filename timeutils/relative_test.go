@@ -0,0 +1,97 @@
+package timeutils
+
+import (
+	"fmt"
+	"testing"
+	"time"
+)
+
+func TestRelativeTime(t *testing.T) {
+	now := time.Date(2024, 3, 4, 12, 0, 0, 0, time.UTC)
+	day := 24 * time.Hour
+
+	tests := []struct {
+		offset time.Duration
+		want   string
+	}{
+		{0, "just now"},
+		{-44 * time.Second, "just now"},
+		{30 * time.Second, "just now"},
+		{-45 * time.Second, "1 minute ago"},
+		{-90 * time.Second, "2 minutes ago"},
+		{-3 * time.Minute, "3 minutes ago"},
+		{10 * time.Minute, "in 10 minutes"},
+		{-45 * time.Minute, "1 hour ago"},
+		{-90 * time.Minute, "2 hours ago"},
+		{5 * time.Hour, "in 5 hours"},
+		{-22 * time.Hour, "1 day ago"},
+		{2 * day, "in 2 days"},
+		{-25 * day, "25 days ago"},
+		{-26 * day, "1 month ago"},
+		{-95 * day, "3 months ago"},
+		{320 * day, "in 1 year"},
+		{-3 * 365 * day, "3 years ago"},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.want, func(t *testing.T) {
+			if got := RelativeTime(now.Add(tc.offset), now); got != tc.want {
+				t.Errorf("RelativeTime(%v) = %q, want %q", tc.offset, got, tc.want)
+			}
+		})
+	}
+}
+
+func TestRelativeFormatter_Thresholds(t *testing.T) {
+	now := time.Date(2024, 3, 4, 12, 0, 0, 0, time.UTC)
+	f := RelativeFormatter{JustNow: 5 * time.Minute, Hour: 48 * time.Hour}
+
+	if got := f.Format(now.Add(-4*time.Minute), now); got != "just now" {
+		t.Errorf("Expected custom JustNow threshold, got %q", got)
+	}
+	if got := f.Format(now.Add(-36*time.Hour), now); got != "36 hours ago" {
+		t.Errorf("Expected custom Hour threshold, got %q", got)
+	}
+}
+
+func TestRelativeFormatter_Phrase(t *testing.T) {
+	now := time.Date(2024, 3, 4, 12, 0, 0, 0, time.UTC)
+	units := map[RelativeUnit]string{RelativeMinute: "minuto", RelativeDay: "día"}
+
+	f := RelativeFormatter{Phrase: func(count int, unit RelativeUnit, future bool) string {
+		if unit == RelativeNow {
+			return "ahora mismo"
+		}
+		name := units[unit]
+		if count != 1 {
+			name += "s"
+		}
+		if future {
+			return fmt.Sprintf("dentro de %d %s", count, name)
+		}
+		return fmt.Sprintf("hace %d %s", count, name)
+	}}
+
+	tests := []struct {
+		t    time.Time
+		want string
+	}{
+		{now, "ahora mismo"},
+		{now.Add(-3 * time.Minute), "hace 3 minutos"},
+		{now.Add(24 * time.Hour), "dentro de 1 día"},
+	}
+	for _, tc := range tests {
+		if got := f.Format(tc.t, now); got != tc.want {
+			t.Errorf("Expected %q, got %q", tc.want, got)
+		}
+	}
+}
+
+func TestRelativeUnit_String(t *testing.T) {
+	if got := RelativeMonth.String(); got != "month" {
+		t.Errorf("Expected month, got %q", got)
+	}
+	if got := RelativeUnit(42).String(); got != "RelativeUnit(42)" {
+		t.Errorf("Expected RelativeUnit(42), got %q", got)
+	}
+}
@@ -0,0 +1,146 @@
+/*
+Package validate provides request validation with field-level error messages.
+
+Errors are collected into a ValidationError, a map from field name to the
+first problem found with that field, which serializes naturally into API
+error responses.
+
+# Features
+
+  - Struct validation driven by `validate` struct tags
+  - Errors keyed by each field's JSON name
+  - Nested structs and slices reported with dotted keys such as "items[2].quantity"
+  - An imperative Validator for checks that do not fit in a tag
+  - Helpers such as NotBlank, MinChars, IsEmail, PermittedValue, and Unique
+  - Shared format checks for UUIDs, URLs, E.164 phone numbers, and dates
+  - Message templates that can be overridden or localized per Validator
+  - Policy-driven password strength checks with a bundled common-password list
+
+# Struct Tags
+
+Struct validates a request DTO in one call:
+
+	type CreateUserRequest struct {
+	    Name  string   `json:"name" validate:"required,max=50"`
+	    Email string   `json:"email" validate:"required,email"`
+	    Role  string   `json:"role" validate:"oneof=admin member"`
+	    Tags  []string `json:"tags" validate:"max=5"`
+	}
+
+	if err := validate.Struct(req); err != nil {
+	    return api.UnprocessableEntityError(err)
+	}
+
+Rules are separated by commas:
+
+  - required: the field must not be blank, zero, nil, or empty
+  - email: a plain email address
+  - min=N, max=N, len=N: characters for strings, items for slices and maps,
+    the value itself for numbers
+  - oneof=a b c: one of the space-separated values
+  - uuid: a UUID in canonical 8-4-4-4-12 form
+  - url, url=a b: an absolute URL with a host whose scheme is http or https,
+    or one of the space-separated schemes
+  - e164: a phone number in E.164 format, such as +14155552671
+  - date, date=LAYOUT: a date string in the time.Parse layout, or in any
+    format accepted by timeutils.ParseAny
+
+A field holding its zero value is only checked by required, so optional
+fields can carry format rules. Pointer fields are dereferenced. Fields of
+embedded structs are validated as if they were declared on the outer struct,
+matching encoding/json.
+
+An unknown rule or malformed parameter is a programming error and is
+reported as ErrInvalidRule rather than as a ValidationError.
+
+# Nested Values
+
+Struct descends into struct fields, pointers to structs, and slices or arrays
+of structs, even without a validate tag. Errors are keyed by their path:
+
+	type Order struct {
+	    Address Address `json:"address"`
+	    Items   []Item  `json:"items" validate:"required,max=50"`
+	}
+
+	// address.street: must be provided
+	// items[2].quantity: must be at least 1
+
+Rules on the field itself, such as max above, apply to the slice as a whole.
+Tag a field `validate:"-"` to skip it.
+
+# Imperative Checks
+
+Validator collects errors from arbitrary checks. Only the first message for
+each field is kept:
+
+	v := validate.New()
+	v.Check(validate.NotBlank(input.Name), "name", "must be provided")
+	v.Check(input.End.After(input.Start), "end", "must be after start")
+	v.Check(validate.IsURL(input.Callback, "https"), "callback", "must be an https URL")
+	if !v.Valid() {
+	    return v.AsValidationError()
+	}
+
+Returned from an api.HandlerFunc, a ValidationError is written as a 422 with
+the field errors as details. api.FromValidation converts it to an api.Error
+explicitly.
+
+Nested records the errors of a group of checks under a prefix:
+
+	v.Nested("address", func(v *validate.Validator) {
+	    v.Check(validate.NotBlank(input.Address.Street), "street", "must be provided")
+	})
+
+# Passwords
+
+IsStrongPassword checks a password against a PasswordPolicy and records the
+first unmet requirement as an actionable message, such as "must contain a
+digit". DefaultPasswordPolicy follows current NIST guidance, requiring length
+rather than character classes and rejecting common passwords:
+
+	policy := validate.DefaultPasswordPolicy()
+	policy.Disallowed = []string{user.Email, "stairsupplies"}
+
+	v := validate.New()
+	v.IsStrongPassword(req.Password, "password", policy)
+
+Common passwords come from a list embedded in the package. A password is
+also rejected when it is a common one with digits or symbols added before or
+after it, so "Password2024!" fails. IsCommonPassword exposes the check on
+its own. The messages use MsgPassword keys and can be replaced or localized
+like any other.
+
+# Custom Messages
+
+The messages Struct reports are templates keyed by constants such as
+MsgRequired and MsgMinLength. {field} is replaced by the field's key and
+{param} by the rule parameter. SetMessages replaces them everywhere:
+
+	validate.SetMessages(map[string]string{
+	    validate.MsgRequired:  "is required",
+	    validate.MsgMinLength: "{field} needs at least {param} characters",
+	})
+
+SetLocaleMessages registers templates for one locale, and a Validator
+created with NewWithLocale uses them. "fr-CA" falls back to "fr", and keys a
+locale does not define fall back to SetMessages and then the defaults:
+
+	validate.SetLocaleMessages("fr", map[string]string{
+	    validate.MsgRequired: "est obligatoire",
+	})
+
+	v := validate.NewWithLocale(user.Locale)
+	if err := v.Struct(req); err != nil {
+	    return err
+	}
+	v.CheckMessage(validate.NotBlank(req.Code), "code", "NotBlank", "")
+	if !v.Valid() {
+	    return api.UnprocessableEntityError(v.Err())
+	}
+
+CheckMessage accepts any key registered with SetMessages or
+SetLocaleMessages, so application-specific messages can be localized too.
+Templates should be registered at startup.
+*/
+package validate
@@ -0,0 +1,120 @@
+package validate_test
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/StairSupplies/go-core/validate"
+)
+
+func ExampleStruct() {
+	type createUserRequest struct {
+		Name  string `json:"name" validate:"required,max=50"`
+		Email string `json:"email" validate:"required,email"`
+		Role  string `json:"role" validate:"oneof=admin member"`
+	}
+
+	req := createUserRequest{Email: "not-an-email", Role: "owner"}
+
+	var verr validate.ValidationError
+	if err := validate.Struct(req); errors.As(err, &verr) {
+		fmt.Println("name:", verr["name"])
+		fmt.Println("email:", verr["email"])
+		fmt.Println("role:", verr["role"])
+	}
+
+	// Output:
+	// name: must be provided
+	// email: must be a valid email address
+	// role: must be one of: admin, member
+}
+
+func ExampleIsURL() {
+	fmt.Println(validate.IsURL("https://example.com/webhook"))
+	fmt.Println(validate.IsURL("ftp://example.com/file"))
+	fmt.Println(validate.IsURL("ftp://example.com/file", "ftp", "sftp"))
+	fmt.Println(validate.IsURL("/relative/path"))
+
+	// Output:
+	// true
+	// false
+	// true
+	// false
+}
+
+func ExampleValidator() {
+	password := "short"
+
+	v := validate.New()
+	v.Check(validate.MinChars(password, 12), "password", "must contain at least 12 characters")
+	v.Check(validate.Unique([]string{"a", "b", "a"}), "tags", "must not contain duplicates")
+
+	fmt.Println(v.Err())
+
+	// Output: validation failed: password must contain at least 12 characters; tags must not contain duplicates
+}
+
+func ExampleValidator_Nested() {
+	type item struct {
+		SKU      string
+		Quantity int
+	}
+	items := []item{{"A-1", 2}, {"B-2", 0}}
+
+	v := validate.New()
+	for i, it := range items {
+		v.Nested(fmt.Sprintf("items[%d]", i), func(v *validate.Validator) {
+			v.Check(validate.NotBlank(it.SKU), "sku", "must be provided")
+			v.Check(it.Quantity > 0, "quantity", "must be at least 1")
+		})
+	}
+
+	fmt.Println(v.Err())
+
+	// Output: validation failed: items[1].quantity must be at least 1
+}
+
+func ExampleSetLocaleMessages() {
+	type signupRequest struct {
+		Name     string `json:"name" validate:"required"`
+		Password string `json:"password" validate:"min=12"`
+	}
+
+	validate.SetLocaleMessages("fr", map[string]string{
+		validate.MsgRequired:  "est obligatoire",
+		validate.MsgMinLength: "doit contenir au moins {param} caractères",
+	})
+	defer validate.SetLocaleMessages("fr", nil)
+
+	v := validate.NewWithLocale("fr-CA")
+	if err := v.Struct(signupRequest{Password: "short"}); err != nil {
+		panic(err)
+	}
+
+	fmt.Println("name:", v.Errors["name"])
+	fmt.Println("password:", v.Errors["password"])
+
+	// Output:
+	// name: est obligatoire
+	// password: doit contenir au moins 12 caractères
+}
+
+func ExampleValidator_IsStrongPassword() {
+	policy := validate.DefaultPasswordPolicy()
+	policy.Disallowed = []string{"jane.doe@example.com", "stairsupplies"}
+
+	for _, password := range []string{"hunter2", "Password2024!", "stairsupplies-rocks", "plum tractor velvet"} {
+		v := validate.New()
+		if v.IsStrongPassword(password, "password", policy) {
+			fmt.Println(password, "ok")
+			continue
+		}
+		fmt.Println(password, v.Errors["password"])
+	}
+
+	// Output:
+	// hunter2 must contain at least 12 characters
+	// Password2024! is too common and easy to guess
+	// stairsupplies-rocks must not contain stairsupplies
+	// plum tractor velvet ok
+}
@@ -0,0 +1,166 @@
+package validate
+
+import (
+	"strings"
+	"sync"
+)
+
+// Message keys used by Struct. Pass them to SetMessages or SetLocaleMessages
+// to replace the built-in English messages.
+const (
+	MsgRequired    = "Required"
+	MsgEmail       = "Email"
+	MsgMin         = "Min"
+	MsgMinLength   = "MinLength"
+	MsgMinItems    = "MinItems"
+	MsgMax         = "Max"
+	MsgMaxLength   = "MaxLength"
+	MsgMaxItems    = "MaxItems"
+	MsgLen         = "Len"
+	MsgExactLength = "ExactLength"
+	MsgExactItems  = "ExactItems"
+	MsgOneOf       = "OneOf"
+	MsgUUID        = "UUID"
+	MsgURL         = "URL"
+	MsgE164        = "E164"
+	MsgDate        = "Date"
+)
+
+// Message keys used by IsStrongPassword
+const (
+	MsgPasswordMinLength  = "PasswordMinLength"
+	MsgPasswordMaxLength  = "PasswordMaxLength"
+	MsgPasswordUpper      = "PasswordUpper"
+	MsgPasswordLower      = "PasswordLower"
+	MsgPasswordDigit      = "PasswordDigit"
+	MsgPasswordSymbol     = "PasswordSymbol"
+	MsgPasswordClasses    = "PasswordClasses"
+	MsgPasswordCommon     = "PasswordCommon"
+	MsgPasswordDisallowed = "PasswordDisallowed"
+)
+
+// defaultMessages are the built-in templates. {field} is replaced by the
+// field's key and {param} by the rule parameter, such as the limit of min.
+var defaultMessages = map[string]string{
+	MsgRequired:    "must be provided",
+	MsgEmail:       "must be a valid email address",
+	MsgMin:         "must be at least {param}",
+	MsgMinLength:   "must contain at least {param} characters",
+	MsgMinItems:    "must contain at least {param} items",
+	MsgMax:         "must not be greater than {param}",
+	MsgMaxLength:   "must not contain more than {param} characters",
+	MsgMaxItems:    "must not contain more than {param} items",
+	MsgLen:         "must be exactly {param}",
+	MsgExactLength: "must contain exactly {param} characters",
+	MsgExactItems:  "must contain exactly {param} items",
+	MsgOneOf:       "must be one of: {param}",
+	MsgUUID:        "must be a valid UUID",
+	MsgURL:         "must be a valid URL",
+	MsgE164:        "must be a valid phone number in E.164 format",
+	MsgDate:        "must be a valid date",
+
+	MsgPasswordMinLength:  "must contain at least {param} characters",
+	MsgPasswordMaxLength:  "must not contain more than {param} characters",
+	MsgPasswordUpper:      "must contain an uppercase letter",
+	MsgPasswordLower:      "must contain a lowercase letter",
+	MsgPasswordDigit:      "must contain a digit",
+	MsgPasswordSymbol:     "must contain a symbol such as ! or #",
+	MsgPasswordClasses:    "must contain at least {param} of: uppercase letters, lowercase letters, digits, symbols",
+	MsgPasswordCommon:     "is too common and easy to guess",
+	MsgPasswordDisallowed: "must not contain {param}",
+}
+
+var (
+	messagesMu sync.RWMutex
+	// overrides holds the templates set with SetMessages
+	overrides = map[string]string{}
+	// locales holds the templates set with SetLocaleMessages, by locale
+	locales = map[string]map[string]string{}
+)
+
+// DefaultMessages returns a copy of the built-in English templates
+func DefaultMessages() map[string]string {
+	m := make(map[string]string, len(defaultMessages))
+	for k, v := range defaultMessages {
+		m[k] = v
+	}
+	return m
+}
+
+// SetMessages replaces the templates for the given keys in every locale
+// that does not define its own. Templates may use {field} and {param}:
+//
+//	validate.SetMessages(map[string]string{
+//	    validate.MsgRequired:  "is required",
+//	    validate.MsgMinLength: "{field} needs at least {param} characters",
+//	})
+//
+// Keys that are not set keep their current template.
+func SetMessages(messages map[string]string) {
+	messagesMu.Lock()
+	defer messagesMu.Unlock()
+
+	for k, v := range messages {
+		overrides[k] = v
+	}
+}
+
+// SetLocaleMessages sets the templates used by Validators whose Locale is
+// locale, such as "fr" or "pt-BR". A Validator with locale "fr-CA" uses the
+// "fr" templates unless "fr-CA" has its own. Keys missing from a locale fall
+// back to SetMessages and then the defaults. Passing nil removes the locale.
+func SetLocaleMessages(locale string, messages map[string]string) {
+	locale = normalizeLocale(locale)
+
+	messagesMu.Lock()
+	defer messagesMu.Unlock()
+
+	if messages == nil {
+		delete(locales, locale)
+		return
+	}
+	m := locales[locale]
+	if m == nil {
+		m = make(map[string]string, len(messages))
+		locales[locale] = m
+	}
+	for k, v := range messages {
+		m[k] = v
+	}
+}
+
+// Message renders the template for key in locale. Unknown keys are returned
+// as they are, so a plain message can be passed in place of a key.
+func Message(locale, key, field, param string) string {
+	return strings.NewReplacer("{field}", field, "{param}", param).Replace(template(locale, key))
+}
+
+// template finds the template for key, from the most specific locale to the defaults
+func template(locale, key string) string {
+	messagesMu.RLock()
+	defer messagesMu.RUnlock()
+
+	if locale = normalizeLocale(locale); locale != "" {
+		if t, ok := locales[locale][key]; ok {
+			return t
+		}
+		if base, _, found := strings.Cut(locale, "-"); found {
+			if t, ok := locales[base][key]; ok {
+				return t
+			}
+		}
+	}
+	if t, ok := overrides[key]; ok {
+		return t
+	}
+	if t, ok := defaultMessages[key]; ok {
+		return t
+	}
+	return key
+}
+
+// normalizeLocale lowercases locale and uses "-" as the separator, so
+// "pt_BR" and "pt-br" are the same locale
+func normalizeLocale(locale string) string {
+	return strings.ReplaceAll(strings.ToLower(strings.TrimSpace(locale)), "_", "-")
+}
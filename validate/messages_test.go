@@ -0,0 +1,118 @@
+package validate
+
+import (
+	"testing"
+)
+
+// resetMessages restores the built-in templates when the test ends
+func resetMessages(t *testing.T) {
+	t.Cleanup(func() {
+		messagesMu.Lock()
+		defer messagesMu.Unlock()
+		overrides = map[string]string{}
+		locales = map[string]map[string]string{}
+	})
+}
+
+type messageRequest struct {
+	Name  string   `json:"name" validate:"required,min=2"`
+	Color string   `json:"color" validate:"oneof=red green"`
+	Tags  []string `json:"tags" validate:"max=1"`
+}
+
+func TestSetMessages(t *testing.T) {
+	resetMessages(t)
+	SetMessages(map[string]string{
+		MsgMinLength: "{field} needs {param}+ characters",
+		MsgOneOf:     "pick one of {param}",
+	})
+
+	err := Struct(messageRequest{Name: "J", Color: "blue", Tags: []string{"a", "b"}})
+	got, ok := err.(ValidationError)
+	if !ok {
+		t.Fatalf("Expected ValidationError, got %v", err)
+	}
+
+	want := ValidationError{
+		"name":  "name needs 2+ characters",
+		"color": "pick one of red, green",
+		"tags":  "must not contain more than 1 items",
+	}
+	for field, msg := range want {
+		if got[field] != msg {
+			t.Errorf("Expected %s to be %q, got %q", field, msg, got[field])
+		}
+	}
+}
+
+func TestValidator_Locale(t *testing.T) {
+	resetMessages(t)
+	SetMessages(map[string]string{MsgRequired: "is required"})
+	SetLocaleMessages("fr", map[string]string{MsgRequired: "est obligatoire"})
+	SetLocaleMessages("fr_CA", map[string]string{MsgMinLength: "doit contenir au moins {param} caractères"})
+
+	tests := []struct {
+		locale string
+		name   string
+		want   string
+	}{
+		{"", "", "is required"},
+		{"de", "", "is required"},
+		{"fr", "", "est obligatoire"},
+		{"FR-ca", "", "est obligatoire"},
+		{"fr-CA", "J", "doit contenir au moins 2 caractères"},
+		{"fr", "J", "must contain at least 2 characters"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.locale+"/"+tt.name, func(t *testing.T) {
+			v := NewWithLocale(tt.locale)
+			if err := v.Struct(messageRequest{Name: tt.name}); err != nil {
+				t.Fatalf("Expected no error, got %v", err)
+			}
+			if v.Errors["name"] != tt.want {
+				t.Errorf("Expected %q, got %q", tt.want, v.Errors["name"])
+			}
+		})
+	}
+
+	SetLocaleMessages("fr", nil)
+	v := NewWithLocale("fr")
+	v.Struct(messageRequest{})
+	if v.Errors["name"] != "is required" {
+		t.Errorf("Expected removed locale to fall back, got %q", v.Errors["name"])
+	}
+}
+
+func TestValidator_CheckMessage(t *testing.T) {
+	resetMessages(t)
+	SetLocaleMessages("es", map[string]string{"NotBlank": "{field} no puede estar vacío"})
+
+	v := NewWithLocale("es")
+	v.CheckMessage(NotBlank(""), "nombre", "NotBlank", "")
+	v.CheckMessage(false, "plain", "must be plain", "")
+	v.Nested("address", func(v *Validator) {
+		v.CheckMessage(false, "zip", MsgExactLength, "5")
+	})
+	v.Nested("contact", func(v *Validator) {
+		v.CheckMessage(false, "email", "NotBlank", "")
+	})
+
+	want := map[string]string{
+		"nombre":        "nombre no puede estar vacío",
+		"plain":         "must be plain",
+		"address.zip":   "must contain exactly 5 characters",
+		"contact.email": "email no puede estar vacío",
+	}
+	for field, msg := range want {
+		if v.Errors[field] != msg {
+			t.Errorf("Expected %s to be %q, got %q", field, msg, v.Errors[field])
+		}
+	}
+}
+
+func TestValidator_StructNotStruct(t *testing.T) {
+	if err := New().Struct("nope"); err != ErrNotStruct {
+		t.Errorf("Expected ErrNotStruct, got %v", err)
+	}
+}
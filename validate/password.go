@@ -0,0 +1,146 @@
+package validate
+
+import (
+	_ "embed"
+	"strconv"
+	"strings"
+	"sync"
+	"unicode"
+	"unicode/utf8"
+)
+
+// PasswordPolicy describes the passwords IsStrongPassword accepts
+type PasswordPolicy struct {
+	// MinLength is the fewest characters allowed
+	MinLength int
+	// MaxLength is the most characters allowed; zero means no limit
+	MaxLength int
+	// RequireUpper, RequireLower, RequireDigit and RequireSymbol each
+	// require a character of that class
+	RequireUpper  bool
+	RequireLower  bool
+	RequireDigit  bool
+	RequireSymbol bool
+	// MinClasses requires characters from at least this many of the four
+	// classes, whichever they are
+	MinClasses int
+	// RejectCommon rejects passwords on the bundled list of common
+	// passwords, also when digits or symbols are added before or after one
+	RejectCommon bool
+	// Disallowed lists words the password must not contain, ignoring case,
+	// such as the user's name or email or the product name. Words shorter
+	// than 3 characters are ignored.
+	Disallowed []string
+}
+
+// DefaultPasswordPolicy returns a policy following current NIST guidance:
+// at least 12 and at most 128 characters, no common passwords, and no
+// character class rules
+func DefaultPasswordPolicy() PasswordPolicy {
+	return PasswordPolicy{
+		MinLength:    12,
+		MaxLength:    128,
+		RejectCommon: true,
+	}
+}
+
+// IsStrongPassword reports whether value satisfies policy. If it does not,
+// the first unmet requirement is recorded for field, in the Validator's
+// locale, so the message tells the user what to change:
+//
+//	v.IsStrongPassword(req.Password, "password", validate.DefaultPasswordPolicy())
+func (v *Validator) IsStrongPassword(value, field string, policy PasswordPolicy) bool {
+	key, param := checkPassword(value, policy)
+	v.CheckMessage(key == "", field, key, param)
+	return key == ""
+}
+
+// checkPassword returns the message key and parameter for the first
+// requirement of policy that value does not meet, or "" if it meets them all
+func checkPassword(value string, policy PasswordPolicy) (string, string) {
+	n := utf8.RuneCountInString(value)
+	if n < policy.MinLength {
+		return MsgPasswordMinLength, strconv.Itoa(policy.MinLength)
+	}
+	if policy.MaxLength > 0 && n > policy.MaxLength {
+		return MsgPasswordMaxLength, strconv.Itoa(policy.MaxLength)
+	}
+
+	var upper, lower, digit, symbol bool
+	for _, r := range value {
+		switch {
+		case unicode.IsUpper(r):
+			upper = true
+		case unicode.IsLower(r):
+			lower = true
+		case unicode.IsDigit(r):
+			digit = true
+		case unicode.IsPunct(r) || unicode.IsSymbol(r) || unicode.IsSpace(r):
+			symbol = true
+		}
+	}
+	switch {
+	case policy.RequireUpper && !upper:
+		return MsgPasswordUpper, ""
+	case policy.RequireLower && !lower:
+		return MsgPasswordLower, ""
+	case policy.RequireDigit && !digit:
+		return MsgPasswordDigit, ""
+	case policy.RequireSymbol && !symbol:
+		return MsgPasswordSymbol, ""
+	}
+	if policy.MinClasses > 0 {
+		classes := 0
+		for _, has := range []bool{upper, lower, digit, symbol} {
+			if has {
+				classes++
+			}
+		}
+		if classes < policy.MinClasses {
+			return MsgPasswordClasses, strconv.Itoa(policy.MinClasses)
+		}
+	}
+
+	lowered := strings.ToLower(value)
+	for _, word := range policy.Disallowed {
+		if utf8.RuneCountInString(word) >= 3 && strings.Contains(lowered, strings.ToLower(word)) {
+			return MsgPasswordDisallowed, word
+		}
+	}
+	if policy.RejectCommon && IsCommonPassword(value) {
+		return MsgPasswordCommon, ""
+	}
+	return "", ""
+}
+
+//go:embed common_passwords.txt
+var commonPasswordList string
+
+var (
+	commonPasswordsOnce sync.Once
+	commonPasswords     map[string]bool
+)
+
+// IsCommonPassword reports whether value, ignoring case and any digits or
+// symbols added before or after it, is on the bundled list of common
+// passwords, so "Password2024!" is common because "password" is
+func IsCommonPassword(value string) bool {
+	commonPasswordsOnce.Do(func() {
+		commonPasswords = make(map[string]bool)
+		for _, line := range strings.Split(commonPasswordList, "\n") {
+			line = strings.TrimSpace(line)
+			if line != "" && !strings.HasPrefix(line, "#") {
+				commonPasswords[line] = true
+			}
+		}
+	})
+
+	lowered := strings.ToLower(value)
+	if commonPasswords[lowered] {
+		return true
+	}
+	core := strings.TrimFunc(lowered, func(r rune) bool {
+		return !unicode.IsLetter(r)
+	})
+	return utf8.RuneCountInString(core) >= 4 && commonPasswords[core]
+}
@@ -0,0 +1,88 @@
+package validate
+
+import "testing"
+
+func TestIsStrongPassword(t *testing.T) {
+	strict := PasswordPolicy{
+		MinLength:     8,
+		RequireUpper:  true,
+		RequireLower:  true,
+		RequireDigit:  true,
+		RequireSymbol: true,
+	}
+
+	tests := []struct {
+		name    string
+		value   string
+		policy  PasswordPolicy
+		message string
+	}{
+		{"default accepts long passphrase", "plum tractor velvet", DefaultPasswordPolicy(), ""},
+		{"too short", "Sh0rt!", DefaultPasswordPolicy(), "must contain at least 12 characters"},
+		{"too long", "abcdefghijk", PasswordPolicy{MaxLength: 10}, "must not contain more than 10 characters"},
+		{"length counts runes", "ñññññññ", PasswordPolicy{MaxLength: 7}, ""},
+		{"common", "password1234", DefaultPasswordPolicy(), "is too common and easy to guess"},
+		{"common ignores case", "PasswordPassword", DefaultPasswordPolicy(), "is too common and easy to guess"},
+		{"common with affixes", "!!Letmein2024!!", DefaultPasswordPolicy(), "is too common and easy to guess"},
+		{"common allowed when not rejected", "password1234", PasswordPolicy{MinLength: 12}, ""},
+		{"missing upper", "abcdef1!", strict, "must contain an uppercase letter"},
+		{"missing lower", "ABCDEF1!", strict, "must contain a lowercase letter"},
+		{"missing digit", "Abcdefg!", strict, "must contain a digit"},
+		{"missing symbol", "Abcdefg1", strict, "must contain a symbol such as ! or #"},
+		{"all classes", "Abcdef1!", strict, ""},
+		{"min classes", "abcdefgh", PasswordPolicy{MinClasses: 2}, "must contain at least 2 of: uppercase letters, lowercase letters, digits, symbols"},
+		{"min classes met", "abcdefg1", PasswordPolicy{MinClasses: 2}, ""},
+		{"disallowed", "my-AcmeCorp-pass", PasswordPolicy{Disallowed: []string{"acmecorp"}}, "must not contain acmecorp"},
+		{"short disallowed ignored", "my-jo-pass", PasswordPolicy{Disallowed: []string{"jo"}}, ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			v := New()
+			ok := v.IsStrongPassword(tt.value, "password", tt.policy)
+
+			if ok != (tt.message == "") {
+				t.Errorf("Expected ok %v, got %v", tt.message == "", ok)
+			}
+			if got := v.Errors["password"]; got != tt.message {
+				t.Errorf("Expected message %q, got %q", tt.message, got)
+			}
+		})
+	}
+}
+
+func TestIsStrongPassword_Locale(t *testing.T) {
+	SetLocaleMessages("fr", map[string]string{
+		MsgPasswordMinLength: "doit contenir au moins {param} caractères",
+	})
+	defer SetLocaleMessages("fr", nil)
+
+	v := NewWithLocale("fr")
+	v.IsStrongPassword("court", "password", DefaultPasswordPolicy())
+
+	if got := v.Errors["password"]; got != "doit contenir au moins 12 caractères" {
+		t.Errorf("Expected localized message, got %q", got)
+	}
+}
+
+func TestIsCommonPassword(t *testing.T) {
+	tests := []struct {
+		value  string
+		common bool
+	}{
+		{"password", true},
+		{"QWERTY", true},
+		{"123456", true},
+		{"Summer2024!", true},
+		{"1abc1", false},
+		{"plum tractor velvet", false},
+		{"# Frequently used passwords, one per line and lowercase, from published", false},
+		{"", false},
+	}
+
+	for _, tt := range tests {
+		if got := IsCommonPassword(tt.value); got != tt.common {
+			t.Errorf("IsCommonPassword(%q): expected %v, got %v", tt.value, tt.common, got)
+		}
+	}
+}
@@ -0,0 +1,377 @@
+package validate
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// Common errors returned by Struct
+var (
+	// ErrNotStruct is returned when Struct is given something other than a struct
+	ErrNotStruct = errors.New("validate: value must be a struct or pointer to struct")
+
+	// ErrInvalidRule is returned for unknown rules or malformed rule parameters
+	ErrInvalidRule = errors.New("validate: invalid rule")
+)
+
+// rule is a single parsed validation rule
+type rule struct {
+	name  string
+	param string
+	// display is param as it appears in messages
+	display string
+	check   ruleFunc
+}
+
+// ruleFunc checks v against param and returns the key of the message to
+// report when it fails
+type ruleFunc func(v reflect.Value, param string) (key string, ok bool)
+
+// fieldRules holds the rules for one struct field
+type fieldRules struct {
+	index    []int
+	name     string
+	required bool
+	rules    []rule
+	nested   nestedKind
+}
+
+// nestedKind says whether a field holds structs to validate recursively
+type nestedKind int
+
+const (
+	nestedNone   nestedKind = iota
+	nestedStruct            // a struct or pointer to struct
+	nestedSlice             // a slice or array of structs or struct pointers
+)
+
+// rules maps rule names to their implementation
+var rules = map[string]ruleFunc{
+	"email": checkEmail,
+	"min":   checkMin,
+	"max":   checkMax,
+	"len":   checkLen,
+	"oneof": checkOneOf,
+	"uuid":  checkUUID,
+	"url":   checkURL,
+	"e164":  checkE164,
+	"date":  checkDate,
+}
+
+// rulesWithParam lists rules that require a parameter
+var rulesWithParam = map[string]bool{"min": true, "max": true, "len": true, "oneof": true}
+
+// typeCache caches parsed rules per struct type
+var typeCache sync.Map // map[reflect.Type][]fieldRules
+
+// Struct validates v using `validate` struct tags and returns a
+// ValidationError keyed by each field's JSON name, or nil if v is valid.
+//
+// Rules are separated by commas, for example `validate:"required,email,max=50"`.
+// Fields that hold their zero value are only checked by required.
+//
+// Nested structs and slices of structs are validated recursively, with
+// errors keyed by their path, such as "address.street" or "items[2].quantity".
+// Tag a field `validate:"-"` to skip it.
+func Struct(v any) error {
+	val := New()
+	if err := val.Struct(v); err != nil {
+		return err
+	}
+	return val.Err()
+}
+
+// Struct validates x like the package-level Struct, recording field errors
+// in v with messages in v's locale. It returns an error only for
+// ErrNotStruct and ErrInvalidRule; use Err for the field errors.
+func (v *Validator) Struct(x any) error {
+	rv := reflect.ValueOf(x)
+	for rv.Kind() == reflect.Pointer {
+		if rv.IsNil() {
+			return ErrNotStruct
+		}
+		rv = rv.Elem()
+	}
+	if rv.Kind() != reflect.Struct {
+		return ErrNotStruct
+	}
+
+	return validateStruct(v, "", rv)
+}
+
+// validateStruct records errors for the fields of rv with keys prefixed by prefix
+func validateStruct(val *Validator, prefix string, rv reflect.Value) error {
+	fields, err := cachedRules(rv.Type())
+	if err != nil {
+		return err
+	}
+
+	for _, f := range fields {
+		key := prefix + f.name
+		fv, ok := fieldByIndex(rv, f.index)
+		if !ok {
+			val.CheckMessage(!f.required, key, MsgRequired, "")
+			continue
+		}
+
+		// Empty values only fail required, but zero structs and arrays are
+		// still descended into so their own required fields are reported
+		if isEmpty(fv) {
+			val.CheckMessage(!f.required, key, MsgRequired, "")
+		} else {
+			for fv.Kind() == reflect.Pointer {
+				fv = fv.Elem()
+			}
+			for _, r := range f.rules {
+				if msgKey, ok := r.check(fv, r.param); !ok {
+					val.CheckMessage(false, key, msgKey, r.display)
+					break
+				}
+			}
+		}
+		for fv.Kind() == reflect.Pointer {
+			if fv.IsNil() {
+				break
+			}
+			fv = fv.Elem()
+		}
+		if fv.Kind() == reflect.Pointer {
+			continue
+		}
+
+		switch f.nested {
+		case nestedStruct:
+			if err := validateStruct(val, key+".", fv); err != nil {
+				return err
+			}
+		case nestedSlice:
+			for i := 0; i < fv.Len(); i++ {
+				ev := fv.Index(i)
+				for ev.Kind() == reflect.Pointer {
+					if ev.IsNil() {
+						break
+					}
+					ev = ev.Elem()
+				}
+				if ev.Kind() != reflect.Struct {
+					continue
+				}
+				if err := validateStruct(val, fmt.Sprintf("%s[%d].", key, i), ev); err != nil {
+					return err
+				}
+			}
+		}
+	}
+
+	return nil
+}
+
+// nestedKindOf reports whether values of t hold structs to validate
+func nestedKindOf(t reflect.Type) nestedKind {
+	for t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+	switch t.Kind() {
+	case reflect.Struct:
+		return nestedStruct
+	case reflect.Slice, reflect.Array:
+		et := t.Elem()
+		for et.Kind() == reflect.Pointer {
+			et = et.Elem()
+		}
+		if et.Kind() == reflect.Struct {
+			return nestedSlice
+		}
+	}
+	return nestedNone
+}
+
+// cachedRules returns the parsed rules for t
+func cachedRules(t reflect.Type) ([]fieldRules, error) {
+	if cached, ok := typeCache.Load(t); ok {
+		return cached.([]fieldRules), nil
+	}
+
+	fields, err := parseRules(t, nil)
+	if err != nil {
+		return nil, err
+	}
+	typeCache.Store(t, fields)
+	return fields, nil
+}
+
+// parseRules parses the validate tags of t, descending into embedded structs
+// whose fields encoding/json would promote
+func parseRules(t reflect.Type, index []int) ([]fieldRules, error) {
+	var fields []fieldRules
+
+	for i := 0; i < t.NumField(); i++ {
+		sf := t.Field(i)
+		fieldIndex := append(append([]int(nil), index...), i)
+
+		ft := sf.Type
+		if ft.Kind() == reflect.Pointer {
+			ft = ft.Elem()
+		}
+		if sf.Anonymous && ft.Kind() == reflect.Struct && sf.Tag.Get("json") == "" {
+			embedded, err := parseRules(ft, fieldIndex)
+			if err != nil {
+				return nil, err
+			}
+			fields = append(fields, embedded...)
+			continue
+		}
+
+		tag := sf.Tag.Get("validate")
+		nested := nestedKindOf(sf.Type)
+		if !sf.IsExported() || tag == "-" || (tag == "" && nested == nestedNone) {
+			continue
+		}
+
+		f := fieldRules{index: fieldIndex, name: jsonName(sf), nested: nested}
+		for _, part := range strings.Split(tag, ",") {
+			if part == "" {
+				continue
+			}
+			name, param, _ := strings.Cut(strings.TrimSpace(part), "=")
+			if name == "required" {
+				f.required = true
+				continue
+			}
+
+			check, known := rules[name]
+			if !known {
+				return nil, fmt.Errorf("%w %q on field %s", ErrInvalidRule, name, sf.Name)
+			}
+			if rulesWithParam[name] && param == "" {
+				return nil, fmt.Errorf("%w: %s on field %s needs a parameter", ErrInvalidRule, name, sf.Name)
+			}
+			if name == "min" || name == "max" || name == "len" {
+				if _, err := strconv.ParseFloat(param, 64); err != nil {
+					return nil, fmt.Errorf("%w: %s=%s on field %s is not a number", ErrInvalidRule, name, param, sf.Name)
+				}
+			}
+			display := param
+			if name == "oneof" {
+				display = strings.Join(strings.Fields(param), ", ")
+			}
+			f.rules = append(f.rules, rule{name: name, param: param, display: display, check: check})
+		}
+		fields = append(fields, f)
+	}
+
+	return fields, nil
+}
+
+// jsonName returns the name encoding/json uses for a field
+func jsonName(sf reflect.StructField) string {
+	name, _, _ := strings.Cut(sf.Tag.Get("json"), ",")
+	if name == "" || name == "-" {
+		return sf.Name
+	}
+	return name
+}
+
+// fieldByIndex is like reflect.Value.FieldByIndex but reports false instead
+// of panicking on a nil embedded pointer
+func fieldByIndex(v reflect.Value, index []int) (reflect.Value, bool) {
+	for i, x := range index {
+		if i > 0 && v.Kind() == reflect.Pointer {
+			if v.IsNil() {
+				return reflect.Value{}, false
+			}
+			v = v.Elem()
+		}
+		v = v.Field(x)
+	}
+	return v, true
+}
+
+// isEmpty reports whether v should be treated as not provided
+func isEmpty(v reflect.Value) bool {
+	switch v.Kind() {
+	case reflect.String:
+		return strings.TrimSpace(v.String()) == ""
+	case reflect.Slice, reflect.Map:
+		return v.Len() == 0
+	case reflect.Pointer, reflect.Interface:
+		return v.IsNil()
+	default:
+		return v.IsZero()
+	}
+}
+
+// size returns the length of strings and collections, or the numeric value
+func size(v reflect.Value) (float64, string, bool) {
+	switch v.Kind() {
+	case reflect.String:
+		return float64(len([]rune(v.String()))), "characters", true
+	case reflect.Slice, reflect.Map, reflect.Array:
+		return float64(v.Len()), "items", true
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return float64(v.Int()), "", true
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return float64(v.Uint()), "", true
+	case reflect.Float32, reflect.Float64:
+		return v.Float(), "", true
+	}
+	return 0, "", false
+}
+
+func checkEmail(v reflect.Value, _ string) (string, bool) {
+	return MsgEmail, v.Kind() == reflect.String && IsEmail(v.String())
+}
+
+func checkMin(v reflect.Value, param string) (string, bool) {
+	n, unit, ok := size(v)
+	limit, _ := strconv.ParseFloat(param, 64)
+	return sizeMessage(unit, MsgMin, MsgMinLength, MsgMinItems), ok && n >= limit
+}
+
+func checkMax(v reflect.Value, param string) (string, bool) {
+	n, unit, ok := size(v)
+	limit, _ := strconv.ParseFloat(param, 64)
+	return sizeMessage(unit, MsgMax, MsgMaxLength, MsgMaxItems), ok && n <= limit
+}
+
+func checkLen(v reflect.Value, param string) (string, bool) {
+	n, unit, ok := size(v)
+	limit, _ := strconv.ParseFloat(param, 64)
+	return sizeMessage(unit, MsgLen, MsgExactLength, MsgExactItems), ok && n == limit
+}
+
+// sizeMessage picks the message key for the unit returned by size
+func sizeMessage(unit, value, chars, items string) string {
+	switch unit {
+	case "characters":
+		return chars
+	case "items":
+		return items
+	}
+	return value
+}
+
+func checkOneOf(v reflect.Value, param string) (string, bool) {
+	value := fmt.Sprint(v.Interface())
+	return MsgOneOf, PermittedValue(value, strings.Fields(param)...)
+}
+
+func checkUUID(v reflect.Value, _ string) (string, bool) {
+	return MsgUUID, v.Kind() == reflect.String && IsUUID(v.String())
+}
+
+func checkURL(v reflect.Value, param string) (string, bool) {
+	return MsgURL, v.Kind() == reflect.String && IsURL(v.String(), strings.Fields(param)...)
+}
+
+func checkE164(v reflect.Value, _ string) (string, bool) {
+	return MsgE164, v.Kind() == reflect.String && IsE164Phone(v.String())
+}
+
+func checkDate(v reflect.Value, param string) (string, bool) {
+	return MsgDate, v.Kind() == reflect.String && IsDate(v.String(), param)
+}
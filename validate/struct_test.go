@@ -0,0 +1,271 @@
+package validate
+
+import (
+	"errors"
+	"testing"
+)
+
+type Audit struct {
+	CreatedBy string `json:"created_by" validate:"required"`
+}
+
+type createOrderRequest struct {
+	Audit
+	Email    string   `json:"email" validate:"required,email,max=50"`
+	Name     string   `json:"name" validate:"required,min=2,max=10"`
+	Quantity int      `json:"quantity" validate:"required,min=1,max=100"`
+	Color    string   `json:"color,omitempty" validate:"oneof=red green blue"`
+	Tags     []string `json:"tags" validate:"max=2"`
+	Note     *string  `json:"note" validate:"max=5"`
+	Code     string   `validate:"len=4"`
+	Ignored  string   `json:"-"`
+}
+
+func TestStruct(t *testing.T) {
+	long := "too long"
+	valid := createOrderRequest{
+		Audit:    Audit{CreatedBy: "u_1"},
+		Email:    "jane@example.com",
+		Name:     "Jane",
+		Quantity: 3,
+	}
+
+	tests := []struct {
+		name   string
+		mutate func(r *createOrderRequest)
+		want   ValidationError
+	}{
+		{"valid", func(r *createOrderRequest) {}, nil},
+		{"missing fields", func(r *createOrderRequest) {
+			r.Email, r.Name, r.Quantity, r.CreatedBy = "", " ", 0, ""
+		}, ValidationError{
+			"email":      "must be provided",
+			"name":       "must be provided",
+			"quantity":   "must be provided",
+			"created_by": "must be provided",
+		}},
+		{"invalid email", func(r *createOrderRequest) { r.Email = "jane" }, ValidationError{"email": "must be a valid email address"}},
+		{"string length", func(r *createOrderRequest) { r.Name = "J" }, ValidationError{"name": "must contain at least 2 characters"}},
+		{"numeric range", func(r *createOrderRequest) { r.Quantity = 101 }, ValidationError{"quantity": "must not be greater than 100"}},
+		{"oneof", func(r *createOrderRequest) { r.Color = "pink" }, ValidationError{"color": "must be one of: red, green, blue"}},
+		{"slice length", func(r *createOrderRequest) { r.Tags = []string{"a", "b", "c"} }, ValidationError{"tags": "must not contain more than 2 items"}},
+		{"pointer value", func(r *createOrderRequest) { r.Note = &long }, ValidationError{"note": "must not contain more than 5 characters"}},
+		{"field without json tag", func(r *createOrderRequest) { r.Code = "ABC" }, ValidationError{"Code": "must contain exactly 4 characters"}},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			req := valid
+			tc.mutate(&req)
+
+			err := Struct(&req)
+			if tc.want == nil {
+				if err != nil {
+					t.Fatalf("Expected no error, got %v", err)
+				}
+				return
+			}
+
+			var got ValidationError
+			if !errors.As(err, &got) {
+				t.Fatalf("Expected ValidationError, got %v", err)
+			}
+			if len(got) != len(tc.want) {
+				t.Errorf("Expected %v, got %v", tc.want, got)
+			}
+			for field, msg := range tc.want {
+				if got[field] != msg {
+					t.Errorf("Expected %s to be %q, got %q", field, msg, got[field])
+				}
+			}
+		})
+	}
+}
+
+func TestStruct_Errors(t *testing.T) {
+	var nilReq *createOrderRequest
+	if err := Struct(nilReq); !errors.Is(err, ErrNotStruct) {
+		t.Errorf("Expected ErrNotStruct for nil pointer, got %v", err)
+	}
+	if err := Struct("not a struct"); !errors.Is(err, ErrNotStruct) {
+		t.Errorf("Expected ErrNotStruct, got %v", err)
+	}
+
+	type unknownRule struct {
+		Name string `validate:"required,shiny"`
+	}
+	if err := Struct(unknownRule{Name: "x"}); !errors.Is(err, ErrInvalidRule) {
+		t.Errorf("Expected ErrInvalidRule for unknown rule, got %v", err)
+	}
+
+	type badParam struct {
+		Name string `validate:"max=ten"`
+	}
+	if err := Struct(badParam{}); !errors.Is(err, ErrInvalidRule) {
+		t.Errorf("Expected ErrInvalidRule for bad parameter, got %v", err)
+	}
+}
+
+func TestStruct_NilEmbeddedPointer(t *testing.T) {
+	type withPointer struct {
+		*Audit
+	}
+	err := Struct(withPointer{})
+	var got ValidationError
+	if !errors.As(err, &got) || got["created_by"] != "must be provided" {
+		t.Errorf("Expected created_by to be required, got %v", err)
+	}
+}
+
+func TestStruct_Nested(t *testing.T) {
+	type address struct {
+		Street string `json:"street" validate:"required"`
+		Zip    string `json:"zip" validate:"len=5"`
+	}
+	type item struct {
+		SKU      string `json:"sku" validate:"required"`
+		Quantity int    `json:"quantity" validate:"min=1"`
+	}
+	type order struct {
+		Address  address  `json:"address"`
+		Billing  *address `json:"billing"`
+		Items    []item   `json:"items" validate:"required,max=3"`
+		Extras   []*item  `json:"extras"`
+		Internal address  `json:"internal" validate:"-"`
+	}
+
+	tests := []struct {
+		name  string
+		input order
+		want  ValidationError
+	}{
+		{
+			"valid",
+			order{
+				Address: address{Street: "1 Main St"},
+				Items:   []item{{SKU: "A", Quantity: 1}},
+			},
+			nil,
+		},
+		{
+			"nested struct and slice",
+			order{
+				Address: address{Zip: "123"},
+				Billing: &address{Street: "2 Side St", Zip: "9"},
+				Items:   []item{{SKU: "A", Quantity: 1}, {SKU: "B", Quantity: 1}, {Quantity: -1}},
+				Extras:  []*item{nil, {SKU: "C", Quantity: -2}},
+			},
+			ValidationError{
+				"address.street":     "must be provided",
+				"address.zip":        "must contain exactly 5 characters",
+				"billing.zip":        "must contain exactly 5 characters",
+				"items[2].sku":       "must be provided",
+				"items[2].quantity":  "must be at least 1",
+				"extras[1].quantity": "must be at least 1",
+			},
+		},
+		{
+			"zero nested struct",
+			order{Items: []item{{SKU: "A", Quantity: 1}}},
+			ValidationError{"address.street": "must be provided"},
+		},
+		{
+			"slice rules still apply",
+			order{
+				Address: address{Street: "1 Main St"},
+				Items:   []item{{SKU: "A"}, {SKU: "B"}, {SKU: "C"}, {SKU: "D"}},
+			},
+			ValidationError{"items": "must not contain more than 3 items"},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			err := Struct(tc.input)
+			if tc.want == nil {
+				if err != nil {
+					t.Fatalf("Expected no error, got %v", err)
+				}
+				return
+			}
+
+			var got ValidationError
+			if !errors.As(err, &got) {
+				t.Fatalf("Expected ValidationError, got %v", err)
+			}
+			if len(got) != len(tc.want) {
+				t.Errorf("Expected %d errors, got %d: %v", len(tc.want), len(got), got)
+			}
+			for field, msg := range tc.want {
+				if got[field] != msg {
+					t.Errorf("Expected %s to be %q, got %q", field, msg, got[field])
+				}
+			}
+		})
+	}
+}
+
+func TestStruct_ZeroNestedStruct(t *testing.T) {
+	type address struct {
+		Street string `json:"street" validate:"required"`
+	}
+	type request struct {
+		Address address `json:"address"`
+	}
+
+	err := Struct(request{})
+	var got ValidationError
+	if !errors.As(err, &got) || got["address.street"] != "must be provided" {
+		t.Errorf("Expected address.street to be required, got %v", err)
+	}
+}
+
+func TestStruct_FormatRules(t *testing.T) {
+	type contact struct {
+		ID       string `json:"id" validate:"uuid"`
+		Website  string `json:"website" validate:"url"`
+		Callback string `json:"callback" validate:"url=https"`
+		Phone    string `json:"phone" validate:"e164"`
+		Birthday string `json:"birthday" validate:"date=2006-01-02"`
+		SeenAt   string `json:"seen_at" validate:"date"`
+	}
+
+	valid := contact{
+		ID:       "3f2504e0-4f89-11d3-9a0c-0305e82c3301",
+		Website:  "http://example.com",
+		Callback: "https://example.com/hook",
+		Phone:    "+14155552671",
+		Birthday: "1990-05-17",
+		SeenAt:   "2024-03-04 15:04:05",
+	}
+	if err := Struct(valid); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	invalid := contact{
+		ID:       "3f2504e0",
+		Website:  "example.com",
+		Callback: "http://example.com/hook",
+		Phone:    "415-555-2671",
+		Birthday: "17/05/1990",
+		SeenAt:   "last week",
+	}
+	want := ValidationError{
+		"id":       "must be a valid UUID",
+		"website":  "must be a valid URL",
+		"callback": "must be a valid URL",
+		"phone":    "must be a valid phone number in E.164 format",
+		"birthday": "must be a valid date",
+		"seen_at":  "must be a valid date",
+	}
+
+	var got ValidationError
+	if !errors.As(Struct(invalid), &got) {
+		t.Fatalf("Expected ValidationError, got %v", got)
+	}
+	for field, msg := range want {
+		if got[field] != msg {
+			t.Errorf("Expected %s to be %q, got %q", field, msg, got[field])
+		}
+	}
+}
@@ -0,0 +1,217 @@
+package validate
+
+import (
+	"fmt"
+	"net/mail"
+	"net/url"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/StairSupplies/go-core/timeutils"
+)
+
+// EmailRX is a pragmatic email address pattern
+var EmailRX = regexp.MustCompile(`^[a-zA-Z0-9.!#$%&'*+/=?^_{|}~-]+@[a-zA-Z0-9](?:[a-zA-Z0-9-]{0,61}[a-zA-Z0-9])?(?:\.[a-zA-Z0-9](?:[a-zA-Z0-9-]{0,61}[a-zA-Z0-9])?)+$`)
+
+// UUIDRX matches a UUID in its canonical 8-4-4-4-12 hexadecimal form
+var UUIDRX = regexp.MustCompile(`^[0-9a-fA-F]{8}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{12}$`)
+
+// E164RX matches a phone number in E.164 format, such as +14155552671
+var E164RX = regexp.MustCompile(`^\+[1-9][0-9]{1,14}$`)
+
+// DefaultURLSchemes are the schemes IsURL accepts when none are given
+var DefaultURLSchemes = []string{"http", "https"}
+
+// ValidationError maps field names to the first problem found with each field
+type ValidationError map[string]string
+
+// Error implements the error interface, listing fields in a stable order
+func (e ValidationError) Error() string {
+	fields := make([]string, 0, len(e))
+	for field := range e {
+		fields = append(fields, field)
+	}
+	sort.Strings(fields)
+
+	parts := make([]string, len(fields))
+	for i, field := range fields {
+		parts[i] = fmt.Sprintf("%s %s", field, e[field])
+	}
+	return "validation failed: " + strings.Join(parts, "; ")
+}
+
+// Validator collects field errors
+type Validator struct {
+	Errors ValidationError
+	// Locale selects the message templates used by Struct and CheckMessage;
+	// empty means the defaults
+	Locale string
+}
+
+// New creates an empty Validator
+func New() *Validator {
+	return &Validator{Errors: make(ValidationError)}
+}
+
+// NewWithLocale creates an empty Validator that renders messages with the
+// templates registered for locale with SetLocaleMessages
+func NewWithLocale(locale string) *Validator {
+	return &Validator{Errors: make(ValidationError), Locale: locale}
+}
+
+// Valid reports whether no errors have been recorded
+func (v *Validator) Valid() bool {
+	return len(v.Errors) == 0
+}
+
+// AddError records message for field unless the field already has an error
+func (v *Validator) AddError(field, message string) {
+	if v.Errors == nil {
+		v.Errors = make(ValidationError)
+	}
+	if _, exists := v.Errors[field]; !exists {
+		v.Errors[field] = message
+	}
+}
+
+// Check records message for field if ok is false
+func (v *Validator) Check(ok bool, field, message string) {
+	if !ok {
+		v.AddError(field, message)
+	}
+}
+
+// CheckMessage records the message template for key, rendered in the
+// Validator's locale, if ok is false. param replaces {param} in the template.
+//
+//	v.CheckMessage(validate.MinChars(pw, 12), "password", validate.MsgMinLength, "12")
+func (v *Validator) CheckMessage(ok bool, field, key, param string) {
+	if !ok {
+		v.AddError(field, Message(v.Locale, key, field, param))
+	}
+}
+
+// Nested runs fn with a fresh Validator and records its errors under prefix,
+// so an error for "street" is reported as "address.street". Use an indexed
+// prefix such as fmt.Sprintf("items[%d]", i) for slice elements.
+func (v *Validator) Nested(prefix string, fn func(*Validator)) {
+	child := NewWithLocale(v.Locale)
+	fn(child)
+	for field, message := range child.Errors {
+		v.AddError(prefix+"."+field, message)
+	}
+}
+
+// Err returns the recorded errors as a ValidationError, or nil if valid
+func (v *Validator) Err() error {
+	if v.Valid() {
+		return nil
+	}
+	return v.Errors
+}
+
+// AsValidationError returns the recorded errors as a ValidationError, or nil
+// if valid, so a handler can return it directly: api.WriteError and
+// api.FromValidation turn it into a 422 response with the field errors as
+// details.
+//
+//	if !v.Valid() {
+//		return v.AsValidationError()
+//	}
+func (v *Validator) AsValidationError() error {
+	return v.Err()
+}
+
+// NotBlank reports whether value contains non-whitespace characters
+func NotBlank(value string) bool {
+	return strings.TrimSpace(value) != ""
+}
+
+// MinChars reports whether value has at least n characters
+func MinChars(value string, n int) bool {
+	return utf8.RuneCountInString(value) >= n
+}
+
+// MaxChars reports whether value has at most n characters
+func MaxChars(value string, n int) bool {
+	return utf8.RuneCountInString(value) <= n
+}
+
+// Matches reports whether value matches rx
+func Matches(value string, rx *regexp.Regexp) bool {
+	return rx.MatchString(value)
+}
+
+// IsEmail reports whether value is a plain email address
+func IsEmail(value string) bool {
+	if len(value) > 254 || !Matches(value, EmailRX) {
+		return false
+	}
+	addr, err := mail.ParseAddress(value)
+	return err == nil && addr.Address == value
+}
+
+// IsUUID reports whether value is a UUID in canonical form
+func IsUUID(value string) bool {
+	return Matches(value, UUIDRX)
+}
+
+// IsURL reports whether value is an absolute URL with a host and one of
+// schemes, or one of DefaultURLSchemes if none are given. Schemes are
+// compared case-insensitively.
+func IsURL(value string, schemes ...string) bool {
+	if len(schemes) == 0 {
+		schemes = DefaultURLSchemes
+	}
+	u, err := url.Parse(value)
+	if err != nil || u.Host == "" {
+		return false
+	}
+	for _, scheme := range schemes {
+		if strings.EqualFold(u.Scheme, scheme) {
+			return true
+		}
+	}
+	return false
+}
+
+// IsE164Phone reports whether value is a phone number in E.164 format
+func IsE164Phone(value string) bool {
+	return Matches(value, E164RX)
+}
+
+// IsDate reports whether value is a date or time in layout. An empty layout
+// accepts any format understood by timeutils.ParseAny.
+func IsDate(value, layout string) bool {
+	if layout == "" {
+		_, err := timeutils.ParseAny(value)
+		return err == nil
+	}
+	_, err := time.Parse(layout, value)
+	return err == nil
+}
+
+// PermittedValue reports whether value is one of permitted
+func PermittedValue[T comparable](value T, permitted ...T) bool {
+	for _, p := range permitted {
+		if value == p {
+			return true
+		}
+	}
+	return false
+}
+
+// Unique reports whether values contains no duplicates
+func Unique[T comparable](values []T) bool {
+	seen := make(map[T]struct{}, len(values))
+	for _, v := range values {
+		if _, ok := seen[v]; ok {
+			return false
+		}
+		seen[v] = struct{}{}
+	}
+	return true
+}
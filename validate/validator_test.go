@@ -0,0 +1,130 @@
+package validate
+
+import (
+	"regexp"
+	"testing"
+)
+
+func TestValidator(t *testing.T) {
+	v := New()
+	if !v.Valid() || v.Err() != nil {
+		t.Fatal("Expected a new validator to be valid")
+	}
+
+	v.Check(NotBlank(""), "name", "must be provided")
+	v.Check(false, "name", "second message is ignored")
+	v.Check(true, "email", "never recorded")
+
+	if v.Valid() {
+		t.Fatal("Expected validator to be invalid")
+	}
+	if v.Errors["name"] != "must be provided" {
+		t.Errorf("Expected first message to win, got %q", v.Errors["name"])
+	}
+	if _, ok := v.Errors["email"]; ok {
+		t.Error("Expected passing check not to record an error")
+	}
+
+	err, ok := v.Err().(ValidationError)
+	if !ok || len(err) != 1 {
+		t.Errorf("Expected ValidationError with one field, got %v", v.Err())
+	}
+}
+
+func TestValidator_AsValidationError(t *testing.T) {
+	v := New()
+	if err := v.AsValidationError(); err != nil {
+		t.Errorf("Expected nil error for a valid validator, got %v", err)
+	}
+
+	v.Check(false, "sku", "must be provided")
+	verr, ok := v.AsValidationError().(ValidationError)
+	if !ok || verr["sku"] != "must be provided" {
+		t.Errorf("Expected ValidationError for sku, got %v", v.AsValidationError())
+	}
+}
+
+func TestValidationError_Error(t *testing.T) {
+	err := ValidationError{"name": "must be provided", "email": "must be a valid email address"}
+	want := "validation failed: email must be a valid email address; name must be provided"
+	if err.Error() != want {
+		t.Errorf("Expected %q, got %q", want, err.Error())
+	}
+}
+
+func TestHelpers(t *testing.T) {
+	tests := []struct {
+		name string
+		got  bool
+		want bool
+	}{
+		{"NotBlank whitespace", NotBlank("  \t"), false},
+		{"NotBlank text", NotBlank(" a "), true},
+		{"MinChars counts runes", MinChars("héllo", 5), true},
+		{"MaxChars counts runes", MaxChars("héllo", 4), false},
+		{"Matches", Matches("ABC-123", regexp.MustCompile(`^[A-Z]+-\d+$`)), true},
+		{"IsEmail valid", IsEmail("jane.doe+orders@example.com"), true},
+		{"IsEmail display name", IsEmail("Jane <jane@example.com>"), false},
+		{"IsEmail missing domain", IsEmail("jane@"), false},
+		{"IsEmail no tld", IsEmail("jane@localhost"), false},
+		{"PermittedValue", PermittedValue("red", "red", "green"), true},
+		{"PermittedValue missing", PermittedValue(3, 1, 2), false},
+		{"Unique", Unique([]string{"a", "b"}), true},
+		{"Unique duplicates", Unique([]int{1, 2, 1}), false},
+		{"IsUUID valid", IsUUID("3F2504E0-4F89-11D3-9A0C-0305E82C3301"), true},
+		{"IsUUID no dashes", IsUUID("3f2504e04f8911d39a0c0305e82c3301"), false},
+		{"IsUUID braces", IsUUID("{3f2504e0-4f89-11d3-9a0c-0305e82c3301}"), false},
+		{"IsURL https", IsURL("https://example.com/orders?id=1"), true},
+		{"IsURL scheme case", IsURL("HTTP://example.com"), true},
+		{"IsURL relative", IsURL("/orders/1"), false},
+		{"IsURL no host", IsURL("https:///orders"), false},
+		{"IsURL scheme not allowed", IsURL("ftp://example.com/file"), false},
+		{"IsURL scheme allowlist", IsURL("ftp://example.com/file", "ftp", "sftp"), true},
+		{"IsURL javascript", IsURL("javascript:alert(1)"), false},
+		{"IsE164Phone valid", IsE164Phone("+14155552671"), true},
+		{"IsE164Phone no plus", IsE164Phone("14155552671"), false},
+		{"IsE164Phone formatted", IsE164Phone("+1 415-555-2671"), false},
+		{"IsE164Phone leading zero", IsE164Phone("+04155552671"), false},
+		{"IsE164Phone too long", IsE164Phone("+1234567890123456"), false},
+		{"IsDate layout", IsDate("2024-03-04", "2006-01-02"), true},
+		{"IsDate wrong layout", IsDate("03/04/2024", "2006-01-02"), false},
+		{"IsDate invalid day", IsDate("2024-02-30", "2006-01-02"), false},
+		{"IsDate any format", IsDate("2024-03-04T15:04:05Z", ""), true},
+		{"IsDate any format invalid", IsDate("tomorrow", ""), false},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			if tc.got != tc.want {
+				t.Errorf("Expected %v, got %v", tc.want, tc.got)
+			}
+		})
+	}
+}
+
+func TestValidator_Nested(t *testing.T) {
+	v := New()
+	v.Check(false, "address.street", "must be provided")
+	v.Nested("address", func(v *Validator) {
+		v.Check(false, "street", "ignored, first message wins")
+		v.Check(false, "zip", "must be provided")
+	})
+	v.Nested("items[2]", func(v *Validator) {
+		v.Check(false, "quantity", "must be at least 1")
+	})
+	v.Nested("empty", func(v *Validator) {})
+
+	want := ValidationError{
+		"address.street":    "must be provided",
+		"address.zip":       "must be provided",
+		"items[2].quantity": "must be at least 1",
+	}
+	if len(v.Errors) != len(want) {
+		t.Fatalf("Expected %d errors, got %v", len(want), v.Errors)
+	}
+	for field, msg := range want {
+		if v.Errors[field] != msg {
+			t.Errorf("Expected %s to be %q, got %q", field, msg, v.Errors[field])
+		}
+	}
+}
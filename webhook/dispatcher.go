@@ -0,0 +1,227 @@
+package webhook
+
+import (
+	"bytes"
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	mathrand "math/rand"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/StairSupplies/go-core/logger"
+	"go.uber.org/zap"
+)
+
+// ErrDeliveryFailed indicates that a webhook could not be delivered after all attempts
+var ErrDeliveryFailed = errors.New("webhook delivery failed")
+
+// DispatcherConfig configures a Dispatcher
+type DispatcherConfig struct {
+	// Secret is used to sign every delivery
+	Secret string
+	// MaxAttempts is the total number of delivery attempts. Defaults to 5.
+	MaxAttempts int
+	// BaseDelay is the delay before the first retry. Defaults to 1 second.
+	BaseDelay time.Duration
+	// MaxDelay caps the exponential backoff and any Retry-After wait. Defaults
+	// to 1 minute.
+	MaxDelay time.Duration
+	// HTTPClient sends the requests. Defaults to a client with a 10 second timeout.
+	HTTPClient *http.Client
+	// UserAgent is sent with every delivery. Defaults to "go-core-webhook".
+	UserAgent string
+	// Logger records delivery attempts. Defaults to a logger with component=webhook.
+	Logger *logger.Logger
+}
+
+// Dispatcher signs and delivers outbound webhooks with retries
+type Dispatcher struct {
+	cfg DispatcherConfig
+	now func() time.Time
+}
+
+// Delivery describes the outcome of a Send call
+type Delivery struct {
+	// ID is the delivery ID sent in the IDHeader
+	ID string
+	// URL is the endpoint the webhook was sent to
+	URL string
+	// Attempts is the number of requests made
+	Attempts int
+	// StatusCode is the status of the last response, or 0 if none was received
+	StatusCode int
+	// Duration is the total time spent delivering, including backoff
+	Duration time.Duration
+}
+
+// NewDispatcher creates a Dispatcher from cfg
+func NewDispatcher(cfg DispatcherConfig) (*Dispatcher, error) {
+	if cfg.Secret == "" {
+		return nil, errors.New("webhook: signing secret is required")
+	}
+
+	if cfg.MaxAttempts <= 0 {
+		cfg.MaxAttempts = 5
+	}
+	if cfg.BaseDelay <= 0 {
+		cfg.BaseDelay = time.Second
+	}
+	if cfg.MaxDelay <= 0 {
+		cfg.MaxDelay = time.Minute
+	}
+	if cfg.HTTPClient == nil {
+		cfg.HTTPClient = &http.Client{Timeout: 10 * time.Second}
+	}
+	if cfg.UserAgent == "" {
+		cfg.UserAgent = "go-core-webhook"
+	}
+	if cfg.Logger == nil {
+		log, err := logger.New(logger.WithInitialFields(map[string]interface{}{
+			"component": "webhook",
+		}))
+		if err != nil {
+			return nil, err
+		}
+		cfg.Logger = log
+	}
+
+	return &Dispatcher{cfg: cfg, now: time.Now}, nil
+}
+
+// Send delivers payload to url. Payloads of type []byte or json.RawMessage are
+// sent as-is; anything else is encoded as JSON. Network errors and 408, 429, and
+// 5xx responses are retried with jittered exponential backoff; other non-2xx
+// responses fail immediately. The returned Delivery is populated even on error.
+func (d *Dispatcher) Send(ctx context.Context, url string, payload any) (*Delivery, error) {
+	var body []byte
+	switch p := payload.(type) {
+	case []byte:
+		body = p
+	case json.RawMessage:
+		body = p
+	default:
+		var err error
+		if body, err = json.Marshal(payload); err != nil {
+			return nil, fmt.Errorf("failed to marshal webhook payload: %w", err)
+		}
+	}
+
+	delivery := &Delivery{ID: newDeliveryID(), URL: url}
+	log := d.cfg.Logger.With(
+		zap.String("webhook_id", delivery.ID),
+		zap.String("url", url),
+	)
+	start := d.now()
+
+	var lastErr error
+deliver:
+	for attempt := 1; attempt <= d.cfg.MaxAttempts; attempt++ {
+		delivery.Attempts = attempt
+
+		status, retryAfter, err := d.attempt(ctx, url, delivery.ID, body)
+		delivery.StatusCode = status
+		delivery.Duration = d.now().Sub(start)
+
+		if err == nil {
+			log.Info("Webhook delivered",
+				zap.Int("attempt", attempt),
+				zap.Int("status", status),
+				zap.Duration("duration", delivery.Duration),
+			)
+			return delivery, nil
+		}
+		lastErr = err
+
+		retryable := status == 0 || status == http.StatusRequestTimeout ||
+			status == http.StatusTooManyRequests || status >= 500
+		if !retryable || attempt == d.cfg.MaxAttempts || ctx.Err() != nil {
+			break
+		}
+
+		// Honor Retry-After, but never wait longer than the backoff allows
+		delay := d.backoff(attempt)
+		if retryAfter > delay {
+			delay = retryAfter
+		}
+		if delay > d.cfg.MaxDelay {
+			delay = d.cfg.MaxDelay
+		}
+		log.Warn("Webhook delivery attempt failed, retrying",
+			zap.Int("attempt", attempt),
+			zap.Int("status", status),
+			zap.Duration("backoff", delay),
+			zap.String("err", err.Error()),
+		)
+
+		select {
+		case <-ctx.Done():
+			lastErr = ctx.Err()
+			break deliver
+		case <-time.After(delay):
+		}
+	}
+
+	delivery.Duration = d.now().Sub(start)
+	log.Error("Webhook delivery failed",
+		zap.Int("attempts", delivery.Attempts),
+		zap.Int("status", delivery.StatusCode),
+		zap.Duration("duration", delivery.Duration),
+		zap.String("err", lastErr.Error()),
+	)
+
+	return delivery, fmt.Errorf("%w: %w", ErrDeliveryFailed, lastErr)
+}
+
+// attempt makes a single signed request, returning the status code and any Retry-After delay
+func (d *Dispatcher) attempt(ctx context.Context, url, id string, body []byte) (int, time.Duration, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewReader(body))
+	if err != nil {
+		return 0, 0, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("User-Agent", d.cfg.UserAgent)
+	req.Header.Set(IDHeader, id)
+	// Sign each attempt with a fresh timestamp so retries stay within the receiver's tolerance
+	req.Header.Set(SignatureHeader, SignDelivery([]byte(d.cfg.Secret), d.now(), id, body))
+
+	resp, err := d.cfg.HTTPClient.Do(req)
+	if err != nil {
+		return 0, 0, err
+	}
+	defer resp.Body.Close()
+	io.Copy(io.Discard, io.LimitReader(resp.Body, 64<<10))
+
+	if resp.StatusCode >= 200 && resp.StatusCode < 300 {
+		return resp.StatusCode, 0, nil
+	}
+
+	var retryAfter time.Duration
+	if secs, err := strconv.Atoi(resp.Header.Get("Retry-After")); err == nil && secs > 0 {
+		retryAfter = time.Duration(secs) * time.Second
+	}
+
+	return resp.StatusCode, retryAfter, fmt.Errorf("unexpected status %d", resp.StatusCode)
+}
+
+// backoff returns the jittered delay before the given retry
+func (d *Dispatcher) backoff(attempt int) time.Duration {
+	delay := d.cfg.BaseDelay << (attempt - 1)
+	if delay > d.cfg.MaxDelay || delay <= 0 {
+		delay = d.cfg.MaxDelay
+	}
+	half := delay / 2
+	return half + time.Duration(mathrand.Int63n(int64(half)+1))
+}
+
+// newDeliveryID returns a random delivery identifier
+func newDeliveryID() string {
+	b := make([]byte, 16)
+	rand.Read(b)
+	return hex.EncodeToString(b)
+}
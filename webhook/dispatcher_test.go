@@ -0,0 +1,153 @@
+package webhook
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/StairSupplies/go-core/logger"
+)
+
+func newTestDispatcher(t *testing.T) *Dispatcher {
+	t.Helper()
+	d, err := NewDispatcher(DispatcherConfig{
+		Secret:      "secret",
+		MaxAttempts: 3,
+		BaseDelay:   time.Millisecond,
+		MaxDelay:    5 * time.Millisecond,
+		Logger:      logger.NewNopLogger(),
+	})
+	if err != nil {
+		t.Fatalf("NewDispatcher() error = %v", err)
+	}
+	return d
+}
+
+func TestNewDispatcher(t *testing.T) {
+	if _, err := NewDispatcher(DispatcherConfig{}); err == nil {
+		t.Error("Expected error for missing secret")
+	}
+}
+
+func TestDispatcher_Send(t *testing.T) {
+	verifier := &Verifier{Secrets: [][]byte{[]byte("secret")}}
+
+	var mu sync.Mutex
+	var ids []string
+	calls := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		defer mu.Unlock()
+		calls++
+
+		body, _ := io.ReadAll(r.Body)
+		if _, err := verifier.VerifyDelivery(r.Header.Get(SignatureHeader), r.Header.Get(IDHeader), body); err != nil {
+			t.Errorf("Expected valid signature, got %v", err)
+		}
+		if string(body) != `{"event":"order.created"}` {
+			t.Errorf("Unexpected body %s", body)
+		}
+		ids = append(ids, r.Header.Get(IDHeader))
+
+		if calls < 2 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	delivery, err := newTestDispatcher(t).Send(context.Background(), server.URL, map[string]string{"event": "order.created"})
+	if err != nil {
+		t.Fatalf("Send() error = %v", err)
+	}
+
+	if delivery.Attempts != 2 || delivery.StatusCode != http.StatusOK {
+		t.Errorf("Expected success on attempt 2, got %+v", delivery)
+	}
+	if len(ids) != 2 || ids[0] != ids[1] || ids[0] != delivery.ID {
+		t.Errorf("Expected the same delivery ID on every attempt, got %v", ids)
+	}
+}
+
+func TestDispatcher_SendFailures(t *testing.T) {
+	tests := []struct {
+		name         string
+		status       int
+		wantAttempts int
+	}{
+		{"client error is not retried", http.StatusBadRequest, 1},
+		{"server error is retried", http.StatusInternalServerError, 3},
+		{"rate limit is retried", http.StatusTooManyRequests, 3},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.WriteHeader(tc.status)
+			}))
+			defer server.Close()
+
+			delivery, err := newTestDispatcher(t).Send(context.Background(), server.URL, []byte(`{}`))
+			if !errors.Is(err, ErrDeliveryFailed) {
+				t.Fatalf("Expected ErrDeliveryFailed, got %v", err)
+			}
+			if delivery.Attempts != tc.wantAttempts {
+				t.Errorf("Expected %d attempts, got %d", tc.wantAttempts, delivery.Attempts)
+			}
+			if delivery.StatusCode != tc.status {
+				t.Errorf("Expected last status %d, got %d", tc.status, delivery.StatusCode)
+			}
+		})
+	}
+}
+
+func TestDispatcher_RetryAfterCapped(t *testing.T) {
+	var calls atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if calls.Add(1) == 1 {
+			w.Header().Set("Retry-After", "3600")
+			w.WriteHeader(http.StatusTooManyRequests)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	d := newTestDispatcher(t)
+	start := time.Now()
+	if _, err := d.Send(context.Background(), server.URL, []byte(`{}`)); err != nil {
+		t.Fatalf("Send() error = %v", err)
+	}
+	if elapsed := time.Since(start); elapsed > time.Second {
+		t.Errorf("Expected Retry-After to be capped at MaxDelay, waited %v", elapsed)
+	}
+}
+
+func TestDispatcher_ContextCancelled(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer server.Close()
+
+	d := newTestDispatcher(t)
+	d.cfg.BaseDelay = time.Hour
+	d.cfg.MaxDelay = time.Hour
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+
+	delivery, err := d.Send(ctx, server.URL, []byte(`{}`))
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("Expected context.DeadlineExceeded, got %v", err)
+	}
+	if delivery.Attempts != 1 {
+		t.Errorf("Expected 1 attempt, got %d", delivery.Attempts)
+	}
+}
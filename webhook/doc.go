@@ -0,0 +1,78 @@
+/*
+Package webhook provides HMAC signing and verification for webhooks sent to and
+received from partners.
+
+Every delivery carries an X-Webhook-Signature header of the form
+
+	t=1700000000,v1=5257a869e7ecebeda32affa62cdca3fa51cad7e77a0e56ff536d0ce8e108d8bd
+
+where v1 is the hex-encoded HMAC-SHA256 of "<timestamp>.<id>.<body>", id being
+the X-Webhook-ID header, or of "<timestamp>.<body>" for deliveries without an
+ID. Including the timestamp in the signed content lets receivers reject old
+deliveries, and the signed ID lets them reject deliveries they have already
+processed.
+
+# Features
+
+  - Sign, SignDelivery and Verifier for producing and checking signatures
+  - Multiple secrets for zero-downtime secret rotation
+  - Timestamp tolerance to reject stale or replayed deliveries
+  - Receiver handler built on api.WrapHandler with body size limits
+  - Optional replay cache keyed by the signed delivery ID
+  - Dispatcher with jittered exponential backoff and Retry-After support
+
+# Receiving Webhooks
+
+Mounting a receiver on a router:
+
+	receiver, err := webhook.NewReceiver(webhook.ReceiverConfig{
+		Secrets:     []string{cfg.WebhookSecret, cfg.PreviousWebhookSecret},
+		ReplayCache: webhook.NewMemoryReplayCache(),
+	})
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	r.Post("/webhooks/orders", receiver.Handler(func(ctx context.Context, event webhook.Event) error {
+		var order Order
+		if err := event.Decode(&order); err != nil {
+			return api.BadRequestError(err)
+		}
+		return processOrder(ctx, order)
+	}))
+
+Invalid signatures are answered with 401, replayed deliveries with 409,
+deliveries without an ID with 400 when a ReplayCache is set, and oversized
+payloads with 413, all using the api error envelope. Errors returned by
+the handler are written with api.WriteError.
+
+# Sending Webhooks
+
+Delivering a webhook with retries:
+
+	dispatcher, err := webhook.NewDispatcher(webhook.DispatcherConfig{
+		Secret:      cfg.WebhookSecret,
+		MaxAttempts: 5,
+	})
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	delivery, err := dispatcher.Send(ctx, partner.WebhookURL, event)
+	if errors.Is(err, webhook.ErrDeliveryFailed) {
+		// delivery.Attempts and delivery.StatusCode describe the last attempt
+	}
+
+Network errors and 408, 429, and 5xx responses are retried; other non-2xx
+responses fail immediately. Each attempt is signed with a fresh timestamp and
+carries the same delivery ID so receivers can deduplicate retries. A
+Retry-After header from the receiver is honored up to MaxDelay.
+
+# Secret Rotation
+
+To rotate a secret, add the new secret to the receiver first, then switch the
+dispatcher to it, and finally remove the old secret from the receiver:
+
+	webhook.ReceiverConfig{Secrets: []string{newSecret, oldSecret}}
+*/
+package webhook
@@ -0,0 +1,61 @@
+package webhook_test
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"time"
+
+	"github.com/StairSupplies/go-core/webhook"
+)
+
+func ExampleSign() {
+	payload := []byte(`{"event":"order.created"}`)
+	signedAt := time.Now()
+
+	header := webhook.Sign([]byte("secret"), signedAt, payload)
+
+	verifier := &webhook.Verifier{Secrets: [][]byte{[]byte("secret")}}
+	if _, err := verifier.Verify(header, payload); err != nil {
+		fmt.Printf("Error verifying signature: %v\n", err)
+		return
+	}
+
+	fmt.Println("signature valid")
+	// Output: signature valid
+}
+
+func ExampleReceiver_Handler() {
+	receiver, err := webhook.NewReceiver(webhook.ReceiverConfig{
+		Secrets: []string{"secret"},
+	})
+	if err != nil {
+		fmt.Printf("Error creating receiver: %v\n", err)
+		return
+	}
+
+	handler := receiver.Handler(func(ctx context.Context, event webhook.Event) error {
+		var payload struct {
+			Event string `json:"event"`
+		}
+		if err := event.Decode(&payload); err != nil {
+			return err
+		}
+		fmt.Printf("received %s (%s)\n", payload.Event, event.ID)
+		return nil
+	})
+
+	body := `{"event":"order.created"}`
+	req := httptest.NewRequest(http.MethodPost, "/webhooks", strings.NewReader(body))
+	req.Header.Set(webhook.IDHeader, "evt_123")
+	req.Header.Set(webhook.SignatureHeader, webhook.SignDelivery([]byte("secret"), time.Now(), "evt_123", []byte(body)))
+
+	w := httptest.NewRecorder()
+	handler(w, req)
+	fmt.Println(w.Code)
+	// Output:
+	// received order.created (evt_123)
+	// 204
+}
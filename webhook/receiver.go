@@ -0,0 +1,141 @@
+package webhook
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+
+	"github.com/StairSupplies/go-core/api"
+)
+
+// DefaultMaxBodyBytes is the largest webhook payload accepted by a Receiver
+const DefaultMaxBodyBytes = 1 << 20
+
+// Event is a verified webhook delivery
+type Event struct {
+	// ID is the delivery ID from the IDHeader, if present
+	ID string
+	// Timestamp is when the sender signed the delivery
+	Timestamp time.Time
+	// Payload is the raw request body
+	Payload []byte
+	// Header contains the request headers
+	Header http.Header
+}
+
+// Decode unmarshals the JSON payload into v
+func (e Event) Decode(v any) error {
+	if err := json.Unmarshal(e.Payload, v); err != nil {
+		return fmt.Errorf("failed to decode webhook payload: %w", err)
+	}
+	return nil
+}
+
+// ReceiverConfig configures a Receiver
+type ReceiverConfig struct {
+	// Secrets are the accepted signing secrets. At least one is required.
+	Secrets []string
+	// Tolerance is the maximum age of a signature. Defaults to DefaultTolerance.
+	Tolerance time.Duration
+	// MaxBodyBytes limits the payload size. Defaults to DefaultMaxBodyBytes.
+	MaxBodyBytes int64
+	// ReplayCache rejects deliveries whose ID was already accepted, and
+	// deliveries without an ID. Optional.
+	ReplayCache ReplayCache
+}
+
+// Receiver verifies incoming webhook requests before handing them to a handler
+type Receiver struct {
+	verifier     *Verifier
+	maxBodyBytes int64
+	replayCache  ReplayCache
+}
+
+// NewReceiver creates a Receiver from cfg
+func NewReceiver(cfg ReceiverConfig) (*Receiver, error) {
+	if len(cfg.Secrets) == 0 {
+		return nil, errors.New("webhook: at least one secret is required")
+	}
+
+	secrets := make([][]byte, len(cfg.Secrets))
+	for i, s := range cfg.Secrets {
+		secrets[i] = []byte(s)
+	}
+
+	if cfg.MaxBodyBytes <= 0 {
+		cfg.MaxBodyBytes = DefaultMaxBodyBytes
+	}
+
+	return &Receiver{
+		verifier:     &Verifier{Secrets: secrets, Tolerance: cfg.Tolerance},
+		maxBodyBytes: cfg.MaxBodyBytes,
+		replayCache:  cfg.ReplayCache,
+	}, nil
+}
+
+// Handler returns an http.HandlerFunc that verifies each request and then calls fn.
+// Verification failures are answered with a 401 api error envelope; errors returned
+// by fn are written with api.WriteError, so fn may return api.Error values.
+// Successful deliveries are acknowledged with 204 No Content.
+func (rc *Receiver) Handler(fn func(ctx context.Context, event Event) error) http.HandlerFunc {
+	return api.WrapHandler(func(w http.ResponseWriter, r *http.Request) error {
+		event, err := rc.Verify(w, r)
+		if err != nil {
+			return err
+		}
+
+		if err := fn(r.Context(), event); err != nil {
+			return err
+		}
+
+		w.WriteHeader(http.StatusNoContent)
+		return nil
+	})
+}
+
+// Verify reads and authenticates the request body, returning the verified event.
+// Returned errors are api.Error values suitable for api.WriteError.
+func (rc *Receiver) Verify(w http.ResponseWriter, r *http.Request) (Event, error) {
+	body, err := io.ReadAll(http.MaxBytesReader(w, r.Body, rc.maxBodyBytes))
+	if err != nil {
+		var maxErr *http.MaxBytesError
+		if errors.As(err, &maxErr) {
+			return Event{}, api.NewError(http.StatusRequestEntityTooLarge, errors.New("webhook payload too large"))
+		}
+		return Event{}, api.BadRequestError(fmt.Errorf("failed to read webhook payload: %w", err))
+	}
+
+	id := r.Header.Get(IDHeader)
+	signedAt, err := rc.verifier.VerifyDelivery(r.Header.Get(SignatureHeader), id, body)
+	if err != nil {
+		return Event{}, api.UnauthorizedError(err)
+	}
+
+	event := Event{
+		ID:        id,
+		Timestamp: signedAt,
+		Payload:   body,
+		Header:    r.Header,
+	}
+
+	if rc.replayCache != nil {
+		// The ID is signed, so a replay cannot get past the cache with a new one
+		if event.ID == "" {
+			return Event{}, api.BadRequestError(ErrMissingID)
+		}
+		// Remember IDs for twice the tolerance so a replay can never outlive the cache entry
+		tolerance := rc.verifier.Tolerance
+		if tolerance <= 0 {
+			tolerance = DefaultTolerance
+		}
+		if rc.replayCache.Seen(event.ID, 2*tolerance) {
+			return Event{}, api.NewError(http.StatusConflict, ErrReplayed)
+		}
+	}
+
+	return event, nil
+}
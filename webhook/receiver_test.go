@@ -0,0 +1,119 @@
+package webhook
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/StairSupplies/go-core/api"
+)
+
+func newSignedRequest(secret, id, body string) *http.Request {
+	req := httptest.NewRequest(http.MethodPost, "/webhooks/orders", strings.NewReader(body))
+	req.Header.Set(SignatureHeader, SignDelivery([]byte(secret), time.Now(), id, []byte(body)))
+	if id != "" {
+		req.Header.Set(IDHeader, id)
+	}
+	return req
+}
+
+func TestNewReceiver(t *testing.T) {
+	if _, err := NewReceiver(ReceiverConfig{}); err == nil {
+		t.Error("Expected error for missing secrets")
+	}
+}
+
+func TestReceiver_Handler(t *testing.T) {
+	receiver, err := NewReceiver(ReceiverConfig{
+		Secrets:      []string{"secret"},
+		MaxBodyBytes: 64,
+		ReplayCache:  NewMemoryReplayCache(),
+	})
+	if err != nil {
+		t.Fatalf("NewReceiver() error = %v", err)
+	}
+
+	var received Event
+	handler := receiver.Handler(func(ctx context.Context, event Event) error {
+		received = event
+		var payload struct {
+			Fail bool `json:"fail"`
+		}
+		if err := event.Decode(&payload); err != nil {
+			return api.BadRequestError(err)
+		}
+		if payload.Fail {
+			return api.UnprocessableEntityError(errors.New("cannot process"))
+		}
+		return nil
+	})
+
+	t.Run("valid delivery", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		handler(w, newSignedRequest("secret", "evt_1", `{"order_id":42}`))
+
+		if w.Code != http.StatusNoContent {
+			t.Fatalf("Expected status 204, got %d: %s", w.Code, w.Body.String())
+		}
+		if received.ID != "evt_1" || string(received.Payload) != `{"order_id":42}` {
+			t.Errorf("Unexpected event %+v", received)
+		}
+	})
+
+	t.Run("replayed delivery", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		handler(w, newSignedRequest("secret", "evt_1", `{"order_id":42}`))
+		if w.Code != http.StatusConflict {
+			t.Errorf("Expected status 409, got %d", w.Code)
+		}
+	})
+
+	t.Run("replay with a new ID", func(t *testing.T) {
+		req := newSignedRequest("secret", "evt_1", `{"order_id":42}`)
+		req.Header.Set(IDHeader, "evt_other")
+		w := httptest.NewRecorder()
+		handler(w, req)
+		if w.Code != http.StatusUnauthorized {
+			t.Errorf("Expected status 401, got %d", w.Code)
+		}
+	})
+
+	t.Run("missing ID", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		handler(w, newSignedRequest("secret", "", `{"order_id":42}`))
+		if w.Code != http.StatusBadRequest {
+			t.Errorf("Expected status 400, got %d", w.Code)
+		}
+	})
+
+	t.Run("invalid signature", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		handler(w, newSignedRequest("wrong", "evt_2", `{"order_id":42}`))
+		if w.Code != http.StatusUnauthorized {
+			t.Errorf("Expected status 401, got %d", w.Code)
+		}
+		if !strings.Contains(w.Body.String(), `"error"`) {
+			t.Errorf("Expected api error envelope, got %s", w.Body.String())
+		}
+	})
+
+	t.Run("payload too large", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		handler(w, newSignedRequest("secret", "evt_3", `{"data":"`+strings.Repeat("x", 100)+`"}`))
+		if w.Code != http.StatusRequestEntityTooLarge {
+			t.Errorf("Expected status 413, got %d", w.Code)
+		}
+	})
+
+	t.Run("handler error", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		handler(w, newSignedRequest("secret", "evt_4", `{"fail":true}`))
+		if w.Code != http.StatusUnprocessableEntity {
+			t.Errorf("Expected status 422, got %d", w.Code)
+		}
+	})
+}
@@ -0,0 +1,180 @@
+package webhook
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Header names used for webhook deliveries
+const (
+	// SignatureHeader carries the timestamped HMAC signature, e.g. "t=1700000000,v1=5257a8..."
+	SignatureHeader = "X-Webhook-Signature"
+	// IDHeader carries the unique delivery ID, which stays the same across retries
+	IDHeader = "X-Webhook-ID"
+)
+
+// DefaultTolerance is the maximum accepted age of a signature timestamp
+const DefaultTolerance = 5 * time.Minute
+
+// Common verification errors
+var (
+	// ErrMissingSignature indicates that the request has no signature header.
+	ErrMissingSignature = errors.New("missing webhook signature")
+
+	// ErrInvalidSignature indicates that no signature matched any configured secret.
+	ErrInvalidSignature = errors.New("invalid webhook signature")
+
+	// ErrExpiredSignature indicates that the signature timestamp is outside the tolerance window.
+	ErrExpiredSignature = errors.New("webhook signature timestamp outside tolerance")
+
+	// ErrReplayed indicates that a delivery with the same ID was already accepted.
+	ErrReplayed = errors.New("webhook delivery already received")
+
+	// ErrMissingID indicates that a delivery has no ID although replays are being checked.
+	ErrMissingID = errors.New("missing webhook delivery ID")
+)
+
+// Sign computes the signature header value for payload at timestamp t.
+// The signature is the hex HMAC-SHA256 of "<unix timestamp>.<payload>".
+// Use SignDelivery for requests that carry an IDHeader.
+func Sign(secret []byte, t time.Time, payload []byte) string {
+	return SignDelivery(secret, t, "", payload)
+}
+
+// SignDelivery is like Sign but also signs the delivery ID sent in the
+// IDHeader, as "<unix timestamp>.<id>.<payload>", so the ID of a captured
+// delivery cannot be changed to get it past a ReplayCache. An empty id signs
+// like Sign.
+func SignDelivery(secret []byte, t time.Time, id string, payload []byte) string {
+	ts := strconv.FormatInt(t.Unix(), 10)
+	return "t=" + ts + ",v1=" + computeSignature(secret, ts, id, payload)
+}
+
+// computeSignature returns the hex HMAC of the signed content
+func computeSignature(secret []byte, ts, id string, payload []byte) string {
+	mac := hmac.New(sha256.New, secret)
+	mac.Write([]byte(ts))
+	mac.Write([]byte("."))
+	if id != "" {
+		mac.Write([]byte(id))
+		mac.Write([]byte("."))
+	}
+	mac.Write(payload)
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+// Verifier checks webhook signatures against one or more secrets.
+// Configuring several secrets allows rotating them without downtime.
+type Verifier struct {
+	// Secrets are the accepted signing secrets
+	Secrets [][]byte
+	// Tolerance is the maximum age of a signature. Defaults to DefaultTolerance.
+	Tolerance time.Duration
+	// Now returns the current time. Defaults to time.Now.
+	Now func() time.Time
+}
+
+// Verify checks header against payload, returning the signed timestamp on success
+func (v *Verifier) Verify(header string, payload []byte) (time.Time, error) {
+	return v.VerifyDelivery(header, "", payload)
+}
+
+// VerifyDelivery is like Verify for a signature made with SignDelivery,
+// checking that it covers the delivery ID id as well as payload
+func (v *Verifier) VerifyDelivery(header, id string, payload []byte) (time.Time, error) {
+	if header == "" {
+		return time.Time{}, ErrMissingSignature
+	}
+
+	var ts string
+	var signatures []string
+	for _, part := range strings.Split(header, ",") {
+		key, value, ok := strings.Cut(strings.TrimSpace(part), "=")
+		if !ok {
+			continue
+		}
+		switch key {
+		case "t":
+			ts = value
+		case "v1":
+			signatures = append(signatures, value)
+		}
+	}
+
+	unix, err := strconv.ParseInt(ts, 10, 64)
+	if err != nil || len(signatures) == 0 {
+		return time.Time{}, ErrInvalidSignature
+	}
+	signedAt := time.Unix(unix, 0)
+
+	tolerance := v.Tolerance
+	if tolerance <= 0 {
+		tolerance = DefaultTolerance
+	}
+	now := time.Now
+	if v.Now != nil {
+		now = v.Now
+	}
+	if age := now().Sub(signedAt); age > tolerance || age < -tolerance {
+		return time.Time{}, ErrExpiredSignature
+	}
+
+	for _, secret := range v.Secrets {
+		expected := computeSignature(secret, ts, id, payload)
+		for _, sig := range signatures {
+			if hmac.Equal([]byte(sig), []byte(expected)) {
+				return signedAt, nil
+			}
+		}
+	}
+
+	return time.Time{}, ErrInvalidSignature
+}
+
+// ReplayCache remembers delivery IDs that have already been accepted
+type ReplayCache interface {
+	// Seen records id and reports whether it had already been recorded.
+	// Entries may be forgotten after ttl.
+	Seen(id string, ttl time.Duration) bool
+}
+
+// MemoryReplayCache is an in-process ReplayCache.
+// Use a shared store such as Redis when running multiple replicas.
+type MemoryReplayCache struct {
+	mu      sync.Mutex
+	entries map[string]time.Time
+	now     func() time.Time
+}
+
+// NewMemoryReplayCache creates an empty in-memory replay cache
+func NewMemoryReplayCache() *MemoryReplayCache {
+	return &MemoryReplayCache{
+		entries: make(map[string]time.Time),
+		now:     time.Now,
+	}
+}
+
+// Seen records id and reports whether it was recorded within its ttl
+func (c *MemoryReplayCache) Seen(id string, ttl time.Duration) bool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	now := c.now()
+	for k, expires := range c.entries {
+		if now.After(expires) {
+			delete(c.entries, k)
+		}
+	}
+
+	if _, ok := c.entries[id]; ok {
+		return true
+	}
+	c.entries[id] = now.Add(ttl)
+	return false
+}
@@ -0,0 +1,109 @@
+package webhook
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestSign(t *testing.T) {
+	ts := time.Unix(1700000000, 0)
+	got := Sign([]byte("secret"), ts, []byte(`{"id":1}`))
+
+	if !strings.HasPrefix(got, "t=1700000000,v1=") {
+		t.Errorf("Unexpected signature format %q", got)
+	}
+	if got != Sign([]byte("secret"), ts, []byte(`{"id":1}`)) {
+		t.Error("Expected signing to be deterministic")
+	}
+	if got == Sign([]byte("other"), ts, []byte(`{"id":1}`)) {
+		t.Error("Expected different secrets to produce different signatures")
+	}
+}
+
+func TestVerifier_Verify(t *testing.T) {
+	now := time.Unix(1700000000, 0)
+	payload := []byte(`{"event":"order.created"}`)
+	verifier := &Verifier{
+		Secrets: [][]byte{[]byte("new-secret"), []byte("old-secret")},
+		Now:     func() time.Time { return now },
+	}
+
+	tests := []struct {
+		name    string
+		header  string
+		payload []byte
+		wantErr error
+	}{
+		{"valid", Sign([]byte("new-secret"), now, payload), payload, nil},
+		{"rotated secret", Sign([]byte("old-secret"), now, payload), payload, nil},
+		{"multiple signatures", Sign([]byte("unknown"), now, payload) + ",v1=" +
+			strings.Split(Sign([]byte("new-secret"), now, payload), "v1=")[1], payload, nil},
+		{"missing", "", payload, ErrMissingSignature},
+		{"wrong secret", Sign([]byte("unknown"), now, payload), payload, ErrInvalidSignature},
+		{"tampered payload", Sign([]byte("new-secret"), now, payload), []byte(`{"event":"order.deleted"}`), ErrInvalidSignature},
+		{"malformed", "garbage", payload, ErrInvalidSignature},
+		{"too old", Sign([]byte("new-secret"), now.Add(-10*time.Minute), payload), payload, ErrExpiredSignature},
+		{"from the future", Sign([]byte("new-secret"), now.Add(10*time.Minute), payload), payload, ErrExpiredSignature},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			signedAt, err := verifier.Verify(tc.header, tc.payload)
+			if !errors.Is(err, tc.wantErr) {
+				t.Fatalf("Verify() error = %v, want %v", err, tc.wantErr)
+			}
+			if err == nil && signedAt.IsZero() {
+				t.Error("Expected signed timestamp to be returned")
+			}
+		})
+	}
+}
+
+func TestVerifier_VerifyDelivery(t *testing.T) {
+	now := time.Unix(1700000000, 0)
+	payload := []byte(`{"event":"order.created"}`)
+	verifier := &Verifier{Secrets: [][]byte{[]byte("secret")}, Now: func() time.Time { return now }}
+	header := SignDelivery([]byte("secret"), now, "evt_1", payload)
+
+	tests := []struct {
+		name    string
+		id      string
+		wantErr error
+	}{
+		{"same ID", "evt_1", nil},
+		{"changed ID", "evt_2", ErrInvalidSignature},
+		{"removed ID", "", ErrInvalidSignature},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			if _, err := verifier.VerifyDelivery(header, tc.id, payload); !errors.Is(err, tc.wantErr) {
+				t.Errorf("VerifyDelivery() error = %v, want %v", err, tc.wantErr)
+			}
+		})
+	}
+
+	if Sign([]byte("secret"), now, payload) != SignDelivery([]byte("secret"), now, "", payload) {
+		t.Error("Expected an empty ID to sign like Sign")
+	}
+}
+
+func TestMemoryReplayCache(t *testing.T) {
+	now := time.Unix(1700000000, 0)
+	cache := NewMemoryReplayCache()
+	cache.now = func() time.Time { return now }
+
+	if cache.Seen("evt_1", time.Minute) {
+		t.Error("Expected first delivery to be unseen")
+	}
+	if !cache.Seen("evt_1", time.Minute) {
+		t.Error("Expected duplicate delivery to be seen")
+	}
+
+	now = now.Add(2 * time.Minute)
+	if cache.Seen("evt_1", time.Minute) {
+		t.Error("Expected entry to expire after ttl")
+	}
+}